@@ -13,19 +13,21 @@ import (
 	"fmt"      // 格式化I/O，用于字符串格式化和错误消息生成
 	"net/http" // HTTP客户端和服务器，提供HTTP请求和响应处理
 	"net/url"  // URL解析和查询，用于处理URL参数和表单数据
-
-	"github.com/purpose168/GoAdmin/context"              // 上下文管理，提供请求和响应的抽象
-	"github.com/purpose168/GoAdmin/modules/auth"         // 认证模块，处理用户身份验证和权限管理
-	"github.com/purpose168/GoAdmin/modules/config"       // 配置模块，提供配置读取和URL处理功能
-	"github.com/purpose168/GoAdmin/modules/constant"     // 常量定义，包含框架使用的各种常量
-	"github.com/purpose168/GoAdmin/modules/db"           // 数据库模块，提供数据库连接和操作接口
-	"github.com/purpose168/GoAdmin/modules/errors"       // 错误处理，提供框架特定的错误类型和消息
-	"github.com/purpose168/GoAdmin/modules/logger"       // 日志模块，提供日志记录功能
-	"github.com/purpose168/GoAdmin/modules/menu"         // 菜单模块，提供菜单生成和管理功能
-	"github.com/purpose168/GoAdmin/plugins"              // 插件接口，定义插件的基本结构和功能
-	"github.com/purpose168/GoAdmin/plugins/admin/models" // 管理模型，提供用户模型等数据结构
-	"github.com/purpose168/GoAdmin/template"             // 模板引擎，提供HTML模板渲染功能
-	"github.com/purpose168/GoAdmin/template/types"       // 模板类型，定义面板、按钮等模板组件
+	"strings"  // 字符串操作，用于处理挂载路径
+
+	"github.com/purpose168/GoAdmin/context"                        // 上下文管理，提供请求和响应的抽象
+	"github.com/purpose168/GoAdmin/modules/auth"                   // 认证模块，处理用户身份验证和权限管理
+	"github.com/purpose168/GoAdmin/modules/config"                 // 配置模块，提供配置读取和URL处理功能
+	"github.com/purpose168/GoAdmin/modules/constant"               // 常量定义，包含框架使用的各种常量
+	"github.com/purpose168/GoAdmin/modules/db"                     // 数据库模块，提供数据库连接和操作接口
+	"github.com/purpose168/GoAdmin/modules/errors"                 // 错误处理，提供框架特定的错误类型和消息
+	"github.com/purpose168/GoAdmin/modules/logger"                 // 日志模块，提供日志记录功能
+	"github.com/purpose168/GoAdmin/modules/menu"                   // 菜单模块，提供菜单生成和管理功能
+	"github.com/purpose168/GoAdmin/plugins"                        // 插件接口，定义插件的基本结构和功能
+	"github.com/purpose168/GoAdmin/plugins/admin/models"           // 管理模型，提供用户模型等数据结构
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response" // 响应处理，提供演示模式等中间件
+	"github.com/purpose168/GoAdmin/template"                       // 模板引擎，提供HTML模板渲染功能
+	"github.com/purpose168/GoAdmin/template/types"                 // 模板类型，定义面板、按钮等模板组件
 )
 
 // WebFrameWork 接口定义了Web框架适配器需要实现的方法
@@ -366,6 +368,48 @@ type WebFrameWork interface {
 	HTMLContentType() string
 }
 
+// EarlyHintsWriter 可选的适配器接口，用于发送 103 Early Hints
+// 开启 config.EarlyHints 后，GetContent 会在渲染页面之前调用该方法，
+// 让浏览器提前加载主题的关键 CSS/JS；未实现该接口的适配器不发送 Early Hints
+//
+// 实现示例：
+//
+//	func (ch *Chi5) WriteEarlyHints(links []string) {
+//	    adapter.WriteEarlyHints(ch.ctx.Response, links)
+//	}
+type EarlyHintsWriter interface {
+	// WriteEarlyHints 发送带有 Link 头的 103 Early Hints 响应
+	//
+	// 参数说明：
+	//   - links: Link 头的值，由 template.GetPreloadLinks 生成
+	WriteEarlyHints(links []string)
+}
+
+// WriteEarlyHints 通过 http.ResponseWriter 发送 103 Early Hints
+// 框架包装的 ResponseWriter 通常会把第一次写入的状态码当作最终状态码，
+// 因此会先通过 Unwrap 方法取得原始的 ResponseWriter。
+// Link 头会保留在最终的响应中，不支持 1xx 响应的客户端仍然可以预加载资源
+//
+// 参数：
+//   - w: 响应对象
+//   - links: Link 头的值
+func WriteEarlyHints(w http.ResponseWriter, links []string) {
+	if len(links) == 0 {
+		return
+	}
+	for {
+		u, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			break
+		}
+		w = u.Unwrap()
+	}
+	for _, link := range links {
+		w.Header().Add("Link", link)
+	}
+	w.WriteHeader(http.StatusEarlyHints)
+}
+
 // BaseAdapter 是Web框架适配器的基础实现
 // 提供了WebFrameWork接口的通用方法，可以被各个Web框架适配器嵌入使用
 //
@@ -545,6 +589,8 @@ func (*BaseAdapter) GetUse(app interface{}, plugin []plugins.Plugin, wf WebFrame
 	for _, plug := range plugin {
 		// 遍历插件的所有路由
 		for path, handlers := range plug.GetHandler() {
+			// 演示模式在所有插件路由之前拦截修改数据的请求
+			handlers = append(context.Handlers{response.DemoHandler}, handlers...)
 			// 如果插件有前缀，将前缀添加到路由路径
 			if plug.Prefix() == "" {
 				// 没有前缀，直接注册路由
@@ -560,6 +606,49 @@ func (*BaseAdapter) GetUse(app interface{}, plugin []plugins.Plugin, wf WebFrame
 	return nil
 }
 
+// CheckMountPath 检查挂载路径与 URL 前缀是否匹配
+// 将 GoAdmin 挂载在路由分组（如 chi 的 r.Route("/backoffice", ...)）下时，
+// URL 前缀需要包含分组的路径（如 "backoffice/admin"），这样 config.Url 生成的 URL 才是完整的
+//
+// 参数：
+//   - mount: 路由分组的路径，如 "/backoffice"
+//
+// 返回值：
+//   - string: 规范化后的挂载路径，根路径返回空字符串
+//   - error: URL 前缀不在挂载路径下时返回错误
+func CheckMountPath(mount string) (string, error) {
+	mount = "/" + strings.Trim(mount, "/")
+	if mount == "/" {
+		return "", nil
+	}
+	if prefix := config.Prefix(); prefix != mount && !strings.HasPrefix(prefix, mount+"/") {
+		return "", fmt.Errorf("url prefix %s is not under the mount path %s", prefix, mount)
+	}
+	return mount, nil
+}
+
+// TrimMountPath 去除路由路径中的挂载路径，得到路由分组中注册的相对路径
+//
+// 参数：
+//   - mount: CheckMountPath 返回的挂载路径
+//   - path: GoAdmin 的路由路径，如 "/backoffice/admin/info/:__prefix"
+//
+// 返回值：
+//   - string: 相对路径，如 "/admin/info/:__prefix"
+//   - bool: 路由路径不在挂载路径下时返回 false，该路由无法注册到路由分组中
+func TrimMountPath(mount, path string) (string, bool) {
+	if mount == "" {
+		return path, true
+	}
+	if path == mount {
+		return "/", true
+	}
+	if !strings.HasPrefix(path, mount+"/") {
+		return "", false
+	}
+	return path[len(mount):], true
+}
+
 // Run 启动Web服务器
 // 这是一个占位方法，具体实现由各个Web框架适配器提供
 //
@@ -670,6 +759,13 @@ func (base *BaseAdapter) GetContent(ctx interface{}, getPanelFn types.GetPanelFn
 	// 创建GoAdmin上下文
 	gctx := context.NewContext(newBase.Request())
 
+	// 在获取面板内容之前发送 Early Hints，Pjax 请求不加载头部资源
+	if config.GetEarlyHints() && !newBase.IsPjax() {
+		if hinter, ok := newBase.(EarlyHintsWriter); ok {
+			hinter.WriteEarlyHints(template.GetPreloadLinks(gctx))
+		}
+	}
+
 	// 检查用户权限
 	// auth.CheckPermissions会检查用户是否有权访问当前路径和方法
 	if !auth.CheckPermissions(user, newBase.Path(), newBase.Method(), newBase.FormParam()) {
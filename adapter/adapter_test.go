@@ -0,0 +1,75 @@
+package adapter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/textproto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// wrappedWriter 模拟框架包装的 ResponseWriter，第一次写入的状态码即为最终状态码
+type wrappedWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *wrappedWriter) WriteHeader(code int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *wrappedWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func TestWriteEarlyHints(t *testing.T) {
+	links := []string{
+		"</admin/assets/all.min.css>; rel=preload; as=style",
+		"</admin/assets/all.min.js>; rel=preload; as=script",
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ww := &wrappedWriter{ResponseWriter: w}
+		WriteEarlyHints(ww, links)
+		ww.WriteHeader(http.StatusOK)
+		_, _ = ww.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	var hints []textproto.MIMEHeader
+	trace := &httptrace.ClientTrace{
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			if code == http.StatusEarlyHints {
+				hints = append(hints, header)
+			}
+			return nil
+		},
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+	res, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer func() { _ = res.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Len(t, hints, 1)
+	if len(hints) == 1 {
+		assert.Equal(t, links, hints[0].Values("Link"))
+	}
+	// 不支持 1xx 响应的客户端仍然可以从最终响应中读取 Link 头
+	assert.Equal(t, links, res.Header.Values("Link"))
+}
+
+func TestWriteEarlyHints_Empty(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteEarlyHints(w, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Values("Link"))
+}
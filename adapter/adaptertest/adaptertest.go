@@ -0,0 +1,177 @@
+// Package adaptertest 提供适配器的一致性测试
+//
+// 适配器的测试使用 Run 启动应用并运行同一组测试用例，覆盖以下行为：
+//   - AddHandler: 路由参数、请求头、状态码、表单和重定向
+//   - Content: Cookie、重定向、权限检查中的路径、Pjax、语言和查询参数
+//
+// 由于 GoAdmin 的配置是全局的，一个测试二进制中只能调用一次 Run。
+//
+// 使用示例：
+//
+//	func TestChi5(t *testing.T) {
+//	    adaptertest.Run(t, "admin", func(cfg *config.Config) (http.Handler, error) {
+//	        r := chi.NewRouter()
+//	        if err := engine.Default().AddConfig(cfg).Use(r); err != nil {
+//	            return nil, err
+//	        }
+//	        r.Get(config.Url(adaptertest.ContentPath), Content(func(ctx Context) (types.Panel, error) {
+//	            return adaptertest.Panel()
+//	        }))
+//	        return r, nil
+//	    })
+//	}
+package adaptertest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	_ "github.com/purpose168/GoAdmin-themes/adminlte" // 导入 adminlte 主题
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
+	"github.com/purpose168/GoAdmin/template/types"
+	"github.com/purpose168/GoAdmin/tests/testkit"
+)
+
+// ContentPath 使用适配器 Content 方法渲染的页面路径，应用需要在 config.Url(ContentPath) 注册该页面
+const ContentPath = "/adaptertest"
+
+// Marker 页面内容中的标记，用于断言页面已渲染
+const Marker = "adaptertest-content-marker"
+
+// AppFn 应用生成函数类型
+// 参数：
+//   - cfg: GoAdmin 的配置，需要传给 engine.AddConfig
+//
+// 返回值：
+//   - http.Handler: 挂载了 GoAdmin 的应用
+//   - error: engine.Use 返回的错误
+type AppFn func(cfg *config.Config) (http.Handler, error)
+
+// Panel 返回 ContentPath 页面的面板
+func Panel() (types.Panel, error) {
+	return types.Panel{
+		Title:   "Adapter Test",
+		Content: Marker,
+	}, nil
+}
+
+// Run 启动应用并运行一致性测试
+// 参数：
+//   - t: 测试对象
+//   - prefix: URL 前缀，挂载在路由分组下时需要包含分组的路径，如 "backoffice/admin"
+//   - fn: 应用生成函数
+func Run(t *testing.T, prefix string, fn AppFn) {
+	dbs := testkit.SQLite(t)
+
+	handler, err := fn(&config.Config{
+		Databases: dbs,
+		UrlPrefix: prefix,
+		Language:  language.EN,
+		IndexUrl:  "/",
+		Theme:     "adminlte",
+	})
+	if err != nil {
+		t.Fatalf("adaptertest: start app: %v", err)
+	}
+
+	content := config.Url(ContentPath)
+
+	t.Run("Redirect", func(t *testing.T) {
+		for _, path := range []string{config.Url("/info/manager"), content} {
+			res := serve(handler, http.MethodGet, path)
+			if res.Code != http.StatusFound {
+				t.Errorf("GET %s: status %d, want %d", path, res.Code, http.StatusFound)
+			}
+			if loc := res.Header().Get("Location"); !strings.HasPrefix(loc, config.Url(config.GetLoginUrl())) {
+				t.Errorf("GET %s: location %q, want the login url", path, loc)
+			}
+		}
+	})
+
+	t.Run("NoAuth", func(t *testing.T) {
+		res := serve(handler, http.MethodGet, config.Url(config.GetLoginUrl()))
+		if res.Code != http.StatusOK {
+			t.Errorf("GET login: status %d, want %d", res.Code, http.StatusOK)
+		}
+		if !strings.Contains(res.Body.String(), config.Url("/assets/")) {
+			t.Error("GET login: the page does not contain the assets url")
+		}
+	})
+
+	kit := testkit.New(t, dbs, handler)
+
+	t.Run("Form", func(t *testing.T) {
+		kit.Expect.POST(config.Url("/signin")).
+			WithFormField("username", "admin").
+			WithFormField("password", "wrong").
+			Expect().Status(http.StatusBadRequest)
+		kit.Login("admin", "admin")
+	})
+
+	t.Run("Params", func(t *testing.T) {
+		kit.Page("/info/manager").Contains(config.Url("/info/manager/new"))
+		kit.Page("/info/roles").Contains(config.Url("/info/roles/new"))
+		kit.Expect.GET(config.Url("/info/manager/detail")).
+			WithQuery(constant.DetailPKKey, "1").
+			Expect().Status(http.StatusOK).Body().Contains("admin")
+	})
+
+	t.Run("Headers", func(t *testing.T) {
+		res := kit.Expect.GET(config.Url("/info/manager")).
+			WithHeader(constant.PjaxHeader, "true").
+			Expect().Status(http.StatusOK)
+		res.Header("Content-Type").Contains("text/html")
+		res.Body().NotContains("<html")
+	})
+
+	t.Run("Content", func(t *testing.T) {
+		res := kit.Expect.GET(content).Expect().Status(http.StatusOK)
+		res.Header("Content-Type").Contains("text/html")
+		res.Body().Contains(Marker).Contains("<html").Contains("main-sidebar")
+	})
+
+	t.Run("ContentPjax", func(t *testing.T) {
+		kit.Expect.GET(content).WithHeader(constant.PjaxHeader, "true").
+			Expect().Status(http.StatusOK).
+			Body().Contains(Marker).NotContains("<html")
+	})
+
+	t.Run("ContentQuery", func(t *testing.T) {
+		kit.Expect.GET(content).WithQuery(constant.IframeKey, "true").
+			Expect().Status(http.StatusOK).
+			Body().Contains(Marker).NotContains("main-sidebar")
+	})
+
+	t.Run("ContentPermission", func(t *testing.T) {
+		// operator 只有首页的权限
+		if _, err := kit.Table("goadmin_users").Where("username", "=", "operator").
+			Update(dialect.H{"password": auth.EncodePassword([]byte("operator"))}); err != nil {
+			t.Fatalf("adaptertest: update password: %v", err)
+		}
+		kit.Logout().Login("operator", "operator")
+		kit.Expect.GET(content).Expect().Status(http.StatusOK).
+			Body().NotContains(Marker).Contains("403")
+	})
+
+	t.Run("Logout", func(t *testing.T) {
+		kit.Logout()
+		res := serve(handler, http.MethodGet, config.Url("/logout"))
+		if res.Code != http.StatusFound {
+			t.Errorf("GET logout: status %d, want %d", res.Code, http.StatusFound)
+		}
+	})
+}
+
+// serve 直接调用处理器，不跟随重定向
+func serve(handler http.Handler, method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+	return res
+}
@@ -105,7 +105,6 @@
 package chi
 
 import (
-	"bytes"    // 字节缓冲区操作，提供字节缓冲区的读写功能
 	"errors"   // 错误处理，提供错误创建和处理功能
 	"net/http" // HTTP 包，提供 HTTP 客户端和服务器功能
 	"net/url"  // URL 解析和查询，提供 URL 解析和查询参数处理功能
@@ -517,10 +516,8 @@ func (ch *Chi) AddHandler(method, path string, handlers context.Handlers) {
 		}
 		// 将响应体写入 Chi 响应
 		if ctx.Response.Body != nil { // 如果 GoAdmin 响应体不为空
-			buf := new(bytes.Buffer)               // 创建字节缓冲区
-			_, _ = buf.ReadFrom(ctx.Response.Body) // 从 GoAdmin 响应体读取数据到缓冲区
 			w.WriteHeader(ctx.Response.StatusCode) // 设置 Chi 响应的状态码为 GoAdmin 响应的状态码
-			_, _ = w.Write(buf.Bytes())            // 将缓冲区数据写入 Chi 响应
+			_ = ctx.WriteBody(w)                   // 将响应体直接写入 Chi 响应
 		} else { // 如果 GoAdmin 响应体为空
 			w.WriteHeader(ctx.Response.StatusCode) // 只设置 Chi 响应的状态码
 		}
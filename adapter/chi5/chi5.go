@@ -0,0 +1,252 @@
+// 版权所有 2019 GoAdmin 核心团队。保留所有权利。
+// 本源代码的使用受 Apache-2.0 风格许可证约束
+// 该许可证可在 LICENSE 文件中找到
+
+// Package chi5 提供 GoAdmin 在 chi v5 框架下的适配器实现
+//
+// 与 chi 适配器相比，本适配器支持将 GoAdmin 挂载在已有的路由分组下：
+//
+//	r := chi.NewRouter()
+//	r.Route("/backoffice", func(sub chi.Router) {
+//	    _ = eng.AddConfig(&config.Config{UrlPrefix: "backoffice/admin"}).
+//	        Use(chi5.Mount(sub, "/backoffice"))
+//	})
+//
+// URL 前缀需要包含分组的路径，这样 config.Url 生成的 URL 才是完整的，
+// 注册到分组中的路由会去除分组的路径。
+package chi5
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/purpose168/GoAdmin/adapter"
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/engine"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/plugins"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// Chi5 结构体实现了 GoAdmin 的适配器接口
+type Chi5 struct {
+	adapter.BaseAdapter
+	ctx   Context
+	app   chi.Router
+	mount string
+}
+
+// init 将 chi v5 适配器注册到 GoAdmin 引擎中
+func init() {
+	engine.Register(new(Chi5))
+}
+
+// Mounted 挂载在路由分组下的 chi 路由器
+type Mounted struct {
+	Router chi.Router // 路由分组，如 r.Route 回调中的路由器
+	Path   string     // 路由分组的路径，如 "/backoffice"
+}
+
+// Mount 返回挂载在路由分组下的路由器，作为 engine.Use 的参数
+func Mount(r chi.Router, path string) Mounted {
+	return Mounted{Router: r, Path: path}
+}
+
+// User 实现了 Adapter.User 方法
+func (ch *Chi5) User(ctx interface{}) (models.UserModel, bool) {
+	return ch.GetUser(ctx, ch)
+}
+
+// Use 实现了 Adapter.Use 方法
+func (ch *Chi5) Use(app interface{}, plugs []plugins.Plugin) error {
+	return ch.GetUse(app, plugs, ch)
+}
+
+// Content 实现了 Adapter.Content 方法
+func (ch *Chi5) Content(ctx interface{}, getPanelFn types.GetPanelFn, fn context.NodeProcessor, btns ...types.Button) {
+	ch.GetContent(ctx, getPanelFn, ch, btns, fn)
+}
+
+// HandlerFunc 处理函数类型，返回面板内容
+type HandlerFunc func(ctx Context) (types.Panel, error)
+
+// Content 将处理函数转换为 http.HandlerFunc
+func Content(handler HandlerFunc) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		ctx := Context{
+			Request:  request,
+			Response: writer,
+		}
+		engine.Content(ctx, func(ctx interface{}) (types.Panel, error) {
+			return handler(ctx.(Context))
+		})
+	}
+}
+
+// SetApp 实现了 Adapter.SetApp 方法
+// 参数 app 可以是 chi.Router（如 *chi.Mux）或 Mount 返回的 Mounted
+func (ch *Chi5) SetApp(app interface{}) error {
+	switch r := app.(type) {
+	case Mounted:
+		mount, err := adapter.CheckMountPath(r.Path)
+		if err != nil {
+			return errors.New("chi5 适配器 SetApp: " + err.Error())
+		}
+		ch.app = r.Router
+		ch.mount = mount
+	case chi.Router:
+		ch.app = r
+		ch.mount = ""
+	default:
+		return errors.New("chi5 适配器 SetApp: 参数类型错误")
+	}
+	return nil
+}
+
+var (
+	paramInMiddle = regexp.MustCompile(":(.*?)/")
+	paramAtEnd    = regexp.MustCompile(":(.*?)$")
+)
+
+// AddHandler 实现了 Adapter.AddHandler 方法
+// 路由参数 :param 会被转换为 chi 的 {param} 格式，挂载在分组下时去除分组的路径
+func (ch *Chi5) AddHandler(method, path string, handlers context.Handlers) {
+	route, ok := adapter.TrimMountPath(ch.mount, path)
+	if !ok {
+		logger.Warnf("chi5: route %s %s is not under the mount path %s, skipped", method, path, ch.mount)
+		return
+	}
+
+	url := paramInMiddle.ReplaceAllString(route, "{$1}/")
+	url = paramAtEnd.ReplaceAllString(url, "{$1}")
+
+	if len(url) > 1 && url[0] == '/' && url[1] == '/' {
+		url = url[1:]
+	}
+
+	ch.app.MethodFunc(strings.ToUpper(method), url, func(w http.ResponseWriter, r *http.Request) {
+
+		if len(r.URL.Path) > 1 && r.URL.Path[len(r.URL.Path)-1] == '/' {
+			r.URL.Path = r.URL.Path[:len(r.URL.Path)-1]
+		}
+
+		ctx := context.NewContext(r)
+
+		params := chi.RouteContext(r.Context()).URLParams
+
+		for i := 0; i < len(params.Values); i++ {
+			if r.URL.RawQuery == "" {
+				r.URL.RawQuery += strings.ReplaceAll(params.Keys[i], ":", "") + "=" + params.Values[i]
+			} else {
+				r.URL.RawQuery += "&" + strings.ReplaceAll(params.Keys[i], ":", "") + "=" + params.Values[i]
+			}
+		}
+
+		ctx.SetHandlers(handlers).Next()
+		for key, head := range ctx.Response.Header {
+			w.Header().Set(key, head[0])
+		}
+		if ctx.Response.Body != nil {
+			w.WriteHeader(ctx.Response.StatusCode)
+			_ = ctx.WriteBody(w)
+		} else {
+			w.WriteHeader(ctx.Response.StatusCode)
+		}
+	})
+}
+
+// Context 包装了请求和响应对象
+type Context struct {
+	Request  *http.Request
+	Response http.ResponseWriter
+}
+
+// SetContext 实现了 Adapter.SetContext 方法
+func (*Chi5) SetContext(contextInterface interface{}) adapter.WebFrameWork {
+	var (
+		ctx Context
+		ok  bool
+	)
+	if ctx, ok = contextInterface.(Context); !ok {
+		panic("chi5 适配器 SetContext: 参数类型错误")
+	}
+	return &Chi5{ctx: ctx}
+}
+
+// Name 实现了 Adapter.Name 方法
+func (*Chi5) Name() string {
+	return "chi5"
+}
+
+// Redirect 实现了 Adapter.Redirect 方法
+func (ch *Chi5) Redirect() {
+	http.Redirect(ch.ctx.Response, ch.ctx.Request, config.Url(config.GetLoginUrl()), http.StatusFound)
+}
+
+// SetContentType 实现了 Adapter.SetContentType 方法
+func (ch *Chi5) SetContentType() {
+	ch.ctx.Response.Header().Set("Content-Type", ch.HTMLContentType())
+}
+
+// Write 实现了 Adapter.Write 方法
+func (ch *Chi5) Write(body []byte) {
+	ch.ctx.Response.WriteHeader(http.StatusOK)
+	_, _ = ch.ctx.Response.Write(body)
+}
+
+// WriteEarlyHints 实现了 adapter.EarlyHintsWriter 接口
+func (ch *Chi5) WriteEarlyHints(links []string) {
+	adapter.WriteEarlyHints(ch.ctx.Response, links)
+}
+
+// GetCookie 实现了 Adapter.GetCookie 方法
+func (ch *Chi5) GetCookie() (string, error) {
+	cookie, err := ch.ctx.Request.Cookie(ch.CookieKey())
+	if err != nil {
+		return "", err
+	}
+	return cookie.Value, err
+}
+
+// Lang 实现了 Adapter.Lang 方法
+func (ch *Chi5) Lang() string {
+	return ch.ctx.Request.URL.Query().Get("__ga_lang")
+}
+
+// Path 实现了 Adapter.Path 方法，返回包含分组路径的完整路径
+func (ch *Chi5) Path() string {
+	return ch.ctx.Request.URL.Path
+}
+
+// Method 实现了 Adapter.Method 方法
+func (ch *Chi5) Method() string {
+	return ch.ctx.Request.Method
+}
+
+// FormParam 实现了 Adapter.FormParam 方法
+func (ch *Chi5) FormParam() url.Values {
+	_ = ch.ctx.Request.ParseMultipartForm(32 << 20)
+	return ch.ctx.Request.PostForm
+}
+
+// IsPjax 实现了 Adapter.IsPjax 方法
+func (ch *Chi5) IsPjax() bool {
+	return ch.ctx.Request.Header.Get(constant.PjaxHeader) == "true"
+}
+
+// Query 实现了 Adapter.Query 方法
+func (ch *Chi5) Query() url.Values {
+	return ch.ctx.Request.URL.Query()
+}
+
+// Request 实现了 Adapter.Request 方法
+func (ch *Chi5) Request() *http.Request {
+	return ch.ctx.Request
+}
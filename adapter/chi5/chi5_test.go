@@ -0,0 +1,35 @@
+package chi5
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/purpose168/GoAdmin/adapter/adaptertest"
+	"github.com/purpose168/GoAdmin/engine"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/template/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChi5_Mount(t *testing.T) {
+	adaptertest.Run(t, "backoffice/admin", func(cfg *config.Config) (http.Handler, error) {
+		r := chi.NewRouter()
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("home"))
+		})
+
+		var err error
+		r.Route("/backoffice", func(sub chi.Router) {
+			err = engine.Default().AddConfig(cfg).Use(Mount(sub, "/backoffice"))
+
+			sub.Get("/admin"+adaptertest.ContentPath, Content(func(ctx Context) (types.Panel, error) {
+				return adaptertest.Panel()
+			}))
+
+			// 前缀不在挂载路径下
+			assert.Error(t, new(Chi5).SetApp(Mount(sub, "/other")))
+		})
+		return r, err
+	})
+}
@@ -0,0 +1,232 @@
+// 版权所有 2019 GoAdmin 核心团队。保留所有权利。
+// 本源代码的使用受 Apache-2.0 风格许可证约束
+// 该许可证可在 LICENSE 文件中找到
+
+// Package echo5 提供 GoAdmin 在 Echo v5 框架下的适配器实现
+//
+// 与 echo 适配器相比，本适配器支持将 GoAdmin 挂载在已有的路由分组下：
+//
+//	e := echo.New()
+//	g := e.Group("/backoffice")
+//	_ = eng.AddConfig(&config.Config{UrlPrefix: "backoffice/admin"}).
+//	    Use(echo5.Mount(g, "/backoffice"))
+//
+// URL 前缀需要包含分组的路径，这样 config.Url 生成的 URL 才是完整的，
+// 注册到分组中的路由会去除分组的路径。
+package echo5
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/purpose168/GoAdmin/adapter"
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/engine"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/plugins"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// Echo5 结构体实现了 GoAdmin 的适配器接口
+type Echo5 struct {
+	adapter.BaseAdapter
+	ctx   *echo.Context
+	app   router
+	mount string
+}
+
+// router 是 *echo.Echo 和 *echo.Group 共有的路由注册方法
+type router interface {
+	Add(method, path string, handler echo.HandlerFunc, middleware ...echo.MiddlewareFunc) echo.RouteInfo
+}
+
+// init 将 Echo v5 适配器注册到 GoAdmin 引擎中
+func init() {
+	engine.Register(new(Echo5))
+}
+
+// Mounted 挂载在路由分组下的 Echo 路由器
+type Mounted struct {
+	Group *echo.Group // 路由分组，如 e.Group("/backoffice")
+	Path  string      // 路由分组的路径，如 "/backoffice"
+}
+
+// Mount 返回挂载在路由分组下的路由器，作为 engine.Use 的参数
+func Mount(g *echo.Group, path string) Mounted {
+	return Mounted{Group: g, Path: path}
+}
+
+// User 实现了 Adapter.User 方法
+func (e *Echo5) User(ctx interface{}) (models.UserModel, bool) {
+	return e.GetUser(ctx, e)
+}
+
+// Use 实现了 Adapter.Use 方法
+func (e *Echo5) Use(app interface{}, plugs []plugins.Plugin) error {
+	return e.GetUse(app, plugs, e)
+}
+
+// Content 实现了 Adapter.Content 方法
+func (e *Echo5) Content(ctx interface{}, getPanelFn types.GetPanelFn, fn context.NodeProcessor, btns ...types.Button) {
+	e.GetContent(ctx, getPanelFn, e, btns, fn)
+}
+
+// HandlerFunc 处理函数类型，返回面板内容
+type HandlerFunc func(ctx *echo.Context) (types.Panel, error)
+
+// Content 将处理函数转换为 echo.HandlerFunc
+func Content(handler HandlerFunc) echo.HandlerFunc {
+	return func(ctx *echo.Context) error {
+		engine.Content(ctx, func(ctx interface{}) (types.Panel, error) {
+			return handler(ctx.(*echo.Context))
+		})
+		return nil
+	}
+}
+
+// SetApp 实现了 Adapter.SetApp 方法
+// 参数 app 可以是 *echo.Echo 或 Mount 返回的 Mounted
+func (e *Echo5) SetApp(app interface{}) error {
+	switch r := app.(type) {
+	case Mounted:
+		if r.Group == nil {
+			return errors.New("echo5 适配器 SetApp: 路由分组为空")
+		}
+		mount, err := adapter.CheckMountPath(r.Path)
+		if err != nil {
+			return errors.New("echo5 适配器 SetApp: " + err.Error())
+		}
+		e.app = r.Group
+		e.mount = mount
+	case *echo.Echo:
+		e.app = r
+		e.mount = ""
+	default:
+		return errors.New("echo5 适配器 SetApp: 参数类型错误")
+	}
+	return nil
+}
+
+// AddHandler 实现了 Adapter.AddHandler 方法
+// 挂载在分组下时去除分组的路径
+func (e *Echo5) AddHandler(method, path string, handlers context.Handlers) {
+	route, ok := adapter.TrimMountPath(e.mount, path)
+	if !ok {
+		logger.Warnf("echo5: route %s %s is not under the mount path %s, skipped", method, path, e.mount)
+		return
+	}
+
+	e.app.Add(strings.ToUpper(method), route, func(c *echo.Context) error {
+
+		ctx := context.NewContext(c.Request())
+
+		for _, param := range c.PathValues() {
+			if c.Request().URL.RawQuery == "" {
+				c.Request().URL.RawQuery += strings.ReplaceAll(param.Name, ":", "") + "=" + param.Value
+			} else {
+				c.Request().URL.RawQuery += "&" + strings.ReplaceAll(param.Name, ":", "") + "=" + param.Value
+			}
+		}
+
+		ctx.SetHandlers(handlers).Next()
+		for key, head := range ctx.Response.Header {
+			c.Response().Header().Set(key, head[0])
+		}
+		if ctx.Response.Body != nil {
+			c.Response().WriteHeader(ctx.Response.StatusCode)
+			_ = ctx.WriteBody(c.Response())
+		} else {
+			c.Response().WriteHeader(ctx.Response.StatusCode)
+		}
+		return nil
+	})
+}
+
+// Name 实现了 Adapter.Name 方法
+func (*Echo5) Name() string {
+	return "echo5"
+}
+
+// SetContext 实现了 Adapter.SetContext 方法
+func (*Echo5) SetContext(contextInterface interface{}) adapter.WebFrameWork {
+	var (
+		ctx *echo.Context
+		ok  bool
+	)
+	if ctx, ok = contextInterface.(*echo.Context); !ok {
+		panic("echo5 适配器 SetContext: 参数类型错误")
+	}
+	return &Echo5{ctx: ctx}
+}
+
+// Redirect 实现了 Adapter.Redirect 方法
+func (e *Echo5) Redirect() {
+	_ = e.ctx.Redirect(http.StatusFound, config.Url(config.GetLoginUrl()))
+}
+
+// SetContentType 实现了 Adapter.SetContentType 方法
+func (e *Echo5) SetContentType() {
+	e.ctx.Response().Header().Set("Content-Type", e.HTMLContentType())
+}
+
+// Write 实现了 Adapter.Write 方法
+func (e *Echo5) Write(body []byte) {
+	e.ctx.Response().WriteHeader(http.StatusOK)
+	_, _ = e.ctx.Response().Write(body)
+}
+
+// WriteEarlyHints 实现了 adapter.EarlyHintsWriter 接口
+func (e *Echo5) WriteEarlyHints(links []string) {
+	adapter.WriteEarlyHints(e.ctx.Response(), links)
+}
+
+// GetCookie 实现了 Adapter.GetCookie 方法
+func (e *Echo5) GetCookie() (string, error) {
+	cookie, err := e.ctx.Cookie(e.CookieKey())
+	if err != nil {
+		return "", err
+	}
+	return cookie.Value, err
+}
+
+// Lang 实现了 Adapter.Lang 方法
+func (e *Echo5) Lang() string {
+	return e.ctx.Request().URL.Query().Get("__ga_lang")
+}
+
+// Path 实现了 Adapter.Path 方法，返回包含分组路径的完整路径
+func (e *Echo5) Path() string {
+	return e.ctx.Request().URL.Path
+}
+
+// Method 实现了 Adapter.Method 方法
+func (e *Echo5) Method() string {
+	return e.ctx.Request().Method
+}
+
+// FormParam 实现了 Adapter.FormParam 方法
+func (e *Echo5) FormParam() url.Values {
+	_ = e.ctx.Request().ParseMultipartForm(32 << 20)
+	return e.ctx.Request().PostForm
+}
+
+// IsPjax 实现了 Adapter.IsPjax 方法
+func (e *Echo5) IsPjax() bool {
+	return e.ctx.Request().Header.Get(constant.PjaxHeader) == "true"
+}
+
+// Query 实现了 Adapter.Query 方法
+func (e *Echo5) Query() url.Values {
+	return e.ctx.Request().URL.Query()
+}
+
+// Request 实现了 Adapter.Request 方法
+func (e *Echo5) Request() *http.Request {
+	return e.ctx.Request()
+}
@@ -0,0 +1,36 @@
+package echo5
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/purpose168/GoAdmin/adapter/adaptertest"
+	"github.com/purpose168/GoAdmin/engine"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/template/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEcho5_Mount(t *testing.T) {
+	adaptertest.Run(t, "backoffice/admin", func(cfg *config.Config) (http.Handler, error) {
+		e := echo.New()
+		e.GET("/", func(c *echo.Context) error {
+			return c.String(http.StatusOK, "home")
+		})
+
+		g := e.Group("/backoffice")
+		if err := engine.Default().AddConfig(cfg).Use(Mount(g, "/backoffice")); err != nil {
+			return nil, err
+		}
+
+		g.GET("/admin"+adaptertest.ContentPath, Content(func(ctx *echo.Context) (types.Panel, error) {
+			return adaptertest.Panel()
+		}))
+
+		// 前缀不在挂载路径下
+		assert.Error(t, new(Echo5).SetApp(Mount(e.Group("/other"), "/other")))
+
+		return e, nil
+	})
+}
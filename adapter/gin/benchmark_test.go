@@ -0,0 +1,35 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/purpose168/GoAdmin/context"
+)
+
+func BenchmarkGin_AddHandler(b *testing.B) {
+	gin.SetMode(gin.ReleaseMode)
+
+	var (
+		app  = gin.New()
+		gins = new(Gin)
+	)
+	_ = gins.SetApp(app)
+	gins.AddHandler("GET", "/admin/info/:__prefix", context.Handlers{
+		func(ctx *context.Context) {
+			ctx.Write(http.StatusOK, map[string]string{"Content-Type": "text/plain"}, ctx.Query("__prefix"))
+		},
+	})
+
+	req := httptest.NewRequest("GET", "/admin/info/users?__page=2&__pageSize=20&name=foo", nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		r := req.Clone(req.Context())
+		app.ServeHTTP(httptest.NewRecorder(), r)
+	}
+}
@@ -5,7 +5,6 @@
 package gin
 
 import (
-	"bytes"
 	"errors"
 	"net/http"
 	"net/url"
@@ -165,10 +164,12 @@ func (gins *Gin) AddHandler(method, path string, handlers context.Handlers) {
 
 		// 如果响应体不为空，则写入响应
 		if ctx.Response.Body != nil {
-			buf := new(bytes.Buffer)
-			_, _ = buf.ReadFrom(ctx.Response.Body)
-			// 使用 Gin 的 String 方法写入响应状态码和响应体
-			c.String(ctx.Response.StatusCode, buf.String())
+			// 未设置内容类型时与 Gin 的 String 方法一致，响应体直接写入响应，不在内存中再复制一份
+			if c.Writer.Header().Get("Content-Type") == "" {
+				c.Header("Content-Type", "text/plain; charset=utf-8")
+			}
+			c.Status(ctx.Response.StatusCode)
+			_ = ctx.WriteBody(c.Writer)
 		} else {
 			// 如果响应体为空，只写入状态码
 			c.Status(ctx.Response.StatusCode)
@@ -231,6 +232,14 @@ func (gins *Gin) Write(body []byte) {
 	gins.ctx.Data(http.StatusOK, gins.HTMLContentType(), body)
 }
 
+// WriteEarlyHints 实现了 adapter.EarlyHintsWriter 接口
+// 该方法用于发送 103 Early Hints，让浏览器提前加载主题的关键资源
+// 参数：
+//   - links: Link 头的值
+func (gins *Gin) WriteEarlyHints(links []string) {
+	adapter.WriteEarlyHints(gins.ctx.Writer, links)
+}
+
 // GetCookie 实现了 Adapter.GetCookie 方法
 // 该方法用于获取指定名称的 Cookie 值
 // Cookie 用于存储用户会话信息，如登录凭证
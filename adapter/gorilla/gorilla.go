@@ -5,7 +5,6 @@
 package gorilla
 
 import (
-	"bytes"    // 字节缓冲区
 	"errors"   // 错误处理
 	"net/http" // HTTP客户端和服务器实现
 	"net/url"  // URL解析和查询
@@ -189,17 +188,8 @@ func (g *Gorilla) AddHandler(method, path string, handlers context.Handlers) {
 		// 写入响应状态码
 		w.WriteHeader(ctx.Response.StatusCode)
 
-		// 读取响应体
-		buf := new(bytes.Buffer)
-		_, _ = buf.ReadFrom(ctx.Response.Body)
-
-		// 写入响应体
-		_, err := w.Write(buf.Bytes())
-		if err != nil {
-			// 如果写入失败，返回内部服务器错误
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
+		// 将响应体直接写入响应，状态码已写入，写入失败时只能中止
+		_ = ctx.WriteBody(w)
 	}).Methods(strings.ToUpper(method))
 }
 
@@ -0,0 +1,217 @@
+// 版权所有 2019 GoAdmin 核心团队。保留所有权利。
+// 本源代码的使用受 Apache-2.0 风格许可证约束
+// 该许可证可在 LICENSE 文件中找到
+
+// Package iris12 提供 GoAdmin 在 Iris v12 框架下的适配器实现
+//
+// 与 iris 适配器相比，本适配器使用当前的 iris.Context API，并支持将 GoAdmin 挂载在 Party 下：
+//
+//	app := iris.New()
+//	_ = eng.AddConfig(&config.Config{UrlPrefix: "backoffice/admin"}).
+//	    Use(app.Party("/backoffice"))
+//
+// URL 前缀需要包含 Party 的路径，这样 config.Url 生成的 URL 才是完整的，
+// 注册到 Party 中的路由会去除 Party 的路径。
+package iris12
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kataras/iris/v12"
+	"github.com/purpose168/GoAdmin/adapter"
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/engine"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/plugins"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// Iris12 结构体实现了 GoAdmin 的适配器接口
+type Iris12 struct {
+	adapter.BaseAdapter
+	ctx   iris.Context
+	app   iris.Party
+	mount string
+}
+
+// init 将 Iris v12 适配器注册到 GoAdmin 引擎中
+func init() {
+	engine.Register(new(Iris12))
+}
+
+// User 实现了 Adapter.User 方法
+func (is *Iris12) User(ctx interface{}) (models.UserModel, bool) {
+	return is.GetUser(ctx, is)
+}
+
+// Use 实现了 Adapter.Use 方法
+func (is *Iris12) Use(app interface{}, plugs []plugins.Plugin) error {
+	return is.GetUse(app, plugs, is)
+}
+
+// Content 实现了 Adapter.Content 方法
+func (is *Iris12) Content(ctx interface{}, getPanelFn types.GetPanelFn, fn context.NodeProcessor, btns ...types.Button) {
+	is.GetContent(ctx, getPanelFn, is, btns, fn)
+}
+
+// HandlerFunc 处理函数类型，返回面板内容
+type HandlerFunc func(ctx iris.Context) (types.Panel, error)
+
+// Content 将处理函数转换为 iris.Handler
+func Content(handler HandlerFunc) iris.Handler {
+	return func(ctx iris.Context) {
+		engine.Content(ctx, func(ctx interface{}) (types.Panel, error) {
+			return handler(ctx.(iris.Context))
+		})
+	}
+}
+
+// SetApp 实现了 Adapter.SetApp 方法
+// 参数 app 可以是 *iris.Application 或 iris.Party，Party 的路径即为挂载路径
+func (is *Iris12) SetApp(app interface{}) error {
+	party, ok := app.(iris.Party)
+	if !ok {
+		return errors.New("iris12 适配器 SetApp: 参数类型错误")
+	}
+
+	// 子域名的 Party 路径形如 "admin.example.com/backoffice"
+	path := party.GetRelPath()
+	if i := strings.Index(path, "/"); i > 0 {
+		path = path[i:]
+	} else if i < 0 {
+		path = "/"
+	}
+
+	mount, err := adapter.CheckMountPath(path)
+	if err != nil {
+		return errors.New("iris12 适配器 SetApp: " + err.Error())
+	}
+	is.app = party
+	is.mount = mount
+	return nil
+}
+
+// AddHandler 实现了 Adapter.AddHandler 方法
+// 挂载在 Party 下时去除 Party 的路径
+func (is *Iris12) AddHandler(method, path string, handlers context.Handlers) {
+	route, ok := adapter.TrimMountPath(is.mount, path)
+	if !ok {
+		logger.Warnf("iris12: route %s %s is not under the mount path %s, skipped", method, path, is.mount)
+		return
+	}
+
+	is.app.Handle(strings.ToUpper(method), route, func(c iris.Context) {
+		ctx := context.NewContext(c.Request())
+
+		for _, param := range c.Params().Store {
+			if c.Request().URL.RawQuery == "" {
+				c.Request().URL.RawQuery += strings.ReplaceAll(param.Key, ":", "") + "=" + param.String()
+			} else {
+				c.Request().URL.RawQuery += "&" + strings.ReplaceAll(param.Key, ":", "") + "=" + param.String()
+			}
+		}
+
+		ctx.SetHandlers(handlers).Next()
+		for key, head := range ctx.Response.Header {
+			c.Header(key, head[0])
+		}
+		c.StatusCode(ctx.Response.StatusCode)
+		if ctx.Response.Body != nil {
+			buf := new(bytes.Buffer)
+			_, _ = buf.ReadFrom(ctx.Response.Body)
+			_, _ = c.Write(buf.Bytes())
+		}
+	})
+}
+
+// Name 实现了 Adapter.Name 方法
+func (*Iris12) Name() string {
+	return "iris12"
+}
+
+// SetContext 实现了 Adapter.SetContext 方法
+func (*Iris12) SetContext(contextInterface interface{}) adapter.WebFrameWork {
+	var (
+		ctx iris.Context
+		ok  bool
+	)
+	if ctx, ok = contextInterface.(iris.Context); !ok {
+		panic("iris12 适配器 SetContext: 参数类型错误")
+	}
+	return &Iris12{ctx: ctx}
+}
+
+// Redirect 实现了 Adapter.Redirect 方法
+func (is *Iris12) Redirect() {
+	is.ctx.Redirect(config.Url(config.GetLoginUrl()), http.StatusFound)
+}
+
+// SetContentType 实现了 Adapter.SetContentType 方法
+func (is *Iris12) SetContentType() {
+	is.ctx.ContentType(is.HTMLContentType())
+}
+
+// Write 实现了 Adapter.Write 方法
+func (is *Iris12) Write(body []byte) {
+	is.ctx.StatusCode(http.StatusOK)
+	_, _ = is.ctx.Write(body)
+}
+
+// WriteEarlyHints 实现了 adapter.EarlyHintsWriter 接口
+// iris 的 ResponseWriter 会延迟写入状态码，因此使用原始的 ResponseWriter
+func (is *Iris12) WriteEarlyHints(links []string) {
+	adapter.WriteEarlyHints(is.ctx.ResponseWriter().Naive(), links)
+}
+
+// GetCookie 实现了 Adapter.GetCookie 方法
+// 直接读取请求的 Cookie，不经过 iris 的 Cookie 编解码
+func (is *Iris12) GetCookie() (string, error) {
+	cookie, err := is.ctx.Request().Cookie(is.CookieKey())
+	if err != nil {
+		return "", err
+	}
+	return cookie.Value, err
+}
+
+// Lang 实现了 Adapter.Lang 方法
+func (is *Iris12) Lang() string {
+	return is.ctx.URLParam("__ga_lang")
+}
+
+// Path 实现了 Adapter.Path 方法，返回包含 Party 路径的完整路径
+func (is *Iris12) Path() string {
+	return is.ctx.Request().URL.Path
+}
+
+// Method 实现了 Adapter.Method 方法
+func (is *Iris12) Method() string {
+	return is.ctx.Method()
+}
+
+// FormParam 实现了 Adapter.FormParam 方法
+func (is *Iris12) FormParam() url.Values {
+	_ = is.ctx.Request().ParseMultipartForm(32 << 20)
+	return is.ctx.Request().PostForm
+}
+
+// IsPjax 实现了 Adapter.IsPjax 方法
+func (is *Iris12) IsPjax() bool {
+	return is.ctx.GetHeader(constant.PjaxHeader) == "true"
+}
+
+// Query 实现了 Adapter.Query 方法
+func (is *Iris12) Query() url.Values {
+	return is.ctx.Request().URL.Query()
+}
+
+// Request 实现了 Adapter.Request 方法
+func (is *Iris12) Request() *http.Request {
+	return is.ctx.Request()
+}
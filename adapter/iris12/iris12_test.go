@@ -0,0 +1,40 @@
+package iris12
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/kataras/iris/v12"
+	"github.com/purpose168/GoAdmin/adapter/adaptertest"
+	"github.com/purpose168/GoAdmin/engine"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/template/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIris12_Party(t *testing.T) {
+	adaptertest.Run(t, "backoffice/admin", func(cfg *config.Config) (http.Handler, error) {
+		app := iris.New()
+		app.Logger().SetLevel("disable")
+		app.Get("/", func(ctx iris.Context) {
+			_, _ = ctx.WriteString("home")
+		})
+
+		party := app.Party("/backoffice")
+		if err := engine.Default().AddConfig(cfg).Use(party); err != nil {
+			return nil, err
+		}
+
+		party.Get("/admin"+adaptertest.ContentPath, Content(func(ctx iris.Context) (types.Panel, error) {
+			return adaptertest.Panel()
+		}))
+
+		// 前缀不在挂载路径下
+		assert.Error(t, new(Iris12).SetApp(app.Party("/other")))
+
+		if err := app.Build(); err != nil {
+			return nil, err
+		}
+		return app, nil
+	})
+}
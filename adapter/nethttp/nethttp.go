@@ -23,7 +23,6 @@
 package nethttp
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"net/http"
@@ -260,11 +259,9 @@ func (nh *NetHTTP) AddHandler(method, path string, handlers context.Handlers) {
 
 		// 步骤4.6: 写入响应体
 		if ctx.Response.Body != nil {
-			// 有响应体: 读取并写入
-			buf := new(bytes.Buffer)
-			_, _ = buf.ReadFrom(ctx.Response.Body)
+			// 有响应体: 直接写入响应
 			w.WriteHeader(ctx.Response.StatusCode)
-			_, _ = w.Write(buf.Bytes())
+			_ = ctx.WriteBody(w)
 		} else {
 			// 无响应体: 仅写入状态码
 			w.WriteHeader(ctx.Response.StatusCode)
@@ -0,0 +1,83 @@
+package context
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// MaxPooledBufferSize is the capacity above which a buffer is not put back to
+// the pool, so that the memory of a rare huge page is not held forever.
+const MaxPooledBufferSize = 4 << 20
+
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// GetBuffer return an empty buffer from the pool. The buffer is put back by
+// PutBuffer, or when it is the body of HTMLBuffer and the body is closed.
+func GetBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// PutBuffer put the buffer back to the pool, the buffer must not be used
+// after. Buffers larger than MaxPooledBufferSize are dropped.
+func PutBuffer(buf *bytes.Buffer) {
+	if buf == nil || buf.Cap() > MaxPooledBufferSize {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+// bufferBody is the response body of a pooled buffer. It implements
+// io.WriterTo, so that io.Copy writes the buffer to the response writer
+// without another copy.
+type bufferBody struct {
+	buf *bytes.Buffer
+}
+
+func (b *bufferBody) Read(p []byte) (int, error) {
+	if b.buf == nil {
+		return 0, io.EOF
+	}
+	return b.buf.Read(p)
+}
+
+func (b *bufferBody) WriteTo(w io.Writer) (int64, error) {
+	if b.buf == nil {
+		return 0, nil
+	}
+	return b.buf.WriteTo(w)
+}
+
+// Close put the buffer back to the pool.
+func (b *bufferBody) Close() error {
+	PutBuffer(b.buf)
+	b.buf = nil
+	return nil
+}
+
+// HTMLBuffer output html response of the buffer, usually from GetBuffer. The
+// buffer is put back to the pool when the body is closed by WriteBody.
+func (ctx *Context) HTMLBuffer(code int, buf *bytes.Buffer) {
+	ctx.SetContentType("text/html; charset=utf-8")
+	ctx.SetStatusCode(code)
+	ctx.Response.Body = &bufferBody{buf: buf}
+}
+
+// WriteBody copy the response body into the writer of the framework and close
+// the body. The buffers of HTMLBuffer are written without another copy and put
+// back to the pool. The pages are still rendered completely before, so the
+// handlers can change the status and the headers, see template.Execute.
+func (ctx *Context) WriteBody(w io.Writer) error {
+	if ctx.Response.Body == nil {
+		return nil
+	}
+	defer func() {
+		_ = ctx.Response.Body.Close()
+	}()
+	_, err := io.Copy(w, ctx.Response.Body)
+	return err
+}
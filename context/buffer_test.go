@@ -0,0 +1,72 @@
+package context
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestHTMLBuffer(t *testing.T) {
+	ctx := NewContext(&http.Request{})
+	buf := GetBuffer()
+	buf.WriteString("<p>hello</p>")
+	ctx.HTMLBuffer(http.StatusOK, buf)
+
+	assert.Equal(t, ctx.Response.Header.Get(HeaderContentType), "text/html; charset=utf-8")
+
+	w := new(bytes.Buffer)
+	assert.Equal(t, ctx.WriteBody(w), nil)
+	assert.Equal(t, w.String(), "<p>hello</p>")
+
+	// the body is closed and the buffer is back in the pool.
+	n, err := ctx.Response.Body.Read(make([]byte, 8))
+	assert.Equal(t, n, 0)
+	assert.Equal(t, err, io.EOF)
+}
+
+func TestWriteBody(t *testing.T) {
+	ctx := NewContext(&http.Request{})
+	assert.Equal(t, ctx.WriteBody(io.Discard), nil)
+
+	ctx.HTML(http.StatusOK, "ok")
+	w := new(bytes.Buffer)
+	assert.Equal(t, ctx.WriteBody(w), nil)
+	assert.Equal(t, w.String(), "ok")
+}
+
+// largePage is a page of about 2MB, as a list of thousands of rows.
+var largePage = strings.Repeat(`<tr><td>1</td><td>user</td><td>user@example.com</td><td>city</td></tr>`, 30000)
+
+// BenchmarkHTML_LargePage renders the page into a new buffer and copies it
+// into the response, as the adapters did before WriteBody.
+func BenchmarkHTML_LargePage(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ctx := NewContext(&http.Request{})
+		page := new(bytes.Buffer)
+		page.WriteString(largePage)
+		ctx.HTML(http.StatusOK, page.String())
+
+		buf := new(bytes.Buffer)
+		_, _ = buf.ReadFrom(ctx.Response.Body)
+		_, _ = io.Discard.Write(buf.Bytes())
+	}
+}
+
+// BenchmarkHTMLBuffer_LargePage renders the page into a pooled buffer which is
+// written into the response directly.
+func BenchmarkHTMLBuffer_LargePage(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ctx := NewContext(&http.Request{})
+		page := GetBuffer()
+		page.WriteString(largePage)
+		ctx.HTMLBuffer(http.StatusOK, page)
+
+		_ = ctx.WriteBody(io.Discard)
+	}
+}
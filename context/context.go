@@ -48,7 +48,6 @@ import (
 	"fmt"           // 格式化I/O，用于字符串格式化和输出
 	"io"            // 基础I/O接口，提供输入输出操作的抽象
 	"math"          // 数学运算，提供数学常数和函数
-	"net"           // 网络基础类型，提供IP地址等网络相关类型
 	"net/http"      // HTTP客户端和服务器，提供HTTP请求和响应处理
 	"net/url"       // URL解析和查询，提供URL解析和构建功能
 	"os"            // 操作系统接口，提供文件系统访问等功能
@@ -426,8 +425,17 @@ func (ctx *Context) Data(code int, contentType string, data []byte) {
 	ctx.Response.Body = io.NopCloser(bytes.NewBuffer(data))
 }
 
-// Redirect add redirect url to header.
+// Redirect add redirect url to header. The absolute urls of the host of the
+// request, which are built from the request behind a reverse proxy, are
+// redirected to the scheme and the host seen by the client, see BaseURL.
 func (ctx *Context) Redirect(path string) {
+	for _, scheme := range []string{"http://", "https://"} {
+		if origin := scheme + ctx.Request.Host; path == origin || strings.HasPrefix(path, origin+"/") ||
+			strings.HasPrefix(path, origin+"?") {
+			path = ctx.BaseURL() + path[len(origin):]
+			break
+		}
+	}
 	ctx.Response.StatusCode = http.StatusFound
 	ctx.SetContentType("text/html; charset=utf-8")
 	ctx.AddHeader("Location", path)
@@ -522,21 +530,23 @@ func (ctx *Context) CheckIfModifiedSince(modtime time.Time) (bool, error) {
 	return true, nil
 }
 
-// LocalIP return the request client ip.
+// LocalIP return the request client ip. The Forwarded, X-Forwarded-For and
+// X-Real-Ip headers are only honored for the trusted proxies, see
+// SetTrustedProxies.
 func (ctx *Context) LocalIP() string {
-	xForwardedFor := ctx.Request.Header.Get("X-Forwarded-For")
-	ip := strings.TrimSpace(strings.Split(xForwardedFor, ",")[0])
-	if ip != "" {
-		return ip
-	}
+	remote := ctx.remoteIP()
 
-	ip = strings.TrimSpace(ctx.Request.Header.Get("X-Real-Ip"))
-	if ip != "" {
-		return ip
+	if isTrustedProxy(remote) {
+		if ip := ctx.forwardedFor(); ip != "" {
+			return ip
+		}
+		if ip := strings.TrimSpace(ctx.Request.Header.Get("X-Real-Ip")); ip != "" {
+			return ip
+		}
 	}
 
-	if ip, _, err := net.SplitHostPort(strings.TrimSpace(ctx.Request.RemoteAddr)); err == nil {
-		return ip
+	if remote != "" {
+		return remote
 	}
 
 	return "127.0.0.1"
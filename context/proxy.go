@@ -0,0 +1,208 @@
+package context
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+)
+
+// proxyList 是可信的反向代理的网段
+//
+// 字段说明：
+//   - set: 是否配置了可信代理，未配置时信任所有来源的X-Forwarded-For等客户端IP头，与配置前的行为一致
+//   - nets: 可信代理的网段
+type proxyList struct {
+	set  bool
+	nets []*net.IPNet
+}
+
+// trustedProxies 保存当前的可信代理，见SetTrustedProxies
+var trustedProxies atomic.Value
+
+// SetTrustedProxies 设置可信的反向代理
+//
+// 参数说明：
+//   - proxies: 代理的IP或CIDR，如"10.0.0.1"、"10.0.0.0/8"
+//
+// 返回值：
+//   - error: 存在无法解析的IP或CIDR时返回错误，此时不修改当前设置
+//
+// 工作原理：
+//   - 只有来自可信代理的请求，才采用Forwarded和X-Forwarded-*头中的客户端IP、协议和主机
+//   - 未设置任何代理时信任所有来源的客户端IP头，与配置前的行为一致，
+//     但忽略协议和主机头，防止客户端伪造跳转地址、绝对URL和cookie的Secure属性
+func SetTrustedProxies(proxies []string) error {
+	list := proxyList{set: len(proxies) > 0}
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return fmt.Errorf("invalid trusted proxy %q", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				bits = 8 * net.IPv4len
+			}
+			list.nets = append(list.nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return fmt.Errorf("invalid trusted proxy %q", proxy)
+		}
+		list.nets = append(list.nets, n)
+	}
+	trustedProxies.Store(list)
+	return nil
+}
+
+// isTrustedProxy 判断IP是否为可信代理
+func isTrustedProxy(ip string) bool {
+	list, _ := trustedProxies.Load().(proxyList)
+	if !list.set {
+		return true
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, n := range list.nets {
+		if n.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteIP 返回直接连接的对端IP，无法解析时返回空字符串
+func (ctx *Context) remoteIP() string {
+	ip, _, err := net.SplitHostPort(strings.TrimSpace(ctx.Request.RemoteAddr))
+	if err != nil {
+		return ""
+	}
+	return ip
+}
+
+// FromTrustedProxy 判断请求是否来自可信代理，见SetTrustedProxies
+func (ctx *Context) FromTrustedProxy() bool {
+	return isTrustedProxy(ctx.remoteIP())
+}
+
+// fromConfiguredProxy 判断请求是否来自明确配置的可信代理，未配置可信代理时返回false
+func (ctx *Context) fromConfiguredProxy() bool {
+	list, _ := trustedProxies.Load().(proxyList)
+	return list.set && ctx.FromTrustedProxy()
+}
+
+// forwardedElements 返回Forwarded头（RFC 7239）中的所有元素，每个元素是参数名到值的映射，参数名为小写
+func (ctx *Context) forwardedElements() []map[string]string {
+	var elements []map[string]string
+	for _, header := range ctx.Request.Header.Values("Forwarded") {
+		for _, element := range strings.Split(header, ",") {
+			params := make(map[string]string)
+			for _, pair := range strings.Split(element, ";") {
+				key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if ok {
+					params[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(value), `"`)
+				}
+			}
+			elements = append(elements, params)
+		}
+	}
+	return elements
+}
+
+// forwardedNodeIP 返回Forwarded头中for参数的IP，去掉端口和IPv6的方括号，未知或混淆的节点返回空字符串
+func forwardedNodeIP(node string) string {
+	if strings.HasPrefix(node, "[") {
+		if end := strings.Index(node, "]"); end > 0 {
+			node = node[1:end]
+		}
+	} else if host, _, err := net.SplitHostPort(node); err == nil {
+		node = host
+	}
+	if net.ParseIP(node) == nil {
+		return ""
+	}
+	return node
+}
+
+// forwardedFor 返回转发链中的客户端IP
+//
+// 工作原理：
+//   - 优先使用Forwarded头，否则使用X-Forwarded-For头，链中依次为客户端和经过的代理
+//   - 配置了可信代理时从右向左跳过可信代理，第一个不可信的IP即为客户端，防止客户端伪造
+//   - 未配置可信代理时返回最左边的IP
+func (ctx *Context) forwardedFor() string {
+	var chain []string
+	if elements := ctx.forwardedElements(); len(elements) > 0 {
+		for _, element := range elements {
+			chain = append(chain, forwardedNodeIP(element["for"]))
+		}
+	} else if header := ctx.Request.Header.Get("X-Forwarded-For"); header != "" {
+		for _, ip := range strings.Split(header, ",") {
+			chain = append(chain, strings.TrimSpace(ip))
+		}
+	}
+	if len(chain) == 0 {
+		return ""
+	}
+
+	if list, _ := trustedProxies.Load().(proxyList); list.set {
+		for i := len(chain) - 1; i >= 0; i-- {
+			if chain[i] != "" && !isTrustedProxy(chain[i]) {
+				return chain[i]
+			}
+		}
+	}
+	return chain[0]
+}
+
+// Scheme 返回客户端请求使用的协议，"https"或"http"
+//
+// 来自明确配置的可信代理的请求采用Forwarded头的proto参数或X-Forwarded-Proto头
+func (ctx *Context) Scheme() string {
+	if ctx.fromConfiguredProxy() {
+		proto := ""
+		if elements := ctx.forwardedElements(); len(elements) > 0 {
+			proto = elements[0]["proto"]
+		}
+		if proto == "" {
+			proto = strings.TrimSpace(strings.Split(ctx.Request.Header.Get("X-Forwarded-Proto"), ",")[0])
+		}
+		if proto = strings.ToLower(proto); proto == "https" || proto == "http" {
+			return proto
+		}
+	}
+	if ctx.Request.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// IsSecure 判断客户端是否通过https请求，见Scheme
+func (ctx *Context) IsSecure() bool {
+	return ctx.Scheme() == "https"
+}
+
+// Host 返回客户端请求的主机，可能包含端口
+//
+// 来自明确配置的可信代理的请求采用Forwarded头的host参数或X-Forwarded-Host头
+func (ctx *Context) Host() string {
+	if ctx.fromConfiguredProxy() {
+		if elements := ctx.forwardedElements(); len(elements) > 0 && elements[0]["host"] != "" {
+			return elements[0]["host"]
+		}
+		if host := strings.TrimSpace(strings.Split(ctx.Request.Header.Get("X-Forwarded-Host"), ",")[0]); host != "" {
+			return host
+		}
+	}
+	return ctx.Request.Host
+}
+
+// BaseURL 返回客户端请求的协议和主机，如"https://admin.example.com"
+func (ctx *Context) BaseURL() string {
+	return ctx.Scheme() + "://" + ctx.Host()
+}
@@ -0,0 +1,108 @@
+package context
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func newProxyRequest(remote string, headers map[string]string) *Context {
+	req := &http.Request{RemoteAddr: remote, Host: "127.0.0.1:9033", Header: make(http.Header)}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return NewContext(req)
+}
+
+func TestLocalIP(t *testing.T) {
+	defer func() { _ = SetTrustedProxies(nil) }()
+
+	// without the trusted proxies, the headers of all the peers are honored.
+	assert.Equal(t, SetTrustedProxies(nil), nil)
+	assert.Equal(t, newProxyRequest("10.0.0.1:5000", nil).LocalIP(), "10.0.0.1")
+	assert.Equal(t, newProxyRequest("", nil).LocalIP(), "127.0.0.1")
+	assert.Equal(t, newProxyRequest("10.0.0.1:5000", map[string]string{
+		"X-Forwarded-For": "1.1.1.1, 2.2.2.2",
+	}).LocalIP(), "1.1.1.1")
+	assert.Equal(t, newProxyRequest("10.0.0.1:5000", map[string]string{
+		"X-Real-Ip": "1.1.1.1",
+	}).LocalIP(), "1.1.1.1")
+
+	assert.Equal(t, SetTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"}), nil)
+
+	// the headers of the untrusted peers are ignored.
+	assert.Equal(t, newProxyRequest("3.3.3.3:5000", map[string]string{
+		"X-Forwarded-For": "1.1.1.1",
+		"X-Real-Ip":       "1.1.1.1",
+	}).LocalIP(), "3.3.3.3")
+
+	// the chain is walked from the right, skipping the trusted proxies, so the
+	// ip forged by the client on the left is ignored.
+	assert.Equal(t, newProxyRequest("192.168.1.1:5000", map[string]string{
+		"X-Forwarded-For": "6.6.6.6, 1.1.1.1, 10.0.0.2",
+	}).LocalIP(), "1.1.1.1")
+	assert.Equal(t, newProxyRequest("10.0.0.1:5000", map[string]string{
+		"Forwarded": `for=6.6.6.6, for="[2001:db8::1]:4711";proto=https, for=10.0.0.2`,
+	}).LocalIP(), "2001:db8::1")
+	assert.Equal(t, newProxyRequest("10.0.0.1:5000", map[string]string{
+		"X-Real-Ip": "1.1.1.1",
+	}).LocalIP(), "1.1.1.1")
+
+	assert.Equal(t, SetTrustedProxies([]string{"10.0.0"}) != nil, true)
+	assert.Equal(t, SetTrustedProxies([]string{"10.0.0.0/33"}) != nil, true)
+}
+
+func TestSchemeAndHost(t *testing.T) {
+	defer func() { _ = SetTrustedProxies(nil) }()
+
+	ctx := newProxyRequest("10.0.0.1:5000", nil)
+	assert.Equal(t, ctx.Scheme(), "http")
+	assert.Equal(t, ctx.BaseURL(), "http://127.0.0.1:9033")
+	ctx.Request.TLS = &tls.ConnectionState{}
+	assert.Equal(t, ctx.IsSecure(), true)
+
+	headers := map[string]string{
+		"X-Forwarded-Proto": "https",
+		"X-Forwarded-Host":  "admin.example.com",
+	}
+
+	// without the trusted proxies, the scheme and the host headers are ignored.
+	assert.Equal(t, SetTrustedProxies(nil), nil)
+	assert.Equal(t, newProxyRequest("10.0.0.1:5000", headers).BaseURL(), "http://127.0.0.1:9033")
+
+	assert.Equal(t, SetTrustedProxies([]string{"10.0.0.0/8"}), nil)
+	assert.Equal(t, newProxyRequest("10.0.0.1:5000", headers).BaseURL(), "https://admin.example.com")
+	assert.Equal(t, newProxyRequest("10.0.0.1:5000", map[string]string{
+		"Forwarded":         `proto=https;host="admin.example.com:8443"`,
+		"X-Forwarded-Proto": "http",
+	}).BaseURL(), "https://admin.example.com:8443")
+	assert.Equal(t, newProxyRequest("3.3.3.3:5000", headers).BaseURL(), "http://127.0.0.1:9033")
+}
+
+func TestRedirectBehindProxy(t *testing.T) {
+	defer func() { _ = SetTrustedProxies(nil) }()
+	assert.Equal(t, SetTrustedProxies([]string{"10.0.0.0/8"}), nil)
+
+	headers := map[string]string{
+		"X-Forwarded-Proto": "https",
+		"X-Forwarded-Host":  "admin.example.com",
+	}
+
+	ctx := newProxyRequest("10.0.0.1:5000", headers)
+	ctx.Redirect("http://127.0.0.1:9033/admin/login?ref=1")
+	assert.Equal(t, ctx.Response.Header.Get("Location"), "https://admin.example.com/admin/login?ref=1")
+
+	ctx = newProxyRequest("10.0.0.1:5000", headers)
+	ctx.Redirect("/admin/login")
+	assert.Equal(t, ctx.Response.Header.Get("Location"), "/admin/login")
+
+	ctx = newProxyRequest("10.0.0.1:5000", headers)
+	ctx.Redirect("http://127.0.0.1:90330/admin")
+	assert.Equal(t, ctx.Response.Header.Get("Location"), "http://127.0.0.1:90330/admin")
+
+	ctx = newProxyRequest("3.3.3.3:5000", headers)
+	ctx.Redirect("http://127.0.0.1:9033/admin/login")
+	assert.Equal(t, ctx.Response.Header.Get("Location"), "http://127.0.0.1:9033/admin/login")
+}
@@ -0,0 +1,53 @@
+// Package data embeds the database files of GoAdmin, so the tools and the
+// tests of the downstream projects can create the databases without the
+// source tree of GoAdmin.
+package data
+
+import (
+	"embed"
+	"path"
+	"sort"
+	"strings"
+)
+
+// SQLiteDatabase is the initial sqlite database with the admin and operator
+// users, which includes the migrations up to SQLiteDatabaseVersion.
+//
+//go:embed admin.db
+var SQLiteDatabase []byte
+
+// SQLiteDatabaseVersion is the version of the last migration applied to
+// SQLiteDatabase.
+const SQLiteDatabaseVersion = "2020_08_04_092427"
+
+//go:embed migrations/*_sqlite.sql
+var migrations embed.FS
+
+// SQLiteMigrations return the statements of the sqlite migrations newer than
+// the version, ordered by the version.
+func SQLiteMigrations(version string) ([]string, error) {
+	entries, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		// admin_2006_01_02_150405_sqlite.sql
+		v := strings.TrimSuffix(strings.TrimPrefix(entry.Name(), "admin_"), "_sqlite.sql")
+		if v > version {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	res := make([]string, 0, len(names))
+	for _, name := range names {
+		content, err := migrations.ReadFile(path.Join("migrations", name))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, string(content))
+	}
+	return res, nil
+}
@@ -0,0 +1,7 @@
+CREATE TABLE goadmin_event_outbox (
+  id bigint IDENTITY(1,1) PRIMARY KEY,
+  topic varchar(255) NOT NULL DEFAULT '',
+  event_key varchar(255) NOT NULL DEFAULT '',
+  payload nvarchar(max) NOT NULL,
+  created_at datetime DEFAULT GETDATE()
+);
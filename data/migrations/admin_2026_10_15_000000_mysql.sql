@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS `goadmin_event_outbox` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `topic` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `event_key` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `payload` text COLLATE utf8mb4_unicode_ci NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS goadmin_event_outbox (
+    id bigserial PRIMARY KEY,
+    topic character varying(255) NOT NULL DEFAULT '',
+    event_key character varying(255) NOT NULL DEFAULT '',
+    payload text NOT NULL,
+    created_at timestamp without time zone DEFAULT now()
+);
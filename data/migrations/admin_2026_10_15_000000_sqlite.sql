@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS `goadmin_event_outbox` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `topic` varchar(255) NOT NULL DEFAULT '',
+  `event_key` varchar(255) NOT NULL DEFAULT '',
+  `payload` text NOT NULL,
+  `created_at` timestamp DEFAULT CURRENT_TIMESTAMP
+);
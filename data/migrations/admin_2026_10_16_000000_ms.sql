@@ -0,0 +1,7 @@
+CREATE TABLE goadmin_ldap_users (
+  id int IDENTITY(1,1) PRIMARY KEY,
+  user_id int NOT NULL UNIQUE,
+  dn varchar(500) NOT NULL DEFAULT '',
+  active tinyint NOT NULL DEFAULT 1,
+  synced_at datetime DEFAULT GETDATE()
+);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS goadmin_ldap_users (
+    id serial PRIMARY KEY,
+    user_id integer NOT NULL UNIQUE,
+    dn character varying(500) NOT NULL DEFAULT '',
+    active smallint NOT NULL DEFAULT 1,
+    synced_at timestamp without time zone DEFAULT now()
+);
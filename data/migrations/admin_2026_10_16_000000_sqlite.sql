@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS `goadmin_ldap_users` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `user_id` integer NOT NULL UNIQUE,
+  `dn` varchar(500) NOT NULL DEFAULT '',
+  `active` integer NOT NULL DEFAULT 1,
+  `synced_at` timestamp DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,9 @@
+CREATE TABLE goadmin_jwt_refresh_tokens (
+  id int IDENTITY(1,1) PRIMARY KEY,
+  user_id int NOT NULL,
+  token_hash char(64) NOT NULL UNIQUE,
+  expires_at bigint NOT NULL,
+  created_at datetime DEFAULT GETDATE()
+);
+
+CREATE INDEX goadmin_jwt_refresh_tokens_user_id_index ON goadmin_jwt_refresh_tokens (user_id);
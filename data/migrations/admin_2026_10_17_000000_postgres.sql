@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS goadmin_jwt_refresh_tokens (
+    id serial PRIMARY KEY,
+    user_id integer NOT NULL,
+    token_hash character(64) NOT NULL UNIQUE,
+    expires_at bigint NOT NULL,
+    created_at timestamp without time zone DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS goadmin_jwt_refresh_tokens_user_id_index ON goadmin_jwt_refresh_tokens (user_id);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS `goadmin_jwt_refresh_tokens` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `user_id` integer NOT NULL,
+  `token_hash` char(64) NOT NULL UNIQUE,
+  `expires_at` integer NOT NULL,
+  `created_at` timestamp DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS `goadmin_jwt_refresh_tokens_user_id_index` ON `goadmin_jwt_refresh_tokens` (`user_id`);
@@ -0,0 +1,9 @@
+CREATE TABLE goadmin_feature_flags (
+  id int IDENTITY(1,1) PRIMARY KEY,
+  name varchar(100) NOT NULL,
+  env varchar(20) NOT NULL DEFAULT '',
+  enabled tinyint NOT NULL DEFAULT 0,
+  percentage tinyint NOT NULL DEFAULT 100,
+  updated_at datetime DEFAULT GETDATE(),
+  UNIQUE (name, env)
+);
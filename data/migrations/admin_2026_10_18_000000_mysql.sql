@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS `goadmin_feature_flags` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `env` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `enabled` tinyint(1) NOT NULL DEFAULT 0,
+  `percentage` tinyint(3) unsigned NOT NULL DEFAULT 100,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `admin_feature_flags_name_env_unique` (`name`,`env`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS goadmin_feature_flags (
+    id serial PRIMARY KEY,
+    name character varying(100) NOT NULL,
+    env character varying(20) NOT NULL DEFAULT '',
+    enabled smallint NOT NULL DEFAULT 0,
+    percentage smallint NOT NULL DEFAULT 100,
+    updated_at timestamp without time zone DEFAULT now(),
+    UNIQUE (name, env)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS `goadmin_feature_flags` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `name` varchar(100) NOT NULL,
+  `env` varchar(20) NOT NULL DEFAULT '',
+  `enabled` integer NOT NULL DEFAULT 0,
+  `percentage` integer NOT NULL DEFAULT 100,
+  `updated_at` timestamp DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (`name`, `env`)
+);
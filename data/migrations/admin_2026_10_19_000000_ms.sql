@@ -0,0 +1,19 @@
+CREATE TABLE goadmin_announcements (
+  id int IDENTITY(1,1) PRIMARY KEY,
+  title nvarchar(255) NOT NULL,
+  content nvarchar(max) NOT NULL,
+  level varchar(20) NOT NULL DEFAULT 'info',
+  roles varchar(255) NOT NULL DEFAULT '',
+  start_at datetime NULL,
+  end_at datetime NULL,
+  created_at datetime DEFAULT GETDATE(),
+  updated_at datetime DEFAULT GETDATE()
+);
+
+CREATE TABLE goadmin_announcement_dismissals (
+  id int IDENTITY(1,1) PRIMARY KEY,
+  announcement_id int NOT NULL,
+  user_id int NOT NULL,
+  created_at datetime DEFAULT GETDATE(),
+  UNIQUE (announcement_id, user_id)
+);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS `goadmin_announcements` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `title` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `content` text COLLATE utf8mb4_unicode_ci NOT NULL,
+  `level` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'info',
+  `roles` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `start_at` timestamp NULL DEFAULT NULL,
+  `end_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `goadmin_announcement_dismissals` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `announcement_id` int(10) unsigned NOT NULL,
+  `user_id` int(10) unsigned NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `admin_announcement_dismissals_unique` (`announcement_id`,`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
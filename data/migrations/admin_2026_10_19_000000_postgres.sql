@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS goadmin_announcements (
+    id serial PRIMARY KEY,
+    title character varying(255) NOT NULL,
+    content text NOT NULL,
+    level character varying(20) NOT NULL DEFAULT 'info',
+    roles character varying(255) NOT NULL DEFAULT '',
+    start_at timestamp without time zone,
+    end_at timestamp without time zone,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS goadmin_announcement_dismissals (
+    id serial PRIMARY KEY,
+    announcement_id integer NOT NULL,
+    user_id integer NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    UNIQUE (announcement_id, user_id)
+);
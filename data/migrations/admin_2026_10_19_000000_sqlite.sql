@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS `goadmin_announcements` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `title` varchar(255) NOT NULL,
+  `content` text NOT NULL,
+  `level` varchar(20) NOT NULL DEFAULT 'info',
+  `roles` varchar(255) NOT NULL DEFAULT '',
+  `start_at` timestamp DEFAULT NULL,
+  `end_at` timestamp DEFAULT NULL,
+  `created_at` timestamp DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS `goadmin_announcement_dismissals` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `announcement_id` integer NOT NULL,
+  `user_id` integer NOT NULL,
+  `created_at` timestamp DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (`announcement_id`, `user_id`)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE goadmin_favorites (
+  id int IDENTITY(1,1) PRIMARY KEY,
+  user_id int NOT NULL,
+  prefix varchar(100) NOT NULL,
+  record_id varchar(100) NOT NULL,
+  title nvarchar(255) NOT NULL DEFAULT '',
+  created_at datetime DEFAULT GETDATE(),
+  UNIQUE (user_id, prefix, record_id)
+);
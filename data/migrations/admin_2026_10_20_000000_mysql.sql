@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS `goadmin_favorites` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` int(10) unsigned NOT NULL,
+  `prefix` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `record_id` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `title` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `admin_favorites_unique` (`user_id`,`prefix`,`record_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS goadmin_favorites (
+    id serial PRIMARY KEY,
+    user_id integer NOT NULL,
+    prefix character varying(100) NOT NULL,
+    record_id character varying(100) NOT NULL,
+    title character varying(255) NOT NULL DEFAULT '',
+    created_at timestamp without time zone DEFAULT now(),
+    UNIQUE (user_id, prefix, record_id)
+);
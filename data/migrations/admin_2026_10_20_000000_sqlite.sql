@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS `goadmin_favorites` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `user_id` integer NOT NULL,
+  `prefix` varchar(100) NOT NULL,
+  `record_id` varchar(100) NOT NULL,
+  `title` varchar(255) NOT NULL DEFAULT '',
+  `created_at` timestamp DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (`user_id`, `prefix`, `record_id`)
+);
@@ -0,0 +1,6 @@
+CREATE TABLE goadmin_seeds (
+  id int IDENTITY(1,1) PRIMARY KEY,
+  name varchar(100) NOT NULL,
+  ran_at datetime DEFAULT GETDATE(),
+  UNIQUE (name)
+);
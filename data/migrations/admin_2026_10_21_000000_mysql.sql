@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS `goadmin_seeds` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `ran_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `admin_seeds_name_unique` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
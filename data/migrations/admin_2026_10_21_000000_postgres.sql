@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS goadmin_seeds (
+    id serial PRIMARY KEY,
+    name character varying(100) NOT NULL,
+    ran_at timestamp without time zone DEFAULT now(),
+    UNIQUE (name)
+);
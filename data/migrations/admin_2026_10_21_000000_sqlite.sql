@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS `goadmin_seeds` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `name` varchar(100) NOT NULL,
+  `ran_at` timestamp DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (`name`)
+);
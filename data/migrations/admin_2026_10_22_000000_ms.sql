@@ -0,0 +1,12 @@
+CREATE TABLE goadmin_usage (
+  id int IDENTITY(1,1) PRIMARY KEY,
+  day date NOT NULL,
+  user_id int NOT NULL,
+  kind varchar(10) NOT NULL,
+  prefix varchar(100) NOT NULL DEFAULT '',
+  action varchar(50) NOT NULL DEFAULT '',
+  path varchar(255) NOT NULL DEFAULT '',
+  hits int NOT NULL DEFAULT 0
+);
+
+CREATE INDEX goadmin_usage_day_index ON goadmin_usage (day);
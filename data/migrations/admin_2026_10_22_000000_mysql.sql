@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `goadmin_usage` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `day` date NOT NULL,
+  `user_id` int(10) unsigned NOT NULL,
+  `kind` varchar(10) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `prefix` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `action` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `path` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `hits` int(10) unsigned NOT NULL DEFAULT 0,
+  PRIMARY KEY (`id`),
+  KEY `goadmin_usage_day_index` (`day`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
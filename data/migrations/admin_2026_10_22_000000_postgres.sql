@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS goadmin_usage (
+    id serial PRIMARY KEY,
+    day date NOT NULL,
+    user_id integer NOT NULL,
+    kind character varying(10) NOT NULL,
+    prefix character varying(100) NOT NULL DEFAULT '',
+    action character varying(50) NOT NULL DEFAULT '',
+    path character varying(255) NOT NULL DEFAULT '',
+    hits integer NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS goadmin_usage_day_index ON goadmin_usage (day);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `goadmin_usage` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `day` date NOT NULL,
+  `user_id` integer NOT NULL,
+  `kind` varchar(10) NOT NULL,
+  `prefix` varchar(100) NOT NULL DEFAULT '',
+  `action` varchar(50) NOT NULL DEFAULT '',
+  `path` varchar(255) NOT NULL DEFAULT '',
+  `hits` integer NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS `goadmin_usage_day_index` ON `goadmin_usage` (`day`);
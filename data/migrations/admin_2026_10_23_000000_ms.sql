@@ -0,0 +1,15 @@
+CREATE TABLE goadmin_reports (
+  id int IDENTITY(1,1) PRIMARY KEY,
+  user_id int NOT NULL,
+  name varchar(100) NOT NULL,
+  prefix varchar(100) NOT NULL,
+  query text,
+  schedule varchar(100) NOT NULL,
+  recipients text,
+  last_run_at datetime NULL,
+  next_run_at datetime NULL,
+  last_error varchar(255) NOT NULL DEFAULT '',
+  created_at datetime DEFAULT GETDATE()
+);
+
+CREATE INDEX goadmin_reports_next_run_at_index ON goadmin_reports (next_run_at);
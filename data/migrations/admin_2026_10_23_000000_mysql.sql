@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS `goadmin_reports` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` int(10) unsigned NOT NULL,
+  `name` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `prefix` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `query` text COLLATE utf8mb4_unicode_ci,
+  `schedule` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `recipients` text COLLATE utf8mb4_unicode_ci,
+  `last_run_at` timestamp NULL DEFAULT NULL,
+  `next_run_at` timestamp NULL DEFAULT NULL,
+  `last_error` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `goadmin_reports_next_run_at_index` (`next_run_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
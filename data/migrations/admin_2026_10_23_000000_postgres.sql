@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS goadmin_reports (
+    id serial PRIMARY KEY,
+    user_id integer NOT NULL,
+    name character varying(100) NOT NULL,
+    prefix character varying(100) NOT NULL,
+    query text,
+    schedule character varying(100) NOT NULL,
+    recipients text,
+    last_run_at timestamp without time zone,
+    next_run_at timestamp without time zone,
+    last_error character varying(255) NOT NULL DEFAULT '',
+    created_at timestamp without time zone DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS goadmin_reports_next_run_at_index ON goadmin_reports (next_run_at);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS `goadmin_reports` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `user_id` integer NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `prefix` varchar(100) NOT NULL,
+  `query` text,
+  `schedule` varchar(100) NOT NULL,
+  `recipients` text,
+  `last_run_at` datetime,
+  `next_run_at` datetime,
+  `last_error` varchar(255) NOT NULL DEFAULT '',
+  `created_at` datetime DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS `goadmin_reports_next_run_at_index` ON `goadmin_reports` (`next_run_at`);
@@ -0,0 +1,19 @@
+CREATE TABLE goadmin_quality_rules (
+  id int IDENTITY(1,1) PRIMARY KEY,
+  name varchar(100) NOT NULL,
+  table_name varchar(100) NOT NULL,
+  kind varchar(20) NOT NULL,
+  field varchar(100) NOT NULL DEFAULT '',
+  expression text,
+  threshold int NOT NULL DEFAULT 0,
+  schedule varchar(100) NOT NULL,
+  owners text,
+  last_count int NOT NULL DEFAULT 0,
+  last_status varchar(20) NOT NULL DEFAULT '',
+  last_error varchar(255) NOT NULL DEFAULT '',
+  last_run_at datetime NULL,
+  next_run_at datetime NULL,
+  created_at datetime DEFAULT GETDATE()
+);
+
+CREATE INDEX goadmin_quality_rules_next_run_at_index ON goadmin_quality_rules (next_run_at);
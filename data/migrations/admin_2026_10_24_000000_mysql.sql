@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS `goadmin_quality_rules` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `table_name` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `kind` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `field` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `expression` text COLLATE utf8mb4_unicode_ci,
+  `threshold` int(10) unsigned NOT NULL DEFAULT 0,
+  `schedule` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `owners` text COLLATE utf8mb4_unicode_ci,
+  `last_count` int(10) unsigned NOT NULL DEFAULT 0,
+  `last_status` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `last_error` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `last_run_at` timestamp NULL DEFAULT NULL,
+  `next_run_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `goadmin_quality_rules_next_run_at_index` (`next_run_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS goadmin_quality_rules (
+    id serial PRIMARY KEY,
+    name character varying(100) NOT NULL,
+    table_name character varying(100) NOT NULL,
+    kind character varying(20) NOT NULL,
+    field character varying(100) NOT NULL DEFAULT '',
+    expression text,
+    threshold integer NOT NULL DEFAULT 0,
+    schedule character varying(100) NOT NULL,
+    owners text,
+    last_count integer NOT NULL DEFAULT 0,
+    last_status character varying(20) NOT NULL DEFAULT '',
+    last_error character varying(255) NOT NULL DEFAULT '',
+    last_run_at timestamp without time zone,
+    next_run_at timestamp without time zone,
+    created_at timestamp without time zone DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS goadmin_quality_rules_next_run_at_index ON goadmin_quality_rules (next_run_at);
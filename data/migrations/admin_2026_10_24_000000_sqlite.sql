@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS `goadmin_quality_rules` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `name` varchar(100) NOT NULL,
+  `table_name` varchar(100) NOT NULL,
+  `kind` varchar(20) NOT NULL,
+  `field` varchar(100) NOT NULL DEFAULT '',
+  `expression` text,
+  `threshold` integer NOT NULL DEFAULT 0,
+  `schedule` varchar(100) NOT NULL,
+  `owners` text,
+  `last_count` integer NOT NULL DEFAULT 0,
+  `last_status` varchar(20) NOT NULL DEFAULT '',
+  `last_error` varchar(255) NOT NULL DEFAULT '',
+  `last_run_at` datetime,
+  `next_run_at` datetime,
+  `created_at` datetime DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS `goadmin_quality_rules_next_run_at_index` ON `goadmin_quality_rules` (`next_run_at`);
@@ -0,0 +1,49 @@
+// 版权所有 2019 GoAdmin 核心团队。保留所有权利。
+// 本源代码的使用受 Apache-2.0 风格许可证管辖
+// 该许可证可在 LICENSE 文件中找到。
+
+package engine
+
+import (
+	"net/http"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/listener"
+	"github.com/purpose168/GoAdmin/modules/logger"
+)
+
+// ServeAutoTLS 以Let's Encrypt自动签发的证书通过https提供服务，适用于不使用反向代理的小型部署
+//
+// 参数说明：
+//   - handler：已通过Use注册了后台路由的路由器，如http.ServeMux、gin.Engine
+//
+// 返回值：
+//   - error：配置无效或监听失败时返回错误，否则一直阻塞
+//
+// 工作原理：
+//   - 读取配置的AutoTLS，只为其中的Domains签发证书，证书缓存在CacheDir，默认为Store目录下的autocert
+//   - 在Addr（默认":443"）上提供https服务，证书通过tls-alpn-01验证签发
+//   - HTTPAddr（默认":80"）不为"-"时，在其上响应http-01验证，并将其他http请求重定向到https
+//
+// 使用示例：
+//
+//	if err := eng.AddConfig(&cfg).Use(r); err != nil {
+//		panic(err)
+//	}
+//	log.Fatal(eng.ServeAutoTLS(r))
+func (eng *Engine) ServeAutoTLS(handler http.Handler) error {
+	cfg := config.GetAutoTLS()
+	m, err := listener.AutocertManager(cfg.Domains, cfg.Email, cfg.CacheDir)
+	if err != nil {
+		return err
+	}
+	if cfg.HTTPAddr != "-" {
+		go func() {
+			if err := listener.ServeRedirect(m, cfg.HTTPAddr); err != nil {
+				logger.Error("serve the http redirect error: ", err)
+			}
+		}()
+	}
+	logger.Info("serving https on " + cfg.Addr)
+	return listener.ServeTLS(m, cfg.Addr, handler)
+}
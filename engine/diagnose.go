@@ -0,0 +1,300 @@
+// 版权所有 2019 GoAdmin 核心团队。保留所有权利。
+// 本源代码的使用受 Apache-2.0 风格许可证管辖
+// 该许可证可在 LICENSE 文件中找到。
+
+package engine
+
+import (
+	"fmt"
+	template2 "html/template"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/system"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// 诊断结果的级别
+const (
+	DiagnosisWarning = "warning"
+	DiagnosisError   = "error"
+)
+
+// Diagnosis 是启动诊断发现的一个问题
+//
+// 字段说明：
+//   - Check：检查项，如"database"、"store"、"theme"、"tables"、"security"
+//   - Level：级别，DiagnosisWarning或DiagnosisError
+//   - Message：问题说明
+//   - Advice：建议的处理方式
+type Diagnosis struct {
+	Check   string
+	Level   string
+	Message string
+	Advice  string
+}
+
+// systemTables 是后台必需的系统表，用户表见AuthUserTable
+var systemTables = []string{
+	"goadmin_menu", "goadmin_operation_log", "goadmin_permissions", "goadmin_role_menu",
+	"goadmin_role_permissions", "goadmin_role_users", "goadmin_roles", "goadmin_session",
+	"goadmin_site", "goadmin_user_permissions",
+}
+
+// Diagnose 检查配置是否合理，返回发现的问题，并以警告打印到日志
+//
+// 返回值：
+//   - []Diagnosis：发现的问题，没有问题时为空
+//
+// 检查项：
+//   - database：配置的数据库是否可以连接
+//   - store：上传文件的目录是否可写
+//   - theme：主题与GoAdmin的版本是否兼容
+//   - tables：默认数据库中是否缺少系统表
+//   - security：生产环境是否开启了调试模式，初始用户是否仍使用默认密码
+//   - prefix：菜单和权限保存的路径是否带有当前或以前的路由前缀，见models.MigratePrefix
+//
+// 注意事项：
+//   - Use 会在启动时调用一次，也可以通过 AddDiagnosePage 注册的页面随时查看
+//   - 诊断只读取数据，不会修改配置或数据库
+func (eng *Engine) Diagnose() []Diagnosis {
+	var res []Diagnosis
+	res = append(res, eng.diagnoseDatabases()...)
+	res = append(res, diagnoseStore(eng.config.Store)...)
+	res = append(res, diagnoseTheme()...)
+	res = append(res, eng.diagnoseTables()...)
+	res = append(res, eng.diagnoseSecurity()...)
+	res = append(res, eng.diagnosePrefix()...)
+
+	for _, d := range res {
+		logger.Warnf("[diagnose] %s: %s %s", d.Check, d.Message, d.Advice)
+	}
+	return res
+}
+
+// diagnoseDatabases 检查所有配置的数据库是否可以连接
+func (eng *Engine) diagnoseDatabases() []Diagnosis {
+	names := make([]string, 0, len(eng.config.Databases))
+	for name := range eng.config.Databases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var res []Diagnosis
+	for _, name := range names {
+		srv, ok := eng.Services.GetOrNot(eng.config.Databases[name].Driver)
+		if !ok {
+			continue
+		}
+		sqlDB := db.GetConnectionFromService(srv).GetDB(name)
+		if sqlDB == nil {
+			continue
+		}
+		if err := sqlDB.Ping(); err != nil {
+			res = append(res, Diagnosis{
+				Check:   "database",
+				Level:   DiagnosisError,
+				Message: fmt.Sprintf(language.Get("database %s is unreachable: %s"), name, err),
+				Advice:  language.Get("check the address, the account and the network of the database"),
+			})
+		}
+	}
+	return res
+}
+
+// diagnoseStore 检查上传文件的目录是否存在且可写
+func diagnoseStore(store config.Store) []Diagnosis {
+	if store.Path == "" {
+		return nil
+	}
+	advice := language.Get("create the directory of the store path and make it writable")
+	info, err := os.Stat(store.Path)
+	if err != nil || !info.IsDir() {
+		return []Diagnosis{{
+			Check:   "store",
+			Level:   DiagnosisError,
+			Message: fmt.Sprintf(language.Get("store path %s is not a directory"), store.Path),
+			Advice:  advice,
+		}}
+	}
+	f, err := os.CreateTemp(store.Path, ".goadmin-diagnose-*")
+	if err != nil {
+		return []Diagnosis{{
+			Check:   "store",
+			Level:   DiagnosisError,
+			Message: fmt.Sprintf(language.Get("store path %s is not writable"), store.Path),
+			Advice:  advice,
+		}}
+	}
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+	return nil
+}
+
+// diagnoseTheme 检查主题与GoAdmin的版本是否兼容
+func diagnoseTheme() []Diagnosis {
+	sysCheck, themeCheck := template.CheckRequirements()
+	if sysCheck && themeCheck {
+		return nil
+	}
+	return []Diagnosis{{
+		Check: "theme",
+		Level: DiagnosisError,
+		Message: fmt.Sprintf(language.Get("theme %s %s is not compatible with goadmin %s"),
+			template.Default().Name(), template.Default().GetVersion(), system.Version()),
+		Advice: language.Get("upgrade the theme and goadmin to the compatible versions"),
+	}}
+}
+
+// diagnoseTables 检查默认数据库中是否缺少系统表
+func (eng *Engine) diagnoseTables() []Diagnosis {
+	if _, ok := eng.config.Databases["default"]; !ok {
+		return nil
+	}
+	if _, ok := eng.Services.GetOrNot(eng.config.Databases.GetDefault().Driver); !ok {
+		return nil
+	}
+	conn := eng.DefaultConnection()
+
+	var missing []string
+	for _, table := range append([]string{eng.config.AuthUserTable}, systemTables...) {
+		if _, err := db.WithDriver(conn).Table(table).Select("count(*)").First(); err != nil {
+			missing = append(missing, table)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return []Diagnosis{{
+		Check:   "tables",
+		Level:   DiagnosisError,
+		Message: fmt.Sprintf(language.Get("system tables are missing: %s"), strings.Join(missing, ", ")),
+		Advice:  language.Get("import the sql file of the database driver from the data directory"),
+	}}
+}
+
+// diagnoseSecurity 检查不安全的配置
+func (eng *Engine) diagnoseSecurity() []Diagnosis {
+	var res []Diagnosis
+	if eng.config.Debug && eng.config.IsProductionEnvironment() {
+		res = append(res, Diagnosis{
+			Check:   "security",
+			Level:   DiagnosisWarning,
+			Message: language.Get("debug mode is on in the production environment"),
+			Advice:  language.Get("turn off the debug mode, it shows the statements of the pages"),
+		})
+	}
+
+	if _, ok := eng.config.Databases["default"]; !ok {
+		return res
+	}
+	if _, ok := eng.Services.GetOrNot(eng.config.Databases.GetDefault().Driver); !ok {
+		return res
+	}
+	for _, name := range []string{"admin", "operator"} {
+		user := models.User().SetConn(eng.DefaultConnection()).FindByUserName(name)
+		if auth.HasDefaultPassword(user) {
+			res = append(res, Diagnosis{
+				Check:   "security",
+				Level:   DiagnosisWarning,
+				Message: fmt.Sprintf(language.Get("user %s has the default password"), name),
+				Advice:  language.Get("change the password of the user"),
+			})
+		}
+	}
+	return res
+}
+
+// diagnosePrefix 检查菜单和权限保存的路径是否带有路由前缀
+//
+// 保存的路径应相对于路由前缀，带有前缀的路径在修改前缀后失效；
+// 以前的前缀配置在FormerUrlPrefixes中时仍然有效，但仍建议迁移
+func (eng *Engine) diagnosePrefix() []Diagnosis {
+	if _, ok := eng.config.Databases["default"]; !ok {
+		return nil
+	}
+	if _, ok := eng.Services.GetOrNot(eng.config.Databases.GetDefault().Driver); !ok {
+		return nil
+	}
+	entries, err := models.PrefixedEntries(eng.DefaultConnection())
+	if err != nil || len(entries) == 0 {
+		return nil
+	}
+	list := make([]string, len(entries))
+	for i, e := range entries {
+		list[i] = fmt.Sprintf("%s #%d %s: %s => %s", e.Table, e.Id, e.Name,
+			strings.ReplaceAll(e.Path, "\n", ","), strings.ReplaceAll(e.Fixed, "\n", ","))
+	}
+	return []Diagnosis{{
+		Check:   "prefix",
+		Level:   DiagnosisWarning,
+		Message: fmt.Sprintf(language.Get("paths stored with the url prefix: %s"), strings.Join(list, "; ")),
+		Advice:  language.Get("remove the url prefix from the paths, or call models.MigratePrefix"),
+	}}
+}
+
+// AddDiagnosePage 注册启动诊断的页面，位于路由前缀下的 /diagnose
+//
+// 返回值：
+//   - *Engine：引擎实例，支持链式调用
+//
+// 注意事项：
+//   - 页面需要登录，并按路径检查权限，建议只授予超级管理员
+//   - 每次打开页面都会重新诊断，见Diagnose
+func (eng *Engine) AddDiagnosePage() *Engine {
+	if eng.Adapter == nil {
+		emptyAdapterPanic()
+	}
+	eng.HTML(http.MethodGet, config.Url("/diagnose"), func(ctx *context.Context) (types.Panel, error) {
+		return diagnosePanel(ctx, eng.Diagnose()), nil
+	})
+	return eng
+}
+
+// diagnosePanel 返回诊断结果的页面
+func diagnosePanel(ctx *context.Context, res []Diagnosis) types.Panel {
+	panel := types.Panel{
+		Title:       template2.HTML(language.Get("diagnose")),
+		Description: template2.HTML(language.Get("configuration check")),
+	}
+	if len(res) == 0 {
+		panel.Content = template.Default(ctx).Alert().SetTheme("success").
+			SetTitle(template2.HTML(language.Get("no problem found"))).GetContent()
+		return panel
+	}
+
+	thead := types.Thead{
+		{Head: language.Get("check"), Field: "check"},
+		{Head: language.Get("level"), Field: "level"},
+		{Head: language.Get("problem"), Field: "message"},
+		{Head: language.Get("advice"), Field: "advice"},
+	}
+	rows := make([]map[string]types.InfoItem, len(res))
+	for i, d := range res {
+		label := "warning"
+		if d.Level == DiagnosisError {
+			label = "danger"
+		}
+		rows[i] = map[string]types.InfoItem{
+			"check": {Content: template2.HTML(template2.HTMLEscapeString(d.Check))},
+			"level": {Content: template2.HTML(`<span class="label label-` + label + `">` +
+				template2.HTMLEscapeString(language.Get(d.Level)) + `</span>`)},
+			"message": {Content: template2.HTML(template2.HTMLEscapeString(d.Message))},
+			"advice":  {Content: template2.HTML(template2.HTMLEscapeString(d.Advice))},
+		}
+	}
+	panel.Content = template.Default(ctx).Box().WithHeadBorder().SetNoPadding().
+		SetBody(template.Default(ctx).Table().SetThead(thead).SetInfoList(rows).SetMinWidth("0.01%").GetContent()).
+		GetContent()
+	return panel
+}
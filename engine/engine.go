@@ -63,22 +63,28 @@ import (
 
 	"github.com/purpose168/GoAdmin/adapter"
 	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/analytics"
 	"github.com/purpose168/GoAdmin/modules/auth"
 	"github.com/purpose168/GoAdmin/modules/config"
 	"github.com/purpose168/GoAdmin/modules/db"
 	"github.com/purpose168/GoAdmin/modules/errors"
 	"github.com/purpose168/GoAdmin/modules/logger"
 	"github.com/purpose168/GoAdmin/modules/menu"
+	"github.com/purpose168/GoAdmin/modules/redis"
 	"github.com/purpose168/GoAdmin/modules/service"
 	"github.com/purpose168/GoAdmin/modules/system"
 	"github.com/purpose168/GoAdmin/modules/ui"
 	"github.com/purpose168/GoAdmin/plugins"
 	"github.com/purpose168/GoAdmin/plugins/admin"
 	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/openapi"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
 	"github.com/purpose168/GoAdmin/template"
 	"github.com/purpose168/GoAdmin/template/types"
+
+	// 引擎总是提供组件的JavaScript运行时
+	_ "github.com/purpose168/GoAdmin/template/runtime"
 )
 
 // Engine 是 GoAdmin 的核心组件
@@ -97,6 +103,9 @@ import (
 //   - NavButtons：导航栏按钮，显示在页面顶部导航栏
 //   - config：配置对象，存储全局配置信息
 //   - announceLock：同步锁，用于确保公告只打印一次
+//   - htmlFiles：HTMLFile和HTMLFiles解析后的模板缓存
+//   - htmlSandbox：HTMLFile和HTMLFiles是否使用沙箱模式，见HTMLSandbox
+//   - templateFuncs：AddTemplateFunc添加的模板函数
 type Engine struct {
 	PluginList   plugins.Plugins
 	Adapter      adapter.WebFrameWork
@@ -104,6 +113,12 @@ type Engine struct {
 	NavButtons   *types.Buttons
 	config       *config.Config
 	announceLock sync.Once
+
+	htmlFiles        htmlFileCache
+	htmlCache        htmlCache
+	htmlSandbox      bool
+	htmlSandboxFuncs template2.FuncMap
+	templateFuncs    template2.FuncMap
 }
 
 // Default 返回默认的引擎实例
@@ -140,8 +155,9 @@ func Default() *Engine {
 //  3. 初始化站点设置
 //  4. 初始化跳转导航按钮
 //  5. 初始化插件
-//  6. 打印初始化成功消息
-//  7. 调用适配器的Use方法，将插件列表注入到框架中
+//  6. 诊断配置，发现的问题打印为警告，见Diagnose
+//  7. 打印初始化成功消息
+//  8. 调用适配器的Use方法，将插件列表注入到框架中
 func (eng *Engine) Use(router interface{}) error {
 	if eng.Adapter == nil {
 		emptyAdapterPanic()
@@ -151,6 +167,7 @@ func (eng *Engine) Use(router interface{}) error {
 	eng.initSiteSetting()
 	eng.initJumpNavButtons()
 	eng.initPlugins()
+	eng.Diagnose()
 
 	printInitMsg(language.Get("initialize success"))
 
@@ -229,6 +246,27 @@ func (eng *Engine) FindPluginByName(name string) (plugins.Plugin, bool) {
 	return nil, false
 }
 
+// Close 关闭引擎
+//
+// 返回值：
+//   - error：第一个关闭失败的插件的错误
+//
+// 工作原理：
+//
+//	调用实现了plugins.Closer的插件的Close方法，停止插件在后台运行的服务，
+//	如admin插件的gRPC服务，应在HTTP服务器关闭后调用
+func (eng *Engine) Close() error {
+	var closeErr error
+	for _, plug := range eng.PluginList {
+		if closer, ok := plug.(plugins.Closer); ok {
+			if err := closer.Close(); err != nil && closeErr == nil {
+				closeErr = err
+			}
+		}
+	}
+	return closeErr
+}
+
 // AddAuthService 使用给定的回调函数自定义认证逻辑
 //
 // 参数：
@@ -246,6 +284,42 @@ func (eng *Engine) AddAuthService(processor auth.Processor) *Engine {
 	return eng
 }
 
+// SetJWT 启用JWT会话模式，供API客户端使用
+//
+// 参数：
+//   - jwt：JWT服务
+//
+// 返回值：
+//   - *Engine：引擎实例，支持链式调用
+//
+// 工作原理：
+//
+//	将JWT服务添加到服务列表中，admin插件会注册令牌签发、刷新、吊销和JWKS路由，
+//	eng.Data等需要认证的路由同时接受Bearer令牌和Cookie会话。需在Use之前调用
+func (eng *Engine) SetJWT(jwt *auth.JWT) *Engine {
+	eng.Services.Add(auth.JWTServiceKey, jwt)
+	return eng
+}
+
+// AddWebhookAuthService 使用外部HTTP接口进行认证
+//
+// 参数：
+//   - cfg：webhook认证配置
+//
+// 返回值：
+//   - *Engine：引擎实例，支持链式调用
+//
+// 工作原理：
+//
+//	将登录表单的用户名和密码POST到配置的接口，并将返回的用户和角色同步到goadmin_users，
+//	支持结果缓存，接口不可用时可回退到本地密码校验
+func (eng *Engine) AddWebhookAuthService(cfg auth.WebhookConfig) *Engine {
+	w := auth.NewWebhook(cfg)
+	return eng.AddAuthService(func(ctx *context.Context) (models.UserModel, bool, string) {
+		return w.Auth(ctx, db.GetConnection(eng.Services))
+	})
+}
+
 // ============================
 // 配置 API
 // ============================
@@ -281,7 +355,7 @@ func (eng *Engine) announce() *Engine {
 //  2. 打印初始化公告
 //  3. 初始化数据库连接
 func (eng *Engine) AddConfig(cfg *config.Config) *Engine {
-	return eng.setConfig(cfg).announce().initDatabase()
+	return eng.setConfig(cfg).announce().initDatabase().initRedis()
 }
 
 // setConfig 设置引擎的配置
@@ -329,7 +403,7 @@ func (eng *Engine) setConfig(cfg *config.Config) *Engine {
 //  4. 初始化数据库连接
 func (eng *Engine) AddConfigFromJSON(path string) *Engine {
 	cfg := config.ReadFromJson(path)
-	return eng.setConfig(&cfg).announce().initDatabase()
+	return eng.setConfig(&cfg).announce().initDatabase().initRedis()
 }
 
 // AddConfigFromYAML 从YAML文件设置全局配置
@@ -347,7 +421,7 @@ func (eng *Engine) AddConfigFromJSON(path string) *Engine {
 //  4. 初始化数据库连接
 func (eng *Engine) AddConfigFromYAML(path string) *Engine {
 	cfg := config.ReadFromYaml(path)
-	return eng.setConfig(&cfg).announce().initDatabase()
+	return eng.setConfig(&cfg).announce().initDatabase().initRedis()
 }
 
 // AddConfigFromINI 从INI文件设置全局配置
@@ -365,7 +439,7 @@ func (eng *Engine) AddConfigFromYAML(path string) *Engine {
 //  4. 初始化数据库连接
 func (eng *Engine) AddConfigFromINI(path string) *Engine {
 	cfg := config.ReadFromINI(path)
-	return eng.setConfig(&cfg).announce().initDatabase()
+	return eng.setConfig(&cfg).announce().initDatabase().initRedis()
 }
 
 // InitDatabase 初始化所有数据库连接
@@ -394,6 +468,28 @@ func (eng *Engine) initDatabase() *Engine {
 	return eng
 }
 
+// initRedis 初始化共享的Redis客户端
+//
+// 工作原理：
+//   - 未配置Redis地址时直接返回
+//   - 创建Redis服务并添加到服务列表中，供HTML缓存、会话、锁和插件共用
+//   - 会话改为保存在Redis中，不再写入goadmin_session表
+//   - 创建失败时记录错误并继续使用数据库会话，不会中断启动
+func (eng *Engine) initRedis() *Engine {
+	if !eng.config.Redis.Enabled() {
+		return eng
+	}
+	printInitMsg(language.Get("initialize redis"))
+	srv, err := redis.NewService(eng.config.Redis)
+	if err != nil {
+		logger.Error("initialize redis error: ", err)
+		return eng
+	}
+	eng.Services.Add(redis.ServiceKey, srv)
+	auth.UseRedisSession(srv)
+	return eng
+}
+
 // AddAdapter 添加引擎的适配器
 //
 // 参数：
@@ -686,6 +782,7 @@ func (eng *Engine) deferHandler(conn db.Connection) context.Handler {
 
 				models.OperationLog().SetConn(conn).New(user.Id, ctx.Path(), ctx.Method(), ctx.LocalIP(), string(input))
 			}
+			analytics.Track(ctx)
 
 			if err := recover(); err != nil {
 				logger.Error(err)
@@ -722,13 +819,17 @@ func (eng *Engine) deferHandler(conn db.Connection) context.Handler {
 // wrapWithAuthMiddleware 将认证中间件包装到给定的处理器中
 func (eng *Engine) wrapWithAuthMiddleware(handler context.Handler) context.Handlers {
 	conn := db.GetConnection(eng.Services)
-	return []context.Handler{eng.deferHandler(conn), response.OffLineHandler, auth.Middleware(conn), handler}
+	authMiddleware := auth.Middleware(conn)
+	if jwt, ok := auth.GetJWTServiceOrNot(eng.Services); ok {
+		authMiddleware = jwt.Middleware(conn)
+	}
+	return []context.Handler{eng.deferHandler(conn), response.OffLineHandler, response.DemoHandler, authMiddleware, handler}
 }
 
 // wrap 将处理器包装到中间件链中（不包含认证中间件）
 func (eng *Engine) wrap(handler context.Handler) context.Handlers {
 	conn := db.GetConnection(eng.Services)
-	return []context.Handler{eng.deferHandler(conn), response.OffLineHandler, handler}
+	return []context.Handler{eng.deferHandler(conn), response.OffLineHandler, response.DemoHandler, handler}
 }
 
 // ============================
@@ -869,6 +970,7 @@ func (eng *Engine) initNavJumpButtonParams() []navJumpButtonParam {
 // initSiteSetting 初始化站点设置
 //
 // 工作原理：
+//   - 无状态模式下跳过数据库，直接使用文件和环境变量中的配置
 //   - 从数据库加载站点配置
 //   - 使用配置初始化Site模型
 //   - 将配置更新到数据库
@@ -882,6 +984,12 @@ func (eng *Engine) initSiteSetting() {
 
 	printInitMsg(language.Get("initialize configuration"))
 
+	if eng.config.IsStateless() {
+		eng.Services.Add("config", config.SrvWithConfig(eng.config))
+		errors.Init()
+		return
+	}
+
 	err := eng.config.Update(models.Site().
 		SetConn(eng.DefaultConnection()).
 		Init(eng.config.ToMap()).
@@ -966,6 +1074,7 @@ func Content(ctx interface{}, panel types.GetPanelFn) {
 //   - 如果noAuth为true，使用wrap包装处理器（不需要认证）
 //   - 否则使用wrapWithAuthMiddleware包装处理器（需要认证）
 //   - 将处理器添加到适配器
+//   - 记录路由，用于生成 /admin/openapi.json 的OpenAPI文档
 //
 // 使用场景：
 //   - 注册API路由
@@ -978,6 +1087,7 @@ func Content(ctx interface{}, panel types.GetPanelFn) {
 //	})
 //	eng.Data("POST", "/api/data", handler, true) // 不需要认证
 func (eng *Engine) Data(method, url string, handler context.Handler, noAuth ...bool) {
+	openapi.AddRoute(openapi.Route{Method: method, Path: url, Auth: len(noAuth) == 0 || !noAuth[0]})
 	if len(noAuth) > 0 && noAuth[0] {
 		eng.Adapter.AddHandler(method, url, eng.wrap(handler))
 	} else {
@@ -1021,29 +1131,7 @@ func (eng *Engine) HTML(method, url string, fn types.GetPanelInfoFn, noAuth ...b
 
 		eng.AdminPlugin().GetAddOperationFn()(panel.Callbacks...)
 
-		var (
-			tmpl, tmplName = template.Default(ctx).GetTemplate(ctx.IsPjax())
-
-			user = auth.Auth(ctx)
-			buf  = new(bytes.Buffer)
-		)
-
-		hasError := tmpl.ExecuteTemplate(buf, tmplName, types.NewPage(ctx, &types.NewPageParam{
-			User:         user,
-			Menu:         menu.GetGlobalMenu(user, eng.Adapter.GetConnection(), ctx.Lang()).SetActiveClass(config.URLRemovePrefix(ctx.Path())),
-			Panel:        panel.GetContent(eng.config.IsProductionEnvironment()),
-			Assets:       template.GetComponentAssetImportHTML(ctx),
-			Buttons:      eng.NavButtons.CheckPermission(user),
-			TmplHeadHTML: template.Default(ctx).GetHeadHTML(),
-			TmplFootJS:   template.Default(ctx).GetFootJS(),
-			Iframe:       ctx.IsIframe(),
-		}))
-
-		if hasError != nil {
-			logger.Error(fmt.Sprintf("错误：%s 适配器内容，", eng.Adapter.Name()), hasError)
-		}
-
-		ctx.HTMLByte(http.StatusOK, buf.Bytes())
+		eng.renderPanel(ctx, panel)
 	}
 
 	if len(noAuth) > 0 && noAuth[0] {
@@ -1053,6 +1141,34 @@ func (eng *Engine) HTML(method, url string, fn types.GetPanelInfoFn, noAuth ...b
 	}
 }
 
+// renderPanel 将面板渲染为完整的页面并返回HTML，菜单和导航按钮按当前用户生成
+func (eng *Engine) renderPanel(ctx *context.Context, panel types.Panel) {
+	var (
+		tmpl, tmplName = template.Default(ctx).GetTemplate(ctx.IsPjax())
+
+		user = auth.Auth(ctx)
+		buf  = context.GetBuffer()
+	)
+
+	hasError := tmpl.ExecuteTemplate(buf, tmplName, types.NewPage(ctx, &types.NewPageParam{
+		User:         user,
+		Menu:         menu.GetGlobalMenu(user, eng.Adapter.GetConnection(), ctx.Lang()).SetActiveClass(config.URLRemovePrefix(ctx.Path())),
+		Panel:        panel.GetContent(eng.config.IsProductionEnvironment()),
+		Assets:       template.GetComponentAssetImportHTML(ctx),
+		Buttons:      eng.NavButtons.CheckPermission(user),
+		TmplHeadHTML: template.Default(ctx).GetHeadHTML(),
+		TmplFootJS:   template.Default(ctx).GetFootJS(),
+		Iframe:       ctx.IsIframe(),
+	}))
+
+	if hasError != nil {
+		logger.Error(fmt.Sprintf("错误：%s 适配器内容，", eng.Adapter.Name()), hasError)
+	}
+
+	template.AddPreloadHeader(ctx)
+	ctx.HTMLBuffer(http.StatusOK, buf)
+}
+
 // HTMLFile将路由和对应的处理器注入到Web框架，处理器返回给定HTML文件路径的面板内容
 //
 // 参数说明：
@@ -1064,7 +1180,8 @@ func (eng *Engine) HTML(method, url string, fn types.GetPanelInfoFn, noAuth ...b
 //
 // 工作原理：
 //   - 创建处理器函数
-//   - 解析HTML文件模板
+//   - 解析HTML文件模板，解析结果会被缓存，文件修改后重新解析
+//   - 沙箱模式下模板数据只在首次渲染时转换一次
 //   - 执行模板并将结果写入缓冲区
 //   - 如果出错则显示错误面板
 //   - 获取模板和用户信息
@@ -1082,45 +1199,7 @@ func (eng *Engine) HTML(method, url string, fn types.GetPanelInfoFn, noAuth ...b
 //	})
 func (eng *Engine) HTMLFile(method, url, path string, data map[string]interface{}, noAuth ...bool) {
 
-	var handler = func(ctx *context.Context) {
-
-		cbuf := new(bytes.Buffer)
-
-		t, err := template2.ParseFiles(path)
-		if err != nil {
-			eng.errorPanelHTML(ctx, cbuf, err)
-			return
-		} else if err := t.Execute(cbuf, data); err != nil {
-			eng.errorPanelHTML(ctx, cbuf, err)
-			return
-		}
-
-		var (
-			tmpl, tmplName = template.Default(ctx).GetTemplate(ctx.IsPjax())
-
-			user = auth.Auth(ctx)
-			buf  = new(bytes.Buffer)
-		)
-
-		hasError := tmpl.ExecuteTemplate(buf, tmplName, types.NewPage(ctx, &types.NewPageParam{
-			User: user,
-			Menu: menu.GetGlobalMenu(user, eng.Adapter.GetConnection(), ctx.Lang()).SetActiveClass(eng.config.URLRemovePrefix(ctx.Path())),
-			Panel: types.Panel{
-				Content: template.HTML(cbuf.String()),
-			},
-			Assets:       template.GetComponentAssetImportHTML(ctx),
-			Buttons:      eng.NavButtons.CheckPermission(user),
-			TmplHeadHTML: template.Default(ctx).GetHeadHTML(),
-			TmplFootJS:   template.Default(ctx).GetFootJS(),
-			Iframe:       ctx.IsIframe(),
-		}))
-
-		if hasError != nil {
-			logger.Error(fmt.Sprintf("错误：%s 适配器内容，", eng.Adapter.Name()), hasError)
-		}
-
-		ctx.HTMLByte(http.StatusOK, buf.Bytes())
-	}
+	var handler = eng.htmlFilesHandler(data, path)
 
 	if len(noAuth) > 0 && noAuth[0] {
 		eng.Adapter.AddHandler(method, url, eng.wrap(handler))
@@ -1191,7 +1270,8 @@ func (eng *Engine) HTMLFilesNoAuth(method, url string, data map[string]interface
 //   - context.Handler: 处理器函数
 //
 // 工作原理：
-//   - 解析HTML文件模板
+//   - 解析HTML文件模板，解析结果会被缓存，文件修改后重新解析
+//   - 沙箱模式下模板数据只在首次渲染时转换一次
 //   - 执行模板并将结果写入缓冲区
 //   - 如果出错则显示错误面板
 //   - 获取模板和用户信息
@@ -1201,16 +1281,14 @@ func (eng *Engine) HTMLFilesNoAuth(method, url string, data map[string]interface
 //   - 内部方法，由HTMLFiles和HTMLFilesNoAuth调用
 //   - 创建HTML文件处理器
 func (eng *Engine) htmlFilesHandler(data map[string]interface{}, files ...string) context.Handler {
+	fileData := &htmlFileData{data: data}
 	return func(ctx *context.Context) {
 
-		cbuf := new(bytes.Buffer)
+		cbuf := context.GetBuffer()
+		defer context.PutBuffer(cbuf)
 
-		t, err := template2.ParseFiles(files...)
-		if err != nil {
-			eng.errorPanelHTML(ctx, cbuf, err)
-			return
-		} else if err := t.Execute(cbuf, data); err != nil {
-			eng.errorPanelHTML(ctx, cbuf, err)
+		if err := eng.executeHTMLFiles(cbuf, fileData, files...); err != nil {
+			eng.errorPanelHTML(ctx, new(bytes.Buffer), err)
 			return
 		}
 
@@ -1218,7 +1296,7 @@ func (eng *Engine) htmlFilesHandler(data map[string]interface{}, files ...string
 			tmpl, tmplName = template.Default(ctx).GetTemplate(ctx.IsPjax())
 
 			user = auth.Auth(ctx)
-			buf  = new(bytes.Buffer)
+			buf  = context.GetBuffer()
 		)
 
 		hasError := tmpl.ExecuteTemplate(buf, tmplName, types.NewPage(ctx, &types.NewPageParam{
@@ -1238,7 +1316,7 @@ func (eng *Engine) htmlFilesHandler(data map[string]interface{}, files ...string
 			logger.Error(fmt.Sprintf("错误：%s 适配器内容，", eng.Adapter.Name()), hasError)
 		}
 
-		ctx.HTMLByte(http.StatusOK, buf.Bytes())
+		ctx.HTMLBuffer(http.StatusOK, buf)
 	}
 }
 
@@ -1420,6 +1498,142 @@ func (eng *Engine) AddGenerator(key string, g table.Generator) *Engine {
 	return eng
 }
 
+// RegisterGenerator 在运行时添加表格模型生成器，可在Use之后调用
+//
+// 参数说明：
+//   - key: 生成器键名
+//   - g: 生成器对象
+//   - menuTitle: 可选，列表页菜单的标题
+//
+// 返回值：
+//   - error: 创建菜单失败时返回错误
+//
+// 工作原理：
+//   - 生成器列表由读写锁保护，运行时添加是线程安全的
+//   - 给出菜单标题且列表页菜单不存在时，创建顶级菜单
+//
+// 使用场景：
+//   - 之后加载的插件添加表格
+//   - 多租户应用动态添加租户的表格
+//
+// 使用示例：
+//
+//	err := eng.RegisterGenerator("tenant_orders", tenant.GetOrdersTable, "订单")
+func (eng *Engine) RegisterGenerator(key string, g table.Generator, menuTitle ...string) error {
+	return eng.AdminPlugin().RegisterGenerator(key, g, menuTitle...)
+}
+
+// AutoMenu 为所有已注册的生成器自动创建或更新列表页的菜单
+//
+// 参数说明：
+//   - options: 可选，菜单的图标、默认分类、排除的生成器和授权的角色
+//
+// 返回值：
+//   - *Engine: 返回Engine本身，支持链式调用
+//
+// 工作原理：
+//   - 菜单标题为表格信息面板的标题，未设置时为生成器键名
+//   - 按信息面板通过SetCategory设置的分类，将菜单归入同名的顶级菜单，顶级菜单不存在时创建
+//   - 列表页菜单已存在时，只更新标题和上级菜单，保留手动调整的图标和排序
+//   - 在Use之前调用时，在admin插件初始化时同步菜单；同步失败时记录错误日志
+//
+// 使用场景：
+//   - 新增表格后无需手动在后台添加菜单，菜单与代码保持一致
+//
+// 使用示例：
+//
+//	eng.AddGenerators(datamodel.Generators).
+//	    AutoMenu(admin.AutoMenuOptions{DefaultCategory: "数据", Roles: []string{"operator"}})
+func (eng *Engine) AutoMenu(options ...admin.AutoMenuOptions) *Engine {
+	if err := eng.AdminPlugin().AutoMenu(options...); err != nil {
+		logger.Error("auto menu error: ", err)
+	}
+	return eng
+}
+
+// AddPage 注册自定义页面，同时创建页面的路由、菜单和权限
+//
+// 参数说明：
+//   - page: 页面的路径、标题、图标、上级菜单标题、权限标识和处理函数
+//
+// 返回值：
+//   - *Engine: 返回Engine本身，支持链式调用
+//
+// 工作原理：
+//   - 路径不含URL前缀，路由为加上前缀后的路径，需要登录访问
+//   - 菜单项按路径查找，不存在时创建，已存在时只更新标题和上级菜单
+//   - 上级菜单按标题查找顶级菜单，不存在时创建
+//   - 权限按标识查找，不存在时创建，HTTP路径为页面路径；已存在的权限保持不变
+//   - 菜单和权限在同一事务中写入，重复启动不会产生重复的记录；写入失败时记录错误日志
+//   - 需要在Use之后调用
+//
+// 使用示例：
+//
+//	eng.AddPage(admin.Page{
+//	    Path:       "/reports/sales",
+//	    Title:      "销售报表",
+//	    Icon:       icon.LineChart,
+//	    MenuParent: "报表",
+//	    Permission: "sales_report",
+//	    Handler:    reports.GetSalesContent,
+//	})
+func (eng *Engine) AddPage(page admin.Page) *Engine {
+	if err := eng.AdminPlugin().AddPage(page); err != nil {
+		logger.Error("add page error: ", err)
+		return eng
+	}
+	eng.HTML(http.MethodGet, config.Url(page.Path), page.Handler)
+	return eng
+}
+
+// UnregisterGenerator 在运行时移除表格模型生成器
+//
+// 参数说明：
+//   - key: 生成器键名
+//
+// 返回值：
+//   - error: 删除菜单失败时返回错误
+//
+// 工作原理：
+//   - 从生成器列表中移除，之后该表格的页面返回404
+//   - 删除列表页的菜单及其角色关联
+func (eng *Engine) UnregisterGenerator(key string) error {
+	return eng.AdminPlugin().UnregisterGenerator(key)
+}
+
+// AddGeneratorsFromDir 从目录中的JSON文件加载表格定义并添加生成器
+//
+// 参数说明：
+//   - dir: JSON文件所在目录
+//
+// 返回值：
+//   - *Engine: 返回Engine本身，支持链式调用
+//
+// 工作原理：
+//   - 按文件名顺序读取目录中的*.json文件，每个文件为一个table.Definition
+//   - 定义的名称为空时使用文件名（不含扩展名）
+//   - 名称与已添加的生成器相同时，修改该生成器表格的字段标题、可见性和筛选等设置
+//   - 否则根据定义创建新的生成器
+//   - 文件读取或定义检查失败时panic
+//
+// 使用场景：
+//   - 非Go开发人员无需重新编译即可调整表格
+//
+// 使用示例：
+//
+//	eng.AddGenerators(datamodel.Generators).
+//	    AddGeneratorsFromDir("./tables")
+//
+// 需在AddGenerators之后调用，表格的定义可以从后台的/definition/:__prefix导出
+func (eng *Engine) AddGeneratorsFromDir(dir string) *Engine {
+	defs, err := table.LoadDefinitions(dir)
+	if err != nil {
+		panic(err)
+	}
+	eng.AdminPlugin().AddDefinitions(defs...)
+	return eng
+}
+
 // AddGlobalDisplayProcessFn 调用types.AddGlobalDisplayProcessFn
 //
 // 参数说明：
@@ -0,0 +1,302 @@
+// 版权所有 2019 GoAdmin 核心团队。保留所有权利。
+// 本源代码的使用受 Apache-2.0 风格许可证管辖
+// 该许可证可在 LICENSE 文件中找到。
+
+package engine
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	template2 "html/template"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	context2 "github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/redis"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// CacheVary 是HTMLCached区分缓存的依据
+type CacheVary string
+
+const (
+	// VaryByUser 按用户区分缓存，面板内容与当前用户有关时使用
+	VaryByUser CacheVary = "user"
+	// VaryByRole 按角色区分缓存，角色相同的用户共用缓存
+	VaryByRole CacheVary = "role"
+	// VaryByLang 按语言区分缓存
+	VaryByLang CacheVary = "lang"
+	// VaryByQuery 按查询参数区分缓存
+	VaryByQuery CacheVary = "query"
+)
+
+// htmlCacheMaxItems 是内存中缓存的最多面板数
+const htmlCacheMaxItems = 10000
+
+// HTMLCached 与HTML相同，但将面板缓存指定的时间，缓存有效时不再调用fn
+//
+// 参数：
+//   - method：HTTP方法
+//   - url：路由路径
+//   - ttl：缓存时间
+//   - fn：面板信息生成函数
+//   - varyBy：区分缓存的依据，未指定时所有用户共用同一缓存
+//
+// 工作原理：
+//   - 缓存的是fn返回的面板，页面布局在每次请求时渲染，菜单和导航栏仍按当前用户生成
+//   - 配置了Redis时缓存保存在Redis中，多个实例共用；否则保存在内存中
+//   - fn返回错误的面板、含有回调或导航按钮的面板不缓存
+//   - 缓存按url区分，可以通过PurgeHTMLCache手动清除
+//   - 需要认证，需在Use之后调用
+//
+// 使用示例：
+//
+//	eng.HTMLCached("GET", "/admin/dashboard", time.Minute*5, datamodel.GetContent, engine.VaryByRole, engine.VaryByLang)
+func (eng *Engine) HTMLCached(method, url string, ttl time.Duration, fn types.GetPanelInfoFn, varyBy ...CacheVary) {
+	if srv, ok := redis.GetServiceOrNot(eng.Services); ok {
+		eng.htmlCache.redis = srv
+	}
+
+	var handler = func(ctx *context2.Context) {
+		key := eng.htmlCache.key(url, ctx, varyBy)
+		if panel, ok := eng.htmlCache.get(key); ok {
+			eng.renderPanel(ctx, panel)
+			return
+		}
+
+		panel, err := fn(ctx)
+		if err != nil {
+			panel = template.WarningPanel(ctx, err.Error())
+		} else if len(panel.Callbacks) == 0 && len(panel.NavButtons) == 0 {
+			eng.htmlCache.set(key, panel, ttl)
+		}
+
+		eng.AdminPlugin().GetAddOperationFn()(panel.Callbacks...)
+
+		eng.renderPanel(ctx, panel)
+	}
+
+	eng.Adapter.AddHandler(method, url, eng.wrapWithAuthMiddleware(handler))
+}
+
+// PurgeHTMLCache 清除HTMLCached缓存的面板
+//
+// 参数：
+//   - urls：注册HTMLCached时的路由路径，未指定时清除所有缓存
+//
+// 返回值：
+//   - *Engine：引擎实例，支持链式调用
+//
+// 使用场景：
+//   - 页面依赖的数据更新后立即生效，如在表格的PostHook中调用
+func (eng *Engine) PurgeHTMLCache(urls ...string) *Engine {
+	eng.htmlCache.purge(urls...)
+	return eng
+}
+
+// htmlCache 缓存HTMLCached的面板，按url的版本号失效，清除时更新版本号
+type htmlCache struct {
+	lock     sync.Mutex
+	items    map[string]htmlCacheItem
+	versions map[string]string
+	redis    *redis.Service
+}
+
+type htmlCacheItem struct {
+	data   []byte
+	expire time.Time
+}
+
+// cachedPanel 是面板中可以缓存的部分
+type cachedPanel struct {
+	Title           template2.HTML `json:"title"`
+	Description     template2.HTML `json:"description"`
+	Content         template2.HTML `json:"content"`
+	CSS             template2.CSS  `json:"css"`
+	JS              template2.JS   `json:"js"`
+	Url             string         `json:"url"`
+	MiniSidebar     bool           `json:"mini_sidebar"`
+	AutoRefresh     bool           `json:"auto_refresh"`
+	RefreshInterval []int          `json:"refresh_interval"`
+	BrowserTitle    string         `json:"browser_title"`
+}
+
+// versionKey 返回url的版本号的键，url为空时为全部缓存的版本号
+func (c *htmlCache) versionKey(url string) string {
+	if url == "" {
+		return c.redis.Key("html_cache", "version")
+	}
+	return c.redis.Key("html_cache", "version", url)
+}
+
+// version 返回全部缓存和url的版本号
+func (c *htmlCache) version(url string) string {
+	if c.redis == nil {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		return c.versions[""] + ":" + c.versions[url]
+	}
+	values, err := c.redis.MGet(context.Background(), c.versionKey(""), c.versionKey(url))
+	if err != nil {
+		logger.Error("html cache version error: ", err)
+		return ""
+	}
+	parts := make([]string, len(values))
+	for i, v := range values {
+		if s, ok := v.(string); ok {
+			parts[i] = s
+		}
+	}
+	return strings.Join(parts, ":")
+}
+
+// key 返回请求的缓存键
+func (c *htmlCache) key(url string, ctx *context2.Context, varyBy []CacheVary) string {
+	parts := []string{c.version(url)}
+	for _, vary := range varyBy {
+		switch vary {
+		case VaryByUser:
+			parts = append(parts, "u="+strconv.FormatInt(auth.Auth(ctx).Id, 10))
+		case VaryByRole:
+			roles := auth.Auth(ctx).Roles
+			slugs := make([]string, len(roles))
+			for i, role := range roles {
+				slugs[i] = role.Slug
+			}
+			sort.Strings(slugs)
+			parts = append(parts, "r="+strings.Join(slugs, ","))
+		case VaryByLang:
+			parts = append(parts, "l="+ctx.Lang())
+		case VaryByQuery:
+			parts = append(parts, "q="+ctx.Request.URL.Query().Encode())
+		}
+	}
+	sum := sha1.Sum([]byte(strings.Join(parts, "\x00")))
+	return url + "\x00" + hex.EncodeToString(sum[:])
+}
+
+func (c *htmlCache) get(key string) (types.Panel, bool) {
+	var data []byte
+	if c.redis != nil {
+		v, err := c.redis.Get(context.Background(), c.redis.Key("html_cache", key))
+		if err != nil {
+			if err != redis.Nil {
+				logger.Error("html cache get error: ", err)
+			}
+			return types.Panel{}, false
+		}
+		data = v
+	} else {
+		c.lock.Lock()
+		item, ok := c.items[key]
+		c.lock.Unlock()
+		if !ok || time.Now().After(item.expire) {
+			return types.Panel{}, false
+		}
+		data = item.data
+	}
+
+	var p cachedPanel
+	if err := json.Unmarshal(data, &p); err != nil {
+		return types.Panel{}, false
+	}
+	return types.Panel{
+		Title:           p.Title,
+		Description:     p.Description,
+		Content:         p.Content,
+		CSS:             p.CSS,
+		JS:              p.JS,
+		Url:             p.Url,
+		MiniSidebar:     p.MiniSidebar,
+		AutoRefresh:     p.AutoRefresh,
+		RefreshInterval: p.RefreshInterval,
+		BrowserTitle:    p.BrowserTitle,
+	}, true
+}
+
+func (c *htmlCache) set(key string, panel types.Panel, ttl time.Duration) {
+	data, err := json.Marshal(cachedPanel{
+		Title:           panel.Title,
+		Description:     panel.Description,
+		Content:         panel.Content,
+		CSS:             panel.CSS,
+		JS:              panel.JS,
+		Url:             panel.Url,
+		MiniSidebar:     panel.MiniSidebar,
+		AutoRefresh:     panel.AutoRefresh,
+		RefreshInterval: panel.RefreshInterval,
+		BrowserTitle:    panel.BrowserTitle,
+	})
+	if err != nil {
+		return
+	}
+
+	if c.redis != nil {
+		if err := c.redis.Set(context.Background(), c.redis.Key("html_cache", key), data, ttl); err != nil {
+			logger.Error("html cache set error: ", err)
+		}
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.items == nil {
+		c.items = make(map[string]htmlCacheItem)
+	}
+	if len(c.items) >= htmlCacheMaxItems {
+		now := time.Now()
+		for k, item := range c.items {
+			if now.After(item.expire) {
+				delete(c.items, k)
+			}
+		}
+		if len(c.items) >= htmlCacheMaxItems {
+			c.items = make(map[string]htmlCacheItem)
+		}
+	}
+	c.items[key] = htmlCacheItem{data: data, expire: time.Now().Add(ttl)}
+}
+
+// purge 更新url的版本号，使已缓存的面板失效
+func (c *htmlCache) purge(urls ...string) {
+	if len(urls) == 0 {
+		urls = []string{""}
+	}
+	version := utils.Uuid(8)
+
+	if c.redis != nil {
+		for _, url := range urls {
+			if err := c.redis.Set(context.Background(), c.versionKey(url), version, 0); err != nil {
+				logger.Error("html cache purge error: ", err)
+			}
+		}
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.versions == nil {
+		c.versions = make(map[string]string)
+	}
+	for _, url := range urls {
+		c.versions[url] = version
+		if url == "" {
+			c.items = nil
+			continue
+		}
+		for k := range c.items {
+			if strings.HasPrefix(k, url+"\x00") {
+				delete(c.items, k)
+			}
+		}
+	}
+}
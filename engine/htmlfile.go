@@ -0,0 +1,206 @@
+// 版权所有 2019 GoAdmin 核心团队。保留所有权利。
+// 本源代码的使用受 Apache-2.0 风格许可证管辖
+// 该许可证可在 LICENSE 文件中找到。
+
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	template2 "html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/template"
+)
+
+// errSandboxCall 是沙箱模式下调用 call 函数时返回的错误
+var errSandboxCall = errors.New("function call is not allowed in the sandbox")
+
+// sandboxFuncs 是沙箱模式下可用的函数，并覆盖禁用的内置函数
+var sandboxFuncs = template2.FuncMap{
+	"lang": language.Get,
+	"call": func(...interface{}) (interface{}, error) {
+		return nil, errSandboxCall
+	},
+}
+
+// HTMLSandbox 启用HTMLFile和HTMLFiles的沙箱模式
+//
+// 参数：
+//   - funcs：模板中允许使用的额外函数
+//
+// 返回值：
+//   - *Engine：引擎实例，支持链式调用
+//
+// 工作原理：
+//
+//	沙箱模式下禁用内置函数call，模板数据在首次渲染时转换为JSON的基本类型，
+//	之后的请求复用转换的结果，因此之后对数据的修改不会生效。
+//	模板无法调用数据的方法，template.HTML等类型的值也会被转义。
+//	模板中只能使用html/template的内置函数、lang、AddTemplateFunc添加的函数和funcs中的函数
+func (eng *Engine) HTMLSandbox(funcs ...template2.FuncMap) *Engine {
+	eng.htmlSandbox = true
+	eng.htmlSandboxFuncs = make(template2.FuncMap)
+	for _, fm := range funcs {
+		for name, fn := range fm {
+			eng.htmlSandboxFuncs[name] = fn
+		}
+	}
+	return eng
+}
+
+// AddTemplateFunc 添加模板函数，HTMLFile、HTMLFiles和主题的模板均可使用
+//
+// 参数：
+//   - name：函数名称
+//   - fn：函数，需满足html/template对模板函数的要求
+//
+// 返回值：
+//   - *Engine：引擎实例，支持链式调用
+//
+// 使用示例：
+//
+//	eng.AddTemplateFunc("currency", func(v float64) string {
+//	    return fmt.Sprintf("¥%.2f", v)
+//	})
+//
+// 需在Use之前调用
+func (eng *Engine) AddTemplateFunc(name string, fn interface{}) *Engine {
+	template.AddFunc(name, fn)
+	if eng.templateFuncs == nil {
+		eng.templateFuncs = make(template2.FuncMap)
+	}
+	eng.templateFuncs[name] = fn
+	return eng
+}
+
+// htmlFileCache 缓存解析后的HTML文件模板，文件修改后重新解析
+type htmlFileCache struct {
+	lock  sync.Mutex
+	items map[string]*htmlFileCacheItem
+}
+
+type htmlFileCacheItem struct {
+	tmpl     *template2.Template
+	modTimes []time.Time
+}
+
+// get 返回文件的模板，文件未修改时使用缓存
+func (c *htmlFileCache) get(sandbox bool, funcs template2.FuncMap, files ...string) (*template2.Template, error) {
+	if len(files) == 0 {
+		return nil, errors.New("no html files")
+	}
+	modTimes := make([]time.Time, len(files))
+	for i, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		modTimes[i] = info.ModTime()
+	}
+
+	key := strings.Join(files, "\x00")
+	if sandbox {
+		key = "sandbox\x00" + key
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if item, ok := c.items[key]; ok && sameTimes(item.modTimes, modTimes) {
+		return item.tmpl, nil
+	}
+
+	// 与template2.ParseFiles一致，模板以第一个文件命名
+	t, err := template2.New(filepath.Base(files[0])).Funcs(funcs).ParseFiles(files...)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.items == nil {
+		c.items = make(map[string]*htmlFileCacheItem)
+	}
+	c.items[key] = &htmlFileCacheItem{tmpl: t, modTimes: modTimes}
+	return t, nil
+}
+
+func sameTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// htmlFileData 是HTMLFile和HTMLFiles的模板数据，沙箱模式下只转换一次
+type htmlFileData struct {
+	data map[string]interface{}
+
+	once      sync.Once
+	sandboxed map[string]interface{}
+	err       error
+}
+
+// get 返回模板数据，沙箱模式下返回首次转换的结果
+func (d *htmlFileData) get(sandbox bool) (map[string]interface{}, error) {
+	if !sandbox {
+		return d.data, nil
+	}
+	d.once.Do(func() {
+		d.sandboxed, d.err = sandboxData(d.data)
+	})
+	return d.sandboxed, d.err
+}
+
+// executeHTMLFiles 执行HTML文件模板并将结果写入缓冲区
+func (eng *Engine) executeHTMLFiles(buf *bytes.Buffer, fileData *htmlFileData, files ...string) error {
+	funcs := template.DefaultFuncMap
+	if eng.htmlSandbox {
+		funcs = make(template2.FuncMap)
+		for name, fn := range eng.templateFuncs {
+			funcs[name] = fn
+		}
+		for name, fn := range eng.htmlSandboxFuncs {
+			funcs[name] = fn
+		}
+		for name, fn := range sandboxFuncs {
+			funcs[name] = fn
+		}
+	}
+
+	data, err := fileData.get(eng.htmlSandbox)
+	if err != nil {
+		return err
+	}
+	t, err := eng.htmlFiles.get(eng.htmlSandbox, funcs, files...)
+	if err != nil {
+		return err
+	}
+	return t.Execute(buf, data)
+}
+
+// sandboxData 将模板数据转换为JSON的基本类型
+func sandboxData(data map[string]interface{}) (map[string]interface{}, error) {
+	if data == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var res map[string]interface{}
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
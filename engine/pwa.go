@@ -0,0 +1,212 @@
+// 版权所有 2019 GoAdmin 核心团队。保留所有权利。
+// 本源代码的使用受 Apache-2.0 风格许可证管辖
+// 该许可证可在 LICENSE 文件中找到。
+
+package engine
+
+import (
+	template2 "html/template"
+	"net/http"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/system"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// PWAIcon 是Web应用清单中的图标
+type PWAIcon struct {
+	Src     string `json:"src"`
+	Sizes   string `json:"sizes"`
+	Type    string `json:"type,omitempty"`
+	Purpose string `json:"purpose,omitempty"`
+}
+
+// PWAConfig 是PWA的配置
+//
+// 字段说明：
+//   - Name：应用名称，为空时使用站点标题
+//   - ShortName：主屏幕上显示的短名称，为空时使用Name
+//   - ThemeColor：主题色，如"#3c8dbc"
+//   - BackgroundColor：启动画面的背景色，默认为白色
+//   - Icons：应用图标，至少应包含192x192和512x512两种尺寸
+//   - Assets：安装时缓存的额外静态资源地址，主题的静态资源在首次加载后缓存
+type PWAConfig struct {
+	Name            string
+	ShortName       string
+	ThemeColor      string
+	BackgroundColor string
+	Icons           []PWAIcon
+	Assets          []string
+}
+
+// PWA 启用PWA支持，使后台可以“安装”到平板等设备的主屏幕
+//
+// 参数：
+//   - cfg：PWA配置
+//
+// 返回值：
+//   - *Engine：引擎实例，支持链式调用
+//
+// 工作原理：
+//   - 注册不需要认证的 /manifest.webmanifest、/sw.js 和 /offline 路由，位于路由前缀下
+//   - 在所有页面头部加入清单链接，并注册Service Worker
+//   - Service Worker缓存应用外壳和静态资源，页面仍从网络加载，不缓存任何数据；
+//     网络不可用时显示离线页面
+//   - 需在Use之后调用
+//
+// 使用示例：
+//
+//	eng.PWA(engine.PWAConfig{
+//	    ThemeColor: "#3c8dbc",
+//	    Icons: []engine.PWAIcon{
+//	        {Src: "/static/icon-192.png", Sizes: "192x192", Type: "image/png"},
+//	        {Src: "/static/icon-512.png", Sizes: "512x512", Type: "image/png"},
+//	    },
+//	})
+func (eng *Engine) PWA(cfg PWAConfig) *Engine {
+	if eng.Adapter == nil {
+		emptyAdapterPanic()
+	}
+
+	var (
+		manifestURL = config.Url("/manifest.webmanifest")
+		swURL       = config.Url("/sw.js")
+		offlineURL  = config.Url("/offline")
+	)
+
+	eng.Adapter.AddHandler(http.MethodGet, manifestURL, eng.wrap(func(ctx *context.Context) {
+		ctx.Data(http.StatusOK, "application/manifest+json", []byte(pwaManifest(cfg)))
+	}))
+	eng.Adapter.AddHandler(http.MethodGet, swURL, eng.wrap(func(ctx *context.Context) {
+		ctx.SetHeader("Cache-Control", "no-cache")
+		ctx.Data(http.StatusOK, "application/javascript; charset=utf-8", []byte(pwaServiceWorker(cfg, offlineURL)))
+	}))
+	eng.Adapter.AddHandler(http.MethodGet, offlineURL, eng.wrap(func(ctx *context.Context) {
+		ctx.HTML(http.StatusOK, pwaOfflinePage(cfg))
+	}))
+
+	types.AddPageHTMLHook(func(_ *context.Context) (template2.HTML, template2.HTML) {
+		head := `<link rel="manifest" href="` + template2.HTMLEscapeString(manifestURL) + `">`
+		if cfg.ThemeColor != "" {
+			head += `<meta name="theme-color" content="` + template2.HTMLEscapeString(cfg.ThemeColor) + `">`
+		}
+		foot := `<script>if ("serviceWorker" in navigator) { navigator.serviceWorker.register(` +
+			utils.JSON(swURL) + `); }</script>`
+		return template2.HTML(head), template2.HTML(foot)
+	})
+
+	return eng
+}
+
+// pwaName 返回应用名称和短名称
+func pwaName(cfg PWAConfig) (string, string) {
+	name := cfg.Name
+	if name == "" {
+		name = config.GetTitle()
+	}
+	shortName := cfg.ShortName
+	if shortName == "" {
+		shortName = name
+	}
+	return name, shortName
+}
+
+// pwaManifest 返回Web应用清单，范围和起始地址为路由前缀
+func pwaManifest(cfg PWAConfig) string {
+	name, shortName := pwaName(cfg)
+	manifest := map[string]interface{}{
+		"name":             name,
+		"short_name":       shortName,
+		"start_url":        config.GetIndexURL(),
+		"scope":            config.PrefixFixSlash() + "/",
+		"display":          "standalone",
+		"background_color": utils.SetDefault(cfg.BackgroundColor, "", "#ffffff"),
+		"icons":            cfg.Icons,
+	}
+	if cfg.ThemeColor != "" {
+		manifest["theme_color"] = cfg.ThemeColor
+	}
+	if cfg.Icons == nil {
+		manifest["icons"] = []PWAIcon{}
+	}
+	return utils.JSON(manifest)
+}
+
+// pwaServiceWorker 返回Service Worker的脚本
+//
+// 缓存策略：
+//   - 安装时缓存离线页面、图标和Assets，缓存名包含版本号，升级后清除旧缓存
+//   - 静态资源（路由前缀下的/assets/和AssetUrl下的资源）缓存优先
+//   - 页面请求只走网络，失败时返回离线页面；其他请求不处理
+func pwaServiceWorker(cfg PWAConfig, offlineURL string) string {
+	shell := []string{offlineURL}
+	for _, icon := range cfg.Icons {
+		shell = append(shell, icon.Src)
+	}
+	shell = append(shell, cfg.Assets...)
+
+	assetPrefixes := []string{config.Url("/assets/")}
+	if assetURL := config.GetAssetUrl(); assetURL != "" {
+		assetPrefixes = append(assetPrefixes, strings.TrimSuffix(assetURL, "/")+"/")
+	}
+
+	return `var CACHE = ` + utils.JSON("goadmin-"+system.Version()) + `;
+var SHELL = ` + utils.JSON(shell) + `;
+var OFFLINE = ` + utils.JSON(offlineURL) + `;
+var ASSETS = ` + utils.JSON(assetPrefixes) + `;
+
+self.addEventListener("install", function (e) {
+    e.waitUntil(caches.open(CACHE).then(function (cache) { return cache.addAll(SHELL); }).then(function () { return self.skipWaiting(); }));
+});
+
+self.addEventListener("activate", function (e) {
+    e.waitUntil(caches.keys().then(function (keys) {
+        return Promise.all(keys.filter(function (key) { return key.indexOf("goadmin-") === 0 && key !== CACHE; })
+            .map(function (key) { return caches.delete(key); }));
+    }).then(function () { return self.clients.claim(); }));
+});
+
+var isAsset = function (url) {
+    var u = new URL(url, self.location.href), path = u.origin === self.location.origin ? u.pathname : u.href;
+    return ASSETS.some(function (prefix) { return path.indexOf(prefix) === 0 || u.href.indexOf(prefix) === 0; });
+};
+
+self.addEventListener("fetch", function (e) {
+    var req = e.request;
+    if (req.method !== "GET") { return; }
+    if (req.mode === "navigate") {
+        e.respondWith(fetch(req).catch(function () { return caches.match(OFFLINE); }));
+        return;
+    }
+    if (!isAsset(req.url)) { return; }
+    e.respondWith(caches.match(req).then(function (cached) {
+        return cached || fetch(req).then(function (res) {
+            if (res.ok) {
+                var copy = res.clone();
+                caches.open(CACHE).then(function (cache) { cache.put(req, copy); });
+            }
+            return res;
+        });
+    }));
+});
+`
+}
+
+// pwaOfflinePage 返回离线页面，不依赖主题的静态资源
+func pwaOfflinePage(cfg PWAConfig) string {
+	name, _ := pwaName(cfg)
+	color := utils.SetDefault(cfg.ThemeColor, "", "#3c8dbc")
+	return `<!DOCTYPE html><html><head><meta charset="utf-8">` +
+		`<meta name="viewport" content="width=device-width, initial-scale=1">` +
+		`<title>` + template2.HTMLEscapeString(name) + `</title>` +
+		`<style>body{margin:0;font-family:sans-serif;display:flex;align-items:center;justify-content:center;height:100vh;background:#f4f6f9;color:#333;text-align:center;}` +
+		`button{margin-top:16px;padding:8px 24px;border:0;border-radius:3px;color:#fff;cursor:pointer;background:` + template2.HTMLEscapeString(color) + `;}</style>` +
+		`</head><body><div><h2>` + template2.HTMLEscapeString(language.Get("you are offline")) + `</h2>` +
+		`<p>` + template2.HTMLEscapeString(language.Get("check the network and retry")) + `</p>` +
+		`<button onclick="location.reload()">` + template2.HTMLEscapeString(language.Get("retry")) + `</button>` +
+		`</div></body></html>`
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/purpose168/GoAdmin/examples/datamodel"
 	"github.com/purpose168/GoAdmin/modules/config"
 	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/listener"
 	"github.com/purpose168/GoAdmin/plugins/example"
 	"github.com/purpose168/GoAdmin/template"
 	"github.com/purpose168/GoAdmin/template/chartjs"
@@ -200,11 +201,13 @@ func main() {
 	// 使用 goroutine 在后台启动服务器
 	// goroutine 是 Go 语言的轻量级线程，可以并发执行函数
 	// 使用 go 关键字启动的函数会在新的 goroutine 中执行
-	// http.ListenAndServe 在指定地址启动 HTTP 服务器
+	// listener.Serve 在指定地址启动 HTTP 服务器
 	// ":3333" 表示监听所有网络接口的 3333 端口
+	// 设置了 GOADMIN_LISTEN 环境变量时改为监听其地址，如 Unix 套接字 "unix:/run/goadmin.sock"
+	// 或 systemd 激活的监听 "systemd"，适用于与 nginx 部署在同一主机而不暴露 TCP 端口
 	// r 是路由处理器，所有请求都会通过 r 进行路由
 	go func() {
-		_ = http.ListenAndServe(":3333", r)
+		_ = listener.Serve(listener.Addr(":3333"), r)
 	}()
 
 	// 设置优雅关闭机制
@@ -0,0 +1,23 @@
+package datamodel
+
+import (
+	"github.com/purpose168/GoAdmin/modules/seed"
+)
+
+// The demo rows of the tables authors and posts, run by the seed plugin at
+// the page /admin/seed or at the startup with the flag -seed.
+func init() {
+	seed.RegisterFixture("demo_authors", "demo authors", []byte(`[
+	{"table": "authors", "rows": [
+		{"first_name": "Jerry", "last_name": "Wang", "email": "jerry@example.com", "birthdate": "1990-01-01"},
+		{"first_name": "Lucy", "last_name": "Li", "email": "lucy@example.com", "birthdate": "1992-06-18"}
+	]}
+]`))
+
+	seed.RegisterFixture("demo_posts", "demo posts", []byte(`[
+	{"table": "posts", "rows": [
+		{"author_id": 1, "title": "Hello GoAdmin", "description": "The first post", "content": "<p>Hello GoAdmin</p>", "date": "2026-10-21 00:00:00"},
+		{"author_id": 2, "title": "Seeders", "description": "Fill the demo data", "content": "<p>Run the seeders</p>", "date": "2026-10-21 00:00:00"}
+	]}
+]`))
+}
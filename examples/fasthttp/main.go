@@ -15,6 +15,7 @@ import (
 	"github.com/purpose168/GoAdmin/examples/datamodel"
 	"github.com/purpose168/GoAdmin/modules/config"
 	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/listener"
 	"github.com/purpose168/GoAdmin/plugins/example"
 	"github.com/purpose168/GoAdmin/template"
 	"github.com/purpose168/GoAdmin/template/chartjs"
@@ -89,7 +90,11 @@ func main() {
 	eng.HTML("GET", "/admin", datamodel.GetContent)
 
 	go func() {
-		_ = fasthttp.ListenAndServe(":8897", router.Handler)
+		lis, err := listener.Listen(listener.Addr(":8897"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		_ = fasthttp.Serve(lis, router.Handler)
 	}()
 
 	quit := make(chan os.Signal, 1)
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"io/ioutil"
 	"log"
 	"os"
@@ -17,12 +18,17 @@ import (
 	"github.com/purpose168/GoAdmin/examples/datamodel"
 	"github.com/purpose168/GoAdmin/modules/config"
 	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/listener"
 	"github.com/purpose168/GoAdmin/plugins/example"
+	"github.com/purpose168/GoAdmin/plugins/seed"
 	"github.com/purpose168/GoAdmin/template"
 	"github.com/purpose168/GoAdmin/template/chartjs"
 )
 
 func main() {
+	runSeeders := flag.Bool("seed", false, "run the pending seeders at the startup")
+	flag.Parse()
+
 	gin.SetMode(gin.ReleaseMode)
 	gin.DefaultWriter = ioutil.Discard
 
@@ -92,7 +98,7 @@ func main() {
 		//
 		AddGenerator("user", datamodel.GetUserTable).
 		AddDisplayFilterXssJsFilter().
-		AddPlugins(examplePlugin).
+		AddPlugins(examplePlugin, seed.NewSeed().RunOnStart(*runSeeders)).
 		Use(r); err != nil {
 		panic(err)
 	}
@@ -104,7 +110,7 @@ func main() {
 	e.HTML("GET", "/admin", datamodel.GetContent)
 
 	go func() {
-		_ = r.Run(":9033")
+		_ = listener.Serve(listener.Addr(":9033"), r)
 	}()
 
 	quit := make(chan os.Signal, 1)
@@ -16,6 +16,7 @@ import (
 	"github.com/purpose168/GoAdmin/examples/datamodel"
 	"github.com/purpose168/GoAdmin/modules/config"
 	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/listener"
 	"github.com/purpose168/GoAdmin/plugins/example"
 	"github.com/purpose168/GoAdmin/template"
 	"github.com/purpose168/GoAdmin/template/chartjs"
@@ -88,7 +89,7 @@ func main() {
 	eng.HTML("GET", "/admin", datamodel.GetContent)
 
 	go func() {
-		_ = http.ListenAndServe(":9033", app)
+		_ = listener.Serve(listener.Addr(":9033"), app)
 	}()
 
 	quit := make(chan os.Signal, 1)
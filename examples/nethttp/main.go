@@ -17,6 +17,7 @@ import (
 	"github.com/purpose168/GoAdmin/examples/datamodel"
 	"github.com/purpose168/GoAdmin/modules/config"
 	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/listener"
 	"github.com/purpose168/GoAdmin/plugins/example"
 	"github.com/purpose168/GoAdmin/template"
 	"github.com/purpose168/GoAdmin/template/chartjs"
@@ -95,9 +96,18 @@ func main() {
 	eng.HTML("GET", "/admin", datamodel.GetContent)
 
 	go func() {
-		_ = http.ListenAndServe(":3333", r)
+		// listen on ":3333", or the unix socket or the systemd-activated listener
+		// of the GOADMIN_LISTEN environment variable, see the listener package.
+		_ = listener.Serve(listener.Addr(":3333"), r)
 	}()
 
+	// or serve https with the certificates issued by Let's Encrypt for the
+	// domains of the AutoTLS of the config:
+	//
+	// go func() {
+	// 	_ = eng.ServeAutoTLS(r)
+	// }()
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt)
 	<-quit
@@ -0,0 +1,198 @@
+// Package analytics records the usage of the admin, the page views and the
+// actions per user and table, into the local table goadmin_usage. Nothing is
+// sent out. It is opt-in: nothing is recorded until a recorder is set by Use,
+// which is done by the analytics plugin.
+//
+// The hits are counted in memory and flushed periodically, one row per day,
+// user, table, action and path.
+package analytics
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
+)
+
+// TableName is the table of the usage, see the migrations of data directory
+// for the schema.
+const TableName = "goadmin_usage"
+
+// Kinds of the usage.
+const (
+	KindView   = "view"
+	KindAction = "action"
+)
+
+// Hit is the usage of a page or an action.
+type Hit struct {
+	Day    string
+	UserID int64
+	Kind   string
+	Prefix string
+	Action string
+	Path   string
+}
+
+// Recorder counts the hits in memory and flushes them into the table.
+type Recorder struct {
+	conn db.Connection
+
+	lock sync.Mutex
+	hits map[Hit]int
+}
+
+// NewRecorder return a recorder of the connection.
+func NewRecorder(conn db.Connection) *Recorder {
+	return &Recorder{conn: conn, hits: make(map[Hit]int)}
+}
+
+// Add count a hit.
+func (r *Recorder) Add(hit Hit) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.hits[hit]++
+}
+
+// Flush write the counted hits into the table, the hits failed to write are
+// kept for the next flush.
+func (r *Recorder) Flush() error {
+	r.lock.Lock()
+	hits := r.hits
+	r.hits = make(map[Hit]int)
+	r.lock.Unlock()
+
+	var lastErr error
+	for hit, count := range hits {
+		if err := r.save(hit, count); err != nil {
+			lastErr = err
+			r.lock.Lock()
+			r.hits[hit] += count
+			r.lock.Unlock()
+		}
+	}
+	return lastErr
+}
+
+func (r *Recorder) save(hit Hit, count int) error {
+	_, err := db.WithDriver(r.conn).Table(TableName).
+		Where("day", "=", hit.Day).
+		Where("user_id", "=", hit.UserID).
+		Where("kind", "=", hit.Kind).
+		Where("prefix", "=", hit.Prefix).
+		Where("action", "=", hit.Action).
+		Where("path", "=", hit.Path).
+		UpdateRaw("hits = hits + ?", count).
+		Exec()
+	if err == nil || !strings.Contains(err.Error(), "no affect") {
+		if db.CheckError(err, db.UPDATE) {
+			return err
+		}
+		return nil
+	}
+	_, err = db.WithDriver(r.conn).Table(TableName).Insert(dialect.H{
+		"day":     hit.Day,
+		"user_id": hit.UserID,
+		"kind":    hit.Kind,
+		"prefix":  hit.Prefix,
+		"action":  hit.Action,
+		"path":    hit.Path,
+		"hits":    count,
+	})
+	if db.CheckError(err, db.INSERT) {
+		return err
+	}
+	return nil
+}
+
+var (
+	recorderLock sync.RWMutex
+	recorder     *Recorder
+)
+
+// Use set the recorder of Track, nil stops the recording.
+func Use(r *Recorder) {
+	recorderLock.Lock()
+	defer recorderLock.Unlock()
+	recorder = r
+}
+
+// Track count the request of the signed in user, it is called after the
+// requests of the admin are handled.
+func Track(ctx *context.Context) {
+	recorderLock.RLock()
+	r := recorder
+	recorderLock.RUnlock()
+	if r == nil {
+		return
+	}
+
+	user, ok := ctx.UserValue["user"].(models.UserModel)
+	if !ok || user.Id == 0 {
+		return
+	}
+	hit, ok := NewHit(ctx.Method(), ctx.Path(), ctx.Query(constant.PrefixKey))
+	if !ok {
+		return
+	}
+	hit.UserID = user.Id
+	r.Add(hit)
+}
+
+// NewHit return the hit of the request of today, the GET requests are the
+// page views and the others are the actions. The assets are not counted.
+func NewHit(method, path, prefix string) (Hit, bool) {
+	base := config.Url("/")
+	if !strings.HasPrefix(path, strings.TrimSuffix(base, "/")) || strings.Contains(path, "/assets/") {
+		return Hit{}, false
+	}
+
+	hit := Hit{
+		Day:    time.Now().Format("2006-01-02"),
+		Kind:   KindAction,
+		Prefix: prefix,
+		Path:   path,
+		Action: action(path, prefix),
+	}
+	if method == "GET" {
+		hit.Kind = KindView
+	}
+	return hit, true
+}
+
+// action return the name of the url format matching the path, or the first
+// segment of the path after the url prefix.
+func action(path, prefix string) string {
+	if prefix != "" {
+		formats := config.GetURLFormats()
+		for name, format := range map[string]string{
+			"info":        formats.Info,
+			"detail":      formats.Detail,
+			"new":         formats.Create,
+			"delete":      formats.Delete,
+			"export":      formats.Export,
+			"edit":        formats.Edit,
+			"show_edit":   formats.ShowEdit,
+			"show_create": formats.ShowCreate,
+			"update":      formats.Update,
+		} {
+			if format != "" && config.Url(strings.Replace(format, ":"+constant.PrefixKey, prefix, 1)) == path {
+				return name
+			}
+		}
+	}
+	rest := strings.Trim(strings.TrimPrefix(path, strings.TrimSuffix(config.Url("/"), "/")), "/")
+	if i := strings.Index(rest, "/"); i > -1 {
+		rest = rest[:i]
+	}
+	if rest == "" {
+		return "index"
+	}
+	return rest
+}
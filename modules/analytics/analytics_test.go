@@ -0,0 +1,65 @@
+package analytics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	_ "github.com/purpose168/GoAdmin/modules/db/drivers/sqlite"
+)
+
+func TestRecorder(t *testing.T) {
+	config.Initialize(&config.Config{UrlPrefix: "admin"})
+
+	conn := db.GetSqliteDB()
+	conn.InitDB(config.DatabaseList{"default": {
+		Driver: db.DriverSqlite,
+		File:   filepath.Join(t.TempDir(), "usage.db"),
+	}})
+	migration, err := os.ReadFile("../../data/migrations/admin_2026_10_22_000000_sqlite.sql")
+	assert.Equal(t, err, nil)
+	for _, statement := range []string{
+		string(migration),
+		"create table goadmin_menu (id integer primary key autoincrement, title text, uri text)",
+		"insert into goadmin_menu (title, uri) values ('Users', '/info/user'), ('Posts', '/info/posts'), ('Admin', '')",
+	} {
+		_, err := conn.Exec(statement)
+		assert.Equal(t, err, nil)
+	}
+
+	hit, ok := NewHit("GET", "/admin/info/user", "user")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, hit.Kind, KindView)
+	assert.Equal(t, hit.Action, "info")
+	_, ok = NewHit("GET", "/admin/assets/dist/js/all.min.js", "")
+	assert.Equal(t, ok, false)
+	del, _ := NewHit("POST", "/admin/delete/user", "user")
+	assert.Equal(t, del.Action, "delete")
+	assert.Equal(t, del.Kind, KindAction)
+
+	r := NewRecorder(conn)
+	for _, userID := range []int64{1, 1, 2} {
+		hit.UserID = userID
+		r.Add(hit)
+	}
+	del.UserID = 1
+	r.Add(del)
+	assert.Equal(t, r.Flush(), nil)
+	// the hits of the same row are added up.
+	r.Add(del)
+	assert.Equal(t, r.Flush(), nil)
+
+	report, err := Load(conn, time.Now().AddDate(0, 0, -7))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(report.Tables), 1)
+	assert.Equal(t, report.Tables[0].Prefix, "user")
+	assert.Equal(t, report.Tables[0].Views, int64(3))
+	assert.Equal(t, report.Tables[0].Actions, int64(2))
+	assert.Equal(t, report.Tables[0].Users, 2)
+	assert.Equal(t, len(report.UnusedMenus), 1)
+	assert.Equal(t, report.UnusedMenus[0].Title, "Posts")
+}
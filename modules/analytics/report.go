@@ -0,0 +1,167 @@
+package analytics
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+)
+
+// TableUsage is the usage of a table in a period.
+type TableUsage struct {
+	Prefix  string
+	Views   int64
+	Actions int64
+	Users   int
+	LastDay string
+}
+
+// Hits return the views and the actions.
+func (t TableUsage) Hits() int64 {
+	return t.Views + t.Actions
+}
+
+// MenuItem is a menu not visited in a period.
+type MenuItem struct {
+	ID    int64
+	Title string
+	URI   string
+}
+
+// Report is the usage of the admin since a day.
+type Report struct {
+	Since       string
+	Tables      []TableUsage
+	UnusedMenus []MenuItem
+}
+
+// Load return the report of the usage since the day, the tables are sorted by
+// the hits in descending order.
+func Load(conn db.Connection, since time.Time) (Report, error) {
+	report := Report{Since: since.Format("2006-01-02")}
+
+	rows, err := db.WithDriver(conn).Table(TableName).Where("day", ">=", report.Since).All()
+	if err != nil {
+		return report, err
+	}
+
+	var (
+		tables = make(map[string]*TableUsage)
+		users  = make(map[string]map[int64]bool)
+		views  = make(map[string]bool)
+	)
+	for _, row := range rows {
+		var (
+			prefix = db.GetValueFromDatabaseType(db.Varchar, row["prefix"], false).String()
+			kind   = db.GetValueFromDatabaseType(db.Varchar, row["kind"], false).String()
+			path   = db.GetValueFromDatabaseType(db.Varchar, row["path"], false).String()
+			day    = dayString(row["day"])
+			hits   = toInt64(row["hits"])
+		)
+		if kind == KindView {
+			views[path] = true
+		}
+		if prefix == "" {
+			continue
+		}
+		usage, ok := tables[prefix]
+		if !ok {
+			usage = &TableUsage{Prefix: prefix}
+			tables[prefix] = usage
+			users[prefix] = make(map[int64]bool)
+		}
+		if kind == KindView {
+			usage.Views += hits
+		} else {
+			usage.Actions += hits
+		}
+		users[prefix][toInt64(row["user_id"])] = true
+		if day > usage.LastDay {
+			usage.LastDay = day
+		}
+	}
+
+	report.Tables = make([]TableUsage, 0, len(tables))
+	for prefix, usage := range tables {
+		usage.Users = len(users[prefix])
+		report.Tables = append(report.Tables, *usage)
+	}
+	sort.Slice(report.Tables, func(i, j int) bool {
+		if report.Tables[i].Hits() != report.Tables[j].Hits() {
+			return report.Tables[i].Hits() > report.Tables[j].Hits()
+		}
+		return report.Tables[i].Prefix < report.Tables[j].Prefix
+	})
+
+	menus, err := db.WithDriver(conn).Table("goadmin_menu").OrderBy("id", "asc").All()
+	if err != nil {
+		return report, err
+	}
+	report.UnusedMenus = make([]MenuItem, 0)
+	for _, menu := range menus {
+		uri := db.GetValueFromDatabaseType(db.Varchar, menu["uri"], false).String()
+		if !menuUsed(uri, views) {
+			report.UnusedMenus = append(report.UnusedMenus, MenuItem{
+				ID:    toInt64(menu["id"]),
+				Title: db.GetValueFromDatabaseType(db.Varchar, menu["title"], false).String(),
+				URI:   uri,
+			})
+		}
+	}
+
+	return report, nil
+}
+
+// menuUsed report whether the page of the menu or a page under it is viewed,
+// only the page itself is checked for the index menu.
+// The menus of the external links and the parent menus without uri are not
+// counted as unused.
+func menuUsed(uri string, views map[string]bool) bool {
+	if uri == "" || strings.Contains(uri, "://") {
+		return true
+	}
+	if i := strings.Index(uri, "?"); i > -1 {
+		uri = uri[:i]
+	}
+	path := strings.TrimSuffix(config.Url(uri), "/")
+	for view := range views {
+		view = strings.TrimSuffix(view, "/")
+		if view == path || strings.Trim(uri, "/") != "" && strings.HasPrefix(view, path+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func dayString(value interface{}) string {
+	if t, ok := value.(time.Time); ok {
+		return t.Format("2006-01-02")
+	}
+	day := db.GetValueFromDatabaseType(db.Varchar, value, false).String()
+	if len(day) > 10 {
+		day = day[:10]
+	}
+	return day
+}
+
+func toInt64(value interface{}) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint64:
+		return int64(v)
+	case float64:
+		return int64(v)
+	case []byte:
+		return db.GetValueFromDatabaseType(db.Int, v, false).ToInt64()
+	case string:
+		return db.GetValueFromDatabaseType(db.Int, v, false).ToInt64()
+	}
+	return 0
+}
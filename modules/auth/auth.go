@@ -7,11 +7,13 @@ package auth
 import (
 	"log"
 	"sync"
+	"time"
 
 	"github.com/purpose168/GoAdmin/modules/db/dialect"
 	"github.com/purpose168/GoAdmin/modules/logger"
 
 	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
 	"github.com/purpose168/GoAdmin/modules/db"
 	"github.com/purpose168/GoAdmin/modules/service"
 	"github.com/purpose168/GoAdmin/plugins/admin/models"
@@ -48,6 +50,23 @@ func comparePassword(comPwd, pwdHash string) bool {
 	return err == nil
 }
 
+// defaultPasswords are the passwords of the users of the initial databases.
+var defaultPasswords = []string{"admin"}
+
+// HasDefaultPassword reports whether the user still has a password of the
+// initial databases.
+func HasDefaultPassword(user models.UserModel) bool {
+	if user.IsEmpty() {
+		return false
+	}
+	for _, pwd := range defaultPasswords {
+		if comparePassword(pwd, user.Password) {
+			return true
+		}
+	}
+	return false
+}
+
 // EncodePassword encode the password.
 func EncodePassword(pwd []byte) string {
 	hash, err := bcrypt.GenerateFromPassword(pwd, bcrypt.DefaultCost)
@@ -59,15 +78,46 @@ func EncodePassword(pwd []byte) string {
 
 // SetCookie set the cookie.
 func SetCookie(ctx *context.Context, user models.UserModel, conn db.Connection) error {
+	return SetCookieWithValues(ctx, user, conn, nil)
+}
+
+// SetCookieWithValues set the cookie, and keep the values in the session.
+func SetCookieWithValues(ctx *context.Context, user models.UserModel, conn db.Connection,
+	values map[string]interface{}) error {
 	ses, err := InitSession(ctx, conn)
 
 	if err != nil {
 		return err
 	}
 
+	for key, value := range values {
+		ses.Values[key] = value
+	}
+	if config.GetIdleTimeout() > 0 {
+		ses.Values[lastActiveSesKey] = time.Now().Unix()
+	}
+
 	return ses.Add("user_id", user.Id)
 }
 
+// TenantSessionKey is the session key of the tenant entered in the login page.
+const TenantSessionKey = "tenant"
+
+// Tenant return the tenant entered in the login page of the session, it is
+// empty when the login page has no tenant field.
+func Tenant(ctx *context.Context, conn db.Connection) string {
+	cookie, err := ctx.Request.Cookie(DefaultCookieKey)
+	if err != nil || cookie.Value == "" {
+		return ""
+	}
+	tenant, err := GetSessionByKey(cookie.Value, TenantSessionKey, conn)
+	if err != nil {
+		return ""
+	}
+	s, _ := tenant.(string)
+	return s
+}
+
 // DelCookie delete the cookie from Context.
 func DelCookie(ctx *context.Context, conn db.Connection) error {
 	ses, err := InitSession(ctx, conn)
@@ -81,6 +131,7 @@ func DelCookie(ctx *context.Context, conn db.Connection) error {
 
 type TokenService struct {
 	tokens CSRFToken
+	used   map[string]time.Time
 	lock   sync.Mutex
 	conn   db.Connection
 }
@@ -136,10 +187,22 @@ func (s *TokenService) AddToken() string {
 }
 
 // CheckToken check the given token with tokens in the CSRFToken, if exist
-// return true.
+// return true. The tokens are single use, so the forms submitted twice are
+// rejected, see Used.
 func (s *TokenService) CheckToken(toCheckToken string) (ok bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if toCheckToken == "" {
+		return false
+	}
+	if _, used := s.used[toCheckToken]; used {
+		return false
+	}
+
 	defer func() {
 		if ok {
+			s.markUsed(toCheckToken)
 			err := db.WithDriver(s.conn).Table("goadmin_session").
 				Where("sid", "=", toCheckToken).
 				Where("values", "=", "__csrf_token__").
@@ -169,6 +232,33 @@ func (s *TokenService) CheckToken(toCheckToken string) (ok bool) {
 	return
 }
 
+// usedTokenTTL is how long the used tokens are remembered to tell the
+// duplicate submits.
+const usedTokenTTL = 10 * time.Minute
+
+// markUsed remember the used token, the lock should be held.
+func (s *TokenService) markUsed(token string) {
+	now := time.Now()
+	if s.used == nil {
+		s.used = make(map[string]time.Time)
+	}
+	for t, at := range s.used {
+		if now.Sub(at) > usedTokenTTL {
+			delete(s.used, t)
+		}
+	}
+	s.used[token] = now
+}
+
+// Used return true when the token is used in a while, which means the form
+// is submitted twice, e.g. by a double click.
+func (s *TokenService) Used(token string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	at, ok := s.used[token]
+	return ok && time.Since(at) <= usedTokenTTL
+}
+
 // CSRFToken is type of a csrf token list.
 type CSRFToken []string
 
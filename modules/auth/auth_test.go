@@ -3,6 +3,9 @@ package auth
 import (
 	"testing"
 
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dbtest"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -10,3 +13,26 @@ func TestEncodePassword(t *testing.T) {
 	pwd := EncodePassword([]byte("123456"))
 	assert.Equal(t, comparePassword("123456", pwd), true)
 }
+
+func TestHasDefaultPassword(t *testing.T) {
+	assert.True(t, HasDefaultPassword(models.UserModel{Id: 1,
+		Password: "$2a$10$U3F/NSaf2kaVbyXTBp7ppOn0jZFyRqXRnYXB.AMioCjXl3Ciaj4oy"}))
+	assert.False(t, HasDefaultPassword(models.UserModel{Id: 1, Password: EncodePassword([]byte("123456"))}))
+	assert.False(t, HasDefaultPassword(models.UserModel{}))
+}
+
+func TestTokenService_CheckToken(t *testing.T) {
+	srv := &TokenService{conn: dbtest.New(db.DriverMysql)}
+	token := srv.AddToken()
+
+	assert.False(t, srv.Used(token))
+	assert.True(t, srv.CheckToken(token))
+
+	// the token is single use, the second submit is told as a duplicate.
+	assert.False(t, srv.CheckToken(token))
+	assert.True(t, srv.Used(token))
+
+	assert.False(t, srv.CheckToken("wrong"))
+	assert.False(t, srv.Used("wrong"))
+	assert.False(t, srv.CheckToken(""))
+}
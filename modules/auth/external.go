@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+)
+
+// syncExternalUser find the local user of the user authenticated by an
+// external identity provider, the user is created when create is true and it
+// does not exist. The roles are replaced by the given role slugs when
+// syncRoles is true or the user is created.
+func syncExternalUser(conn db.Connection, username, name string, roles []string, create, syncRoles bool) (models.UserModel, error) {
+	user := models.User().SetConn(conn).FindByUserName(username)
+	if user.IsEmpty() {
+		if !create {
+			return user, errors.New("user " + username + " does not exist")
+		}
+		if name == "" {
+			name = username
+		}
+		var err error
+		user, err = models.User().SetConn(conn).New(username, EncodePassword([]byte(utils.Uuid(32))), name, "")
+		if db.CheckError(err, db.INSERT) {
+			return user, err
+		}
+		syncRoles = true
+	}
+
+	if syncRoles {
+		if err := setRoles(conn, user, roles); err != nil {
+			return user, err
+		}
+	}
+
+	return user.WithRoles().WithPermissions().WithMenus(), nil
+}
+
+// setRoles replace the roles of the user by the role slugs, the slugs which
+// do not exist are ignored.
+func setRoles(conn db.Connection, user models.UserModel, slugs []string) error {
+	if err := user.DeleteRoles(); db.CheckError(err, db.DELETE) {
+		return err
+	}
+	if len(slugs) == 0 {
+		return nil
+	}
+	args := make([]interface{}, len(slugs))
+	for i, slug := range slugs {
+		args[i] = slug
+	}
+	roles, err := db.WithDriver(conn).Table("goadmin_roles").Select("id").WhereIn("slug", args).All()
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		id := db.GetValueFromDatabaseType(db.Int, role["id"], false).ToInt64()
+		if _, err := user.AddRole(fmt.Sprintf("%d", id)); db.CheckError(err, db.INSERT) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"strings"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/logger"
+)
+
+// KeepAlivePath is the path pinged by the pages to keep the session alive
+// when the idle timeout is enabled.
+const KeepAlivePath = "/keepalive"
+
+// lastActiveSesKey is the session key of the unix time of the last activity.
+const lastActiveSesKey = "last_active"
+
+// maxIdleTouchInterval is the longest interval of recording the activities
+// into the session, so not every request writes the session.
+const maxIdleTouchInterval = 60
+
+// checkIdle return false and clear the session when it is idle for longer
+// than the idle timeout, the activity of the request is recorded otherwise.
+// The background requests, such as the server-sent events, are not counted
+// as the activities.
+func checkIdle(ctx *context.Context, ses *Session) bool {
+	return checkIdleTimeout(ctx, ses, int64(config.GetIdleTimeout()))
+}
+
+// checkIdleTimeout is checkIdle of the given idle timeout in seconds.
+func checkIdleTimeout(ctx *context.Context, ses *Session, timeout int64) bool {
+	if timeout == 0 {
+		return true
+	}
+
+	now := time.Now().Unix()
+	last, ok := ses.Get(lastActiveSesKey).(float64)
+	if ok && now-int64(last) > timeout {
+		if err := ses.Clear(); err != nil {
+			logger.ErrorCtx(ctx, "clear idle session error: %s", err)
+		}
+		return false
+	}
+
+	if strings.Contains(ctx.Headers("Accept"), "text/event-stream") {
+		return true
+	}
+
+	interval := timeout / 4
+	if interval > maxIdleTouchInterval {
+		interval = maxIdleTouchInterval
+	}
+	if !ok || now-int64(last) >= interval || ctx.Request.URL.Path == config.Url(KeepAlivePath) {
+		if err := ses.Add(lastActiveSesKey, now); err != nil {
+			logger.ErrorCtx(ctx, "record session activity error: %s", err)
+		}
+	}
+	return true
+}
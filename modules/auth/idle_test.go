@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryDriver map[string]map[string]interface{}
+
+func (d memoryDriver) Load(sid string) (map[string]interface{}, error) { return d[sid], nil }
+
+func (d memoryDriver) Update(sid string, values map[string]interface{}) error {
+	d[sid] = values
+	return nil
+}
+
+func TestCheckIdle(t *testing.T) {
+	newSession := func(path, accept string, last int64) (*context.Context, *Session) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		ses := &Session{Sid: "sid", Driver: memoryDriver{}, Context: context.NewContext(req),
+			Values: map[string]interface{}{"user_id": float64(1)}}
+		if last != 0 {
+			ses.Values[lastActiveSesKey] = float64(last)
+		}
+		return ses.Context, ses
+	}
+
+	now := time.Now().Unix()
+
+	// the activity is recorded for the sessions without it.
+	ctx, ses := newSession("/admin/info/user", "", 0)
+	assert.True(t, checkIdleTimeout(ctx, ses, 600))
+	assert.InDelta(t, now, ses.Values[lastActiveSesKey], 1)
+
+	// the recent activity is not recorded again except by the keep-alive.
+	ctx, ses = newSession("/admin/info/user", "", now-10)
+	assert.True(t, checkIdleTimeout(ctx, ses, 600))
+	assert.Equal(t, float64(now-10), ses.Values[lastActiveSesKey])
+	ctx, ses = newSession(config.Url(KeepAlivePath), "", now-10)
+	assert.True(t, checkIdleTimeout(ctx, ses, 600))
+	assert.InDelta(t, now, ses.Values[lastActiveSesKey], 1)
+
+	// the server-sent events are not activities.
+	ctx, ses = newSession("/admin/notifications", "text/event-stream", now-100)
+	assert.True(t, checkIdleTimeout(ctx, ses, 600))
+	assert.Equal(t, float64(now-100), ses.Values[lastActiveSesKey])
+
+	// the idle session is cleared.
+	ctx, ses = newSession("/admin/info/user", "", now-601)
+	assert.False(t, checkIdleTimeout(ctx, ses, 600))
+	assert.Len(t, ses.Values, 0)
+}
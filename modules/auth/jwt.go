@@ -0,0 +1,461 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/service"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+)
+
+// JWTServiceKey is the key of the JWT service.
+const JWTServiceKey = "jwt"
+
+// JWT routes, relative to the url prefix.
+const (
+	JWTTokenPath   = "/api/token"
+	JWTRefreshPath = "/api/token/refresh"
+	JWTRevokePath  = "/api/token/revoke"
+	JWKSPath       = "/.well-known/jwks.json"
+)
+
+// RefreshTokenTableName is the table of the refresh tokens, see the migrations
+// of data directory for the schema.
+const RefreshTokenTableName = "goadmin_jwt_refresh_tokens"
+
+// JWTConfig is the config of the JWT sessions.
+type JWTConfig struct {
+	// Issuer is the "iss" claim of the tokens.
+	Issuer string
+	// AccessTTL is the lifetime of the access tokens, default 15 minutes.
+	AccessTTL time.Duration
+	// RefreshTTL is the lifetime of the refresh tokens, default 7 days.
+	RefreshTTL time.Duration
+}
+
+// Claims are the claims of the access tokens.
+type Claims struct {
+	Issuer    string `json:"iss,omitempty"`
+	Subject   string `json:"sub"`
+	Username  string `json:"username"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// TokenPair is the response of the token endpoints.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+type jwtKey struct {
+	id      string
+	key     *rsa.PrivateKey
+	retired time.Time
+}
+
+// JWT issues the short-lived RS256 access tokens and the refresh tokens for the
+// api clients, as an alternative of the cookie sessions. The keys can be
+// rotated, the retired keys are still used to verify the tokens until the
+// tokens signed by them expire, and all the public keys are published by the
+// JWKS endpoint for other services.
+type JWT struct {
+	cfg JWTConfig
+
+	lock sync.RWMutex
+	keys []*jwtKey
+}
+
+// NewJWT return the JWT service signing the tokens by the key.
+func NewJWT(cfg JWTConfig, key *rsa.PrivateKey) *JWT {
+	if cfg.AccessTTL == 0 {
+		cfg.AccessTTL = time.Minute * 15
+	}
+	if cfg.RefreshTTL == 0 {
+		cfg.RefreshTTL = time.Hour * 24 * 7
+	}
+	return &JWT{
+		cfg:  cfg,
+		keys: []*jwtKey{{id: keyID(&key.PublicKey), key: key}},
+	}
+}
+
+// Name implements service.Service.Name.
+func (j *JWT) Name() string {
+	return JWTServiceKey
+}
+
+// GetJWTServiceOrNot return the JWT service of the list and whether it exists.
+func GetJWTServiceOrNot(srv service.List) (*JWT, bool) {
+	if v, ok := srv.GetOrNot(JWTServiceKey); ok {
+		j, ok := v.(*JWT)
+		return j, ok
+	}
+	return nil, false
+}
+
+func keyID(pub *rsa.PublicKey) string {
+	sum := sha256.Sum256(pub.N.Bytes())
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+// Rotate sign the new tokens by the key, the previous key is retired.
+func (j *JWT) Rotate(key *rsa.PrivateKey) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	now := time.Now()
+	keys := []*jwtKey{{id: keyID(&key.PublicKey), key: key}}
+	for _, k := range j.keys {
+		if k.retired.IsZero() {
+			k.retired = now
+		}
+		if now.Sub(k.retired) <= j.cfg.AccessTTL {
+			keys = append(keys, k)
+		}
+	}
+	j.keys = keys
+}
+
+// key return the key of the id, or the current key when id is empty.
+func (j *JWT) key(id string) *jwtKey {
+	j.lock.RLock()
+	defer j.lock.RUnlock()
+	if id == "" {
+		return j.keys[0]
+	}
+	for _, k := range j.keys {
+		if k.id == id && (k.retired.IsZero() || time.Since(k.retired) <= j.cfg.AccessTTL) {
+			return k
+		}
+	}
+	return nil
+}
+
+var b64 = base64.RawURLEncoding
+
+// Sign return the access token of the claims.
+func (j *JWT) Sign(claims Claims) (string, error) {
+	k := j.key("")
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": k.id})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	unsigned := b64.EncodeToString(header) + "." + b64.EncodeToString(payload)
+	sum := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, k.key, crypto.SHA256, sum[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + b64.EncodeToString(sig), nil
+}
+
+// ErrInvalidToken is returned when the token is malformed, expired or not
+// signed by the keys.
+var ErrInvalidToken = errors.New("jwt: invalid token")
+
+// Verify check the access token and return the claims.
+func (j *JWT) Verify(token string) (Claims, error) {
+	var claims Claims
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims, ErrInvalidToken
+	}
+	rawHeader, err := b64.DecodeString(parts[0])
+	if err != nil {
+		return claims, ErrInvalidToken
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal(rawHeader, &header); err != nil || header.Alg != "RS256" || header.Kid == "" {
+		return claims, ErrInvalidToken
+	}
+	k := j.key(header.Kid)
+	if k == nil {
+		return claims, ErrInvalidToken
+	}
+	sig, err := b64.DecodeString(parts[2])
+	if err != nil {
+		return claims, ErrInvalidToken
+	}
+	sum := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if rsa.VerifyPKCS1v15(&k.key.PublicKey, crypto.SHA256, sum[:], sig) != nil {
+		return claims, ErrInvalidToken
+	}
+	payload, err := b64.DecodeString(parts[1])
+	if err != nil {
+		return claims, ErrInvalidToken
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return claims, ErrInvalidToken
+	}
+	if time.Now().Unix() >= claims.ExpiresAt || (j.cfg.Issuer != "" && claims.Issuer != j.cfg.Issuer) {
+		return claims, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// JWKS return the JSON Web Key Set of the public keys.
+func (j *JWT) JWKS() map[string]interface{} {
+	j.lock.RLock()
+	defer j.lock.RUnlock()
+	keys := make([]map[string]string, 0, len(j.keys))
+	for _, k := range j.keys {
+		keys = append(keys, map[string]string{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": k.id,
+			"n":   b64.EncodeToString(k.key.N.Bytes()),
+			"e":   b64.EncodeToString(big.NewInt(int64(k.key.E)).Bytes()),
+		})
+	}
+	return map[string]interface{}{"keys": keys}
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// AccessToken return an access token of the user, which is verified by the
+// keys of the JWKS endpoint.
+func (j *JWT) AccessToken(user models.UserModel) (string, error) {
+	now := time.Now()
+	return j.Sign(Claims{
+		Issuer:    j.cfg.Issuer,
+		Subject:   strconv.FormatInt(user.Id, 10),
+		Username:  user.UserName,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(j.cfg.AccessTTL).Unix(),
+	})
+}
+
+// Issue return the access token and a new refresh token of the user.
+func (j *JWT) Issue(conn db.Connection, user models.UserModel) (TokenPair, error) {
+	var pair TokenPair
+
+	now := time.Now()
+	access, err := j.AccessToken(user)
+	if err != nil {
+		return pair, err
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return pair, err
+	}
+	refresh := b64.EncodeToString(buf)
+	_, err = db.WithDriver(conn).Table(RefreshTokenTableName).Insert(dialect.H{
+		"user_id":    user.Id,
+		"token_hash": hashToken(refresh),
+		"expires_at": now.Add(j.cfg.RefreshTTL).Unix(),
+	})
+	if db.CheckError(err, db.INSERT) {
+		return pair, err
+	}
+
+	return TokenPair{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(j.cfg.AccessTTL / time.Second),
+	}, nil
+}
+
+// Refresh exchange the refresh token for a new token pair, the refresh token
+// can only be used once.
+func (j *JWT) Refresh(conn db.Connection, refresh string) (TokenPair, error) {
+	userID, err := claimRefreshToken(conn, refresh)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	user, ok := GetCurUserByID(userID, conn)
+	if !ok {
+		return TokenPair{}, ErrInvalidToken
+	}
+	return j.Issue(conn, user)
+}
+
+// claimRefreshToken delete the refresh token and return its user id. The token
+// is claimed by the request whose delete affects the row, so the concurrent
+// requests reusing the same token get ErrInvalidToken.
+func claimRefreshToken(conn db.Connection, refresh string) (int64, error) {
+	hash := hashToken(refresh)
+	item, err := db.WithDriver(conn).Table(RefreshTokenTableName).Where("token_hash", "=", hash).First()
+	if err != nil || item == nil {
+		return 0, ErrInvalidToken
+	}
+	err = db.WithDriver(conn).Table(RefreshTokenTableName).Where("token_hash", "=", hash).Delete()
+	if db.CheckError(err, db.DELETE) {
+		return 0, err
+	}
+	if err != nil {
+		// no affected row, the token has been claimed by another request.
+		return 0, ErrInvalidToken
+	}
+	if db.GetValueFromDatabaseType(db.Int, item["expires_at"], false).ToInt64() <= time.Now().Unix() {
+		return 0, ErrInvalidToken
+	}
+	return db.GetValueFromDatabaseType(db.Int, item["user_id"], false).ToInt64(), nil
+}
+
+// Revoke delete the refresh token.
+func (j *JWT) Revoke(conn db.Connection, refresh string) error {
+	err := db.WithDriver(conn).Table(RefreshTokenTableName).Where("token_hash", "=", hashToken(refresh)).Delete()
+	if db.CheckError(err, db.DELETE) {
+		return err
+	}
+	return nil
+}
+
+func bearer(ctx *context.Context) string {
+	h := ctx.Headers("Authorization")
+	if len(h) > 7 && strings.EqualFold(h[:7], "Bearer ") {
+		return strings.TrimSpace(h[7:])
+	}
+	return ""
+}
+
+func jsonFail(ctx *context.Context, code int, msg string) {
+	ctx.JSON(code, map[string]interface{}{
+		"code": code,
+		"msg":  language.Get(msg),
+	})
+}
+
+// Middleware authenticate the requests with a bearer token by the token, and
+// the others by the cookie session as Middleware does.
+func (j *JWT) Middleware(conn db.Connection) context.Handler {
+	cookie := Middleware(conn)
+	return func(ctx *context.Context) {
+		token := bearer(ctx)
+		if token == "" {
+			cookie(ctx)
+			return
+		}
+
+		claims, err := j.Verify(token)
+		if err != nil {
+			jsonFail(ctx, http.StatusUnauthorized, "invalid token")
+			ctx.Abort()
+			return
+		}
+		id, _ := strconv.ParseInt(claims.Subject, 10, 64)
+		user, ok := GetCurUserByID(id, conn)
+		if !ok {
+			jsonFail(ctx, http.StatusUnauthorized, "invalid token")
+			ctx.Abort()
+			return
+		}
+		ctx.SetUserValue("user", user)
+		if !CheckPermissions(user, ctx.Request.URL.String(), ctx.Method(), ctx.PostForm()) {
+			jsonFail(ctx, http.StatusForbidden, "permission denied")
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+func (j *JWT) respond(ctx *context.Context, pair TokenPair, err error) {
+	if err != nil {
+		if errors.Is(err, ErrInvalidToken) {
+			jsonFail(ctx, http.StatusUnauthorized, "invalid token")
+			return
+		}
+		jsonFail(ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"code": http.StatusOK,
+		"msg":  "ok",
+		"data": pair,
+	})
+}
+
+// formOrJSON return the value of the key from the form or the JSON body.
+func formOrJSON(ctx *context.Context, keys ...string) map[string]string {
+	values := make(map[string]string, len(keys))
+	if strings.HasPrefix(ctx.GetContentType(), "application/json") {
+		var body map[string]string
+		_ = ctx.BindJSON(&body)
+		for _, key := range keys {
+			values[key] = body[key]
+		}
+		return values
+	}
+	for _, key := range keys {
+		values[key] = ctx.FormValue(key)
+	}
+	return values
+}
+
+// TokenHandler issue the token pair of the username and password.
+func (j *JWT) TokenHandler(conn db.Connection) context.Handler {
+	return func(ctx *context.Context) {
+		values := formOrJSON(ctx, "username", "password")
+		if values["username"] == "" || values["password"] == "" {
+			jsonFail(ctx, http.StatusBadRequest, "wrong password or username")
+			return
+		}
+		user, ok := Check(values["password"], values["username"], conn)
+		if !ok {
+			jsonFail(ctx, http.StatusUnauthorized, "wrong password or username")
+			return
+		}
+		pair, err := j.Issue(conn, user)
+		j.respond(ctx, pair, err)
+	}
+}
+
+// RefreshHandler exchange the refresh token for a new token pair.
+func (j *JWT) RefreshHandler(conn db.Connection) context.Handler {
+	return func(ctx *context.Context) {
+		pair, err := j.Refresh(conn, formOrJSON(ctx, "refresh_token")["refresh_token"])
+		j.respond(ctx, pair, err)
+	}
+}
+
+// RevokeHandler revoke the refresh token, such as when the client logs out.
+func (j *JWT) RevokeHandler(conn db.Connection) context.Handler {
+	return func(ctx *context.Context) {
+		if err := j.Revoke(conn, formOrJSON(ctx, "refresh_token")["refresh_token"]); err != nil {
+			jsonFail(ctx, http.StatusInternalServerError, err.Error())
+			return
+		}
+		ctx.JSON(http.StatusOK, map[string]interface{}{
+			"code": http.StatusOK,
+			"msg":  "ok",
+		})
+	}
+}
+
+// JWKSHandler serve the JSON Web Key Set.
+func (j *JWT) JWKSHandler(ctx *context.Context) {
+	ctx.JSON(http.StatusOK, j.JWKS())
+}
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+	_ "github.com/purpose168/GoAdmin/modules/db/drivers/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWT(t *testing.T) {
+	key1, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	key2, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+
+	j := NewJWT(JWTConfig{Issuer: "goadmin"}, key1)
+
+	now := time.Now()
+	token, err := j.Sign(Claims{Issuer: "goadmin", Subject: "1", IssuedAt: now.Unix(), ExpiresAt: now.Add(time.Minute).Unix()})
+	assert.Nil(t, err)
+
+	claims, err := j.Verify(token)
+	assert.Nil(t, err)
+	assert.Equal(t, claims.Subject, "1")
+
+	// tampered
+	parts := strings.Split(token, ".")
+	_, err = j.Verify(parts[0] + "." + b64.EncodeToString([]byte(`{"sub":"2","exp":9999999999}`)) + "." + parts[2])
+	assert.Equal(t, err, ErrInvalidToken)
+
+	// expired
+	expired, _ := j.Sign(Claims{Issuer: "goadmin", Subject: "1", ExpiresAt: now.Add(-time.Second).Unix()})
+	_, err = j.Verify(expired)
+	assert.Equal(t, err, ErrInvalidToken)
+
+	// the tokens of the retired key are still valid after rotation.
+	j.Rotate(key2)
+	_, err = j.Verify(token)
+	assert.Nil(t, err)
+	assert.Equal(t, len(j.JWKS()["keys"].([]map[string]string)), 2)
+
+	token2, _ := j.Sign(Claims{Issuer: "goadmin", Subject: "1", ExpiresAt: now.Add(time.Minute).Unix()})
+	assert.Equal(t, j.JWKS()["keys"].([]map[string]string)[0]["kid"], keyID(&key2.PublicKey))
+	_, err = j.Verify(token2)
+	assert.Nil(t, err)
+
+	// other issuers
+	other := NewJWT(JWTConfig{Issuer: "others"}, key2)
+	_, err = other.Verify(token2)
+	assert.Equal(t, err, ErrInvalidToken)
+}
+
+func TestClaimRefreshToken(t *testing.T) {
+	conn := db.GetSqliteDB()
+	conn.InitDB(config.DatabaseList{"default": {
+		Driver: db.DriverSqlite,
+		File:   filepath.Join(t.TempDir(), "jwt.db"),
+	}})
+	migration, err := os.ReadFile("../../data/migrations/admin_2026_10_17_000000_sqlite.sql")
+	assert.Nil(t, err)
+	_, err = conn.Exec(string(migration))
+	assert.Nil(t, err)
+
+	insert := func(token string, expiresAt int64) {
+		_, err := db.WithDriver(conn).Table(RefreshTokenTableName).Insert(dialect.H{
+			"user_id":    7,
+			"token_hash": hashToken(token),
+			"expires_at": expiresAt,
+		})
+		assert.Nil(t, err)
+	}
+
+	insert("a", time.Now().Add(time.Hour).Unix())
+	id, err := claimRefreshToken(conn, "a")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), id)
+
+	// the refresh token can not be reused.
+	_, err = claimRefreshToken(conn, "a")
+	assert.Equal(t, ErrInvalidToken, err)
+
+	// only one of the concurrent requests claims the token.
+	insert("b", time.Now().Add(time.Hour).Unix())
+	var (
+		wg      sync.WaitGroup
+		claimed int32
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := claimRefreshToken(conn, "b"); err == nil {
+				atomic.AddInt32(&claimed, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), claimed)
+
+	// the expired token is deleted as well.
+	insert("c", time.Now().Add(-time.Second).Unix())
+	_, err = claimRefreshToken(conn, "c")
+	assert.Equal(t, ErrInvalidToken, err)
+	item, _ := db.WithDriver(conn).Table(RefreshTokenTableName).Where("token_hash", "=", hashToken("c")).First()
+	assert.Nil(t, item)
+}
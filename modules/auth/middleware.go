@@ -27,6 +27,7 @@ type Invoker struct {
 	prefix                 string
 	authFailCallback       MiddlewareCallback
 	permissionDenyCallback MiddlewareCallback
+	skipPermission         bool
 	conn                   db.Connection
 }
 
@@ -35,6 +36,12 @@ func Middleware(conn db.Connection) context.Handler {
 	return DefaultInvoker(conn).Middleware()
 }
 
+// SignedInMiddleware only checks the sign in of the user, it is used by the
+// pages open to all the users, such as the help pages.
+func SignedInMiddleware(conn db.Connection) context.Handler {
+	return DefaultInvoker(conn).SkipPermission().Middleware()
+}
+
 // DefaultInvoker return a default Invoker.
 func DefaultInvoker(conn db.Connection) *Invoker {
 	return &Invoker{
@@ -50,8 +57,9 @@ func DefaultInvoker(conn db.Connection) *Invoker {
 				return
 			}
 			param := ""
-			if ref := ctx.Referer(); ref != "" {
-				param = "?ref=" + url.QueryEscape(ref)
+			page := ctx.Headers(constant.PjaxHeader) != "" || ctx.Headers("X-Requested-With") != "XMLHttpRequest"
+			if ref := loginRef(ctx.Method(), ctx.Request.URL.RequestURI(), ctx.Referer(), page); ref != "" {
+				param = "?" + RefKey + "=" + url.QueryEscape(ref)
 			}
 
 			u := config.Url(config.GetLoginUrl() + param)
@@ -119,6 +127,12 @@ func (invoker *Invoker) SetPermissionDenyCallback(callback MiddlewareCallback) *
 	return invoker
 }
 
+// SkipPermission make the Invoker only check the sign in of the user.
+func (invoker *Invoker) SkipPermission() *Invoker {
+	invoker.skipPermission = true
+	return invoker
+}
+
 // MiddlewareCallback is type of callback function.
 type MiddlewareCallback func(ctx *context.Context)
 
@@ -127,7 +141,7 @@ func (invoker *Invoker) Middleware() context.Handler {
 	return func(ctx *context.Context) {
 		user, authOk, permissionOk := Filter(ctx, invoker.conn)
 
-		if authOk && permissionOk {
+		if authOk && (permissionOk || invoker.skipPermission) {
 			ctx.SetUserValue("user", user)
 			ctx.Next()
 			return
@@ -168,6 +182,10 @@ func Filter(ctx *context.Context, conn db.Connection) (models.UserModel, bool, b
 		return user, false, false
 	}
 
+	if !checkIdle(ctx, ses) {
+		return user, false, false
+	}
+
 	user, ok = GetCurUserByID(int64(id), conn)
 
 	if !ok {
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"net/url"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+)
+
+// User return the signed in user of the context, false when the context is
+// not passed through the auth middleware.
+func User(ctx *context.Context) (models.UserModel, bool) {
+	if ctx == nil {
+		return models.UserModel{}, false
+	}
+	user, ok := ctx.User().(models.UserModel)
+	return user, ok && !user.IsEmpty()
+}
+
+// Can check the signed in user of the context has all the permissions of the
+// slugs, so the custom pages and buttons are gated by the same permissions as
+// the admin plugin:
+//
+//	if auth.Can(ctx, "users.edit") {
+//		...
+//	}
+func Can(ctx *context.Context, slugs ...string) bool {
+	user, ok := User(ctx)
+	return ok && user.Can(slugs...)
+}
+
+// CanAny check the signed in user of the context has any of the permissions
+// of the slugs.
+func CanAny(ctx *context.Context, slugs ...string) bool {
+	user, ok := User(ctx)
+	return ok && user.CanAny(slugs...)
+}
+
+// CanVisit check the signed in user of the context can visit the path by the
+// method, the path is without the url prefix, such as "/info/users".
+func CanVisit(ctx *context.Context, path, method string) bool {
+	user, ok := User(ctx)
+	return ok && user.CheckPermissionByUrlMethod(config.Url(path), method, url.Values{})
+}
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCan(t *testing.T) {
+
+	ctx := context.NewContext(httptest.NewRequest(http.MethodGet, "/admin", nil))
+
+	assert.False(t, Can(ctx))
+	assert.False(t, CanAny(ctx, "users.edit"))
+	assert.False(t, CanVisit(ctx, "/info/users", "GET"))
+
+	ctx.SetUserValue("user", models.UserModel{
+		Id: 1,
+		Permissions: []models.PermissionModel{
+			{Slug: "users.edit", HttpMethod: []string{"GET"}, HttpPath: []string{"/info/users"}},
+			{Slug: "posts.view", HttpMethod: []string{"GET"}, HttpPath: []string{"/info/posts"}},
+		},
+	})
+
+	assert.True(t, Can(ctx, "users.edit"))
+	assert.True(t, Can(ctx, "users.edit", "posts.view"))
+	assert.False(t, Can(ctx, "users.edit", "users.delete"))
+	assert.True(t, CanAny(ctx, "users.delete", "posts.view"))
+	assert.False(t, CanAny(ctx, "users.delete"))
+	assert.True(t, CanVisit(ctx, "/info/users", "GET"))
+	assert.False(t, CanVisit(ctx, "/info/roles", "GET"))
+
+	ctx.SetUserValue("user", models.UserModel{
+		Id: 1,
+		Permissions: []models.PermissionModel{
+			{Slug: "*", HttpMethod: []string{""}, HttpPath: []string{"*"}},
+		},
+	})
+
+	assert.True(t, Can(ctx, "users.delete"))
+	assert.True(t, CanVisit(ctx, "/info/roles", "POST"))
+}
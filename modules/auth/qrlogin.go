@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+)
+
+const (
+	// QRLoginPath is the path creating the code shown by the login page.
+	QRLoginPath = "/qrlogin"
+	// QRLoginStatusPath is the path polled by the login page, which logs in
+	// the browser once the code is approved.
+	QRLoginStatusPath = "/qrlogin/status"
+	// QRLoginApprovePath is the path of the code, the page scanned by the
+	// signed in mobile to approve the login.
+	QRLoginApprovePath = "/qrlogin/approve"
+)
+
+// QRLoginTTL is how long a code of the qr login can be approved.
+const QRLoginTTL = 2 * time.Minute
+
+// qrLoginSidPrefix is the prefix of the sessions keeping the codes, the codes
+// are kept as the sessions so all the instances of the admin share them. The
+// sessions have no user, so they are never signed in.
+const qrLoginSidPrefix = "qr_"
+
+// The states of the qr login.
+const (
+	QRLoginPending  = "pending"
+	QRLoginApproved = "approved"
+	QRLoginExpired  = "expired"
+)
+
+// QRLogin is a code of the qr login.
+//
+// The Code is shown in the qr code of the login page and approved by the
+// mobile, the Token is kept by the login page to claim the login, so the
+// ones seeing the qr code cannot log in with it.
+type QRLogin struct {
+	Code      string
+	Token     string
+	IP        string
+	UserAgent string
+	Created   time.Time
+	UserID    int64
+}
+
+// Approved reports whether the code is approved.
+func (q QRLogin) Approved() bool {
+	return q.UserID != 0
+}
+
+// Expired reports whether the code is too old to be approved.
+func (q QRLogin) Expired() bool {
+	return time.Since(q.Created) > QRLoginTTL
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func hashQRToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewQRLogin create a code of the qr login for the browser of the ctx.
+func NewQRLogin(ctx *context.Context, conn db.Connection) (QRLogin, error) {
+	q := QRLogin{
+		Code:      randomHex(16),
+		Token:     randomHex(16),
+		IP:        ctx.LocalIP(),
+		UserAgent: ctx.Headers("User-Agent"),
+		Created:   time.Now(),
+	}
+	err := newDBDriver(conn).Update(qrLoginSidPrefix+q.Code, map[string]interface{}{
+		"qr_token":   hashQRToken(q.Token),
+		"qr_ip":      q.IP,
+		"qr_agent":   q.UserAgent,
+		"qr_created": q.Created.Unix(),
+		"qr_user_id": 0,
+	})
+	return q, err
+}
+
+// GetQRLogin return the code of the qr login, the Token is not returned.
+func GetQRLogin(code string, conn db.Connection) (QRLogin, bool) {
+	q, _, ok := loadQRLogin(newDBDriver(conn), code)
+	return q, ok
+}
+
+func loadQRLogin(driver *DBDriver, code string) (QRLogin, map[string]interface{}, bool) {
+	if code == "" {
+		return QRLogin{}, nil, false
+	}
+	values, err := driver.Load(qrLoginSidPrefix + code)
+	if err != nil {
+		return QRLogin{}, nil, false
+	}
+	created, ok := values["qr_created"].(float64)
+	if !ok {
+		return QRLogin{}, nil, false
+	}
+	q := QRLogin{Code: code, Created: time.Unix(int64(created), 0)}
+	q.IP, _ = values["qr_ip"].(string)
+	q.UserAgent, _ = values["qr_agent"].(string)
+	if id, ok := values["qr_user_id"].(float64); ok {
+		q.UserID = int64(id)
+	}
+	return q, values, true
+}
+
+// ApproveQRLogin approve the code for the user, the browser of the code is
+// logged in as the user at the next poll.
+func ApproveQRLogin(code string, user models.UserModel, conn db.Connection) bool {
+	driver := newDBDriver(conn)
+	q, values, ok := loadQRLogin(driver, code)
+	if !ok || q.Expired() || q.Approved() {
+		return false
+	}
+	values["qr_user_id"] = user.Id
+	return driver.Update(qrLoginSidPrefix+code, values) == nil
+}
+
+// ClaimQRLogin return the state of the code for the browser keeping the
+// token, and set the cookie of the user approving the code when it is
+// approved. The approved codes are claimed once, in twice the QRLoginTTL.
+func ClaimQRLogin(ctx *context.Context, code, token string, conn db.Connection) (string, error) {
+	driver := newDBDriver(conn)
+	q, values, ok := loadQRLogin(driver, code)
+	if !ok {
+		return QRLoginExpired, nil
+	}
+	hash, _ := values["qr_token"].(string)
+	if token == "" || subtle.ConstantTimeCompare([]byte(hash), []byte(hashQRToken(token))) != 1 {
+		return QRLoginExpired, nil
+	}
+	if !q.Approved() && !q.Expired() {
+		return QRLoginPending, nil
+	}
+
+	err := driver.table().Where("sid", "=", qrLoginSidPrefix+code).Delete()
+	if db.CheckError(err, db.DELETE) {
+		return "", err
+	}
+	if !q.Approved() || time.Since(q.Created) > 2*QRLoginTTL {
+		return QRLoginExpired, nil
+	}
+
+	user := models.User().SetConn(conn).Find(q.UserID)
+	if user.IsEmpty() {
+		return QRLoginExpired, nil
+	}
+	if err := SetCookie(ctx, user, conn); err != nil {
+		return "", err
+	}
+	return QRLoginApproved, nil
+}
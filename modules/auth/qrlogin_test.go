@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dbtest"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQRLogin(t *testing.T) {
+	initConfig()
+
+	session := func(created time.Time, userID int64) *dbtest.Connection {
+		return dbtest.New(db.DriverMysql).OnQuery("from `goadmin_session`", map[string]interface{}{
+			"sid": qrLoginSidPrefix + "code",
+			"values": utils.JSON(map[string]interface{}{
+				"qr_token":   hashQRToken("token"),
+				"qr_ip":      "10.0.0.1",
+				"qr_agent":   "Firefox",
+				"qr_created": created.Unix(),
+				"qr_user_id": userID,
+			}),
+		})
+	}
+	ctx := context.NewContext(httptest.NewRequest(http.MethodPost, "/admin/qrlogin/status", nil))
+
+	q, ok := GetQRLogin("code", session(time.Now(), 0))
+	assert.True(t, ok)
+	assert.Equal(t, "10.0.0.1", q.IP)
+	assert.Equal(t, "Firefox", q.UserAgent)
+	assert.False(t, q.Approved())
+	assert.False(t, q.Expired())
+
+	_, ok = GetQRLogin("", session(time.Now(), 0))
+	assert.False(t, ok)
+	_, ok = GetQRLogin("code", dbtest.New(db.DriverMysql))
+	assert.False(t, ok)
+
+	// the code is pending until approved, and cannot be claimed without the
+	// token of the login page.
+	state, err := ClaimQRLogin(ctx, "code", "token", session(time.Now(), 0))
+	assert.NoError(t, err)
+	assert.Equal(t, QRLoginPending, state)
+	state, _ = ClaimQRLogin(ctx, "code", "wrong", session(time.Now(), 1))
+	assert.Equal(t, QRLoginExpired, state)
+	state, _ = ClaimQRLogin(ctx, "code", "", session(time.Now(), 1))
+	assert.Equal(t, QRLoginExpired, state)
+
+	// the old codes are deleted.
+	conn := session(time.Now().Add(-QRLoginTTL-time.Second), 0)
+	state, _ = ClaimQRLogin(ctx, "code", "token", conn)
+	assert.Equal(t, QRLoginExpired, state)
+	assert.Contains(t, conn.Calls()[len(conn.Calls())-1].Query, "delete from `goadmin_session`")
+
+	// the expired and the approved codes cannot be approved.
+	assert.False(t, ApproveQRLogin("code", models.UserModel{Id: 1}, session(time.Now().Add(-QRLoginTTL-time.Second), 0)))
+	assert.False(t, ApproveQRLogin("code", models.UserModel{Id: 1}, session(time.Now(), 2)))
+}
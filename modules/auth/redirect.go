@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+)
+
+// RefKey is the query key of the login page keeping the url to return to
+// after the login.
+const RefKey = "ref"
+
+// pjaxKey is the query key of the pjax requests.
+const pjaxKey = "_pjax"
+
+// SafeRedirect return the path and the query of the url when it is a page
+// under the prefix of the admin, so only the pages of the admin are returned
+// to after the login. The urls with a scheme or a host, the login, the logout
+// and the urls which are not pages are rejected.
+func SafeRedirect(ref string) (string, bool) {
+	if ref == "" || strings.ContainsAny(ref, "\\\r\n\t") || strings.HasPrefix(ref, "//") {
+		return "", false
+	}
+	u, err := url.Parse(ref)
+	if err != nil || u.Opaque != "" || u.Scheme != "" || u.Host != "" || u.User != nil {
+		return "", false
+	}
+
+	path := u.EscapedPath()
+	if path == "" || path[0] != '/' || strings.HasPrefix(path, "//") {
+		return "", false
+	}
+	prefix := config.PrefixFixSlash()
+	if prefix != "" && path != prefix && !strings.HasPrefix(path, prefix+"/") {
+		return "", false
+	}
+	switch strings.TrimRight(path, "/") {
+	case config.Url(config.GetLoginUrl()), config.Url("/logout"), config.Url("/signin"):
+		return "", false
+	}
+	if strings.HasPrefix(path, config.Url("/assets/")) {
+		return "", false
+	}
+
+	query := u.Query()
+	query.Del(pjaxKey)
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return path, true
+}
+
+// loginRef return the url to return to after the login of the request
+// failing the auth, which is the page requested, or the page sending the
+// request when it is an ajax or a form request.
+func loginRef(method, requestURI, referer string, page bool) string {
+	ref := refererPath(referer)
+	if method == "GET" && page {
+		ref = requestURI
+	}
+	if r, ok := SafeRedirect(ref); ok {
+		return r
+	}
+	if r, ok := SafeRedirect(refererPath(referer)); ok {
+		return r
+	}
+	return ""
+}
+
+// refererPath return the path and the query of the referer, which is an
+// absolute url of the page sending the request.
+func refererPath(referer string) string {
+	u, err := url.Parse(referer)
+	if err != nil || u.Opaque != "" {
+		return ""
+	}
+	if u.RawQuery != "" {
+		return u.EscapedPath() + "?" + u.RawQuery
+	}
+	return u.EscapedPath()
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/stretchr/testify/assert"
+)
+
+var configOnce sync.Once
+
+// initConfig initialize the config of the tests once unless
+// TestCheckPermissions has, as the config can not be initialized twice.
+func initConfig() {
+	configOnce.Do(func() {
+		if config.GetUrlPrefix() == "" {
+			config.Initialize(&config.Config{UrlPrefix: "admin"})
+		}
+	})
+}
+
+func TestSafeRedirect(t *testing.T) {
+	initConfig()
+
+	for ref, want := range map[string]string{
+		"/admin/info/user?page=2":                  "/admin/info/user?page=2",
+		"http://example.com/admin/info/user":       "",
+		"/admin/info/user?_pjax=%23pjax-container": "/admin/info/user",
+		"/admin":                              "/admin",
+		"":                                    "",
+		"/other":                              "",
+		"/administrator":                      "",
+		"//evil.com/admin":                    "",
+		"///evil.com/admin":                   "",
+		"/\\evil.com/admin":                   "",
+		"/admin\\..\\evil":                    "",
+		"javascript:alert(1)":                 "",
+		"/admin/login":                        "",
+		"/admin/logout":                       "",
+		"/admin/assets/login/dist/all.min.js": "",
+		"https://evil.com/admin/info/user?x=1#frag": "",
+		"/admin/info/user?x=1#frag":                 "/admin/info/user?x=1",
+	} {
+		got, ok := SafeRedirect(ref)
+		assert.Equal(t, want, got, ref)
+		assert.Equal(t, want != "", ok, ref)
+	}
+}
+
+func TestLoginRef(t *testing.T) {
+	initConfig()
+
+	assert.Equal(t, "/admin/info/user", loginRef("GET", "/admin/info/user", "", true))
+	assert.Equal(t, "/admin/info/post", loginRef("GET", "/admin/info/user/data", "http://localhost/admin/info/post", false))
+	assert.Equal(t, "/admin/info/post", loginRef("POST", "/admin/edit/user", "http://localhost/admin/info/post", true))
+	assert.Equal(t, "/admin/info/post?page=2", loginRef("POST", "/admin/edit/user", "http://localhost/admin/info/post?page=2", true))
+	assert.Equal(t, "", loginRef("POST", "/admin/edit/user", "http://localhost//evil.com/admin", true))
+	assert.Equal(t, "", loginRef("POST", "/admin/edit/user", "", true))
+}
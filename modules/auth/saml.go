@@ -0,0 +1,225 @@
+//go:build !goadmin_nosaml
+
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/crewjam/saml"
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+)
+
+const samlRequestCookie = "go_admin_saml_request"
+
+// SAML is a SAML 2.0 service provider signing in the users of the identity
+// provider, it works alongside the login form.
+type SAML struct {
+	cfg  SAMLConfig
+	sp   saml.ServiceProvider
+	conn db.Connection
+}
+
+// NewSAML return the service provider of the config.
+func NewSAML(cfg SAMLConfig) (*SAML, error) {
+	pair, err := tls.LoadX509KeyPair(cfg.CertificateFile, cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := pair.PrivateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("saml: the key must be a RSA private key")
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+
+	idp := new(saml.EntityDescriptor)
+	if err := xml.Unmarshal(cfg.IDPMetadata, idp); err != nil {
+		return nil, fmt.Errorf("saml: parse idp metadata error: %w", err)
+	}
+
+	return &SAML{
+		cfg: cfg,
+		sp: saml.ServiceProvider{
+			EntityID:          cfg.EntityID,
+			Key:               key,
+			Certificate:       cert,
+			IDPMetadata:       idp,
+			AllowIDPInitiated: cfg.AllowIDPInitiated,
+		},
+	}, nil
+}
+
+// Init set the database connection and the urls of the service provider. It
+// is called by the admin plugin after the config is loaded.
+func (s *SAML) Init(conn db.Connection) error {
+	root, err := url.Parse(strings.TrimRight(s.cfg.RootURL, "/"))
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	s.sp.MetadataURL = *root.ResolveReference(&url.URL{Path: root.Path + config.Url(SAMLMetadataPath)})
+	s.sp.AcsURL = *root.ResolveReference(&url.URL{Path: root.Path + config.Url(SAMLACSPath)})
+	if s.sp.EntityID == "" {
+		s.sp.EntityID = s.sp.MetadataURL.String()
+	}
+	return nil
+}
+
+// LoginURL return the url starting the sign in of the identity provider.
+func (s *SAML) LoginURL() string {
+	return config.Url(SAMLLoginPath)
+}
+
+// Metadata serve the metadata of the service provider.
+func (s *SAML) Metadata(ctx *context.Context) {
+	buf, err := xml.MarshalIndent(s.sp.Metadata(), "", "  ")
+	if err != nil {
+		ctx.Data(http.StatusInternalServerError, "text/plain; charset=utf-8", []byte(err.Error()))
+		return
+	}
+	ctx.Data(http.StatusOK, "application/samlmetadata+xml", buf)
+}
+
+// Login redirect to the identity provider, the page to return to after the
+// sign in is kept in the relay state.
+func (s *SAML) Login(ctx *context.Context) {
+	req, err := s.sp.MakeAuthenticationRequest(s.sp.GetSSOBindingLocation(saml.HTTPRedirectBinding),
+		saml.HTTPRedirectBinding, saml.HTTPPostBinding)
+	if err != nil {
+		s.fail(ctx, err)
+		return
+	}
+	relayState, _ := SafeRedirect(ctx.Query(RefKey))
+	u, err := req.Redirect(relayState, &s.sp)
+	if err != nil {
+		s.fail(ctx, err)
+		return
+	}
+	ctx.SetCookie(&http.Cookie{
+		Name:     samlRequestCookie,
+		Value:    req.ID,
+		Path:     config.Url("/saml"),
+		MaxAge:   300,
+		HttpOnly: true,
+		Secure:   s.sp.AcsURL.Scheme == "https",
+		// the response is posted by the identity provider from another site.
+		SameSite: http.SameSiteNoneMode,
+	})
+	ctx.Redirect(u.String())
+}
+
+// ACS is the assertion consumer service, it checks the response of the
+// identity provider, syncs the user and signs in.
+func (s *SAML) ACS(ctx *context.Context) {
+	ids := make([]string, 0, 1)
+	if id := ctx.Cookie(samlRequestCookie); id != "" {
+		ids = append(ids, id)
+	}
+	ctx.SetCookie(&http.Cookie{Name: samlRequestCookie, Path: config.Url("/saml"), MaxAge: -1})
+
+	assertion, err := s.sp.ParseResponse(ctx.Request, ids)
+	if err != nil {
+		var invalid *saml.InvalidResponseError
+		if errors.As(err, &invalid) {
+			err = invalid.PrivateErr
+		}
+		s.fail(ctx, err)
+		return
+	}
+
+	user, err := s.user(assertion)
+	if err != nil {
+		s.fail(ctx, err)
+		return
+	}
+
+	if err := SetCookie(ctx, user, s.conn); err != nil {
+		s.fail(ctx, err)
+		return
+	}
+	if ref, ok := SafeRedirect(ctx.FormValue("RelayState")); ok {
+		ctx.Redirect(ref)
+		return
+	}
+	ctx.Redirect(config.GetIndexURL())
+}
+
+func (s *SAML) fail(ctx *context.Context, err error) {
+	logger.ErrorCtx(ctx, "saml sign in error: %s", err)
+	ctx.Data(http.StatusForbidden, "text/plain; charset=utf-8", []byte("saml sign in fail"))
+}
+
+// attributes return the values of the attributes by name and friendly name.
+func attributes(assertion *saml.Assertion) map[string][]string {
+	attrs := make(map[string][]string)
+	for _, statement := range assertion.AttributeStatements {
+		for _, attr := range statement.Attributes {
+			values := make([]string, len(attr.Values))
+			for i, v := range attr.Values {
+				values[i] = v.Value
+			}
+			attrs[attr.Name] = append(attrs[attr.Name], values...)
+			if attr.FriendlyName != "" && attr.FriendlyName != attr.Name {
+				attrs[attr.FriendlyName] = append(attrs[attr.FriendlyName], values...)
+			}
+		}
+	}
+	return attrs
+}
+
+func first(values []string) string {
+	if len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// roles return the role slugs mapped from the attributes.
+func (s *SAML) roles(attrs map[string][]string) []string {
+	roles := make([]string, 0, len(s.cfg.DefaultRoles))
+	roles = append(roles, s.cfg.DefaultRoles...)
+	for _, value := range attrs[s.cfg.RoleAttribute] {
+		for _, role := range s.cfg.RoleMapping[value] {
+			if !utils.InArray(roles, role) {
+				roles = append(roles, role)
+			}
+		}
+	}
+	return roles
+}
+
+// user find or create the user of the assertion and sync the roles.
+func (s *SAML) user(assertion *saml.Assertion) (models.UserModel, error) {
+	attrs := attributes(assertion)
+
+	username := ""
+	if s.cfg.UsernameAttribute != "" {
+		username = first(attrs[s.cfg.UsernameAttribute])
+	} else if assertion.Subject != nil && assertion.Subject.NameID != nil {
+		username = assertion.Subject.NameID.Value
+	}
+	if username == "" {
+		return models.UserModel{}, errors.New("saml: no username in the assertion")
+	}
+	name := first(attrs[s.cfg.NameAttribute])
+	if name == "" {
+		name = username
+	}
+
+	return syncExternalUser(s.conn, username, name, s.roles(attrs), s.cfg.CreateUser, s.cfg.RoleAttribute != "")
+}
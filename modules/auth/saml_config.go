@@ -0,0 +1,44 @@
+package auth
+
+// SAML routes, relative to the url prefix.
+const (
+	SAMLMetadataPath = "/saml/metadata"
+	SAMLLoginPath    = "/saml/login"
+	SAMLACSPath      = "/saml/acs"
+)
+
+// SAMLConfig is the config of the SAML 2.0 service provider.
+type SAMLConfig struct {
+	// RootURL is the external url of the site, such as "https://admin.example.com",
+	// the metadata and ACS urls are generated from it and the url prefix.
+	RootURL string
+	// EntityID of the service provider, default the metadata url.
+	EntityID string
+
+	// KeyFile and CertificateFile are the PEM files of the RSA key pair used to
+	// sign the requests and decrypt the assertions.
+	KeyFile         string
+	CertificateFile string
+
+	// IDPMetadata is the XML metadata of the identity provider.
+	IDPMetadata []byte
+
+	// UsernameAttribute is the attribute used as the username, default the NameID
+	// of the subject.
+	UsernameAttribute string
+	// NameAttribute is the attribute used as the name, default the username.
+	NameAttribute string
+	// RoleAttribute is the attribute of the groups or roles of the user, the
+	// roles are not synced when it is empty.
+	RoleAttribute string
+	// RoleMapping map the values of RoleAttribute to role slugs.
+	RoleMapping map[string][]string
+	// DefaultRoles are the role slugs given to all the users signed in by SAML.
+	DefaultRoles []string
+
+	// CreateUser create the users which do not exist, otherwise the sign in of
+	// them fails.
+	CreateUser bool
+	// AllowIDPInitiated accept the responses not requested by the login url.
+	AllowIDPInitiated bool
+}
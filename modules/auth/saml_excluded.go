@@ -0,0 +1,35 @@
+//go:build goadmin_nosaml
+
+package auth
+
+import (
+	"errors"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/db"
+)
+
+// SAML is the SAML 2.0 service provider, which is excluded by the
+// goadmin_nosaml build tag.
+type SAML struct{}
+
+// NewSAML fail as the SAML 2.0 sign in is excluded by the goadmin_nosaml
+// build tag.
+func NewSAML(SAMLConfig) (*SAML, error) {
+	return nil, errors.New("saml: excluded by the goadmin_nosaml build tag")
+}
+
+// Init implements the method of the service provider.
+func (s *SAML) Init(db.Connection) error { return nil }
+
+// LoginURL implements the method of the service provider.
+func (s *SAML) LoginURL() string { return "" }
+
+// Metadata implements the method of the service provider.
+func (s *SAML) Metadata(*context.Context) {}
+
+// Login implements the method of the service provider.
+func (s *SAML) Login(*context.Context) {}
+
+// ACS implements the method of the service provider.
+func (s *SAML) ACS(*context.Context) {}
@@ -0,0 +1,38 @@
+//go:build !goadmin_nosaml
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/crewjam/saml"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSAMLRoles(t *testing.T) {
+	assertion := &saml.Assertion{
+		AttributeStatements: []saml.AttributeStatement{{
+			Attributes: []saml.Attribute{{
+				Name:         "urn:oid:1.3.6.1.4.1.5923.1.5.1.1",
+				FriendlyName: "groups",
+				Values:       []saml.AttributeValue{{Value: "admins"}, {Value: "ops"}, {Value: "sales"}},
+			}},
+		}},
+	}
+
+	s := &SAML{cfg: SAMLConfig{
+		RoleAttribute: "groups",
+		RoleMapping: map[string][]string{
+			"admins": {"administrator", "operator"},
+			"ops":    {"operator"},
+		},
+		DefaultRoles: []string{"viewer"},
+	}}
+
+	attrs := attributes(assertion)
+	assert.Equal(t, attrs["groups"], attrs["urn:oid:1.3.6.1.4.1.5923.1.5.1.1"])
+	assert.Equal(t, s.roles(attrs), []string{"viewer", "administrator", "operator"})
+
+	s.cfg.RoleAttribute = "roles"
+	assert.Equal(t, s.roles(attrs), []string{"viewer"})
+}
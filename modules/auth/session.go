@@ -5,7 +5,11 @@
 package auth
 
 import (
+	gocontext "context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -15,6 +19,7 @@ import (
 	"github.com/purpose168/GoAdmin/modules/db"
 	"github.com/purpose168/GoAdmin/modules/db/dialect"
 	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/redis"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules"
 )
 
@@ -28,6 +33,38 @@ func newDBDriver(conn db.Connection) *DBDriver {
 	}
 }
 
+// sessionRedis is the redis store of the sessions, see UseRedisSession.
+var sessionRedis redisStore
+
+// UseRedisSession store the sessions in the redis of the service instead of
+// the goadmin_session table. It is called by the engine when the redis is
+// configured.
+func UseRedisSession(srv *redis.Service) {
+	if srv == nil {
+		sessionRedis = nil
+		return
+	}
+	sessionRedis = srv
+}
+
+// newDriver return the redis driver when UseRedisSession is called, otherwise
+// the database driver.
+func newDriver(conn db.Connection) PersistenceDriver {
+	if sessionRedis != nil {
+		return &RedisDriver{store: sessionRedis}
+	}
+	return newDBDriver(conn)
+}
+
+// DeleteUserSessions delete the sessions of the user, so that the user is
+// signed out, such as when the user is deactivated.
+func DeleteUserSessions(conn db.Connection, userID int64) error {
+	if sessionRedis != nil {
+		return (&RedisDriver{store: sessionRedis}).deleteUser(userID)
+	}
+	return newDBDriver(conn).deleteUser(userID)
+}
+
 // PersistenceDriver is a driver of storing and getting the session info.
 type PersistenceDriver interface {
 	Load(string) (map[string]interface{}, error)
@@ -36,7 +73,7 @@ type PersistenceDriver interface {
 
 // GetSessionByKey get the session value by key.
 func GetSessionByKey(sesKey, key string, conn db.Connection) (interface{}, error) {
-	m, err := newDBDriver(conn).Load(sesKey)
+	m, err := newDriver(conn).Load(sesKey)
 	return m[key], err
 }
 
@@ -79,6 +116,7 @@ func (ses *Session) Add(key string, value interface{}) error {
 		MaxAge:   config.GetSessionLifeTime(),
 		Expires:  time.Now().Add(ses.Expires),
 		HttpOnly: true,
+		Secure:   ses.Context.IsSecure(),
 		Path:     "/",
 	}
 	if config.GetDomain() != "" {
@@ -126,7 +164,7 @@ func InitSession(ctx *context.Context, conn db.Connection) (*Session, error) {
 		Cookie:  DefaultCookieKey,
 	})
 
-	sessions.UseDriver(newDBDriver(conn))
+	sessions.UseDriver(newDriver(conn))
 	sessions.Values = make(map[string]interface{})
 
 	return sessions.StartCtx(ctx)
@@ -233,6 +271,144 @@ func (driver *DBDriver) Update(sid string, values map[string]interface{}) error
 	return nil
 }
 
+func (driver *DBDriver) deleteUser(userID int64) error {
+	list, err := driver.table().Select("sid", "values").All()
+	if db.CheckError(err, db.QUERY) {
+		return err
+	}
+	for _, item := range list {
+		var values map[string]interface{}
+		if json.Unmarshal([]byte(db.GetValueFromDatabaseType(db.Varchar, item["values"], false).String()), &values) != nil {
+			continue
+		}
+		if id, ok := values[defaultUserIDSesKey].(float64); !ok || int64(id) != userID {
+			continue
+		}
+		err := driver.table().Where("sid", "=", item["sid"]).Delete()
+		if db.CheckError(err, db.DELETE) {
+			return err
+		}
+	}
+	return nil
+}
+
 func (driver *DBDriver) table() *db.SQL {
 	return db.Table(driver.tableName).WithDriver(driver.conn)
 }
+
+type redisStore interface {
+	Get(ctx gocontext.Context, key string) ([]byte, error)
+	Set(ctx gocontext.Context, key string, value interface{}, ttl time.Duration) error
+	Del(ctx gocontext.Context, keys ...string) error
+	Key(parts ...string) string
+}
+
+// RedisDriver is a driver which uses redis as a persistence tool. The values
+// expire after the session life time.
+type RedisDriver struct {
+	store redisStore
+}
+
+// Load implements the PersistenceDriver.Load.
+func (driver *RedisDriver) Load(sid string) (map[string]interface{}, error) {
+	data, err := driver.store.Get(gocontext.Background(), driver.store.Key("session", sid))
+	if err == redis.Nil {
+		return map[string]interface{}{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]interface{}
+	err = json.Unmarshal(data, &values)
+	return values, err
+}
+
+// Update implements the PersistenceDriver.Update. Like the DBDriver, the
+// session of the same values stored by another sid is removed unless the
+// NoLimitLoginIP is set.
+func (driver *RedisDriver) Update(sid string, values map[string]interface{}) error {
+	if sid == "" {
+		return nil
+	}
+	ctx := gocontext.Background()
+	key := driver.store.Key("session", sid)
+	if len(values) == 0 {
+		return driver.store.Del(ctx, key)
+	}
+	valuesByte, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	ttl := time.Second * time.Duration(config.GetSessionLifeTime())
+	if !config.GetNoLimitLoginIP() {
+		sum := sha256.Sum256(valuesByte)
+		indexKey := driver.store.Key("session_values", hex.EncodeToString(sum[:]))
+		old, err := driver.store.Get(ctx, indexKey)
+		if err != nil && err != redis.Nil {
+			return err
+		}
+		if len(old) > 0 && string(old) != sid {
+			if err := driver.store.Del(ctx, driver.store.Key("session", string(old))); err != nil {
+				return err
+			}
+		}
+		if err := driver.store.Set(ctx, indexKey, sid, ttl); err != nil {
+			return err
+		}
+	}
+	if id, ok := values[defaultUserIDSesKey]; ok {
+		if f, ok := id.(float64); ok {
+			id = int64(f)
+		}
+		if err := driver.addUserSession(ctx, fmt.Sprint(id), sid, ttl); err != nil {
+			return err
+		}
+	}
+	return driver.store.Set(ctx, key, valuesByte, ttl)
+}
+
+// addUserSession add the sid into the sids of the user, see deleteUser.
+func (driver *RedisDriver) addUserSession(ctx gocontext.Context, userID, sid string, ttl time.Duration) error {
+	key := driver.store.Key("session_user", userID)
+	sids, err := driver.userSessions(ctx, key)
+	if err != nil {
+		return err
+	}
+	for _, s := range sids {
+		if s == sid {
+			return nil
+		}
+	}
+	data, err := json.Marshal(append(sids, sid))
+	if err != nil {
+		return err
+	}
+	return driver.store.Set(ctx, key, data, ttl)
+}
+
+func (driver *RedisDriver) userSessions(ctx gocontext.Context, key string) ([]string, error) {
+	data, err := driver.store.Get(ctx, key)
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var sids []string
+	_ = json.Unmarshal(data, &sids)
+	return sids, nil
+}
+
+func (driver *RedisDriver) deleteUser(userID int64) error {
+	ctx := gocontext.Background()
+	key := driver.store.Key("session_user", strconv.FormatInt(userID, 10))
+	sids, err := driver.userSessions(ctx, key)
+	if err != nil {
+		return err
+	}
+	keys := []string{key}
+	for _, sid := range sids {
+		keys = append(keys, driver.store.Key("session", sid))
+	}
+	return driver.store.Del(ctx, keys...)
+}
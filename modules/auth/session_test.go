@@ -0,0 +1,83 @@
+package auth
+
+import (
+	gocontext "context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryStore map[string][]byte
+
+func (m memoryStore) Get(_ gocontext.Context, key string) ([]byte, error) {
+	if v, ok := m[key]; ok {
+		return v, nil
+	}
+	return nil, redis.Nil
+}
+
+func (m memoryStore) Set(_ gocontext.Context, key string, value interface{}, _ time.Duration) error {
+	switch v := value.(type) {
+	case []byte:
+		m[key] = v
+	case string:
+		m[key] = []byte(v)
+	}
+	return nil
+}
+
+func (m memoryStore) Del(_ gocontext.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(m, key)
+	}
+	return nil
+}
+
+func (m memoryStore) Key(parts ...string) string {
+	return "goadmin:" + strings.Join(parts, ":")
+}
+
+func TestRedisDriver(t *testing.T) {
+	store := memoryStore{}
+	driver := &RedisDriver{store: store}
+
+	values, err := driver.Load("a")
+	assert.NoError(t, err)
+	assert.Empty(t, values)
+
+	assert.NoError(t, driver.Update("a", map[string]interface{}{"user_id": 1}))
+	values, err = driver.Load("a")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), values["user_id"])
+
+	// the login of the same user from another sid removes the old session.
+	assert.NoError(t, driver.Update("b", map[string]interface{}{"user_id": 1}))
+	values, _ = driver.Load("a")
+	assert.Empty(t, values)
+	values, _ = driver.Load("b")
+	assert.Equal(t, float64(1), values["user_id"])
+
+	assert.NoError(t, driver.Update("b", map[string]interface{}{}))
+	_, ok := store["goadmin:session:b"]
+	assert.False(t, ok)
+}
+
+func TestRedisDriver_DeleteUser(t *testing.T) {
+	store := memoryStore{}
+	driver := &RedisDriver{store: store}
+
+	assert.NoError(t, driver.Update("a", map[string]interface{}{"user_id": int64(1000000), "x": 1}))
+	assert.NoError(t, driver.Update("b", map[string]interface{}{"user_id": float64(1000000)}))
+	assert.NoError(t, driver.Update("c", map[string]interface{}{"user_id": 2}))
+
+	assert.NoError(t, driver.deleteUser(1000000))
+	values, _ := driver.Load("a")
+	assert.Empty(t, values)
+	values, _ = driver.Load("b")
+	assert.Empty(t, values)
+	values, _ = driver.Load("c")
+	assert.Equal(t, float64(2), values["user_id"])
+}
@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+)
+
+// WebhookConfig is the config of the webhook authentication.
+type WebhookConfig struct {
+	// URL of the endpoint, the credentials are posted to it as the JSON
+	// {"username": "...", "password": "..."}.
+	URL string
+	// Headers are added to the requests, such as the api key of the endpoint.
+	Headers map[string]string
+	// Timeout of the requests, default 5 seconds.
+	Timeout time.Duration
+
+	// CacheTTL is how long the successful results are cached, the cache is
+	// disabled when it is zero.
+	CacheTTL time.Duration
+	// Fallback check the local password when the endpoint is unavailable.
+	Fallback bool
+
+	// CreateUser create the users which do not exist, otherwise the sign in of
+	// them fails.
+	CreateUser bool
+	// RoleMapping map the roles of the response to role slugs, the roles are
+	// used as the slugs when it is nil.
+	RoleMapping map[string][]string
+}
+
+// WebhookUser is the response of the endpoint when the credentials are valid.
+// The endpoint responds 401 or 403 with {"message": "..."} when they are not.
+type WebhookUser struct {
+	ID       string   `json:"id"`
+	Username string   `json:"username"`
+	Name     string   `json:"name"`
+	Roles    []string `json:"roles"`
+}
+
+type webhookCache struct {
+	user    WebhookUser
+	expires time.Time
+}
+
+// Webhook authenticates the users by an external endpoint, the users are
+// synced into goadmin_users so that the sessions and permissions work as
+// usual.
+type Webhook struct {
+	cfg    WebhookConfig
+	client *http.Client
+
+	lock  sync.Mutex
+	cache map[string]webhookCache
+}
+
+// NewWebhook return the webhook authentication of the config.
+func NewWebhook(cfg WebhookConfig) *Webhook {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = time.Second * 5
+	}
+	return &Webhook{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+		cache:  make(map[string]webhookCache),
+	}
+}
+
+// SetHTTPClient set the http client of the requests.
+func (w *Webhook) SetHTTPClient(client *http.Client) *Webhook {
+	w.client = client
+	return w
+}
+
+// Processor return the processor of the connection for NewService.
+func (w *Webhook) Processor(conn db.Connection) Processor {
+	return func(ctx *context.Context) (models.UserModel, bool, string) {
+		return w.Auth(ctx, conn)
+	}
+}
+
+// errWebhookDenied is returned when the endpoint rejects the credentials.
+type errWebhookDenied struct {
+	msg string
+}
+
+func (e errWebhookDenied) Error() string {
+	return e.msg
+}
+
+// Auth check the credentials of the form by the endpoint, and sync the user.
+func (w *Webhook) Auth(ctx *context.Context, conn db.Connection) (models.UserModel, bool, string) {
+	username := ctx.FormValue("username")
+	password := ctx.FormValue("password")
+	if username == "" || password == "" {
+		return models.UserModel{}, false, "wrong password or username"
+	}
+
+	remote, err := w.Verify(username, password)
+	if err != nil {
+		var denied errWebhookDenied
+		if errors.As(err, &denied) {
+			return models.UserModel{}, false, denied.msg
+		}
+		logger.ErrorCtx(ctx, "webhook authentication error: %s", err)
+		if w.cfg.Fallback {
+			if user, ok := Check(password, username, conn); ok {
+				return user, true, ""
+			}
+			return models.UserModel{}, false, "wrong password or username"
+		}
+		return models.UserModel{}, false, "authentication service unavailable"
+	}
+
+	if remote.Username == "" {
+		remote.Username = username
+	}
+	user, err := syncExternalUser(conn, remote.Username, remote.Name, w.roles(remote.Roles), w.cfg.CreateUser, true)
+	if err != nil {
+		logger.ErrorCtx(ctx, "webhook authentication sync user error: %s", err)
+		return models.UserModel{}, false, "fail"
+	}
+	return user, true, ""
+}
+
+func (w *Webhook) roles(remote []string) []string {
+	if w.cfg.RoleMapping == nil {
+		return remote
+	}
+	roles := make([]string, 0, len(remote))
+	for _, role := range remote {
+		roles = append(roles, w.cfg.RoleMapping[role]...)
+	}
+	return roles
+}
+
+func webhookCacheKey(username, password string) string {
+	sum := sha256.Sum256([]byte(username + "\x00" + password))
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify post the credentials to the endpoint and return the user, the
+// results are cached by the CacheTTL of the config.
+func (w *Webhook) Verify(username, password string) (WebhookUser, error) {
+	key := webhookCacheKey(username, password)
+	if w.cfg.CacheTTL > 0 {
+		w.lock.Lock()
+		item, ok := w.cache[key]
+		w.lock.Unlock()
+		if ok && time.Now().Before(item.expires) {
+			return item.user, nil
+		}
+	}
+
+	user, err := w.post(username, password)
+	if err != nil {
+		return user, err
+	}
+
+	if w.cfg.CacheTTL > 0 {
+		now := time.Now()
+		w.lock.Lock()
+		for k, item := range w.cache {
+			if now.After(item.expires) {
+				delete(w.cache, k)
+			}
+		}
+		w.cache[key] = webhookCache{user: user, expires: now.Add(w.cfg.CacheTTL)}
+		w.lock.Unlock()
+	}
+	return user, nil
+}
+
+func (w *Webhook) post(username, password string) (WebhookUser, error) {
+	var user WebhookUser
+
+	body, err := json.Marshal(map[string]string{"username": username, "password": password})
+	if err != nil {
+		return user, err
+	}
+	req, err := http.NewRequest(http.MethodPost, w.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return user, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	for k, v := range w.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return user, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return user, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		var res struct {
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(data, &res)
+		if res.Message == "" {
+			res.Message = "wrong password or username"
+		}
+		return user, errWebhookDenied{msg: res.Message}
+	case resp.StatusCode != http.StatusOK:
+		return user, errors.New("webhook: " + resp.Status)
+	}
+
+	if err := json.Unmarshal(data, &user); err != nil {
+		return user, err
+	}
+	return user, nil
+}
@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookVerify(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, r.Header.Get("X-Api-Key"), "secret")
+		var req map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req["password"] != "123456" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"bad credentials"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"7","username":"` + req["username"] + `","name":"Jack","roles":["admin","dev"]}`))
+	}))
+	defer srv.Close()
+
+	w := NewWebhook(WebhookConfig{
+		URL:         srv.URL,
+		Headers:     map[string]string{"X-Api-Key": "secret"},
+		CacheTTL:    time.Minute,
+		RoleMapping: map[string][]string{"admin": {"administrator"}},
+	})
+
+	user, err := w.Verify("jack", "123456")
+	assert.Nil(t, err)
+	assert.Equal(t, user.Name, "Jack")
+	assert.Equal(t, w.roles(user.Roles), []string{"administrator"})
+
+	_, err = w.Verify("jack", "123456")
+	assert.Nil(t, err)
+	assert.Equal(t, calls, 1)
+
+	_, err = w.Verify("jack", "654321")
+	assert.Equal(t, err, errWebhookDenied{msg: "bad credentials"})
+	assert.Equal(t, calls, 2)
+
+	srv.Close()
+	_, err = w.Verify("rose", "123456")
+	assert.NotNil(t, err)
+}
@@ -17,6 +17,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/purpose168/GoAdmin/context"
 	"github.com/purpose168/GoAdmin/modules/logger"
 	"github.com/purpose168/GoAdmin/modules/utils"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
@@ -250,6 +251,61 @@ func GetStoreFromJSON(m string) Store {
 	return s
 }
 
+// EnvBadge is the badge of an environment shown in the nav bar, such as
+// "STAGING" in orange, so that the environment is not mistaken. The text is
+// the upper case env when it is empty.
+type EnvBadge struct {
+	Text       string `json:"text,omitempty" yaml:"text,omitempty" ini:"text,omitempty"`
+	Color      string `json:"color,omitempty" yaml:"color,omitempty" ini:"color,omitempty"`
+	TintHeader bool   `json:"tint_header,omitempty" yaml:"tint_header,omitempty" ini:"tint_header,omitempty"`
+}
+
+// Mail is the smtp server sending the mails of the admin, such as the
+// scheduled reports, see the modules/mail. The mails are not sent when Host is
+// empty.
+type Mail struct {
+	Host     string `json:"host,omitempty" yaml:"host,omitempty" ini:"host,omitempty"`
+	Port     int    `json:"port,omitempty" yaml:"port,omitempty" ini:"port,omitempty"`
+	Username string `json:"username,omitempty" yaml:"username,omitempty" ini:"username,omitempty"`
+	Password string `json:"password,omitempty" yaml:"password,omitempty" ini:"password,omitempty"`
+	From     string `json:"from,omitempty" yaml:"from,omitempty" ini:"from,omitempty"`
+}
+
+// AutoTLS is the certificates issued by Let's Encrypt for serving https
+// without a reverse proxy, see engine.ServeAutoTLS. Only the Domains are
+// issued, the certificates are cached in CacheDir, which is the "autocert"
+// directory of the Store path when empty. Addr is ":443" when empty, and
+// HTTPAddr redirecting http to https is ":80" when empty and disabled when
+// "-".
+type AutoTLS struct {
+	Domains  []string `json:"domains,omitempty" yaml:"domains,omitempty" ini:"domains,omitempty"`
+	Email    string   `json:"email,omitempty" yaml:"email,omitempty" ini:"email,omitempty"`
+	CacheDir string   `json:"cache_dir,omitempty" yaml:"cache_dir,omitempty" ini:"cache_dir,omitempty"`
+	Addr     string   `json:"addr,omitempty" yaml:"addr,omitempty" ini:"addr,omitempty"`
+	HTTPAddr string   `json:"http_addr,omitempty" yaml:"http_addr,omitempty" ini:"http_addr,omitempty"`
+}
+
+// Redis is the config of the shared redis client. Set MasterName to connect
+// to the sentinels of Addrs, or set Cluster to connect to a redis cluster.
+type Redis struct {
+	Addrs              []string `json:"addrs,omitempty" yaml:"addrs,omitempty" ini:"addrs,omitempty"`
+	MasterName         string   `json:"master_name,omitempty" yaml:"master_name,omitempty" ini:"master_name,omitempty"`
+	Cluster            bool     `json:"cluster,omitempty" yaml:"cluster,omitempty" ini:"cluster,omitempty"`
+	Username           string   `json:"username,omitempty" yaml:"username,omitempty" ini:"username,omitempty"`
+	Password           string   `json:"password,omitempty" yaml:"password,omitempty" ini:"password,omitempty"`
+	DB                 int      `json:"db,omitempty" yaml:"db,omitempty" ini:"db,omitempty"`
+	PoolSize           int      `json:"pool_size,omitempty" yaml:"pool_size,omitempty" ini:"pool_size,omitempty"`
+	MinIdleConns       int      `json:"min_idle_conns,omitempty" yaml:"min_idle_conns,omitempty" ini:"min_idle_conns,omitempty"`
+	TLS                bool     `json:"tls,omitempty" yaml:"tls,omitempty" ini:"tls,omitempty"`
+	InsecureSkipVerify bool     `json:"insecure_skip_verify,omitempty" yaml:"insecure_skip_verify,omitempty" ini:"insecure_skip_verify,omitempty"`
+	KeyPrefix          string   `json:"key_prefix,omitempty" yaml:"key_prefix,omitempty" ini:"key_prefix,omitempty"`
+}
+
+// Enabled check if the redis is configured.
+func (r Redis) Enabled() bool {
+	return len(r.Addrs) > 0
+}
+
 // Config type is the global config of goAdmin. It will be
 // initialized in the engine.
 type Config struct {
@@ -272,6 +328,23 @@ type Config struct {
 	// The global url prefix.
 	UrlPrefix string `json:"prefix,omitempty" yaml:"prefix,omitempty" ini:"prefix,omitempty"`
 
+	// The former url prefixes. The menus and the permissions stored with one
+	// of them, such as "/admin/info/users", keep working after the prefix is
+	// changed, see NormalizePath.
+	FormerUrlPrefixes []string `json:"former_prefixes,omitempty" yaml:"former_prefixes,omitempty" ini:"former_prefixes,omitempty"`
+
+	// The ips or the cidrs of the trusted reverse proxies, such as "10.0.0.0/8".
+	// Only their Forwarded and X-Forwarded-* headers are honored for the client
+	// ip, the scheme and the host. When empty, the client ip headers of all the
+	// peers are honored as before, and the scheme and the host headers are
+	// ignored.
+	TrustedProxies []string `json:"trusted_proxies,omitempty" yaml:"trusted_proxies,omitempty" ini:"trusted_proxies,omitempty"`
+
+	// The public base url of the admin, such as "https://admin.example.com",
+	// used for the absolute urls instead of the scheme and the host of the
+	// requests, see AbsoluteUrl.
+	BaseURL string `json:"base_url,omitempty" yaml:"base_url,omitempty" ini:"base_url,omitempty"`
+
 	// The theme name of template.
 	Theme string `json:"theme,omitempty" yaml:"theme,omitempty" ini:"theme,omitempty"`
 
@@ -314,6 +387,18 @@ type Config struct {
 	// Sql operator record log switch.
 	SqlLog bool `json:"sql_log,omitempty" yaml:"sql_log,omitempty" ini:"sql_log,omitempty"`
 
+	// Threshold of the slow requests in milliseconds, 0 disables the watchdog.
+	// The requests still running after it are logged, and logged again with
+	// the user and the slowest statements when they end. When the notify is
+	// on, the pages of the session are also warned by a toast.
+	SlowRequest       int  `json:"slow_request,omitempty" yaml:"slow_request,omitempty" ini:"slow_request,omitempty"`
+	SlowRequestNotify bool `json:"slow_request_notify,omitempty" yaml:"slow_request_notify,omitempty" ini:"slow_request_notify,omitempty"`
+
+	// Seconds the roles, the permissions and the menus of the users are cached,
+	// 0 disables the cache. The cache is dropped by the changes made by the
+	// admin, the changes made by the other instances take effect after it.
+	AuthCacheTTL int `json:"auth_cache_ttl,omitempty" yaml:"auth_cache_ttl,omitempty" ini:"auth_cache_ttl,omitempty"`
+
 	AccessLogOff bool `json:"access_log_off,omitempty" yaml:"access_log_off,omitempty" ini:"access_log_off,omitempty"`
 	InfoLogOff   bool `json:"info_log_off,omitempty" yaml:"info_log_off,omitempty" ini:"info_log_off,omitempty"`
 	ErrorLogOff  bool `json:"error_log_off,omitempty" yaml:"error_log_off,omitempty" ini:"error_log_off,omitempty"`
@@ -326,6 +411,17 @@ type Config struct {
 	// Session valid time duration,units are seconds. Default 7200.
 	SessionLifeTime int `json:"session_life_time,omitempty" yaml:"session_life_time,omitempty" ini:"session_life_time,omitempty"`
 
+	// Idle timeout of the sessions in seconds, the user is logged out after
+	// the time without any activity, 0 disables it. A countdown modal is shown
+	// IdleWarning seconds before, default 60, to keep the session alive.
+	IdleTimeout int `json:"idle_timeout,omitempty" yaml:"idle_timeout,omitempty" ini:"idle_timeout,omitempty"`
+	IdleWarning int `json:"idle_warning,omitempty" yaml:"idle_warning,omitempty" ini:"idle_warning,omitempty"`
+
+	// Timezone of the datetime values stored in the database, such as "UTC".
+	// When it is set, the datetime filters are entered in the timezone of the
+	// browser of the user and converted to it. Empty disables the conversion.
+	Timezone string `json:"timezone,omitempty" yaml:"timezone,omitempty" ini:"timezone,omitempty"`
+
 	// Assets visit link.
 	AssetUrl string `json:"asset_url,omitempty" yaml:"asset_url,omitempty" ini:"asset_url,omitempty"`
 
@@ -347,6 +443,10 @@ type Config struct {
 	// Login page logo
 	LoginLogo template.HTML `json:"login_logo,omitempty" yaml:"login_logo,omitempty" ini:"login_logo,omitempty"`
 
+	// Login page layout, background, links, terms and fields, which are
+	// rendered by the default login component.
+	LoginPage LoginPage `json:"login_page,omitempty" yaml:"login_page,omitempty" ini:"login_page,omitempty"`
+
 	// Auth user table
 	AuthUserTable string `json:"auth_user_table,omitempty" yaml:"auth_user_table,omitempty" ini:"auth_user_table,omitempty"`
 
@@ -368,6 +468,11 @@ type Config struct {
 	// Prohibit config modification
 	ProhibitConfigModification bool `json:"prohibit_config_modification,omitempty" yaml:"prohibit_config_modification,omitempty" ini:"prohibit_config_modification,omitempty"`
 
+	// Stateless mode: the config is only sourced from the files and the
+	// environment variables, the site settings are not stored in the database
+	// and the config center is disabled.
+	Stateless bool `json:"stateless,omitempty" yaml:"stateless,omitempty" ini:"stateless,omitempty"`
+
 	// Hide app info entrance flag
 	HideAppInfoEntrance bool `json:"hide_app_info_entrance,omitempty" yaml:"hide_app_info_entrance,omitempty" ini:"hide_app_info_entrance,omitempty"`
 
@@ -406,6 +511,32 @@ type Config struct {
 
 	URLFormat URLFormat `json:"url_format,omitempty" yaml:"url_format,omitempty" ini:"url_format,omitempty"`
 
+	// Send the theme's critical css and js as 103 Early Hints, or as Link
+	// preload headers when the adapter cannot write informational responses.
+	EarlyHints bool `json:"early_hints,omitempty" yaml:"early_hints,omitempty" ini:"early_hints,omitempty"`
+
+	// Serve the large inline scripts and styles of the pages as cacheable
+	// assets in the production environment, see the modules/inline.
+	ExternalInlineAssets bool `json:"external_inline_assets,omitempty" yaml:"external_inline_assets,omitempty" ini:"external_inline_assets,omitempty"`
+
+	// The shared redis client, see the modules/redis.
+	Redis Redis `json:"redis,omitempty" yaml:"redis,omitempty" ini:"redis,omitempty"`
+
+	// The badges of the environments by the env, the badge of the current env
+	// is shown, e.g. {"test": {"text": "STAGING", "color": "#f39c12"}}.
+	EnvBadges map[string]EnvBadge `json:"env_badges,omitempty" yaml:"env_badges,omitempty" ini:"env_badges,omitempty"`
+
+	// Demo mode for the public demos: the mutating requests are blocked, or
+	// run with the writes rolled back when DemoRevert is true.
+	DemoMode   bool `json:"demo_mode,omitempty" yaml:"demo_mode,omitempty" ini:"demo_mode,omitempty"`
+	DemoRevert bool `json:"demo_revert,omitempty" yaml:"demo_revert,omitempty" ini:"demo_revert,omitempty"`
+
+	// The smtp server of the mails, see the modules/mail.
+	Mail Mail `json:"mail,omitempty" yaml:"mail,omitempty" ini:"mail,omitempty"`
+
+	// The certificates issued by Let's Encrypt, see AutoTLS.
+	AutoTLS AutoTLS `json:"auto_tls,omitempty" yaml:"auto_tls,omitempty" ini:"auto_tls,omitempty"`
+
 	prefix string       `json:"-" yaml:"-" ini:"-"`
 	lock   sync.RWMutex `json:"-" yaml:"-" ini:"-"`
 }
@@ -480,6 +611,64 @@ func (p PageAnimation) JSON() string {
 	return utils.JSON(p)
 }
 
+// The layouts of the login page.
+const (
+	LoginLayoutCenter = "center"
+	LoginLayoutLeft   = "left"
+	LoginLayoutRight  = "right"
+)
+
+// LoginPage is the settings of the login page rendered by the default login
+// component, the custom login components may ignore them.
+type LoginPage struct {
+	// Layout of the form: center (default), or left and right which put the
+	// form in a panel beside the background.
+	Layout string `json:"layout,omitempty" yaml:"layout,omitempty" ini:"layout,omitempty"`
+	// Background is an image url or a css color, the animated particles are
+	// shown when it is empty.
+	Background string `json:"background,omitempty" yaml:"background,omitempty" ini:"background,omitempty"`
+	// Links shown below the form, e.g. the help or the password reset.
+	Links []LoginLink `json:"links,omitempty" yaml:"links,omitempty" ini:"links,omitempty"`
+	// Terms is the legal text of the checkbox which must be checked to login,
+	// no checkbox is shown when it is empty.
+	Terms template.HTML `json:"terms,omitempty" yaml:"terms,omitempty" ini:"terms,omitempty"`
+	// UsernameLabel replaces the label of the username, e.g. "Email".
+	UsernameLabel string `json:"username_label,omitempty" yaml:"username_label,omitempty" ini:"username_label,omitempty"`
+	// TenantLabel shows a required tenant field with the label, the tenant
+	// is kept in the session, see auth.Tenant.
+	TenantLabel string `json:"tenant_label,omitempty" yaml:"tenant_label,omitempty" ini:"tenant_label,omitempty"`
+	// QRLogin shows the qr code which a signed in mobile scans to approve
+	// the login of the browser, see auth.QRLoginPath.
+	QRLogin bool `json:"qr_login,omitempty" yaml:"qr_login,omitempty" ini:"qr_login,omitempty"`
+}
+
+// LoginLink is a link of the login page.
+type LoginLink struct {
+	Text string `json:"text" yaml:"text" ini:"text"`
+	URL  string `json:"url" yaml:"url" ini:"url"`
+}
+
+// LinksJSON return the links as json, empty when there is none.
+func (l LoginPage) LinksJSON() string {
+	if len(l.Links) == 0 {
+		return ""
+	}
+	return utils.JSON(l.Links)
+}
+
+// BackgroundCSS return the css of the background, the urls and the paths are
+// images and the others are colors.
+func (l LoginPage) BackgroundCSS() template.CSS {
+	bg := strings.TrimSpace(l.Background)
+	if bg == "" || strings.ContainsAny(bg, `;{}<>"\`) {
+		return ""
+	}
+	if strings.HasPrefix(bg, "http://") || strings.HasPrefix(bg, "https://") || strings.HasPrefix(bg, "/") {
+		return template.CSS(`background:url("` + bg + `") center / cover no-repeat;`)
+	}
+	return template.CSS("background:" + bg + ";")
+}
+
 // FileUploadEngine is a file upload engine.
 type FileUploadEngine struct {
 	Name   string                 `json:"name,omitempty" yaml:"name,omitempty" ini:"name,omitempty"`
@@ -517,6 +706,9 @@ func (c *Config) GetIndexURL() string {
 
 // Url get url with the given suffix.
 func (c *Config) Url(suffix string) string {
+	if suffix == "" || suffix[0] != '/' {
+		suffix = "/" + suffix
+	}
 	if c.prefix == "/" {
 		return suffix
 	}
@@ -526,6 +718,15 @@ func (c *Config) Url(suffix string) string {
 	return c.prefix + suffix
 }
 
+// AbsoluteUrl get the absolute url with the given suffix, which starts with
+// the BaseURL, or the scheme and the host seen by the client of the request.
+func (c *Config) AbsoluteUrl(ctx *context.Context, suffix string) string {
+	if c.BaseURL != "" {
+		return strings.TrimRight(c.BaseURL, "/") + c.Url(suffix)
+	}
+	return ctx.BaseURL() + c.Url(suffix)
+}
+
 // IsTestEnvironment check the environment if it is test.
 func (c *Config) IsTestEnvironment() bool {
 	return c.Env == EnvTest
@@ -550,15 +751,93 @@ func (c *Config) IsAllowConfigModification() bool {
 	return !c.ProhibitConfigModification
 }
 
+// IsStateless check the config if it is in the stateless mode.
+func (c *Config) IsStateless() bool {
+	return c.Stateless
+}
+
+// EnvPrefix is the prefix of the environment variables overriding the config
+// in the stateless mode, such as GOADMIN_TITLE for "title".
+const EnvPrefix = "GOADMIN_"
+
+// applyEnv override the string, bool and int fields by the environment
+// variables of their json keys.
+func (c *Config) applyEnv() {
+	rType := reflect.TypeOf(c).Elem()
+	rVal := reflect.ValueOf(c).Elem()
+	for i := 0; i < rType.NumField(); i++ {
+		v := rVal.Field(i)
+		if !v.CanSet() {
+			continue
+		}
+		keyName := strings.Split(rType.Field(i).Tag.Get("json"), ",")[0]
+		if keyName == "" || keyName == "-" {
+			continue
+		}
+		mv, ok := os.LookupEnv(EnvPrefix + strings.ToUpper(keyName))
+		if !ok {
+			continue
+		}
+		switch v.Kind() {
+		case reflect.String:
+			v.SetString(mv)
+		case reflect.Bool:
+			v.SetBool(utils.ParseBool(mv))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if n, err := strconv.ParseInt(mv, 10, 64); err == nil {
+				v.SetInt(n)
+			}
+		}
+	}
+}
+
 // URLRemovePrefix remove prefix from the given url.
 func (c *Config) URLRemovePrefix(url string) string {
-	if url == c.prefix {
+	return removePrefix(url, c.prefix)
+}
+
+// NormalizePath return the stored path of a menu or a permission without the
+// former url prefixes, the paths without them are returned as they are.
+func (c *Config) NormalizePath(path string) string {
+	for _, prefix := range c.FormerUrlPrefixes {
+		if p := removePrefix(path, fixSlash(prefix)); p != path {
+			return p
+		}
+	}
+	return path
+}
+
+// RemoveURLPrefixes return the stored path of a menu or a permission without
+// the current and the former url prefixes. The stored paths are relative to
+// the prefix, the paths changed by it should be updated.
+func (c *Config) RemoveURLPrefixes(path string) string {
+	if p := removePrefix(path, c.prefix); p != path {
+		return p
+	}
+	return c.NormalizePath(path)
+}
+
+// removePrefix remove the prefix from the url.
+func removePrefix(url, prefix string) string {
+	if url == prefix {
 		return "/"
 	}
-	if c.prefix == "/" {
+	if prefix == "/" || !strings.HasPrefix(url, prefix) {
+		return url
+	}
+	// only the whole path segments are removed, "/admin" is not the prefix of
+	// "/administrator".
+	rest := url[len(prefix):]
+	if rest == "" {
+		return "/"
+	}
+	if rest[0] == '?' || rest[0] == '#' {
+		return "/" + rest
+	}
+	if rest[0] != '/' {
 		return url
 	}
-	return strings.Replace(url, c.prefix, "", 1)
+	return rest
 }
 
 // Index return the index url without prefix.
@@ -592,13 +871,27 @@ func (c *Config) AddUpdateProcessFn(fn UpdateConfigProcessFn) *Config {
 
 // PrefixFixSlash return the prefix fix the slash error.
 func (c *Config) PrefixFixSlash() string {
-	if c.UrlPrefix == "/" {
+	if c.UrlPrefix == "" {
 		return ""
 	}
-	if c.UrlPrefix != "" && c.UrlPrefix[0] != '/' {
-		return "/" + c.UrlPrefix
+	prefix := fixSlash(c.UrlPrefix)
+	if prefix == "/" {
+		return ""
 	}
-	return c.UrlPrefix
+	return prefix
+}
+
+// fixSlash return the path with a leading slash and without the trailing
+// slashes, the empty path and the path of slashes are fixed as "/".
+func fixSlash(path string) string {
+	path = strings.TrimRight(path, "/")
+	if path == "" {
+		return "/"
+	}
+	if path[0] != '/' {
+		return "/" + path
+	}
+	return path
 }
 
 func (c *Config) Copy() *Config {
@@ -696,6 +989,14 @@ func (c *Config) ToMap() map[string]string {
 				m["databases"] = utils.JSON(v.Interface())
 			case "config.FileUploadEngine":
 				m["file_upload_engine"] = c.FileUploadEngine.JSON()
+			case "config.LoginPage":
+				m["login_layout"] = c.LoginPage.Layout
+				m["login_background"] = c.LoginPage.Background
+				m["login_links"] = c.LoginPage.LinksJSON()
+				m["login_terms"] = string(c.LoginPage.Terms)
+				m["login_username_label"] = c.LoginPage.UsernameLabel
+				m["login_tenant_label"] = c.LoginPage.TenantLabel
+				m["login_qr"] = strconv.FormatBool(c.LoginPage.QRLogin)
 			}
 		case reflect.Map:
 			if t.Type.String() == "config.ExtraInfo" {
@@ -797,6 +1098,23 @@ func (c *Config) Update(m map[string]string) error {
 				initLogger(c)
 			case "config.FileUploadEngine":
 				c.FileUploadEngine = GetFileUploadEngineFromJSON(m["file_upload_engine"])
+			case "config.LoginPage":
+				if _, ok := m["login_layout"]; !ok {
+					continue
+				}
+				var links []LoginLink
+				if m["login_links"] != "" {
+					_ = json.Unmarshal([]byte(m["login_links"]), &links)
+				}
+				c.LoginPage = LoginPage{
+					Layout:        m["login_layout"],
+					Background:    m["login_background"],
+					Links:         links,
+					Terms:         template.HTML(m["login_terms"]),
+					UsernameLabel: m["login_username_label"],
+					TenantLabel:   m["login_tenant_label"],
+					QRLogin:       m["login_qr"] == "true",
+				}
 			}
 		case reflect.Map:
 			if t.Type.String() == "config.ExtraInfo" && m["extra"] != "" {
@@ -816,6 +1134,7 @@ func (c *Config) EraseSens() *Config {
 			Driver: c.Databases[key].Driver,
 		}
 	}
+	c.Redis.Password = ""
 	return c
 }
 
@@ -906,19 +1225,14 @@ func SetDefault(cfg *Config) *Config {
 	cfg.AssetRootPath = utils.SetDefault(cfg.AssetRootPath, "", "./public/")
 	cfg.AssetRootPath = filepath.ToSlash(cfg.AssetRootPath)
 	cfg.FileUploadEngine.Name = utils.SetDefault(cfg.FileUploadEngine.Name, "", "local")
+	cfg.LoginPage.Layout = utils.SetDefault(cfg.LoginPage.Layout, "", LoginLayoutCenter)
 	cfg.Env = utils.SetDefault(cfg.Env, "", EnvProd)
 	if cfg.SessionLifeTime == 0 {
 		// default two hours
 		cfg.SessionLifeTime = 7200
 	}
 	cfg.AppID = utils.Uuid(12)
-	if cfg.UrlPrefix == "" {
-		cfg.prefix = "/"
-	} else if cfg.UrlPrefix[0] != '/' {
-		cfg.prefix = "/" + cfg.UrlPrefix
-	} else {
-		cfg.prefix = cfg.UrlPrefix
-	}
+	cfg.prefix = fixSlash(cfg.UrlPrefix)
 	cfg.URLFormat = cfg.URLFormat.SetDefault()
 	return cfg
 }
@@ -934,6 +1248,17 @@ func Initialize(cfg *Config) *Config {
 	}
 	atomic.StoreUint32(&count, 1)
 
+	if cfg.Stateless || utils.ParseBool(os.Getenv(EnvPrefix+"STATELESS")) {
+		cfg.applyEnv()
+		cfg.Stateless = true
+		cfg.ProhibitConfigModification = true
+		cfg.HideConfigCenterEntrance = true
+	}
+
+	if err := context.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		panic(err)
+	}
+
 	initLogger(SetDefault(cfg))
 
 	_global = cfg
@@ -1000,10 +1325,26 @@ func URLRemovePrefix(url string) string {
 	return _global.URLRemovePrefix(url)
 }
 
+// NormalizePath return the stored path without the former url prefixes.
+func NormalizePath(path string) string {
+	return _global.NormalizePath(path)
+}
+
+// RemoveURLPrefixes return the stored path without the current and the former
+// url prefixes.
+func RemoveURLPrefixes(path string) string {
+	return _global.RemoveURLPrefixes(path)
+}
+
 func Url(suffix string) string {
 	return _global.Url(suffix)
 }
 
+// AbsoluteUrl get the absolute url with the given suffix, see Config.AbsoluteUrl.
+func AbsoluteUrl(ctx *context.Context, suffix string) string {
+	return _global.AbsoluteUrl(ctx, suffix)
+}
+
 func GetURLFormats() URLFormat {
 	return _global.URLFormat
 }
@@ -1070,6 +1411,18 @@ func GetOpenAdminApi() bool {
 	return _global.OpenAdminApi
 }
 
+func GetEarlyHints() bool {
+	_global.lock.RLock()
+	defer _global.lock.RUnlock()
+	return _global.EarlyHints
+}
+
+func GetExternalInlineAssets() bool {
+	_global.lock.RLock()
+	defer _global.lock.RUnlock()
+	return _global.ExternalInlineAssets
+}
+
 func GetAllowDelOperationLog() bool {
 	_global.lock.RLock()
 	defer _global.lock.RUnlock()
@@ -1136,6 +1489,41 @@ func GetSiteOff() bool {
 	return _global.SiteOff
 }
 
+func GetDemoMode() bool {
+	_global.lock.RLock()
+	defer _global.lock.RUnlock()
+	return _global.DemoMode
+}
+
+func GetDemoRevert() bool {
+	_global.lock.RLock()
+	defer _global.lock.RUnlock()
+	return _global.DemoRevert
+}
+
+func GetMail() Mail {
+	_global.lock.RLock()
+	defer _global.lock.RUnlock()
+	return _global.Mail
+}
+
+// GetAutoTLS return the AutoTLS with the defaults of the empty fields.
+func GetAutoTLS() AutoTLS {
+	_global.lock.RLock()
+	defer _global.lock.RUnlock()
+	a := _global.AutoTLS
+	if a.CacheDir == "" {
+		a.CacheDir = filepath.Join(_global.Store.Path, "autocert")
+	}
+	if a.Addr == "" {
+		a.Addr = ":443"
+	}
+	if a.HTTPAddr == "" {
+		a.HTTPAddr = ":80"
+	}
+	return a
+}
+
 func GetMiniLogo() template.HTML {
 	_global.lock.RLock()
 	defer _global.lock.RUnlock()
@@ -1166,6 +1554,17 @@ func GetEnv() string {
 	return _global.Env
 }
 
+// GetEnvBadge return the badge of the current env and whether it is set.
+func GetEnvBadge() (EnvBadge, bool) {
+	_global.lock.RLock()
+	defer _global.lock.RUnlock()
+	badge, ok := _global.EnvBadges[_global.Env]
+	if ok && badge.Text == "" {
+		badge.Text = strings.ToUpper(_global.Env)
+	}
+	return badge, ok
+}
+
 func GetInfoLogPath() string {
 	_global.lock.RLock()
 	defer _global.lock.RUnlock()
@@ -1218,6 +1617,73 @@ func GetSessionLifeTime() int {
 	return _global.SessionLifeTime
 }
 
+// GetSlowRequest return the threshold of the slow requests, 0 when the
+// watchdog is disabled.
+func GetSlowRequest() time.Duration {
+	_global.lock.RLock()
+	defer _global.lock.RUnlock()
+	if _global.SlowRequest < 0 {
+		return 0
+	}
+	return time.Duration(_global.SlowRequest) * time.Millisecond
+}
+
+// GetAuthCacheTTL return the ttl of the cached roles, permissions and menus
+// of the users, 0 when the cache is disabled.
+func GetAuthCacheTTL() time.Duration {
+	_global.lock.RLock()
+	defer _global.lock.RUnlock()
+	if _global.AuthCacheTTL < 0 {
+		return 0
+	}
+	return time.Duration(_global.AuthCacheTTL) * time.Second
+}
+
+// GetSlowRequestNotify return whether the sessions are warned of their slow
+// requests.
+func GetSlowRequestNotify() bool {
+	_global.lock.RLock()
+	defer _global.lock.RUnlock()
+	return _global.SlowRequestNotify
+}
+
+// DefaultIdleWarning is the default seconds of the countdown before the idle
+// timeout.
+const DefaultIdleWarning = 60
+
+// GetIdleTimeout return the idle timeout in seconds, 0 when it is disabled.
+func GetIdleTimeout() int {
+	_global.lock.RLock()
+	defer _global.lock.RUnlock()
+	if _global.IdleTimeout < 0 {
+		return 0
+	}
+	return _global.IdleTimeout
+}
+
+// GetIdleWarning return the seconds of the countdown before the idle timeout,
+// which is at most the half of the timeout.
+func GetIdleWarning() int {
+	_global.lock.RLock()
+	defer _global.lock.RUnlock()
+	warning := _global.IdleWarning
+	if warning <= 0 {
+		warning = DefaultIdleWarning
+	}
+	if half := _global.IdleTimeout / 2; warning > half {
+		warning = half
+	}
+	return warning
+}
+
+// GetTimezone return the timezone of the datetime values stored in the
+// database, empty when the datetime filters are not converted.
+func GetTimezone() string {
+	_global.lock.RLock()
+	defer _global.lock.RUnlock()
+	return _global.Timezone
+}
+
 func GetAssetUrl() string {
 	_global.lock.RLock()
 	defer _global.lock.RUnlock()
@@ -1260,6 +1726,12 @@ func GetLoginLogo() template.HTML {
 	return _global.LoginLogo
 }
 
+func GetLoginPage() LoginPage {
+	_global.lock.RLock()
+	defer _global.lock.RUnlock()
+	return _global.LoginPage
+}
+
 func GetAuthUserTable() string {
 	_global.lock.RLock()
 	defer _global.lock.RUnlock()
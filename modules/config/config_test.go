@@ -2,9 +2,12 @@ package config
 
 import (
 	"fmt"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/purpose168/GoAdmin/context"
 	"github.com/purpose168/GoAdmin/modules/utils"
 
 	"github.com/stretchr/testify/assert"
@@ -342,11 +345,12 @@ func TestToMap(t *testing.T) {
 		"color_scheme", "session_life_time", "asset_url", "file_upload_engine", "custom_head_html", "custom_foot_html",
 		"custom_404_html", "custom_403_html", "custom_500_html", "bootstrap_file_path", "go_mod_file_path", "footer_info",
 		"app_id", "login_title", "login_logo", "auth_user_table", "exclude_theme_components",
+		"login_layout", "login_background", "login_links", "login_terms", "login_username_label", "login_tenant_label",
 		"extra",
 		"animation_type", "animation_duration", "animation_delay",
 		"no_limit_login_ip", "allow_del_operation_log", "operation_log_off",
 		"hide_config_center_entrance", "hide_app_info_entrance", "hide_tool_entrance", "hide_plugin_entrance",
-		"asset_root_path",
+		"asset_root_path", "stateless", "idle_timeout", "idle_warning", "timezone",
 	}
 
 	for key := range m {
@@ -357,3 +361,128 @@ func TestToMap(t *testing.T) {
 
 	fmt.Println(len(arr), len(m))
 }
+
+func TestApplyEnv(t *testing.T) {
+	t.Setenv("GOADMIN_TITLE", "GitOps Admin")
+	t.Setenv("GOADMIN_DEBUG", "true")
+	t.Setenv("GOADMIN_SESSION_LIFE_TIME", "3600")
+	t.Setenv("GOADMIN_SESSION_LIFE_TIME_X", "1")
+
+	cfg := &Config{Title: "GoAdmin", SessionLifeTime: 7200}
+	cfg.applyEnv()
+
+	assert.Equal(t, cfg.Title, "GitOps Admin")
+	assert.Equal(t, cfg.Debug, true)
+	assert.Equal(t, cfg.SessionLifeTime, 3600)
+}
+
+func TestConfig_URLRemovePrefix_Segments(t *testing.T) {
+	cfg := &Config{prefix: "/admin"}
+
+	assert.Equal(t, "/", cfg.URLRemovePrefix("/admin"))
+	assert.Equal(t, "/", cfg.URLRemovePrefix("/admin/"))
+	assert.Equal(t, "/?a=1", cfg.URLRemovePrefix("/admin?a=1"))
+	assert.Equal(t, "/info/user", cfg.URLRemovePrefix("/admin/info/user"))
+	assert.Equal(t, "/administrator", cfg.URLRemovePrefix("/administrator"))
+	assert.Equal(t, "/info/admin/user", cfg.URLRemovePrefix("/info/admin/user"))
+
+	assert.Equal(t, "/admin/info", cfg.Url("info"))
+	assert.Equal(t, "/admin", cfg.Url(""))
+	assert.Equal(t, "/admin", fixSlash("admin//"))
+	assert.Equal(t, "/", fixSlash("//"))
+}
+
+func TestConfig_NormalizePath(t *testing.T) {
+	cfg := &Config{prefix: "/manage", FormerUrlPrefixes: []string{"admin", "/old/"}}
+
+	assert.Equal(t, "/info/user", cfg.NormalizePath("/admin/info/user"))
+	assert.Equal(t, "/info/user", cfg.NormalizePath("/old/info/user"))
+	assert.Equal(t, "/", cfg.NormalizePath("/admin"))
+	assert.Equal(t, "/administrator", cfg.NormalizePath("/administrator"))
+	assert.Equal(t, "/manage/info/user", cfg.NormalizePath("/manage/info/user"))
+	assert.Equal(t, "https://admin.example.com", cfg.NormalizePath("https://admin.example.com"))
+
+	assert.Equal(t, "/info/user", cfg.RemoveURLPrefixes("/manage/info/user"))
+	assert.Equal(t, "/info/user", cfg.RemoveURLPrefixes("/admin/info/user"))
+	assert.Equal(t, "/info/user", cfg.RemoveURLPrefixes("/info/user"))
+}
+
+func TestConfig_AbsoluteUrl(t *testing.T) {
+	ctx := context.NewContext(&http.Request{RemoteAddr: "10.0.0.1:5000", Host: "127.0.0.1:9033", Header: http.Header{
+		"X-Forwarded-Proto": []string{"https"},
+	}})
+
+	cfg := &Config{prefix: "/admin"}
+	assert.Equal(t, "http://127.0.0.1:9033/admin/login", cfg.AbsoluteUrl(ctx, "/login"))
+
+	defer func() { _ = context.SetTrustedProxies(nil) }()
+	assert.NoError(t, context.SetTrustedProxies([]string{"10.0.0.1"}))
+	assert.Equal(t, "https://127.0.0.1:9033/admin/login", cfg.AbsoluteUrl(ctx, "/login"))
+
+	cfg.BaseURL = "https://admin.example.com/"
+	assert.Equal(t, "https://admin.example.com/admin/login", cfg.AbsoluteUrl(ctx, "login"))
+}
+
+func FuzzFixSlash(f *testing.F) {
+	for _, seed := range []string{"", "/", "//", "admin", "/admin", "admin/", "/admin/", "/a/b/", "中文/"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, path string) {
+		fixed := fixSlash(path)
+		if fixed == "" || fixed[0] != '/' {
+			t.Fatalf("%q is fixed as %q without leading slash", path, fixed)
+		}
+		if fixed != "/" && strings.HasSuffix(fixed, "/") {
+			t.Fatalf("%q is fixed as %q with trailing slash", path, fixed)
+		}
+		if fixSlash(fixed) != fixed {
+			t.Fatalf("fixSlash of %q is not idempotent", path)
+		}
+	})
+}
+
+func FuzzConfig_URL(f *testing.F) {
+	for _, seed := range [][2]string{{"", "/info"}, {"admin", "/info"}, {"/admin/", "info"},
+		{"admin", "/"}, {"admin", ""}, {"/", "/admin/x"}, {"admin", "/admin"}, {"admin", "/admin?x=1"}} {
+		f.Add(seed[0], seed[1])
+	}
+
+	f.Fuzz(func(t *testing.T, prefix, suffix string) {
+		cfg := &Config{UrlPrefix: prefix, prefix: fixSlash(prefix)}
+
+		url := cfg.Url(suffix)
+		if !strings.HasPrefix(url, cfg.prefix) {
+			t.Fatalf("url %q is not under the prefix %q", url, cfg.prefix)
+		}
+		if strings.TrimLeft(suffix, "/") == "" || strings.HasPrefix(suffix, "/?") || strings.HasPrefix(suffix, "/#") {
+			return
+		}
+		if removed := cfg.URLRemovePrefix(url); "/"+strings.TrimPrefix(suffix, "/") != removed {
+			t.Fatalf("prefix %q, suffix %q: url %q is removed as %q", prefix, suffix, url, removed)
+		}
+	})
+}
+
+func TestLoginPage(t *testing.T) {
+	c := &Config{LoginPage: LoginPage{
+		Layout:      LoginLayoutLeft,
+		Links:       []LoginLink{{Text: "Help", URL: "/help"}},
+		TenantLabel: "Tenant",
+	}}
+	m := c.ToMap()
+	assert.Equal(t, LoginLayoutLeft, m["login_layout"])
+	assert.Equal(t, `[{"text":"Help","url":"/help"}]`, m["login_links"])
+
+	c2 := &Config{}
+	assert.NoError(t, c2.Update(m))
+	assert.Equal(t, c.LoginPage, c2.LoginPage)
+
+	// the login page is kept when the site settings have not it.
+	assert.NoError(t, c2.Update(map[string]string{"title": "GoAdmin"}))
+	assert.Equal(t, c.LoginPage, c2.LoginPage)
+
+	assert.Equal(t, `background:url("/bg.png") center / cover no-repeat;`, string(LoginPage{Background: "/bg.png"}.BackgroundCSS()))
+	assert.Equal(t, `background:#222;`, string(LoginPage{Background: "#222"}.BackgroundCSS()))
+	assert.Equal(t, "", string(LoginPage{Background: "red;}body{x"}.BackgroundCSS()))
+}
@@ -0,0 +1,319 @@
+// Package cron parses the standard cron expressions, computes the next run
+// times and describes the schedules in human-readable text.
+//
+// An expression has five fields, minute, hour, day of month, month and day of
+// week, or is one of the macros @yearly, @monthly, @weekly, @daily and
+// @hourly:
+//
+//	s, err := cron.Parse("30 9 * * MON-FRI")
+//	next := s.Next(time.Now())
+//	text := s.Describe(language.EN) // at 09:30, on Monday through Friday
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/language"
+)
+
+// field is the range and the names of a field of the expressions.
+type field struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: []string{"", "jan", "feb", "mar", "apr", "may",
+		"jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	dowField = field{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu",
+		"fri", "sat"}}
+)
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Schedule is a parsed cron expression. The fields are the bit sets of the
+// allowed values.
+type Schedule struct {
+	expr                          string
+	fields                        []string
+	minute, hour, dom, month, dow uint64
+}
+
+// Parse parse the cron expression.
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.Join(strings.Fields(expr), " ")
+	if expr == "" {
+		return nil, errors.New("cron: empty expression")
+	}
+
+	spec := expr
+	if strings.HasPrefix(spec, "@") {
+		var ok bool
+		if spec, ok = macros[strings.ToLower(spec)]; !ok {
+			return nil, fmt.Errorf("cron: unknown macro %s", expr)
+		}
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron: expected 5 fields, found %d", len(fields))
+	}
+
+	s := &Schedule{expr: expr, fields: fields}
+	for i, item := range []struct {
+		f    field
+		bits *uint64
+	}{{minuteField, &s.minute}, {hourField, &s.hour}, {domField, &s.dom}, {monthField, &s.month}, {dowField, &s.dow}} {
+		b, err := parseField(fields[i], item.f)
+		if err != nil {
+			return nil, err
+		}
+		*item.bits = b
+	}
+	// 7 is sunday as well as 0.
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	return s, nil
+}
+
+// String return the expression.
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+func parseField(value string, f field) (uint64, error) {
+	var res uint64
+	for _, part := range strings.Split(value, ",") {
+		b, err := parsePart(part, f)
+		if err != nil {
+			return 0, err
+		}
+		res |= b
+	}
+	return res, nil
+}
+
+func parsePart(part string, f field) (uint64, error) {
+	var (
+		rng  = part
+		step = 1
+		err  error
+	)
+	if i := strings.Index(part, "/"); i >= 0 {
+		rng = part[:i]
+		if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+			return 0, fmt.Errorf("cron: invalid step %q of the %s", part, f.name)
+		}
+	}
+
+	var start, end int
+	switch {
+	case rng == "*" || rng == "?":
+		start, end = f.min, f.max
+		if f.max == 7 {
+			end = 6
+		}
+	case strings.Contains(rng, "-"):
+		i := strings.Index(rng, "-")
+		if start, err = parseValue(rng[:i], f); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(rng[i+1:], f); err != nil {
+			return 0, err
+		}
+	default:
+		if start, err = parseValue(rng, f); err != nil {
+			return 0, err
+		}
+		end = start
+		if strings.Contains(part, "/") {
+			end = f.max
+		}
+	}
+	if start > end {
+		return 0, fmt.Errorf("cron: invalid range %q of the %s", part, f.name)
+	}
+
+	var res uint64
+	for i := start; i <= end; i += step {
+		res |= 1 << uint(i)
+	}
+	return res, nil
+}
+
+func parseValue(value string, f field) (int, error) {
+	for i, name := range f.names {
+		if name != "" && strings.EqualFold(value, name) {
+			return i, nil
+		}
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("cron: invalid value %q of the %s", value, f.name)
+	}
+	return n, nil
+}
+
+// Next return the first run time after t, or the zero time if there is no
+// such time in five years, such as "0 0 30 2 *".
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// NextN return the first n run times after t.
+func (s *Schedule) NextN(t time.Time, n int) []time.Time {
+	res := make([]time.Time, 0, n)
+	for len(res) < n {
+		if t = s.Next(t); t.IsZero() {
+			break
+		}
+		res = append(res, t)
+	}
+	return res
+}
+
+// dayMatches check the day of month and the day of week. As the other cron
+// implementations, a day matches either of them when both are restricted.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	var (
+		domMatch = s.dom&(1<<uint(t.Day())) != 0
+		dowMatch = s.dow&(1<<uint(t.Weekday())) != 0
+	)
+	if s.isAll(s.fields[2]) || s.isAll(s.fields[4]) {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func (s *Schedule) isAll(value string) bool {
+	return value == "*" || value == "?"
+}
+
+var (
+	weekdays = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+	months   = []string{"", "January", "February", "March", "April", "May", "June", "July", "August",
+		"September", "October", "November", "December"}
+)
+
+// Describe return the human-readable text of the schedule, such as
+// "at 09:30, on Monday through Friday".
+func (s *Schedule) Describe(lang string) string {
+	var (
+		get   = func(key string) string { return language.GetWithLang(key, lang) }
+		parts = make([]string, 0, 3)
+	)
+
+	minutes, hours := values(s.minute, 0, 59), values(s.hour, 0, 23)
+	switch {
+	case s.isAll(s.fields[0]) && s.isAll(s.fields[1]):
+		parts = append(parts, get("every minute"))
+	case strings.HasPrefix(s.fields[0], "*/") && len(minutes) > 1 && s.isAll(s.fields[1]):
+		parts = append(parts, fmt.Sprintf(get("every %d minutes"), minutes[1]-minutes[0]))
+	case len(minutes) == 1 && s.isAll(s.fields[1]):
+		parts = append(parts, fmt.Sprintf(get("at minute %d past every hour"), minutes[0]))
+	case len(minutes)*len(hours) <= 6:
+		times := make([]string, 0, len(minutes)*len(hours))
+		for _, h := range hours {
+			for _, m := range minutes {
+				times = append(times, fmt.Sprintf("%02d:%02d", h, m))
+			}
+		}
+		parts = append(parts, fmt.Sprintf(get("at %s"), strings.Join(times, ", ")))
+	default:
+		parts = append(parts, fmt.Sprintf(get("at minute %s past hour %s"), s.fields[0], s.fields[1]))
+	}
+
+	days := make([]string, 0, 2)
+	if !s.isAll(s.fields[2]) {
+		days = append(days, fmt.Sprintf(get("on day %s of the month"), describeList(values(s.dom, 1, 31), nil, get)))
+	}
+	if !s.isAll(s.fields[4]) {
+		days = append(days, fmt.Sprintf(get("on %s"), describeList(values(s.dow, 0, 6), weekdays, get)))
+	}
+	if len(days) > 0 {
+		parts = append(parts, strings.Join(days, " "+get("or")+" "))
+	}
+	if !s.isAll(s.fields[3]) {
+		parts = append(parts, fmt.Sprintf(get("only in %s"), describeList(values(s.month, 1, 12), months, get)))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// describeList join the values, the consecutive values of more than two are
+// described as a range such as "Monday through Friday".
+func describeList(items []int, names []string, get func(string) string) string {
+	name := func(i int) string {
+		if names != nil {
+			return get(names[i])
+		}
+		return strconv.Itoa(i)
+	}
+
+	res := make([]string, 0, len(items))
+	for i := 0; i < len(items); {
+		j := i
+		for j+1 < len(items) && items[j+1] == items[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			res = append(res, fmt.Sprintf(get("%s through %s"), name(items[i]), name(items[j])))
+		} else {
+			for k := i; k <= j; k++ {
+				res = append(res, name(items[k]))
+			}
+		}
+		i = j + 1
+	}
+	return strings.Join(res, ", ")
+}
+
+func values(b uint64, min, max int) []int {
+	res := make([]int, 0, bits.OnesCount64(b))
+	for i := min; i <= max; i++ {
+		if b&(1<<uint(i)) != 0 {
+			res = append(res, i)
+		}
+	}
+	return res
+}
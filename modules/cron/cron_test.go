@@ -0,0 +1,94 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	for _, expr := range []string{"* * * * *", "*/15 9-17 * * MON-FRI", "0 0 1,15 * ?", "@daily",
+		"@Weekly", "5 4 * jan,jul 7", " 0  12 * * * "} {
+		_, err := Parse(expr)
+		assert.NoError(t, err, expr)
+	}
+
+	for _, expr := range []string{"", "* * * *", "* * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *",
+		"* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "a * * * *", "@every"} {
+		_, err := Parse(expr)
+		assert.Error(t, err, expr)
+	}
+
+	s, err := Parse(" 0  12 * * * ")
+	assert.NoError(t, err)
+	assert.Equal(t, "0 12 * * *", s.String())
+}
+
+func TestSchedule_Next(t *testing.T) {
+	from := time.Date(2024, 1, 31, 10, 20, 30, 0, time.UTC) // Wednesday
+
+	cases := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 1, 31, 10, 21, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 1, 31, 10, 30, 0, 0, time.UTC)},
+		{"30 9 * * *", time.Date(2024, 2, 1, 9, 30, 0, 0, time.UTC)},
+		{"0 0 * * SAT", time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// Either the day of month or the day of week matches when both are restricted.
+		{"0 0 15 * FRI", time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, c := range cases {
+		s, err := Parse(c.expr)
+		assert.NoError(t, err, c.expr)
+		assert.Equal(t, c.want, s.Next(from), c.expr)
+	}
+}
+
+func TestSchedule_NextN(t *testing.T) {
+	s, err := Parse("0 9 * * MON-FRI")
+	assert.NoError(t, err)
+
+	from := time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC) // Thursday
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 2, 2, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 5, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 6, 9, 0, 0, 0, time.UTC),
+	}, s.NextN(from, 3))
+
+	s, err = Parse("0 0 31 2 *")
+	assert.NoError(t, err)
+	assert.Empty(t, s.NextN(from, 5))
+}
+
+func TestSchedule_Describe(t *testing.T) {
+	cases := []struct {
+		expr, want string
+	}{
+		{"* * * * *", "every minute"},
+		{"*/5 * * * *", "every 5 minutes"},
+		{"15 * * * *", "at minute 15 past every hour"},
+		{"30 9 * * MON-FRI", "at 09:30, on Monday through Friday"},
+		{"0 8,20 1 * *", "at 08:00, 20:00, on day 1 of the month"},
+		{"0 0 1 * SUN", "at 00:00, on day 1 of the month or on Sunday"},
+		{"0 0 * 6-8 *", "at 00:00, only in June through August"},
+		{"*/10 9-17 * * *", "at minute */10 past hour 9-17"},
+	}
+
+	for _, c := range cases {
+		s, err := Parse(c.expr)
+		assert.NoError(t, err, c.expr)
+		assert.Equal(t, c.want, s.Describe(language.EN), c.expr)
+	}
+
+	s, _ := Parse("30 9 * * MON-FRI")
+	assert.Equal(t, "在09:30, 每星期一至星期五", s.Describe(language.CN))
+}
@@ -2,11 +2,9 @@ package db
 
 import (
 	"database/sql"
-	"errors"
 	"sync"
 
 	"github.com/purpose168/GoAdmin/modules/config"
-	"xorm.io/xorm"
 )
 
 // Base is a common Connection.
@@ -30,25 +28,6 @@ func (db *Base) GetDB(key string) *sql.DB {
 	return db.DbList[key]
 }
 
-func (db *Base) CreateDB(name string, beans ...interface{}) error {
-	cfg := db.GetConfig(name)
-	if cfg.Driver == "" {
-		return errors.New("wrong connection name")
-	}
-	engine, err := xorm.NewEngine(cfg.Driver, cfg.GetDSN())
-	if err != nil {
-		return err
-	}
-	defer func() {
-		_ = engine.Close()
-	}()
-	err = engine.Sync(beans...)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
 func (db *Base) GetConfig(name string) config.Database {
 	return db.Configs[name]
 }
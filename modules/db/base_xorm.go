@@ -0,0 +1,30 @@
+//go:build !goadmin_noxorm
+
+package db
+
+import (
+	"errors"
+
+	"xorm.io/xorm"
+)
+
+// CreateDB implements the method Connection.CreateDB, which syncs the tables
+// of the beans by xorm.
+func (db *Base) CreateDB(name string, beans ...interface{}) error {
+	cfg := db.GetConfig(name)
+	if cfg.Driver == "" {
+		return errors.New("wrong connection name")
+	}
+	engine, err := xorm.NewEngine(cfg.Driver, cfg.GetDSN())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = engine.Close()
+	}()
+	err = engine.Sync(beans...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build goadmin_noxorm
+
+package db
+
+import "errors"
+
+// CreateDB implements the method Connection.CreateDB, which fails as xorm is
+// excluded by the goadmin_noxorm build tag.
+func (db *Base) CreateDB(string, ...interface{}) error {
+	return errors.New("create db: xorm is excluded by the goadmin_noxorm build tag")
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ConstraintKind is the kind of the constraint violated by a statement.
+type ConstraintKind uint8
+
+const (
+	// ConstraintUnique is the violation of a unique index or a primary key.
+	ConstraintUnique ConstraintKind = iota + 1
+	// ConstraintForeignKey is the violation of a foreign key.
+	ConstraintForeignKey
+)
+
+// ConstraintError is a violation of a unique index or a foreign key parsed
+// from the error of the driver. The columns are known with the drivers which
+// report them, the name of the constraint is known otherwise, which usually
+// contains the names of the columns.
+type ConstraintError struct {
+	Kind       ConstraintKind
+	Constraint string
+	Columns    []string
+	Value      string
+	Err        error
+}
+
+// Error implements the error interface.
+func (e *ConstraintError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap return the error of the driver.
+func (e *ConstraintError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	mysqlDuplicateReg  = regexp.MustCompile("Error 1062.*Duplicate entry '(.*)' for key '([^']+)'")
+	mysqlForeignKeyReg = regexp.MustCompile("Error 145[12].*CONSTRAINT `([^`]+)` FOREIGN KEY \\(([^)]+)\\)")
+
+	postgresConstraintReg = regexp.MustCompile(`violates (unique|foreign key) constraint "([^"]+)"`)
+	postgresDetailReg     = regexp.MustCompile(`Key \(([^)]+)\)=\((.*)\)`)
+
+	sqliteUniqueReg = regexp.MustCompile(`UNIQUE constraint failed: (.+)$`)
+
+	mssqlUniqueReg     = regexp.MustCompile(`(?:constraint|unique index) '([^']+)'.*duplicate key value is \((.*)\)`)
+	mssqlForeignKeyReg = regexp.MustCompile(`FOREIGN KEY constraint "([^"]+)"`)
+)
+
+// pqError is the error of lib/pq, which keeps the detail of the error out of
+// the message.
+type pqError interface {
+	Get(k byte) string
+}
+
+// ParseConstraintError parse the error of the driver into a ConstraintError
+// when it is a violation of a unique index or a foreign key.
+func ParseConstraintError(driver string, err error) (*ConstraintError, bool) {
+	if err == nil {
+		return nil, false
+	}
+	var ce *ConstraintError
+	if errors.As(err, &ce) {
+		return ce, true
+	}
+
+	msg := err.Error()
+	ce = &ConstraintError{Err: err}
+
+	switch driver {
+	case DriverMysql, DriverOceanBase:
+		if m := mysqlDuplicateReg.FindStringSubmatch(msg); m != nil {
+			ce.Kind, ce.Value, ce.Constraint = ConstraintUnique, m[1], m[2]
+		} else if m := mysqlForeignKeyReg.FindStringSubmatch(msg); m != nil {
+			ce.Kind, ce.Constraint, ce.Columns = ConstraintForeignKey, m[1], splitColumns(m[2])
+		}
+	case DriverPostgresql:
+		m := postgresConstraintReg.FindStringSubmatch(msg)
+		if m == nil {
+			break
+		}
+		ce.Kind, ce.Constraint = ConstraintUnique, m[2]
+		if m[1] == "foreign key" {
+			ce.Kind = ConstraintForeignKey
+		}
+		var pe pqError
+		if errors.As(err, &pe) {
+			if d := postgresDetailReg.FindStringSubmatch(pe.Get('D')); d != nil {
+				ce.Columns, ce.Value = splitColumns(d[1]), d[2]
+			}
+		}
+	case DriverSqlite:
+		if m := sqliteUniqueReg.FindStringSubmatch(msg); m != nil {
+			ce.Kind, ce.Columns = ConstraintUnique, splitColumns(m[1])
+		} else if strings.Contains(msg, "FOREIGN KEY constraint failed") {
+			ce.Kind = ConstraintForeignKey
+		}
+	case DriverMssql:
+		if m := mssqlUniqueReg.FindStringSubmatch(msg); m != nil {
+			ce.Kind, ce.Constraint, ce.Value = ConstraintUnique, m[1], m[2]
+		} else if m := mssqlForeignKeyReg.FindStringSubmatch(msg); m != nil {
+			ce.Kind, ce.Constraint = ConstraintForeignKey, m[1]
+		}
+	}
+
+	if ce.Kind == 0 {
+		return nil, false
+	}
+	return ce, true
+}
+
+// MatchColumn return the column of the given ones violating the constraint.
+// The columns reported by the driver are used, the longest column contained
+// in the name of the constraint is returned otherwise.
+func (e *ConstraintError) MatchColumn(columns []string) (string, bool) {
+	for _, col := range e.Columns {
+		for _, c := range columns {
+			if strings.EqualFold(col, c) {
+				return c, true
+			}
+		}
+	}
+
+	name := "_" + strings.NewReplacer(".", "_", "-", "_", " ", "_").Replace(strings.ToLower(e.Constraint)) + "_"
+	match := ""
+	for _, c := range columns {
+		if len(c) > len(match) && strings.Contains(name, "_"+strings.ToLower(c)+"_") {
+			match = c
+		}
+	}
+	return match, match != ""
+}
+
+// splitColumns split the column list of the driver, such as "a, b" or
+// "users.a, users.b", into the column names.
+func splitColumns(list string) []string {
+	var columns []string
+	for _, col := range strings.Split(list, ",") {
+		col = strings.Trim(strings.TrimSpace(col), "`\"[]")
+		if i := strings.LastIndex(col, "."); i != -1 {
+			col = col[i+1:]
+		}
+		if col != "" {
+			columns = append(columns, col)
+		}
+	}
+	return columns
+}
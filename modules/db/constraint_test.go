@@ -0,0 +1,73 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+type testPqError struct {
+	msg    string
+	detail string
+}
+
+func (e *testPqError) Error() string { return "pq: " + e.msg }
+
+func (e *testPqError) Get(k byte) string {
+	if k == 'D' {
+		return e.detail
+	}
+	return ""
+}
+
+func TestParseConstraintError(t *testing.T) {
+	columns := []string{"id", "name", "email", "user_id"}
+
+	for _, c := range []struct {
+		driver string
+		err    error
+		kind   ConstraintKind
+		column string
+	}{
+		{DriverMysql, errors.New("Error 1062 (23000): Duplicate entry 'a@b.com' for key 'users.email'"), ConstraintUnique, "email"},
+		{DriverMysql, errors.New("Error 1062: Duplicate entry 'a@b.com' for key 'email'"), ConstraintUnique, "email"},
+		{DriverOceanBase, errors.New("Error 1062: Duplicate entry 'a' for key 'users_name_unique'"), ConstraintUnique, "name"},
+		{DriverMysql, errors.New("Error 1452: Cannot add or update a child row: a foreign key constraint fails " +
+			"(`db`.`posts`, CONSTRAINT `posts_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`))"), ConstraintForeignKey, "user_id"},
+		{DriverPostgresql, &testPqError{`duplicate key value violates unique constraint "users_email_key"`,
+			"Key (email)=(a@b.com) already exists."}, ConstraintUnique, "email"},
+		{DriverPostgresql, &testPqError{`insert or update on table "posts" violates foreign key constraint "fk_author"`,
+			`Key (user_id)=(9) is not present in table "users".`}, ConstraintForeignKey, "user_id"},
+		{DriverPostgresql, fmt.Errorf("post: %w", errors.New(`pq: duplicate key value violates unique constraint "users_user_id_key"`)),
+			ConstraintUnique, "user_id"},
+		{DriverSqlite, errors.New("UNIQUE constraint failed: users.email"), ConstraintUnique, "email"},
+		{DriverSqlite, errors.New("FOREIGN KEY constraint failed"), ConstraintForeignKey, ""},
+		{DriverMssql, errors.New("mssql: Violation of UNIQUE KEY constraint 'UQ_users_email'. Cannot insert duplicate key " +
+			"in object 'dbo.users'. The duplicate key value is (a@b.com)."), ConstraintUnique, "email"},
+		{DriverMssql, errors.New("mssql: Cannot insert duplicate key row in object 'dbo.users' with unique index " +
+			"'IX_users_name'. The duplicate key value is (a)."), ConstraintUnique, "name"},
+		{DriverMssql, errors.New(`mssql: The INSERT statement conflicted with the FOREIGN KEY constraint "FK_posts_user_id". ` +
+			`The conflict occurred in database "db", table "dbo.users", column 'id'.`), ConstraintForeignKey, "user_id"},
+	} {
+		ce, ok := ParseConstraintError(c.driver, c.err)
+		assert.Equal(t, ok, true, c.err.Error())
+		assert.Equal(t, ce.Kind, c.kind, c.err.Error())
+		assert.Equal(t, errors.Is(ce, c.err), true)
+		col, _ := ce.MatchColumn(columns)
+		assert.Equal(t, col, c.column, c.err.Error())
+	}
+
+	for driver, err := range map[string]error{
+		DriverMysql:      errors.New("Error 1146: Table 'db.users' doesn't exist"),
+		DriverSqlite:     errors.New("constraint failed"),
+		DriverPostgresql: errors.New("pq: syntax error"),
+		"":               errors.New("UNIQUE constraint failed: users.email"),
+	} {
+		_, ok := ParseConstraintError(driver, err)
+		assert.Equal(t, ok, false, err.Error())
+	}
+	_, ok := ParseConstraintError(DriverMysql, nil)
+	assert.Equal(t, ok, false)
+}
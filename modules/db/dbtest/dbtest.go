@@ -0,0 +1,378 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+// Package dbtest provides a fake db.Connection replying the queries with the
+// given rows and recording them, so the handlers and the plugins can be unit
+// tested without a live database.
+//
+//	conn := dbtest.New(db.DriverMysql).
+//		OnQuery("from `users`", map[string]interface{}{"id": int64(1), "name": "foo"}).
+//		OnExec("update `users`", dbtest.Result{Affected: 1})
+//
+//	res, _ := db.WithDriver(conn).Table("users").All()
+//	calls := conn.Calls()
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+)
+
+// ErrUnexpected is returned by the queries matching no expectation when the
+// connection is strict.
+var ErrUnexpected = errors.New("dbtest: unexpected query")
+
+// QueryFn replies a query.
+type QueryFn func(query string, args []interface{}) ([]map[string]interface{}, error)
+
+// ExecFn replies an exec.
+type ExecFn func(query string, args []interface{}) (sql.Result, error)
+
+// Call is a recorded query or exec.
+type Call struct {
+	Conn  string
+	Query string
+	Args  []interface{}
+	Exec  bool
+	InTx  bool
+}
+
+// Result is the sql.Result replied by the execs.
+type Result struct {
+	LastID   int64
+	Affected int64
+}
+
+// LastInsertId implements sql.Result.
+func (r Result) LastInsertId() (int64, error) { return r.LastID, nil }
+
+// RowsAffected implements sql.Result.
+func (r Result) RowsAffected() (int64, error) { return r.Affected, nil }
+
+type expectation struct {
+	pattern string
+	query   QueryFn
+	exec    ExecFn
+}
+
+// Connection is a fake db.Connection. The queries are matched against the
+// expectations in order by the case-insensitive substring of the pattern, the
+// queries matching nothing reply no rows and the execs reply an empty Result,
+// which the updates of db.SQL report as no affected row, or ErrUnexpected when
+// the connection is strict.
+//
+// The transactions are real *sql.Tx of a no-op driver, so the code beginning
+// and committing transactions works, and the queries of the transactions are
+// replied by the expectations as well.
+type Connection struct {
+	dialect db.Connection
+	strict  bool
+	configs config.DatabaseList
+
+	lock         sync.Mutex
+	expectations []expectation
+	calls        []Call
+	txDB         *sql.DB
+}
+
+// New return a fake connection of the driver, whose delimiters and name are
+// the same as the real connection of the driver.
+func New(driver string) *Connection {
+	return &Connection{
+		dialect: db.GetConnectionByDriver(driver),
+		configs: config.DatabaseList{"default": {Driver: driver}},
+	}
+}
+
+// Strict make the queries matching no expectation fail with ErrUnexpected.
+func (c *Connection) Strict() *Connection {
+	c.strict = true
+	return c
+}
+
+// OnQuery reply the queries containing the pattern with the rows.
+func (c *Connection) OnQuery(pattern string, rows ...map[string]interface{}) *Connection {
+	return c.OnQueryFn(pattern, func(string, []interface{}) ([]map[string]interface{}, error) {
+		return rows, nil
+	})
+}
+
+// OnQueryFn reply the queries containing the pattern with the function.
+func (c *Connection) OnQueryFn(pattern string, fn QueryFn) *Connection {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.expectations = append(c.expectations, expectation{pattern: strings.ToLower(pattern), query: fn})
+	return c
+}
+
+// OnExec reply the execs containing the pattern with the result.
+func (c *Connection) OnExec(pattern string, result sql.Result) *Connection {
+	return c.OnExecFn(pattern, func(string, []interface{}) (sql.Result, error) {
+		return result, nil
+	})
+}
+
+// OnExecFn reply the execs containing the pattern with the function.
+func (c *Connection) OnExecFn(pattern string, fn ExecFn) *Connection {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.expectations = append(c.expectations, expectation{pattern: strings.ToLower(pattern), exec: fn})
+	return c
+}
+
+// OnError fail the queries and the execs containing the pattern with the error.
+func (c *Connection) OnError(pattern string, err error) *Connection {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.expectations = append(c.expectations, expectation{
+		pattern: strings.ToLower(pattern),
+		query:   func(string, []interface{}) ([]map[string]interface{}, error) { return nil, err },
+		exec:    func(string, []interface{}) (sql.Result, error) { return nil, err },
+	})
+	return c
+}
+
+// Calls return the recorded queries and execs.
+func (c *Connection) Calls() []Call {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]Call(nil), c.calls...)
+}
+
+// Queries return the texts of the recorded queries and execs.
+func (c *Connection) Queries() []string {
+	calls := c.Calls()
+	res := make([]string, len(calls))
+	for i, call := range calls {
+		res[i] = call.Query
+	}
+	return res
+}
+
+// Reset remove the recorded calls, the expectations are kept.
+func (c *Connection) Reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.calls = nil
+}
+
+func (c *Connection) match(call Call) (expectation, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.calls = append(c.calls, call)
+	query := strings.ToLower(call.Query)
+	for _, e := range c.expectations {
+		if (call.Exec && e.exec == nil) || (!call.Exec && e.query == nil) {
+			continue
+		}
+		if strings.Contains(query, e.pattern) {
+			return e, true
+		}
+	}
+	return expectation{}, false
+}
+
+func (c *Connection) query(tx *sql.Tx, conn, query string, args []interface{}) ([]map[string]interface{}, error) {
+	e, ok := c.match(Call{Conn: conn, Query: query, Args: args, InTx: tx != nil})
+	if !ok {
+		if c.strict {
+			return nil, fmt.Errorf("%w: %s", ErrUnexpected, query)
+		}
+		return []map[string]interface{}{}, nil
+	}
+	return e.query(query, args)
+}
+
+func (c *Connection) exec(tx *sql.Tx, conn, query string, args []interface{}) (sql.Result, error) {
+	e, ok := c.match(Call{Conn: conn, Query: query, Args: args, Exec: true, InTx: tx != nil})
+	if !ok {
+		if c.strict {
+			return nil, fmt.Errorf("%w: %s", ErrUnexpected, query)
+		}
+		return Result{}, nil
+	}
+	return e.exec(query, args)
+}
+
+// Query implements the method Connection.Query.
+func (c *Connection) Query(query string, args ...interface{}) ([]map[string]interface{}, error) {
+	return c.query(nil, "default", query, args)
+}
+
+// Exec implements the method Connection.Exec.
+func (c *Connection) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.exec(nil, "default", query, args)
+}
+
+// QueryWithConnection implements the method Connection.QueryWithConnection.
+func (c *Connection) QueryWithConnection(conn, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	return c.query(nil, conn, query, args)
+}
+
+// QueryWithTx implements the method Connection.QueryWithTx.
+func (c *Connection) QueryWithTx(tx *sql.Tx, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	return c.query(tx, "default", query, args)
+}
+
+// QueryWith implements the method Connection.QueryWith.
+func (c *Connection) QueryWith(tx *sql.Tx, conn, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	return c.query(tx, conn, query, args)
+}
+
+// ExecWithConnection implements the method Connection.ExecWithConnection.
+func (c *Connection) ExecWithConnection(conn, query string, args ...interface{}) (sql.Result, error) {
+	return c.exec(nil, conn, query, args)
+}
+
+// ExecWithTx implements the method Connection.ExecWithTx.
+func (c *Connection) ExecWithTx(tx *sql.Tx, query string, args ...interface{}) (sql.Result, error) {
+	return c.exec(tx, "default", query, args)
+}
+
+// ExecWith implements the method Connection.ExecWith.
+func (c *Connection) ExecWith(tx *sql.Tx, conn, query string, args ...interface{}) (sql.Result, error) {
+	return c.exec(tx, conn, query, args)
+}
+
+// BeginTxWithReadUncommitted implements the method Connection.BeginTxWithReadUncommitted.
+func (c *Connection) BeginTxWithReadUncommitted() *sql.Tx {
+	return c.BeginTxWithLevel(sql.LevelReadUncommitted)
+}
+
+// BeginTxWithReadCommitted implements the method Connection.BeginTxWithReadCommitted.
+func (c *Connection) BeginTxWithReadCommitted() *sql.Tx {
+	return c.BeginTxWithLevel(sql.LevelReadCommitted)
+}
+
+// BeginTxWithRepeatableRead implements the method Connection.BeginTxWithRepeatableRead.
+func (c *Connection) BeginTxWithRepeatableRead() *sql.Tx {
+	return c.BeginTxWithLevel(sql.LevelRepeatableRead)
+}
+
+// BeginTx implements the method Connection.BeginTx.
+func (c *Connection) BeginTx() *sql.Tx {
+	return c.BeginTxWithLevel(sql.LevelDefault)
+}
+
+// BeginTxWithLevel implements the method Connection.BeginTxWithLevel.
+func (c *Connection) BeginTxWithLevel(level sql.IsolationLevel) *sql.Tx {
+	return c.BeginTxWithLevelAndConnection("default", level)
+}
+
+// BeginTxWithReadUncommittedAndConnection implements the method Connection.BeginTxWithReadUncommittedAndConnection.
+func (c *Connection) BeginTxWithReadUncommittedAndConnection(conn string) *sql.Tx {
+	return c.BeginTxWithLevelAndConnection(conn, sql.LevelReadUncommitted)
+}
+
+// BeginTxWithReadCommittedAndConnection implements the method Connection.BeginTxWithReadCommittedAndConnection.
+func (c *Connection) BeginTxWithReadCommittedAndConnection(conn string) *sql.Tx {
+	return c.BeginTxWithLevelAndConnection(conn, sql.LevelReadCommitted)
+}
+
+// BeginTxWithRepeatableReadAndConnection implements the method Connection.BeginTxWithRepeatableReadAndConnection.
+func (c *Connection) BeginTxWithRepeatableReadAndConnection(conn string) *sql.Tx {
+	return c.BeginTxWithLevelAndConnection(conn, sql.LevelRepeatableRead)
+}
+
+// BeginTxAndConnection implements the method Connection.BeginTxAndConnection.
+func (c *Connection) BeginTxAndConnection(conn string) *sql.Tx {
+	return c.BeginTxWithLevelAndConnection(conn, sql.LevelDefault)
+}
+
+// BeginTxWithLevelAndConnection implements the method Connection.BeginTxWithLevelAndConnection.
+func (c *Connection) BeginTxWithLevelAndConnection(_ string, level sql.IsolationLevel) *sql.Tx {
+	tx, err := c.GetDB("default").BeginTx(context.Background(), &sql.TxOptions{Isolation: level})
+	if err != nil {
+		panic(err)
+	}
+	return tx
+}
+
+// InitDB implements the method Connection.InitDB, the configs are kept only.
+func (c *Connection) InitDB(cfg map[string]config.Database) db.Connection {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.configs = cfg
+	return c
+}
+
+// Name implements the method Connection.Name.
+func (c *Connection) Name() string {
+	return c.dialect.Name()
+}
+
+// Close implements the method Connection.Close.
+func (c *Connection) Close() []error {
+	return []error{}
+}
+
+// GetDelimiter implements the method Connection.GetDelimiter.
+func (c *Connection) GetDelimiter() string {
+	return c.dialect.GetDelimiter()
+}
+
+// GetDelimiter2 implements the method Connection.GetDelimiter2.
+func (c *Connection) GetDelimiter2() string {
+	return c.dialect.GetDelimiter2()
+}
+
+// GetDelimiters implements the method Connection.GetDelimiters.
+func (c *Connection) GetDelimiters() []string {
+	return c.dialect.GetDelimiters()
+}
+
+// GetDB implements the method Connection.GetDB, the returned database of the
+// no-op driver supports the transactions only.
+func (c *Connection) GetDB(string) *sql.DB {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.txDB == nil {
+		c.txDB = sql.OpenDB(noopConnector{})
+	}
+	return c.txDB
+}
+
+// GetConfig implements the method Connection.GetConfig.
+func (c *Connection) GetConfig(name string) config.Database {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.configs[name]
+}
+
+// CreateDB implements the method Connection.CreateDB, which is not supported.
+func (c *Connection) CreateDB(string, ...interface{}) error {
+	return errors.New("dbtest: create database is not supported")
+}
+
+var errNoop = errors.New("dbtest: use the methods of the connection to query")
+
+// noopConnector connects to the no-op driver, whose transactions commit and
+// rollback successfully and whose statements are not supported.
+type noopConnector struct{}
+
+func (noopConnector) Connect(context.Context) (driver.Conn, error) { return noopConn{}, nil }
+func (noopConnector) Driver() driver.Driver                        { return noopDriver{} }
+
+type noopDriver struct{}
+
+func (noopDriver) Open(string) (driver.Conn, error) { return noopConn{}, nil }
+
+type noopConn struct{}
+
+func (noopConn) Prepare(string) (driver.Stmt, error) { return nil, errNoop }
+func (noopConn) Close() error                        { return nil }
+func (noopConn) Begin() (driver.Tx, error)           { return noopConn{}, nil }
+func (noopConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return noopConn{}, nil
+}
+func (noopConn) Commit() error   { return nil }
+func (noopConn) Rollback() error { return nil }
@@ -0,0 +1,63 @@
+package dbtest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnection(t *testing.T) {
+	conn := New(db.DriverMysql).
+		OnQuery("from `users`", map[string]interface{}{"id": int64(1), "name": "foo"}).
+		OnExec("insert into `users`", Result{LastID: 2, Affected: 1}).
+		OnError("`roles`", errors.New("broken"))
+
+	assert.Equal(t, db.DriverMysql, conn.Name())
+	assert.Equal(t, "`", conn.GetDelimiter())
+
+	res, err := db.WithDriver(conn).Table("users").Where("id", "=", 1).All()
+	assert.Nil(t, err)
+	assert.Equal(t, "foo", res[0]["name"])
+
+	id, err := db.WithDriver(conn).Table("users").Insert(dialect.H{"name": "bar"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), id)
+
+	_, err = db.WithDriver(conn).Table("roles").All()
+	assert.EqualError(t, err, "broken")
+
+	res, err = db.WithDriver(conn).Table("menus").All()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(res))
+
+	calls := conn.Calls()
+	assert.Equal(t, 4, len(calls))
+	assert.Equal(t, []interface{}{1}, calls[0].Args)
+	assert.Equal(t, true, calls[1].Exec)
+
+	conn.Reset()
+	assert.Equal(t, 0, len(conn.Queries()))
+}
+
+func TestConnection_Tx(t *testing.T) {
+	conn := New(db.DriverPostgresql).OnExec("update", Result{Affected: 1})
+
+	tx := conn.BeginTxWithReadCommitted()
+	_, err := db.WithDriver(conn).WithTx(tx).Table("users").Update(dialect.H{"name": "foo"})
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Commit())
+	assert.Equal(t, true, conn.Calls()[0].InTx)
+	assert.Equal(t, `"`, conn.GetDelimiter())
+}
+
+func TestConnection_Strict(t *testing.T) {
+	conn := New(db.DriverSqlite).Strict()
+
+	_, err := conn.Query("select 1")
+	assert.True(t, errors.Is(err, ErrUnexpected))
+	_, err = conn.Exec("delete from users")
+	assert.True(t, errors.Is(err, ErrUnexpected))
+}
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/logger"
+)
+
+// ConnectionRetryInterval is the interval at which the unreachable
+// connections are pinged again.
+var ConnectionRetryInterval = 30 * time.Second
+
+// downConnections keeps the errors of the connections unreachable at the
+// initialization, by the names of the connections.
+var downConnections = struct {
+	sync.RWMutex
+	errs map[string]error
+}{errs: make(map[string]error)}
+
+// ConnectionError return the error of the connection when it is unreachable,
+// nil otherwise.
+func ConnectionError(conn string) error {
+	downConnections.RLock()
+	defer downConnections.RUnlock()
+	return downConnections.errs[conn]
+}
+
+// DownConnections return the errors of the unreachable connections by their
+// names.
+func DownConnections() map[string]error {
+	downConnections.RLock()
+	defer downConnections.RUnlock()
+	errs := make(map[string]error, len(downConnections.errs))
+	for conn, err := range downConnections.errs {
+		errs[conn] = err
+	}
+	return errs
+}
+
+func setConnectionError(conn string, err error) {
+	downConnections.Lock()
+	defer downConnections.Unlock()
+	if err == nil {
+		delete(downConnections.errs, conn)
+	} else {
+		downConnections.errs[conn] = err
+	}
+}
+
+// pingDB check the connection at the initialization.
+func pingDB(conn string, sqlDB *sql.DB) {
+	checkConnection(conn, sqlDB.Ping)
+}
+
+// checkConnection ping the connection. The default connection keeps the
+// tables of the admin, so its error panics. The other ones are marked down
+// instead and pinged again every ConnectionRetryInterval in the background
+// until they are reachable, the admin starts without them.
+func checkConnection(conn string, ping func() error) {
+	err := ping()
+	if err == nil {
+		return
+	}
+	if conn == "default" {
+		panic(err)
+	}
+
+	logger.Error("connection ", conn, " is unreachable, retry in the background: ", err)
+	setConnectionError(conn, err)
+	go func() {
+		for {
+			time.Sleep(ConnectionRetryInterval)
+			if err := ping(); err != nil {
+				setConnectionError(conn, err)
+				continue
+			}
+			setConnectionError(conn, nil)
+			logger.Info("connection ", conn, " is reachable")
+			return
+		}
+	}()
+}
@@ -0,0 +1,40 @@
+package db
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestCheckConnection(t *testing.T) {
+	interval := ConnectionRetryInterval
+	ConnectionRetryInterval = time.Millisecond
+	defer func() { ConnectionRetryInterval = interval }()
+
+	checkConnection("ok", func() error { return nil })
+	assert.Equal(t, ConnectionError("ok"), nil)
+
+	var pings int32
+	checkConnection("secondary", func() error {
+		if atomic.AddInt32(&pings, 1) < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	assert.Equal(t, ConnectionError("secondary") != nil, true)
+	assert.Equal(t, DownConnections()["secondary"] != nil, true)
+
+	// the connection is marked up once the retry pings it.
+	for i := 0; i < 1000 && ConnectionError("secondary") != nil; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, ConnectionError("secondary"), nil)
+	assert.Equal(t, len(DownConnections()), 0)
+
+	assert.Panic(t, func() {
+		checkConnection("default", func() error { return errors.New("connection refused") })
+	}, "connection refused")
+}
@@ -272,9 +272,7 @@ func (db *Mssql) InitDB(cfgs map[string]config.Database) Connection {
 
 			db.DbList[conn] = sqlDB
 
-			if err := sqlDB.Ping(); err != nil {
-				panic(err)
-			}
+			pingDB(conn, sqlDB)
 		}
 	})
 	return db
@@ -63,9 +63,7 @@ func (db *OceanBase) InitDB(cfgs map[string]config.Database) Connection {
 
 			db.DbList[conn] = sqlDB
 
-			if err := sqlDB.Ping(); err != nil {
-				panic(err)
-			}
+			pingDB(conn, sqlDB)
 		}
 	})
 	return db
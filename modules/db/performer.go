@@ -14,12 +14,22 @@ import (
 // CommonQuery is a common method of query.
 func CommonQuery(db *sql.DB, query string, args ...interface{}) ([]map[string]interface{}, error) {
 
+	if r := currentRevert(); r != nil {
+		return r.query(db, query, args...)
+	}
+
 	rs, err := db.Query(query, args...)
 
 	if err != nil {
 		return nil, err
 	}
 
+	return scanRows(rs)
+}
+
+// scanRows read the rows and close them.
+func scanRows(rs *sql.Rows) ([]map[string]interface{}, error) {
+
 	defer func() {
 		if rs != nil {
 			_ = rs.Close()
@@ -66,6 +76,9 @@ func CommonQuery(db *sql.DB, query string, args ...interface{}) ([]map[string]in
 
 // CommonExec is a common method of exec.
 func CommonExec(db *sql.DB, query string, args ...interface{}) (sql.Result, error) {
+	if r := currentRevert(); r != nil {
+		return r.exec(db, query, args...)
+	}
 
 	rs, err := db.Exec(query, args...)
 	if err != nil {
@@ -77,58 +90,26 @@ func CommonExec(db *sql.DB, query string, args ...interface{}) (sql.Result, erro
 // CommonQueryWithTx is a common method of query.
 func CommonQueryWithTx(tx *sql.Tx, query string, args ...interface{}) ([]map[string]interface{}, error) {
 
-	rs, err := tx.Query(query, args...)
-
-	if err != nil {
-		panic(err)
+	if r := currentRevert(); r != nil && r.owns(tx) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
 	}
 
-	defer func() {
-		if rs != nil {
-			_ = rs.Close()
-		}
-	}()
-
-	col, colErr := rs.Columns()
-
-	if colErr != nil {
-		return nil, colErr
-	}
+	rs, err := tx.Query(query, args...)
 
-	typeVal, err := rs.ColumnTypes()
 	if err != nil {
-		return nil, err
+		panic(err)
 	}
 
-	// TODO: regular expressions for sqlite, use the dialect module
-	// tell the drive to reduce the performance loss
-	results := make([]map[string]interface{}, 0)
-
-	r, _ := regexp.Compile(`\\((.*)\\)`)
-	for rs.Next() {
-		var colVar = make([]interface{}, len(col))
-		for i := 0; i < len(col); i++ {
-			typeName := strings.ToUpper(r.ReplaceAllString(typeVal[i].DatabaseTypeName(), ""))
-			SetColVarType(&colVar, i, typeName)
-		}
-		result := make(map[string]interface{})
-		if scanErr := rs.Scan(colVar...); scanErr != nil {
-			return nil, scanErr
-		}
-		for j := 0; j < len(col); j++ {
-			typeName := strings.ToUpper(r.ReplaceAllString(typeVal[j].DatabaseTypeName(), ""))
-			SetResultValue(&result, col[j], colVar[j], typeName)
-		}
-		results = append(results, result)
-	}
-	if err := rs.Err(); err != nil {
-		return nil, err
-	}
-	return results, nil
+	return scanRows(rs)
 }
 
 // CommonExecWithTx is a common method of exec.
 func CommonExecWithTx(tx *sql.Tx, query string, args ...interface{}) (sql.Result, error) {
+	if r := currentRevert(); r != nil && r.owns(tx) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+	}
 	rs, err := tx.Exec(query, args...)
 	if err != nil {
 		return nil, err
@@ -138,6 +119,13 @@ func CommonExecWithTx(tx *sql.Tx, query string, args ...interface{}) (sql.Result
 
 // CommonBeginTxWithLevel starts a transaction with given transaction isolation level and db connection.
 func CommonBeginTxWithLevel(db *sql.DB, level sql.IsolationLevel) *sql.Tx {
+	if r := currentRevert(); r != nil {
+		tx, err := r.begin(db)
+		if err != nil {
+			panic(err)
+		}
+		return tx
+	}
 	tx, err := db.BeginTx(context.Background(), &sql.TxOptions{Isolation: level})
 	if err != nil {
 		panic(err)
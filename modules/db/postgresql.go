@@ -110,9 +110,7 @@ func (db *Postgresql) InitDB(cfgList map[string]config.Database) Connection {
 
 			db.DbList[conn] = sqlDB
 
-			if err := sqlDB.Ping(); err != nil {
-				panic(err)
-			}
+			pingDB(conn, sqlDB)
 		}
 	})
 	return db
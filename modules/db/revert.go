@@ -0,0 +1,149 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"sync/atomic"
+)
+
+// revertTx holds the transactions of the writes reverted by RevertWrites, one
+// of each database, which are begun by the first statement and rolled back
+// when RevertWrites returns.
+type revertTx struct {
+	mu  sync.Mutex
+	txs map[*sql.DB]*sql.Tx
+}
+
+var (
+	// revertLock is held by RevertWrites, and read by WaitRevert, so that the
+	// other requests do not run in the reverted transactions.
+	revertLock sync.RWMutex
+	reverting  atomic.Pointer[revertTx]
+)
+
+// RevertWrites call fn with its writes reverted, which is used by the demo
+// mode. While fn is running, every statement of the connections, including
+// the ones of the goroutines started by fn, runs in a transaction rolled back
+// after fn returns, so the statements succeed and the later reads of fn see
+// the earlier writes, but nothing is saved. The transactions of
+// WithTransaction are the same transaction, and they are not committed.
+//
+// The calls of RevertWrites are serialized, and the other requests should run
+// by WaitRevert, so that their writes, such as the operation logs, are not
+// reverted. The writes of the goroutines still running after fn returns are
+// saved as usual.
+func RevertWrites(fn func()) {
+	revertLock.Lock()
+	defer revertLock.Unlock()
+
+	r := &revertTx{txs: make(map[*sql.DB]*sql.Tx)}
+	reverting.Store(r)
+	defer func() {
+		reverting.Store(nil)
+		r.rollback()
+	}()
+	fn()
+}
+
+// WaitRevert call fn when no RevertWrites is running, and RevertWrites waits
+// for fn to return.
+func WaitRevert(fn func()) {
+	revertLock.RLock()
+	defer revertLock.RUnlock()
+	fn()
+}
+
+// currentRevert return the transactions of the running RevertWrites, or nil.
+func currentRevert() *revertTx {
+	return reverting.Load()
+}
+
+// tx return the transaction of the database, r.mu must be held.
+func (r *revertTx) tx(db *sql.DB) (*sql.Tx, error) {
+	if tx, ok := r.txs[db]; ok {
+		return tx, nil
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	r.txs[db] = tx
+	return tx, nil
+}
+
+// begin return the transaction of the database as the transaction of
+// WithTransaction.
+func (r *revertTx) begin(db *sql.DB) (*sql.Tx, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.tx(db)
+}
+
+// owns report whether the transaction is one of r.
+func (r *revertTx) owns(tx *sql.Tx) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, t := range r.txs {
+		if t == tx {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *revertTx) exec(db *sql.DB, query string, args ...interface{}) (sql.Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tx, err := r.tx(db)
+	if err != nil {
+		return nil, err
+	}
+	return tx.Exec(query, args...)
+}
+
+func (r *revertTx) query(db *sql.DB, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tx, err := r.tx(db)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanRows(rs)
+}
+
+func (r *revertTx) rollback() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, tx := range r.txs {
+		_ = tx.Rollback()
+	}
+}
+
+// isRevertTx report whether the transaction is one of the running
+// RevertWrites, which is committed or rolled back by RevertWrites only.
+func isRevertTx(tx *sql.Tx) bool {
+	r := currentRevert()
+	return r != nil && r.owns(tx)
+}
+
+// commit commit the transaction of WithTransaction, the transaction of
+// RevertWrites is left open.
+func commit(tx *sql.Tx) error {
+	if isRevertTx(tx) {
+		return nil
+	}
+	return tx.Commit()
+}
+
+// rollback roll back the transaction of WithTransaction, the transaction of
+// RevertWrites is left open.
+func rollback(tx *sql.Tx) {
+	if isRevertTx(tx) {
+		return
+	}
+	_ = tx.Rollback()
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	dbsql "database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/modules/config"
+)
+
+func TestRevertWrites(t *testing.T) {
+	conn := GetSqliteDB()
+	conn.InitDB(config.DatabaseList{"default": {
+		Driver: DriverSqlite,
+		File:   filepath.Join(t.TempDir(), "revert.db"),
+	}})
+	_, err := conn.Exec("create table posts (id integer primary key autoincrement, title text)")
+	assert.Equal(t, err, nil)
+
+	count := func() int {
+		rows, err := WithDriver(conn).Table("posts").All()
+		assert.Equal(t, err, nil)
+		return len(rows)
+	}
+
+	RevertWrites(func() {
+		_, err := WithDriver(conn).Table("posts").Insert(map[string]interface{}{"title": "a"})
+		assert.Equal(t, err, nil)
+		_, err = WithDriver(conn).WithTransaction(func(tx *dbsql.Tx) (error, map[string]interface{}) {
+			_, err := WithDriver(conn).WithTx(tx).Table("posts").Insert(map[string]interface{}{"title": "b"})
+			return err, nil
+		})
+		assert.Equal(t, err, nil)
+
+		// the writes of the goroutines started by fn are reverted as well.
+		done := make(chan error)
+		go func() {
+			_, err := WithDriver(conn).Table("posts").Insert(map[string]interface{}{"title": "c"})
+			done <- err
+		}()
+		assert.Equal(t, <-done, nil)
+
+		// the later reads see the earlier writes.
+		assert.Equal(t, count(), 3)
+	})
+	assert.Equal(t, count(), 0)
+
+	_, err = WithDriver(conn).Table("posts").Insert(map[string]interface{}{"title": "d"})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, count(), 1)
+
+	// the other callers wait for RevertWrites.
+	started, written := make(chan struct{}), make(chan struct{})
+	go func() {
+		<-started
+		WaitRevert(func() {
+			_, err := WithDriver(conn).Table("posts").Insert(map[string]interface{}{"title": "e"})
+			assert.Equal(t, err, nil)
+		})
+		close(written)
+	}()
+	RevertWrites(func() {
+		close(started)
+		_, err := WithDriver(conn).Table("posts").Insert(map[string]interface{}{"title": "f"})
+		assert.Equal(t, err, nil)
+	})
+	<-written
+	assert.Equal(t, count(), 2)
+}
@@ -96,9 +96,7 @@ func (db *Sqlite) InitDB(cfgList map[string]config.Database) Connection {
 
 			db.DbList[conn] = sqlDB
 
-			if err := sqlDB.Ping(); err != nil {
-				panic(err)
-			}
+			pingDB(conn, sqlDB)
 		}
 	})
 	return db
@@ -11,7 +11,9 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/purpose168/GoAdmin/context"
 	"github.com/purpose168/GoAdmin/modules/db/dialect"
 	"github.com/purpose168/GoAdmin/modules/logger"
 )
@@ -23,6 +25,8 @@ type SQL struct {
 	dialect dialect.Dialect
 	conn    string
 	tx      *dbsql.Tx
+	ctx     *context.Context
+	begin   time.Time
 }
 
 // SQLPool is a object pool of SQL.
@@ -52,7 +56,9 @@ type H map[string]interface{}
 
 // newSQL get a new SQL from SQLPool.
 func newSQL() *SQL {
-	return SQLPool.Get().(*SQL)
+	sql := SQLPool.Get().(*SQL)
+	sql.begin = time.Now()
+	return sql
 }
 
 // *******************************
@@ -104,6 +110,13 @@ func (sql *SQL) WithTx(tx *dbsql.Tx) *SQL {
 	return sql
 }
 
+// WithContext set the request of SQL, the statement is logged with the request
+// id and recorded for the debug panel of the page, see logger.LogSQLCtx.
+func (sql *SQL) WithContext(ctx *context.Context) *SQL {
+	sql.ctx = ctx
+	return sql
+}
+
 // TableName set table of SQL.
 func (sql *SQL) Table(table string) *SQL {
 	sql.clean()
@@ -369,14 +382,14 @@ func (sql *SQL) WithTransaction(fn TxFn) (res map[string]interface{}, err error)
 	defer func() {
 		if p := recover(); p != nil {
 			// a panic occurred, rollback and repanic
-			_ = tx.Rollback()
+			rollback(tx)
 			panic(p)
 		} else if err != nil {
 			// something went wrong, rollback
-			_ = tx.Rollback()
+			rollback(tx)
 		} else {
 			// all good, commit
-			err = tx.Commit()
+			err = commit(tx)
 		}
 	}()
 
@@ -393,14 +406,14 @@ func (sql *SQL) WithTransactionByLevel(level dbsql.IsolationLevel, fn TxFn) (res
 	defer func() {
 		if p := recover(); p != nil {
 			// a panic occurred, rollback and repanic
-			_ = tx.Rollback()
+			rollback(tx)
 			panic(p)
 		} else if err != nil {
 			// something went wrong, rollback
-			_ = tx.Rollback()
+			rollback(tx)
 		} else {
 			// all good, commit
-			err = tx.Commit()
+			err = commit(tx)
 		}
 	}()
 
@@ -607,6 +620,39 @@ func (sql *SQL) Insert(values dialect.H) (int64, error) {
 	return res.LastInsertId()
 }
 
+// InsertAndGetID insert the values and return the id of the inserted row. The
+// drivers such as postgresql do not return the inserted id, then the latest row
+// whose key fields equal to the values is found instead.
+func (sql *SQL) InsertAndGetID(values dialect.H, keys ...string) (int64, error) {
+	var (
+		table = sql.TableName
+		conn  = sql.conn
+		diver = sql.diver
+		tx    = sql.tx
+	)
+
+	id, err := sql.Insert(values)
+	if CheckError(err, INSERT) {
+		return 0, err
+	}
+	if id != 0 || len(keys) == 0 {
+		return id, nil
+	}
+
+	find := WithDriverAndConnection(conn, diver).WithTx(tx).Table(table)
+	for _, key := range keys {
+		find = find.Where(key, "=", values[key])
+	}
+	row, err := find.OrderBy("id", "desc").First()
+	if CheckError(err, QUERY) {
+		return 0, err
+	}
+	if row == nil {
+		return 0, nil
+	}
+	return GetValueFromDatabaseType(Int, row["id"], false).ToInt64(), nil
+}
+
 func (sql *SQL) wrap(field string) string {
 	return sql.diver.GetDelimiter() + field + sql.diver.GetDelimiter2()
 }
@@ -631,13 +677,14 @@ func (sql *SQL) clean() {
 // RecycleSQL clear the SQL and put into the pool.
 func RecycleSQL(sql *SQL) {
 
-	logger.LogSQL(sql.Statement, sql.Args)
+	logger.LogSQLCtx(sql.ctx, sql.Statement, sql.Args, time.Since(sql.begin))
 
 	sql.clean()
 
 	sql.conn = ""
 	sql.diver = nil
 	sql.tx = nil
+	sql.ctx = nil
 	sql.dialect = nil
 
 	SQLPool.Put(sql)
@@ -2,9 +2,11 @@ package db
 
 import (
 	"database/sql"
+	"path/filepath"
 	"testing"
 
 	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/modules/config"
 	_ "github.com/purpose168/GoAdmin/modules/db/drivers/mssql"
 	_ "github.com/purpose168/GoAdmin/modules/db/drivers/postgres"
 )
@@ -101,5 +103,26 @@ func testSQLExec(t *testing.T, conn Connection) {}
 // TODO
 func testSQLInsert(t *testing.T, conn Connection) {}
 
+func TestSQL_InsertAndGetID(t *testing.T) {
+	conn := GetSqliteDB()
+	conn.InitDB(config.DatabaseList{"default": {
+		Driver: DriverSqlite,
+		File:   filepath.Join(t.TempDir(), "insert.db"),
+	}})
+	_, err := conn.Exec("create table posts (id integer primary key autoincrement, title text)")
+	assert.Equal(t, err, nil)
+	// the tables without rowid do not return the inserted id.
+	_, err = conn.Exec("create table tags (id integer primary key, name text) without rowid")
+	assert.Equal(t, err, nil)
+
+	id, err := WithDriver(conn).Table("posts").InsertAndGetID(map[string]interface{}{"title": "a"}, "title")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, id, int64(1))
+
+	id, err = WithDriver(conn).Table("tags").InsertAndGetID(map[string]interface{}{"id": 7, "name": "a"}, "name")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, id, int64(7))
+}
+
 // TODO
 func testSQLWrap(t *testing.T, conn Connection) {}
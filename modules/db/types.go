@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"html/template"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // DatabaseType is the database field type.
@@ -111,6 +113,7 @@ const (
 
 	Name DatabaseType = "NAME"
 	UUID DatabaseType = "UUID"
+	ULID DatabaseType = "ULID"
 
 	Timestamptz DatabaseType = "TIMESTAMPTZ"
 	Timetz      DatabaseType = "TIMETZ"
@@ -143,7 +146,7 @@ var (
 		Line, Lseg, Box, Path, Polygon, Circle, Cidr, Inet, Macaddr, Character, Varyingcharacter,
 		Nchar, Nativecharacter, Nvarchar, Clob, Binary, Varbinary, Enum, Set, Geometry, Multilinestring,
 		Multipolygon, Linestring, Multipoint, Geometrycollection, Name, UUID, Timestamptz,
-		Name, UUID, Inet}
+		Name, UUID, Inet, ULID}
 
 	// BoolTypeList is a DatabaseType list of bool.
 	BoolTypeList = []DatabaseType{Bool, Boolean}
@@ -280,3 +283,29 @@ func GetValueFromJSONOfDatabaseType(typ DatabaseType, value interface{}) Value {
 	}
 	panic("wrong type：" + string(typ))
 }
+
+// timeLayouts are the layouts of the times returned by the drivers.
+var timeLayouts = []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// ParseTime return the time of the value of a datetime column, which is saved
+// as the local time. Some drivers return the times in the format of RFC 3339
+// such as 2006-01-02T15:04:05Z, whose zone is ignored. It returns the zero time
+// when the value is nil or can not be parsed.
+func ParseTime(value interface{}) time.Time {
+	if value == nil {
+		return time.Time{}
+	}
+	if t, ok := value.(time.Time); ok {
+		return t
+	}
+	text := strings.Replace(GetValueFromSQLOfDatabaseType(Datetime, value).String(), "T", " ", 1)
+	for _, layout := range timeLayouts {
+		if len(text) < len(layout) {
+			continue
+		}
+		if t, err := time.ParseInLocation(layout, text[:len(layout)], time.Local); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
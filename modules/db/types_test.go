@@ -241,3 +241,13 @@ func testCurrentPath() string {
 	}
 	return dir
 }
+
+func TestParseTime(t *testing.T) {
+	assert.Equal(t, ParseTime("2026-10-19 08:30:00").Format("2006-01-02 15:04:05"), "2026-10-19 08:30:00")
+	assert.Equal(t, ParseTime([]byte("2026-10-19T08:30:00Z")).Format("2006-01-02 15:04:05"), "2026-10-19 08:30:00")
+	assert.Equal(t, ParseTime("2026-10-19 08:30").Format("2006-01-02 15:04:05"), "2026-10-19 08:30:00")
+	assert.Equal(t, ParseTime("2026-10-19").Format("2006-01-02 15:04:05"), "2026-10-19 00:00:00")
+	assert.Equal(t, ParseTime("2026-10-19 08:30:00").Location(), time.Local)
+	assert.Equal(t, ParseTime(nil).IsZero(), true)
+	assert.Equal(t, ParseTime("bad").IsZero(), true)
+}
@@ -20,6 +20,7 @@ const (
 	OperationNotAllow    = "operation not allow"
 	EditFailWrongToken   = "edit fail, wrong token"
 	CreateFailWrongToken = "create fail, wrong token"
+	DuplicateSubmit      = "duplicate submit, the form is already submitted"
 	NoPermission         = "no permission"
 	SiteOff              = "site is off"
 )
@@ -0,0 +1,100 @@
+// Package event is the bus of the data changes made by the tables of the
+// admin plugin. The handlers subscribed with SubscribeTx are called within the
+// transaction of the change, the ones subscribed with Subscribe are called
+// asynchronously after the change is committed.
+package event
+
+import (
+	dbsql "database/sql"
+	"sync"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/logger"
+)
+
+// Action is the kind of the data change.
+type Action string
+
+const (
+	Insert Action = "insert"
+	Update Action = "update"
+	Delete Action = "delete"
+)
+
+// Event is a data change of a table.
+type Event struct {
+	Connection string                 `json:"connection"`
+	Table      string                 `json:"table"`
+	Action     Action                 `json:"action"`
+	IDs        []string               `json:"ids"`
+	Values     map[string]interface{} `json:"values,omitempty"`
+	Time       time.Time              `json:"time"`
+}
+
+// Handler handles the committed event.
+type Handler func(e Event)
+
+// TxHandler handles the event within the transaction of the change, the change
+// is rolled back when it returns an error.
+type TxHandler func(tx *dbsql.Tx, e Event) error
+
+var (
+	lock       sync.RWMutex
+	handlers   = make([]Handler, 0)
+	txHandlers = make([]TxHandler, 0)
+)
+
+// Subscribe add a handler of the committed events.
+func Subscribe(h Handler) {
+	lock.Lock()
+	defer lock.Unlock()
+	handlers = append(handlers, h)
+}
+
+// SubscribeTx add a handler called within the transaction of the change.
+func SubscribeTx(h TxHandler) {
+	lock.Lock()
+	defer lock.Unlock()
+	txHandlers = append(txHandlers, h)
+}
+
+// HasTxSubscriber check if the changes should be made in a transaction.
+func HasTxSubscriber() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return len(txHandlers) > 0
+}
+
+// PublishTx call the transaction handlers and return the first error.
+func PublishTx(tx *dbsql.Tx, e Event) error {
+	lock.RLock()
+	list := make([]TxHandler, len(txHandlers))
+	copy(list, txHandlers)
+	lock.RUnlock()
+
+	for _, h := range list {
+		if err := h(tx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Publish call the handlers of the committed event asynchronously.
+func Publish(e Event) {
+	lock.RLock()
+	list := make([]Handler, len(handlers))
+	copy(list, handlers)
+	lock.RUnlock()
+
+	for _, h := range list {
+		go func(h Handler) {
+			defer func() {
+				if err := recover(); err != nil {
+					logger.Error("event handler error: ", err)
+				}
+			}()
+			h(e)
+		}(h)
+	}
+}
@@ -0,0 +1,43 @@
+package mq
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Kafka publishes the messages to the Kafka brokers.
+type Kafka struct {
+	writer  *kafka.Writer
+	timeout time.Duration
+}
+
+// NewKafka return a publisher of the brokers, the messages of the same key
+// are sent to the same partition.
+func NewKafka(brokers ...string) *Kafka {
+	return &Kafka{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		},
+		timeout: time.Second * 10,
+	}
+}
+
+// Publish implements Publisher.Publish.
+func (k *Kafka) Publish(topic, key string, payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), k.timeout)
+	defer cancel()
+	return k.writer.WriteMessages(ctx, kafka.Message{
+		Topic: topic,
+		Key:   []byte(key),
+		Value: payload,
+	})
+}
+
+// Close implements Publisher.Close.
+func (k *Kafka) Close() error {
+	return k.writer.Close()
+}
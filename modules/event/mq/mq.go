@@ -0,0 +1,235 @@
+// Package mq publishes the data change events of the event bus to message
+// queues such as Kafka or NATS.
+//
+// The events are written to the outbox table within the transaction of the
+// change, and a relay publishes the rows of the outbox and deletes them once
+// the message queue accepted them. So an event is delivered at least once even
+// if the process or the message queue goes down after the change is committed.
+//
+// The values of the changes are only published for the fields allowed by
+// Outbox.Fields, so the columns such as the passwords do not leave the
+// database by default.
+package mq
+
+import (
+	dbsql "database/sql"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+	"github.com/purpose168/GoAdmin/modules/event"
+	"github.com/purpose168/GoAdmin/modules/logger"
+)
+
+// TableName is the name of the outbox table, see the migrations of data
+// directory for the schema.
+const TableName = "goadmin_event_outbox"
+
+// Publisher sends the message to a topic of the message queue. Publish should
+// only return after the message queue accepted the message.
+type Publisher interface {
+	Publish(topic, key string, payload []byte) error
+	Close() error
+}
+
+// Outbox writes the events of the configured tables to the outbox table and
+// relays them to the publisher.
+type Outbox struct {
+	conn     db.Connection
+	connName string
+	pub      Publisher
+	interval time.Duration
+	batch    int
+
+	lock   sync.RWMutex
+	topics map[string]string
+	fields map[string]map[string]struct{}
+
+	notify chan struct{}
+	stop   chan struct{}
+	once   sync.Once
+}
+
+// New return an outbox of the default connection.
+func New(conn db.Connection, pub Publisher) *Outbox {
+	return &Outbox{
+		conn:     conn,
+		connName: "default",
+		pub:      pub,
+		interval: time.Second * 5,
+		batch:    100,
+		topics:   make(map[string]string),
+		fields:   make(map[string]map[string]struct{}),
+		notify:   make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+	}
+}
+
+// SetConnection set the connection name of the outbox, only the changes of the
+// connection are written to the outbox.
+func (o *Outbox) SetConnection(name string) *Outbox {
+	o.connName = name
+	return o
+}
+
+// SetInterval set the polling interval of the relay.
+func (o *Outbox) SetInterval(interval time.Duration) *Outbox {
+	o.interval = interval
+	return o
+}
+
+// SetBatch set the max count of the rows relayed at a time.
+func (o *Outbox) SetBatch(batch int) *Outbox {
+	o.batch = batch
+	return o
+}
+
+// Topic publish the changes of the table to the topic. The table "*" matches
+// the tables which have no topic.
+func (o *Outbox) Topic(table, topic string) *Outbox {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.topics[table] = topic
+	return o
+}
+
+func (o *Outbox) topic(table string) string {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+	if topic, ok := o.topics[table]; ok {
+		return topic
+	}
+	return o.topics["*"]
+}
+
+// Fields publish the values of the fields of the table with the events, the
+// values of the other fields are removed. No value is published for the
+// tables without the fields.
+func (o *Outbox) Fields(table string, fields ...string) *Outbox {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	set := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		set[field] = struct{}{}
+	}
+	o.fields[table] = set
+	return o
+}
+
+// values return the values of the event allowed by Fields.
+func (o *Outbox) values(table string, values map[string]interface{}) map[string]interface{} {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+	allowed, ok := o.fields[table]
+	if !ok || len(values) == 0 {
+		return nil
+	}
+	res := make(map[string]interface{}, len(allowed))
+	for field, value := range values {
+		if _, ok := allowed[field]; ok {
+			res[field] = value
+		}
+	}
+	return res
+}
+
+// Start subscribe the event bus and run the relay in background.
+func (o *Outbox) Start() {
+	event.SubscribeTx(o.write)
+	event.Subscribe(func(e event.Event) {
+		if o.accept(e) {
+			select {
+			case o.notify <- struct{}{}:
+			default:
+			}
+		}
+	})
+	go o.run()
+}
+
+// Stop the relay and close the publisher.
+func (o *Outbox) Stop() {
+	o.once.Do(func() {
+		close(o.stop)
+		if err := o.pub.Close(); err != nil {
+			logger.Error("close event publisher error: ", err)
+		}
+	})
+}
+
+func (o *Outbox) accept(e event.Event) bool {
+	name := e.Connection
+	if name == "" {
+		name = "default"
+	}
+	return name == o.connName && o.topic(e.Table) != ""
+}
+
+func (o *Outbox) write(tx *dbsql.Tx, e event.Event) error {
+	if !o.accept(e) {
+		return nil
+	}
+	e.Values = o.values(e.Table, e.Values)
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = db.WithDriverAndConnection(o.connName, o.conn).WithTx(tx).Table(TableName).Insert(dialect.H{
+		"topic":      o.topic(e.Table),
+		"event_key":  e.Table + ":" + strings.Join(e.IDs, ","),
+		"payload":    string(payload),
+		"created_at": e.Time.Format("2006-01-02 15:04:05"),
+	})
+	if db.CheckError(err, db.INSERT) {
+		return err
+	}
+	return nil
+}
+
+func (o *Outbox) run() {
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+	for {
+		if err := o.Flush(); err != nil {
+			logger.Error("relay event outbox error: ", err)
+		}
+		select {
+		case <-o.stop:
+			return
+		case <-ticker.C:
+		case <-o.notify:
+		}
+	}
+}
+
+// Flush publish the pending rows of the outbox in order, it stops at the first
+// failure so that the rows are retried in the same order later.
+func (o *Outbox) Flush() error {
+	for {
+		rows, err := db.WithDriverAndConnection(o.connName, o.conn).Table(TableName).
+			OrderBy("id", "asc").
+			Take(o.batch).
+			All()
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := o.pub.Publish(db.GetValueFromDatabaseType(db.Varchar, row["topic"], false).String(),
+				db.GetValueFromDatabaseType(db.Varchar, row["event_key"], false).String(),
+				[]byte(db.GetValueFromDatabaseType(db.Text, row["payload"], false).String())); err != nil {
+				return err
+			}
+			if err := db.WithDriverAndConnection(o.connName, o.conn).Table(TableName).
+				Where("id", "=", row["id"]).
+				Delete(); db.CheckError(err, db.DELETE) {
+				return err
+			}
+		}
+		if len(rows) < o.batch {
+			return nil
+		}
+	}
+}
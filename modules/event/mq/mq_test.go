@@ -0,0 +1,115 @@
+package mq
+
+import (
+	dbsql "database/sql"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	_ "github.com/purpose168/GoAdmin/modules/db/drivers/sqlite"
+	"github.com/purpose168/GoAdmin/modules/event"
+)
+
+func TestAccept(t *testing.T) {
+	o := New(nil, nil).Topic("users", "user-changes")
+
+	assert.Equal(t, o.accept(event.Event{Table: "users"}), true)
+	assert.Equal(t, o.accept(event.Event{Connection: "default", Table: "users"}), true)
+	assert.Equal(t, o.accept(event.Event{Connection: "mysql2", Table: "users"}), false)
+	assert.Equal(t, o.accept(event.Event{Table: "posts"}), false)
+
+	o.Topic("*", "changes")
+	assert.Equal(t, o.topic("posts"), "changes")
+	assert.Equal(t, o.topic("users"), "user-changes")
+}
+
+type message struct {
+	topic, key string
+	payload    event.Event
+}
+
+type publisher struct {
+	fails int
+	sent  []message
+}
+
+func (p *publisher) Publish(topic, key string, payload []byte) error {
+	if p.fails > 0 {
+		p.fails--
+		return errors.New("unavailable")
+	}
+	var e event.Event
+	if err := json.Unmarshal(payload, &e); err != nil {
+		return err
+	}
+	p.sent = append(p.sent, message{topic: topic, key: key, payload: e})
+	return nil
+}
+
+func (p *publisher) Close() error {
+	return nil
+}
+
+func TestOutbox(t *testing.T) {
+	conn := db.GetSqliteDB()
+	conn.InitDB(config.DatabaseList{"default": {
+		Driver: db.DriverSqlite,
+		File:   filepath.Join(t.TempDir(), "outbox.db"),
+	}})
+	migration, err := os.ReadFile("../../../data/migrations/admin_2026_10_15_000000_sqlite.sql")
+	assert.Equal(t, err, nil)
+	_, err = conn.Exec(string(migration))
+	assert.Equal(t, err, nil)
+
+	pub := &publisher{fails: 1}
+	o := New(conn, pub).SetBatch(1).
+		Topic("users", "user-changes").
+		Fields("users", "name")
+
+	change := func(id string, fail bool) error {
+		_, err := db.WithDriver(conn).WithTransaction(func(tx *dbsql.Tx) (error, map[string]interface{}) {
+			err := o.write(tx, event.Event{Table: "users", Action: event.Update, IDs: []string{id},
+				Values: map[string]interface{}{"name": "n" + id, "password": "secret"}, Time: time.Now()})
+			if err == nil && fail {
+				err = errors.New("failed")
+			}
+			return err, nil
+		})
+		return err
+	}
+
+	// the events of the rolled back changes are not written.
+	assert.Equal(t, change("1", false), nil)
+	assert.Equal(t, change("2", true) != nil, true)
+	assert.Equal(t, change("3", false), nil)
+	rows, err := db.WithDriver(conn).Table(TableName).All()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(rows), 2)
+
+	// the rows are kept when the publisher fails, and retried in order.
+	assert.Equal(t, o.Flush() != nil, true)
+	assert.Equal(t, len(pub.sent), 0)
+	assert.Equal(t, o.Flush(), nil)
+	assert.Equal(t, len(pub.sent), 2)
+	assert.Equal(t, pub.sent[0].topic, "user-changes")
+	assert.Equal(t, pub.sent[0].key, "users:1")
+	assert.Equal(t, pub.sent[1].key, "users:3")
+	assert.Equal(t, pub.sent[0].payload.Values, map[string]interface{}{"name": "n1"})
+	rows, err = db.WithDriver(conn).Table(TableName).All()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(rows), 0)
+}
+
+func TestOutbox_Values(t *testing.T) {
+	o := New(nil, nil).Fields("users", "name", "email")
+	values := map[string]interface{}{"name": "a", "email": "a@example.com", "password": "secret"}
+
+	assert.Equal(t, o.values("users", values), map[string]interface{}{"name": "a", "email": "a@example.com"})
+	assert.Equal(t, o.values("posts", values) == nil, true)
+}
@@ -0,0 +1,64 @@
+package mq
+
+import (
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// KeyHeader is the message header of the event key.
+const KeyHeader = "Goadmin-Event-Key"
+
+// NATS publishes the messages to the subjects of a NATS server. When JetStream
+// is enabled the message is acknowledged by the stream of the subject,
+// otherwise the connection is flushed after each message.
+type NATS struct {
+	conn    *nats.Conn
+	js      nats.JetStreamContext
+	timeout time.Duration
+}
+
+// NewNATS connect to the NATS server of the url.
+func NewNATS(url string, opts ...nats.Option) (*NATS, error) {
+	conn, err := nats.Connect(url, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &NATS{conn: conn, timeout: time.Second * 10}, nil
+}
+
+// NewJetStream connect to the NATS server of the url and publish the messages
+// to JetStream.
+func NewJetStream(url string, opts ...nats.Option) (*NATS, error) {
+	n, err := NewNATS(url, opts...)
+	if err != nil {
+		return nil, err
+	}
+	n.js, err = n.conn.JetStream()
+	if err != nil {
+		n.conn.Close()
+		return nil, err
+	}
+	return n, nil
+}
+
+// Publish implements Publisher.Publish.
+func (n *NATS) Publish(topic, key string, payload []byte) error {
+	msg := nats.NewMsg(topic)
+	msg.Header.Set(KeyHeader, key)
+	msg.Data = payload
+	if n.js != nil {
+		_, err := n.js.PublishMsg(msg, nats.AckWait(n.timeout))
+		return err
+	}
+	if err := n.conn.PublishMsg(msg); err != nil {
+		return err
+	}
+	return n.conn.FlushTimeout(n.timeout)
+}
+
+// Close implements Publisher.Close.
+func (n *NATS) Close() error {
+	n.conn.Close()
+	return nil
+}
@@ -0,0 +1,527 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrDivisionByZero is returned by the division and the modulo by zero.
+var ErrDivisionByZero = errors.New("expr: division by zero")
+
+type node interface{}
+
+type literalNode struct {
+	value interface{}
+}
+
+type identNode struct {
+	name string
+}
+
+type unaryNode struct {
+	op      string
+	operand node
+	pos     int
+}
+
+type binaryNode struct {
+	op          string
+	left, right node
+	pos         int
+}
+
+type condNode struct {
+	cond, then, otherwise node
+}
+
+type callNode struct {
+	name string
+	fn   function
+	args []node
+	pos  int
+}
+
+func walk(n node, fn func(node)) {
+	fn(n)
+	switch n := n.(type) {
+	case unaryNode:
+		walk(n.operand, fn)
+	case binaryNode:
+		walk(n.left, fn)
+		walk(n.right, fn)
+	case condNode:
+		walk(n.cond, fn)
+		walk(n.then, fn)
+		walk(n.otherwise, fn)
+	case callNode:
+		for _, arg := range n.args {
+			walk(arg, fn)
+		}
+	}
+}
+
+// Eval evaluate the expression with the values of the row, the values not
+// in the row are null. The result is a float64, a string, a bool or nil.
+func (p *Program) Eval(vars map[string]interface{}) (interface{}, error) {
+	return eval(p.root, vars)
+}
+
+// EvalBool evaluate the expression and return whether the result is truthy.
+func (p *Program) EvalBool(vars map[string]interface{}) (bool, error) {
+	v, err := p.Eval(vars)
+	if err != nil {
+		return false, err
+	}
+	return Truthy(v), nil
+}
+
+// EvalString evaluate the expression and return the result as a string.
+func (p *Program) EvalString(vars map[string]interface{}) (string, error) {
+	v, err := p.Eval(vars)
+	if err != nil {
+		return "", err
+	}
+	return ToString(v), nil
+}
+
+// StringVars convert the values of a posted form, which are all strings, to
+// the values of the expressions.
+func StringVars(row map[string]string) map[string]interface{} {
+	vars := make(map[string]interface{}, len(row))
+	for k, v := range row {
+		vars[k] = v
+	}
+	return vars
+}
+
+func eval(n node, vars map[string]interface{}) (interface{}, error) {
+	switch n := n.(type) {
+	case literalNode:
+		return n.value, nil
+	case identNode:
+		return normalize(vars[n.name]), nil
+	case unaryNode:
+		v, err := eval(n.operand, vars)
+		if err != nil {
+			return nil, err
+		}
+		if n.op == "!" {
+			return !Truthy(v), nil
+		}
+		f, ok := toNumber(v)
+		if !ok {
+			return nil, fmt.Errorf("expr: %s is not a number", describe(v))
+		}
+		return -f, nil
+	case binaryNode:
+		return evalBinary(n, vars)
+	case condNode:
+		cond, err := eval(n.cond, vars)
+		if err != nil {
+			return nil, err
+		}
+		if Truthy(cond) {
+			return eval(n.then, vars)
+		}
+		return eval(n.otherwise, vars)
+	case callNode:
+		args := make([]interface{}, len(n.args))
+		for i, arg := range n.args {
+			v, err := eval(arg, vars)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = v
+		}
+		v, err := n.fn.call(args)
+		if err != nil {
+			return nil, fmt.Errorf("expr: %s: %v", n.name, err)
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("expr: unknown node %T", n)
+}
+
+func evalBinary(n binaryNode, vars map[string]interface{}) (interface{}, error) {
+	left, err := eval(n.left, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	// the logical operators are short-circuit.
+	switch n.op {
+	case "&&":
+		if !Truthy(left) {
+			return false, nil
+		}
+		right, err := eval(n.right, vars)
+		return Truthy(right), err
+	case "||":
+		if Truthy(left) {
+			return true, nil
+		}
+		right, err := eval(n.right, vars)
+		return Truthy(right), err
+	}
+
+	right, err := eval(n.right, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	case "<", "<=", ">", ">=":
+		c := compare(left, right)
+		switch n.op {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		default:
+			return c >= 0, nil
+		}
+	}
+
+	a, aok := toNumber(left)
+	b, bok := toNumber(right)
+	if n.op == "+" && (!aok || !bok) {
+		return ToString(left) + ToString(right), nil
+	}
+	if !aok {
+		return nil, fmt.Errorf("expr: %s is not a number", describe(left))
+	}
+	if !bok {
+		return nil, fmt.Errorf("expr: %s is not a number", describe(right))
+	}
+	switch n.op {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	case "/":
+		if b == 0 {
+			return nil, ErrDivisionByZero
+		}
+		return a / b, nil
+	case "%":
+		if b == 0 {
+			return nil, ErrDivisionByZero
+		}
+		return math.Mod(a, b), nil
+	}
+	return nil, fmt.Errorf("expr: unknown operator %s", n.op)
+}
+
+// Truthy report whether the value is true in the conditions. Null, false,
+// zero, the empty string, "0" and "false" are false.
+func Truthy(v interface{}) bool {
+	switch v := normalize(v).(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != "" && v != "0" && !strings.EqualFold(v, "false")
+	}
+	return true
+}
+
+// ToString format the value as a string, null is the empty string and the
+// numbers have no trailing zeros.
+func ToString(v interface{}) string {
+	switch v := normalize(v).(type) {
+	case nil:
+		return ""
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// normalize convert the values of the rows to the types of the expressions.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil, bool, float64, string:
+		return v
+	case []byte:
+		return string(v)
+	case int:
+		return float64(v)
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint:
+		return float64(v)
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05")
+	case []string:
+		return strings.Join(v, ",")
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// toNumber convert the numbers and the strings of numbers to float64.
+func toNumber(v interface{}) (float64, bool) {
+	switch v := normalize(v).(type) {
+	case float64:
+		return v, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func equal(a, b interface{}) bool {
+	a, b = normalize(a), normalize(b)
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if x, ok := a.(bool); ok {
+		return x == Truthy(b)
+	}
+	if y, ok := b.(bool); ok {
+		return y == Truthy(a)
+	}
+	if x, ok := toNumber(a); ok {
+		if y, ok := toNumber(b); ok {
+			return x == y
+		}
+	}
+	return ToString(a) == ToString(b)
+}
+
+// compare compare the values as numbers when both are numbers, or as
+// strings.
+func compare(a, b interface{}) int {
+	if x, ok := toNumber(a); ok {
+		if y, ok := toNumber(b); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	return strings.Compare(ToString(a), ToString(b))
+}
+
+func describe(v interface{}) string {
+	if s, ok := normalize(v).(string); ok {
+		return strconv.Quote(s)
+	}
+	if v == nil {
+		return "null"
+	}
+	return ToString(v)
+}
+
+// *******************************
+// functions
+// *******************************
+
+type function struct {
+	minArgs, maxArgs int
+	call             func(args []interface{}) (interface{}, error)
+}
+
+var functions = map[string]function{
+	"len": {1, 1, func(args []interface{}) (interface{}, error) {
+		return float64(utf8.RuneCountInString(ToString(args[0]))), nil
+	}},
+	"upper": {1, 1, func(args []interface{}) (interface{}, error) {
+		return strings.ToUpper(ToString(args[0])), nil
+	}},
+	"lower": {1, 1, func(args []interface{}) (interface{}, error) {
+		return strings.ToLower(ToString(args[0])), nil
+	}},
+	"trim": {1, 1, func(args []interface{}) (interface{}, error) {
+		return strings.TrimSpace(ToString(args[0])), nil
+	}},
+	"contains": {2, 2, func(args []interface{}) (interface{}, error) {
+		return strings.Contains(ToString(args[0]), ToString(args[1])), nil
+	}},
+	"startsWith": {2, 2, func(args []interface{}) (interface{}, error) {
+		return strings.HasPrefix(ToString(args[0]), ToString(args[1])), nil
+	}},
+	"endsWith": {2, 2, func(args []interface{}) (interface{}, error) {
+		return strings.HasSuffix(ToString(args[0]), ToString(args[1])), nil
+	}},
+	"matches": {2, 2, func(args []interface{}) (interface{}, error) {
+		reg, err := compileRegexp(ToString(args[1]))
+		if err != nil {
+			return nil, err
+		}
+		return reg.MatchString(ToString(args[0])), nil
+	}},
+	"empty": {1, 1, func(args []interface{}) (interface{}, error) {
+		return strings.TrimSpace(ToString(args[0])) == "", nil
+	}},
+	"in": {2, -1, func(args []interface{}) (interface{}, error) {
+		for _, arg := range args[1:] {
+			if equal(args[0], arg) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}},
+	"number": {1, 1, func(args []interface{}) (interface{}, error) {
+		f, ok := toNumber(args[0])
+		if !ok {
+			return nil, fmt.Errorf("%s is not a number", describe(args[0]))
+		}
+		return f, nil
+	}},
+	"string": {1, 1, func(args []interface{}) (interface{}, error) {
+		return ToString(args[0]), nil
+	}},
+	"abs":   {1, 1, numberFunc(math.Abs)},
+	"floor": {1, 1, numberFunc(math.Floor)},
+	"ceil":  {1, 1, numberFunc(math.Ceil)},
+	"round": {1, 2, func(args []interface{}) (interface{}, error) {
+		nums, err := numbers(args)
+		if err != nil {
+			return nil, err
+		}
+		if len(nums) == 1 {
+			return math.Round(nums[0]), nil
+		}
+		scale := math.Pow(10, math.Floor(nums[1]))
+		return math.Round(nums[0]*scale) / scale, nil
+	}},
+	"fixed": {2, 2, func(args []interface{}) (interface{}, error) {
+		nums, err := numbers(args)
+		if err != nil {
+			return nil, err
+		}
+		if nums[1] < 0 || nums[1] > 20 {
+			return nil, fmt.Errorf("wrong decimals %s", ToString(nums[1]))
+		}
+		return strconv.FormatFloat(nums[0], 'f', int(nums[1]), 64), nil
+	}},
+	"min": {1, -1, func(args []interface{}) (interface{}, error) {
+		nums, err := numbers(args)
+		if err != nil {
+			return nil, err
+		}
+		sort.Float64s(nums)
+		return nums[0], nil
+	}},
+	"max": {1, -1, func(args []interface{}) (interface{}, error) {
+		nums, err := numbers(args)
+		if err != nil {
+			return nil, err
+		}
+		sort.Float64s(nums)
+		return nums[len(nums)-1], nil
+	}},
+}
+
+// Functions return the names of the functions of the expressions.
+func Functions() []string {
+	names := make([]string, 0, len(functions))
+	for name := range functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func numberFunc(fn func(float64) float64) func(args []interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		nums, err := numbers(args)
+		if err != nil {
+			return nil, err
+		}
+		return fn(nums[0]), nil
+	}
+}
+
+func numbers(args []interface{}) ([]float64, error) {
+	nums := make([]float64, len(args))
+	for i, arg := range args {
+		f, ok := toNumber(arg)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a number", describe(arg))
+		}
+		nums[i] = f
+	}
+	return nums, nil
+}
+
+// maxRegexps is the most patterns cached by matches.
+const maxRegexps = 256
+
+var (
+	regexpsLock sync.Mutex
+	regexps     = make(map[string]*regexp.Regexp)
+)
+
+// compileRegexp compile the pattern of matches, the patterns are cached as
+// they are usually literals.
+func compileRegexp(pattern string) (*regexp.Regexp, error) {
+	regexpsLock.Lock()
+	defer regexpsLock.Unlock()
+	if reg, ok := regexps[pattern]; ok {
+		return reg, nil
+	}
+	if len(pattern) > MaxLength {
+		return nil, fmt.Errorf("pattern longer than %d bytes", MaxLength)
+	}
+	reg, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(regexps) < maxRegexps {
+		regexps[pattern] = reg
+	}
+	return reg, nil
+}
@@ -0,0 +1,400 @@
+// Package expr is a small sandboxed expression language used by the computed
+// displays, the validations, the row formatting and the visibility of the
+// fields, so they can be written in the JSON definitions of the tables
+// without recompiling.
+//
+// An expression reads the values of the row by the names of the columns and
+// has no access to anything else, it has no loops or assignments, so the
+// evaluation always ends:
+//
+//	p, err := expr.Compile("price * qty")
+//	total, err := p.Eval(map[string]interface{}{"price": 9.5, "qty": "2"}) // 19
+//
+//	p = expr.MustCompile(`status == "paid" && amount > 1000`)
+//	ok, err := p.EvalBool(row)
+//
+// The literals are the numbers, the strings quoted by ' or ", true, false and
+// null. The operators are + - * / % == != < <= > >= && || ! and the ternary
+// cond ? a : b, "and", "or" and "not" are the aliases of && || and !. The
+// strings of numbers are treated as numbers by the arithmetic and the
+// comparison, as the values of the rows are usually strings, + joins the
+// values as strings when any of them is not a number. The functions are
+// listed by Functions.
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// MaxLength is the most bytes of the source of an expression.
+const MaxLength = 1024
+
+// maxDepth is the most nested levels of an expression.
+const maxDepth = 64
+
+// SyntaxError is the error of compiling an expression, Pos is the byte
+// offset of the source where the error is found.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("expr: %s at position %d", e.Msg, e.Pos+1)
+}
+
+// Program is a compiled expression, it is safe for the concurrent use.
+type Program struct {
+	src  string
+	root node
+}
+
+var cache sync.Map
+
+// Compile compile the expression, the programs are cached by the sources.
+func Compile(src string) (*Program, error) {
+	if p, ok := cache.Load(src); ok {
+		return p.(*Program), nil
+	}
+	if len(src) > MaxLength {
+		return nil, &SyntaxError{Pos: MaxLength, Msg: fmt.Sprintf("expression longer than %d bytes", MaxLength)}
+	}
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	ps := &parser{tokens: tokens}
+	root, err := ps.expression(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := ps.peek(); tok.kind != tokEOF {
+		return nil, &SyntaxError{Pos: tok.pos, Msg: "unexpected " + tok.String()}
+	}
+	p := &Program{src: src, root: root}
+	cache.Store(src, p)
+	return p, nil
+}
+
+// MustCompile is like Compile but panics when the expression is wrong, it is
+// used by the expressions written in Go.
+func MustCompile(src string) *Program {
+	p, err := Compile(src)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// String return the source of the expression.
+func (p *Program) String() string {
+	return p.src
+}
+
+// Identifiers return the names of the values read by the expression.
+func (p *Program) Identifiers() []string {
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	walk(p.root, func(n node) {
+		if id, ok := n.(identNode); ok && !seen[id.name] {
+			seen[id.name] = true
+			names = append(names, id.name)
+		}
+	})
+	return names
+}
+
+// *******************************
+// lexer
+// *******************************
+
+type tokenKind uint8
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	num  float64
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return strconv.Quote(t.text)
+	default:
+		return `"` + t.text + `"`
+	}
+}
+
+// operators is sorted by the length so the longest one is matched first.
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||",
+	"+", "-", "*", "/", "%", "<", ">", "!", "(", ")", ",", "?", ":"}
+
+// keywordOperators is the word aliases of the operators.
+var keywordOperators = map[string]string{"and": "&&", "or": "||", "not": "!"}
+
+func lex(src string) ([]token, error) {
+	tokens := make([]token, 0)
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c >= '0' && c <= '9' || c == '.' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9':
+			start := i
+			for i < len(src) && (src[i] >= '0' && src[i] <= '9' || src[i] == '.') {
+				i++
+			}
+			if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+				i++
+				if i < len(src) && (src[i] == '+' || src[i] == '-') {
+					i++
+				}
+				for i < len(src) && src[i] >= '0' && src[i] <= '9' {
+					i++
+				}
+			}
+			num, err := strconv.ParseFloat(src[start:i], 64)
+			if err != nil {
+				return nil, &SyntaxError{Pos: start, Msg: "wrong number " + src[start:i]}
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: src[start:i], num: num, pos: start})
+		case c == '"' || c == '\'':
+			start := i
+			var sb strings.Builder
+			i++
+			for {
+				if i >= len(src) {
+					return nil, &SyntaxError{Pos: start, Msg: "unterminated string"}
+				}
+				if src[i] == c {
+					i++
+					break
+				}
+				if src[i] == '\\' && i+1 < len(src) {
+					i++
+					switch src[i] {
+					case 'n':
+						sb.WriteByte('\n')
+					case 't':
+						sb.WriteByte('\t')
+					default:
+						sb.WriteByte(src[i])
+					}
+					i++
+					continue
+				}
+				sb.WriteByte(src[i])
+				i++
+			}
+			tokens = append(tokens, token{kind: tokString, text: sb.String(), pos: start})
+		case c == '_' || c < 0x80 && unicode.IsLetter(rune(c)):
+			start := i
+			for i < len(src) && (src[i] == '_' || src[i] < 0x80 && (unicode.IsLetter(rune(src[i])) || unicode.IsDigit(rune(src[i])))) {
+				i++
+			}
+			word := src[start:i]
+			if op, ok := keywordOperators[word]; ok {
+				tokens = append(tokens, token{kind: tokOp, text: op, pos: start})
+			} else {
+				tokens = append(tokens, token{kind: tokIdent, text: word, pos: start})
+			}
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(src[i:], op) {
+					tokens = append(tokens, token{kind: tokOp, text: op, pos: i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, &SyntaxError{Pos: i, Msg: fmt.Sprintf("unexpected character %q", c)}
+			}
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(src)}), nil
+}
+
+// *******************************
+// parser
+// *******************************
+
+// binaryPrecedence is the precedence of the binary operators, the ternary
+// operator has the lowest one.
+var binaryPrecedence = map[string]int{
+	"||": 2,
+	"&&": 3,
+	"==": 4, "!=": 4,
+	"<": 5, "<=": 5, ">": 5, ">=": 5,
+	"+": 6, "-": 6,
+	"*": 7, "/": 7, "%": 7,
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+func (ps *parser) peek() token {
+	return ps.tokens[ps.pos]
+}
+
+func (ps *parser) next() token {
+	tok := ps.tokens[ps.pos]
+	if tok.kind != tokEOF {
+		ps.pos++
+	}
+	return tok
+}
+
+func (ps *parser) isOp(op string) bool {
+	tok := ps.peek()
+	return tok.kind == tokOp && tok.text == op
+}
+
+func (ps *parser) expect(op string) error {
+	if tok := ps.next(); tok.kind != tokOp || tok.text != op {
+		return &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("expect %q but got %s", op, tok.String())}
+	}
+	return nil
+}
+
+// expression parse the operators whose precedences are higher than the
+// given one by the precedence climbing.
+func (ps *parser) expression(minPrecedence int) (node, error) {
+	ps.depth++
+	defer func() { ps.depth-- }()
+	if ps.depth > maxDepth {
+		return nil, &SyntaxError{Pos: ps.peek().pos, Msg: "expression nested too deeply"}
+	}
+
+	left, err := ps.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := ps.peek()
+		if tok.kind != tokOp {
+			return left, nil
+		}
+		if tok.text == "?" && minPrecedence <= 1 {
+			ps.next()
+			then, err := ps.expression(1)
+			if err != nil {
+				return nil, err
+			}
+			if err := ps.expect(":"); err != nil {
+				return nil, err
+			}
+			otherwise, err := ps.expression(1)
+			if err != nil {
+				return nil, err
+			}
+			left = condNode{cond: left, then: then, otherwise: otherwise}
+			continue
+		}
+		precedence, ok := binaryPrecedence[tok.text]
+		if !ok || precedence <= minPrecedence {
+			return left, nil
+		}
+		ps.next()
+		right, err := ps.expression(precedence)
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: tok.text, left: left, right: right, pos: tok.pos}
+	}
+}
+
+func (ps *parser) unary() (node, error) {
+	if ps.isOp("!") || ps.isOp("-") {
+		tok := ps.next()
+		ps.depth++
+		defer func() { ps.depth-- }()
+		if ps.depth > maxDepth {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: "expression nested too deeply"}
+		}
+		operand, err := ps.unary()
+		if err != nil {
+			return nil, err
+		}
+		return unaryNode{op: tok.text, operand: operand, pos: tok.pos}, nil
+	}
+	return ps.primary()
+}
+
+func (ps *parser) primary() (node, error) {
+	tok := ps.next()
+	switch tok.kind {
+	case tokNumber:
+		return literalNode{value: tok.num}, nil
+	case tokString:
+		return literalNode{value: tok.text}, nil
+	case tokIdent:
+		switch tok.text {
+		case "true":
+			return literalNode{value: true}, nil
+		case "false":
+			return literalNode{value: false}, nil
+		case "null", "nil":
+			return literalNode{value: nil}, nil
+		}
+		if !ps.isOp("(") {
+			return identNode{name: tok.text}, nil
+		}
+		fn, ok := functions[tok.text]
+		if !ok {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: "unknown function " + tok.text}
+		}
+		ps.next()
+		args := make([]node, 0)
+		for !ps.isOp(")") {
+			if len(args) > 0 {
+				if err := ps.expect(","); err != nil {
+					return nil, err
+				}
+			}
+			arg, err := ps.expression(0)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+		}
+		ps.next()
+		if len(args) < fn.minArgs || fn.maxArgs >= 0 && len(args) > fn.maxArgs {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("wrong number of arguments of %s", tok.text)}
+		}
+		return callNode{name: tok.text, fn: fn, args: args, pos: tok.pos}, nil
+	case tokOp:
+		if tok.text == "(" {
+			n, err := ps.expression(0)
+			if err != nil {
+				return nil, err
+			}
+			if err := ps.expect(")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		}
+	}
+	return nil, &SyntaxError{Pos: tok.pos, Msg: "unexpected " + tok.String()}
+}
@@ -0,0 +1,87 @@
+package expr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompile(t *testing.T) {
+	for _, src := range []string{"price * qty", `status == "paid" && amount > 1000`, "a ? b : c ? d : e",
+		"not (a or b)", "round(price * 1.13, 2)", "in(status, 'a', \"b\")", "-x + .5e1", "len(name) <= 20"} {
+		_, err := Compile(src)
+		assert.NoError(t, err, src)
+	}
+
+	for _, src := range []string{"", "a +", "(a", "a b", "foo(a)", "len()", "len(a, b)", "'abc", "a ? b",
+		"a # b", "1.2.3", strings.Repeat("(", 100) + "a" + strings.Repeat(")", 100), strings.Repeat("a+", MaxLength)} {
+		_, err := Compile(src)
+		assert.Error(t, err, src)
+	}
+
+	_, err := Compile("a +* b")
+	assert.Equal(t, `expr: unexpected "*" at position 4`, err.Error())
+
+	assert.Equal(t, []string{"price", "qty"}, MustCompile("price * qty + price").Identifiers())
+}
+
+func TestProgram_Eval(t *testing.T) {
+	row := map[string]interface{}{
+		"price":  []byte("9.5"),
+		"qty":    int64(2),
+		"status": "paid",
+		"name":   "Acme",
+		"note":   nil,
+	}
+
+	cases := map[string]interface{}{
+		"price * qty":                     float64(19),
+		"price * qty - 1 / 2":             18.5,
+		"(1 + 2) * 3 % 4":                 float64(1),
+		"name + ' ' + qty":                "Acme 2",
+		"qty + '3'":                       float64(5),
+		`status == "paid"`:                true,
+		"qty == '2.0'":                    true,
+		"name < 'B'":                      true,
+		"qty > 10 || status != 'paid'":    false,
+		"note == null":                    true,
+		"missing == null":                 true,
+		"!note":                           true,
+		"qty > 1 ? 'many' : 'one'":        "many",
+		"upper(name) + len(name)":         "ACME4",
+		"contains(lower(name), 'cm')":     true,
+		"matches(name, '^A.+e$')":         true,
+		"in(status, 'new', 'paid')":       true,
+		"round(2.345, 2)":                 2.35,
+		"fixed(price * qty, 2)":           "19.00",
+		"max(1, qty, price)":              9.5,
+		"empty(note) and not empty(name)": true,
+	}
+	for src, want := range cases {
+		got, err := MustCompile(src).Eval(row)
+		assert.NoError(t, err, src)
+		assert.Equal(t, want, got, src)
+	}
+
+	_, err := MustCompile("qty / (price - 9.5)").Eval(row)
+	assert.Equal(t, ErrDivisionByZero, err)
+	_, err = MustCompile("name * 2").Eval(row)
+	assert.Error(t, err)
+
+	// the right side is not evaluated when the left decides.
+	ok, err := MustCompile("status != 'paid' && name * 2 > 1").EvalBool(row)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestTruthy(t *testing.T) {
+	for _, v := range []interface{}{nil, false, 0, "", "0", "FALSE", []byte("")} {
+		assert.False(t, Truthy(v), v)
+	}
+	for _, v := range []interface{}{true, 1, -0.5, "a", "1", int64(3)} {
+		assert.True(t, Truthy(v), v)
+	}
+	assert.Equal(t, "0.1", ToString(0.1))
+	assert.Equal(t, "12", ToString(uint8(12)))
+}
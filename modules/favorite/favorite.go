@@ -0,0 +1,170 @@
+// Package favorite provides the records pinned by the users.
+//
+// The tables enabling the favorites by InfoPanel.EnableFavorite show a star
+// toggle on the rows and the detail page, and a "My pinned" button filtering
+// the list by the records pinned by the current user. The pinned records of
+// all the tables are listed by the widget, which can be added into the
+// dashboard:
+//
+//	func DashboardPage(ctx *context.Context) (types.Panel, error) {
+//		return types.Panel{
+//			Content: favorite.Widget(ctx, conn, 10),
+//			Title:   "Dashboard",
+//		}, nil
+//	}
+package favorite
+
+import (
+	"html/template"
+	"net/url"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
+	tmpl "github.com/purpose168/GoAdmin/template"
+)
+
+// TableName is the table of the pinned records, see the migrations of data
+// directory for the schema.
+const TableName = "goadmin_favorites"
+
+// Item is a record pinned by a user.
+type Item struct {
+	ID       int64
+	UserID   int64
+	Prefix   string
+	RecordID string
+	Title    string
+}
+
+// DetailURL return the url of the detail page of the record.
+func (item Item) DetailURL() string {
+	return config.Url(strings.Replace(config.GetURLFormats().Detail, ":__prefix", item.Prefix, 1)) +
+		"?" + constant.DetailPKKey + "=" + url.QueryEscape(item.RecordID)
+}
+
+// Store saves the pinned records of the users.
+type Store struct {
+	conn db.Connection
+}
+
+// NewStore return the store of the connection.
+func NewStore(conn db.Connection) *Store {
+	return &Store{conn: conn}
+}
+
+func (s *Store) table() *db.SQL {
+	return db.WithDriver(s.conn).Table(TableName)
+}
+
+// IsPinned check the record if it is pinned by the user.
+func (s *Store) IsPinned(userID int64, prefix, id string) (bool, error) {
+	item, err := s.table().Where("user_id", "=", userID).Where("prefix", "=", prefix).
+		Where("record_id", "=", id).First()
+	if db.CheckError(err, db.QUERY) {
+		return false, err
+	}
+	return item != nil, nil
+}
+
+// Pin pin the record for the user, the title is shown in the widget.
+func (s *Store) Pin(userID int64, prefix, id, title string) error {
+	pinned, err := s.IsPinned(userID, prefix, id)
+	if err != nil || pinned {
+		return err
+	}
+	_, err = s.table().Insert(dialect.H{
+		"user_id":   userID,
+		"prefix":    prefix,
+		"record_id": id,
+		"title":     title,
+	})
+	if db.CheckError(err, db.INSERT) {
+		return err
+	}
+	return nil
+}
+
+// Unpin unpin the record for the user.
+func (s *Store) Unpin(userID int64, prefix, id string) error {
+	err := s.table().Where("user_id", "=", userID).Where("prefix", "=", prefix).
+		Where("record_id", "=", id).Delete()
+	if db.CheckError(err, db.DELETE) {
+		return err
+	}
+	return nil
+}
+
+// IDs return the ids of the records of the table pinned by the user.
+func (s *Store) IDs(userID int64, prefix string) ([]string, error) {
+	rows, err := s.table().Select("record_id").Where("user_id", "=", userID).
+		Where("prefix", "=", prefix).All()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = db.GetValueFromDatabaseType(db.Varchar, row["record_id"], false).String()
+	}
+	return ids, nil
+}
+
+// List return the records of all the tables pinned by the user, the latest
+// first. All the records are returned when limit is not positive.
+func (s *Store) List(userID int64, limit int) ([]Item, error) {
+	sql := s.table().Where("user_id", "=", userID).OrderBy("id", "desc")
+	if limit > 0 {
+		sql = sql.Take(limit)
+	}
+	rows, err := sql.All()
+	if err != nil {
+		return nil, err
+	}
+	items := make([]Item, len(rows))
+	for i, row := range rows {
+		items[i] = Item{
+			ID:       db.GetValueFromDatabaseType(db.Int, row["id"], false).ToInt64(),
+			UserID:   db.GetValueFromDatabaseType(db.Int, row["user_id"], false).ToInt64(),
+			Prefix:   db.GetValueFromDatabaseType(db.Varchar, row["prefix"], false).String(),
+			RecordID: db.GetValueFromDatabaseType(db.Varchar, row["record_id"], false).String(),
+			Title:    db.GetValueFromDatabaseType(db.Varchar, row["title"], false).String(),
+		}
+	}
+	return items, nil
+}
+
+// Widget return the box listing the records pinned by the current user.
+func Widget(ctx *context.Context, conn db.Connection, limit int) template.HTML {
+	items, err := NewStore(conn).List(auth.Auth(ctx).Id, limit)
+	if err != nil {
+		logger.Error("list favorites error: ", err)
+	}
+
+	body := template.HTML(`<p class="text-muted">` + template.HTMLEscapeString(language.Get("no pinned records")) + `</p>`)
+	if len(items) > 0 {
+		body = `<ul class="list-unstyled" style="margin: 0;">`
+		for _, item := range items {
+			title := item.Title
+			if title == "" {
+				title = item.RecordID
+			}
+			body += template.HTML(`<li style="padding: 5px 0;"><i class="fa fa-star text-yellow"></i> <a href="` +
+				template.HTMLEscapeString(item.DetailURL()) + `">` + template.HTMLEscapeString(title) +
+				`</a> <span class="label label-default pull-right">` + template.HTMLEscapeString(item.Prefix) +
+				`</span></li>`)
+		}
+		body += `</ul>`
+	}
+
+	return tmpl.Default(ctx).Box().
+		SetHeader(template.HTML(template.HTMLEscapeString(language.Get("pinned records")))).
+		WithHeadBorder().
+		SetBody(body).
+		GetContent()
+}
@@ -0,0 +1,45 @@
+package favorite
+
+import (
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	conn := dbtest.New(db.DriverMysql).
+		OnQuery("`record_id` = ?", map[string]interface{}{"id": int64(1)}).
+		OnQuery("from `goadmin_favorites`",
+			map[string]interface{}{"id": int64(2), "user_id": int64(1), "prefix": "posts", "record_id": "9", "title": "foo"},
+			map[string]interface{}{"id": int64(1), "user_id": int64(1), "prefix": "users", "record_id": "3", "title": ""}).
+		OnExec("insert into `goadmin_favorites`", dbtest.Result{LastID: 3, Affected: 1})
+	s := NewStore(conn)
+
+	pinned, err := s.IsPinned(1, "posts", "9")
+	assert.Nil(t, err)
+	assert.True(t, pinned)
+
+	// the record pinned already is not inserted again.
+	assert.Nil(t, s.Pin(1, "posts", "9", "foo"))
+	for _, call := range conn.Calls() {
+		assert.False(t, call.Exec)
+	}
+
+	pinned, err = NewStore(dbtest.New(db.DriverMysql)).IsPinned(1, "posts", "10")
+	assert.Nil(t, err)
+	assert.False(t, pinned)
+
+	ids, err := s.IDs(1, "posts")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"9", "3"}, ids)
+
+	config.Initialize(&config.Config{UrlPrefix: "admin"})
+	items, err := s.List(1, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(items))
+	assert.Equal(t, "posts", items[0].Prefix)
+	assert.Equal(t, "/admin/info/posts/detail?__goadmin_detail_pk=9", items[0].DetailURL())
+}
@@ -0,0 +1,316 @@
+// Package featureflag provides the feature flags defined in code and toggled
+// at runtime.
+//
+// The flags are defined with their defaults by Define, the rules overriding
+// the defaults are stored in the table goadmin_feature_flags, per environment
+// or for all the environments, with an optional percentage of rollout. The
+// rules are edited by the page of the featureflag plugin, and the flags are
+// checked by Enabled in the handlers, templates and plugins:
+//
+//	var newEditor = featureflag.Define("new_editor", "use the new rich text editor", false)
+//
+//	if newEditor.Enabled(auth.Auth(ctx).UserName) {
+//		...
+//	}
+package featureflag
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+	"github.com/purpose168/GoAdmin/modules/logger"
+)
+
+// TableName is the table of the rules, see the migrations of data directory
+// for the schema.
+const TableName = "goadmin_feature_flags"
+
+// AllEnv is the environment of the rules applied to all the environments.
+const AllEnv = ""
+
+// Flag is a feature flag defined in code.
+type Flag struct {
+	Name        string
+	Description string
+	Default     bool
+}
+
+// Enabled check the flag of the current environment. The key, such as the
+// username, decides whether the flag is enabled in a percentage rollout, the
+// flag is disabled for an empty key unless the rollout is 100%.
+func (f *Flag) Enabled(key ...string) bool {
+	return Enabled(f.Name, key...)
+}
+
+// Rule overrides the default of a flag.
+type Rule struct {
+	Env        string
+	Enabled    bool
+	Percentage int
+}
+
+var (
+	flagsLock sync.RWMutex
+	flags     = make(map[string]*Flag)
+)
+
+// Define define a flag with the default, the same flag is returned when it is
+// defined twice.
+func Define(name, description string, def bool) *Flag {
+	flagsLock.Lock()
+	defer flagsLock.Unlock()
+	if f, ok := flags[name]; ok {
+		return f
+	}
+	f := &Flag{Name: name, Description: description, Default: def}
+	flags[name] = f
+	return f
+}
+
+// Flags return all the defined flags sorted by name.
+func Flags() []*Flag {
+	flagsLock.RLock()
+	defer flagsLock.RUnlock()
+	list := make([]*Flag, 0, len(flags))
+	for _, f := range flags {
+		list = append(list, f)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// Get return the flag of the name and whether it is defined.
+func Get(name string) (*Flag, bool) {
+	flagsLock.RLock()
+	defer flagsLock.RUnlock()
+	f, ok := flags[name]
+	return f, ok
+}
+
+// Store loads and saves the rules of the database.
+type Store struct {
+	conn db.Connection
+	ttl  time.Duration
+
+	lock   sync.RWMutex
+	rules  map[string]map[string]Rule
+	loaded time.Time
+}
+
+// NewStore return the store of the connection. The rules are reloaded when
+// they are older than the ttl, so that the changes made by other instances are
+// applied.
+func NewStore(conn db.Connection, ttl time.Duration) *Store {
+	return &Store{conn: conn, ttl: ttl, rules: make(map[string]map[string]Rule)}
+}
+
+// Reload load the rules of the database.
+func (s *Store) Reload() error {
+	items, err := db.WithDriver(s.conn).Table(TableName).All()
+	if err != nil {
+		return err
+	}
+	rules := make(map[string]map[string]Rule)
+	for _, item := range items {
+		name := db.GetValueFromDatabaseType(db.Varchar, item["name"], false).String()
+		rule := Rule{
+			Env:        db.GetValueFromDatabaseType(db.Varchar, item["env"], false).String(),
+			Enabled:    db.GetValueFromDatabaseType(db.Int, item["enabled"], false).ToInt64() == 1,
+			Percentage: int(db.GetValueFromDatabaseType(db.Int, item["percentage"], false).ToInt64()),
+		}
+		if rules[name] == nil {
+			rules[name] = make(map[string]Rule)
+		}
+		rules[name][rule.Env] = rule
+	}
+	s.lock.Lock()
+	s.rules = rules
+	s.loaded = time.Now()
+	s.lock.Unlock()
+	return nil
+}
+
+// Rules return the rules of the flag by environment.
+func (s *Store) Rules(name string) map[string]Rule {
+	s.refresh()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	rules := make(map[string]Rule, len(s.rules[name]))
+	for env, rule := range s.rules[name] {
+		rules[env] = rule
+	}
+	return rules
+}
+
+func (s *Store) refresh() {
+	s.lock.RLock()
+	stale := s.ttl > 0 && time.Since(s.loaded) > s.ttl
+	s.lock.RUnlock()
+	if stale {
+		if err := s.Reload(); err != nil {
+			logger.Error("reload feature flags error: ", err)
+			// retry after the ttl instead of every check.
+			s.lock.Lock()
+			s.loaded = time.Now()
+			s.lock.Unlock()
+		}
+	}
+}
+
+// rule return the rule of the flag of the environment, falling back to the
+// rule of all the environments.
+func (s *Store) rule(name, env string) (Rule, bool) {
+	s.refresh()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if rule, ok := s.rules[name][env]; ok {
+		return rule, true
+	}
+	rule, ok := s.rules[name][AllEnv]
+	return rule, ok
+}
+
+// Set save the rule of the flag.
+func (s *Store) Set(name string, rule Rule) error {
+	if rule.Percentage < 0 {
+		rule.Percentage = 0
+	}
+	if rule.Percentage > 100 {
+		rule.Percentage = 100
+	}
+	enabled := 0
+	if rule.Enabled {
+		enabled = 1
+	}
+	values := dialect.H{
+		"enabled":    enabled,
+		"percentage": rule.Percentage,
+		"updated_at": time.Now().Format("2006-01-02 15:04:05"),
+	}
+
+	item, err := db.WithDriver(s.conn).Table(TableName).
+		Where("name", "=", name).Where("env", "=", rule.Env).First()
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		values["name"] = name
+		values["env"] = rule.Env
+		_, err = db.WithDriver(s.conn).Table(TableName).Insert(values)
+		if db.CheckError(err, db.INSERT) {
+			return err
+		}
+	} else {
+		_, err = db.WithDriver(s.conn).Table(TableName).
+			Where("name", "=", name).Where("env", "=", rule.Env).Update(values)
+		if db.CheckError(err, db.UPDATE) {
+			return err
+		}
+	}
+
+	s.lock.Lock()
+	if s.rules[name] == nil {
+		s.rules[name] = make(map[string]Rule)
+	}
+	s.rules[name][rule.Env] = rule
+	s.lock.Unlock()
+	return nil
+}
+
+// Delete remove the rule of the flag of the environment, the flag falls back
+// to the rule of all the environments or the default.
+func (s *Store) Delete(name, env string) error {
+	err := db.WithDriver(s.conn).Table(TableName).
+		Where("name", "=", name).Where("env", "=", env).Delete()
+	if db.CheckError(err, db.DELETE) {
+		return err
+	}
+	s.lock.Lock()
+	delete(s.rules[name], env)
+	s.lock.Unlock()
+	return nil
+}
+
+// Enabled check the flag of the environment for the key.
+func (s *Store) Enabled(name, env string, key ...string) bool {
+	f, ok := Get(name)
+	if !ok {
+		return false
+	}
+	rule, ok := s.rule(name, env)
+	if !ok {
+		return f.Default
+	}
+	return rule.match(name, key...)
+}
+
+func (r Rule) match(name string, key ...string) bool {
+	if !r.Enabled {
+		return false
+	}
+	if r.Percentage >= 100 {
+		return true
+	}
+	if len(key) == 0 || key[0] == "" {
+		return false
+	}
+	return bucket(name, key[0]) < r.Percentage
+}
+
+// bucket return the stable bucket in [0, 100) of the key for the flag.
+func bucket(name, key string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name + ":" + key))
+	return int(h.Sum32() % 100)
+}
+
+var (
+	currentLock sync.RWMutex
+	current     *Store
+)
+
+// Use set the store used by Enabled.
+func Use(s *Store) {
+	currentLock.Lock()
+	current = s
+	currentLock.Unlock()
+}
+
+// Current return the store used by Enabled, nil when there is none.
+func Current() *Store {
+	currentLock.RLock()
+	defer currentLock.RUnlock()
+	return current
+}
+
+// Enabled check the flag of the environment of the config for the key, the
+// default of the flag is used when there is no store. The undefined flags are
+// always disabled.
+func Enabled(name string, key ...string) bool {
+	if s := Current(); s != nil {
+		return s.Enabled(name, config.GetEnv(), key...)
+	}
+	if f, ok := Get(name); ok {
+		return f.Default
+	}
+	return false
+}
+
+// String return the rule in text, such as "on 20%".
+func (r Rule) String() string {
+	if !r.Enabled {
+		return "off"
+	}
+	if r.Percentage >= 100 {
+		return "on"
+	}
+	return "on " + strconv.Itoa(r.Percentage) + "%"
+}
@@ -0,0 +1,39 @@
+package featureflag
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnabled(t *testing.T) {
+	Define("test_on", "", true)
+	Define("test_off", "", false)
+
+	assert.Equal(t, Enabled("test_on"), true)
+	assert.Equal(t, Enabled("test_off"), false)
+	assert.Equal(t, Enabled("test_undefined"), false)
+
+	s := NewStore(nil, 0)
+	s.rules["test_on"] = map[string]Rule{AllEnv: {Enabled: false}}
+	s.rules["test_off"] = map[string]Rule{
+		AllEnv: {Enabled: true, Percentage: 100},
+		"prod": {Env: "prod", Enabled: true, Percentage: 30},
+	}
+
+	assert.Equal(t, s.Enabled("test_on", "local"), false)
+	assert.Equal(t, s.Enabled("test_off", "local"), true)
+	assert.Equal(t, s.Enabled("test_off", "prod"), false)
+
+	on := 0
+	for i := 0; i < 1000; i++ {
+		key := "user" + strconv.Itoa(i)
+		enabled := s.Enabled("test_off", "prod", key)
+		assert.Equal(t, s.Enabled("test_off", "prod", key), enabled)
+		if enabled {
+			on++
+		}
+	}
+	assert.Equal(t, on > 200 && on < 400, true)
+}
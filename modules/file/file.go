@@ -5,6 +5,8 @@
 package file
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -81,6 +83,42 @@ func Upload(c UploadFun, form *multipart.Form) error {
 	return nil
 }
 
+// UploadBytes uploads the content as a file of given name with the Uploader,
+// so that the generated files such as the signatures can be put into the
+// same store as the uploaded ones. It returns the stored path.
+func UploadBytes(up Uploader, name string, content []byte) (string, error) {
+	var (
+		buf bytes.Buffer
+		w   = multipart.NewWriter(&buf)
+	)
+	part, err := w.CreateFormFile("file", name)
+	if err != nil {
+		return "", err
+	}
+	if _, err = part.Write(content); err != nil {
+		return "", err
+	}
+	if err = w.Close(); err != nil {
+		return "", err
+	}
+
+	form, err := multipart.NewReader(&buf, w.Boundary()).ReadForm(int64(len(content)) + 1024)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = form.RemoveAll()
+	}()
+
+	if err = up.Upload(form); err != nil {
+		return "", err
+	}
+	if len(form.Value["file"]) == 0 {
+		return "", errors.New("uploader returns no path")
+	}
+	return form.Value["file"][0], nil
+}
+
 // SaveMultipartFile used in a local Uploader which help to save file in the local path.
 func SaveMultipartFile(fh *multipart.FileHeader, path string) error {
 	f, err := fh.Open()
@@ -0,0 +1,22 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadBytes(t *testing.T) {
+	dir := t.TempDir()
+
+	path, err := UploadBytes(&LocalFileUploader{BasePath: dir}, "signature.png", []byte("content"))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(path, ".png"))
+
+	content, err := os.ReadFile(filepath.Join(dir, path))
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+}
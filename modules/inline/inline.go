@@ -0,0 +1,179 @@
+// Package inline shrinks the inline scripts and styles which the components
+// add into the pages, such as the scripts of the actions in the footers of
+// the list pages.
+//
+// In the production environment the repeated blocks of a page are removed
+// and the blocks are minified. When config.ExternalInlineAssets is true the
+// large blocks which are rendered again and again are replaced by the links
+// of the assets served by the admin plugin, so they are cached by the
+// browsers and are compressed by brotli or gzip.
+//
+// The minifier and brotli are excluded by the goadmin_nominify build tag, the
+// blocks are then only deduplicated and the assets are compressed by gzip.
+package inline
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"html/template"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// Path is the path of the assets without the url prefix.
+const Path = "/assets/inline/"
+
+// MinExternalSize is the least bytes of a minified block to be served as an
+// asset, the smaller ones are kept inline.
+const MinExternalSize = 512
+
+// maxAssets is the most blocks kept, all of them are dropped when it is
+// full, so the blocks with the tokens or the data of the requests which are
+// never rendered twice do not fill the memory.
+const maxAssets = 4096
+
+var (
+	scriptReg = regexp.MustCompile(`(?is)<script(?:\s+type=["']text/javascript["'])?\s*>(.*?)</script>`)
+	styleReg  = regexp.MustCompile(`(?is)<style(?:\s+type=["']text/css["'])?\s*>(.*?)</style>`)
+)
+
+// Asset is a minified block.
+type Asset struct {
+	Name        string
+	ContentType string
+	Data        []byte
+
+	hits int
+
+	once   sync.Once
+	brotli []byte
+	gzip   []byte
+}
+
+// Brotli return the data compressed by brotli, nil when brotli is excluded by
+// the goadmin_nominify build tag.
+func (a *Asset) Brotli() []byte {
+	a.compress()
+	return a.brotli
+}
+
+// Gzip return the data compressed by gzip.
+func (a *Asset) Gzip() []byte {
+	a.compress()
+	return a.gzip
+}
+
+func (a *Asset) compress() {
+	a.once.Do(func() {
+		a.brotli = compressBrotli(a.Data)
+
+		var gbuf bytes.Buffer
+		gw, _ := gzip.NewWriterLevel(&gbuf, gzip.BestCompression)
+		_, _ = gw.Write(a.Data)
+		_ = gw.Close()
+		a.gzip = gbuf.Bytes()
+	})
+}
+
+var store = struct {
+	lock   sync.Mutex
+	assets map[string]*Asset
+}{assets: make(map[string]*Asset)}
+
+// Get return the asset of the name.
+func Get(name string) (*Asset, bool) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	a, ok := store.assets[name]
+	return a, ok && a.hits > 1
+}
+
+// asset return the minified asset of the block and count the renders of it.
+func asset(body, contentType, ext string) *Asset {
+	sum := sha256.Sum256([]byte(body))
+	name := hex.EncodeToString(sum[:16]) + ext
+
+	store.lock.Lock()
+	a, ok := store.assets[name]
+	if ok {
+		a.hits++
+		store.lock.Unlock()
+		return a
+	}
+	store.lock.Unlock()
+
+	data, err := minifyBlock(contentType, []byte(body))
+	if err != nil {
+		data = []byte(strings.TrimSpace(body))
+	}
+	a = &Asset{Name: name, ContentType: contentType, Data: data, hits: 1}
+
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	if old, ok := store.assets[name]; ok {
+		old.hits++
+		return old
+	}
+	if len(store.assets) >= maxAssets {
+		store.assets = make(map[string]*Asset)
+	}
+	store.assets[name] = a
+	return a
+}
+
+// Process remove the repeated inline scripts and styles of the content and
+// minify them, the large ones rendered before are replaced by the links of
+// the assets when external is true.
+func Process(content template.HTML, external bool) template.HTML {
+	s := string(content)
+	s = process(s, scriptReg, "text/javascript", ".js", external, func(url string) string {
+		return `<script src="` + url + `"></script>`
+	}, "<script>", "</script>")
+	s = process(s, styleReg, "text/css", ".css", external, func(url string) string {
+		return `<link rel="stylesheet" href="` + url + `">`
+	}, "<style>", "</style>")
+	return template.HTML(s)
+}
+
+func process(s string, reg *regexp.Regexp, contentType, ext string, external bool,
+	link func(url string) string, open, close string) string {
+	seen := make(map[string]bool)
+	return reg.ReplaceAllStringFunc(s, func(block string) string {
+		body := reg.FindStringSubmatch(block)[1]
+		if strings.TrimSpace(body) == "" || seen[body] {
+			return ""
+		}
+		seen[body] = true
+		a := asset(body, contentType, ext)
+		if external && len(a.Data) >= MinExternalSize && hits(a) > 1 {
+			return link(config.Url(Path + a.Name))
+		}
+		return open + string(a.Data) + close
+	})
+}
+
+func hits(a *Asset) int {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	return a.hits
+}
+
+// Decorator is the page decorator processing the content of the pages in
+// the production environment, the assets are not used by the pjax requests
+// as the scripts loaded by pjax are not run in order.
+func Decorator(ctx *context.Context, _ models.UserModel, panel types.Panel) types.Panel {
+	if !config.IsProductionEnvironment() {
+		return panel
+	}
+	external := config.GetExternalInlineAssets() && ctx != nil && ctx.Request != nil && !ctx.IsPjax()
+	panel.Content = Process(panel.Content, external)
+	return panel
+}
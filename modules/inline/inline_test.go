@@ -0,0 +1,43 @@
+//go:build !goadmin_nominify
+
+package inline
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/modules/config"
+)
+
+func TestProcess(t *testing.T) {
+	config.Initialize(&config.Config{UrlPrefix: "admin"})
+
+	content := template.HTML(`<div>a</div>
+<script>
+    var a = 1;
+</script>
+<script>
+    var a = 1;
+</script>
+<style type="text/css"> .a { color: red; } </style>`)
+
+	res := string(Process(content, false))
+	assert.Equal(t, strings.Count(res, "<script>"), 1)
+	assert.Equal(t, strings.Contains(res, "var a=1"), true)
+	assert.Equal(t, strings.Contains(res, "<style>.a{color:red}</style>"), true)
+
+	// the large block is externalized from the second render.
+	large := template.HTML(`<script>window.goadminInlineTest = "` + strings.Repeat("x", MinExternalSize) + `";</script>`)
+	res = string(Process(large, true))
+	assert.Equal(t, strings.Contains(res, "<script>window.goadminInlineTest"), true)
+	res = string(Process(large, true))
+	assert.Equal(t, strings.HasPrefix(res, `<script src="/admin`+Path), true)
+
+	name := strings.TrimSuffix(strings.TrimPrefix(res, `<script src="/admin`+Path), `"></script>`)
+	asset, ok := Get(name)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, asset.ContentType, "text/javascript")
+	assert.Equal(t, len(asset.Brotli()) < len(asset.Data), true)
+}
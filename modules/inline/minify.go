@@ -0,0 +1,33 @@
+//go:build !goadmin_nominify
+
+package inline
+
+import (
+	"bytes"
+
+	"github.com/andybalholm/brotli"
+	"github.com/tdewolff/minify/v2"
+	"github.com/tdewolff/minify/v2/css"
+	"github.com/tdewolff/minify/v2/js"
+)
+
+var minifier = func() *minify.M {
+	m := minify.New()
+	m.AddFunc("text/javascript", js.Minify)
+	m.AddFunc("text/css", css.Minify)
+	return m
+}()
+
+// minifyBlock minify the script or style of the content type.
+func minifyBlock(contentType string, data []byte) ([]byte, error) {
+	return minifier.Bytes(contentType, data)
+}
+
+// compressBrotli compress the data by brotli.
+func compressBrotli(data []byte) []byte {
+	var buf bytes.Buffer
+	bw := brotli.NewWriterLevel(&buf, brotli.BestCompression)
+	_, _ = bw.Write(data)
+	_ = bw.Close()
+	return buf.Bytes()
+}
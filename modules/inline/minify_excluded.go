@@ -0,0 +1,17 @@
+//go:build goadmin_nominify
+
+package inline
+
+import "bytes"
+
+// minifyBlock trim the script or style, as the minifier is excluded by the
+// goadmin_nominify build tag.
+func minifyBlock(_ string, data []byte) ([]byte, error) {
+	return bytes.TrimSpace(data), nil
+}
+
+// compressBrotli return nil as brotli is excluded by the goadmin_nominify
+// build tag.
+func compressBrotli([]byte) []byte {
+	return nil
+}
@@ -92,8 +92,25 @@ var cn = LangSet{
 	"uri":        "路径",
 	"close":      "关闭",
 
-	"login":      "登录",
-	"login fail": "登录失败",
+	"login":                 "登录",
+	"login fail":            "登录失败",
+	"login terms required":  "请先阅读并同意条款",
+	"login tenant required": "请输入租户",
+	"idle timeout":          "即将登出",
+	"idle timeout warning":  "您已长时间未操作，将在%d秒后自动登出，是否保持登录？",
+	"stay signed in":        "保持登录",
+	"single sign-on":        "单点登录",
+	"invalid token":         "无效的令牌",
+
+	"duplicate submit, the form is already submitted": "表单已提交，请勿重复提交",
+
+	"the record already exists":         "记录已存在",
+	"the related record does not exist": "关联的记录不存在",
+	"%s already exists":                 "%s已存在",
+	"%s does not exist":                 "%s不存在",
+
+	"invalid date":   "无效的日期",
+	"invalid number": "无效的数字",
 
 	"admin":     "管理",
 	"user":      "用户",
@@ -135,6 +152,7 @@ var cn = LangSet{
 	"initialize navigation buttons":   "初始化导航栏按钮",
 	"initialize plugins":              "初始化插件",
 	"initialize database connections": "初始化数据库连接",
+	"initialize redis":                "初始化Redis",
 	"initialize success":              "初始化成功🍺🍺",
 
 	"plugins":          "插件",
@@ -208,6 +226,16 @@ var cn = LangSet{
 	"config.hide plugin entrance":        "隐藏插件列表入口",
 	"config.footer info":                 "自定义底部信息",
 	"config.login logo":                  "登录Logo",
+	"config.login layout":                "登录页布局",
+	"config.login background":            "登录页背景",
+	"config.login links":                 "登录页链接",
+	"config.login terms":                 "登录页条款",
+	"config.login username label":        "用户名标签",
+	"config.login qr":                    "扫码登录",
+	"config.login tenant label":          "租户字段标签",
+	"config.login layout center":         "居中",
+	"config.login layout left":           "左侧",
+	"config.login layout right":          "右侧",
 	"config.no limit login ip":           "取消限制多IP登录",
 	"config.operation log off":           "关闭操作日志",
 	"config.allow delete operation log":  "允许删除操作日志",
@@ -449,6 +477,222 @@ var cn = LangSet{
 	"plugin.restart to install":                           "重启程序进行安装",
 	"plugin.can not connect to the goadmin remote server": "连接到GoAdmin远程服务器失败，请检查您的网络连接。",
 
+	"er diagram":                         "ER图",
+	"relations of the registered tables": "已注册表格的关联关系",
+	"api document":                       "API文档",
+
+	"grid edit":                       "表格编辑",
+	"not displayed in time":           "未及时显示，请刷新重试",
+	"grid paste tip":                  "从Excel中复制单元格区域，粘贴到下方，选择每列对应的字段，预览修改后保存。",
+	"grid paste here":                 "在此处粘贴",
+	"grid first row is header":        "第一行为表头",
+	"grid preview":                    "预览",
+	"grid ignore":                     "忽略",
+	"grid field":                      "字段",
+	"grid old value":                  "原值",
+	"grid new value":                  "新值",
+	"grid no changes":                 "没有修改",
+	"grid commit success":             "保存成功",
+	"grid row without primary key":    "缺少主键",
+	"grid row not found":              "记录不存在",
+	"grid row duplicated":             "重复的记录",
+	"grid field %s can not be edited": "字段%s不可编辑",
+	"grid field %s is required":       "%s不能为空",
+	"grid has invalid rows":           "存在错误的行",
+	"wrong grid rows":                 "错误的表格数据",
+
+	"invalid phone number":  "电话号码格式错误",
+	"invalid email address": "邮箱地址格式错误",
+	"invalid value":         "值不正确",
+
+	"%dd":        "%d天",
+	"%dh":        "%d小时",
+	"%dm":        "%d分",
+	"%ds":        "%d秒",
+	"just now":   "刚刚",
+	"a minute":   "1分钟",
+	"%d minutes": "%d分钟",
+	"an hour":    "1小时",
+	"%d hours":   "%d小时",
+	"a day":      "1天",
+	"%d days":    "%d天",
+	"a month":    "1个月",
+	"%d months":  "%d个月",
+	"a year":     "1年",
+	"%d years":   "%d年",
+	"in %s":      "%s后",
+	"%s ago":     "%s前",
+
+	"every minute":                 "每分钟",
+	"every %d minutes":             "每%d分钟",
+	"at minute %d past every hour": "每小时的第%d分钟",
+	"at %s":                        "在%s",
+	"at minute %s past hour %s":    "分钟为%s，小时为%s",
+	"on day %s of the month":       "每月%s日",
+	"on %s":                        "每%s",
+	"or":                           "或",
+	"only in %s":                   "仅在%s",
+	"%s through %s":                "%s至%s",
+	"sunday":                       "星期日",
+	"monday":                       "星期一",
+	"tuesday":                      "星期二",
+	"wednesday":                    "星期三",
+	"thursday":                     "星期四",
+	"friday":                       "星期五",
+	"saturday":                     "星期六",
+	"january":                      "一月",
+	"february":                     "二月",
+	"march":                        "三月",
+	"april":                        "四月",
+	"may":                          "五月",
+	"june":                         "六月",
+	"july":                         "七月",
+	"august":                       "八月",
+	"september":                    "九月",
+	"october":                      "十月",
+	"november":                     "十一月",
+	"december":                     "十二月",
+	"invalid cron expression":      "cron表达式格式错误",
+	"next runs":                    "接下来的运行时间",
+
+	"clear signature":   "清除签名",
+	"invalid signature": "签名格式错误",
+
+	"content exceeds the maximum size of %d bytes": "内容超过了%d字节的大小限制",
+	"invalid json": "JSON格式错误",
+
+	"very weak": "非常弱",
+	"weak":      "弱",
+	"fair":      "一般",
+	"good":      "较强",
+	"strong":    "强",
+	"password must be at least %d characters": "密码至少需要%d个字符",
+	"password is too long":                    "密码过长",
+
+	"edit manually":   "手动编辑",
+	"sort":            "排序",
+	"drag to reorder": "拖动排序",
+
+	"pin":               "收藏",
+	"unpin":             "取消收藏",
+	"my pinned":         "我的收藏",
+	"pinned records":    "收藏的记录",
+	"no pinned records": "暂无收藏的记录",
+
+	"explain":       "执行计划",
+	"sql time":      "SQL耗时",
+	"rows":          "行数",
+	"arguments":     "参数",
+	"query plan":    "查询计划",
+	"rows examined": "扫描行数",
+
+	"data sync":           "数据同步",
+	"sync export":         "导出数据包",
+	"sync import":         "导入数据包",
+	"sync import tip":     "选择从其他环境导出的数据包文件，或将其内容粘贴到下方，试运行预览修改后导入。记录按匹配字段对应，主键和外键会重新映射。",
+	"sync dry run":        "试运行",
+	"sync select rows":    "请先选择要导出的记录",
+	"sync table":          "表",
+	"sync key":            "原主键",
+	"sync new key":        "新主键",
+	"sync action":         "操作",
+	"sync insert":         "新增",
+	"sync update":         "更新",
+	"sync unchanged":      "无变化",
+	"sync import success": "导入成功",
+
+	"demo mode":         "演示模式",
+	"demo mode blocked": "演示模式下不允许修改数据",
+	"demo mode tip":     "当前为演示模式，不允许修改数据。",
+	"demo mode revert":  "当前为演示模式，修改会被自动撤销。",
+	"demo mode go back": "返回",
+
+	"reports":                    "报表",
+	"scheduled reports":          "定时报表",
+	"new report":                 "新建定时报表",
+	"no reports":                 "暂无定时报表",
+	"schedule report":            "定时报表",
+	"report name":                "名称",
+	"report view":                "视图",
+	"report schedule":            "执行计划",
+	"report schedule tip":        "cron 表达式：分 时 日 月 周，例如 0 8 * * 1 为每周一 8:00",
+	"report recipients":          "收件人",
+	"report recipients tip":      "多个邮箱用逗号或换行分隔",
+	"report last run":            "上次执行",
+	"report next run":            "下次执行",
+	"report sent":                "已发送",
+	"report failed":              "失败",
+	"report mail not configured": "未配置邮件服务（config.Mail），定时报表不会发送。",
+	"run now":                    "立即执行",
+
+	"find replace":                              "查找替换",
+	"find replace tip":                          "对当前筛选结果中的一列执行查找替换，预览受影响的记录后再执行，所有修改在一个事务中提交并记录到操作日志。",
+	"find replace find":                         "查找",
+	"find replace replace with":                 "替换为",
+	"find replace match case":                   "区分大小写",
+	"find replace apply":                        "执行替换",
+	"find replace affected rows":                "受影响的记录",
+	"find replace preview first rows":           "仅显示前%d条",
+	"find replace no match":                     "没有匹配的记录",
+	"find replace confirm":                      "确定执行替换吗？该操作会修改所有受影响的记录。",
+	"find replace success":                      "已替换%d条记录",
+	"find replace empty find":                   "查找内容不能为空",
+	"find replace field %s can not be replaced": "字段%s不可替换",
+	"find replace more than %d rows":            "筛选结果超过%d条，请缩小筛选范围",
+
+	"notification done":  "完成",
+	"notification fail":  "失败",
+	"notification items": "条",
+
+	"go to page":                             "跳至",
+	"hide total":                             "不统计总数",
+	"show total":                             "统计总数",
+	"showing <b>%s</b> to <b>%s</b> entries": "显示第 <b>%s</b> 到第 <b>%s</b> 条记录",
+
+	"you are offline":             "网络已断开",
+	"check the network and retry": "请检查网络后重试",
+	"retry":                       "重试",
+
+	"paste a list, one value per line": "粘贴列表，每行一个值",
+
+	"the request is taking longer than usual": "请求耗时超出预期，请稍候",
+
+	"degraded mode":                          "以下数据库暂时无法连接，相关页面暂不可用",
+	"database %s is temporarily unavailable": "数据库 %s 暂时不可用，请稍后重试",
+
+	"diagnose":                       "诊断",
+	"configuration check":            "配置检查",
+	"no problem found":               "未发现问题",
+	"check":                          "检查项",
+	"level":                          "级别",
+	"problem":                        "问题",
+	"advice":                         "建议",
+	"warning":                        "警告",
+	"database %s is unreachable: %s": "数据库 %s 无法连接：%s",
+	"check the address, the account and the network of the database":     "请检查数据库的地址、账号和网络",
+	"store path %s is not a directory":                                   "上传目录 %s 不存在",
+	"store path %s is not writable":                                      "上传目录 %s 不可写",
+	"create the directory of the store path and make it writable":        "请创建上传目录并设置为可写",
+	"theme %s %s is not compatible with goadmin %s":                      "主题 %s %s 与 GoAdmin %s 不兼容",
+	"upgrade the theme and goadmin to the compatible versions":           "请将主题和 GoAdmin 升级到兼容的版本",
+	"system tables are missing: %s":                                      "缺少系统表：%s",
+	"import the sql file of the database driver from the data directory": "请从 data 目录导入对应数据库驱动的 SQL 文件",
+	"debug mode is on in the production environment":                     "生产环境开启了调试模式",
+	"turn off the debug mode, it shows the statements of the pages":      "请关闭调试模式，它会在页面上显示执行的 SQL",
+	"user %s has the default password":                                   "用户 %s 仍在使用默认密码",
+	"change the password of the user":                                    "请修改该用户的密码",
+	"paths stored with the url prefix: %s":                               "以下菜单和权限保存的路径带有路由前缀：%s",
+	"remove the url prefix from the paths, or call models.MigratePrefix": "请去掉路径中的路由前缀，或调用 models.MigratePrefix 迁移",
+
+	"scan to log in": "扫码登录",
+	"scan the qr code with the signed in mobile": "请使用已登录的手机扫描二维码",
+	"qr code expired":                   "二维码已过期",
+	"log in on another device":          "在其他设备上登录",
+	"log in as %s on the device below?": "以 %s 的身份登录以下设备？",
+	"approve login":                     "确认登录",
+	"login approved":                    "已确认登录",
+	"login with password":               "密码登录",
+
 	"admin.basic admin": "基础Admin",
 	"admin.a built-in plugins of goadmin which help you to build a crud manager platform quickly.": "一个内置GoAdmin插件，帮助您快速搭建curd简易管理后台。",
 	"admin.official": "GoAdmin官方",
@@ -165,6 +165,15 @@ var en = LangSet{
 	"config.hide tool entrance":          "Hide Tool Button",
 	"config.footer info":                 "Footer Info",
 	"config.login logo":                  "Login Logo",
+	"config.login layout":                "Login Layout",
+	"config.login background":            "Login Background",
+	"config.login links":                 "Login Links",
+	"config.login terms":                 "Login Terms",
+	"config.login username label":        "Username Label",
+	"config.login tenant label":          "Tenant Field Label",
+	"config.login layout center":         "Center",
+	"config.login layout left":           "Left",
+	"config.login layout right":          "Right",
 	"config.no limit login ip":           "No Limit Login Multi IPs",
 	"config.operation log off":           "Operation Log Off",
 	"config.allow delete operation log":  "Allow Delete Operation Log",
@@ -439,6 +448,11 @@ var en = LangSet{
 	"modify success":                      "modify success",
 	"menu name":                           "menu name",
 	"login fail":                          "login failed",
+	"login terms required":                "please read and accept the terms",
+	"login tenant required":               "please enter the tenant",
+	"idle timeout":                        "Session Timeout",
+	"idle timeout warning":                "You will be logged out in %ds due to inactivity, stay signed in?",
+	"stay signed in":                      "Stay signed in",
 	"export":                              "export",
 	"no permission":                       "no permission",
 	"confirm":                             "confirm",
@@ -448,4 +462,14 @@ var en = LangSet{
 	"site info":                           "Site Info",
 	"more":                                "More",
 	"config.test":                         "test env",
+
+	"duplicate submit, the form is already submitted": "the form is already submitted",
+
+	"the record already exists":         "the record already exists",
+	"the related record does not exist": "the related record does not exist",
+	"%s already exists":                 "%s already exists",
+	"%s does not exist":                 "%s does not exist",
+
+	"invalid date":   "invalid date",
+	"invalid number": "invalid number",
 }
@@ -0,0 +1,47 @@
+package listener
+
+import (
+	"errors"
+	"net/http"
+	"os"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// AutocertManager return the manager of the certificates of the domains,
+// which are issued by Let's Encrypt and cached in the cacheDir. The other
+// domains are refused, so that the certificates are not issued for the hosts
+// of the forged requests.
+func AutocertManager(domains []string, email, cacheDir string) (*autocert.Manager, error) {
+	if len(domains) == 0 {
+		return nil, errors.New("no domain of the certificates")
+	}
+	if cacheDir == "" {
+		return nil, errors.New("empty cache dir of the certificates")
+	}
+	if err := os.MkdirAll(cacheDir, 0700); err != nil {
+		return nil, err
+	}
+	return &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(domains...),
+		Cache:      autocert.DirCache(cacheDir),
+		Email:      email,
+	}, nil
+}
+
+// ServeTLS listen on the address and serve the handler over https with the
+// certificates of the manager, see Listen.
+func ServeTLS(m *autocert.Manager, addr string, handler http.Handler) error {
+	lis, err := Listen(addr)
+	if err != nil {
+		return err
+	}
+	return (&http.Server{Handler: handler, TLSConfig: m.TLSConfig()}).ServeTLS(lis, "", "")
+}
+
+// ServeRedirect listen on the address, answer the http-01 challenges of the
+// manager and redirect the other http requests to https.
+func ServeRedirect(m *autocert.Manager, addr string) error {
+	return Serve(addr, m.HTTPHandler(nil))
+}
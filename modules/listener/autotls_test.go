@@ -0,0 +1,27 @@
+package listener
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutocertManager(t *testing.T) {
+	_, err := AutocertManager(nil, "", t.TempDir())
+	assert.Error(t, err)
+	_, err = AutocertManager([]string{"admin.example.com"}, "", "")
+	assert.Error(t, err)
+
+	dir := filepath.Join(t.TempDir(), "autocert")
+	m, err := AutocertManager([]string{"admin.example.com"}, "admin@example.com", dir)
+	assert.NoError(t, err)
+	info, err := os.Stat(dir)
+	assert.NoError(t, err)
+	assert.True(t, info.IsDir())
+
+	assert.NoError(t, m.HostPolicy(context.Background(), "admin.example.com"))
+	assert.Error(t, m.HostPolicy(context.Background(), "evil.example.com"))
+}
@@ -0,0 +1,170 @@
+// Package listener provides the listeners the admin is served on. Besides the
+// tcp addresses, it listens on the unix sockets and inherits the listeners
+// activated by systemd, so that the admin behind nginx on the same host does
+// not expose a tcp port:
+//
+//	// ":3333", "unix:/run/goadmin/goadmin.sock", "systemd" or "systemd:web",
+//	// overridden by the GOADMIN_LISTEN environment variable.
+//	err := listener.Serve(listener.Addr(":3333"), handler)
+package listener
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// EnvAddr is the environment variable overriding the address, see Addr.
+	EnvAddr = "GOADMIN_LISTEN"
+
+	unixPrefix    = "unix:"
+	systemdPrefix = "systemd"
+
+	// listenFdsStart is the first file descriptor passed by systemd.
+	listenFdsStart = 3
+)
+
+// SocketMode is the permission of the unix sockets, the group of the socket
+// such as the one of nginx can connect to it.
+var SocketMode os.FileMode = 0660
+
+// Addr return the address of the EnvAddr environment variable, or the given
+// one when it is not set.
+func Addr(addr string) string {
+	if env := strings.TrimSpace(os.Getenv(EnvAddr)); env != "" {
+		return env
+	}
+	return addr
+}
+
+// Listen return the listener of the address, which is one of:
+//
+//   - "unix:/path/to.sock": the unix socket at the path, the stale socket left
+//     by the former process is removed and the socket is chmod to SocketMode.
+//   - "systemd": the first listener activated by systemd.
+//   - "systemd:name": the listener activated by systemd with the name, which is
+//     the FileDescriptorName of the socket unit.
+//   - others: the tcp address, such as ":3333".
+func Listen(addr string) (net.Listener, error) {
+	switch {
+	case strings.HasPrefix(addr, unixPrefix):
+		return listenUnix(strings.TrimPrefix(addr, unixPrefix))
+	case addr == systemdPrefix:
+		return inherit("")
+	case strings.HasPrefix(addr, systemdPrefix+":"):
+		return inherit(strings.TrimPrefix(addr, systemdPrefix+":"))
+	default:
+		return net.Listen("tcp", addr)
+	}
+}
+
+// Serve listen on the address and serve the handler, see Listen.
+func Serve(addr string, handler http.Handler) error {
+	lis, err := Listen(addr)
+	if err != nil {
+		return err
+	}
+	return (&http.Server{Handler: handler}).Serve(lis)
+}
+
+func listenUnix(path string) (net.Listener, error) {
+	if path == "" {
+		return nil, errors.New("empty unix socket path")
+	}
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a unix socket", path)
+		}
+		// the socket left by the former process, which is refused when it is
+		// still in use.
+		if conn, err := net.Dial("unix", path); err == nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("unix socket %s is in use", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, SocketMode); err != nil {
+		_ = lis.Close()
+		return nil, err
+	}
+	return lis, nil
+}
+
+type activatedFile struct {
+	name string
+	file *os.File
+	used bool
+}
+
+var (
+	activatedOnce  sync.Once
+	activatedLock  sync.Mutex
+	activatedFiles []*activatedFile
+)
+
+// activated return the files passed by systemd, which are parsed from the
+// LISTEN_PID, LISTEN_FDS and LISTEN_FDNAMES environment variables once.
+func activated() []*activatedFile {
+	activatedOnce.Do(func() {
+		if pid, err := strconv.Atoi(os.Getenv("LISTEN_PID")); err != nil || pid != os.Getpid() {
+			return
+		}
+		n, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+		if err != nil || n <= 0 {
+			return
+		}
+		names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+		for i := 0; i < n; i++ {
+			name := ""
+			if i < len(names) {
+				name = names[i]
+			}
+			fd := uintptr(listenFdsStart + i)
+			activatedFiles = append(activatedFiles, &activatedFile{
+				name: name,
+				file: os.NewFile(fd, "LISTEN_FD_"+strconv.Itoa(int(fd))),
+			})
+		}
+	})
+	return activatedFiles
+}
+
+// inherit return the first unused listener activated by systemd with the
+// name, or the first unused one when the name is empty.
+func inherit(name string) (net.Listener, error) {
+	activatedLock.Lock()
+	defer activatedLock.Unlock()
+
+	files := activated()
+	if len(files) == 0 {
+		return nil, errors.New("no listener is activated by systemd")
+	}
+	for _, f := range files {
+		if f.used || (name != "" && f.name != name) {
+			continue
+		}
+		lis, err := net.FileListener(f.file)
+		if err != nil {
+			return nil, err
+		}
+		f.used = true
+		_ = f.file.Close()
+		return lis, nil
+	}
+	if name == "" {
+		return nil, errors.New("all the listeners activated by systemd are used")
+	}
+	return nil, fmt.Errorf("no listener named %s is activated by systemd", name)
+}
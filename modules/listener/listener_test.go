@@ -0,0 +1,97 @@
+package listener
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddr(t *testing.T) {
+	t.Setenv(EnvAddr, "")
+	assert.Equal(t, ":3333", Addr(":3333"))
+	t.Setenv(EnvAddr, "unix:/run/goadmin.sock")
+	assert.Equal(t, "unix:/run/goadmin.sock", Addr(":3333"))
+}
+
+func TestListenUnix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goadmin.sock")
+
+	lis, err := Listen("unix:" + path)
+	assert.NoError(t, err)
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, SocketMode, info.Mode().Perm())
+
+	go func() {
+		_ = (&http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		})}).Serve(lis)
+	}()
+
+	client := &http.Client{Transport: &http.Transport{
+		Dial: func(_, _ string) (net.Conn, error) { return net.Dial("unix", path) },
+	}}
+	res, err := client.Get("http://goadmin/")
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	assert.Equal(t, "ok", string(body))
+
+	// the socket in use is not removed.
+	_, err = Listen("unix:" + path)
+	assert.Error(t, err)
+	_ = lis.Close()
+
+	// the stale socket is removed.
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	assert.NoError(t, err)
+	stale.SetUnlinkOnClose(false)
+	_ = stale.Close()
+	lis, err = Listen("unix:" + path)
+	assert.NoError(t, err)
+	_ = lis.Close()
+
+	// the other files are not removed.
+	file := filepath.Join(t.TempDir(), "file")
+	assert.NoError(t, os.WriteFile(file, []byte("data"), 0600))
+	_, err = Listen("unix:" + file)
+	assert.Error(t, err)
+	_, err = os.Stat(file)
+	assert.NoError(t, err)
+}
+
+func TestListenSystemd(t *testing.T) {
+	activatedOnce.Do(func() {})
+	defer func() { activatedFiles = nil }()
+
+	_, err := Listen("systemd")
+	assert.Error(t, err)
+
+	for _, name := range []string{"web", "grpc"} {
+		tcp, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		f, err := tcp.(*net.TCPListener).File()
+		assert.NoError(t, err)
+		_ = tcp.Close()
+		activatedFiles = append(activatedFiles, &activatedFile{name: name, file: f})
+	}
+
+	lis, err := Listen("systemd:grpc")
+	assert.NoError(t, err)
+	_ = lis.Close()
+	_, err = Listen("systemd:grpc")
+	assert.Error(t, err)
+	_, err = Listen("systemd:admin")
+	assert.Error(t, err)
+
+	lis, err = Listen("systemd")
+	assert.NoError(t, err)
+	_ = lis.Close()
+	_, err = Listen("systemd")
+	assert.Error(t, err)
+}
@@ -378,11 +378,12 @@ func Access(ctx *context.Context) {
 	}
 }
 
-// LogSQL print the sql info message.
+// LogSQL print the sql info message, the arguments are redacted, see
+// RedactSQLArgs. Use LogSQLCtx for the statements of a request.
 func LogSQL(statement string, args []interface{}) {
 	if !logger.infoLogOff && logger.sqlLogOpen && statement != "" {
 		if logger.Level <= zapcore.InfoLevel {
-			logger.sugaredLogger.With("statement", statement, "args", args).Info("[GoAdmin]")
+			logger.sugaredLogger.With("statement", statement, "args", RedactSQLArgs(statement, args)).Info("[GoAdmin]")
 		}
 	}
 }
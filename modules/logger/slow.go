@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"sort"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// MaxSlowSQL is the number of the slowest statements logged with a slow
+// request.
+const MaxSlowSQL = 5
+
+// SlowestSQL return the n slowest statements of the records, the slowest
+// first. The statements as slow keep the order of execution.
+func SlowestSQL(records []SQLRecord, n int) []SQLRecord {
+	slowest := append([]SQLRecord(nil), records...)
+	sort.SliceStable(slowest, func(i, j int) bool {
+		return slowest[i].Elapsed > slowest[j].Elapsed
+	})
+	if len(slowest) > n {
+		slowest = slowest[:n]
+	}
+	return slowest
+}
+
+// LogLongRequest print the warning of a request still running after the
+// threshold of the watchdog. It is called by the timer of the watchdog, so
+// it takes the trace id instead of the ctx, whose values may be set by the
+// request at the same time.
+func LogLongRequest(traceID, method, path string, elapsed time.Duration) {
+	if logger.infoLogOff || logger.Level > zapcore.WarnLevel {
+		return
+	}
+	logger.logger.Warn("[GoAdmin] long request",
+		zap.String("traceID", traceID),
+		zap.String("method", method),
+		zap.String("path", path),
+		zap.Duration("elapsed", elapsed))
+}
+
+// LogSlowRequest print the warning of a request slower than the threshold of
+// the watchdog, with the user, the number and the total time of the recorded
+// statements of the ctx and the MaxSlowSQL slowest ones, see RecordSQL.
+func LogSlowRequest(ctx *context.Context, user string, elapsed time.Duration) {
+	if logger.infoLogOff || logger.Level > zapcore.WarnLevel {
+		return
+	}
+	records := SQLRecords(ctx)
+	var sqlElapsed time.Duration
+	for _, record := range records {
+		sqlElapsed += record.Elapsed
+	}
+	status := 0
+	if ctx.Response != nil {
+		status = ctx.Response.StatusCode
+	}
+	logger.logger.Warn("[GoAdmin] slow request",
+		zap.String("traceID", trace.GetTraceID(ctx)),
+		zap.String("method", ctx.Method()),
+		zap.String("path", ctx.Path()),
+		zap.String("user", user),
+		zap.Int("statuscode", status),
+		zap.Duration("elapsed", elapsed),
+		zap.Int("queries", len(records)),
+		zap.Duration("sql_elapsed", sqlElapsed),
+		zap.Any("slowest", SlowestSQL(records, MaxSlowSQL)))
+}
@@ -0,0 +1,137 @@
+package logger
+
+import (
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SQLRecord is a sql statement executed by a request.
+type SQLRecord struct {
+	Statement string
+	Args      []interface{}
+	Elapsed   time.Duration
+}
+
+// sqlRecorder keeps the statements of a request, the statements may be
+// executed by several goroutines.
+type sqlRecorder struct {
+	lock    sync.Mutex
+	records []SQLRecord
+}
+
+const sqlRecorderKey = "goadmin_sql_recorder"
+
+// MaxSQLArgLength is the length above which a string argument is truncated in
+// the logs.
+const MaxSQLArgLength = 256
+
+// sensitiveSQLWords are the words of the statements whose string arguments
+// are redacted, as the statements updating the passwords.
+var sensitiveSQLWords = []string{"password", "secret", "token"}
+
+// RecordSQL start recording the statements executed with the ctx, see
+// SQLRecords.
+func RecordSQL(ctx *context.Context) {
+	if ctx != nil {
+		ctx.SetUserValue(sqlRecorderKey, &sqlRecorder{})
+	}
+}
+
+// SQLRecords return the statements executed with the ctx since RecordSQL, in
+// the order of execution.
+func SQLRecords(ctx *context.Context) []SQLRecord {
+	recorder := getSQLRecorder(ctx)
+	if recorder == nil {
+		return nil
+	}
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+	return append([]SQLRecord(nil), recorder.records...)
+}
+
+func getSQLRecorder(ctx *context.Context) *sqlRecorder {
+	if ctx == nil {
+		return nil
+	}
+	recorder, _ := ctx.GetUserValue(sqlRecorderKey).(*sqlRecorder)
+	return recorder
+}
+
+// LogSQLCtx print the sql info message with the request id, the route and the
+// elapsed time of the statement, and record it when the ctx records the
+// statements. The arguments are redacted, see RedactSQLArgs.
+func LogSQLCtx(ctx *context.Context, statement string, args []interface{}, elapsed time.Duration) {
+	if statement == "" {
+		return
+	}
+	args = RedactSQLArgs(statement, args)
+
+	if recorder := getSQLRecorder(ctx); recorder != nil {
+		recorder.lock.Lock()
+		recorder.records = append(recorder.records, SQLRecord{Statement: statement, Args: args, Elapsed: elapsed})
+		recorder.lock.Unlock()
+	}
+
+	if logger.infoLogOff || !logger.sqlLogOpen || logger.Level > zapcore.InfoLevel {
+		return
+	}
+	fields := []zapcore.Field{zap.Duration("elapsed", elapsed), zap.String("statement", statement), zap.Any("args", args)}
+	if ctx != nil {
+		fields = append(fields,
+			zap.String("traceID", trace.GetTraceID(ctx)),
+			zap.String("method", ctx.Method()),
+			zap.String("path", ctx.Path()))
+	}
+	logger.logger.Info("[GoAdmin] sql", fields...)
+}
+
+// RedactSQLArgs return the arguments of the statement to be logged. The string
+// arguments of a statement mentioning a password, a secret or a token are
+// replaced, and the long ones are truncated to MaxSQLArgLength.
+func RedactSQLArgs(statement string, args []interface{}) []interface{} {
+	if len(args) == 0 {
+		return args
+	}
+	lower := strings.ToLower(statement)
+	sensitive := false
+	for _, word := range sensitiveSQLWords {
+		if strings.Contains(lower, word) {
+			sensitive = true
+			break
+		}
+	}
+
+	redacted := make([]interface{}, len(args))
+	for i, arg := range args {
+		var s string
+		switch v := arg.(type) {
+		case string:
+			s = v
+		case []byte:
+			s = string(v)
+		default:
+			redacted[i] = arg
+			continue
+		}
+		switch {
+		case sensitive:
+			redacted[i] = "******"
+		case len(s) > MaxSQLArgLength:
+			cut := MaxSQLArgLength
+			for cut > 0 && !utf8.RuneStart(s[cut]) {
+				cut--
+			}
+			redacted[i] = s[:cut] + "..."
+		default:
+			redacted[i] = s
+		}
+	}
+	return redacted
+}
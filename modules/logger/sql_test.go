@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/context"
+)
+
+func TestRedactSQLArgs(t *testing.T) {
+	args := RedactSQLArgs("update goadmin_users set password = ? where id = ?", []interface{}{"secret", 1})
+	assert.Equal(t, args, []interface{}{"******", 1})
+
+	long := strings.Repeat("a", MaxSQLArgLength+10)
+	args = RedactSQLArgs("select * from users where name = ?", []interface{}{long, []byte("jack")})
+	assert.Equal(t, args, []interface{}{long[:MaxSQLArgLength] + "...", "jack"})
+}
+
+func TestRecordSQL(t *testing.T) {
+	ctx := context.NewContext(httptest.NewRequest("GET", "/admin/info/users", nil))
+	LogSQLCtx(ctx, "select 1", nil, time.Millisecond)
+	assert.Equal(t, len(SQLRecords(ctx)), 0)
+
+	RecordSQL(ctx)
+	LogSQLCtx(ctx, "select * from users where id = ?", []interface{}{1}, time.Millisecond)
+	LogSQLCtx(ctx, "", nil, 0)
+	LogSQLCtx(nil, "select 2", nil, 0)
+	assert.Equal(t, SQLRecords(ctx), []SQLRecord{
+		{Statement: "select * from users where id = ?", Args: []interface{}{1}, Elapsed: time.Millisecond},
+	})
+}
+
+func TestSlowestSQL(t *testing.T) {
+	records := []SQLRecord{
+		{Statement: "select 1", Elapsed: time.Millisecond},
+		{Statement: "select 2", Elapsed: 3 * time.Millisecond},
+		{Statement: "select 3", Elapsed: time.Millisecond},
+		{Statement: "select 4", Elapsed: 2 * time.Millisecond},
+	}
+	slowest := SlowestSQL(records, 3)
+	assert.Equal(t, []string{slowest[0].Statement, slowest[1].Statement, slowest[2].Statement},
+		[]string{"select 2", "select 4", "select 1"})
+	assert.Equal(t, records[0].Statement, "select 1")
+	assert.Equal(t, len(SlowestSQL(nil, 3)), 0)
+}
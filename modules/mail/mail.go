@@ -0,0 +1,173 @@
+// Package mail provides the mails sent by the admin, such as the scheduled
+// reports. The mails are sent by the smtp server of config.Mail by default,
+// another sender can be used instead:
+//
+//	mail.Use(mySender)
+//
+//	err := mail.Send(mail.Message{
+//		To:      []string{"someone@example.com"},
+//		Subject: "Weekly users",
+//		Body:    "See the attachment.",
+//		Attachments: []mail.Attachment{
+//			{Name: "users.xlsx", ContentType: mail.XLSXContentType, Data: data},
+//		},
+//	})
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+)
+
+// XLSXContentType is the content type of the xlsx attachments.
+const XLSXContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// ErrNotConfigured is returned when no sender is used and the smtp server is
+// not configured.
+var ErrNotConfigured = errors.New("mail is not configured")
+
+// Message is a mail with the attachments, the body is plain text.
+type Message struct {
+	To          []string
+	Subject     string
+	Body        string
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to the message.
+type Attachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Sender sends the messages.
+type Sender interface {
+	Send(msg Message) error
+}
+
+var (
+	senderLock sync.RWMutex
+	sender     Sender
+)
+
+// Use set the sender of the messages, the smtp server of config.Mail is used
+// when it is nil.
+func Use(s Sender) {
+	senderLock.Lock()
+	defer senderLock.Unlock()
+	sender = s
+}
+
+// Enabled check whether the messages can be sent.
+func Enabled() bool {
+	senderLock.RLock()
+	defer senderLock.RUnlock()
+	return sender != nil || config.GetMail().Host != ""
+}
+
+// Send send the message by the sender.
+func Send(msg Message) error {
+	senderLock.RLock()
+	s := sender
+	senderLock.RUnlock()
+	if s == nil {
+		cfg := config.GetMail()
+		if cfg.Host == "" {
+			return ErrNotConfigured
+		}
+		s = SMTP(cfg)
+	}
+	if len(msg.To) == 0 {
+		return errors.New("mail has no recipient")
+	}
+	return s.Send(msg)
+}
+
+// SMTP is the sender of the smtp server, it authenticates with PLAIN auth when
+// the username is set.
+type SMTP config.Mail
+
+// Send implements Sender.Send.
+func (s SMTP) Send(msg Message) error {
+	port := s.Port
+	if port == 0 {
+		port = 25
+	}
+	from := s.From
+	if from == "" {
+		from = s.Username
+	}
+	var a smtp.Auth
+	if s.Username != "" {
+		a = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+	return smtp.SendMail(s.Host+":"+strconv.Itoa(port), a, from, msg.To, Build(from, msg, time.Now()))
+}
+
+// Build return the MIME message of the mail, the attachments are encoded by
+// base64 in the parts of a multipart/mixed message.
+func Build(from string, msg Message, date time.Time) []byte {
+	var buf bytes.Buffer
+	boundary := fmt.Sprintf("goadmin-%d", date.UnixNano())
+
+	header := func(key, value string) {
+		buf.WriteString(key + ": " + value + "\r\n")
+	}
+	header("From", from)
+	header("To", strings.Join(msg.To, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", date.Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+
+	if len(msg.Attachments) == 0 {
+		header("Content-Type", "text/plain; charset=utf-8")
+		header("Content-Transfer-Encoding", "base64")
+		buf.WriteString("\r\n")
+		writeBase64(&buf, []byte(msg.Body))
+		return buf.Bytes()
+	}
+
+	header("Content-Type", `multipart/mixed; boundary="`+boundary+`"`)
+	buf.WriteString("\r\n--" + boundary + "\r\n")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "base64")
+	buf.WriteString("\r\n")
+	writeBase64(&buf, []byte(msg.Body))
+
+	for _, attachment := range msg.Attachments {
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		name := mime.QEncoding.Encode("utf-8", attachment.Name)
+		buf.WriteString("\r\n--" + boundary + "\r\n")
+		header("Content-Type", contentType+`; name="`+name+`"`)
+		header("Content-Disposition", `attachment; filename="`+name+`"`)
+		header("Content-Transfer-Encoding", "base64")
+		buf.WriteString("\r\n")
+		writeBase64(&buf, attachment.Data)
+	}
+	buf.WriteString("\r\n--" + boundary + "--\r\n")
+	return buf.Bytes()
+}
+
+// writeBase64 write the data in the lines of 76 characters.
+func writeBase64(buf *bytes.Buffer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+}
@@ -0,0 +1,74 @@
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recorder struct {
+	sent []Message
+}
+
+func (r *recorder) Send(msg Message) error {
+	r.sent = append(r.sent, msg)
+	return nil
+}
+
+func TestBuild(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), 200)
+	raw := Build("admin@example.com", Message{
+		To:          []string{"a@example.com", "b@example.com"},
+		Subject:     "周报 users",
+		Body:        "see the attachment",
+		Attachments: []Attachment{{Name: "users.xlsx", ContentType: XLSXContentType, Data: data}},
+	}, time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC))
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	assert.Nil(t, err)
+	assert.Equal(t, "a@example.com, b@example.com", msg.Header.Get("To"))
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	assert.Nil(t, err)
+	assert.Equal(t, "周报 users", subject)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	assert.Nil(t, err)
+	assert.Equal(t, "multipart/mixed", mediaType)
+
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	body, err := reader.NextPart()
+	assert.Nil(t, err)
+	text, _ := io.ReadAll(base64.NewDecoder(base64.StdEncoding, body))
+	assert.Equal(t, "see the attachment", string(text))
+
+	attachment, err := reader.NextPart()
+	assert.Nil(t, err)
+	assert.Equal(t, "users.xlsx", attachment.FileName())
+	content, _ := io.ReadAll(base64.NewDecoder(base64.StdEncoding, attachment))
+	assert.Equal(t, data, content)
+
+	_, err = reader.NextPart()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestSend(t *testing.T) {
+	Use(nil)
+	assert.False(t, Enabled())
+	assert.Equal(t, ErrNotConfigured, Send(Message{To: []string{"a@example.com"}}))
+
+	r := new(recorder)
+	Use(r)
+	defer Use(nil)
+	assert.True(t, Enabled())
+	assert.NotNil(t, Send(Message{Subject: "no recipient"}))
+	assert.Nil(t, Send(Message{To: []string{"a@example.com"}, Subject: "hi"}))
+	assert.Equal(t, 1, len(r.sent))
+	assert.Equal(t, "hi", r.sent[0].Subject)
+}
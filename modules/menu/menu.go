@@ -12,6 +12,7 @@ import (
 
 	"github.com/purpose168/GoAdmin/modules/db/dialect"
 
+	"github.com/purpose168/GoAdmin/modules/config"
 	"github.com/purpose168/GoAdmin/modules/db"
 	"github.com/purpose168/GoAdmin/modules/language"
 	"github.com/purpose168/GoAdmin/plugins/admin/models"
@@ -29,6 +30,22 @@ type Item struct {
 	ChildrenList []Item `json:"childrenList"`
 }
 
+// Types of the menu items, saved in the column "type" of goadmin_menu.
+const (
+	// TypeNormal items link to the uri.
+	TypeNormal int64 = 0
+	// TypeSystem items are the builtin items, their titles are translated.
+	TypeSystem int64 = 1
+	// TypeIframe items embed the uri, such as an external app, in an iframe
+	// of the admin pages at EmbedPath.
+	TypeIframe int64 = 2
+)
+
+// EmbedPath is the path of the page embedding the TypeIframe item of the id.
+func EmbedPath(id string) string {
+	return "/embed/" + id
+}
+
 // Menu contains list of menu items and other info.
 type Menu struct {
 	List        []Item              `json:"list"`
@@ -137,6 +154,8 @@ type NewMenuData struct {
 }
 
 func NewMenu(conn db.Connection, data NewMenuData) (int64, error) {
+	defer models.InvalidateAuthCache()
+
 	maxOrder := data.Order
 	checkOrder, _ := db.WithDriver(conn).Table("goadmin_menu").
 		Where("plugin_name", "=", data.PluginName).
@@ -178,14 +197,15 @@ func GetGlobalMenu(user models.UserModel, conn db.Connection, lang string, plugi
 		plugName = pluginNames[0]
 	}
 
-	user.WithRoles().WithMenus()
-
+	// the rows are cached by the roles of the user, see models.CachedRows.
 	if user.IsSuperAdmin() {
-		menus, _ = db.WithDriver(conn).Table("goadmin_menu").
-			Where("id", ">", 0).
-			Where("plugin_name", "=", plugName).
-			OrderBy("order", "asc").
-			All()
+		menus = models.CachedRows("menu:"+plugName+":super", func() ([]map[string]interface{}, error) {
+			return db.WithDriver(conn).Table("goadmin_menu").
+				Where("id", ">", 0).
+				Where("plugin_name", "=", plugName).
+				OrderBy("order", "asc").
+				All()
+		})
 	} else {
 
 		var ids []interface{}
@@ -193,11 +213,14 @@ func GetGlobalMenu(user models.UserModel, conn db.Connection, lang string, plugi
 			ids = append(ids, user.MenuIds[i])
 		}
 
-		menus, _ = db.WithDriver(conn).Table("goadmin_menu").
-			WhereIn("id", ids).
-			Where("plugin_name", "=", plugName).
-			OrderBy("order", "asc").
-			All()
+		menus = models.CachedRows("menu:"+plugName+":roles:"+models.RoleSetKey(user.GetAllRoleId()),
+			func() ([]map[string]interface{}, error) {
+				return db.WithDriver(conn).Table("goadmin_menu").
+					WhereIn("id", ids).
+					Where("plugin_name", "=", plugName).
+					OrderBy("order", "asc").
+					All()
+			})
 	}
 
 	var title string
@@ -231,7 +254,7 @@ func constructMenuTree(menus []map[string]interface{}, parentID int64, lang stri
 	var title string
 	for j := 0; j < len(menus); j++ {
 		if parentID == menus[j]["parent_id"].(int64) {
-			if menus[j]["type"].(int64) == 1 {
+			if menus[j]["type"].(int64) == TypeSystem {
 				title = language.Get(menus[j]["title"].(string))
 			} else {
 				title = menus[j]["title"].(string)
@@ -239,7 +262,11 @@ func constructMenuTree(menus []map[string]interface{}, parentID int64, lang stri
 
 			header, _ := menus[j]["header"].(string)
 
-			uri := menus[j]["uri"].(string)
+			// the uris stored with a former url prefix keep working.
+			uri := config.NormalizePath(menus[j]["uri"].(string))
+			if menus[j]["type"].(int64) == TypeIframe {
+				uri = EmbedPath(strconv.FormatInt(menus[j]["id"].(int64), 10))
+			}
 
 			if lang != "" {
 				if strings.Contains(uri, "?") {
@@ -0,0 +1,255 @@
+// Package notify keeps the notifications of the sessions, such as the
+// progress of the long operations and the toasts, which are streamed to the
+// pages by the server-sent events of the admin plugin.
+//
+// The events are kept by the channels of the sessions in the memory, the
+// latest ones of a channel are replayed to the client reconnecting with the
+// Last-Event-ID, so the events sent between the reconnections are not lost:
+//
+//	p := notify.NewProgress(ctx, "export", "Export users", total)
+//	for ... {
+//		p.Add(len(chunk))
+//	}
+//	p.Finish()
+package notify
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+)
+
+// The types of the events.
+const (
+	TypeProgress = "progress"
+	TypeToast    = "toast"
+)
+
+// The levels of the toasts.
+const (
+	LevelInfo    = "info"
+	LevelSuccess = "success"
+	LevelWarning = "warning"
+	LevelError   = "error"
+)
+
+// Event is a notification of a session.
+type Event struct {
+	ID   int64       `json:"id"`
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// ProgressData is the data of the progress events, Total is 0 when the
+// number of the items is unknown.
+type ProgressData struct {
+	Op       string `json:"op"`
+	Title    string `json:"title"`
+	Done     int    `json:"done"`
+	Total    int    `json:"total"`
+	Finished bool   `json:"finished"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ToastData is the data of the toast events.
+type ToastData struct {
+	Level string `json:"level"`
+	Msg   string `json:"msg"`
+}
+
+// Hub keeps the channels of the sessions.
+type Hub struct {
+	lock      sync.Mutex
+	channels  map[string]*channel
+	size      int
+	idle      time.Duration
+	lastSweep time.Time
+}
+
+type channel struct {
+	events  []Event
+	lastID  int64
+	wait    chan struct{}
+	touched time.Time
+}
+
+// DefaultSize is the most events kept by a channel of the default hub.
+const DefaultSize = 50
+
+// DefaultIdle is the time after which the channels without any event or
+// client are removed by the default hub.
+const DefaultIdle = time.Hour
+
+// NewHub return a hub keeping the latest size events of every channel.
+func NewHub(size int, idle time.Duration) *Hub {
+	return &Hub{channels: make(map[string]*channel), size: size, idle: idle}
+}
+
+var defaultHub = NewHub(DefaultSize, DefaultIdle)
+
+// Default return the hub used by the admin plugin.
+func Default() *Hub {
+	return defaultHub
+}
+
+// Key return the key of the channel of the request, which is the session of
+// the cookie, or the user of the request authenticated by the tokens.
+func Key(ctx *context.Context) string {
+	if key := SessionKey(ctx); key != "" {
+		return key
+	}
+	return "user:" + strconv.FormatInt(auth.Auth(ctx).Id, 10)
+}
+
+// SessionKey return the key of the channel of the session of the cookie,
+// empty when there is none. Unlike Key, it only reads the request, so it can
+// be called before the auth middleware.
+func SessionKey(ctx *context.Context) string {
+	if cookie, err := ctx.Request.Cookie(auth.DefaultCookieKey); err == nil && cookie.Value != "" {
+		return "session:" + cookie.Value
+	}
+	return ""
+}
+
+// channel return the channel of the key, the lock should be held.
+func (h *Hub) channel(key string) *channel {
+	now := time.Now()
+	if now.Sub(h.lastSweep) > h.idle/4 {
+		for k, c := range h.channels {
+			if now.Sub(c.touched) > h.idle {
+				delete(h.channels, k)
+			}
+		}
+		h.lastSweep = now
+	}
+	c, ok := h.channels[key]
+	if !ok {
+		c = &channel{wait: make(chan struct{})}
+		h.channels[key] = c
+	}
+	c.touched = now
+	return c
+}
+
+// Publish add the event into the channel and wake up the waiting clients.
+func (h *Hub) Publish(key, typ string, data interface{}) Event {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	c := h.channel(key)
+	c.lastID++
+	e := Event{ID: c.lastID, Type: typ, Data: data}
+	c.events = append(c.events, e)
+	if len(c.events) > h.size {
+		c.events = c.events[len(c.events)-h.size:]
+	}
+	close(c.wait)
+	c.wait = make(chan struct{})
+	return e
+}
+
+// LastID return the id of the last event of the channel.
+func (h *Hub) LastID(key string) int64 {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.channel(key).lastID
+}
+
+// since return the events after the id and the channel to wait for the next
+// one, the lock should be held. All the kept events are returned when the id
+// is newer than the last one, which means the channel is lost by a restart.
+func (h *Hub) since(key string, lastID int64) ([]Event, <-chan struct{}) {
+	c := h.channel(key)
+	if lastID > c.lastID {
+		lastID = 0
+	}
+	events := make([]Event, 0)
+	for _, e := range c.events {
+		if e.ID > lastID {
+			events = append(events, e)
+		}
+	}
+	return events, c.wait
+}
+
+// Wait return the events of the channel after the id, it waits for the
+// next event until the timeout or the done channel is closed when there is
+// none.
+func (h *Hub) Wait(key string, lastID int64, timeout time.Duration, done <-chan struct{}) []Event {
+	h.lock.Lock()
+	events, wait := h.since(key, lastID)
+	h.lock.Unlock()
+	if len(events) > 0 {
+		return events
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-wait:
+	case <-timer.C:
+		return events
+	case <-done:
+		return events
+	}
+
+	h.lock.Lock()
+	events, _ = h.since(key, lastID)
+	h.lock.Unlock()
+	return events
+}
+
+// Toast publish a toast into the channel of the request.
+func Toast(ctx *context.Context, level, msg string) {
+	defaultHub.Publish(Key(ctx), TypeToast, ToastData{Level: level, Msg: msg})
+}
+
+// Progress reports the progress of an operation into the channel of the
+// request, the progress events are published at most every interval except
+// the first and the last ones.
+type Progress struct {
+	hub      *Hub
+	key      string
+	data     ProgressData
+	last     time.Time
+	interval time.Duration
+}
+
+// NewProgress publish the start of the operation and return its progress.
+func NewProgress(ctx *context.Context, op, title string, total int) *Progress {
+	p := &Progress{hub: defaultHub, key: Key(ctx), interval: time.Second / 2,
+		data: ProgressData{Op: op, Title: title, Total: total}}
+	p.publish()
+	return p
+}
+
+func (p *Progress) publish() {
+	p.last = time.Now()
+	p.hub.Publish(p.key, TypeProgress, p.data)
+}
+
+// Add report the done items, it does nothing when the progress is nil.
+func (p *Progress) Add(n int) {
+	if p == nil {
+		return
+	}
+	p.data.Done += n
+	if time.Since(p.last) >= p.interval {
+		p.publish()
+	}
+}
+
+// Finish publish the end of the operation, the error is reported when it is
+// not nil. It does nothing when the progress is nil.
+func (p *Progress) Finish(err ...error) {
+	if p == nil {
+		return
+	}
+	p.data.Finished = true
+	if len(err) > 0 && err[0] != nil {
+		p.data.Error = err[0].Error()
+	}
+	p.publish()
+}
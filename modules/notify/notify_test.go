@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestHub(t *testing.T) {
+	hub := NewHub(2, time.Hour)
+
+	assert.Equal(t, hub.LastID("a"), int64(0))
+	assert.Equal(t, len(hub.Wait("a", 0, time.Millisecond, nil)), 0)
+
+	hub.Publish("a", TypeToast, ToastData{Level: LevelInfo, Msg: "1"})
+	hub.Publish("a", TypeToast, ToastData{Level: LevelInfo, Msg: "2"})
+	hub.Publish("a", TypeToast, ToastData{Level: LevelInfo, Msg: "3"})
+	hub.Publish("b", TypeToast, ToastData{Level: LevelInfo, Msg: "4"})
+
+	events := hub.Wait("a", 0, time.Millisecond, nil)
+	assert.Equal(t, len(events), 2)
+	assert.Equal(t, events[0].ID, int64(2))
+	assert.Equal(t, events[1].Data.(ToastData).Msg, "3")
+
+	assert.Equal(t, len(hub.Wait("a", 2, time.Millisecond, nil)), 1)
+	assert.Equal(t, hub.LastID("b"), int64(1))
+
+	// the id newer than the last one replays the kept events.
+	assert.Equal(t, len(hub.Wait("b", 10, time.Millisecond, nil)), 1)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		hub.Publish("a", TypeProgress, ProgressData{Op: "export", Done: 1})
+	}()
+	events = hub.Wait("a", 3, time.Second, nil)
+	assert.Equal(t, len(events), 1)
+	assert.Equal(t, events[0].Type, TypeProgress)
+
+	done := make(chan struct{})
+	close(done)
+	assert.Equal(t, len(hub.Wait("a", 4, time.Second, done)), 0)
+}
+
+func TestNilProgress(t *testing.T) {
+	var p *Progress
+	p.Add(1)
+	p.Finish(nil)
+}
@@ -0,0 +1,445 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+// Package qrcode encodes the short texts, such as the urls, as the QR codes
+// rendered into svg, so no external service sees the encoded text.
+//
+// Only the byte mode and the medium error correction level are supported,
+// with the versions 1 to 10, which hold up to 213 bytes.
+package qrcode
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrTooLong is returned when the text does not fit in the largest version.
+var ErrTooLong = errors.New("qrcode: text too long")
+
+// MaxLength is the length in bytes of the longest text to encode.
+const MaxLength = 213
+
+// versions are the codewords of the versions 1 to 10 of the medium error
+// correction level.
+var versions = []struct {
+	total, blocks, ecc int
+	align              []int
+}{
+	{26, 1, 10, nil},
+	{44, 1, 16, []int{6, 18}},
+	{70, 1, 26, []int{6, 22}},
+	{100, 2, 18, []int{6, 26}},
+	{134, 2, 24, []int{6, 30}},
+	{172, 4, 16, []int{6, 34}},
+	{196, 4, 18, []int{6, 22, 38}},
+	{242, 4, 22, []int{6, 24, 42}},
+	{292, 5, 22, []int{6, 26, 46}},
+	{346, 5, 26, []int{6, 28, 50}},
+}
+
+// Code is an encoded QR code.
+type Code struct {
+	// Size is the number of the modules of a side.
+	Size     int
+	modules  [][]bool
+	function [][]bool
+}
+
+// Dark reports whether the module of the column x and the row y is dark.
+func (c *Code) Dark(x, y int) bool {
+	return x >= 0 && y >= 0 && x < c.Size && y < c.Size && c.modules[y][x]
+}
+
+// Encode encodes the text in the smallest version it fits in.
+func Encode(text string) (*Code, error) {
+	data := []byte(text)
+	for i, v := range versions {
+		version := i + 1
+		countBits := 8
+		if version >= 10 {
+			countBits = 16
+		}
+		capacity := (v.total - v.blocks*v.ecc) * 8
+		if 4+countBits+len(data)*8 > capacity {
+			continue
+		}
+
+		var bits bitBuffer
+		bits.append(4, 4)
+		bits.append(len(data), countBits)
+		for _, b := range data {
+			bits.append(int(b), 8)
+		}
+		terminator := capacity - len(bits)
+		if terminator > 4 {
+			terminator = 4
+		}
+		bits.append(0, terminator)
+		bits.append(0, (8-len(bits)%8)%8)
+		for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+			bits.append(pad, 8)
+		}
+
+		c := newCode(version)
+		c.drawCodewords(interleave(bits.bytes(), v.total, v.blocks, v.ecc))
+		c.applyBestMask()
+		return c, nil
+	}
+	return nil, ErrTooLong
+}
+
+// SVG return the svg of the code, with a quiet zone of four modules, which
+// is scaled to the given size in pixels.
+func (c *Code) SVG(size int) string {
+	n := strconv.Itoa(c.Size + 8)
+	var path strings.Builder
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.modules[y][x] {
+				path.WriteString("M" + strconv.Itoa(x+4) + "," + strconv.Itoa(y+4) + "h1v1h-1z")
+			}
+		}
+	}
+	return `<svg xmlns="http://www.w3.org/2000/svg" width="` + strconv.Itoa(size) + `" height="` + strconv.Itoa(size) +
+		`" viewBox="0 0 ` + n + " " + n + `" shape-rendering="crispEdges">` +
+		`<rect width="100%" height="100%" fill="#fff"/><path d="` + path.String() + `" fill="#000"/></svg>`
+}
+
+func newCode(version int) *Code {
+	size := version*4 + 17
+	c := &Code{Size: size, modules: make([][]bool, size), function: make([][]bool, size)}
+	for i := range c.modules {
+		c.modules[i] = make([]bool, size)
+		c.function[i] = make([]bool, size)
+	}
+
+	for i := 0; i < size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+	c.drawFinder(3, 3)
+	c.drawFinder(size-4, 3)
+	c.drawFinder(3, size-4)
+
+	align := versions[version-1].align
+	last := len(align) - 1
+	for i := range align {
+		for j := range align {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					c.setFunction(align[i]+dx, align[j]+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	// the format bits are reserved until the mask is chosen.
+	c.drawFormat(0)
+	if version >= 7 {
+		bits := versionBits(version)
+		for i := 0; i < 18; i++ {
+			a, b := size-11+i%3, i/3
+			c.setFunction(a, b, bits>>i&1 == 1)
+			c.setFunction(b, a, bits>>i&1 == 1)
+		}
+	}
+	return c
+}
+
+// versionBits return the version bits of the versions 7 and above.
+func versionBits(version int) int {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = rem<<1 ^ (rem>>11)*0x1F25
+	}
+	return version<<12 | rem
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.function[y][x] = true
+}
+
+func (c *Code) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx >= 0 && yy >= 0 && xx < c.Size && yy < c.Size {
+				dist := max(abs(dx), abs(dy))
+				c.setFunction(xx, yy, dist != 2 && dist != 4)
+			}
+		}
+	}
+}
+
+// formatBits return the format bits of the medium level and the mask.
+func formatBits(mask int) int {
+	data := mask // the medium level is 00
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+func (c *Code) drawFormat(mask int) {
+	bits := formatBits(mask)
+	bit := func(i int) bool { return bits>>i&1 == 1 }
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(i))
+	}
+	c.setFunction(8, 7, bit(6))
+	c.setFunction(8, 8, bit(7))
+	c.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(i))
+	}
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.Size-15+i, bit(i))
+	}
+	c.setFunction(8, c.Size-8, true)
+}
+
+// drawCodewords draws the codewords in the zigzag order, from the bottom
+// right corner in the columns of two modules.
+func (c *Code) drawCodewords(data []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if !c.function[y][x] && i < len(data)*8 {
+					c.modules[y][x] = data[i>>3]>>(7-i&7)&1 == 1
+					i++
+				}
+			}
+		}
+	}
+}
+
+func maskBit(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if !c.function[y][x] && maskBit(mask, x, y) {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// applyBestMask applies the mask of the lowest penalty, masking twice
+// restores the modules.
+func (c *Code) applyBestMask() {
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormat(mask)
+		if p := c.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		c.applyMask(mask)
+	}
+	c.applyMask(best)
+	c.drawFormat(best)
+}
+
+var finderLike = [][]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+// penalty return the penalty of the current modules by the four rules of
+// the specification.
+func (c *Code) penalty() int {
+	n, dark := c.Size, 0
+	at := func(x, y int, vertical bool) bool {
+		if vertical {
+			return c.modules[x][y]
+		}
+		return c.modules[y][x]
+	}
+	result := 0
+	for _, vertical := range []bool{false, true} {
+		for y := 0; y < n; y++ {
+			run := 1
+			for x := 1; x <= n; x++ {
+				if x < n && at(x, y, vertical) == at(x-1, y, vertical) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					result += run - 2
+				}
+				run = 1
+			}
+			for x := 0; x+11 <= n; x++ {
+				for _, pattern := range finderLike {
+					match := true
+					for k, d := range pattern {
+						if at(x+k, y, vertical) != d {
+							match = false
+							break
+						}
+					}
+					if match {
+						result += 40
+					}
+				}
+			}
+		}
+	}
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			if c.modules[y][x] {
+				dark++
+			}
+			if x+1 < n && y+1 < n {
+				d := c.modules[y][x]
+				if c.modules[y][x+1] == d && c.modules[y+1][x] == d && c.modules[y+1][x+1] == d {
+					result += 3
+				}
+			}
+		}
+	}
+	total := n * n
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	return result + k*10
+}
+
+// interleave splits the data into the blocks, appends the error correction
+// codewords of the blocks, and interleaves the codewords of the blocks.
+func interleave(data []byte, total, blocks, ecc int) []byte {
+	var (
+		short      = blocks - total%blocks
+		shortLen   = total/blocks - ecc
+		divisor    = rsDivisor(ecc)
+		dataBlocks = make([][]byte, blocks)
+		eccBlocks  = make([][]byte, blocks)
+	)
+	for i, k := 0, 0; i < blocks; i++ {
+		l := shortLen
+		if i >= short {
+			l++
+		}
+		dataBlocks[i] = data[k : k+l]
+		eccBlocks[i] = rsRemainder(dataBlocks[i], divisor)
+		k += l
+	}
+
+	result := make([]byte, 0, total)
+	for i := 0; i <= shortLen; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < ecc; i++ {
+		for _, block := range eccBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+// rsDivisor return the Reed-Solomon generator polynomial of the degree,
+// without the leading term.
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMul(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMul(root, 0x02)
+	}
+	return result
+}
+
+func rsRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, d := range divisor {
+			result[i] ^= gfMul(d, factor)
+		}
+	}
+	return result
+}
+
+// gfMul multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1.
+func gfMul(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x11D
+		z ^= int(y>>i&1) * int(x)
+	}
+	return byte(z)
+}
+
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*b = append(*b, value>>i&1 == 1)
+	}
+}
+
+func (b bitBuffer) bytes() []byte {
+	result := make([]byte, len(b)/8)
+	for i, bit := range b {
+		if bit {
+			result[i>>3] |= 1 << (7 - i&7)
+		}
+	}
+	return result
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
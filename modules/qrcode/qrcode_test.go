@@ -0,0 +1,143 @@
+package qrcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestRSRemainder(t *testing.T) {
+	// HELLO WORLD of the version 1-M in the alphanumeric mode.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	assert.Equal(t, rsRemainder(data, rsDivisor(10)), []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23})
+}
+
+func TestFormatAndVersionBits(t *testing.T) {
+	assert.Equal(t, formatBits(0), 0b101010000010010)
+	assert.Equal(t, formatBits(1), 0b101000100100101)
+	assert.Equal(t, versionBits(7), 0x07C94)
+	assert.Equal(t, versionBits(10), 0x0A4D3)
+}
+
+// decode reads the text of the code back, checking the format and the error
+// correction codewords.
+func decode(t *testing.T, c *Code) string {
+	version := (c.Size - 17) / 4
+	v := versions[version-1]
+
+	format := 0
+	for i := 0; i < 8; i++ {
+		if c.Dark(c.Size-1-i, 8) {
+			format |= 1 << i
+		}
+	}
+	for i := 8; i < 15; i++ {
+		if c.Dark(8, c.Size-15+i) {
+			format |= 1 << i
+		}
+	}
+	mask := -1
+	for m := 0; m < 8; m++ {
+		if formatBits(m) == format {
+			mask = m
+		}
+	}
+	if mask < 0 {
+		t.Fatalf("wrong format bits %b", format)
+	}
+
+	var bits []bool
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if !c.function[y][x] {
+					bits = append(bits, c.modules[y][x] != maskBit(mask, x, y))
+				}
+			}
+		}
+	}
+	codewords := bitBuffer(bits[:v.total*8]).bytes()
+
+	short, shortLen := v.blocks-v.total%v.blocks, v.total/v.blocks-v.ecc
+	blocks := make([][]byte, v.blocks)
+	k := 0
+	for i := 0; i <= shortLen; i++ {
+		for b := range blocks {
+			if i < shortLen || b >= short {
+				blocks[b] = append(blocks[b], codewords[k])
+				k++
+			}
+		}
+	}
+	var data []byte
+	for b := range blocks {
+		data = append(data, blocks[b]...)
+	}
+	for b := range blocks {
+		ecc := make([]byte, v.ecc)
+		for i := range ecc {
+			ecc[i] = codewords[k+b+i*v.blocks]
+		}
+		assert.Equal(t, rsRemainder(blocks[b], rsDivisor(v.ecc)), ecc)
+	}
+
+	var all bitBuffer
+	for _, b := range data {
+		all.append(int(b), 8)
+	}
+	read := func(n int) int {
+		value := 0
+		for i := 0; i < n; i++ {
+			value <<= 1
+			if all[0] {
+				value |= 1
+			}
+			all = all[1:]
+		}
+		return value
+	}
+	assert.Equal(t, read(4), 4)
+	countBits := 8
+	if version >= 10 {
+		countBits = 16
+	}
+	n := read(countBits)
+	text := make([]byte, n)
+	for i := range text {
+		text[i] = byte(read(8))
+	}
+	return string(text)
+}
+
+func TestEncode(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"hello",
+		"https://example.com/admin/qrlogin/approve?code=0123456789abcdef0123456789abcdef",
+		strings.Repeat("x", 100),
+		strings.Repeat("y", 150),
+		strings.Repeat("z", MaxLength),
+	} {
+		c, err := Encode(text)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, decode(t, c), text)
+	}
+
+	_, err := Encode(strings.Repeat("x", MaxLength+1))
+	assert.Equal(t, err, ErrTooLong)
+}
+
+func TestSVG(t *testing.T) {
+	c, _ := Encode("hello")
+	svg := c.SVG(200)
+	assert.Equal(t, c.Size, 21)
+	assert.Equal(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="200" height="200" viewBox="0 0 29 29"`), true)
+}
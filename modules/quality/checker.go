@@ -0,0 +1,105 @@
+package quality
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/mail"
+)
+
+// Checker runs the due rules over the tables of the connection.
+type Checker struct {
+	conn  db.Connection
+	store *Store
+	lock  sync.Mutex
+}
+
+// NewChecker return the checker of the rules of the connection, the rules and
+// the tables they check are in the same connection.
+func NewChecker(conn db.Connection) *Checker {
+	return &Checker{conn: conn, store: NewStore(conn)}
+}
+
+// Start check the due rules every interval in the background.
+func (c *Checker) Start(interval time.Duration) {
+	go func() {
+		for now := range time.Tick(interval) {
+			c.Tick(now)
+		}
+	}()
+}
+
+// Tick run the rules which are due at now.
+func (c *Checker) Tick(now time.Time) {
+	rules, err := c.store.Due(now)
+	if err != nil {
+		logger.Error("load due quality rules error: ", err)
+		return
+	}
+	for _, r := range rules {
+		_, _ = c.Run(r, now)
+	}
+}
+
+// Run count the bad rows of the rule and record the result, the next run
+// time is the next time of the schedule after now. The owners are notified
+// when the rule turns to exceeded. The rule with the result is returned.
+func (c *Checker) Run(r Rule, now time.Time) (Rule, error) {
+	// the ticks and the "run now" of the dashboard do not run the rules at
+	// the same time.
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	previous := r.LastStatus
+	count, err := r.Count(c.conn)
+
+	r.LastRunAt = now
+	r.LastError = ""
+	switch {
+	case err != nil:
+		r.LastStatus = StatusError
+		r.LastError = err.Error()
+		logger.Errorf("quality rule %d %s error: %v", r.ID, r.Name, err)
+	case count > r.Threshold:
+		r.LastCount, r.LastStatus = count, StatusExceeded
+	default:
+		r.LastCount, r.LastStatus = count, StatusOK
+	}
+
+	next, nextErr := r.Next(now)
+	if nextErr != nil {
+		// the schedule is validated when saved, keep the rule off the due list
+		// rather than retrying it every tick.
+		next = now.AddDate(100, 0, 0)
+	}
+	r.NextRunAt = next
+
+	if r.LastStatus == StatusExceeded && previous != StatusExceeded {
+		c.notify(r)
+	}
+
+	if finishErr := c.store.Finish(r); finishErr != nil {
+		logger.Error("record quality rule run error: ", finishErr)
+	}
+	return r, err
+}
+
+func (c *Checker) notify(r Rule) {
+	owners := r.OwnerList()
+	if len(owners) == 0 {
+		logger.Warnf("quality rule %d %s exceeds the threshold %d: %d rows", r.ID, r.Name, r.Threshold, r.LastCount)
+		return
+	}
+	err := mail.Send(mail.Message{
+		To:      owners,
+		Subject: fmt.Sprintf("[data quality] %s: %d rows", r.Name, r.LastCount),
+		Body: fmt.Sprintf("The data quality rule %q of the table %s found %d rows at %s, "+
+			"which exceeds the threshold %d.\n", r.Name, r.Table, r.LastCount, r.LastRunAt.Format(timeFormat), r.Threshold),
+	})
+	if err != nil {
+		logger.Error("notify quality rule owners error: ", err)
+	}
+}
@@ -0,0 +1,306 @@
+// Package quality provides the data quality rules, which count the bad rows
+// of the tables by a cron schedule and notify the owners when the counts
+// exceed the thresholds.
+//
+// A rule is one of the kinds:
+//
+//	null       the rows whose field is null, e.g. the orders without customer_id
+//	duplicate  the rows whose field is the same as another row, e.g. the duplicate emails
+//	condition  the rows matching the where condition, e.g. "amount < 0"
+//
+// The rules are saved in the table goadmin_quality_rules and run by the
+// checker, which is started by the quality plugin. The owners are mailed by
+// modules/mail when a rule turns to exceeded, so they are not mailed again
+// until the rule is fixed.
+package quality
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/cron"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+)
+
+// TableName is the table of the rules, see the migrations of data directory
+// for the schema.
+const TableName = "goadmin_quality_rules"
+
+const timeFormat = "2006-01-02 15:04:05"
+
+// The kinds of the rules.
+const (
+	KindNull      = "null"
+	KindDuplicate = "duplicate"
+	KindCondition = "condition"
+)
+
+// Kinds is the kinds of the rules.
+var Kinds = []string{KindNull, KindDuplicate, KindCondition}
+
+// The statuses of the rules.
+const (
+	StatusPending  = ""
+	StatusOK       = "ok"
+	StatusExceeded = "exceeded"
+	StatusError    = "error"
+)
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Rule is a data quality rule, the rule is exceeded when the count of the bad
+// rows is greater than the threshold.
+type Rule struct {
+	ID        int64
+	Name      string
+	Table     string
+	Kind      string
+	Field     string
+	Condition string
+	Threshold int64
+	Schedule  string
+	Owners    string
+
+	LastCount  int64
+	LastStatus string
+	LastError  string
+	LastRunAt  time.Time
+	NextRunAt  time.Time
+}
+
+// OwnerList return the addresses of the owners, which are separated by the
+// commas, the semicolons or the spaces.
+func (r Rule) OwnerList() []string {
+	return strings.FieldsFunc(r.Owners, func(c rune) bool {
+		return c == ',' || c == ';' || c == ' ' || c == '\n' || c == '\r' || c == '\t'
+	})
+}
+
+// Validate check the rule. The table and the field must be the identifiers,
+// the condition is the raw sql of the admins and is not checked.
+func (r Rule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("name is empty")
+	}
+	if !identifier.MatchString(r.Table) {
+		return fmt.Errorf("wrong table %s", r.Table)
+	}
+	switch r.Kind {
+	case KindNull, KindDuplicate:
+		if !identifier.MatchString(r.Field) {
+			return fmt.Errorf("wrong field %s", r.Field)
+		}
+	case KindCondition:
+		if strings.TrimSpace(r.Condition) == "" {
+			return errors.New("condition is empty")
+		}
+		if strings.Contains(r.Condition, ";") {
+			return errors.New("condition must be a single expression")
+		}
+	default:
+		return fmt.Errorf("wrong kind %s", r.Kind)
+	}
+	if r.Threshold < 0 {
+		return errors.New("threshold is negative")
+	}
+	if _, err := cron.Parse(r.Schedule); err != nil {
+		return err
+	}
+	for _, owner := range r.OwnerList() {
+		if !strings.Contains(owner, "@") {
+			return fmt.Errorf("wrong owner %s", owner)
+		}
+	}
+	return nil
+}
+
+// Next return the next run time of the rule after t.
+func (r Rule) Next(t time.Time) (time.Time, error) {
+	schedule, err := cron.Parse(r.Schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(t), nil
+}
+
+// Count count the bad rows of the rule.
+func (r Rule) Count(conn db.Connection) (int64, error) {
+	if err := r.Validate(); err != nil {
+		return 0, err
+	}
+
+	var (
+		wrap = func(name string) string {
+			return conn.GetDelimiter() + name + conn.GetDelimiter2()
+		}
+		table = wrap(r.Table)
+		query string
+	)
+
+	switch r.Kind {
+	case KindNull:
+		field := wrap(r.Field)
+		query = "select count(*) as n from " + table + " where " + field + " is null"
+	case KindDuplicate:
+		field := wrap(r.Field)
+		query = "select count(*) as n from " + table + " where " + field + " in (select " + field + " from " +
+			table + " where " + field + " is not null group by " + field + " having count(*) > 1)"
+	case KindCondition:
+		query = "select count(*) as n from " + table + " where (" + r.Condition + ")"
+	}
+
+	rows, err := conn.Query(query)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return db.GetValueFromDatabaseType(db.Int, rows[0]["n"], false).ToInt64(), nil
+}
+
+// Store saves the rules.
+type Store struct {
+	conn db.Connection
+}
+
+// NewStore return the store of the connection.
+func NewStore(conn db.Connection) *Store {
+	return &Store{conn: conn}
+}
+
+func (s *Store) table() *db.SQL {
+	return db.WithDriver(s.conn).Table(TableName)
+}
+
+// Add validate and save the rule, the first run is the next time of the
+// schedule after now.
+func (s *Store) Add(r Rule, now time.Time) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	next, err := r.Next(now)
+	if err != nil {
+		return err
+	}
+	_, err = s.table().Insert(dialect.H{
+		"name":        strings.TrimSpace(r.Name),
+		"table_name":  r.Table,
+		"kind":        r.Kind,
+		"field":       r.Field,
+		"expression":  r.Condition,
+		"threshold":   r.Threshold,
+		"schedule":    r.Schedule,
+		"owners":      strings.Join(r.OwnerList(), ", "),
+		"last_count":  0,
+		"last_status": StatusPending,
+		"last_error":  "",
+		"next_run_at": next.Format(timeFormat),
+		"created_at":  now.Format(timeFormat),
+	})
+	if db.CheckError(err, db.INSERT) {
+		return err
+	}
+	return nil
+}
+
+// Get return the rule of the id and whether it exists.
+func (s *Store) Get(id int64) (Rule, bool, error) {
+	row, err := s.table().Where("id", "=", id).First()
+	if db.CheckError(err, db.QUERY) {
+		return Rule{}, false, err
+	}
+	if row == nil {
+		return Rule{}, false, nil
+	}
+	return toRule(row), true, nil
+}
+
+// List return all the rules in the order of the creation.
+func (s *Store) List() ([]Rule, error) {
+	rows, err := s.table().OrderBy("id", "asc").All()
+	if err != nil {
+		return nil, err
+	}
+	return toRules(rows), nil
+}
+
+// Due return the rules whose next run time is not after now.
+func (s *Store) Due(now time.Time) ([]Rule, error) {
+	rows, err := s.table().Where("next_run_at", "<=", now.Format(timeFormat)).OrderBy("next_run_at", "asc").All()
+	if err != nil {
+		return nil, err
+	}
+	return toRules(rows), nil
+}
+
+// Delete delete the rule.
+func (s *Store) Delete(id int64) error {
+	err := s.table().Where("id", "=", id).Delete()
+	if db.CheckError(err, db.DELETE) {
+		return err
+	}
+	return nil
+}
+
+// Finish record the result of the run of the rule.
+func (s *Store) Finish(r Rule) error {
+	msg := r.LastError
+	if len(msg) > 255 {
+		msg = msg[:255]
+	}
+	_, err := s.table().Where("id", "=", r.ID).Update(dialect.H{
+		"last_count":  r.LastCount,
+		"last_status": r.LastStatus,
+		"last_error":  msg,
+		"last_run_at": r.LastRunAt.Format(timeFormat),
+		"next_run_at": r.NextRunAt.Format(timeFormat),
+	})
+	if db.CheckError(err, db.UPDATE) {
+		return err
+	}
+	return nil
+}
+
+func toRules(rows []map[string]interface{}) []Rule {
+	rules := make([]Rule, len(rows))
+	for i, row := range rows {
+		rules[i] = toRule(row)
+	}
+	return rules
+}
+
+func toRule(row map[string]interface{}) Rule {
+	str := func(key string) string {
+		if row[key] == nil {
+			return ""
+		}
+		return db.GetValueFromDatabaseType(db.Varchar, row[key], false).String()
+	}
+	num := func(key string) int64 {
+		if row[key] == nil {
+			return 0
+		}
+		return db.GetValueFromDatabaseType(db.Int, row[key], false).ToInt64()
+	}
+	return Rule{
+		ID:         num("id"),
+		Name:       str("name"),
+		Table:      str("table_name"),
+		Kind:       str("kind"),
+		Field:      str("field"),
+		Condition:  str("expression"),
+		Threshold:  num("threshold"),
+		Schedule:   str("schedule"),
+		Owners:     str("owners"),
+		LastCount:  num("last_count"),
+		LastStatus: str("last_status"),
+		LastError:  str("last_error"),
+		LastRunAt:  db.ParseTime(row["last_run_at"]),
+		NextRunAt:  db.ParseTime(row["next_run_at"]),
+	}
+}
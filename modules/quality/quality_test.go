@@ -0,0 +1,102 @@
+package quality
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	_ "github.com/purpose168/GoAdmin/modules/db/drivers/sqlite"
+	"github.com/purpose168/GoAdmin/modules/mail"
+)
+
+type outbox struct {
+	sent []mail.Message
+}
+
+func (o *outbox) Send(msg mail.Message) error {
+	o.sent = append(o.sent, msg)
+	return nil
+}
+
+func TestChecker(t *testing.T) {
+	config.Initialize(&config.Config{UrlPrefix: "admin"})
+
+	conn := db.GetSqliteDB()
+	conn.InitDB(config.DatabaseList{"default": {
+		Driver: db.DriverSqlite,
+		File:   filepath.Join(t.TempDir(), "quality.db"),
+	}})
+	migration, err := os.ReadFile("../../data/migrations/admin_2026_10_24_000000_sqlite.sql")
+	assert.Equal(t, err, nil)
+	for _, statement := range []string{
+		string(migration),
+		"create table orders (id integer primary key autoincrement, customer_id integer, email text, amount integer)",
+		`insert into orders (customer_id, email, amount) values (1, 'a@example.com', 10), (null, 'b@example.com', 5),
+			(null, 'a@example.com', -1), (2, 'c@example.com', 3)`,
+	} {
+		_, err := conn.Exec(statement)
+		assert.Equal(t, err, nil)
+	}
+
+	box := new(outbox)
+	mail.Use(box)
+	defer mail.Use(nil)
+
+	count := func(r Rule) int64 {
+		n, err := r.Count(conn)
+		assert.Equal(t, err, nil)
+		return n
+	}
+	assert.Equal(t, count(Rule{Name: "n", Table: "orders", Kind: KindNull, Field: "customer_id", Schedule: "* * * * *"}), int64(2))
+	assert.Equal(t, count(Rule{Name: "d", Table: "orders", Kind: KindDuplicate, Field: "email", Schedule: "* * * * *"}), int64(2))
+	assert.Equal(t, count(Rule{Name: "c", Table: "orders", Kind: KindCondition, Condition: "amount < 0", Schedule: "* * * * *"}), int64(1))
+
+	// the identifiers and the single expression are checked.
+	_, err = Rule{Name: "x", Table: "orders; drop table orders", Kind: KindNull, Field: "id", Schedule: "* * * * *"}.Count(conn)
+	assert.Equal(t, err != nil, true)
+	_, err = Rule{Name: "x", Table: "orders", Kind: KindCondition, Condition: "1 = 1; delete from orders", Schedule: "* * * * *"}.Count(conn)
+	assert.Equal(t, err != nil, true)
+
+	store := NewStore(conn)
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.Local)
+	assert.Equal(t, store.Add(Rule{Name: "orders without customer", Table: "orders", Kind: KindNull, Field: "customer_id",
+		Threshold: 1, Schedule: "0 * * * *", Owners: "owner@example.com"}, now), nil)
+	assert.Equal(t, store.Add(Rule{Name: "bad expression", Table: "orders", Kind: KindCondition, Condition: "missing > 0",
+		Schedule: "0 * * * *"}, now), nil)
+
+	rules, err := store.List()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(rules), 2)
+	assert.Equal(t, rules[0].NextRunAt.Format(timeFormat), "2026-10-19 09:00:00")
+
+	checker := NewChecker(conn)
+	checker.Tick(time.Date(2026, 10, 19, 9, 0, 0, 0, time.Local))
+
+	rules, _ = store.List()
+	assert.Equal(t, rules[0].LastStatus, StatusExceeded)
+	assert.Equal(t, rules[0].LastCount, int64(2))
+	assert.Equal(t, rules[0].NextRunAt.Format(timeFormat), "2026-10-19 10:00:00")
+	assert.Equal(t, rules[1].LastStatus, StatusError)
+	assert.Equal(t, rules[1].LastError != "", true)
+	assert.Equal(t, len(box.sent), 1)
+	assert.Equal(t, box.sent[0].To, []string{"owner@example.com"})
+
+	// the owners are not mailed again until the rule is fixed.
+	r, _ := checker.Run(rules[0], time.Date(2026, 10, 19, 10, 0, 0, 0, time.Local))
+	assert.Equal(t, r.LastStatus, StatusExceeded)
+	assert.Equal(t, len(box.sent), 1)
+
+	_, err = conn.Exec("update orders set customer_id = 3 where customer_id is null")
+	assert.Equal(t, err, nil)
+	r, _ = checker.Run(r, time.Date(2026, 10, 19, 11, 0, 0, 0, time.Local))
+	assert.Equal(t, r.LastStatus, StatusOK)
+	assert.Equal(t, r.LastCount, int64(0))
+
+	assert.Equal(t, store.Delete(r.ID), nil)
+	_, ok, _ := store.Get(r.ID)
+	assert.Equal(t, ok, false)
+}
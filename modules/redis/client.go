@@ -0,0 +1,122 @@
+//go:build !goadmin_noredis
+
+package redis
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Nil is returned by the client when the key does not exist.
+const Nil = goredis.Nil
+
+// Service holds the shared redis client.
+type Service struct {
+	client goredis.UniversalClient
+	prefix string
+}
+
+// NewService create the client of the config and check the connection. A
+// failover client is created when MasterName is set, a cluster client when
+// Cluster is true, otherwise a client of the first address.
+func NewService(cfg config.Redis) (*Service, error) {
+	opts := &goredis.UniversalOptions{
+		Addrs:        cfg.Addrs,
+		MasterName:   cfg.MasterName,
+		Username:     cfg.Username,
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		PoolSize:     cfg.PoolSize,
+		MinIdleConns: cfg.MinIdleConns,
+	}
+	if cfg.TLS {
+		opts.TLSConfig = &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify}
+	}
+
+	var client goredis.UniversalClient
+	switch {
+	case cfg.MasterName != "":
+		client = goredis.NewFailoverClient(opts.Failover())
+	case cfg.Cluster:
+		client = goredis.NewClusterClient(opts.Cluster())
+	default:
+		client = goredis.NewClient(opts.Simple())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	return &Service{client: client, prefix: cfg.KeyPrefix}, nil
+}
+
+// NewServiceWithClient wrap an existing client as the service.
+func NewServiceWithClient(client goredis.UniversalClient, prefix string) *Service {
+	return &Service{client: client, prefix: prefix}
+}
+
+// Client return the shared client, which is not available when the client is
+// excluded by the goadmin_noredis build tag, so the features of GoAdmin use
+// the methods of the service instead.
+func (s *Service) Client() goredis.UniversalClient {
+	return s.client
+}
+
+// Get return the value of the key, it returns Nil when the key does not exist.
+func (s *Service) Get(ctx context.Context, key string) ([]byte, error) {
+	return s.client.Get(ctx, key).Bytes()
+}
+
+// MGet return the values of the keys, the value of a missing key is nil.
+func (s *Service) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
+	return s.client.MGet(ctx, keys...).Result()
+}
+
+// Set set the value of the key which expires after the ttl, it never expires
+// when the ttl is 0.
+func (s *Service) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return s.client.Set(ctx, key, value, ttl).Err()
+}
+
+// Del delete the keys.
+func (s *Service) Del(ctx context.Context, keys ...string) error {
+	return s.client.Del(ctx, keys...).Err()
+}
+
+// Close the client.
+func (s *Service) Close() error {
+	return s.client.Close()
+}
+
+var unlockScript = goredis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0`)
+
+// Lock obtain the lock of the key which expires after the ttl, it returns
+// ErrLockNotObtained when the lock is held by others.
+func (s *Service) Lock(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	l := &Lock{s: s, key: s.Key("lock", key), token: utils.Uuid(16)}
+	ok, err := s.client.SetNX(ctx, l.key, l.token, ttl).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrLockNotObtained
+	}
+	return l, nil
+}
+
+// Unlock release the lock if it is still held.
+func (l *Lock) Unlock(ctx context.Context) error {
+	return unlockScript.Run(ctx, l.s.client, []string{l.key}, l.token).Err()
+}
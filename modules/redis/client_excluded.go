@@ -0,0 +1,60 @@
+//go:build goadmin_noredis
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+)
+
+// Nil is returned when the key does not exist.
+var Nil = errors.New("redis: nil")
+
+// Service is the redis service, which has no client as it is excluded by the
+// goadmin_noredis build tag.
+type Service struct {
+	prefix string
+}
+
+// NewService return ErrExcluded.
+func NewService(config.Redis) (*Service, error) {
+	return nil, ErrExcluded
+}
+
+// Get return ErrExcluded.
+func (s *Service) Get(context.Context, string) ([]byte, error) {
+	return nil, ErrExcluded
+}
+
+// MGet return ErrExcluded.
+func (s *Service) MGet(context.Context, ...string) ([]interface{}, error) {
+	return nil, ErrExcluded
+}
+
+// Set return ErrExcluded.
+func (s *Service) Set(context.Context, string, interface{}, time.Duration) error {
+	return ErrExcluded
+}
+
+// Del return ErrExcluded.
+func (s *Service) Del(context.Context, ...string) error {
+	return ErrExcluded
+}
+
+// Close do nothing.
+func (s *Service) Close() error {
+	return nil
+}
+
+// Lock return ErrExcluded.
+func (s *Service) Lock(context.Context, string, time.Duration) (*Lock, error) {
+	return nil, ErrExcluded
+}
+
+// Unlock return ErrExcluded.
+func (l *Lock) Unlock(context.Context) error {
+	return ErrExcluded
+}
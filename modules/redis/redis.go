@@ -0,0 +1,66 @@
+// Package redis provides the redis client shared by the engine and plugins.
+//
+// The client is created from the redis config and added into the service list
+// by the engine. The engine then stores the sessions and the html cache in it,
+// and the locks and custom plugins use the same configured redis instead of
+// wiring their own.
+//
+// The client is excluded by the goadmin_noredis build tag, NewService then
+// returns ErrExcluded and go-redis is not compiled in.
+package redis
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/modules/service"
+)
+
+// ServiceKey is the key of the redis service.
+const ServiceKey = "redis"
+
+// ErrLockNotObtained is returned when the lock is held by others.
+var ErrLockNotObtained = errors.New("redis: lock not obtained")
+
+// ErrExcluded is returned when the client is excluded by the goadmin_noredis
+// build tag.
+var ErrExcluded = errors.New("redis: excluded by the goadmin_noredis build tag")
+
+// Name implements service.Service.Name.
+func (s *Service) Name() string {
+	return ServiceKey
+}
+
+// GetService return the redis service of the list.
+func GetService(srv service.List) *Service {
+	if v, ok := srv.Get(ServiceKey).(*Service); ok {
+		return v
+	}
+	panic("wrong service")
+}
+
+// GetServiceOrNot return the redis service of the list and whether it exists.
+func GetServiceOrNot(srv service.List) (*Service, bool) {
+	if v, ok := srv.GetOrNot(ServiceKey); ok {
+		s, ok := v.(*Service)
+		return s, ok
+	}
+	return nil, false
+}
+
+// Key join the parts with ":" and add the key prefix of the config. Plugins
+// should use their names as the first part to avoid conflicts.
+func (s *Service) Key(parts ...string) string {
+	key := strings.Join(parts, ":")
+	if s.prefix == "" {
+		return key
+	}
+	return s.prefix + ":" + key
+}
+
+// Lock is a distributed lock held by the service.
+type Lock struct {
+	s     *Service
+	key   string
+	token string
+}
@@ -0,0 +1,23 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/modules/service"
+)
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, (&Service{}).Key("lock", "menu"), "lock:menu")
+	assert.Equal(t, (&Service{prefix: "goadmin"}).Key("lock", "menu"), "goadmin:lock:menu")
+}
+
+func TestGetServiceOrNot(t *testing.T) {
+	list := make(service.List)
+	_, ok := GetServiceOrNot(list)
+	assert.Equal(t, ok, false)
+
+	list.Add(ServiceKey, &Service{})
+	_, ok = GetServiceOrNot(list)
+	assert.Equal(t, ok, true)
+}
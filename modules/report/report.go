@@ -0,0 +1,209 @@
+// Package report provides the scheduled reports, which mail a view of a table
+// as xlsx to the recipients by a cron schedule, such as "every Monday 8:00".
+//
+// The view is the prefix of the table and the query of the list page, so the
+// filters, the sort and the columns chosen in the list page are kept. The
+// reports are saved in the table goadmin_reports and run by the scheduler,
+// which is started by the admin plugin when the mails are configured, see
+// config.Mail. The failed reports are recorded and notified to the recipients.
+package report
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/cron"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+)
+
+// TableName is the table of the reports, see the migrations of data directory
+// for the schema.
+const TableName = "goadmin_reports"
+
+const timeFormat = "2006-01-02 15:04:05"
+
+// Report is a scheduled report of a user.
+type Report struct {
+	ID         int64
+	UserID     int64
+	Name       string
+	Prefix     string
+	Query      string
+	Schedule   string
+	Recipients string
+	LastRunAt  time.Time
+	NextRunAt  time.Time
+	LastError  string
+}
+
+// RecipientList return the addresses of the recipients, which are separated
+// by the commas, the semicolons or the spaces.
+func (r Report) RecipientList() []string {
+	return strings.FieldsFunc(r.Recipients, func(c rune) bool {
+		return c == ',' || c == ';' || c == ' ' || c == '\n' || c == '\r' || c == '\t'
+	})
+}
+
+// Validate check the name, the schedule and the recipients of the report.
+func (r Report) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("name is empty")
+	}
+	if r.Prefix == "" {
+		return errors.New("table is empty")
+	}
+	if _, err := cron.Parse(r.Schedule); err != nil {
+		return err
+	}
+	recipients := r.RecipientList()
+	if len(recipients) == 0 {
+		return errors.New("recipients are empty")
+	}
+	for _, recipient := range recipients {
+		if !strings.Contains(recipient, "@") {
+			return fmt.Errorf("wrong recipient %s", recipient)
+		}
+	}
+	return nil
+}
+
+// Next return the next run time of the report after t.
+func (r Report) Next(t time.Time) (time.Time, error) {
+	schedule, err := cron.Parse(r.Schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(t), nil
+}
+
+// Store saves the reports.
+type Store struct {
+	conn db.Connection
+}
+
+// NewStore return the store of the connection.
+func NewStore(conn db.Connection) *Store {
+	return &Store{conn: conn}
+}
+
+func (s *Store) table() *db.SQL {
+	return db.WithDriver(s.conn).Table(TableName)
+}
+
+// Add validate and save the report, the first run is the next time of the
+// schedule after now.
+func (s *Store) Add(r Report, now time.Time) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	next, err := r.Next(now)
+	if err != nil {
+		return err
+	}
+	_, err = s.table().Insert(dialect.H{
+		"user_id":     r.UserID,
+		"name":        strings.TrimSpace(r.Name),
+		"prefix":      r.Prefix,
+		"query":       r.Query,
+		"schedule":    r.Schedule,
+		"recipients":  strings.Join(r.RecipientList(), ", "),
+		"next_run_at": next.Format(timeFormat),
+		"last_error":  "",
+		"created_at":  now.Format(timeFormat),
+	})
+	if db.CheckError(err, db.INSERT) {
+		return err
+	}
+	return nil
+}
+
+// Get return the report of the id and whether it exists.
+func (s *Store) Get(id int64) (Report, bool, error) {
+	row, err := s.table().Where("id", "=", id).First()
+	if db.CheckError(err, db.QUERY) {
+		return Report{}, false, err
+	}
+	if row == nil {
+		return Report{}, false, nil
+	}
+	return toReport(row), true, nil
+}
+
+// List return the reports of the user, the latest first.
+func (s *Store) List(userID int64) ([]Report, error) {
+	rows, err := s.table().Where("user_id", "=", userID).OrderBy("id", "desc").All()
+	if err != nil {
+		return nil, err
+	}
+	return toReports(rows), nil
+}
+
+// Due return the reports whose next run time is not after now.
+func (s *Store) Due(now time.Time) ([]Report, error) {
+	rows, err := s.table().Where("next_run_at", "<=", now.Format(timeFormat)).OrderBy("next_run_at", "asc").All()
+	if err != nil {
+		return nil, err
+	}
+	return toReports(rows), nil
+}
+
+// Delete delete the report of the user.
+func (s *Store) Delete(userID, id int64) error {
+	err := s.table().Where("user_id", "=", userID).Where("id", "=", id).Delete()
+	if db.CheckError(err, db.DELETE) {
+		return err
+	}
+	return nil
+}
+
+// Finish record the run of the report, the error is cleared when runErr is nil.
+func (s *Store) Finish(id int64, ranAt, next time.Time, runErr error) error {
+	msg := ""
+	if runErr != nil {
+		msg = runErr.Error()
+		if len(msg) > 255 {
+			msg = msg[:255]
+		}
+	}
+	_, err := s.table().Where("id", "=", id).Update(dialect.H{
+		"last_run_at": ranAt.Format(timeFormat),
+		"next_run_at": next.Format(timeFormat),
+		"last_error":  msg,
+	})
+	if db.CheckError(err, db.UPDATE) {
+		return err
+	}
+	return nil
+}
+
+func toReports(rows []map[string]interface{}) []Report {
+	reports := make([]Report, len(rows))
+	for i, row := range rows {
+		reports[i] = toReport(row)
+	}
+	return reports
+}
+
+func toReport(row map[string]interface{}) Report {
+	str := func(key string) string {
+		if row[key] == nil {
+			return ""
+		}
+		return db.GetValueFromDatabaseType(db.Varchar, row[key], false).String()
+	}
+	return Report{
+		ID:         db.GetValueFromDatabaseType(db.Int, row["id"], false).ToInt64(),
+		UserID:     db.GetValueFromDatabaseType(db.Int, row["user_id"], false).ToInt64(),
+		Name:       str("name"),
+		Prefix:     str("prefix"),
+		Query:      str("query"),
+		Schedule:   str("schedule"),
+		Recipients: str("recipients"),
+		LastRunAt:  db.ParseTime(row["last_run_at"]),
+		NextRunAt:  db.ParseTime(row["next_run_at"]),
+		LastError:  str("last_error"),
+	}
+}
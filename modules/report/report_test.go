@@ -0,0 +1,103 @@
+package report
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	_ "github.com/purpose168/GoAdmin/modules/db/drivers/sqlite"
+	"github.com/purpose168/GoAdmin/modules/mail"
+)
+
+type outbox struct {
+	sent []mail.Message
+}
+
+func (o *outbox) Send(msg mail.Message) error {
+	o.sent = append(o.sent, msg)
+	return nil
+}
+
+func TestScheduler(t *testing.T) {
+	config.Initialize(&config.Config{UrlPrefix: "admin"})
+
+	conn := db.GetSqliteDB()
+	conn.InitDB(config.DatabaseList{"default": {
+		Driver: db.DriverSqlite,
+		File:   filepath.Join(t.TempDir(), "reports.db"),
+	}})
+	migration, err := os.ReadFile("../../data/migrations/admin_2026_10_23_000000_sqlite.sql")
+	assert.Equal(t, err, nil)
+	_, err = conn.Exec(string(migration))
+	assert.Equal(t, err, nil)
+
+	box := new(outbox)
+	mail.Use(box)
+	defer mail.Use(nil)
+
+	store := NewStore(conn)
+	// Sunday, the first run is Monday 8:00.
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.Local)
+
+	assert.Equal(t, store.Add(Report{UserID: 1, Name: "users", Prefix: "user", Schedule: "0 8 * * 1"}, now) != nil, true)
+	assert.Equal(t, store.Add(Report{UserID: 1, Name: "users", Prefix: "user", Schedule: "bad",
+		Recipients: "a@example.com"}, now) != nil, true)
+	assert.Equal(t, store.Add(Report{UserID: 1, Name: "users", Prefix: "user", Schedule: "0 8 * * 1",
+		Query: "__sort=id", Recipients: "a@example.com; b@example.com"}, now), nil)
+	assert.Equal(t, store.Add(Report{UserID: 2, Name: "posts", Prefix: "posts", Schedule: "0 9 * * *",
+		Recipients: "c@example.com"}, now), nil)
+
+	reports, err := store.List(1)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(reports), 1)
+	assert.Equal(t, reports[0].Recipients, "a@example.com, b@example.com")
+	assert.Equal(t, reports[0].NextRunAt.Format(timeFormat), "2026-10-19 08:00:00")
+
+	failed := false
+	s := NewScheduler(conn, func(r Report) (mail.Attachment, error) {
+		if failed {
+			return mail.Attachment{}, errors.New("table not found")
+		}
+		return mail.Attachment{Name: r.Prefix + ".xlsx", Data: []byte(r.Query)}, nil
+	})
+
+	due, err := store.Due(now)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(due), 0)
+
+	// the posts are run at 9:00 and the users are not due until Monday.
+	s.Tick(time.Date(2026, 10, 19, 8, 30, 0, 0, time.Local))
+	assert.Equal(t, len(box.sent), 1)
+	assert.Equal(t, box.sent[0].To, []string{"a@example.com", "b@example.com"})
+	assert.Equal(t, box.sent[0].Attachments[0].Name, "user.xlsx")
+	assert.Equal(t, string(box.sent[0].Attachments[0].Data), "__sort=id")
+
+	r, ok, err := store.Get(reports[0].ID)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, r.LastRunAt.Format(timeFormat), "2026-10-19 08:30:00")
+	assert.Equal(t, r.NextRunAt.Format(timeFormat), "2026-10-26 08:00:00")
+
+	// the failure is recorded and notified.
+	failed = true
+	assert.Equal(t, s.Run(r, time.Date(2026, 10, 20, 10, 0, 0, 0, time.Local)) != nil, true)
+	assert.Equal(t, len(box.sent), 2)
+	assert.Equal(t, box.sent[1].Subject, "[failed] users")
+	assert.Equal(t, len(box.sent[1].Attachments), 0)
+	r, _, _ = store.Get(r.ID)
+	assert.Equal(t, r.LastError, "table not found")
+	assert.Equal(t, r.NextRunAt.Format(timeFormat), "2026-10-26 08:00:00")
+
+	// only the report of the user is deleted.
+	assert.Equal(t, store.Delete(2, r.ID), nil)
+	_, ok, _ = store.Get(r.ID)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, store.Delete(1, r.ID), nil)
+	_, ok, _ = store.Get(r.ID)
+	assert.Equal(t, ok, false)
+}
@@ -0,0 +1,99 @@
+package report
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/mail"
+)
+
+// ExportFunc export the view of the report as the attachment of the mail.
+type ExportFunc func(r Report) (mail.Attachment, error)
+
+// Scheduler runs the due reports, the view of a report is exported by the
+// export function and mailed to the recipients.
+type Scheduler struct {
+	store  *Store
+	export ExportFunc
+	lock   sync.Mutex
+}
+
+// NewScheduler return the scheduler of the reports of the connection.
+func NewScheduler(conn db.Connection, export ExportFunc) *Scheduler {
+	return &Scheduler{store: NewStore(conn), export: export}
+}
+
+// Start check the due reports every interval in the background.
+func (s *Scheduler) Start(interval time.Duration) {
+	go func() {
+		for now := range time.Tick(interval) {
+			s.Tick(now)
+		}
+	}()
+}
+
+// Tick run the reports which are due at now.
+func (s *Scheduler) Tick(now time.Time) {
+	reports, err := s.store.Due(now)
+	if err != nil {
+		logger.Error("load due reports error: ", err)
+		return
+	}
+	for _, r := range reports {
+		_ = s.Run(r, now)
+	}
+}
+
+// Run export and mail the report, and record the run. The next run time is
+// the next time of the schedule after now. The recipients are notified when
+// the report fails.
+func (s *Scheduler) Run(r Report, now time.Time) error {
+	// the ticks and the "run now" of the page do not run the reports at the
+	// same time.
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	err := s.send(r)
+	if err != nil {
+		logger.Errorf("report %d %s error: %v", r.ID, r.Name, err)
+		notifyErr := mail.Send(mail.Message{
+			To:      r.RecipientList(),
+			Subject: fmt.Sprintf("[failed] %s", r.Name),
+			Body: fmt.Sprintf("The scheduled report %q of %s failed at %s:\n\n%v\n",
+				r.Name, r.Prefix, now.Format(timeFormat), err),
+		})
+		if notifyErr != nil {
+			logger.Error("notify report failure error: ", notifyErr)
+		}
+	}
+
+	next, nextErr := r.Next(now)
+	if nextErr != nil {
+		// the schedule is validated when saved, keep the report off the due
+		// list rather than retrying it every tick.
+		next = now.AddDate(100, 0, 0)
+		if err == nil {
+			err = nextErr
+		}
+	}
+	if finishErr := s.store.Finish(r.ID, now, next, err); finishErr != nil {
+		logger.Error("record report run error: ", finishErr)
+	}
+	return err
+}
+
+func (s *Scheduler) send(r Report) error {
+	attachment, err := s.export(r)
+	if err != nil {
+		return err
+	}
+	return mail.Send(mail.Message{
+		To:          r.RecipientList(),
+		Subject:     r.Name,
+		Body:        fmt.Sprintf("The scheduled report %q of %s is attached.\n", r.Name, r.Prefix),
+		Attachments: []mail.Attachment{attachment},
+	})
+}
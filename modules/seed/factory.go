@@ -0,0 +1,327 @@
+package seed
+
+import (
+	dbsql "database/sql"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	"github.com/purpose168/GoAdmin/template/types"
+	"github.com/purpose168/GoAdmin/template/types/form"
+)
+
+// ValueFn returns the value of a field of the i-th generated row.
+type ValueFn func(i int, r *rand.Rand) interface{}
+
+// Factory produces the random rows of the table of a generator, the values
+// follow the new form of the generator: the database types and the form types
+// of the fields, the options of the selections and the existing values of the
+// option tables as the foreign keys. The columns missing in the table and the
+// auto increment primary key are left out.
+//
+//	err := seed.NewFactory(GetUserTable).
+//		Set("role", seed.Const("guest")).
+//		Insert(conn, nil, 1000)
+type Factory struct {
+	gen    table.Generator
+	rand   *rand.Rand
+	values map[string]ValueFn
+	skips  map[string]bool
+}
+
+// NewFactory returns the factory of the rows of the table of the generator.
+func NewFactory(gen table.Generator) *Factory {
+	return &Factory{
+		gen:    gen,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		values: make(map[string]ValueFn),
+		skips:  make(map[string]bool),
+	}
+}
+
+// Seed makes the generated rows reproducible.
+func (f *Factory) Seed(seed int64) *Factory {
+	f.rand = rand.New(rand.NewSource(seed))
+	return f
+}
+
+// Set sets the value function of the field instead of the random value.
+func (f *Factory) Set(field string, fn ValueFn) *Factory {
+	f.values[field] = fn
+	return f
+}
+
+// Skip leaves out the fields, their columns get the default of the table.
+func (f *Factory) Skip(fields ...string) *Factory {
+	for _, field := range fields {
+		f.skips[field] = true
+	}
+	return f
+}
+
+// Const returns the value function of a constant value.
+func Const(value interface{}) ValueFn {
+	return func(int, *rand.Rand) interface{} { return value }
+}
+
+// Rows returns n random rows of the table of the generator, the columns and
+// the foreign keys are read in the transaction tx which can be nil.
+func (f *Factory) Rows(conn db.Connection, tx *dbsql.Tx, n int) ([]dialect.H, error) {
+	_, rows, err := f.rows(conn, tx, n)
+	return rows, err
+}
+
+// Insert inserts n random rows into the table of the generator.
+func (f *Factory) Insert(conn db.Connection, tx *dbsql.Tx, n int) error {
+	name, rows, err := f.rows(conn, tx, n)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		_, err := db.WithDriver(conn).WithTx(tx).Table(name).Insert(row)
+		if db.CheckError(err, db.INSERT) {
+			return fmt.Errorf("insert into %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// RegisterFactory register the seeder inserting n random rows of the factory.
+func RegisterFactory(name, description string, factory *Factory, n int) *Seeder {
+	return Register(name, description, func(conn db.Connection, tx *dbsql.Tx) error {
+		return factory.Insert(conn, tx, n)
+	})
+}
+
+func (f *Factory) rows(conn db.Connection, tx *dbsql.Tx, n int) (string, []dialect.H, error) {
+	tb := f.gen(context.NewContext(&http.Request{URL: &url.URL{}, Header: make(http.Header)}))
+	panel := tb.GetActualNewForm()
+	if panel.Table == "" {
+		return "", nil, errors.New("factory: the form has no table")
+	}
+
+	columns, err := f.columns(conn, tx, panel.Table)
+	if err != nil {
+		return "", nil, fmt.Errorf("factory: columns of %s: %v", panel.Table, err)
+	}
+
+	pk := tb.GetPrimaryKey()
+	fields := make([]types.FormField, 0, len(panel.FieldList))
+	keys := make(map[string][]string)
+	for _, field := range panel.FieldList {
+		if !columns[field.Field] || f.skips[field.Field] || (field.NotAllowAdd && field.GenerateFn == nil) {
+			continue
+		}
+		if _, ok := f.values[field.Field]; ok {
+			fields = append(fields, field)
+			continue
+		}
+		if field.Field == pk.Name && db.Contains(field.TypeName, db.IntTypeList) {
+			continue
+		}
+		if len(field.Options) == 0 && field.OptionTable.Table != "" {
+			values, err := f.references(conn, tx, field.OptionTable)
+			if err != nil {
+				return "", nil, fmt.Errorf("factory: references of %s: %v", field.Field, err)
+			}
+			if len(values) == 0 {
+				if field.Must {
+					return "", nil, fmt.Errorf("factory: %s references the empty table %s",
+						field.Field, field.OptionTable.Table)
+				}
+				continue
+			}
+			keys[field.Field] = values
+		}
+		fields = append(fields, field)
+	}
+
+	rows := make([]dialect.H, n)
+	for i := 0; i < n; i++ {
+		row := make(dialect.H, len(fields))
+		for _, field := range fields {
+			if fn, ok := f.values[field.Field]; ok {
+				row[field.Field] = fn(i, f.rand)
+				continue
+			}
+			value, err := f.value(i, field, keys[field.Field])
+			if err != nil {
+				return "", nil, fmt.Errorf("factory: %s: %v", field.Field, err)
+			}
+			row[field.Field] = value
+		}
+		rows[i] = row
+	}
+	return panel.Table, rows, nil
+}
+
+func (f *Factory) columns(conn db.Connection, tx *dbsql.Tx, name string) (map[string]bool, error) {
+	items, err := conn.QueryWith(tx, "default", dialect.GetDialectByDriver(conn.Name()).ShowColumns(name))
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]bool, len(items))
+	for _, item := range items {
+		for _, key := range []string{"column_name", "COLUMN_NAME", "Field", "name"} {
+			if v, ok := item[key]; ok && v != nil {
+				columns[db.GetValueFromDatabaseType(db.Varchar, v, false).String()] = true
+				break
+			}
+		}
+	}
+	if len(columns) == 0 {
+		return nil, errors.New("table not found")
+	}
+	return columns, nil
+}
+
+func (f *Factory) references(conn db.Connection, tx *dbsql.Tx, ot types.OptionTable) ([]string, error) {
+	field := ot.ValueField
+	if field == "" {
+		field = "id"
+	}
+	sql := db.WithDriver(conn).WithTx(tx).Table(ot.Table).Select(field)
+	if ot.QueryProcessFn != nil {
+		sql = ot.QueryProcessFn(sql)
+	}
+	items, err := sql.All()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		switch v := item[field].(type) {
+		case nil:
+		case []byte:
+			values = append(values, string(v))
+		default:
+			values = append(values, fmt.Sprint(v))
+		}
+	}
+	return values, nil
+}
+
+func (f *Factory) value(i int, field types.FormField, keys []string) (interface{}, error) {
+	r := f.rand
+
+	// Keys generated by the form, as uuid primary keys, are not random of the
+	// seed.
+	if field.GenerateFn != nil {
+		return field.GenerateFn(), nil
+	}
+
+	if options := optionValues(field.Options, keys); len(options) > 0 {
+		if !field.FormType.IsMultiSelect() {
+			return options[r.Intn(len(options))], nil
+		}
+		picked := make([]string, 0)
+		for _, k := range r.Perm(len(options))[:1+r.Intn(len(options))] {
+			picked = append(picked, options[k])
+		}
+		delimiter := field.DefaultOptionDelimiter
+		if delimiter == "" {
+			delimiter = ","
+		}
+		return strings.Join(picked, delimiter), nil
+	}
+
+	typ := field.TypeName
+	switch {
+	case field.FormType == form.Switch || field.FormType == form.CheckboxSingle ||
+		db.Contains(typ, db.BoolTypeList) || typ == db.Tinyint || typ == db.Bit:
+		return r.Intn(2), nil
+	case field.FormType == form.Rate:
+		return 1 + r.Intn(5), nil
+	case field.FormType == form.Slider:
+		return r.Intn(101), nil
+	case typ == db.Year:
+		return time.Now().Year() - r.Intn(10), nil
+	case db.Contains(typ, db.IntTypeList) || db.Contains(typ, db.UintTypeList):
+		return 1 + r.Intn(1000), nil
+	case db.Contains(typ, db.FloatTypeList):
+		return fmt.Sprintf("%.2f", r.Float64()*1000), nil
+	case typ == db.Date || field.FormType.IsDate():
+		return randomTime(r).Format("2006-01-02"), nil
+	case typ == db.Time || typ == db.Timetz:
+		return randomTime(r).Format("15:04:05"), nil
+	case typ == db.Datetime || typ == db.Timestamp || typ == db.Timestamptz || field.FormType.IsDateTime():
+		return randomTime(r).Format("2006-01-02 15:04:05"), nil
+	case typ == db.JSON:
+		return "{}", nil
+	case typ == db.UUID:
+		b := make([]byte, 16)
+		r.Read(b)
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+	}
+
+	word := words[r.Intn(len(words))]
+	switch field.FormType {
+	case form.Email:
+		return fmt.Sprintf("%s%d.%d@example.com", word, i, r.Intn(10000)), nil
+	case form.Url:
+		return fmt.Sprintf("https://example.com/%s/%d", word, r.Intn(10000)), nil
+	case form.Ip:
+		return fmt.Sprintf("10.%d.%d.%d", r.Intn(256), r.Intn(256), 1+r.Intn(254)), nil
+	case form.Color:
+		return fmt.Sprintf("#%06x", r.Intn(0x1000000)), nil
+	case form.Password:
+		password := fmt.Sprintf("%s%d", word, r.Intn(10000))
+		if field.PasswordHasher != nil {
+			return field.PasswordHasher(password)
+		}
+		return password, nil
+	case form.TextArea, form.RichText, form.Code:
+		return sentence(r, 12+r.Intn(20)), nil
+	}
+
+	if typ == db.Char || typ == db.Bpchar || typ == db.Nchar {
+		return word[:1], nil
+	}
+	if field.SlugFrom != "" {
+		return fmt.Sprintf("%s-%d-%d", word, i, r.Intn(10000)), nil
+	}
+	return fmt.Sprintf("%s %d", capitalize(word), i*10000+r.Intn(10000)), nil
+}
+
+// optionValues returns the values of the options, or the existing values of
+// the option table when the options are loaded from the table.
+func optionValues(options types.FieldOptions, keys []string) []string {
+	if len(keys) > 0 {
+		return keys
+	}
+	values := make([]string, 0, len(options))
+	for _, option := range options {
+		if option.Value != "" {
+			values = append(values, option.Value)
+		}
+	}
+	return values
+}
+
+func randomTime(r *rand.Rand) time.Time {
+	return time.Now().Add(-time.Duration(r.Int63n(int64(365 * 24 * time.Hour)))).Truncate(time.Second)
+}
+
+func sentence(r *rand.Rand, n int) string {
+	list := make([]string, n)
+	for k := range list {
+		list[k] = words[r.Intn(len(words))]
+	}
+	return capitalize(strings.Join(list, " ")) + "."
+}
+
+func capitalize(s string) string {
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+var words = []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel", "india",
+	"juliet", "kilo", "lima", "mike", "november", "oscar", "papa", "quebec", "romeo", "sierra", "tango",
+	"uniform", "victor", "whiskey", "xray", "yankee", "zulu"}
@@ -0,0 +1,71 @@
+package seed
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	"github.com/purpose168/GoAdmin/template/types"
+	"github.com/purpose168/GoAdmin/template/types/form"
+)
+
+func getArticleTable(ctx *context.Context) table.Table {
+	tb := table.NewDefaultTable(ctx, table.DefaultConfigWithDriver(db.DriverSqlite))
+	formList := tb.GetForm()
+	formList.AddField("ID", "id", db.Int, form.Default).FieldNotAllowAdd()
+	formList.AddField("Title", "title", db.Varchar, form.Text).FieldMust()
+	formList.AddField("Email", "email", db.Varchar, form.Email)
+	formList.AddField("Author", "author_id", db.Int, form.SelectSingle).
+		FieldOptionsFromTable("posts", "title", "id").FieldMust()
+	formList.AddField("Status", "status", db.Varchar, form.Radio).
+		FieldOptions(types.FieldOptions{{Text: "draft", Value: "draft"}, {Text: "published", Value: "published"}})
+	formList.AddField("Price", "price", db.Decimal, form.Currency)
+	formList.AddField("Published at", "published_at", db.Datetime, form.Datetime)
+	formList.AddField("Confirm", "confirm", db.Varchar, form.Text)
+	formList.SetTable("articles")
+	return tb
+}
+
+func TestFactory(t *testing.T) {
+	conn := newConn(t)
+	_, err := conn.Exec(`create table articles (id integer primary key autoincrement, title varchar(100) not null,
+		email varchar(100), author_id integer not null, status varchar(20), price decimal(10,2), published_at datetime)`)
+	assert.Equal(t, err, nil)
+
+	factory := NewFactory(getArticleTable).Seed(1)
+	// the referenced table is empty.
+	assert.Equal(t, factory.Insert(conn, nil, 1) != nil, true)
+
+	_, err = db.WithDriver(conn).Table("posts").Insert(map[string]interface{}{"title": "a"})
+	assert.Equal(t, err, nil)
+
+	rows, err := factory.Rows(conn, nil, 20)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(rows), 20)
+	for _, row := range rows {
+		_, ok := row["id"]
+		assert.Equal(t, ok, false)
+		_, ok = row["confirm"]
+		assert.Equal(t, ok, false)
+		assert.Equal(t, row["author_id"], "1")
+		assert.Equal(t, row["status"] == "draft" || row["status"] == "published", true)
+		assert.Equal(t, strings.HasSuffix(row["email"].(string), "@example.com"), true)
+		assert.Equal(t, len(row["published_at"].(string)), len("2006-01-02 15:04:05"))
+	}
+
+	factory.Set("status", Const("archived")).Set("title", func(i int, r *rand.Rand) interface{} {
+		return "title"
+	}).Skip("price")
+	assert.Equal(t, factory.Insert(conn, nil, 50), nil)
+
+	items, err := db.WithDriver(conn).Table("articles").All()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(items), 50)
+	assert.Equal(t, items[0]["status"], "archived")
+	assert.Equal(t, items[0]["title"], "title")
+	assert.Equal(t, db.GetValueFromDatabaseType(db.Decimal, items[0]["price"], false).String(), "")
+}
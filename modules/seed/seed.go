@@ -0,0 +1,211 @@
+// Package seed provides the seeders filling the demo and the test data.
+//
+// The plugins and the applications register the seeders as Go functions or
+// JSON fixtures, they are run by the page of the seed plugin or at the
+// startup. A seeder is run once, the name of the seeder is saved in the table
+// goadmin_seeds as the marker in the same transaction of the data, so that a
+// seeder is run again only when it is forced:
+//
+//	seed.Register("demo_users", "demo users", func(conn db.Connection, tx *sql.Tx) error {
+//		_, err := db.WithDriver(conn).WithTx(tx).Table("users").Insert(dialect.H{"name": "demo"})
+//		return err
+//	})
+//
+//	seed.RegisterFixture("demo_posts", "demo posts", []byte(`[
+//		{"table": "posts", "rows": [{"title": "hello"}]}
+//	]`))
+package seed
+
+import (
+	dbsql "database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+)
+
+// TableName is the table of the markers of the seeders, see the migrations of
+// data directory for the schema.
+const TableName = "goadmin_seeds"
+
+// Func seeds the data in the transaction tx of the connection, the statements
+// are run by db.WithDriver(conn).WithTx(tx).
+type Func func(conn db.Connection, tx *dbsql.Tx) error
+
+// Seeder is a registered seeder.
+type Seeder struct {
+	Name        string
+	Description string
+	Fn          Func
+}
+
+// Fixture is the rows of a table of a JSON fixture, the fixture is a list of
+// them inserted in order.
+type Fixture struct {
+	Table string                   `json:"table"`
+	Rows  []map[string]interface{} `json:"rows"`
+}
+
+var (
+	seedersLock sync.RWMutex
+	seeders     = make([]*Seeder, 0)
+)
+
+// Register register the seeder of the function, the seeders are listed and
+// run in the order of the registration. The seeder of the same name is
+// replaced.
+func Register(name, description string, fn Func) *Seeder {
+	seedersLock.Lock()
+	defer seedersLock.Unlock()
+	s := &Seeder{Name: name, Description: description, Fn: fn}
+	for i, seeder := range seeders {
+		if seeder.Name == name {
+			seeders[i] = s
+			return s
+		}
+	}
+	seeders = append(seeders, s)
+	return s
+}
+
+// RegisterFixture register the seeder inserting the rows of the JSON fixture.
+func RegisterFixture(name, description string, data []byte) *Seeder {
+	return Register(name, description, func(conn db.Connection, tx *dbsql.Tx) error {
+		return insertFixture(conn, tx, data)
+	})
+}
+
+// RegisterFixtureFile register the seeder inserting the rows of the JSON
+// fixture file, the file is read when the seeder is run.
+func RegisterFixtureFile(name, description, path string) *Seeder {
+	return Register(name, description, func(conn db.Connection, tx *dbsql.Tx) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return insertFixture(conn, tx, data)
+	})
+}
+
+func insertFixture(conn db.Connection, tx *dbsql.Tx, data []byte) error {
+	var fixtures []Fixture
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return fmt.Errorf("wrong fixture: %v", err)
+	}
+	for _, fixture := range fixtures {
+		if fixture.Table == "" {
+			return errors.New("wrong fixture: table is empty")
+		}
+		for _, row := range fixture.Rows {
+			_, err := db.WithDriver(conn).WithTx(tx).Table(fixture.Table).Insert(dialect.H(row))
+			if db.CheckError(err, db.INSERT) {
+				return fmt.Errorf("insert into %s: %v", fixture.Table, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Seeders return the registered seeders.
+func Seeders() []*Seeder {
+	seedersLock.RLock()
+	defer seedersLock.RUnlock()
+	return append([]*Seeder(nil), seeders...)
+}
+
+// Get return the seeder of the name and whether it is registered.
+func Get(name string) (*Seeder, bool) {
+	seedersLock.RLock()
+	defer seedersLock.RUnlock()
+	for _, seeder := range seeders {
+		if seeder.Name == name {
+			return seeder, true
+		}
+	}
+	return nil, false
+}
+
+// Ran return the time when the seeders were run by name.
+func Ran(conn db.Connection) (map[string]time.Time, error) {
+	items, err := db.WithDriver(conn).Table(TableName).All()
+	if err != nil {
+		return nil, err
+	}
+	ran := make(map[string]time.Time, len(items))
+	for _, item := range items {
+		name := db.GetValueFromDatabaseType(db.Varchar, item["name"], false).String()
+		ran[name] = ranAt(item["ran_at"])
+	}
+	return ran, nil
+}
+
+func ranAt(value interface{}) time.Time {
+	if at, ok := value.(time.Time); ok {
+		return at
+	}
+	at, _ := time.ParseInLocation("2006-01-02 15:04:05",
+		db.GetValueFromDatabaseType(db.Datetime, value, false).String(), time.Local)
+	return at
+}
+
+// Run run the seeder of the name and save the marker in a transaction. The
+// seeder which has been run is skipped unless it is forced, the first return
+// value reports whether the seeder is run.
+func Run(conn db.Connection, name string, force bool) (bool, error) {
+	seeder, ok := Get(name)
+	if !ok {
+		return false, fmt.Errorf("seeder %s is not registered", name)
+	}
+
+	ran, err := Ran(conn)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := ran[name]; ok && !force {
+		return false, nil
+	}
+
+	_, err = db.WithDriver(conn).WithTransaction(func(tx *dbsql.Tx) (error, map[string]interface{}) {
+		if err := seeder.Fn(conn, tx); err != nil {
+			return err, nil
+		}
+		err := db.WithDriver(conn).WithTx(tx).Table(TableName).Where("name", "=", name).Delete()
+		if db.CheckError(err, db.DELETE) {
+			return err, nil
+		}
+		_, err = db.WithDriver(conn).WithTx(tx).Table(TableName).Insert(dialect.H{
+			"name":   name,
+			"ran_at": time.Now().Format("2006-01-02 15:04:05"),
+		})
+		if db.CheckError(err, db.INSERT) {
+			return err, nil
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("seeder %s: %v", name, err)
+	}
+	return true, nil
+}
+
+// RunPending run the seeders which have not been run in the order of the
+// registration, the names of the seeders run are returned. It stops at the
+// first failed seeder.
+func RunPending(conn db.Connection) ([]string, error) {
+	names := make([]string, 0)
+	for _, seeder := range Seeders() {
+		ok, err := Run(conn, seeder.Name, false)
+		if err != nil {
+			return names, err
+		}
+		if ok {
+			names = append(names, seeder.Name)
+		}
+	}
+	return names, nil
+}
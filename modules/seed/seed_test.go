@@ -0,0 +1,82 @@
+package seed
+
+import (
+	dbsql "database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	_ "github.com/purpose168/GoAdmin/modules/db/drivers/sqlite"
+)
+
+func newConn(t *testing.T) db.Connection {
+	conn := db.GetSqliteDB()
+	conn.InitDB(config.DatabaseList{"default": {
+		Driver: db.DriverSqlite,
+		File:   filepath.Join(t.TempDir(), "seed.db"),
+	}})
+	migration, err := os.ReadFile("../../data/migrations/admin_2026_10_21_000000_sqlite.sql")
+	assert.Equal(t, err, nil)
+	for _, statement := range []string{
+		string(migration),
+		"create table posts (id integer primary key autoincrement, title text)",
+	} {
+		_, err := conn.Exec(statement)
+		assert.Equal(t, err, nil)
+	}
+	return conn
+}
+
+func count(t *testing.T, conn db.Connection) int {
+	rows, err := db.WithDriver(conn).Table("posts").All()
+	assert.Equal(t, err, nil)
+	return len(rows)
+}
+
+func TestRun(t *testing.T) {
+	seedersLock.Lock()
+	seeders = make([]*Seeder, 0)
+	seedersLock.Unlock()
+
+	conn := newConn(t)
+
+	RegisterFixture("posts", "", []byte(`[{"table": "posts", "rows": [{"title": "a"}, {"title": "b"}]}]`))
+	Register("broken", "", func(conn db.Connection, tx *dbsql.Tx) error {
+		_, err := db.WithDriver(conn).WithTx(tx).Table("posts").Insert(map[string]interface{}{"title": "c"})
+		if err != nil {
+			return err
+		}
+		return errors.New("broken")
+	})
+
+	names, err := RunPending(conn)
+	assert.Equal(t, err != nil, true)
+	assert.Equal(t, names, []string{"posts"})
+	// the rows of the failed seeder are rolled back.
+	assert.Equal(t, count(t, conn), 2)
+
+	ran, err := Ran(conn)
+	assert.Equal(t, err, nil)
+	_, ok := ran["posts"]
+	assert.Equal(t, ok, true)
+	_, ok = ran["broken"]
+	assert.Equal(t, ok, false)
+
+	// the seeder which has been run is skipped unless it is forced.
+	done, err := Run(conn, "posts", false)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, done, false)
+	assert.Equal(t, count(t, conn), 2)
+
+	done, err = Run(conn, "posts", true)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, done, true)
+	assert.Equal(t, count(t, conn), 4)
+
+	_, err = Run(conn, "missing", false)
+	assert.Equal(t, err != nil, true)
+}
@@ -0,0 +1,69 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+// Package servicetest builds the service.List for the unit tests of the
+// handlers, which the engine builds from the config and the databases.
+//
+//	conn := dbtest.New(db.DriverMysql)
+//	srvs := servicetest.New().
+//		WithConnection(conn).
+//		WithConfig(&config.Config{UrlPrefix: "admin"}).
+//		WithTokenService().
+//		Build()
+package servicetest
+
+import (
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/service"
+)
+
+// Builder builds a service.List.
+type Builder struct {
+	list service.List
+	conn db.Connection
+}
+
+// New return an empty builder.
+func New() *Builder {
+	return &Builder{list: make(service.List)}
+}
+
+// WithConnection add the connection keyed by its driver name, as the engine
+// adds the database connections.
+func (b *Builder) WithConnection(conn db.Connection) *Builder {
+	b.list.Add(conn.Name(), conn)
+	if b.conn == nil {
+		b.conn = conn
+	}
+	return b
+}
+
+// WithConfig add the config service.
+func (b *Builder) WithConfig(cfg *config.Config) *Builder {
+	b.list.Add("config", config.SrvWithConfig(cfg))
+	return b
+}
+
+// WithTokenService add the csrf token service using the first connection,
+// the tokens are loaded from the goadmin_session table of the connection.
+func (b *Builder) WithTokenService() *Builder {
+	if b.conn == nil {
+		panic("servicetest: WithConnection should be called before WithTokenService")
+	}
+	b.list.Add(auth.InitCSRFTokenSrv(b.conn))
+	return b
+}
+
+// With add the service of the key.
+func (b *Builder) With(key string, srv service.Service) *Builder {
+	b.list.Add(key, srv)
+	return b
+}
+
+// Build return the service list.
+func (b *Builder) Build() service.List {
+	return b.list
+}
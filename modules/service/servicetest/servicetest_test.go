@@ -0,0 +1,26 @@
+package servicetest
+
+import (
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	conn := dbtest.New(db.DriverMysql).
+		OnQuery("goadmin_session", map[string]interface{}{"sid": "token"})
+
+	srvs := New().
+		WithConnection(conn).
+		WithConfig(&config.Config{UrlPrefix: "admin"}).
+		WithTokenService().
+		Build()
+
+	assert.Equal(t, conn, srvs.Get(db.DriverMysql))
+	assert.Equal(t, "admin", config.GetService(srvs.Get("config")).UrlPrefix)
+	assert.True(t, auth.GetTokenService(srvs.Get(auth.TokenServiceKey)).CheckToken("token"))
+}
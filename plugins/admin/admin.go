@@ -1,15 +1,28 @@
 package admin
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
 	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/inline"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/mail"
+	"github.com/purpose168/GoAdmin/modules/report"
 	"github.com/purpose168/GoAdmin/modules/service"
 	"github.com/purpose168/GoAdmin/modules/system"
 	"github.com/purpose168/GoAdmin/modules/utils"
 	"github.com/purpose168/GoAdmin/plugins"
 	"github.com/purpose168/GoAdmin/plugins/admin/controller"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules/guard"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	"github.com/purpose168/GoAdmin/template/icon"
 	"github.com/purpose168/GoAdmin/template/types"
 	"github.com/purpose168/GoAdmin/template/types/action"
 	_ "github.com/purpose168/GoAdmin/template/types/display"
@@ -22,6 +35,13 @@ type Admin struct {
 	tableList table.GeneratorList
 	guardian  *guard.Guard
 	handler   *controller.Handler
+	grpcAddr  string
+	stopGRPC  func()
+	saml      *auth.SAML
+
+	systemTables []string
+	autoMenu     *AutoMenuOptions
+	pages        []Page
 }
 
 // InitPlugin implements Plugin.InitPlugin.
@@ -48,6 +68,7 @@ func (admin *Admin) InitPlugin(services service.List) {
 		genList.Add("generate", st.GetGenerateForm)
 	}
 	admin.tableList.Combine(genList)
+	admin.systemTables = genList.Keys()
 	admin.guardian = guard.New(admin.Services, admin.Conn, admin.tableList, admin.UI.NavButtons)
 	handlerCfg := controller.Config{
 		Config:     c,
@@ -57,13 +78,53 @@ func (admin *Admin) InitPlugin(services service.List) {
 	}
 	admin.config = c
 	admin.handler.UpdateCfg(handlerCfg)
+	if admin.saml != nil {
+		if err := admin.saml.Init(admin.Conn); err != nil {
+			logger.Error("saml service provider init error: ", err)
+			admin.saml = nil
+		} else {
+			admin.handler.SetSSOURL(admin.saml.LoginURL())
+		}
+	}
 	admin.initRouter()
 	admin.handler.SetRoutes(admin.App.Routers)
 	admin.handler.AddNavButton(admin.UI.NavButtons)
 
 	table.SetServices(services)
 
+	if c.DemoMode {
+		types.AddPageDecorator(response.DemoDecorator)
+	}
+	types.AddPageDecorator(inline.Decorator)
+	types.AddPageHTMLHook(admin.handler.NotificationsHTML)
+	types.AddPageHTMLHook(admin.handler.IdleHTML)
+	types.AddPageHTMLHook(admin.handler.SubmitOnceHTML)
+	types.AddPageHTMLHook(admin.handler.TimezoneHTML)
+	types.AddPageHTMLHook(admin.handler.DegradedHTML)
+
+	reports := report.NewScheduler(admin.Conn, admin.handler.ExportReport)
+	admin.handler.SetReportScheduler(reports)
+	if mail.Enabled() {
+		reports.Start(time.Minute)
+	}
+
 	action.InitOperationHandlerSetter(admin.GetAddOperationFn())
+
+	if admin.autoMenu != nil {
+		if err := admin.syncMenu(*admin.autoMenu); err != nil {
+			logger.Error("auto menu error: ", err)
+		}
+	}
+
+	for _, page := range admin.pages {
+		if err := admin.syncPage(page); err != nil {
+			logger.Error("add page ", page.Path, " error: ", err)
+		}
+	}
+
+	if admin.grpcAddr != "" {
+		admin.serveGRPC()
+	}
 }
 
 func (admin *Admin) GetIndexURL() string {
@@ -105,6 +166,28 @@ func (admin *Admin) SetCaptcha(captcha map[string]string) *Admin {
 	return admin
 }
 
+// SetGRPCAddr set the listening address of the gRPC data service, the service
+// is not started when the address is empty.
+func (admin *Admin) SetGRPCAddr(addr string) *Admin {
+	admin.grpcAddr = addr
+	return admin
+}
+
+// Close stop the gRPC data service, it implements plugins.Closer.
+func (admin *Admin) Close() error {
+	if admin.stopGRPC != nil {
+		admin.stopGRPC()
+	}
+	return nil
+}
+
+// SetSAML enable the SAML 2.0 sign in of the service provider alongside the
+// login form.
+func (admin *Admin) SetSAML(saml *auth.SAML) *Admin {
+	admin.saml = saml
+	return admin
+}
+
 // AddGenerator add table model generator.
 func (admin *Admin) AddGenerator(key string, g table.Generator) *Admin {
 	admin.tableList.Add(key, g)
@@ -117,6 +200,68 @@ func (admin *Admin) AddGenerators(gen ...table.GeneratorList) *Admin {
 	return admin
 }
 
+// RegisterGenerator add the generator after the engine is started. A menu
+// item of the list page is created with the title when it is given and the
+// menu item does not exist.
+func (admin *Admin) RegisterGenerator(key string, g table.Generator, menuTitle ...string) error {
+	admin.tableList.Add(key, g)
+	table.InvalidateGeneratorCache(key)
+	if len(menuTitle) == 0 || menuTitle[0] == "" || admin.Conn == nil {
+		return nil
+	}
+	uri := generatorMenuURI(key)
+	item, err := db.WithDriver(admin.Conn).Table("goadmin_menu").Where("uri", "=", uri).First()
+	if err != nil || item != nil {
+		return err
+	}
+	_, err = models.Menu().SetConn(admin.Conn).New(menuTitle[0], icon.Table, uri, "", "", 0, 0)
+	return err
+}
+
+// UnregisterGenerator remove the generator and the menu items of the list page.
+func (admin *Admin) UnregisterGenerator(key string) error {
+	admin.tableList.Remove(key)
+	table.InvalidateGeneratorCache(key)
+	if admin.Conn == nil {
+		return nil
+	}
+	items, err := db.WithDriver(admin.Conn).Table("goadmin_menu").Where("uri", "=", generatorMenuURI(key)).All()
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		models.MenuWithId(fmt.Sprintf("%v", item["id"])).SetConn(admin.Conn).Delete()
+	}
+	return nil
+}
+
+func generatorMenuURI(key string) string {
+	return strings.Replace(config.GetURLFormats().Info, ":__prefix", key, 1)
+}
+
+// AddDefinitions add the generators of the table definitions. The definition
+// of an added generator changes the tables of the generator instead, so the
+// generators defined in Go should be added before.
+func (admin *Admin) AddDefinitions(defs ...table.Definition) *Admin {
+	for _, def := range defs {
+		d := def
+		table.InvalidateGeneratorCache(d.Name)
+		if gen, ok := admin.tableList.Get(d.Name); ok {
+			admin.tableList.Add(d.Name, func(ctx *context.Context) table.Table {
+				t := gen(ctx)
+				d.Apply(t)
+				return t
+			})
+			continue
+		}
+		if d.Table == "" {
+			panic("definition " + d.Name + ": empty table")
+		}
+		admin.tableList.Add(d.Name, d.Generator())
+	}
+	return admin
+}
+
 // AddGlobalDisplayProcessFn call types.AddGlobalDisplayProcessFn
 func (admin *Admin) AddGlobalDisplayProcessFn(f types.FieldFilterFn) *Admin {
 	types.AddGlobalDisplayProcessFn(f)
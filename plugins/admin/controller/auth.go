@@ -5,6 +5,7 @@ import (
 	template2 "html/template"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/purpose168/GoAdmin/context"
 	"github.com/purpose168/GoAdmin/modules/auth"
@@ -40,6 +41,21 @@ func (h *Handler) Auth(ctx *context.Context) {
 		}
 	}
 
+	loginPage := h.config.LoginPage
+	if loginPage.Terms != "" && ctx.FormValue("terms") != "on" {
+		response.BadRequest(ctx, "login terms required")
+		return
+	}
+	var values map[string]interface{}
+	if loginPage.TenantLabel != "" {
+		tenant := strings.TrimSpace(ctx.FormValue("tenant"))
+		if tenant == "" {
+			response.BadRequest(ctx, "login tenant required")
+			return
+		}
+		values = map[string]interface{}{auth.TenantSessionKey: tenant}
+	}
+
 	if !exist {
 		password := ctx.FormValue("password")
 		username := ctx.FormValue("username")
@@ -58,31 +74,34 @@ func (h *Handler) Auth(ctx *context.Context) {
 		return
 	}
 
-	err := auth.SetCookie(ctx, user, h.conn)
+	err := auth.SetCookieWithValues(ctx, user, h.conn, values)
 
 	if err != nil {
 		response.Error(ctx, err.Error())
 		return
 	}
 
-	if ref := ctx.Referer(); ref != "" {
-		if u, err := url.Parse(ref); err == nil {
-			v := u.Query()
-			if r := v.Get("ref"); r != "" {
-				rr, _ := url.QueryUnescape(r)
-				response.OkWithData(ctx, map[string]interface{}{
-					"url": rr,
-				})
-				return
-			}
-		}
-	}
-
 	response.OkWithData(ctx, map[string]interface{}{
-		"url": h.config.GetIndexURL(),
+		"url": h.loginRedirect(ctx),
 	})
 }
 
+// loginRedirect return the page requested before the login, which is posted
+// by the login page or kept in the query of its url, or the index page when
+// there is none or it is not a page of the admin.
+func (h *Handler) loginRedirect(ctx *context.Context) string {
+	ref := ctx.FormValue(auth.RefKey)
+	if ref == "" {
+		if u, err := url.Parse(ctx.Referer()); err == nil {
+			ref = u.Query().Get(auth.RefKey)
+		}
+	}
+	if r, ok := auth.SafeRedirect(ref); ok {
+		return r
+	}
+	return h.config.GetIndexURL()
+}
+
 // Logout delete the cookie.
 func (h *Handler) Logout(ctx *context.Context) {
 	err := auth.DelCookie(ctx, db.GetConnection(h.services))
@@ -93,6 +112,13 @@ func (h *Handler) Logout(ctx *context.Context) {
 	ctx.SetStatusCode(302)
 }
 
+// loginRef return the safe url to return to after the login of the login
+// page.
+func loginRef(ctx *context.Context) string {
+	ref, _ := auth.SafeRedirect(ctx.Query(auth.RefKey))
+	return ref
+}
+
 // ShowLogin show the login page.
 func (h *Handler) ShowLogin(ctx *context.Context) {
 
@@ -103,15 +129,21 @@ func (h *Handler) ShowLogin(ctx *context.Context) {
 		Title     string
 		Logo      template2.HTML
 		CdnUrl    string
+		SSOUrl    string
 		System    types.SystemInfo
+		Page      config.LoginPage
+		Ref       string
 	}{
 		UrlPrefix: h.config.AssertPrefix(),
 		Title:     h.config.LoginTitle,
 		Logo:      h.config.LoginLogo,
+		Page:      h.config.LoginPage,
+		Ref:       loginRef(ctx),
 		System: types.SystemInfo{
 			Version: system.Version(),
 		},
 		CdnUrl: h.config.AssetUrl,
+		SSOUrl: h.ssoURL,
 	}); err == nil {
 		ctx.HTML(http.StatusOK, buf.String())
 	} else {
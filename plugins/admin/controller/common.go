@@ -2,6 +2,7 @@ package controller
 
 import (
 	"bytes"
+	"errors"
 	template2 "html/template"
 	"net/http"
 	"regexp"
@@ -16,6 +17,7 @@ import (
 	"github.com/purpose168/GoAdmin/modules/db"
 	"github.com/purpose168/GoAdmin/modules/language"
 	"github.com/purpose168/GoAdmin/modules/menu"
+	"github.com/purpose168/GoAdmin/modules/report"
 	"github.com/purpose168/GoAdmin/modules/service"
 	"github.com/purpose168/GoAdmin/plugins/admin/models"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
@@ -37,6 +39,8 @@ type Handler struct {
 	navButtons    *types.Buttons
 	operationLock sync.Mutex
 	assetsTheme   map[string]string
+	ssoURL        string
+	reports       *report.Scheduler
 }
 
 func New(cfg ...Config) *Handler {
@@ -76,6 +80,11 @@ func (h *Handler) SetCaptcha(captcha map[string]string) {
 	h.captchaConfig = captcha
 }
 
+// SetSSOURL set the url of the single sign-on shown on the login page.
+func (h *Handler) SetSSOURL(url string) {
+	h.ssoURL = url
+}
+
 func (h *Handler) AssetsTheme(asset, theme string) {
 	h.assetsTheme[asset] = theme
 }
@@ -85,7 +94,8 @@ func (h *Handler) SetRoutes(r context.RouterMap) {
 }
 
 func (h *Handler) table(prefix string, ctx *context.Context) table.Table {
-	t := h.generators[prefix](ctx)
+	gen, _ := h.generators.Get(prefix)
+	t := gen(ctx)
 	authHandler := auth.Middleware(db.GetConnection(h.services))
 	for _, cb := range t.GetInfo().Callbacks {
 		if cb.Value[constant.ContextNodeNeedAuth] == 1 {
@@ -169,7 +179,8 @@ func (h *Handler) OperationHandler(path string, ctx *context.Context) bool {
 func (h *Handler) HTML(ctx *context.Context, user models.UserModel, panel types.Panel,
 	options ...template.ExecuteOptions) {
 	buf := h.Execute(ctx, user, panel, "", options...)
-	ctx.HTML(http.StatusOK, buf.String())
+	template.AddPreloadHeader(ctx)
+	ctx.HTMLBuffer(http.StatusOK, buf)
 }
 
 func (h *Handler) HTMLPlug(ctx *context.Context, user models.UserModel, panel types.Panel, plugName string,
@@ -191,7 +202,8 @@ func (h *Handler) HTMLPlug(ctx *context.Context, user models.UserModel, panel ty
 			CheckPermission(user)
 	}
 	buf := h.ExecuteWithBtns(ctx, user, panel, plugName, btns, options...)
-	ctx.HTML(http.StatusOK, buf.String())
+	template.AddPreloadHeader(ctx)
+	ctx.HTMLBuffer(http.StatusOK, buf)
 }
 
 func (h *Handler) ExecuteWithBtns(ctx *context.Context, user models.UserModel, panel types.Panel, plugName string, btns types.Buttons,
@@ -256,6 +268,19 @@ func (h *Handler) authSrv() *auth.TokenService {
 	return auth.GetTokenService(h.services.Get(auth.TokenServiceKey))
 }
 
+// saveErrorData is the data of the json response of a failed save, the
+// field of the error is returned so the form can mark it.
+func (h *Handler) saveErrorData(err error) map[string]interface{} {
+	data := map[string]interface{}{
+		"token": h.authSrv().AddToken(),
+	}
+	var fe *table.FieldError
+	if errors.As(err, &fe) && fe.Field != "" {
+		data["field"] = fe.Field
+	}
+	return data
+}
+
 func aAlert(ctx *context.Context) types.AlertAttribute {
 	return aTemplate(ctx).Alert()
 }
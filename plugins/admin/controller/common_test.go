@@ -1,9 +1,15 @@
 package controller
 
 import (
+	"html/template"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+	"github.com/purpose168/GoAdmin/template/types"
+	"github.com/purpose168/GoAdmin/template/types/form"
 )
 
 func TestIsInfoUrl(t *testing.T) {
@@ -15,3 +21,57 @@ func TestIsNewUrl(t *testing.T) {
 	u := "https://localhost:8098/admin/info/user/new?id=sdfs"
 	assert.Equal(t, true, isNewUrl(u, "user"))
 }
+
+func TestErdMermaid(t *testing.T) {
+	graph := erdMermaid(map[string]string{"users": "user"}, []erdRelation{
+		{From: "users", To: "roles", Label: "role_id = id"},
+		{From: "users", To: "roles", Label: "role_id = id"},
+	}, func(prefix string) string { return "/admin/info/" + prefix })
+	assert.Equal(t, graph, `graph LR
+    t0["roles"]
+    t1["users"]
+    t1 -->|"role_id = id"| t0
+    click t1 "/admin/info/user"
+`)
+}
+
+func TestEmbedOrigin(t *testing.T) {
+	origin, ok := embedOrigin("https://grafana.example.com:3000/d/abc?x=1")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, origin, "https://grafana.example.com:3000")
+
+	origin, ok = embedOrigin("/apps/report")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, origin, "")
+
+	_, ok = embedOrigin("//evil.example.com")
+	assert.Equal(t, ok, false)
+	_, ok = embedOrigin("javascript:alert(1)")
+	assert.Equal(t, ok, false)
+}
+
+func TestFilterChips(t *testing.T) {
+	u, _ := url.Parse("/admin/info/user?name=go&age=18")
+	params := parameter.GetParam(u, 10)
+
+	assert.Equal(t, filterChips("/admin/info/user", params, nil), template.HTML(""))
+
+	content := string(filterChips("/admin/info/user", params, []types.FormField{
+		{Field: "name", Head: "Name", FormType: form.Text, Value: "go"},
+		{Field: "age", Head: "Age", FormType: form.Number, Value: "18"},
+	}))
+	assert.Equal(t, strings.Count(content, `class="label label-primary"`), 2)
+	assert.Equal(t, strings.Contains(content, "Name: go"), true)
+	assert.Equal(t, strings.Contains(content, `href="/admin/info/user?__page=1&amp;__pageSize=10&amp;__sort=id&amp;__sort_type=desc&amp;age=18"`), true)
+	assert.Equal(t, strings.Contains(content, `href="/admin/info/user?__page=1&amp;__pageSize=10&amp;__sort=id&amp;__sort_type=desc"`), true)
+}
+
+func TestDetailJSON(t *testing.T) {
+	data := detailJSON("1", "User", types.FormFields{
+		{Field: "name", Head: "Name", Value: "jack"},
+		{Field: "password", Head: "Password", Value: "secret", Hide: true},
+	}, "/admin/info/user/edit?__goadmin_edit_pk=1", "")
+	assert.Equal(t, data["data"], map[string]interface{}{"name": "jack"})
+	assert.Equal(t, len(data["fields"].([]map[string]interface{})), 1)
+	assert.Equal(t, data["delete_url"], "")
+}
@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+)
+
+// ExportDefinition download the JSON definition of the table, which can be
+// edited and loaded by engine.AddGeneratorsFromDir.
+func (h *Handler) ExportDefinition(ctx *context.Context) {
+	prefix := ctx.Query(constant.PrefixKey)
+
+	data, err := json.MarshalIndent(table.NewDefinition(prefix, h.table(prefix, ctx)), "", "  ")
+	if err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	ctx.DataWithHeaders(http.StatusOK, map[string]string{
+		"Content-Type":        "application/json; charset=utf-8",
+		"Content-Disposition": `attachment; filename="` + prefix + `.json"`,
+	}, data)
+}
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"html/template"
+	"sort"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/language"
+)
+
+// DegradedHTML is the page html hook of the degraded mode. While some of the
+// databases are unreachable, a banner lists them on the top of the pages, and
+// the pages of their tables show them as temporarily unavailable. The errors
+// are only logged, as they may contain the addresses of the databases.
+func (h *Handler) DegradedHTML(_ *context.Context) (template.HTML, template.HTML) {
+	down := db.DownConnections()
+	if len(down) == 0 {
+		return "", ""
+	}
+	names := make([]string, 0, len(down))
+	for name := range down {
+		names = append(names, template.HTMLEscapeString(name))
+	}
+	sort.Strings(names)
+
+	foot := template.HTML(`<div class="goadmin-degraded alert alert-warning" style="margin:0;border-radius:0;">` +
+		`<i class="fa fa-exclamation-triangle"></i> ` + language.Get("degraded mode") + `: <b>` +
+		strings.Join(names, ", ") + `</b></div>` +
+		`<script>(function () {
+    var banners = document.querySelectorAll(".goadmin-degraded"), banner = banners[banners.length - 1];
+    var wrapper = document.querySelector(".content-wrapper");
+    if (wrapper) { wrapper.insertBefore(banner, wrapper.firstChild); }
+})();</script>`)
+	return "", foot
+}
@@ -1,8 +1,11 @@
 package controller
 
 import (
+	"strings"
+
 	"github.com/purpose168/GoAdmin/context"
 	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/notify"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules/guard"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
 )
@@ -20,7 +23,23 @@ func (h *Handler) Delete(ctx *context.Context) {
 	//	return
 	//}
 
-	if err := h.table(param.Prefix, ctx).DeleteData(param.Id); err != nil {
+	panel := h.table(param.Prefix, ctx)
+
+	// the batch deletes report their progress to the notifications.
+	var (
+		progress *notify.Progress
+		ids      = strings.Split(param.Id, ",")
+	)
+	if len(ids) > 1 {
+		progress = notify.NewProgress(ctx, "delete", panel.GetInfo().Title, len(ids))
+	}
+
+	err := panel.DeleteData(param.Id)
+	if err == nil {
+		progress.Add(len(ids))
+	}
+	progress.Finish(err)
+	if err != nil {
 		logger.ErrorCtx(ctx, "Delete error %+v", err)
 		response.Error(ctx, "delete fail")
 		return
@@ -5,11 +5,14 @@ import (
 
 	"github.com/purpose168/GoAdmin/context"
 	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/favorite"
 	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
 	form2 "github.com/purpose168/GoAdmin/plugins/admin/modules/form"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
 	"github.com/purpose168/GoAdmin/template"
 	"github.com/purpose168/GoAdmin/template/types"
 	"github.com/purpose168/GoAdmin/template/types/form"
@@ -135,23 +138,76 @@ $('.delete-btn').on('click', function (event) {
 
 	formInfo, err := newPanel.GetDataWithId(param.WithPKs(id))
 
+	if ctx.WantJSON() {
+		if err != nil {
+			response.Error(ctx, err.Error())
+			return
+		}
+		response.OkWithData(ctx, detailJSON(id, title, formInfo.FieldList, editUrl, deleteUrl))
+		return
+	}
+
 	if err != nil {
 		h.HTML(ctx, user, template.WarningPanelWithDescAndTitle(ctx, err.Error(), desc, title),
 			template.ExecuteOptions{Animation: param.Animation})
 		return
 	}
 
+	formTitle, favoriteJs := template.HTML(title), template.HTML("")
+
+	if info.FavoriteField != "" {
+		favoriteUrl := user.GetCheckPermissionByUrlMethod(h.routePathWithPrefix("favorite", prefix), h.route("favorite").Method())
+		if favoriteUrl != "" {
+			pinned, err := favorite.NewStore(h.conn).IsPinned(user.Id, prefix, id)
+			if err != nil {
+				logger.Error("get favorite error: ", err)
+			}
+			formTitle = favoriteStar(id, pinned) + formTitle
+			favoriteJs = template.HTML(`<script>` + string(favoriteJS(favoriteUrl)) + `</script>`)
+		}
+	}
+
 	h.HTML(ctx, user, types.Panel{
 		Content: detailContent(ctx, aForm(ctx).
-			SetTitle(template.HTML(title)).
+			SetTitle(formTitle).
 			SetContent(formInfo.FieldList).
 			SetHeader(detail.HeaderHtml).
-			SetFooter(template.HTML(deleteJs)+detail.FooterHtml).
+			SetFooter(template.HTML(deleteJs)+favoriteJs+detail.FooterHtml).
 			SetHiddenFields(map[string]string{
 				form2.PreviousKey: infoUrl,
 			}).
 			SetPrefix(h.config.PrefixFixSlash()), editUrl, deleteUrl, !isNotIframe),
-		Description: template.HTML(desc),
-		Title:       template.HTML(title),
+		Description:  template.HTML(desc),
+		Title:        template.HTML(title),
+		BrowserTitle: types.PageTitle(title + " #" + id),
 	}, template.ExecuteOptions{Animation: param.Animation})
 }
+
+// detailJSON is the data of the detail page requested as json, such as by the
+// widgets of the custom pages. The hidden fields are left out, and the edit
+// and the delete urls are empty when the user has no permission.
+func detailJSON(id, title string, fields types.FormFields, editUrl, deleteUrl string) map[string]interface{} {
+	var (
+		data = make(map[string]interface{}, len(fields))
+		list = make([]map[string]interface{}, 0, len(fields))
+	)
+	for _, field := range fields {
+		if field.Hide {
+			continue
+		}
+		data[field.Field] = string(field.Value)
+		list = append(list, map[string]interface{}{
+			"field": field.Field,
+			"head":  field.Head,
+			"value": string(field.Value),
+		})
+	}
+	return map[string]interface{}{
+		"id":         id,
+		"title":      title,
+		"data":       data,
+		"fields":     list,
+		"edit_url":   editUrl,
+		"delete_url": deleteUrl,
+	}
+}
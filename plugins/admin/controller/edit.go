@@ -125,10 +125,11 @@ func (h *Handler) showForm(ctx *context.Context, alert template2.HTML, prefix st
 	}
 
 	h.HTML(ctx, user, types.Panel{
-		Content:     alert + content,
-		Description: template2.HTML(formInfo.Description),
-		Title:       modules.AorBHTML(isNotIframe, template2.HTML(formInfo.Title), ""),
-		MiniSidebar: f.HideSideBar,
+		Content:      alert + content,
+		Description:  template2.HTML(formInfo.Description),
+		Title:        modules.AorBHTML(isNotIframe, template2.HTML(formInfo.Title), ""),
+		MiniSidebar:  f.HideSideBar,
+		BrowserTitle: types.PageTitle(language.Get(formInfo.Title), language.Get("edit")+" #"+param.PK()),
 	}, template.ExecuteOptions{Animation: alert == "" || ((len(animation) > 0) && animation[0]), NoCompress: f.NoCompress})
 
 	if isEdit {
@@ -173,9 +174,7 @@ func (h *Handler) EditForm(ctx *context.Context) {
 	if err != nil {
 		logger.ErrorCtx(ctx, "update data error: %+v", err)
 		if ctx.WantJSON() {
-			response.Error(ctx, err.Error(), map[string]interface{}{
-				"token": h.authSrv().AddToken(),
-			})
+			response.Error(ctx, err.Error(), h.saveErrorData(err))
 		} else {
 			h.showForm(ctx, aAlert(ctx).Warning(err.Error()), param.Prefix, param.Param, true)
 		}
@@ -225,6 +224,6 @@ func (h *Handler) EditForm(ctx *context.Context) {
 
 	buf := h.showTable(ctx, param.Prefix, param.Param.DeletePK().DeleteEditPk(), nil)
 
-	ctx.HTML(http.StatusOK, buf.String())
+	ctx.HTMLBuffer(http.StatusOK, buf)
 	ctx.AddHeader(constant.PjaxUrlHeader, param.PreviousPath)
 }
@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/errors"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/menu"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	template2 "github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// EmbedIDKey is the route parameter of the menu id of the embed page.
+const EmbedIDKey = "__goadmin_embed_id"
+
+// ShowEmbed show the page embedding the uri of a menu item of menu.TypeIframe.
+//
+// The embedded app talks to the admin page by postMessage:
+//
+//	{type: "goadmin:height", height: 800}          resize the iframe
+//	{type: "goadmin:navigate", path: "/info/user"} open an admin page
+//	{type: "goadmin:token"}                        ask for an access token
+//
+// The access token is replied as {type: "goadmin:token", token: "..."} when the
+// JWT session mode is enabled, the app verifies it by the JWKS endpoint.
+func (h *Handler) ShowEmbed(ctx *context.Context) {
+	user := auth.Auth(ctx)
+
+	item, ok := h.embedMenu(user, ctx.Query(EmbedIDKey))
+	if !ok {
+		h.HTML(ctx, user, template2.WarningPanel(ctx, errors.PageError404.Error(), template2.Missing404Page))
+		return
+	}
+
+	src := item.Uri
+	origin, ok := embedOrigin(src)
+	if !ok {
+		h.HTML(ctx, user, template2.WarningPanel(ctx, "wrong embed uri"))
+		return
+	}
+
+	token := ""
+	if jwt, ok := auth.GetJWTServiceOrNot(h.services); ok {
+		var err error
+		if token, err = jwt.AccessToken(user); err != nil {
+			logger.ErrorCtx(ctx, "sign embed token error: %s", err)
+		}
+	}
+
+	opt, _ := json.Marshal(map[string]string{
+		"origin": origin,
+		"prefix": config.Prefix(),
+		"token":  token,
+	})
+
+	h.HTML(ctx, user, types.Panel{
+		Content: template.HTML(`<iframe id="goadmin-embed" src="` + template.HTMLEscapeString(src) +
+			`" style="width:100%;height:calc(100vh - 160px);min-height:300px;border:0;background:#fff;"></iframe>`),
+		Title: template.HTML(template.HTMLEscapeString(language.Get(item.Title))),
+		JS:    template.JS(`(` + embedBridge + `)(` + string(opt) + `);`),
+	})
+}
+
+// embedMenu return the iframe menu item of the id which the user can see.
+func (h *Handler) embedMenu(user models.UserModel, id string) (models.MenuModel, bool) {
+	menuID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return models.MenuModel{}, false
+	}
+	item, err := db.WithDriver(h.conn).Table("goadmin_menu").Where("id", "=", menuID).First()
+	if err != nil || item == nil {
+		return models.MenuModel{}, false
+	}
+	if db.GetValueFromDatabaseType(db.Int, item["type"], false).ToInt64() != menu.TypeIframe {
+		return models.MenuModel{}, false
+	}
+	if !user.IsSuperAdmin() {
+		visible := false
+		for _, mid := range user.WithRoles().WithMenus().MenuIds {
+			if mid == menuID {
+				visible = true
+				break
+			}
+		}
+		if !visible {
+			return models.MenuModel{}, false
+		}
+	}
+	return models.MenuModel{
+		Id:    menuID,
+		Title: db.GetValueFromDatabaseType(db.Varchar, item["title"], false).String(),
+		Uri:   db.GetValueFromDatabaseType(db.Varchar, item["uri"], false).String(),
+	}, true
+}
+
+// embedOrigin return the origin of the embedded uri, empty for the paths of
+// the same origin. Only the http(s) urls and the absolute paths are allowed.
+func embedOrigin(src string) (string, bool) {
+	if strings.HasPrefix(src, "/") && !strings.HasPrefix(src, "//") {
+		return "", true
+	}
+	u, err := url.Parse(src)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", false
+	}
+	return u.Scheme + "://" + u.Host, true
+}
+
+// embedBridge handles the messages of the embedded app, the messages of the
+// other windows and origins are ignored.
+const embedBridge = `function (opt) {
+    var frame = document.getElementById("goadmin-embed");
+    if (!frame) { return; }
+    var origin = opt.origin || window.location.origin;
+    if (window.__goadminEmbedBridge) { window.removeEventListener("message", window.__goadminEmbedBridge); }
+    window.__goadminEmbedBridge = function (e) {
+        if (e.source !== frame.contentWindow || e.origin !== origin || !e.data || typeof e.data.type !== "string") { return; }
+        switch (e.data.type) {
+            case "goadmin:height":
+                var height = parseInt(e.data.height, 10);
+                if (height > 0) { frame.style.height = Math.max(height, 300) + "px"; }
+                break;
+            case "goadmin:navigate":
+                var path = String(e.data.path || "");
+                if (path.charAt(0) !== "/" || path.charAt(1) === "/") { return; }
+                var url = (opt.prefix === "/" ? "" : opt.prefix) + path;
+                if ($.pjax) { $.pjax({url: url, container: '#pjax-container'}); } else { window.location.href = url; }
+                break;
+            case "goadmin:token":
+                frame.contentWindow.postMessage({type: "goadmin:token", token: opt.token}, origin);
+                break;
+        }
+    };
+    window.addEventListener("message", window.__goadminEmbedBridge);
+}`
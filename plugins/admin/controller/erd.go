@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// MermaidJSURL is the script used to render the entity-relationship diagram.
+var MermaidJSURL = "https://cdn.jsdelivr.net/npm/mermaid@10/dist/mermaid.min.js"
+
+// erdRelation is an edge of the diagram: a join of the info table or an option
+// table of the form.
+type erdRelation struct {
+	From  string
+	To    string
+	Label string
+}
+
+// ShowERDiagram show the entity-relationship diagram of the registered generators.
+func (h *Handler) ShowERDiagram(ctx *context.Context) {
+	var (
+		tables    = make(map[string]string)
+		relations = make([]erdRelation, 0)
+	)
+
+	for _, prefix := range h.generators.Keys() {
+		gen, ok := h.generators.Get(prefix)
+		if !ok {
+			continue
+		}
+		t := gen(ctx)
+		info := t.GetInfo()
+		if info.Table == "" {
+			continue
+		}
+		if _, ok := tables[info.Table]; !ok {
+			tables[info.Table] = prefix
+		}
+		relations = append(relations, erdInfoRelations(info)...)
+		relations = append(relations, erdFormRelations(info.Table, t.GetForm())...)
+	}
+
+	graph := erdMermaid(tables, relations, func(prefix string) string {
+		return h.routePathWithPrefix("info", prefix)
+	})
+
+	h.HTML(ctx, auth.Auth(ctx), types.Panel{
+		Content: aBox(ctx).
+			SetBody(template.HTML(`<div class="mermaid">` + template.HTMLEscapeString(graph) + `</div>`)).
+			GetContent(),
+		Title:       template.HTML(language.Get("er diagram")),
+		Description: template.HTML(language.Get("relations of the registered tables")),
+		JS: template.JS(`$.getScript("` + MermaidJSURL + `", function () {
+	mermaid.initialize({startOnLoad: false, securityLevel: "loose"});
+	mermaid.run();
+});`),
+	})
+}
+
+func erdInfoRelations(info *types.InfoPanel) []erdRelation {
+	relations := make([]erdRelation, 0)
+	for _, field := range info.FieldList {
+		for _, join := range field.Joins {
+			if !join.Valid() {
+				continue
+			}
+			base := join.BaseTable
+			if base == "" {
+				base = info.Table
+			}
+			relations = append(relations, erdRelation{
+				From:  base,
+				To:    join.Table,
+				Label: join.Field + " = " + join.JoinField,
+			})
+		}
+		for _, filter := range field.FilterFormFields {
+			if filter.OptionTable.Table != "" {
+				relations = append(relations, erdRelation{
+					From:  info.Table,
+					To:    filter.OptionTable.Table,
+					Label: field.Field + " = " + filter.OptionTable.ValueField,
+				})
+			}
+		}
+	}
+	return relations
+}
+
+func erdFormRelations(table string, form *types.FormPanel) []erdRelation {
+	relations := make([]erdRelation, 0)
+	if form.Table != "" {
+		table = form.Table
+	}
+	for _, field := range form.FieldList {
+		if field.OptionTable.Table != "" {
+			relations = append(relations, erdRelation{
+				From:  table,
+				To:    field.OptionTable.Table,
+				Label: field.Field + " = " + field.OptionTable.ValueField,
+			})
+		}
+	}
+	return relations
+}
+
+// erdMermaid build a mermaid flowchart of the tables and relations, the tables
+// which have a generator are linked to their list page.
+func erdMermaid(tables map[string]string, relations []erdRelation, url func(prefix string) string) string {
+	var (
+		ids   = make(map[string]string)
+		names = make([]string, 0, len(tables))
+		b     = new(strings.Builder)
+		seen  = make(map[erdRelation]bool)
+	)
+
+	for name := range tables {
+		names = append(names, name)
+	}
+	for _, r := range relations {
+		if _, ok := tables[r.To]; !ok {
+			tables[r.To] = ""
+			names = append(names, r.To)
+		}
+		if _, ok := tables[r.From]; !ok {
+			tables[r.From] = ""
+			names = append(names, r.From)
+		}
+	}
+	sort.Strings(names)
+
+	b.WriteString("graph LR\n")
+	for i, name := range names {
+		ids[name] = fmt.Sprintf("t%d", i)
+		fmt.Fprintf(b, "    %s[\"%s\"]\n", ids[name], erdEscape(name))
+	}
+	for _, r := range relations {
+		if seen[r] {
+			continue
+		}
+		seen[r] = true
+		fmt.Fprintf(b, "    %s -->|\"%s\"| %s\n", ids[r.From], erdEscape(r.Label), ids[r.To])
+	}
+	for _, name := range names {
+		if prefix := tables[name]; prefix != "" {
+			fmt.Fprintf(b, "    click %s \"%s\"\n", ids[name], erdEscape(url(prefix)))
+		}
+	}
+
+	return b.String()
+}
+
+func erdEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(s)
+}
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"fmt"
+	template2 "html/template"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/guard"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	"github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// ShowExplain show the statement, the timing and the plan of the list query.
+func (h *Handler) ShowExplain(ctx *context.Context) {
+	var (
+		param   = guard.GetExplainParam(ctx)
+		user    = auth.Auth(ctx)
+		info    = param.Table.GetInfo()
+		title   = info.Title + " - " + language.Get("explain")
+		infoUrl = h.routePathWithPrefix("info", param.Prefix) + param.Param.GetRouteParamStr()
+	)
+
+	plan, err := param.Panel.Explain(ctx, param.Param)
+	if err != nil {
+		h.HTML(ctx, user, template.WarningPanelWithDescAndTitle(ctx, err.Error(), info.Description, title))
+		return
+	}
+
+	args := make([]string, len(plan.Query.Args))
+	for k, arg := range plan.Query.Args {
+		args[k] = fmt.Sprintf("%v", arg)
+	}
+	examined := "-"
+	if plan.Examined >= 0 {
+		examined = strconv.FormatInt(plan.Examined, 10)
+	}
+
+	queryBox := aBox(ctx).
+		WithHeadBorder().
+		SetHeader(template2.HTML(`<a href="` + template2.HTMLEscapeString(infoUrl) + `" class="btn btn-sm btn-default">` +
+			`<i class="fa fa-arrow-left"></i> ` + language.Get("back") + `</a>`)).
+		SetBody(template2.HTML(`<pre style="white-space: pre-wrap;">`+template2.HTMLEscapeString(plan.Query.Statement)+`</pre>`) +
+			stripedTable(ctx, []map[string]types.InfoItem{
+				{"key": {Content: lgExplain("arguments")}, "value": {Content: template2.HTML(template2.HTMLEscapeString(strings.Join(args, ", ")))}},
+				{"key": {Content: lgExplain("sql time")}, "value": {Content: template2.HTML(millisecond(plan.Query.Duration))}},
+				{"key": {Content: lgExplain("query time")}, "value": {Content: template2.HTML(millisecond(plan.Query.Elapsed))}},
+				{"key": {Content: lgExplain("rows")}, "value": {Content: template2.HTML(strconv.Itoa(plan.Query.Rows) + " / " + strconv.Itoa(plan.Query.Total))}},
+				{"key": {Content: lgExplain("rows examined")}, "value": {Content: template2.HTML(examined)}},
+			})).
+		GetContent()
+
+	thead := make(types.Thead, len(plan.Columns))
+	for k, column := range plan.Columns {
+		thead[k] = types.TheadItem{Head: column, Field: column}
+	}
+	rows := make([]map[string]types.InfoItem, len(plan.Rows))
+	for k, row := range plan.Rows {
+		rows[k] = make(map[string]types.InfoItem, len(row))
+		for _, column := range plan.Columns {
+			value := row[column]
+			if b, ok := value.([]byte); ok {
+				value = string(b)
+			}
+			if value == nil {
+				value = ""
+			}
+			rows[k][column] = types.InfoItem{Content: template2.HTML(template2.HTMLEscapeString(fmt.Sprintf("%v", value)))}
+		}
+	}
+
+	planBox := aBox(ctx).
+		WithHeadBorder().
+		SetHeader(lgExplain("query plan")).
+		SetBody(aTable(ctx).SetThead(thead).SetInfoList(rows).SetMinWidth("0.01%").GetContent()).
+		SetNoPadding().
+		GetContent()
+
+	h.HTML(ctx, user, types.Panel{
+		Content:     queryBox + planBox,
+		Title:       template2.HTML(title),
+		Description: template2.HTML(info.Description),
+	})
+}
+
+// queryInfo return the query time of the list page with the details of the
+// query for the users having the query info permission.
+func (h *Handler) queryInfo(user models.UserModel, prefix string, params parameter.Parameters,
+	panel table.Table, query table.QueryInfo) template2.HTML {
+
+	if _, ok := panel.(table.ExplainTable); !ok || query.Statement == "" || !user.Can(table.QueryInfoPermission) {
+		return ""
+	}
+
+	content := template2.HTML(`<b>` + language.Get("query time") + `: </b>` + millisecond(query.Elapsed) +
+		`&nbsp;&nbsp;<b>` + language.Get("sql time") + `: </b>` + millisecond(query.Duration) +
+		`&nbsp;&nbsp;<b>` + language.Get("rows") + `: </b>` + strconv.Itoa(query.Rows) + " / " + strconv.Itoa(query.Total))
+
+	explainUrl := user.GetCheckPermissionByUrlMethod(h.routePathWithPrefix("explain", prefix)+params.GetRouteParamStr(),
+		h.route("explain").Method())
+	if explainUrl != "" {
+		content += template2.HTML(`&nbsp;&nbsp;<a href="` + template2.HTMLEscapeString(explainUrl) + `" title="` +
+			template2.HTMLEscapeString(query.Statement) + `">EXPLAIN</a>`)
+	}
+	return content
+}
+
+func millisecond(d time.Duration) string {
+	return fmt.Sprintf("%.3fms", d.Seconds()*1000)
+}
+
+func lgExplain(v string) template2.HTML {
+	return template2.HTML(template2.HTMLEscapeString(language.Get(v)))
+}
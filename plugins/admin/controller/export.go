@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/notify"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// exportWarningHeader is the response header telling the export is cut by the
+// export limit of the table.
+const exportWarningHeader = "X-Export-Warning"
+
+// utf8BOM makes excel open the csv files as utf-8.
+const utf8BOM = "\xEF\xBB\xBF"
+
+// firstExportChunk fetch the first chunk of the iterator, which gives the
+// thead of the export, and warn when the rows are more than the limit.
+func firstExportChunk(ctx *context.Context, prefix string, limit int, iterator *table.ExportIterator) (table.PanelInfo, error) {
+	truncated, err := iterator.Truncated()
+	if err != nil {
+		return table.PanelInfo{}, err
+	}
+	if truncated {
+		logger.Warnf("export of %s is truncated to %d rows", prefix, limit)
+		ctx.AddHeader(exportWarningHeader, fmt.Sprintf("the export is truncated to %d rows", limit))
+	}
+
+	if !iterator.Next() && iterator.Err() != nil {
+		return table.PanelInfo{}, iterator.Err()
+	}
+	return iterator.Data(), nil
+}
+
+// exportCSV write the rows as csv into the response body, the rows left in
+// the iterator are fetched and flushed chunk by chunk while the body is read.
+// The written rows are reported to the progress when it is not nil.
+func exportCSV(ctx *context.Context, fileName string, tableInfo *types.InfoPanel, infoData table.PanelInfo,
+	iterator *table.ExportIterator, progress *notify.Progress) {
+
+	reader, writer := io.Pipe()
+
+	go func() {
+		w := csv.NewWriter(writer)
+		_, _ = io.WriteString(writer, utf8BOM)
+		_ = w.Write(exportHeads(infoData.Thead))
+
+		writeRows := func(list types.InfoList) error {
+			for _, info := range list {
+				_ = w.Write(exportRow(tableInfo, infoData.Thead, info))
+			}
+			progress.Add(len(list))
+			w.Flush()
+			return w.Error()
+		}
+
+		err := writeRows(infoData.InfoList)
+		if iterator != nil {
+			for err == nil && iterator.Next() {
+				err = writeRows(iterator.Data().InfoList)
+			}
+			if err == nil {
+				err = iterator.Err()
+			}
+		}
+		if err != nil {
+			logger.Error("export error: ", err)
+		}
+		progress.Finish(err)
+		_ = writer.CloseWithError(err)
+	}()
+
+	ctx.AddHeader("content-disposition", `attachment; filename=`+fileName)
+	ctx.SetContentType("text/csv; charset=utf-8")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.Response.Body = reader
+}
+
+// exportHeads return the heads of the visible columns.
+func exportHeads(thead types.Thead) []string {
+	heads := make([]string, 0, len(thead))
+	for _, head := range thead {
+		if !head.Hide {
+			heads = append(heads, head.Head)
+		}
+	}
+	return heads
+}
+
+// exportRow return the cells of the visible columns of the row, the values
+// or the contents depending on the export type of the table.
+func exportRow(tableInfo *types.InfoPanel, thead types.Thead, info map[string]types.InfoItem) []string {
+	row := make([]string, 0, len(thead))
+	for _, head := range thead {
+		if head.Hide {
+			continue
+		}
+		if tableInfo.IsExportValue() {
+			row = append(row, info[head.Field].Value)
+		} else {
+			row = append(row, string(info[head.Field].Content))
+		}
+	}
+	return row
+}
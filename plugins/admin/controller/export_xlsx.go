@@ -0,0 +1,78 @@
+//go:build !goadmin_noxlsx
+
+package controller
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/purpose168/GoAdmin/modules/notify"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// exportXLSX write the rows as xlsx, the rows left in the iterator are
+// fetched chunk by chunk. The written rows are reported to the progress when
+// it is not nil.
+func exportXLSX(tableInfo *types.InfoPanel, infoData table.PanelInfo, iterator *table.ExportIterator,
+	progress *notify.Progress) (buf *bytes.Buffer, err error) {
+
+	defer func() { progress.Finish(err) }()
+
+	tableName := "Sheet1"
+
+	f := excelize.NewFile()
+	index := f.NewSheet(tableName)
+	f.SetActiveSheet(index)
+
+	// TODO: support any numbers of fields.
+	orders := []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K",
+		"L", "M", "N", "O", "P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y", "Z"}
+
+	if len(infoData.Thead) > 26 {
+		j := -1
+		for i := 0; i < len(infoData.Thead)-26; i++ {
+			if i%26 == 0 {
+				j++
+			}
+			letter := orders[j] + orders[i%26]
+			orders = append(orders, letter)
+		}
+	}
+
+	for columnIndex, head := range exportHeads(infoData.Thead) {
+		f.SetCellValue(tableName, orders[columnIndex]+"1", head)
+	}
+
+	count := 2
+	writeRows := func(list types.InfoList) {
+		for _, info := range list {
+			for columnIndex, value := range exportRow(tableInfo, infoData.Thead, info) {
+				f.SetCellValue(tableName, orders[columnIndex]+strconv.Itoa(count), value)
+			}
+			count++
+		}
+		progress.Add(len(list))
+	}
+
+	writeRows(infoData.InfoList)
+	if iterator != nil {
+		for iterator.Next() {
+			writeRows(iterator.Data().InfoList)
+		}
+		if iterator.Err() != nil {
+			return nil, iterator.Err()
+		}
+	}
+
+	buf, err = f.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, errors.New("empty xlsx")
+	}
+	return buf, nil
+}
@@ -0,0 +1,22 @@
+//go:build goadmin_noxlsx
+
+package controller
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/purpose168/GoAdmin/modules/notify"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// exportXLSX fail as the xlsx export is excluded by the goadmin_noxlsx build
+// tag, the csv export still works.
+func exportXLSX(_ *types.InfoPanel, _ table.PanelInfo, _ *table.ExportIterator,
+	progress *notify.Progress) (*bytes.Buffer, error) {
+
+	err := errors.New("xlsx export is excluded by the goadmin_noxlsx build tag")
+	progress.Finish(err)
+	return nil, err
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	template2 "html/template"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/favorite"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/guard"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+)
+
+// Favorite pin or unpin the record for the current user.
+func (h *Handler) Favorite(ctx *context.Context) {
+	var (
+		param = guard.GetFavoriteParam(ctx)
+		user  = auth.Auth(ctx)
+		store = favorite.NewStore(h.conn)
+	)
+
+	pinned, err := store.IsPinned(user.Id, param.Prefix, param.ID)
+	if err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	if pinned {
+		err = store.Unpin(user.Id, param.Prefix, param.ID)
+	} else {
+		var title string
+		title, err = param.Panel.FavoriteTitle(param.ID)
+		if err == nil {
+			err = store.Pin(user.Id, param.Prefix, param.ID, title)
+		}
+	}
+	if err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	response.OkWithData(ctx, map[string]interface{}{
+		"pinned": !pinned,
+	})
+}
+
+// favoriteIDs return the ids of the records of the table pinned by the
+// current user.
+func (h *Handler) favoriteIDs(ctx *context.Context, prefix string) ([]string, error) {
+	return favorite.NewStore(h.conn).IDs(auth.Auth(ctx).Id, prefix)
+}
+
+// favoriteStar return the star toggling the favorite of the record.
+func favoriteStar(id string, pinned bool) template2.HTML {
+	class, title := "fa-star-o", language.Get("pin")
+	if pinned {
+		class, title = "fa-star text-yellow", language.Get("unpin")
+	}
+	return template2.HTML(`<a href="javascript:;" class="ga-favorite" data-id="` + template2.HTMLEscapeString(id) +
+		`" title="` + template2.HTMLEscapeString(title) + `" style="margin-right: 5px;"><i class="fa ` + class +
+		`"></i></a>`)
+}
+
+// favoriteJS return the js posting the stars clicked to the url.
+func favoriteJS(url string) template2.JS {
+	return template2.JS(`
+$('.ga-favorite').off('click').on('click', function () {
+	var star = $(this);
+	$.ajax({
+		method: 'post',
+		url: ` + utils.JSON(url) + `,
+		data: {id: star.attr('data-id')},
+		success: function (data) {
+			if (typeof (data) === "string") {
+				data = JSON.parse(data);
+			}
+			if (data.code !== 200) {
+				swal(data.msg, '', 'error');
+				return;
+			}
+			var pinned = data.data.pinned;
+			star.find('i').toggleClass('fa-star text-yellow', pinned).toggleClass('fa-star-o', !pinned);
+			star.attr('title', pinned ? ` + utils.JSON(language.Get("unpin")) + ` : ` + utils.JSON(language.Get("pin")) + `);
+		},
+		error: function (data) {
+			swal(data.responseJSON ? data.responseJSON.msg : 'error', '', 'error');
+		}
+	});
+});`)
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	template2 "html/template"
+
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// filterChips return the applied filters as the chips shown above the table,
+// each chip links to the list without its condition.
+func filterChips(infoUrl string, params parameter.Parameters, fields []types.FormField) template2.HTML {
+	chips := types.FilterChips(fields)
+	if len(chips) == 0 {
+		return ""
+	}
+
+	var (
+		content = template2.HTML(`<div class="ga-filter-chips" style="padding: 8px 10px 0;">`)
+		all     = params
+	)
+	for _, chip := range chips {
+		p := params.WithoutFilter(chip.Field)
+		all = all.WithoutFilter(chip.Field)
+		text := chip.Head + ": "
+		if chip.Operator != "" {
+			text += string(chip.Operator) + " "
+		}
+		content += template2.HTML(`<span class="label label-primary" style="display: inline-block;margin: 0 5px 5px 0;padding: 5px 8px;font-size: 12px;font-weight: normal;">` +
+			template2.HTMLEscapeString(text+chip.Value) +
+			` <a href="` + template2.HTMLEscapeString(infoUrl+p.SetPage("1").GetRouteParamStr()) +
+			`" title="` + template2.HTMLEscapeString(language.Get("remove")) +
+			`" style="color: #fff;margin-left: 3px;"><i class="fa fa-times"></i></a></span>`)
+	}
+	if len(chips) > 1 {
+		content += template2.HTML(`<a href="` + template2.HTMLEscapeString(infoUrl+all.SetPage("1").GetRouteParamStr()) +
+			`" style="font-size: 12px;margin-left: 5px;">` + template2.HTMLEscapeString(language.Get("reset")) + `</a>`)
+	}
+	return content + `</div>`
+}
@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"encoding/json"
+	"html/template"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/guard"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	template2 "github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// findReplacePreviewRows is the most rows shown by the preview.
+const findReplacePreviewRows = 100
+
+// ShowFindReplace show the find and replace page of the filtered rows of the
+// list page, the affected rows are previewed before the apply.
+func (h *Handler) ShowFindReplace(ctx *context.Context) {
+	var (
+		param   = guard.GetFindReplaceParam(ctx)
+		user    = auth.Auth(ctx)
+		info    = param.Table.GetInfo()
+		query   = param.Param.GetRouteParamStr()
+		infoUrl = h.routePathWithPrefix("info", param.Prefix) + query
+	)
+
+	fields := param.Panel.FindReplaceFields()
+	if len(fields) == 0 {
+		h.HTML(ctx, user, template2.WarningPanel(ctx, table.ErrFindReplaceNotSupported.Error()))
+		return
+	}
+
+	opt, _ := json.Marshal(map[string]interface{}{
+		"preview": h.routePathWithPrefix("find_replace_preview", param.Prefix) + query,
+		"apply":   h.routePathWithPrefix("find_replace_apply", param.Prefix) + query,
+		"list":    infoUrl,
+		"token":   h.authSrv().AddToken(),
+		"pk":      param.Table.GetPrimaryKey().Name,
+		"labels": map[string]string{
+			"old":     language.Get("grid old value"),
+			"new":     language.Get("grid new value"),
+			"total":   language.Get("find replace affected rows"),
+			"more":    language.Get("find replace preview first rows"),
+			"nothing": language.Get("find replace no match"),
+			"confirm": language.Get("find replace confirm"),
+			"success": language.Get("find replace success"),
+		},
+	})
+
+	h.HTML(ctx, user, types.Panel{
+		Content:     aBox(ctx).SetBody(findReplaceForm(fields, infoUrl)).GetContent(),
+		Title:       template.HTML(template.HTMLEscapeString(info.Title)),
+		Description: template.HTML(language.Get("find replace")),
+		JS:          template.JS(`(` + findReplaceRunner + `)(` + string(opt) + `);`),
+	})
+}
+
+// findReplaceForm return the form of the find and replace page, the
+// options of the select are the replaceable fields.
+func findReplaceForm(fields types.FieldList, infoUrl string) template.HTML {
+	options := ""
+	for _, field := range fields {
+		options += `<option value="` + template.HTMLEscapeString(field.Field) + `">` +
+			template.HTMLEscapeString(language.Get(field.Head)) + `</option>`
+	}
+
+	return template.HTML(`<div id="goadmin-find-replace">
+<p>` + language.Get("find replace tip") + `</p>
+<div class="form-inline">
+<select class="form-control input-sm" name="field">` + options + `</select>
+<input type="text" class="form-control input-sm" name="find" placeholder="` + template.HTMLEscapeString(language.Get("find replace find")) + `">
+<input type="text" class="form-control input-sm" name="replace" placeholder="` + template.HTMLEscapeString(language.Get("find replace replace with")) + `">
+<div class="checkbox"><label><input type="checkbox" name="match_case" value="1"> ` + language.Get("find replace match case") + `</label></div>
+<button type="button" class="btn btn-sm btn-info fr-preview">` + language.Get("grid preview") + `</button>
+</div>
+<div class="fr-diff" style="margin-top: 15px;"></div>
+<div class="text-right">
+<a class="btn btn-sm btn-default" href="` + template.HTMLEscapeString(infoUrl) + `">` + language.Get("cancel") + `</a>
+<button type="button" class="btn btn-sm btn-danger fr-apply" disabled>` + language.Get("find replace apply") + `</button>
+</div>
+</div>`)
+}
+
+// FindReplacePreview return the number of the affected rows and the first
+// of them.
+func (h *Handler) FindReplacePreview(ctx *context.Context) {
+	param := guard.GetFindReplaceParam(ctx)
+
+	rows, err := param.Panel.PreviewFindReplace(ctx, param.Param, param.FindReplace)
+	if err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	response.OkWithData(ctx, findReplaceData(rows))
+}
+
+// FindReplaceApply update the affected rows and record an operation log of
+// every changed row, a new token is replied when failed.
+func (h *Handler) FindReplaceApply(ctx *context.Context) {
+	var (
+		param = guard.GetFindReplaceParam(ctx)
+		user  = auth.Auth(ctx)
+	)
+
+	rows, err := param.Panel.ApplyFindReplace(ctx, param.Param, param.FindReplace)
+	if err != nil {
+		response.Error(ctx, err.Error(), map[string]interface{}{
+			"token": h.authSrv().AddToken(),
+		})
+		return
+	}
+
+	logger.Infof("find and replace of %s.%s by user %d changed %d rows", param.Prefix,
+		param.FindReplace.Field, user.Id, len(rows))
+
+	for _, row := range rows {
+		models.OperationLog().SetConn(h.conn).New(user.Id, ctx.Path(), ctx.Method(), ctx.LocalIP(), utils.JSON(map[string]interface{}{
+			"table":      param.Prefix,
+			"field":      param.FindReplace.Field,
+			"id":         row.ID,
+			"old":        row.Old,
+			"new":        row.New,
+			"find":       param.FindReplace.Find,
+			"replace":    param.FindReplace.Replace,
+			"match_case": param.FindReplace.MatchCase,
+		}))
+	}
+
+	response.OkWithData(ctx, findReplaceData(rows))
+}
+
+func findReplaceData(rows []table.FindReplaceRow) map[string]interface{} {
+	total := len(rows)
+	if len(rows) > findReplacePreviewRows {
+		rows = rows[:findReplacePreviewRows]
+	}
+	return map[string]interface{}{
+		"total": total,
+		"rows":  rows,
+	}
+}
+
+// findReplaceRunner posts the find and replace for the preview and the
+// apply, the apply is enabled only after a preview of the same input.
+const findReplaceRunner = `function (opt) {
+    var root = $("#goadmin-find-replace"), diff = root.find(".fr-diff"), apply = root.find(".fr-apply");
+
+    var input = function () {
+        return {
+            field: root.find("select[name=field]").val(),
+            find: root.find("input[name=find]").val(),
+            replace: root.find("input[name=replace]").val(),
+            match_case: root.find("input[name=match_case]").prop("checked") ? "1" : "0"
+        };
+    };
+
+    var show = function (data) {
+        diff.empty();
+        if (!data.total) { diff.append($('<p class="text-muted"></p>').text(opt.labels.nothing)); return false; }
+        diff.append($("<p></p>").append($("<b></b>").text(opt.labels.total + ": " + data.total)));
+        if (data.total > data.rows.length) { diff.append($('<p class="text-muted"></p>').text(opt.labels.more.replace("%d", data.rows.length))); }
+        var table = $('<table class="table table-condensed"></table>');
+        table.append($("<tr></tr>").append($("<th></th>").text(opt.pk), $("<th></th>").text(opt.labels.old),
+            $("<th></th>").text(opt.labels.new)));
+        $.each(data.rows, function (_, row) {
+            table.append($("<tr></tr>").append($("<td></td>").text(row.id),
+                $('<td class="text-muted"></td>').text(row.old), $('<td class="text-success"></td>').text(row.new)));
+        });
+        diff.append(table);
+        return true;
+    };
+
+    var fail = function (res) {
+        var data = res.responseJSON || {};
+        if (data.data && data.data.token) { opt.token = data.data.token; }
+        swal(data.msg || "error", "", "error");
+        apply.prop("disabled", true);
+    };
+
+    root.find("select, input").on("change input", function () { apply.prop("disabled", true); });
+    root.find(".fr-preview").on("click", function () {
+        $.post(opt.preview, input(), function (res) {
+            apply.prop("disabled", !show(res.data));
+        }).fail(fail);
+    });
+    apply.on("click", function () {
+        if (!confirm(opt.labels.confirm)) { return; }
+        apply.prop("disabled", true);
+        var data = input();
+        data["` + form.TokenKey + `"] = opt.token;
+        $.post(opt.apply, data, function (res) {
+            swal(opt.labels.success.replace("%d", res.data.total), "", "success");
+            $.pjax({url: opt.list, container: '#pjax-container'});
+        }).fail(fail);
+    });
+}`
@@ -0,0 +1,22 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+func TestFindReplaceForm(t *testing.T) {
+	content := string(findReplaceForm(types.FieldList{
+		{Field: "title", Head: "Title"},
+		{Field: "body", Head: `<b>Body</b>`},
+	}, "/admin/info/posts?q=a&b=1"))
+
+	assert.Equal(t, strings.Contains(content, `<option value="title">Title</option>`), true)
+	assert.Equal(t, strings.Contains(content, `<option value="body">&lt;b&gt;Body&lt;/b&gt;</option>`), true)
+	assert.Equal(t, strings.Contains(content, `href="/admin/info/posts?q=a&amp;b=1"`), true)
+	assert.Equal(t, strings.Contains(content, `name="find"`), true)
+	assert.Equal(t, strings.Contains(content, `class="btn btn-sm btn-danger fr-apply" disabled`), true)
+}
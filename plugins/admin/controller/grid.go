@@ -0,0 +1,259 @@
+package controller
+
+import (
+	"encoding/json"
+	"html/template"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/guard"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	template2 "github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// ShowGridEdit show the grid edit page of the table, the ranges copied from
+// the spreadsheets are pasted into the grid, mapped to the columns, and
+// committed after the preview of the changes.
+func (h *Handler) ShowGridEdit(ctx *context.Context) {
+	var (
+		prefix = ctx.Query(constant.PrefixKey)
+		panel  = h.table(prefix, ctx)
+		user   = auth.Auth(ctx)
+		info   = panel.GetInfo()
+	)
+
+	gridPanel, ok := panel.(table.GridTable)
+	if !ok || !info.GridEdit || !panel.GetEditable() {
+		h.HTML(ctx, user, template2.WarningPanel(ctx, language.Get("operation not allow")))
+		return
+	}
+
+	fields := gridPanel.GridFields()
+	if len(fields) == 0 {
+		h.HTML(ctx, user, template2.WarningPanel(ctx, table.ErrGridNotSupported.Error()))
+		return
+	}
+
+	columns := []map[string]string{{"field": panel.GetPrimaryKey().Name, "head": panel.GetPrimaryKey().Name}}
+	for _, field := range fields {
+		columns = append(columns, map[string]string{"field": field.Field, "head": language.Get(field.Head)})
+	}
+
+	opt, _ := json.Marshal(map[string]interface{}{
+		"pk":      panel.GetPrimaryKey().Name,
+		"columns": columns,
+		"preview": h.routePathWithPrefix("grid_preview", prefix),
+		"commit":  h.routePathWithPrefix("grid_commit", prefix),
+		"list":    h.routePathWithPrefix("info", prefix),
+		"token":   h.authSrv().AddToken(),
+		"labels": map[string]string{
+			"ignore":  language.Get("grid ignore"),
+			"field":   language.Get("grid field"),
+			"old":     language.Get("grid old value"),
+			"new":     language.Get("grid new value"),
+			"error":   language.Get("error"),
+			"nothing": language.Get("grid no changes"),
+			"success": language.Get("grid commit success"),
+		},
+	})
+
+	body := template.HTML(`<div id="goadmin-grid">
+<p>` + language.Get("grid paste tip") + `</p>
+<textarea class="form-control grid-paste" rows="4" placeholder="` +
+		template.HTMLEscapeString(language.Get("grid paste here")) + `"></textarea>
+<div class="checkbox"><label><input type="checkbox" class="grid-header"> ` + language.Get("grid first row is header") + `</label></div>
+<div style="overflow-x:auto;"><table class="table table-bordered table-condensed grid-sheet"></table></div>
+<div class="grid-diff"></div>
+<div class="text-right">
+<a class="btn btn-sm btn-default" href="` + template.HTMLEscapeString(h.routePathWithPrefix("info", prefix)) + `">` + language.Get("cancel") + `</a>
+<button type="button" class="btn btn-sm btn-info grid-preview">` + language.Get("grid preview") + `</button>
+<button type="button" class="btn btn-sm btn-primary grid-commit" disabled>` + language.Get("save") + `</button>
+</div>
+</div>`)
+
+	h.HTML(ctx, user, types.Panel{
+		Content:     aBox(ctx).SetBody(body).GetContent(),
+		Title:       template.HTML(template.HTMLEscapeString(info.Title)),
+		Description: template.HTML(language.Get("grid edit")),
+		JS:          template.JS(`(` + gridRunner + `)(` + string(opt) + `);`),
+	})
+}
+
+// GridPreview return the changes and the errors of the rows.
+func (h *Handler) GridPreview(ctx *context.Context) {
+	param := guard.GetGridParam(ctx)
+
+	rows, valid, err := param.Panel.DiffGrid(param.Rows)
+	if err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	response.OkWithData(ctx, map[string]interface{}{
+		"rows":  rows,
+		"valid": valid,
+	})
+}
+
+// GridCommit update the changed rows, a new token is replied when failed so
+// the rows can be fixed and committed again.
+func (h *Handler) GridCommit(ctx *context.Context) {
+	param := guard.GetGridParam(ctx)
+
+	rows, err := param.Panel.CommitGrid(ctx, param.Rows)
+	if err != nil {
+		response.Error(ctx, err.Error(), map[string]interface{}{
+			"rows":  rows,
+			"token": h.authSrv().AddToken(),
+		})
+		return
+	}
+
+	response.OkWithData(ctx, map[string]interface{}{
+		"rows": rows,
+	})
+}
+
+// gridRunner parses the pasted tab separated cells, maps the columns to the
+// fields and posts the rows for the preview and the commit.
+const gridRunner = `function (opt) {
+    var root = $("#goadmin-grid"), sheet = root.find(".grid-sheet"), diff = root.find(".grid-diff");
+    var commit = root.find(".grid-commit"), cells = [], mapping = [];
+
+    var parse = function (text) {
+        var rows = [], row = [], cell = "", quoted = false, i, c;
+        text = text.replace(/\r\n?/g, "\n");
+        for (i = 0; i < text.length; i++) {
+            c = text.charAt(i);
+            if (quoted) {
+                if (c === '"' && text.charAt(i + 1) === '"') { cell += '"'; i++; }
+                else if (c === '"') { quoted = false; }
+                else { cell += c; }
+            } else if (c === '"' && cell === "") { quoted = true; }
+            else if (c === "\t") { row.push(cell); cell = ""; }
+            else if (c === "\n") { row.push(cell); rows.push(row); row = []; cell = ""; }
+            else { cell += c; }
+        }
+        if (cell !== "" || row.length > 0) { row.push(cell); rows.push(row); }
+        return rows;
+    };
+
+    var guess = function (name, index) {
+        var key = $.trim(name || "").toLowerCase(), found = "";
+        $.each(opt.columns, function (_, col) {
+            if (key !== "" && (col.field.toLowerCase() === key || col.head.toLowerCase() === key)) { found = col.field; }
+        });
+        if (found === "" && name === undefined && index < opt.columns.length) { found = opt.columns[index].field; }
+        return found;
+    };
+
+    var render = function () {
+        var width = 0;
+        $.each(cells, function (_, r) { width = Math.max(width, r.length); });
+        var head = $("<tr></tr>");
+        for (var j = 0; j < width; j++) {
+            var sel = $('<select class="form-control input-sm"></select>').attr("data-index", j);
+            sel.append($("<option></option>").val("").text(opt.labels.ignore));
+            $.each(opt.columns, function (_, col) { sel.append($("<option></option>").val(col.field).text(col.head)); });
+            sel.val(mapping[j] || "");
+            head.append($("<th></th>").append(sel));
+        }
+        sheet.empty().append($("<thead></thead>").append(head));
+        var body = $("<tbody></tbody>");
+        $.each(cells, function (i, r) {
+            var tr = $("<tr></tr>");
+            for (var j = 0; j < width; j++) {
+                tr.append($('<td contenteditable="true"></td>').attr({"data-row": i, "data-col": j}).text(r[j] || ""));
+            }
+            body.append(tr);
+        });
+        sheet.append(body);
+        diff.empty();
+        commit.prop("disabled", true);
+    };
+
+    var rows = function () {
+        var res = [];
+        $.each(cells, function (_, r) {
+            var row = {id: "", values: {}};
+            $.each(mapping, function (j, field) {
+                if (!field) { return; }
+                if (field === opt.pk) { row.id = $.trim(r[j] || ""); } else { row.values[field] = r[j] || ""; }
+            });
+            res.push(row);
+        });
+        return res;
+    };
+
+    var show = function (data) {
+        var table = $('<table class="table table-condensed"></table>');
+        table.append($("<tr></tr>").append($("<th></th>").text(opt.pk), $("<th></th>").text(opt.labels.field),
+            $("<th></th>").text(opt.labels.old), $("<th></th>").text(opt.labels.new)));
+        var changed = 0;
+        $.each(data.rows || [], function (_, row) {
+            if (row.error) {
+                table.append($('<tr class="danger"></tr>').append($("<td></td>").text(row.id),
+                    $('<td colspan="3"></td>').text(opt.labels.error + ": " + row.error)));
+                return;
+            }
+            $.each(row.changes || [], function (_, c) {
+                changed++;
+                table.append($("<tr></tr>").append($("<td></td>").text(row.id), $("<td></td>").text(c.field),
+                    $('<td class="text-muted"></td>').text(c.old), $('<td class="text-success"></td>').text(c.new)));
+            });
+        });
+        diff.empty();
+        if (changed === 0 && data.valid) { diff.append($('<p class="text-muted"></p>').text(opt.labels.nothing)); return false; }
+        diff.append(table);
+        return data.valid && changed > 0;
+    };
+
+    var fail = function (res) {
+        var data = res.responseJSON || {};
+        if (data.data && data.data.token) { opt.token = data.data.token; }
+        if (data.data && data.data.rows) { show({rows: data.data.rows, valid: false}); }
+        swal(data.msg || "error", "", "error");
+        commit.prop("disabled", true);
+    };
+
+    root.find(".grid-paste").on("paste", function (e) {
+        var clip = (e.originalEvent || e).clipboardData;
+        if (!clip) { return; }
+        e.preventDefault();
+        cells = parse(clip.getData("text/plain"));
+        mapping = [];
+        var header = root.find(".grid-header").prop("checked");
+        var first = cells[0] || [];
+        for (var j = 0; j < first.length; j++) { mapping[j] = guess(header ? first[j] : undefined, j); }
+        if (header) { cells.shift(); }
+        render();
+    });
+    sheet.on("change", "select", function () {
+        mapping[parseInt($(this).attr("data-index"), 10)] = $(this).val();
+        commit.prop("disabled", true);
+    });
+    sheet.on("input", "td", function () {
+        var td = $(this), r = cells[parseInt(td.attr("data-row"), 10)];
+        r[parseInt(td.attr("data-col"), 10)] = td.text();
+        commit.prop("disabled", true);
+    });
+    root.find(".grid-preview").on("click", function () {
+        $.post(opt.preview, {rows: JSON.stringify(rows())}, function (res) {
+            commit.prop("disabled", !show(res.data));
+        }).fail(fail);
+    });
+    commit.on("click", function () {
+        commit.prop("disabled", true);
+        var data = {rows: JSON.stringify(rows())};
+        data["` + form.TokenKey + `"] = opt.token;
+        $.post(opt.commit, data, function () {
+            swal(opt.labels.success, "", "success");
+            $.pjax({url: opt.list, container: '#pjax-container'});
+        }).fail(fail);
+    });
+}`
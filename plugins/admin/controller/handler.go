@@ -5,6 +5,7 @@ import (
 	"regexp"
 
 	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/analytics"
 	"github.com/purpose168/GoAdmin/modules/auth"
 	"github.com/purpose168/GoAdmin/modules/errors"
 	"github.com/purpose168/GoAdmin/modules/logger"
@@ -28,6 +29,8 @@ func (h *Handler) GlobalDeferHandler(ctx *context.Context) {
 		h.RecordOperationLog(ctx)
 	}
 
+	analytics.Track(ctx)
+
 	if err := recover(); err != nil {
 		logger.ErrorCtx(ctx, "GlobalDeferHandler error %+v", err)
 
@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"html/template"
+	"strconv"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+)
+
+// KeepAlive keep the session alive, the activity is recorded by the auth
+// middleware.
+func (h *Handler) KeepAlive(ctx *context.Context) {
+	response.OkWithData(ctx, map[string]interface{}{
+		"idle_timeout": config.GetIdleTimeout(),
+	})
+}
+
+// IdleHTML is the page html hook of the idle timeout. The activities of the
+// user in all the tabs are tracked, the session is kept alive while the user
+// is active, and a countdown modal is shown before the timeout, the user is
+// logged out when it ends.
+func (h *Handler) IdleHTML(_ *context.Context) (template.HTML, template.HTML) {
+	timeout := config.GetIdleTimeout()
+	if timeout == 0 {
+		return "", ""
+	}
+
+	head := template.HTML(`<style>.goadmin-idle{position:fixed;top:0;right:0;bottom:0;left:0;z-index:2060;` +
+		`background:rgba(0,0,0,.4);}.goadmin-idle .modal-dialog{margin-top:120px;}</style>`)
+	foot := template.HTML(`<script>(function () {
+    if (window.goadminIdle) { return; }
+    window.goadminIdle = true;
+    var timeout = ` + strconv.Itoa(timeout) + ` * 1000, warning = ` + strconv.Itoa(config.GetIdleWarning()) + ` * 1000;
+    var keepAliveUrl = ` + utils.JSON(config.Url(auth.KeepAlivePath)) + `, logoutUrl = ` + utils.JSON(config.Url("/logout")) + `;
+    var labels = ` + utils.JSON(map[string]string{
+		"title":  language.Get("idle timeout"),
+		"msg":    language.Get("idle timeout warning"),
+		"stay":   language.Get("stay signed in"),
+		"logout": language.Get("sign out"),
+	}) + `;
+    var key = "goadmin_last_active", lastPing = Date.now(), modal = null;
+    var lastActive = function () {
+        var v = 0;
+        try { v = parseInt(localStorage.getItem(key), 10) || 0; } catch (e) {}
+        return Math.max(v, window.goadminLastActive || 0);
+    };
+    var touch = function () {
+        window.goadminLastActive = Date.now();
+        try { localStorage.setItem(key, String(window.goadminLastActive)); } catch (e) {}
+    };
+    var ping = function () {
+        lastPing = Date.now();
+        var xhr = new XMLHttpRequest();
+        xhr.open("POST", keepAliveUrl);
+        xhr.setRequestHeader("X-Requested-With", "XMLHttpRequest");
+        xhr.send();
+    };
+    var hide = function () {
+        if (modal) { modal.parentNode.removeChild(modal); modal = null; }
+    };
+    var show = function () {
+        modal = document.createElement("div");
+        modal.className = "goadmin-idle";
+        modal.innerHTML = '<div class="modal-dialog modal-sm"><div class="modal-content">' +
+            '<div class="modal-header"><h4 class="modal-title"></h4></div><div class="modal-body"><p></p></div>' +
+            '<div class="modal-footer"><button type="button" class="btn btn-default idle-logout"></button>' +
+            '<button type="button" class="btn btn-primary idle-stay"></button></div></div></div>';
+        modal.querySelector(".modal-title").textContent = labels.title;
+        modal.querySelector(".idle-logout").textContent = labels.logout;
+        modal.querySelector(".idle-stay").textContent = labels.stay;
+        modal.querySelector(".idle-logout").onclick = function () { location.href = logoutUrl; };
+        modal.querySelector(".idle-stay").onclick = function () { touch(); ping(); hide(); };
+        document.body.appendChild(modal);
+    };
+    var throttled = 0;
+    var onActivity = function () {
+        if (modal || Date.now() - throttled < 5000) { return; }
+        throttled = Date.now();
+        touch();
+    };
+    ["mousemove", "mousedown", "keydown", "scroll", "touchstart"].forEach(function (name) {
+        document.addEventListener(name, onActivity, {passive: true, capture: true});
+    });
+    touch();
+    setInterval(function () {
+        var idle = Date.now() - lastActive();
+        if (idle >= timeout) {
+            location.href = logoutUrl;
+            return;
+        }
+        if (idle >= timeout - warning) {
+            if (!modal) { show(); }
+            var left = Math.ceil((timeout - idle) / 1000);
+            modal.querySelector(".modal-body p").textContent = labels.msg.replace("%d", left);
+            return;
+        }
+        hide();
+        if (idle < timeout / 3 && Date.now() - lastPing >= timeout / 3) { ping(); }
+    }, 1000);
+})();</script>`)
+	return head, foot
+}
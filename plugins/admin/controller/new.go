@@ -83,10 +83,11 @@ func (h *Handler) showNewForm(ctx *context.Context, alert template2.HTML, prefix
 	}
 
 	h.HTML(ctx, user, types.Panel{
-		Content:     alert + content,
-		Description: template2.HTML(f.Description),
-		Title:       modules.AorBHTML(isNotIframe, template2.HTML(f.Title), ""),
-		MiniSidebar: f.HideSideBar,
+		Content:      alert + content,
+		Description:  template2.HTML(f.Description),
+		Title:        modules.AorBHTML(isNotIframe, template2.HTML(f.Title), ""),
+		MiniSidebar:  f.HideSideBar,
+		BrowserTitle: types.PageTitle(language.Get(f.Title), language.Get("new")),
 	}, template.ExecuteOptions{Animation: alert == ""})
 
 	if isNew {
@@ -117,9 +118,7 @@ func (h *Handler) NewForm(ctx *context.Context) {
 	if err != nil {
 		logger.ErrorCtx(ctx, "insert data error: %+v", err)
 		if ctx.WantJSON() {
-			response.Error(ctx, err.Error(), map[string]interface{}{
-				"token": h.authSrv().AddToken(),
-			})
+			response.Error(ctx, err.Error(), h.saveErrorData(err))
 		} else {
 			h.showNewForm(ctx, aAlert(ctx).Warning(err.Error()), param.Prefix, param.Param.GetRouteParamStr(), true)
 		}
@@ -166,6 +165,6 @@ func (h *Handler) NewForm(ctx *context.Context) {
 
 	buf := h.showTable(ctx, param.Prefix, param.Param, nil)
 
-	ctx.HTML(http.StatusOK, buf.String())
+	ctx.HTMLBuffer(http.StatusOK, buf)
 	ctx.AddHeader(constant.PjaxUrlHeader, h.routePathWithPrefix("info", param.Prefix)+param.Param.GetRouteParamStr())
 }
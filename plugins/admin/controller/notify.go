@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/notify"
+	"github.com/purpose168/GoAdmin/modules/utils"
+)
+
+// notifyWait is the longest time a request of the notifications waits for
+// the events, which is below the timeouts of the common proxies.
+const notifyWait = 25 * time.Second
+
+// notifyRetry is the reconnection time of the event source in milliseconds.
+const notifyRetry = 500
+
+// Notifications reply the server-sent events of the session. The response is
+// ended after a batch of events, or when no event comes in a while, and the
+// event source reconnects with the Last-Event-ID to get the next ones.
+func (h *Handler) Notifications(ctx *context.Context) {
+	key := notify.Key(ctx)
+	hub := notify.Default()
+
+	lastID, err := strconv.ParseInt(ctx.Headers("Last-Event-ID"), 10, 64)
+	if err != nil {
+		// a new page only receives the events after it is opened.
+		lastID = hub.LastID(key)
+	}
+
+	events := hub.Wait(key, lastID, notifyWait, ctx.Request.Context().Done())
+
+	var buf bytes.Buffer
+	buf.WriteString("retry: " + strconv.Itoa(notifyRetry) + "\n\n")
+	if len(events) == 0 {
+		buf.WriteString("id: " + strconv.FormatInt(lastID, 10) + "\n\n")
+	}
+	for _, e := range events {
+		data, err := json.Marshal(e.Data)
+		if err != nil {
+			continue
+		}
+		buf.WriteString("id: " + strconv.FormatInt(e.ID, 10) + "\n")
+		buf.WriteString("event: " + e.Type + "\n")
+		buf.WriteString("data: " + string(data) + "\n\n")
+	}
+
+	ctx.DataWithHeaders(http.StatusOK, map[string]string{
+		"Content-Type":      "text/event-stream; charset=utf-8",
+		"Cache-Control":     "no-cache",
+		"X-Accel-Buffering": "no",
+	}, buf.Bytes())
+}
+
+// NotificationsHTML is the page html hook showing the progress and the
+// toasts of the session streamed by the notifications.
+func (h *Handler) NotificationsHTML(_ *context.Context) (template.HTML, template.HTML) {
+	head := template.HTML(`<style>.goadmin-notify{position:fixed;right:15px;bottom:15px;z-index:2050;width:320px;}` +
+		`.goadmin-notify .alert{margin-bottom:10px;box-shadow:0 1px 4px rgba(0,0,0,.2);}` +
+		`.goadmin-notify .progress{margin:6px 0 0;height:8px;}</style>`)
+	foot := template.HTML(`<script>(function () {
+    if (window.goadminNotify || typeof window.EventSource !== "function") { return; }
+    var labels = ` + utils.JSON(map[string]string{
+		"done":  language.Get("notification done"),
+		"fail":  language.Get("notification fail"),
+		"items": language.Get("notification items"),
+	}) + `;
+    var box = document.createElement("div");
+    box.className = "goadmin-notify";
+    document.body.appendChild(box);
+    var levels = {info: "info", success: "success", warning: "warning", error: "danger"};
+    var alert = function (level, id) {
+        var el = id ? box.querySelector('[data-op="' + id + '"]') : null;
+        if (!el) {
+            el = document.createElement("div");
+            if (id) { el.setAttribute("data-op", id); }
+            box.appendChild(el);
+        }
+        el.className = "alert alert-" + (levels[level] || "info");
+        return el;
+    };
+    var remove = function (el, delay) {
+        setTimeout(function () { if (el.parentNode) { el.parentNode.removeChild(el); } }, delay);
+    };
+    var source = new EventSource(` + utils.JSON(config.Url("/notifications")) + `);
+    window.goadminNotify = source;
+    source.addEventListener("toast", function (e) {
+        var data = JSON.parse(e.data), el = alert(data.level);
+        el.textContent = data.msg;
+        remove(el, 5000);
+    });
+    source.addEventListener("progress", function (e) {
+        var data = JSON.parse(e.data);
+        var el = alert(data.error ? "error" : (data.finished ? "success" : "info"), data.op + ":" + data.title);
+        var text = data.title + " " + data.done + (data.total > 0 ? "/" + data.total : "") + " " + labels.items;
+        if (data.finished) { text += " " + (data.error ? labels.fail + ": " + data.error : labels.done); }
+        el.textContent = text;
+        if (!data.finished) {
+            var pct = data.total > 0 ? Math.min(100, Math.round(data.done * 100 / data.total)) : 100;
+            var bar = document.createElement("div");
+            bar.className = "progress";
+            bar.innerHTML = '<div class="progress-bar progress-bar-striped active" style="width:' + pct + '%"></div>';
+            el.appendChild(bar);
+        } else {
+            remove(el, data.error ? 10000 : 5000);
+        }
+    });
+})();</script>`)
+	return head, foot
+}
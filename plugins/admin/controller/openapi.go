@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/openapi"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// SwaggerUIAssetsURL is the url prefix of the swagger-ui-dist assets.
+var SwaggerUIAssetsURL = "https://cdn.jsdelivr.net/npm/swagger-ui-dist@5"
+
+// OpenAPI return the OpenAPI document of the json apis and the custom data routes.
+func (h *Handler) OpenAPI(ctx *context.Context) {
+	b, err := json.Marshal(openapi.Generate(ctx, h.generators))
+	if err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", b)
+}
+
+// ShowOpenAPI show the swagger ui page of the OpenAPI document.
+func (h *Handler) ShowOpenAPI(ctx *context.Context) {
+	h.HTML(ctx, auth.Auth(ctx), types.Panel{
+		Content: aBox(ctx).
+			SetBody(template.HTML(`<link rel="stylesheet" href="` + SwaggerUIAssetsURL + `/swagger-ui.css"><div id="swagger-ui"></div>`)).
+			GetContent(),
+		Title:       template.HTML(language.Get("api document")),
+		Description: "OpenAPI",
+		JS: template.JS(`$.getScript("` + SwaggerUIAssetsURL + `/swagger-ui-bundle.js", function () {
+	SwaggerUIBundle({url: "` + h.routePath("openapi_json") + `", dom_id: "#swagger-ui"});
+});`),
+	})
+}
@@ -17,7 +17,6 @@ import (
 
 	"github.com/purpose168/GoAdmin/modules/config"
 
-	"github.com/gin-gonic/gin"
 	"github.com/purpose168/GoAdmin/context"
 	"github.com/purpose168/GoAdmin/modules/auth"
 	"github.com/purpose168/GoAdmin/modules/language"
@@ -168,7 +167,7 @@ func (h *Handler) PluginDetail(ctx *context.Context) {
 
 	plug, exist := plugins.FindByNameAll(name)
 	if !exist {
-		ctx.JSON(http.StatusOK, gin.H{
+		ctx.JSON(http.StatusOK, map[string]interface{}{
 			"code": 400,
 			"msg":  "bad request",
 		})
@@ -181,10 +180,10 @@ func (h *Handler) PluginDetail(ctx *context.Context) {
 		info.MiniCover = config.Url("/assets/dist/img/plugin_default.png")
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
+	ctx.JSON(http.StatusOK, map[string]interface{}{
 		"code": 0,
 		"msg":  "ok",
-		"data": gin.H{
+		"data": map[string]interface{}{
 			"mini_cover":      info.MiniCover,
 			"title":           language.GetWithScope(info.Title, name),
 			"author":          fmt.Sprintf(plugWord("provided by %s"), language.GetWithScope(info.Author, name)),
@@ -514,7 +513,7 @@ func (h *Handler) ServerLogin(ctx *context.Context) {
 			Path:     "/",
 		})
 	}
-	ctx.JSON(http.StatusOK, gin.H{
+	ctx.JSON(http.StatusOK, map[string]interface{}{
 		"code": res.Code,
 		"data": res.Data,
 		"msg":  res.Msg,
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"fmt"
+	"html/template"
+	"net/url"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/errors"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/qrcode"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+	template2 "github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// qrCodeSize is the size in pixels of the qr code of the login page.
+const qrCodeSize = 200
+
+// qrLoginURL return the absolute url of the approve page of the code, which
+// is opened by the mobile scanning the qr code.
+func qrLoginURL(ctx *context.Context, code string) string {
+	return config.AbsoluteUrl(ctx, auth.QRLoginApprovePath) + "?code=" + url.QueryEscape(code)
+}
+
+// QRLogin create a code of the qr login for the login page, and return the
+// qr code of its approve page and the token to poll its state with.
+func (h *Handler) QRLogin(ctx *context.Context) {
+	if !config.GetLoginPage().QRLogin {
+		response.BadRequest(ctx, "qr login disabled")
+		return
+	}
+
+	q, err := auth.NewQRLogin(ctx, h.conn)
+	if err != nil {
+		logger.ErrorCtx(ctx, "create qr login error: %s", err)
+		response.Error(ctx, err.Error())
+		return
+	}
+	code, err := qrcode.Encode(qrLoginURL(ctx, q.Code))
+	if err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	response.OkWithData(ctx, map[string]interface{}{
+		"code":    q.Code,
+		"token":   q.Token,
+		"qr_code": code.SVG(qrCodeSize),
+		"ttl":     int(auth.QRLoginTTL.Seconds()),
+	})
+}
+
+// QRLoginStatus return the state of the code polled by the login page, and
+// log in the browser once the code is approved.
+func (h *Handler) QRLoginStatus(ctx *context.Context) {
+	if !config.GetLoginPage().QRLogin {
+		response.BadRequest(ctx, "qr login disabled")
+		return
+	}
+
+	state, err := auth.ClaimQRLogin(ctx, ctx.FormValue("code"), ctx.FormValue("token"), h.conn)
+	if err != nil {
+		logger.ErrorCtx(ctx, "claim qr login error: %s", err)
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	data := map[string]interface{}{"state": state}
+	if state == auth.QRLoginApproved {
+		data["url"] = h.loginRedirect(ctx)
+	}
+	response.OkWithData(ctx, data)
+}
+
+// ShowQRLoginApprove show the page of the code scanned by the mobile, which
+// asks the user to approve the login of the browser of the code.
+func (h *Handler) ShowQRLoginApprove(ctx *context.Context) {
+	user := auth.Auth(ctx)
+	q, ok := auth.GetQRLogin(ctx.Query("code"), h.conn)
+	if !config.GetLoginPage().QRLogin || !ok || q.Expired() || q.Approved() {
+		h.HTML(ctx, user, template2.WarningPanel(ctx, language.Get("qr code expired")))
+		return
+	}
+
+	body := template.HTML(`<form method="post" action="` + template.HTMLEscapeString(config.Url(auth.QRLoginApprovePath)) + `">
+<p>` + fmt.Sprintf(language.Get("log in as %s on the device below?"), template.HTMLEscapeString(user.Name)) + `</p>
+<p class="text-muted">` + template.HTMLEscapeString(q.IP) + `<br>` + template.HTMLEscapeString(q.UserAgent) + `</p>
+<input type="hidden" name="code" value="` + template.HTMLEscapeString(q.Code) + `">
+<input type="hidden" name="` + form.TokenKey + `" value="` + h.authSrv().AddToken() + `">
+<div class="text-right">
+<a class="btn btn-default" href="` + template.HTMLEscapeString(h.config.GetIndexURL()) + `">` + language.Get("cancel") + `</a>
+<button type="submit" class="btn btn-primary">` + language.Get("approve login") + `</button>
+</div>
+</form>`)
+
+	h.HTML(ctx, user, types.Panel{
+		Content:     aBox(ctx).SetBody(body).GetContent(),
+		Title:       template.HTML(language.Get("scan to log in")),
+		Description: template.HTML(language.Get("log in on another device")),
+	})
+}
+
+// QRLoginApprove approve the code posted by the approve page.
+func (h *Handler) QRLoginApprove(ctx *context.Context) {
+	user := auth.Auth(ctx)
+	if !h.authSrv().CheckToken(ctx.FormValue(form.TokenKey)) {
+		h.HTML(ctx, user, template2.WarningPanel(ctx, language.Get(errors.EditFailWrongToken)))
+		return
+	}
+	if !config.GetLoginPage().QRLogin || !auth.ApproveQRLogin(ctx.FormValue("code"), user, h.conn) {
+		h.HTML(ctx, user, template2.WarningPanel(ctx, language.Get("qr code expired")))
+		return
+	}
+
+	h.HTML(ctx, user, types.Panel{
+		Content:     aAlert(ctx).SetTitle(template.HTML(language.Get("login approved"))).SetTheme("success").GetContent(),
+		Title:       template.HTML(language.Get("scan to log in")),
+		Description: template.HTML(language.Get("log in on another device")),
+	})
+}
@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/guard"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+)
+
+// RowReorder save the new order of the rows dragged in the list page.
+func (h *Handler) RowReorder(ctx *context.Context) {
+	param := guard.GetReorderParam(ctx)
+
+	if err := param.Panel.ReorderRows(ctx, param.IDs, param.Desc); err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	response.Ok(ctx)
+}
@@ -0,0 +1,351 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/mail"
+	"github.com/purpose168/GoAdmin/modules/report"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/guard"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// defaultReportSchedule is the schedule filled in the form, every Monday 8:00.
+const defaultReportSchedule = "0 8 * * 1"
+
+// SetReportScheduler set the scheduler running the reports of the "run now"
+// button of the reports page.
+func (h *Handler) SetReportScheduler(s *report.Scheduler) {
+	h.reports = s
+}
+
+// ShowReports show the scheduled reports of the current user. The form of a
+// new report of the view is shown when the prefix and the query of the list
+// page are given by the "schedule report" button.
+func (h *Handler) ShowReports(ctx *context.Context) {
+	var (
+		user    = auth.Auth(ctx)
+		prefix  = ctx.Query(constant.PrefixKey)
+		content template.HTML
+	)
+
+	if !mail.Enabled() {
+		content += aAlert(ctx).Warning(language.Get("report mail not configured"))
+	}
+
+	if _, ok := h.generators.Get(prefix); ok {
+		if addUrl := h.reportAddUrl(user, prefix, h.table(prefix, ctx).GetInfo()); addUrl != "" {
+			content += h.reportForm(ctx, prefix, addUrl)
+		}
+	}
+
+	reports, err := report.NewStore(h.conn).List(user.Id)
+	if err != nil {
+		h.HTML(ctx, user, types.Panel{
+			Content:     aAlert(ctx).Warning(err.Error()),
+			Title:       template.HTML(language.Get("reports")),
+			Description: template.HTML(language.Get("scheduled reports")),
+		})
+		return
+	}
+
+	var body template.HTML
+	if len(reports) == 0 {
+		body = template.HTML(`<p class="text-muted">` + language.Get("no reports") + `</p>`)
+	} else {
+		token := h.authSrv().AddToken()
+		infos := make([]map[string]types.InfoItem, len(reports))
+		for i, r := range reports {
+			schedule := template.HTMLEscapeString(r.Schedule)
+			if desc, _, err := types.CronPreview(r.Schedule, ctx.Lang()); err == nil {
+				schedule = `<code>` + schedule + `</code> ` + template.HTMLEscapeString(desc)
+			}
+			lastRun := "-"
+			if !r.LastRunAt.IsZero() {
+				lastRun = r.LastRunAt.Format("2006-01-02 15:04")
+				if r.LastError != "" {
+					lastRun += ` <span class="label label-danger" title="` + template.HTMLEscapeString(r.LastError) + `">` +
+						language.Get("report failed") + `</span>`
+				} else {
+					lastRun += ` <span class="label label-success">` + language.Get("report sent") + `</span>`
+				}
+			}
+			infos[i] = map[string]types.InfoItem{
+				"name": {Content: template.HTML(template.HTMLEscapeString(r.Name))},
+				"view": {Content: template.HTML(`<a href="` + template.HTMLEscapeString(h.reportViewUrl(r)) + `">` +
+					template.HTMLEscapeString(r.Prefix) + `</a>`)},
+				"schedule":   {Content: template.HTML(schedule)},
+				"recipients": {Content: template.HTML(template.HTMLEscapeString(r.Recipients))},
+				"last_run":   {Content: template.HTML(lastRun)},
+				"next_run":   {Content: template.HTML(r.NextRunAt.Format("2006-01-02 15:04"))},
+				"operation":  {Content: reportButtons(r.ID)},
+			}
+			if r.LastError != "" {
+				infos[i]["last_run"] = types.InfoItem{Content: infos[i]["last_run"].Content +
+					template.HTML(`<div class="text-red small">`+template.HTMLEscapeString(r.LastError)+`</div>`)}
+			}
+		}
+		body = aTable(ctx).SetThead(types.Thead{
+			{Head: language.Get("report name"), Field: "name"},
+			{Head: language.Get("report view"), Field: "view"},
+			{Head: language.Get("report schedule"), Field: "schedule"},
+			{Head: language.Get("report recipients"), Field: "recipients"},
+			{Head: language.Get("report last run"), Field: "last_run"},
+			{Head: language.Get("report next run"), Field: "next_run"},
+			{Head: language.Get("operation"), Field: "operation"},
+		}).SetInfoList(infos).GetContent()
+		body += template.HTML(`<script>(` + reportRunner + `)(` + utils.JSON(map[string]interface{}{
+			"run":     h.routePath("report_run"),
+			"delete":  h.routePath("report_delete"),
+			"list":    h.routePath("reports"),
+			"token":   token,
+			"confirm": language.Get("are you sure to delete"),
+			"sent":    language.Get("report sent"),
+		}) + `);</script>`)
+	}
+
+	content += aBox(ctx).SetHeader(template.HTML(language.Get("scheduled reports"))).WithHeadBorder().
+		SetBody(body).GetContent()
+
+	h.HTML(ctx, user, types.Panel{
+		Content:     content,
+		Title:       template.HTML(language.Get("reports")),
+		Description: template.HTML(language.Get("scheduled reports")),
+	})
+}
+
+// AddReport save the report of the view posted by the form of the reports page.
+func (h *Handler) AddReport(ctx *context.Context) {
+	param := guard.GetReportParam(ctx)
+
+	if h.reportAddUrl(auth.Auth(ctx), param.Report.Prefix, h.table(param.Report.Prefix, ctx).GetInfo()) == "" {
+		response.BadRequest(ctx, "permission denied")
+		return
+	}
+
+	if err := report.NewStore(h.conn).Add(param.Report, time.Now()); err != nil {
+		response.Error(ctx, err.Error(), map[string]interface{}{
+			"token": h.authSrv().AddToken(),
+		})
+		return
+	}
+
+	response.Ok(ctx)
+}
+
+// DeleteReport delete the report of the current user.
+func (h *Handler) DeleteReport(ctx *context.Context) {
+	param := guard.GetReportParam(ctx)
+
+	if err := report.NewStore(h.conn).Delete(param.Report.UserID, param.Report.ID); err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	response.Ok(ctx)
+}
+
+// RunReport run the report of the current user now, the next run is the next
+// time of the schedule after now.
+func (h *Handler) RunReport(ctx *context.Context) {
+	param := guard.GetReportParam(ctx)
+
+	if h.reports == nil {
+		response.Error(ctx, "report scheduler is not set")
+		return
+	}
+
+	if err := h.reports.Run(param.Report, time.Now()); err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	response.Ok(ctx)
+}
+
+// ExportReport export the view of the report as xlsx with the permissions of
+// the owner of the report, it is the export function of the report scheduler.
+func (h *Handler) ExportReport(r report.Report) (mail.Attachment, error) {
+	user := models.User().SetConn(h.conn).Find(r.UserID)
+	if user.IsEmpty() {
+		return mail.Attachment{}, fmt.Errorf("the owner %d of the report is not found", r.UserID)
+	}
+	user = user.WithRoles().WithPermissions().WithMenus()
+
+	if _, ok := h.generators.Get(r.Prefix); !ok {
+		return mail.Attachment{}, fmt.Errorf("table %s is not found", r.Prefix)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, h.routePathWithPrefix("info", r.Prefix)+"?"+r.Query, nil)
+	if err != nil {
+		return mail.Attachment{}, err
+	}
+	ctx := context.NewContext(req)
+	ctx.SetUserValue("user", user)
+
+	var (
+		panel     = h.table(r.Prefix, ctx)
+		tableInfo = panel.GetInfo()
+		params    = parameter.GetParam(req.URL, tableInfo.DefaultPageSize, tableInfo.SortField, tableInfo.GetSort())
+		infoData  table.PanelInfo
+		iterator  *table.ExportIterator
+	)
+
+	if h.reportAddUrl(user, r.Prefix, tableInfo) == "" {
+		return mail.Attachment{}, errors.New("the owner of the report is not allowed to export the table")
+	}
+
+	if fn := tableInfo.ExportProcessFn; fn != nil {
+		p, err := fn(params.WithIsAll(true))
+		if err != nil {
+			return mail.Attachment{}, err
+		}
+		infoData.Thead = p.Thead
+		infoData.InfoList = p.InfoList
+	} else {
+		iterator = table.NewExportIterator(ctx, panel, params, tableInfo.ExportChunkSize, tableInfo.ExportLimit)
+		if truncated, err := iterator.Truncated(); err != nil {
+			return mail.Attachment{}, err
+		} else if truncated {
+			logger.Warnf("report %d of %s is truncated to %d rows", r.ID, r.Prefix, tableInfo.ExportLimit)
+		}
+		if !iterator.Next() && iterator.Err() != nil {
+			return mail.Attachment{}, iterator.Err()
+		}
+		infoData = iterator.Data()
+	}
+
+	buf, err := exportXLSX(tableInfo, infoData, iterator, nil)
+	if err != nil {
+		return mail.Attachment{}, err
+	}
+
+	return mail.Attachment{
+		Name:        fmt.Sprintf("%s-%s.xlsx", tableInfo.Title, time.Now().Format("20060102")),
+		ContentType: mail.XLSXContentType,
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// reportAddUrl return the url adding the report of the table, it is empty when
+// the table can not be exported by the user.
+func (h *Handler) reportAddUrl(user models.UserModel, prefix string, info *types.InfoPanel) string {
+	if info.IsHideExportButton {
+		return ""
+	}
+	if user.GetCheckPermissionByUrlMethod(h.routePathWithPrefix("export", prefix), h.route("export").Method()) == "" {
+		return ""
+	}
+	return user.GetCheckPermissionByUrlMethod(h.routePathWithPrefix("report_add", prefix), h.route("report_add").Method())
+}
+
+func (h *Handler) reportViewUrl(r report.Report) string {
+	u := h.routePathWithPrefix("info", r.Prefix)
+	if r.Query != "" {
+		u += "?" + r.Query
+	}
+	return u
+}
+
+// reportForm return the form of a new report of the view of the query.
+func (h *Handler) reportForm(ctx *context.Context, prefix, addUrl string) template.HTML {
+	var (
+		info  = h.table(prefix, ctx).GetInfo()
+		query = ctx.Query("query")
+		view  = h.reportViewUrl(report.Report{Prefix: prefix, Query: query})
+	)
+
+	body := template.HTML(`<form id="goadmin-report-form" class="form-horizontal">
+<input type="hidden" name="` + form.TokenKey + `" value="` + template.HTMLEscapeString(h.authSrv().AddToken()) + `">
+<input type="hidden" name="query" value="` + template.HTMLEscapeString(query) + `">
+<div class="form-group"><label class="col-sm-2 control-label">` + language.Get("report view") + `</label>
+<div class="col-sm-8"><p class="form-control-static"><a href="` + template.HTMLEscapeString(view) + `">` +
+		template.HTMLEscapeString(info.Title) + `</a></p></div></div>
+<div class="form-group"><label class="col-sm-2 control-label">` + language.Get("report name") + `</label>
+<div class="col-sm-8"><input type="text" name="name" class="form-control" value="` + template.HTMLEscapeString(info.Title) + `"></div></div>
+<div class="form-group"><label class="col-sm-2 control-label">` + language.Get("report schedule") + `</label>
+<div class="col-sm-8"><input type="text" name="schedule" class="form-control" value="` + defaultReportSchedule + `">
+<span class="help-block">` + language.Get("report schedule tip") + `</span></div></div>
+<div class="form-group"><label class="col-sm-2 control-label">` + language.Get("report recipients") + `</label>
+<div class="col-sm-8"><textarea name="recipients" class="form-control" rows="2"></textarea>
+<span class="help-block">` + language.Get("report recipients tip") + `</span></div></div>
+<div class="form-group"><div class="col-sm-offset-2 col-sm-8">
+<button type="submit" class="btn btn-sm btn-primary">` + language.Get("save") + `</button></div></div>
+</form>
+<script>(` + reportFormRunner + `)(` + utils.JSON(map[string]interface{}{
+		"add":  addUrl,
+		"list": h.routePath("reports"),
+	}) + `);</script>`)
+
+	return aBox(ctx).SetHeader(template.HTML(language.Get("new report"))).WithHeadBorder().
+		SetBody(body).GetContent()
+}
+
+// reportButton return the "schedule report" button of the list page, which
+// opens the reports page with the current view.
+func reportButton(reportsUrl, prefix string, query url.Values) template.HTML {
+	return template.HTML(`<div class="btn-group pull-right" style="margin-right: 10px"><a href="` +
+		template.HTMLEscapeString(reportsUrl+"?"+constant.PrefixKey+"="+url.QueryEscape(prefix)+
+			"&query="+url.QueryEscape(query.Encode())) + `" class="btn btn-sm btn-default">` +
+		`<i class="fa fa-envelope-o"></i>&nbsp;` + language.Get("schedule report") + `</a></div>`)
+}
+
+func reportButtons(id int64) template.HTML {
+	return template.HTML(fmt.Sprintf(`<a href="javascript:;" class="btn btn-xs btn-default goadmin-report-run" data-id="%d">%s</a> `+
+		`<a href="javascript:;" class="btn btn-xs btn-danger goadmin-report-delete" data-id="%d">%s</a>`,
+		id, language.Get("run now"), id, language.Get("delete")))
+}
+
+// reportFormRunner posts the form of a new report and reloads the reports
+// page, the token is replaced when the report fails to be saved.
+const reportFormRunner = `function (opt) {
+    var f = $("#goadmin-report-form");
+    f.on("submit", function (e) {
+        e.preventDefault();
+        $.post(opt.add, f.serialize(), function () {
+            $.pjax({url: opt.list, container: '#pjax-container'});
+        }).fail(function (res) {
+            var data = res.responseJSON || {};
+            if (data.data && data.data.token) { f.find("input[name=` + form.TokenKey + `]").val(data.data.token); }
+            swal(data.msg || "error", "", "error");
+        });
+    });
+}`
+
+// reportRunner posts the "run now" and the delete buttons of the reports.
+const reportRunner = `function (opt) {
+    var post = function (url, id, done) {
+        var data = {id: id};
+        data["` + form.TokenKey + `"] = opt.token;
+        $.post(url, data, done).fail(function (res) {
+            swal((res.responseJSON || {}).msg || "error", "", "error");
+        }).always(function () {
+            $.pjax({url: opt.list, container: '#pjax-container'});
+        });
+    };
+    $(".goadmin-report-run").on("click", function () {
+        $(this).addClass("disabled");
+        post(opt.run, $(this).data("id"), function () { swal(opt.sent, "", "success"); });
+    });
+    $(".goadmin-report-delete").on("click", function () {
+        var id = $(this).data("id");
+        swal({title: opt.confirm, type: "warning", showCancelButton: true}, function () {
+            post(opt.delete, id);
+        });
+    });
+}`
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/search"
+)
+
+// Search query the search indices with the keyword of the parameter "q", only
+// the results of the tables visible to the user are returned.
+func (h *Handler) Search(ctx *context.Context) {
+	ix := search.Current()
+	if ix == nil {
+		ctx.JSON(http.StatusNotFound, map[string]interface{}{
+			"code": http.StatusNotFound,
+			"msg":  "search index is not enabled",
+		})
+		return
+	}
+
+	keyword := ctx.Query("q")
+	if keyword == "" {
+		response.BadRequest(ctx, "wrong parameter")
+		return
+	}
+
+	results, err := ix.Search(keyword, 20)
+	if err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	var (
+		user  = auth.Auth(ctx)
+		items = make([]map[string]interface{}, 0, len(results))
+	)
+
+	for _, res := range results {
+		if _, ok := h.generators.Get(res.Prefix); !ok {
+			continue
+		}
+		infoURL := h.routePathWithPrefix("info", res.Prefix)
+		if !user.CheckPermissionByUrlMethod(infoURL, "GET", nil) {
+			continue
+		}
+		items = append(items, map[string]interface{}{
+			"prefix": res.Prefix,
+			"id":     res.ID,
+			"title":  res.Title,
+			"score":  res.Score,
+			"url":    h.routePathWithPrefix("detail", res.Prefix) + "?" + constant.DetailPKKey + "=" + url.QueryEscape(res.ID),
+		})
+	}
+
+	response.OkWithData(ctx, map[string]interface{}{
+		"results": items,
+	})
+}
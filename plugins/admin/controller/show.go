@@ -12,13 +12,15 @@ import (
 	"strings"
 	"time"
 
-	"github.com/360EntSecGroup-Skylar/excelize"
 	"github.com/GoAdminGroup/html"
 	"github.com/purpose168/GoAdmin/context"
 	"github.com/purpose168/GoAdmin/modules/auth"
 	"github.com/purpose168/GoAdmin/modules/errors"
+	"github.com/purpose168/GoAdmin/modules/inline"
 	"github.com/purpose168/GoAdmin/modules/language"
 	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/notify"
+	"github.com/purpose168/GoAdmin/modules/utils"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
@@ -59,7 +61,7 @@ func (h *Handler) ShowInfo(ctx *context.Context) {
 		panel.GetInfo().GetSort())
 
 	buf := h.showTable(ctx, prefix, params, panel)
-	ctx.HTML(http.StatusOK, buf.String())
+	ctx.HTMLBuffer(http.StatusOK, buf)
 }
 
 func (h *Handler) showTableData(ctx *context.Context, prefix string, params parameter.Parameters,
@@ -68,6 +70,14 @@ func (h *Handler) showTableData(ctx *context.Context, prefix string, params para
 		panel = h.table(prefix, ctx)
 	}
 
+	if panel.GetInfo().FavoriteField != "" && params.IsPinned() {
+		ids, err := h.favoriteIDs(ctx, prefix)
+		if err != nil {
+			return panel, table.PanelInfo{}, nil, err
+		}
+		params = params.WithPinnedIDs(ids)
+	}
+
 	panelInfo, err := panel.GetData(ctx, params.WithIsAll(false))
 
 	if err != nil {
@@ -182,6 +192,115 @@ func (h *Handler) showTable(ctx *context.Context, prefix string, params paramete
 
 	btns, btnsJs := info.Buttons.CheckPermissionWhenURLAndMethodNotEmpty(user).Content(ctx)
 
+	if info.GridEdit && panel.GetEditable() {
+		gridUrl := user.GetCheckPermissionByUrlMethod(h.routePathWithPrefix("grid", prefix), h.route("grid").Method())
+		if gridUrl != "" {
+			btns += template2.HTML(`<div class="btn-group pull-right" style="margin-right: 10px"><a href="`+
+				template2.HTMLEscapeString(gridUrl)+`" class="btn btn-sm btn-default">`) + icon.Icon(icon.Table) +
+				template2.HTML(`&nbsp;`+language.Get("grid edit")+`</a></div>`)
+		}
+	}
+
+	if info.FindReplace && panel.GetEditable() && user.Can(table.FindReplacePermission) {
+		frUrl := user.GetCheckPermissionByUrlMethod(h.routePathWithPrefix("find_replace", prefix), h.route("find_replace").Method())
+		if frUrl != "" {
+			btns += template2.HTML(`<div class="btn-group pull-right" style="margin-right: 10px"><a href="`+
+				template2.HTMLEscapeString(frUrl+params.GetRouteParamStr())+`" class="btn btn-sm btn-default">`) + icon.Icon(icon.Exchange) +
+				template2.HTML(`&nbsp;`+language.Get("find replace")+`</a></div>`)
+		}
+	}
+
+	if info.SyncField != "" {
+		exportUrl := user.GetCheckPermissionByUrlMethod(h.routePathWithPrefix("sync_export", prefix), h.route("sync_export").Method())
+		syncUrl := ""
+		if panel.GetCanAdd() && panel.GetEditable() {
+			syncUrl = user.GetCheckPermissionByUrlMethod(h.routePathWithPrefix("sync", prefix), h.route("sync").Method())
+		}
+		if exportUrl != "" || syncUrl != "" {
+			btns += template2.HTML(`<div class="btn-group pull-right" style="margin-right: 10px">`)
+			if exportUrl != "" {
+				btns += template2.HTML(`<a href="javascript:;" class="btn btn-sm btn-default goadmin-sync-export">`) +
+					icon.Icon(icon.Download) + template2.HTML(`&nbsp;`+language.Get("sync export")+`</a>`)
+				btnsJs += syncJS(exportUrl)
+			}
+			if syncUrl != "" {
+				btns += template2.HTML(`<a href="`+template2.HTMLEscapeString(syncUrl)+`" class="btn btn-sm btn-default">`) +
+					icon.Icon(icon.Upload) + template2.HTML(`&nbsp;`+language.Get("sync import")+`</a>`)
+			}
+			btns += `</div>`
+		}
+	}
+
+	if exportUrl != "" {
+		// the current view can be mailed by the scheduled reports.
+		if reportUrl := h.reportAddUrl(user, prefix, info); reportUrl != "" {
+			query := params.GetFixedParamStr()
+			query.Set(parameter.Page, "1")
+			btns += reportButton(h.routePath("reports"), prefix, query)
+		}
+	}
+
+	if info.RowReorderField != "" && panel.GetEditable() {
+		// the url is empty without the permission, so the rows can not be dragged.
+		reorderUrl := user.GetCheckPermissionByUrlMethod(h.routePathWithPrefix("reorder", prefix), h.route("reorder").Method())
+		btnsJs += template2.JS(`window.goAdminRowReorderUrl = ` + utils.JSON(reorderUrl) + `;`)
+	}
+
+	if info.FavoriteField != "" {
+		// the stars and the "My pinned" button are shown only with the permission of toggling the stars.
+		favoriteUrl := user.GetCheckPermissionByUrlMethod(h.routePathWithPrefix("favorite", prefix), h.route("favorite").Method())
+		if favoriteUrl != "" {
+			ids, err := h.favoriteIDs(ctx, prefix)
+			if err != nil {
+				logger.Error("get favorites error: ", err)
+			}
+			pinned := make(map[string]bool, len(ids))
+			for _, id := range ids {
+				pinned[id] = true
+			}
+			for _, row := range panelInfo.InfoList {
+				if item, ok := row[info.FavoriteField]; ok {
+					id := row[panel.GetPrimaryKey().Name].Value
+					row[info.FavoriteField] = types.InfoItem{Content: favoriteStar(id, pinned[id]) + item.Content, Value: item.Value}
+				}
+			}
+
+			query, class := params.GetFixedParamStr(), "btn-default"
+			query.Set(parameter.Page, "1")
+			if params.IsPinned() {
+				query.Del(parameter.Pinned)
+				class = "btn-primary"
+			} else {
+				query.Set(parameter.Pinned, parameter.True)
+			}
+			btns += template2.HTML(`<div class="btn-group pull-right" style="margin-right: 10px"><a href="`+
+				template2.HTMLEscapeString(infoUrl+"?"+query.Encode())+`" class="btn btn-sm `+class+`">`) +
+				icon.Icon(icon.Star) + template2.HTML(`&nbsp;`+language.Get("my pinned")+`</a></div>`)
+			btnsJs += favoriteJS(favoriteUrl)
+		}
+	}
+
+	if len(info.RowClasses) > 0 {
+		// the rows are marked in the first visible cell and the classes are
+		// added to the rows by the js, as the rows are rendered by the themes.
+		if field := firstVisibleField(panelInfo.Thead); field != "" {
+			for _, row := range panelInfo.InfoList {
+				if class := info.RowClass(row); class != "" {
+					item := row[field]
+					item.Content = template2.HTML(`<span class="goadmin-row-class" data-class="`+class+`"></span>`) + item.Content
+					row[field] = item
+				}
+			}
+			btnsJs += rowClassJS
+		}
+	}
+
+	if hasAction && info.DisplayWorkers > 1 {
+		// the rows not displayed in time are marked in the primary key, and
+		// their actions in the last cell are removed by the js.
+		btnsJs += template2.JS(`$(".` + table.DisplayPlaceholderClass + `").closest("tr").find("td:last-child").empty();`)
+	}
+
 	if info.TabGroups.Valid() {
 
 		dataTable = aDataTable(ctx).
@@ -251,6 +370,12 @@ func (h *Handler) showTable(ctx *context.Context, prefix string, params paramete
 	isNotIframe := ctx.Query(constant.IframeKey) != "true"
 	paginator := panelInfo.Paginator
 
+	if !info.IsHideQueryInfo {
+		if queryInfo := h.queryInfo(user, prefix, params, panel, panelInfo.Query); queryInfo != "" {
+			paginator = paginator.SetExtraInfo(queryInfo)
+		}
+	}
+
 	if !isNotIframe {
 		paginator = paginator.SetEntriesInfo("")
 	}
@@ -259,7 +384,7 @@ func (h *Handler) showTable(ctx *context.Context, prefix string, params paramete
 		SetBody(body).
 		SetStyle(template2.HTMLAttr(`overflow-x: auto;overflow-y: hidden;`)).
 		SetNoPadding().
-		SetHeader(dataTable.GetDataTableHeader() + info.HeaderHtml).
+		SetHeader(dataTable.GetDataTableHeader() + filterChips(infoUrl, params, panelInfo.FilterFormData) + info.HeaderHtml).
 		WithHeadBorder().
 		SetIframeStyle(!isNotIframe).
 		SetFooter(paginator.GetContent() + info.FooterHtml + `
@@ -347,6 +472,7 @@ func (h *Handler) showTable(ctx *context.Context, prefix string, params paramete
 		MiniSidebar:     info.HideSideBar,
 		AutoRefresh:     autoRefresh,
 		RefreshInterval: interval,
+		NavButtons:      info.NavButtons,
 	}, "", template.ExecuteOptions{Animation: params.Animation, NoCompress: info.NoCompress})
 }
 
@@ -393,24 +519,58 @@ func (h *Handler) Assets(ctx *context.Context) {
 	}, data)
 }
 
-// Export export table rows as excel object.
+// InlineAsset serve the inline scripts and styles of the pages externalized
+// by the modules/inline, compressed by brotli or gzip when accepted.
+func (h *Handler) InlineAsset(ctx *context.Context) {
+	asset, ok := inline.Get(ctx.Query("__name"))
+	if !ok {
+		ctx.Write(http.StatusNotFound, map[string]string{}, "")
+		return
+	}
+
+	// the name is the hash of the content.
+	if match := ctx.Headers("If-None-Match"); match != "" && strings.Contains(match, asset.Name) {
+		ctx.SetStatusCode(http.StatusNotModified)
+		return
+	}
+
+	headers := map[string]string{
+		"Content-Type":  asset.ContentType + "; charset=utf-8",
+		"Cache-Control": "private, max-age=31536000, immutable",
+		"ETag":          `"` + asset.Name + `"`,
+		"Vary":          "Accept-Encoding",
+	}
+	data := asset.Data
+	accept := ctx.Headers("Accept-Encoding")
+	switch {
+	case strings.Contains(accept, "br") && asset.Brotli() != nil:
+		data = asset.Brotli()
+		headers["Content-Encoding"] = "br"
+	case strings.Contains(accept, "gzip"):
+		data = asset.Gzip()
+		headers["Content-Encoding"] = "gzip"
+	}
+	headers["Content-Length"] = strconv.Itoa(len(data))
+	ctx.DataWithHeaders(http.StatusOK, headers, data)
+}
+
+// Export export table rows as excel object, or as csv when the table exports
+// csv. When all the rows are exported, they are fetched chunk by chunk and cut
+// by the export limit of the table.
 func (h *Handler) Export(ctx *context.Context) {
 	param := guard.GetExportParam(ctx)
 
-	tableName := "Sheet1"
 	prefix := ctx.Query(constant.PrefixKey)
 	panel := h.table(prefix, ctx)
 
-	f := excelize.NewFile()
-	index := f.NewSheet(tableName)
-	f.SetActiveSheet(index)
-
 	var (
 		infoData  table.PanelInfo
 		fileName  string
 		err       error
 		tableInfo = panel.GetInfo()
 		params    parameter.Parameters
+		iterator  *table.ExportIterator
+		progress  *notify.Progress
 	)
 
 	if fn := panel.GetInfo().ExportProcessFn; fn != nil {
@@ -427,13 +587,18 @@ func (h *Handler) Export(ctx *context.Context) {
 		if len(param.Id) == 0 {
 			params = parameter.GetParam(ctx.Request.URL, tableInfo.DefaultPageSize, tableInfo.SortField,
 				tableInfo.GetSort())
-			infoData, err = panel.GetData(ctx, params.WithIsAll(param.IsAll))
-			fileName = fmt.Sprintf("%s-%d-page-%s-pageSize-%s.xlsx", tableInfo.Title, time.Now().Unix(),
+			if param.IsAll {
+				iterator = table.NewExportIterator(ctx, panel, params, tableInfo.ExportChunkSize, tableInfo.ExportLimit)
+				infoData, err = firstExportChunk(ctx, prefix, tableInfo.ExportLimit, iterator)
+			} else {
+				infoData, err = panel.GetData(ctx, params.WithIsAll(false))
+			}
+			fileName = fmt.Sprintf("%s-%d-page-%s-pageSize-%s", tableInfo.Title, time.Now().Unix(),
 				params.Page, params.PageSize)
 		} else {
 			infoData, err = panel.GetDataWithIds(ctx, parameter.GetParam(ctx.Request.URL,
 				tableInfo.DefaultPageSize, tableInfo.SortField, tableInfo.GetSort()).WithPKs(param.Id...))
-			fileName = fmt.Sprintf("%s-%d-id-%s.xlsx", tableInfo.Title, time.Now().Unix(), strings.Join(param.Id, "_"))
+			fileName = fmt.Sprintf("%s-%d-id-%s", tableInfo.Title, time.Now().Unix(), strings.Join(param.Id, "_"))
 		}
 		if err != nil {
 			response.Error(ctx, "export error")
@@ -441,52 +606,36 @@ func (h *Handler) Export(ctx *context.Context) {
 		}
 	}
 
-	// TODO: support any numbers of fields.
-	orders := []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K",
-		"L", "M", "N", "O", "P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y", "Z"}
-
-	if len(infoData.Thead) > 26 {
-		j := -1
-		for i := 0; i < len(infoData.Thead)-26; i++ {
-			if i%26 == 0 {
-				j++
-			}
-			letter := orders[j] + orders[i%26]
-			orders = append(orders, letter)
-		}
-	}
-
-	columnIndex := 0
-	for _, head := range infoData.Thead {
-		if !head.Hide {
-			f.SetCellValue(tableName, orders[columnIndex]+"1", head.Head)
-			columnIndex++
-		}
+	if iterator != nil {
+		progress = notify.NewProgress(ctx, "export", tableInfo.Title, 0)
 	}
 
-	count := 2
-	for _, info := range infoData.InfoList {
-		columnIndex = 0
-		for _, head := range infoData.Thead {
-			if !head.Hide {
-				if tableInfo.IsExportValue() {
-					f.SetCellValue(tableName, orders[columnIndex]+strconv.Itoa(count), info[head.Field].Value)
-				} else {
-					f.SetCellValue(tableName, orders[columnIndex]+strconv.Itoa(count), info[head.Field].Content)
-				}
-				columnIndex++
-			}
-		}
-		count++
+	if tableInfo.ExportCSV {
+		exportCSV(ctx, fileName+".csv", tableInfo, infoData, iterator, progress)
+		return
 	}
 
-	buf, err := f.WriteToBuffer()
-
-	if err != nil || buf == nil {
+	buf, err := exportXLSX(tableInfo, infoData, iterator, progress)
+	if err != nil {
 		response.Error(ctx, "export error")
 		return
 	}
 
-	ctx.AddHeader("content-disposition", `attachment; filename=`+fileName)
+	ctx.AddHeader("content-disposition", `attachment; filename=`+fileName+".xlsx")
 	ctx.Data(200, "application/vnd.ms-excel", buf.Bytes())
 }
+
+// firstVisibleField return the first field of the columns shown.
+func firstVisibleField(thead types.Thead) string {
+	for _, head := range thead {
+		if !head.Hide {
+			return head.Field
+		}
+	}
+	return ""
+}
+
+// rowClassJS add the classes of the marked rows to the rows.
+const rowClassJS = template2.JS(`$(".goadmin-row-class").each(function () {
+    $(this).closest("tr").addClass($(this).data("class"));
+});`)
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"html/template"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+)
+
+// SubmitOnceHTML is the page html hook disabling the submit buttons of the
+// forms with the token while they are submitted, so a double click does not
+// submit the form twice. The buttons are enabled again when the ajax or pjax
+// requests end, the submit is cancelled, or after a while.
+func (h *Handler) SubmitOnceHTML(_ *context.Context) (template.HTML, template.HTML) {
+	return "", template.HTML(`<script>(function () {
+    if (window.goadminSubmitOnce) { return; }
+    window.goadminSubmitOnce = true;
+    var buttons = 'button[type="submit"], input[type="submit"], button:not([type])';
+    var each = function (list, fn) { Array.prototype.forEach.call(list, fn); };
+    var guarded = function (form) {
+        return form && form.nodeName === "FORM" && form.querySelector('input[name=' + ` + utils.JSON(form.TokenKey) + ` + ']');
+    };
+    var release = function () {
+        each(document.querySelectorAll("form[data-goadmin-submitting]"), function (f) {
+            f.removeAttribute("data-goadmin-submitting");
+            each(f.querySelectorAll("[data-goadmin-disabled]"), function (btn) {
+                btn.disabled = false;
+                btn.removeAttribute("data-goadmin-disabled");
+            });
+        });
+    };
+    var inFlight = function () {
+        return typeof window.jQuery === "function" && window.jQuery.active > 0;
+    };
+    document.addEventListener("submit", function (e) {
+        var f = e.target;
+        if (!guarded(f)) { return; }
+        if (f.hasAttribute("data-goadmin-submitting")) {
+            e.preventDefault();
+            e.stopImmediatePropagation();
+            return;
+        }
+        f.setAttribute("data-goadmin-submitting", "1");
+        setTimeout(function () {
+            // the submit cancelled by the validations sends no request.
+            if (e.defaultPrevented && !inFlight()) {
+                f.removeAttribute("data-goadmin-submitting");
+                return;
+            }
+            each(f.querySelectorAll(buttons), function (btn) {
+                if (!btn.disabled) {
+                    btn.disabled = true;
+                    btn.setAttribute("data-goadmin-disabled", "1");
+                }
+            });
+            setTimeout(release, 15000);
+        }, 0);
+    }, true);
+    document.addEventListener("click", function (e) {
+        var btn = e.target.closest ? e.target.closest(buttons) : null;
+        if (btn && guarded(btn.form) && btn.form.hasAttribute("data-goadmin-submitting")) {
+            e.preventDefault();
+            e.stopImmediatePropagation();
+        }
+    }, true);
+    window.addEventListener("pageshow", release);
+    if (typeof window.jQuery === "function") {
+        window.jQuery(document).on("ajaxStop pjax:end pjax:error", release);
+    }
+})();</script>`)
+}
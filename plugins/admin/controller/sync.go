@@ -0,0 +1,210 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/notify"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/guard"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	template2 "github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// ShowDataSync show the import page of the data bundles, the bundle exported
+// from another environment is loaded, previewed by a dry run and imported.
+func (h *Handler) ShowDataSync(ctx *context.Context) {
+	var (
+		prefix = ctx.Query(constant.PrefixKey)
+		panel  = h.table(prefix, ctx)
+		user   = auth.Auth(ctx)
+		info   = panel.GetInfo()
+	)
+
+	if _, ok := panel.(table.SyncTable); !ok || info.SyncField == "" || !panel.GetCanAdd() || !panel.GetEditable() {
+		h.HTML(ctx, user, template2.WarningPanel(ctx, language.Get("operation not allow")))
+		return
+	}
+
+	opt, _ := json.Marshal(map[string]interface{}{
+		"preview": h.routePathWithPrefix("sync_preview", prefix),
+		"import":  h.routePathWithPrefix("sync_import", prefix),
+		"list":    h.routePathWithPrefix("info", prefix),
+		"token":   h.authSrv().AddToken(),
+		"labels": map[string]string{
+			"table":     language.Get("sync table"),
+			"key":       language.Get("sync key"),
+			"new_key":   language.Get("sync new key"),
+			"action":    language.Get("sync action"),
+			"field":     language.Get("grid field"),
+			"old":       language.Get("grid old value"),
+			"new":       language.Get("grid new value"),
+			"insert":    language.Get("sync insert"),
+			"update":    language.Get("sync update"),
+			"unchanged": language.Get("sync unchanged"),
+			"nothing":   language.Get("grid no changes"),
+			"success":   language.Get("sync import success"),
+		},
+	})
+
+	body := template.HTML(`<div id="goadmin-sync">
+<p>` + language.Get("sync import tip") + `</p>
+<input type="file" class="sync-file" accept=".json,application/json">
+<textarea class="form-control sync-bundle" rows="6" style="margin-top: 10px;"></textarea>
+<div class="sync-diff" style="margin-top: 10px;"></div>
+<div class="text-right">
+<a class="btn btn-sm btn-default" href="` + template.HTMLEscapeString(h.routePathWithPrefix("info", prefix)) + `">` + language.Get("cancel") + `</a>
+<button type="button" class="btn btn-sm btn-info sync-preview">` + language.Get("sync dry run") + `</button>
+<button type="button" class="btn btn-sm btn-primary sync-import" disabled>` + language.Get("sync import") + `</button>
+</div>
+</div>`)
+
+	h.HTML(ctx, user, types.Panel{
+		Content:     aBox(ctx).SetBody(body).GetContent(),
+		Title:       template.HTML(template.HTMLEscapeString(info.Title)),
+		Description: template.HTML(language.Get("data sync")),
+		JS:          template.JS(`(` + syncRunner + `)(` + string(opt) + `);`),
+	})
+}
+
+// ExportBundle download the selected rows and the rows of the option tables
+// they refer to as a data bundle.
+func (h *Handler) ExportBundle(ctx *context.Context) {
+	param := guard.GetSyncParam(ctx)
+
+	bundle, err := param.Panel.ExportBundle(param.IDs)
+	if err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	ctx.DataWithHeaders(http.StatusOK, map[string]string{
+		"Content-Type":        "application/json; charset=utf-8",
+		"Content-Disposition": fmt.Sprintf(`attachment; filename="%s-bundle-%d.json"`, param.Prefix, time.Now().Unix()),
+	}, data)
+}
+
+// SyncPreview return the changes the import of the bundle would make.
+func (h *Handler) SyncPreview(ctx *context.Context) {
+	param := guard.GetSyncParam(ctx)
+
+	changes, err := param.Panel.ImportBundle(param.Bundle, true)
+	if err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	response.OkWithData(ctx, map[string]interface{}{
+		"changes": changes,
+	})
+}
+
+// SyncImport import the bundle, a new token is replied when failed so the
+// bundle can be fixed and imported again.
+func (h *Handler) SyncImport(ctx *context.Context) {
+	param := guard.GetSyncParam(ctx)
+
+	progress := notify.NewProgress(ctx, "import", language.Get("sync import"), 0)
+	changes, err := param.Panel.ImportBundle(param.Bundle, false)
+	progress.Add(len(changes))
+	progress.Finish(err)
+	if err != nil {
+		response.Error(ctx, err.Error(), map[string]interface{}{
+			"token": h.authSrv().AddToken(),
+		})
+		return
+	}
+
+	response.OkWithData(ctx, map[string]interface{}{
+		"changes": changes,
+	})
+}
+
+// syncJS export the rows selected in the list page as a data bundle.
+func syncJS(exportUrl string) template.JS {
+	return template.JS(`$(".goadmin-sync-export").on("click", function () {
+    var ids = typeof(selectedRows) === "function" ? selectedRows().join() : "";
+    if (ids === "") { swal(` + utils.JSON(language.Get("sync select rows")) + `, "", "warning"); return; }
+    window.location.href = ` + utils.JSON(exportUrl) + ` + "?id=" + encodeURIComponent(ids);
+});`)
+}
+
+// syncRunner loads the bundle from the file or the textarea and posts it for
+// the dry run and the import.
+const syncRunner = `function (opt) {
+    var root = $("#goadmin-sync"), bundle = root.find(".sync-bundle"), diff = root.find(".sync-diff");
+    var importBtn = root.find(".sync-import");
+
+    var show = function (changes) {
+        var table = $('<table class="table table-condensed"></table>');
+        table.append($("<tr></tr>").append($("<th></th>").text(opt.labels.table), $("<th></th>").text(opt.labels.key),
+            $("<th></th>").text(opt.labels.new_key), $("<th></th>").text(opt.labels.action),
+            $("<th></th>").text(opt.labels.field), $("<th></th>").text(opt.labels.old), $("<th></th>").text(opt.labels.new)));
+        var changed = 0;
+        $.each(changes || [], function (_, row) {
+            var cls = row.action === "insert" ? "success" : (row.action === "update" ? "warning" : "");
+            var first = $('<tr></tr>').addClass(cls).append($("<td></td>").text(row.table), $("<td></td>").text(row.key),
+                $("<td></td>").text(row.new_key), $("<td></td>").text(opt.labels[row.action] || row.action));
+            if (row.action !== "unchanged") { changed++; }
+            if (!row.changes || row.changes.length === 0) {
+                table.append(first.append($('<td colspan="3"></td>')));
+                return;
+            }
+            $.each(row.changes, function (i, c) {
+                var tr = i === 0 ? first : $('<tr></tr>').addClass(cls).append($('<td colspan="4"></td>'));
+                table.append(tr.append($("<td></td>").text(c.field), $('<td class="text-muted"></td>').text(c.old),
+                    $('<td class="text-success"></td>').text(c.new)));
+            });
+        });
+        diff.empty();
+        if (changed === 0) { diff.append($('<p class="text-muted"></p>').text(opt.labels.nothing)); }
+        diff.append(table);
+        return changed > 0;
+    };
+
+    var fail = function (res) {
+        var data = res.responseJSON || {};
+        if (data.data && data.data.token) { opt.token = data.data.token; }
+        swal(data.msg || "error", "", "error");
+        importBtn.prop("disabled", true);
+    };
+
+    root.find(".sync-file").on("change", function () {
+        var file = this.files && this.files[0];
+        if (!file) { return; }
+        var reader = new FileReader();
+        reader.onload = function () { bundle.val(reader.result); diff.empty(); importBtn.prop("disabled", true); };
+        reader.readAsText(file);
+    });
+    bundle.on("input", function () { importBtn.prop("disabled", true); });
+    root.find(".sync-preview").on("click", function () {
+        $.post(opt.preview, {bundle: bundle.val()}, function (res) {
+            importBtn.prop("disabled", !show(res.data.changes));
+        }).fail(fail);
+    });
+    importBtn.on("click", function () {
+        importBtn.prop("disabled", true);
+        var data = {bundle: bundle.val()};
+        data["` + form.TokenKey + `"] = opt.token;
+        $.post(opt.import, data, function () {
+            swal(opt.labels.success, "", "success");
+            $.pjax({url: opt.list, container: '#pjax-container'});
+        }).fail(fail);
+    });
+}`
@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"html/template"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// TimezoneHTML is the page html hook reporting the timezone of the browser in
+// a cookie when the timezone of the database is configured, so the datetime
+// filters are converted from the timezone of the user.
+func (h *Handler) TimezoneHTML(_ *context.Context) (template.HTML, template.HTML) {
+	if config.GetTimezone() == "" {
+		return "", ""
+	}
+	return "", template.HTML(`<script>(function () {
+    var tz = "";
+    try { tz = Intl.DateTimeFormat().resolvedOptions().timeZone || ""; } catch (e) {}
+    if (!tz) { return; }
+    var key = ` + utils.JSON(types.TimezoneCookie) + `;
+    if (document.cookie.indexOf(key + "=" + encodeURIComponent(tz)) !== -1) { return; }
+    document.cookie = key + "=" + encodeURIComponent(tz) + ";path=/;max-age=31536000;samesite=lax";
+})();</script>`)
+}
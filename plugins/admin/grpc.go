@@ -0,0 +1,21 @@
+//go:build !goadmin_nogrpc
+
+package admin
+
+import (
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/rpc"
+)
+
+// serveGRPC serve the gRPC data service of the tables on the grpcAddr in the
+// background, the writes are run by the handlers of the routes of the admin,
+// and the service is stopped by Close.
+func (admin *Admin) serveGRPC() {
+	srv := rpc.New(admin.Services, admin.Conn, admin.tableList, admin.App)
+	admin.stopGRPC = srv.Stop
+	go func() {
+		if err := srv.Serve(admin.grpcAddr); err != nil {
+			logger.Error("grpc service error: ", err)
+		}
+	}()
+}
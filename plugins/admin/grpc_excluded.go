@@ -0,0 +1,11 @@
+//go:build goadmin_nogrpc
+
+package admin
+
+import "github.com/purpose168/GoAdmin/modules/logger"
+
+// serveGRPC log the error as the gRPC data service is excluded by the
+// goadmin_nogrpc build tag.
+func (admin *Admin) serveGRPC() {
+	logger.Error("grpc service error: excluded by the goadmin_nogrpc build tag")
+}
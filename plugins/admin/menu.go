@@ -0,0 +1,198 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	"github.com/purpose168/GoAdmin/template/icon"
+)
+
+// AutoMenuOptions is the options of AutoMenu.
+type AutoMenuOptions struct {
+	// Icon is the icon of the menu items of the list pages, icon.Table by default.
+	Icon string
+	// CategoryIcon is the icon of the menus of the categories, icon.Folder by default.
+	CategoryIcon string
+	// DefaultCategory is the category of the generators without one, the menu
+	// items of them are at the top level when it is empty.
+	DefaultCategory string
+	// Exclude is the keys of the generators without the menu items.
+	Exclude []string
+	// Roles is the slugs of the roles which the created menus are granted to.
+	Roles []string
+}
+
+// AutoMenu create the menu items of the list pages of all the registered
+// generators, and update the titles and the parents of the existing ones. The
+// title of a menu item is the title of the info panel, and the menu items
+// are grouped into the top level menus of the categories set by
+// InfoPanel.SetCategory. The menus are synced when the plugin is initialized
+// if the connection is not ready yet.
+func (admin *Admin) AutoMenu(opts ...AutoMenuOptions) error {
+	o := AutoMenuOptions{}
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	admin.autoMenu = &o
+	if admin.Conn == nil {
+		return nil
+	}
+	return admin.syncMenu(o)
+}
+
+func (admin *Admin) syncMenu(opts AutoMenuOptions) error {
+	if opts.Icon == "" {
+		opts.Icon = icon.Table
+	}
+	if opts.CategoryIcon == "" {
+		opts.CategoryIcon = icon.Folder
+	}
+
+	exclude := make(map[string]bool)
+	for _, key := range admin.systemTables {
+		exclude[key] = true
+	}
+	for _, key := range opts.Exclude {
+		exclude[key] = true
+	}
+
+	roles, err := admin.menuRoles(opts.Roles)
+	if err != nil {
+		return err
+	}
+
+	var (
+		categories = make(map[string]int64)
+		orders     = make(map[int64]int64)
+	)
+	for _, key := range admin.tableList.Keys() {
+		gen, ok := admin.tableList.Get(key)
+		if !ok || exclude[key] {
+			continue
+		}
+		title, category := generatorMenu(key, gen)
+		if category == "" {
+			category = opts.DefaultCategory
+		}
+
+		var parent int64
+		if category != "" {
+			if parent, ok = categories[category]; !ok {
+				if parent, err = admin.categoryMenu(category, opts.CategoryIcon, roles); err != nil {
+					return err
+				}
+				categories[category] = parent
+			}
+		}
+
+		orders[parent]++
+		if err := admin.syncMenuItem(generatorMenuURI(key), title, opts.Icon, parent, orders[parent], roles); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generatorMenu return the title and the category of the info panel of the
+// generator, the title is the key when the generator fails.
+func generatorMenu(key string, gen table.Generator) (title, category string) {
+	title = key
+	defer func() {
+		if err := recover(); err != nil {
+			logger.Error("auto menu of generator ", key, " error: ", err)
+		}
+	}()
+	req, err := http.NewRequest(http.MethodGet, config.Url(generatorMenuURI(key)), nil)
+	if err != nil {
+		return
+	}
+	info := gen(context.NewContext(req)).GetInfo()
+	if info.Title != "" {
+		title = info.Title
+	}
+	return title, info.Category
+}
+
+// menuRoles return the ids of the roles of the slugs.
+func (admin *Admin) menuRoles(slugs []string) ([]string, error) {
+	if len(slugs) == 0 {
+		return nil, nil
+	}
+	args := make([]interface{}, len(slugs))
+	for i, slug := range slugs {
+		args[i] = slug
+	}
+	rows, err := db.WithDriver(admin.Conn).Table("goadmin_roles").Select("id").WhereIn("slug", args).All()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = fmt.Sprintf("%v", row["id"])
+	}
+	return ids, nil
+}
+
+// categoryMenu return the id of the top level menu of the category, which is
+// created if it does not exist.
+func (admin *Admin) categoryMenu(category, ico string, roles []string) (int64, error) {
+	item, err := db.WithDriver(admin.Conn).Table("goadmin_menu").
+		Where("parent_id", "=", 0).Where("title", "=", category).Where("uri", "=", "").
+		First()
+	if db.CheckError(err, db.QUERY) {
+		return 0, err
+	}
+	if item != nil {
+		return db.GetValueFromDatabaseType(db.Int, item["id"], false).ToInt64(), nil
+	}
+	menu, err := models.Menu().SetConn(admin.Conn).New(category, ico, "", "", "", 0, 0)
+	if db.CheckError(err, db.INSERT) {
+		return 0, err
+	}
+	return menu.Id, addMenuRoles(menu, roles)
+}
+
+// syncMenuItem create the menu item of the uri, or update the title and the
+// parent of the existing one.
+func (admin *Admin) syncMenuItem(uri, title, ico string, parent, order int64, roles []string) error {
+	item, err := db.WithDriver(admin.Conn).Table("goadmin_menu").Where("uri", "=", uri).First()
+	if db.CheckError(err, db.QUERY) {
+		return err
+	}
+	if item == nil {
+		menu, err := models.Menu().SetConn(admin.Conn).New(title, ico, uri, "", "", parent, order)
+		if db.CheckError(err, db.INSERT) {
+			return err
+		}
+		return addMenuRoles(menu, roles)
+	}
+
+	menu := models.Menu().SetConn(admin.Conn).MapToModel(item)
+	if menu.Title == title && menu.ParentId == parent {
+		return nil
+	}
+	pluginName := db.GetValueFromDatabaseType(db.Varchar, item["plugin_name"], false).String()
+	_, err = menu.Update(title, menu.Icon, menu.Uri, menu.Header, pluginName, parent)
+	if db.CheckError(err, db.UPDATE) {
+		return err
+	}
+	return nil
+}
+
+func addMenuRoles(menu models.MenuModel, roles []string) error {
+	if menu.Id == 0 {
+		return nil
+	}
+	for _, role := range roles {
+		if _, err := menu.AddRole(role); db.CheckError(err, db.INSERT) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package admin
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dbtest"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoMenu(t *testing.T) {
+	config.Initialize(&config.Config{UrlPrefix: "admin"})
+
+	gen := func(title, category string) table.Generator {
+		return func(ctx *context.Context) table.Table {
+			tb := table.NewDefaultTable(ctx, table.DefaultConfigWithDriver(db.DriverMysql))
+			tb.GetInfo().SetTitle(title).SetCategory(category)
+			return tb
+		}
+	}
+
+	var lastID int64 = 10
+	conn := dbtest.New(db.DriverMysql).
+		OnQueryFn("from `goadmin_menu`", func(query string, args []interface{}) ([]map[string]interface{}, error) {
+			if len(args) == 1 && args[0] == "/info/users" {
+				return []map[string]interface{}{{"id": int64(1), "parent_id": int64(0), "title": "Users", "icon": "fa-user", "uri": "/info/users"}}, nil
+			}
+			if len(args) == 1 && args[0] == "/info/tags" {
+				return []map[string]interface{}{{"id": int64(2), "parent_id": int64(11), "title": "Tags", "uri": "/info/tags"}}, nil
+			}
+			return nil, nil
+		}).
+		OnQuery("from `goadmin_roles`", map[string]interface{}{"id": int64(3)}).
+		OnExecFn("insert into `goadmin_menu`", func(string, []interface{}) (sql.Result, error) {
+			lastID++
+			return dbtest.Result{LastID: lastID, Affected: 1}, nil
+		}).
+		OnExec("", dbtest.Result{Affected: 1})
+
+	admin := NewAdmin(table.GeneratorList{
+		"posts": gen("Posts", "Content"),
+		"tags":  gen("Tags", "Content"),
+		"users": gen("Accounts", ""),
+		"logs":  gen("Logs", ""),
+	})
+	admin.Conn = conn
+	admin.systemTables = []string{"manager"}
+
+	assert.Nil(t, admin.AutoMenu(AutoMenuOptions{DefaultCategory: "Others", Exclude: []string{"logs"}, Roles: []string{"operator"}}))
+
+	var menus, roles, updates [][]interface{}
+	for _, call := range conn.Calls() {
+		switch {
+		case !call.Exec:
+		case strings.HasPrefix(call.Query, "insert into `goadmin_menu`"):
+			menus = append(menus, call.Args)
+		case strings.HasPrefix(call.Query, "insert into `goadmin_role_menu`"):
+			roles = append(roles, call.Args)
+		case strings.HasPrefix(call.Query, "update `goadmin_menu`"):
+			updates = append(updates, call.Args)
+		}
+	}
+
+	// the menu of the category "Content" (11), the menu item of posts (12)
+	// and the menu of the category "Others" (13) are created and granted to
+	// the role. users is renamed and moved into "Others", tags is unchanged.
+	assert.Equal(t, 3, len(menus))
+	assert.True(t, contains(menus[0], "Content"))
+	assert.True(t, contains(menus[1], "Posts") && contains(menus[1], "/info/posts") && contains(menus[1], int64(11)))
+	assert.True(t, contains(menus[2], "Others"))
+	assert.Equal(t, 3, len(roles))
+	assert.Equal(t, 1, len(updates))
+	assert.True(t, contains(updates[0], "Accounts") && contains(updates[0], int64(13)) && contains(updates[0], "fa-user"))
+}
+
+func contains(args []interface{}, v interface{}) bool {
+	for _, arg := range args {
+		if arg == v {
+			return true
+		}
+	}
+	return false
+}
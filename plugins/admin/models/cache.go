@@ -0,0 +1,79 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+)
+
+// The roles, the permissions and the menus of the users are queried on every
+// request. When config.AuthCacheTTL is set, the rows are cached by the user or
+// the set of the roles, until the ttl or the changes made by the admin.
+var authCache = struct {
+	lock       sync.RWMutex
+	generation uint64
+	items      map[string]authCacheItem
+}{items: make(map[string]authCacheItem)}
+
+type authCacheItem struct {
+	rows   []map[string]interface{}
+	expire time.Time
+}
+
+// CachedRows return the rows of the key, which are loaded by the load and
+// cached for config.AuthCacheTTL. The rows are shared by the callers and must
+// not be modified. The load is called every time when the cache is disabled,
+// and its rows are not cached when it fails.
+func CachedRows(key string, load func() ([]map[string]interface{}, error)) []map[string]interface{} {
+	ttl := config.GetAuthCacheTTL()
+	if ttl <= 0 {
+		rows, _ := load()
+		return rows
+	}
+
+	now := time.Now()
+	authCache.lock.RLock()
+	item, ok := authCache.items[key]
+	generation := authCache.generation
+	authCache.lock.RUnlock()
+	if ok && now.Before(item.expire) {
+		return item.rows
+	}
+
+	rows, err := load()
+	if err != nil {
+		return rows
+	}
+
+	authCache.lock.Lock()
+	// the rows loaded before an invalidation may be stale.
+	if generation == authCache.generation {
+		authCache.items[key] = authCacheItem{rows: rows, expire: now.Add(ttl)}
+	}
+	authCache.lock.Unlock()
+	return rows
+}
+
+// InvalidateAuthCache drop the cached rows of CachedRows. It is called after
+// the changes of the menus, the roles, the permissions and their assignments,
+// the other instances see the changes after config.AuthCacheTTL.
+func InvalidateAuthCache() {
+	authCache.lock.Lock()
+	authCache.generation++
+	authCache.items = make(map[string]authCacheItem)
+	authCache.lock.Unlock()
+}
+
+// RoleSetKey return the cache key of the set of the role ids.
+func RoleSetKey(ids []interface{}) string {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = fmt.Sprintf("%v", id)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedRows(t *testing.T) {
+	config.Initialize(&config.Config{AuthCacheTTL: 60})
+	defer InvalidateAuthCache()
+
+	loads := 0
+	load := func() ([]map[string]interface{}, error) {
+		loads++
+		return []map[string]interface{}{{"id": int64(loads)}}, nil
+	}
+
+	assert.Equal(t, int64(1), CachedRows("roles:user:1", load)[0]["id"])
+	assert.Equal(t, int64(1), CachedRows("roles:user:1", load)[0]["id"])
+	assert.Equal(t, 1, loads)
+
+	InvalidateAuthCache()
+	assert.Equal(t, int64(2), CachedRows("roles:user:1", load)[0]["id"])
+	assert.Equal(t, 2, loads)
+
+	// the failed loads are not cached.
+	fails := 0
+	fail := func() ([]map[string]interface{}, error) {
+		fails++
+		return nil, errors.New("connection refused")
+	}
+	assert.Nil(t, CachedRows("roles:user:2", fail))
+	assert.Nil(t, CachedRows("roles:user:2", fail))
+	assert.Equal(t, 2, fails)
+
+	// the rows loaded before an invalidation are not cached.
+	CachedRows("roles:user:3", func() ([]map[string]interface{}, error) {
+		InvalidateAuthCache()
+		return []map[string]interface{}{{"id": int64(0)}}, nil
+	})
+	assert.Equal(t, int64(3), CachedRows("roles:user:3", load)[0]["id"])
+}
+
+func TestRoleSetKey(t *testing.T) {
+	assert.Equal(t, "1,2,3", RoleSetKey([]interface{}{int64(3), int64(1), int64(2)}))
+	assert.Equal(t, "", RoleSetKey(nil))
+}
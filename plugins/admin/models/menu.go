@@ -47,8 +47,9 @@ func (t MenuModel) Find(id interface{}) MenuModel {
 
 // New create a new menu model.
 func (t MenuModel) New(title, icon, uri, header, pluginName string, parentId, order int64) (MenuModel, error) {
+	defer InvalidateAuthCache()
 
-	id, err := t.Table(t.TableName).Insert(dialect.H{
+	id, err := t.Table(t.TableName).InsertAndGetID(dialect.H{
 		"title":       title,
 		"parent_id":   parentId,
 		"icon":        icon,
@@ -56,7 +57,7 @@ func (t MenuModel) New(title, icon, uri, header, pluginName string, parentId, or
 		"order":       order,
 		"header":      header,
 		"plugin_name": pluginName,
-	})
+	}, "title", "parent_id", "uri")
 
 	t.Id = id
 	t.Title = title
@@ -70,6 +71,7 @@ func (t MenuModel) New(title, icon, uri, header, pluginName string, parentId, or
 
 // Delete delete the menu model.
 func (t MenuModel) Delete() {
+	defer InvalidateAuthCache()
 	_ = t.Table(t.TableName).Where("id", "=", t.Id).Delete()
 	_ = t.Table("goadmin_role_menu").Where("menu_id", "=", t.Id).Delete()
 	items, _ := t.Table(t.TableName).Where("parent_id", "=", t.Id).All()
@@ -87,6 +89,7 @@ func (t MenuModel) Delete() {
 
 // Update update the menu model.
 func (t MenuModel) Update(title, icon, uri, header, pluginName string, parentId int64) (int64, error) {
+	defer InvalidateAuthCache()
 	return t.Table(t.TableName).
 		Where("id", "=", t.Id).
 		Update(dialect.H{
@@ -109,6 +112,7 @@ type OrderItem struct {
 
 // ResetOrder update the order of menu models.
 func (t MenuModel) ResetOrder(data []byte) {
+	defer InvalidateAuthCache()
 
 	var items OrderItems
 	_ = json.Unmarshal(data, &items)
@@ -177,6 +181,7 @@ func (t MenuModel) CheckRole(roleId string) bool {
 func (t MenuModel) AddRole(roleId string) (int64, error) {
 	if roleId != "" {
 		if !t.CheckRole(roleId) {
+			defer InvalidateAuthCache()
 			return t.Table("goadmin_role_menu").
 				Insert(dialect.H{
 					"role_id": roleId,
@@ -189,6 +194,7 @@ func (t MenuModel) AddRole(roleId string) (int64, error) {
 
 // DeleteRoles delete roles with menu.
 func (t MenuModel) DeleteRoles() error {
+	defer InvalidateAuthCache()
 	return t.Table("goadmin_role_menu").
 		Where("menu_id", "=", t.Id).
 		Delete()
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+)
+
+// PrefixedEntry is a menu or a permission whose stored path has the current
+// or a former url prefix. The stored paths are relative to the url prefix,
+// the prefixed ones break when the prefix is changed.
+type PrefixedEntry struct {
+	Table string
+	Id    int64
+	Name  string
+	Path  string
+	// Fixed is the path without the prefixes.
+	Fixed string
+}
+
+// PrefixedEntries return the menus and the permissions whose stored paths
+// have the url prefixes, see config.RemoveURLPrefixes.
+func PrefixedEntries(conn db.Connection) ([]PrefixedEntry, error) {
+	entries := make([]PrefixedEntry, 0)
+
+	menus, err := db.WithDriver(conn).Table("goadmin_menu").Select("id", "title", "uri").All()
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range menus {
+		uri, _ := m["uri"].(string)
+		if fixed := config.RemoveURLPrefixes(uri); fixed != uri {
+			title, _ := m["title"].(string)
+			entries = append(entries, PrefixedEntry{Table: "goadmin_menu", Id: m["id"].(int64),
+				Name: title, Path: uri, Fixed: fixed})
+		}
+	}
+
+	permissions, err := db.WithDriver(conn).Table("goadmin_permissions").Select("id", "name", "http_path").All()
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range permissions {
+		path, _ := m["http_path"].(string)
+		paths := strings.Split(path, "\n")
+		changed := false
+		for i := range paths {
+			p := strings.TrimSpace(paths[i])
+			if fixed := config.RemoveURLPrefixes(p); fixed != p {
+				paths[i] = fixed
+				changed = true
+			}
+		}
+		if changed {
+			fixed := strings.Join(paths, "\n")
+			name, _ := m["name"].(string)
+			entries = append(entries, PrefixedEntry{Table: "goadmin_permissions", Id: m["id"].(int64),
+				Name: name, Path: path, Fixed: fixed})
+		}
+	}
+
+	return entries, nil
+}
+
+// MigratePrefix rewrite the stored paths of the PrefixedEntries without the
+// url prefixes, and return the number of the rewritten entries. It should be
+// called after the url prefix is changed, with the former one added into the
+// FormerUrlPrefixes of the config.
+func MigratePrefix(conn db.Connection) (int, error) {
+	defer InvalidateAuthCache()
+
+	entries, err := PrefixedEntries(conn)
+	if err != nil {
+		return 0, err
+	}
+	for i, e := range entries {
+		field := "uri"
+		if e.Table == "goadmin_permissions" {
+			field = "http_path"
+		}
+		_, err := db.WithDriver(conn).Table(e.Table).Where("id", "=", e.Id).Update(dialect.H{
+			field: e.Fixed,
+		})
+		if db.CheckError(err, db.UPDATE) {
+			return i, err
+		}
+	}
+	return len(entries), nil
+}
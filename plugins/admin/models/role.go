@@ -77,6 +77,7 @@ func (t RoleModel) New(name, slug string) (RoleModel, error) {
 
 // Update update the role model.
 func (t RoleModel) Update(name, slug string) (int64, error) {
+	defer InvalidateAuthCache()
 
 	return t.WithTx(t.Tx).Table(t.TableName).
 		Where("id", "=", t.Id).
@@ -98,6 +99,7 @@ func (t RoleModel) CheckPermission(permissionId string) bool {
 
 // DeletePermissions delete all the permissions of role.
 func (t RoleModel) DeletePermissions() error {
+	defer InvalidateAuthCache()
 	return t.WithTx(t.Tx).Table("goadmin_role_permissions").
 		Where("role_id", "=", t.Id).
 		Delete()
@@ -107,6 +109,7 @@ func (t RoleModel) DeletePermissions() error {
 func (t RoleModel) AddPermission(permissionId string) (int64, error) {
 	if permissionId != "" {
 		if !t.CheckPermission(permissionId) {
+			defer InvalidateAuthCache()
 			return t.WithTx(t.Tx).Table("goadmin_role_permissions").
 				Insert(dialect.H{
 					"permission_id": permissionId,
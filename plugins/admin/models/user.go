@@ -2,6 +2,7 @@ package models
 
 import (
 	"database/sql"
+	"fmt"
 	"net/url"
 	"regexp"
 	"strconv"
@@ -132,6 +133,12 @@ func (t UserModel) CheckPermissionByUrlMethod(path, method string, formParams ur
 		return true
 	}
 
+	// the notifications and the keep-alive are of the own session.
+	switch strings.Split(path, "?")[0] {
+	case config.Url("/notifications"), config.Url("/keepalive"):
+		return true
+	}
+
 	if path != "/" && path[len(path)-1] == '/' {
 		path = path[:len(path)-1]
 	}
@@ -155,7 +162,7 @@ func (t UserModel) CheckPermissionByUrlMethod(path, method string, formParams ur
 
 			for i := 0; i < len(v.HttpPath); i++ {
 
-				matchPath := config.Url(t.Template(strings.TrimSpace(v.HttpPath[i])))
+				matchPath := config.Url(t.Template(config.NormalizePath(strings.TrimSpace(v.HttpPath[i]))))
 				matchPath, matchParam := getParam(matchPath)
 
 				if matchPath == path {
@@ -243,12 +250,14 @@ func (t UserModel) UpdateAvatar(avatar string) {
 
 // WithRoles query the role info of the user.
 func (t UserModel) WithRoles() UserModel {
-	roleModel, _ := t.Table("goadmin_role_users").
-		LeftJoin("goadmin_roles", "goadmin_roles.id", "=", "goadmin_role_users.role_id").
-		Where("user_id", "=", t.Id).
-		Select("goadmin_roles.id", "goadmin_roles.name", "goadmin_roles.slug",
-			"goadmin_roles.created_at", "goadmin_roles.updated_at").
-		All()
+	roleModel := CachedRows(fmt.Sprintf("roles:user:%d", t.Id), func() ([]map[string]interface{}, error) {
+		return t.Table("goadmin_role_users").
+			LeftJoin("goadmin_roles", "goadmin_roles.id", "=", "goadmin_role_users.role_id").
+			Where("user_id", "=", t.Id).
+			Select("goadmin_roles.id", "goadmin_roles.name", "goadmin_roles.slug",
+				"goadmin_roles.created_at", "goadmin_roles.updated_at").
+			All()
+	})
 
 	for _, role := range roleModel {
 		t.Roles = append(t.Roles, Role().MapToModel(role))
@@ -276,27 +285,33 @@ func (t UserModel) GetAllRoleId() []interface{} {
 // WithPermissions query the permission info of the user.
 func (t UserModel) WithPermissions() UserModel {
 
+	// the cached rows are shared, the permissions are collected into a new
+	// slice.
 	var permissions = make([]map[string]interface{}, 0)
 
 	roleIds := t.GetAllRoleId()
 
 	if len(roleIds) > 0 {
-		permissions, _ = t.Table("goadmin_role_permissions").
-			LeftJoin("goadmin_permissions", "goadmin_permissions.id", "=", "goadmin_role_permissions.permission_id").
-			WhereIn("role_id", roleIds).
+		permissions = append(permissions, CachedRows("permissions:roles:"+RoleSetKey(roleIds), func() ([]map[string]interface{}, error) {
+			return t.Table("goadmin_role_permissions").
+				LeftJoin("goadmin_permissions", "goadmin_permissions.id", "=", "goadmin_role_permissions.permission_id").
+				WhereIn("role_id", roleIds).
+				Select("goadmin_permissions.http_method", "goadmin_permissions.http_path",
+					"goadmin_permissions.id", "goadmin_permissions.name", "goadmin_permissions.slug",
+					"goadmin_permissions.created_at", "goadmin_permissions.updated_at").
+				All()
+		})...)
+	}
+
+	userPermissions := CachedRows(fmt.Sprintf("permissions:user:%d", t.Id), func() ([]map[string]interface{}, error) {
+		return t.Table("goadmin_user_permissions").
+			LeftJoin("goadmin_permissions", "goadmin_permissions.id", "=", "goadmin_user_permissions.permission_id").
+			Where("user_id", "=", t.Id).
 			Select("goadmin_permissions.http_method", "goadmin_permissions.http_path",
 				"goadmin_permissions.id", "goadmin_permissions.name", "goadmin_permissions.slug",
 				"goadmin_permissions.created_at", "goadmin_permissions.updated_at").
 			All()
-	}
-
-	userPermissions, _ := t.Table("goadmin_user_permissions").
-		LeftJoin("goadmin_permissions", "goadmin_permissions.id", "=", "goadmin_user_permissions.permission_id").
-		Where("user_id", "=", t.Id).
-		Select("goadmin_permissions.http_method", "goadmin_permissions.http_path",
-			"goadmin_permissions.id", "goadmin_permissions.name", "goadmin_permissions.slug",
-			"goadmin_permissions.created_at", "goadmin_permissions.updated_at").
-		All()
+	})
 
 	permissions = append(permissions, userPermissions...)
 
@@ -323,18 +338,22 @@ func (t UserModel) WithMenus() UserModel {
 	var menuIdsModel []map[string]interface{}
 
 	if t.IsSuperAdmin() {
-		menuIdsModel, _ = t.Table("goadmin_role_menu").
-			LeftJoin("goadmin_menu", "goadmin_menu.id", "=", "goadmin_role_menu.menu_id").
-			Select("menu_id", "parent_id").
-			All()
-	} else {
-		rolesId := t.GetAllRoleId()
-		if len(rolesId) > 0 {
-			menuIdsModel, _ = t.Table("goadmin_role_menu").
+		menuIdsModel = CachedRows("menus:super", func() ([]map[string]interface{}, error) {
+			return t.Table("goadmin_role_menu").
 				LeftJoin("goadmin_menu", "goadmin_menu.id", "=", "goadmin_role_menu.menu_id").
-				WhereIn("goadmin_role_menu.role_id", rolesId).
 				Select("menu_id", "parent_id").
 				All()
+		})
+	} else {
+		rolesId := t.GetAllRoleId()
+		if len(rolesId) > 0 {
+			menuIdsModel = CachedRows("menus:roles:"+RoleSetKey(rolesId), func() ([]map[string]interface{}, error) {
+				return t.Table("goadmin_role_menu").
+					LeftJoin("goadmin_menu", "goadmin_menu.id", "=", "goadmin_role_menu.menu_id").
+					WhereIn("goadmin_role_menu.role_id", rolesId).
+					Select("menu_id", "parent_id").
+					All()
+			})
 		}
 	}
 
@@ -360,12 +379,12 @@ func (t UserModel) WithMenus() UserModel {
 // New create a user model.
 func (t UserModel) New(username, password, name, avatar string) (UserModel, error) {
 
-	id, err := t.WithTx(t.Tx).Table(t.TableName).Insert(dialect.H{
+	id, err := t.WithTx(t.Tx).Table(t.TableName).InsertAndGetID(dialect.H{
 		"username": username,
 		"password": password,
 		"name":     name,
 		"avatar":   avatar,
-	})
+	}, "username")
 
 	t.Id = id
 	t.UserName = username
@@ -422,6 +441,7 @@ func (t UserModel) CheckRoleId(roleId string) bool {
 
 // DeleteRoles delete all the roles of the user model.
 func (t UserModel) DeleteRoles() error {
+	defer InvalidateAuthCache()
 	return t.Table("goadmin_role_users").
 		Where("user_id", "=", t.Id).
 		Delete()
@@ -431,6 +451,7 @@ func (t UserModel) DeleteRoles() error {
 func (t UserModel) AddRole(roleId string) (int64, error) {
 	if roleId != "" {
 		if !t.CheckRoleId(roleId) {
+			defer InvalidateAuthCache()
 			return t.WithTx(t.Tx).Table("goadmin_role_users").
 				Insert(dialect.H{
 					"role_id": roleId,
@@ -472,8 +493,37 @@ func (t UserModel) CheckPermission(permission string) bool {
 	return false
 }
 
+// Can check the user has all the permissions of the slugs, the super
+// administrator has all the permissions.
+func (t UserModel) Can(slugs ...string) bool {
+	if t.IsSuperAdmin() {
+		return true
+	}
+	for _, slug := range slugs {
+		if !t.CheckPermission(slug) {
+			return false
+		}
+	}
+	return true
+}
+
+// CanAny check the user has any of the permissions of the slugs, the super
+// administrator has all the permissions.
+func (t UserModel) CanAny(slugs ...string) bool {
+	if t.IsSuperAdmin() {
+		return true
+	}
+	for _, slug := range slugs {
+		if t.CheckPermission(slug) {
+			return true
+		}
+	}
+	return false
+}
+
 // DeletePermissions delete all the permissions of the user model.
 func (t UserModel) DeletePermissions() error {
+	defer InvalidateAuthCache()
 	return t.WithTx(t.Tx).Table("goadmin_user_permissions").
 		Where("user_id", "=", t.Id).
 		Delete()
@@ -483,6 +533,7 @@ func (t UserModel) DeletePermissions() error {
 func (t UserModel) AddPermission(permissionId string) (int64, error) {
 	if permissionId != "" {
 		if !t.CheckPermissionById(permissionId) {
+			defer InvalidateAuthCache()
 			return t.WithTx(t.Tx).Table("goadmin_user_permissions").
 				Insert(dialect.H{
 					"permission_id": permissionId,
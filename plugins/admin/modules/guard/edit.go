@@ -105,9 +105,7 @@ func (g *Guard) EditForm(ctx *context.Context) {
 	}
 	token := ctx.FormValue(form.TokenKey)
 
-	if !auth.GetTokenService(g.services.Get(auth.TokenServiceKey)).CheckToken(token) {
-		alert(ctx, panel, errors.EditFailWrongToken, g.conn, g.navBtns)
-		ctx.Abort()
+	if !g.checkFormToken(ctx, panel, token, errors.EditFailWrongToken) {
 		return
 	}
 
@@ -124,10 +122,24 @@ func (g *Guard) EditForm(ctx *context.Context) {
 
 	var (
 		multiForm = ctx.Request.MultipartForm
-		id        = multiForm.Value[panel.GetPrimaryKey().Name][0]
 		values    = ctx.Request.MultipartForm.Value
+		pk        = panel.GetPrimaryKey().Name
+		id        = form.Values(values).Get(pk)
 	)
 
+	// the api clients can tell the row by the edit pk as the edit page does.
+	if id == "" {
+		id = form.Values(values).Get(constant.EditPKKey)
+		values[pk] = []string{id}
+	}
+	delete(values, constant.EditPKKey)
+
+	if id == "" {
+		alert(ctx, panel, errors.WrongID, g.conn, g.navBtns)
+		ctx.Abort()
+		return
+	}
+
 	ctx.SetUserValue(editFormParamKey, &EditFormParam{
 		Panel:        panel,
 		Id:           id,
@@ -153,6 +165,22 @@ func GetEditFormParam(ctx *context.Context) *EditFormParam {
 	return ctx.UserValue[editFormParamKey].(*EditFormParam)
 }
 
+// checkFormToken check the single use token of the form and abort the request
+// when it is wrong, the forms submitted twice are told apart from the wrong
+// tokens.
+func (g *Guard) checkFormToken(ctx *context.Context, panel table.Table, token, wrongTokenMsg string) bool {
+	srv := auth.GetTokenService(g.services.Get(auth.TokenServiceKey))
+	if srv.CheckToken(token) {
+		return true
+	}
+	if srv.Used(token) {
+		wrongTokenMsg = errors.DuplicateSubmit
+	}
+	alert(ctx, panel, wrongTokenMsg, g.conn, g.navBtns)
+	ctx.Abort()
+	return false
+}
+
 func alert(ctx *context.Context, panel table.Table, msg string, conn db.Connection, btns *types.Buttons) {
 	if ctx.WantJSON() {
 		response.BadRequest(ctx, msg)
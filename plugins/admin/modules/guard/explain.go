@@ -0,0 +1,43 @@
+package guard
+
+import (
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/errors"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+)
+
+type ExplainParam struct {
+	Panel  table.ExplainTable
+	Table  table.Table
+	Prefix string
+	Param  parameter.Parameters
+}
+
+// Explain check the permission of showing the plan of the list query, the
+// query of the list page is given by the parameters of the request.
+func (g *Guard) Explain(ctx *context.Context) {
+	panel, prefix := g.table(ctx)
+
+	explainPanel, ok := panel.(table.ExplainTable)
+	if !ok || !auth.Auth(ctx).Can(table.QueryInfoPermission) {
+		alert(ctx, panel, errors.OperationNotAllow, g.conn, g.navBtns)
+		ctx.Abort()
+		return
+	}
+
+	info := panel.GetInfo()
+
+	ctx.SetUserValue(explainParamKey, &ExplainParam{
+		Panel:  explainPanel,
+		Table:  panel,
+		Prefix: prefix,
+		Param:  parameter.GetParam(ctx.Request.URL, info.DefaultPageSize, info.SortField, info.GetSort()),
+	})
+	ctx.Next()
+}
+
+func GetExplainParam(ctx *context.Context) *ExplainParam {
+	return ctx.UserValue[explainParamKey].(*ExplainParam)
+}
@@ -0,0 +1,46 @@
+package guard
+
+import (
+	"strings"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/errors"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+)
+
+type FavoriteParam struct {
+	Panel  table.FavoriteTable
+	Prefix string
+	ID     string
+}
+
+// Favorite check the record toggled by the star of the list and detail page.
+func (g *Guard) Favorite(ctx *context.Context) {
+	panel, prefix := g.table(ctx)
+
+	favoritePanel, ok := panel.(table.FavoriteTable)
+	if !ok || panel.GetInfo().FavoriteField == "" {
+		response.BadRequest(ctx, errors.OperationNotAllow)
+		ctx.Abort()
+		return
+	}
+
+	id := strings.TrimSpace(ctx.FormValue("id"))
+	if id == "" {
+		response.BadRequest(ctx, "wrong "+panel.GetPrimaryKey().Name)
+		ctx.Abort()
+		return
+	}
+
+	ctx.SetUserValue(favoriteParamKey, &FavoriteParam{
+		Panel:  favoritePanel,
+		Prefix: prefix,
+		ID:     id,
+	})
+	ctx.Next()
+}
+
+func GetFavoriteParam(ctx *context.Context) *FavoriteParam {
+	return ctx.UserValue[favoriteParamKey].(*FavoriteParam)
+}
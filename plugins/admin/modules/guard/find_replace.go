@@ -0,0 +1,105 @@
+package guard
+
+import (
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/errors"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+)
+
+type FindReplaceParam struct {
+	Panel       table.FindReplaceTable
+	Table       table.Table
+	Prefix      string
+	Param       parameter.Parameters
+	FindReplace table.FindReplace
+}
+
+// ShowFindReplace check the permission of showing the find and replace page,
+// the filtered set is given by the parameters of the request.
+func (g *Guard) ShowFindReplace(ctx *context.Context) {
+	panel, prefix := g.table(ctx)
+
+	frPanel, ok := findReplaceTable(ctx, panel)
+	if !ok {
+		alert(ctx, panel, errors.OperationNotAllow, g.conn, g.navBtns)
+		ctx.Abort()
+		return
+	}
+
+	g.setFindReplaceParam(ctx, panel, frPanel, prefix)
+}
+
+// FindReplacePreview check the find and replace posted for the preview.
+func (g *Guard) FindReplacePreview(ctx *context.Context) {
+	g.findReplace(ctx, false)
+}
+
+// FindReplaceApply check the find and replace and the token posted for the
+// apply.
+func (g *Guard) FindReplaceApply(ctx *context.Context) {
+	g.findReplace(ctx, true)
+}
+
+func (g *Guard) findReplace(ctx *context.Context, checkToken bool) {
+	panel, prefix := g.table(ctx)
+
+	frPanel, ok := findReplaceTable(ctx, panel)
+	if !ok {
+		response.BadRequest(ctx, errors.OperationNotAllow)
+		ctx.Abort()
+		return
+	}
+
+	if checkToken && !auth.GetTokenService(g.services.Get(auth.TokenServiceKey)).CheckToken(ctx.FormValue(form.TokenKey)) {
+		response.BadRequest(ctx, errors.EditFailWrongToken)
+		ctx.Abort()
+		return
+	}
+
+	if ctx.Request.PostFormValue("field") == "" || ctx.Request.PostFormValue("find") == "" {
+		response.BadRequest(ctx, "wrong find and replace")
+		ctx.Abort()
+		return
+	}
+
+	g.setFindReplaceParam(ctx, panel, frPanel, prefix)
+}
+
+// setFindReplaceParam read the find and replace from the posted form only,
+// as the query of the request is the filters of the list page.
+func (g *Guard) setFindReplaceParam(ctx *context.Context, panel table.Table, frPanel table.FindReplaceTable, prefix string) {
+	info := panel.GetInfo()
+
+	ctx.SetUserValue(findReplaceParamKey, &FindReplaceParam{
+		Panel:  frPanel,
+		Table:  panel,
+		Prefix: prefix,
+		Param:  parameter.GetParam(ctx.Request.URL, info.DefaultPageSize, info.SortField, info.GetSort()),
+		FindReplace: table.FindReplace{
+			Field:     ctx.Request.PostFormValue("field"),
+			Find:      ctx.Request.PostFormValue("find"),
+			Replace:   ctx.Request.PostFormValue("replace"),
+			MatchCase: ctx.Request.PostFormValue("match_case") == "1",
+		},
+	})
+	ctx.Next()
+}
+
+// findReplaceTable check the table supports the find and replace and the
+// user has the permission of it.
+func findReplaceTable(ctx *context.Context, panel table.Table) (table.FindReplaceTable, bool) {
+	frPanel, ok := panel.(table.FindReplaceTable)
+	if !ok || !panel.GetInfo().FindReplace || !panel.GetEditable() ||
+		!auth.Auth(ctx).Can(table.FindReplacePermission) {
+		return nil, false
+	}
+	return frPanel, true
+}
+
+func GetFindReplaceParam(ctx *context.Context) *FindReplaceParam {
+	return ctx.UserValue[findReplaceParamKey].(*FindReplaceParam)
+}
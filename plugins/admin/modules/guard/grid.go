@@ -0,0 +1,63 @@
+package guard
+
+import (
+	"encoding/json"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/errors"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+)
+
+type GridParam struct {
+	Panel  table.GridTable
+	Prefix string
+	Rows   []table.GridRow
+}
+
+// GridPreview check the rows posted by the grid edit page.
+func (g *Guard) GridPreview(ctx *context.Context) {
+	g.grid(ctx, false)
+}
+
+// GridCommit check the rows and the token posted by the grid edit page.
+func (g *Guard) GridCommit(ctx *context.Context) {
+	g.grid(ctx, true)
+}
+
+func (g *Guard) grid(ctx *context.Context, checkToken bool) {
+	panel, prefix := g.table(ctx)
+
+	gridPanel, ok := panel.(table.GridTable)
+	if !ok || !panel.GetInfo().GridEdit || !panel.GetEditable() {
+		response.BadRequest(ctx, errors.OperationNotAllow)
+		ctx.Abort()
+		return
+	}
+
+	if checkToken && !auth.GetTokenService(g.services.Get(auth.TokenServiceKey)).CheckToken(ctx.FormValue(form.TokenKey)) {
+		response.BadRequest(ctx, errors.EditFailWrongToken)
+		ctx.Abort()
+		return
+	}
+
+	var rows []table.GridRow
+	if err := json.Unmarshal([]byte(ctx.FormValue("rows")), &rows); err != nil || len(rows) == 0 {
+		response.BadRequest(ctx, "wrong grid rows")
+		ctx.Abort()
+		return
+	}
+
+	ctx.SetUserValue(gridParamKey, &GridParam{
+		Panel:  gridPanel,
+		Prefix: prefix,
+		Rows:   rows,
+	})
+	ctx.Next()
+}
+
+func GetGridParam(ctx *context.Context) *GridParam {
+	return ctx.UserValue[gridParamKey].(*GridParam)
+}
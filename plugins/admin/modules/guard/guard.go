@@ -30,14 +30,15 @@ func New(s service.List, c db.Connection, t table.GeneratorList, b *types.Button
 
 func (g *Guard) table(ctx *context.Context) (table.Table, string) {
 	prefix := ctx.Query(constant.PrefixKey)
-	return g.tableList[prefix](ctx), prefix
+	gen, _ := g.tableList.Get(prefix)
+	return gen(ctx), prefix
 }
 
 func (g *Guard) CheckPrefix(ctx *context.Context) {
 
 	prefix := ctx.Query(constant.PrefixKey)
 
-	if _, ok := g.tableList[prefix]; !ok {
+	if _, ok := g.tableList.Get(prefix); !ok {
 		if ctx.Headers(constant.PjaxHeader) == "" && ctx.Method() != "GET" {
 			response.BadRequest(ctx, errors.Msg)
 		} else {
@@ -61,6 +62,13 @@ const (
 	newMenuParamKey     = "new_menu_param"
 	newFormParamKey     = "new_form_param"
 	updateParamKey      = "update_param"
+	gridParamKey        = "grid_param"
+	reorderParamKey     = "reorder_param"
+	favoriteParamKey    = "favorite_param"
+	explainParamKey     = "explain_param"
+	syncParamKey        = "sync_param"
+	reportParamKey      = "report_param"
+	findReplaceParamKey = "find_replace_param"
 	showFormParamKey    = "show_form_param"
 	showNewFormParam    = "show_new_form_param"
 )
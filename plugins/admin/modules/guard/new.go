@@ -6,9 +6,7 @@ import (
 	"strings"
 
 	"github.com/purpose168/GoAdmin/context"
-	"github.com/purpose168/GoAdmin/modules/auth"
 	"github.com/purpose168/GoAdmin/modules/config"
-	"github.com/purpose168/GoAdmin/modules/db"
 	"github.com/purpose168/GoAdmin/modules/errors"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
@@ -86,13 +84,10 @@ func (g *Guard) NewForm(ctx *context.Context) {
 	var (
 		previous      = ctx.FormValue(form.PreviousKey)
 		panel, prefix = g.table(ctx)
-		conn          = db.GetConnection(g.services)
 		token         = ctx.FormValue(form.TokenKey)
 	)
 
-	if !auth.GetTokenService(g.services.Get(auth.TokenServiceKey)).CheckToken(token) {
-		alert(ctx, panel, errors.CreateFailWrongToken, conn, g.navBtns)
-		ctx.Abort()
+	if !g.checkFormToken(ctx, panel, token, errors.CreateFailWrongToken) {
 		return
 	}
 
@@ -0,0 +1,60 @@
+package guard
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/errors"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+)
+
+type ReorderParam struct {
+	Panel  table.ReorderTable
+	Prefix string
+	IDs    []string
+	Desc   bool
+}
+
+// RowReorder check the rows posted by the drag and drop of the list page. The
+// query of the list page tells whether the rows are sorted descending.
+func (g *Guard) RowReorder(ctx *context.Context) {
+	panel, prefix := g.table(ctx)
+
+	reorderPanel, ok := panel.(table.ReorderTable)
+	info := panel.GetInfo()
+	if !ok || info.RowReorderField == "" || !panel.GetEditable() {
+		response.BadRequest(ctx, errors.OperationNotAllow)
+		ctx.Abort()
+		return
+	}
+
+	ids := make([]string, 0)
+	for _, id := range strings.Split(ctx.FormValue("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) < 2 {
+		response.BadRequest(ctx, "wrong "+panel.GetPrimaryKey().Name)
+		ctx.Abort()
+		return
+	}
+
+	u := &url.URL{RawQuery: ctx.FormValue("query")}
+	params := parameter.GetParam(u, info.DefaultPageSize, info.SortField, info.GetSort())
+
+	ctx.SetUserValue(reorderParamKey, &ReorderParam{
+		Panel:  reorderPanel,
+		Prefix: prefix,
+		IDs:    ids,
+		Desc:   params.SortField == info.RowReorderField && params.SortType == "desc",
+	})
+	ctx.Next()
+}
+
+func GetReorderParam(ctx *context.Context) *ReorderParam {
+	return ctx.UserValue[reorderParamKey].(*ReorderParam)
+}
@@ -0,0 +1,83 @@
+package guard
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/errors"
+	"github.com/purpose168/GoAdmin/modules/report"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+)
+
+type ReportParam struct {
+	Report report.Report
+}
+
+// ReportAdd check the report of the view of the table posted by the reports
+// page, the token is checked at last so the form can be fixed and posted
+// again.
+func (g *Guard) ReportAdd(ctx *context.Context) {
+	panel, prefix := g.table(ctx)
+
+	if panel.GetInfo().IsHideExportButton {
+		response.BadRequest(ctx, errors.OperationNotAllow)
+		ctx.Abort()
+		return
+	}
+
+	r := report.Report{
+		UserID:     auth.Auth(ctx).Id,
+		Name:       strings.TrimSpace(ctx.FormValue("name")),
+		Prefix:     prefix,
+		Query:      ctx.FormValue("query"),
+		Schedule:   strings.TrimSpace(ctx.FormValue("schedule")),
+		Recipients: ctx.FormValue("recipients"),
+	}
+	if err := r.Validate(); err != nil {
+		response.BadRequest(ctx, err.Error())
+		ctx.Abort()
+		return
+	}
+
+	if !auth.GetTokenService(g.services.Get(auth.TokenServiceKey)).CheckToken(ctx.FormValue(form.TokenKey)) {
+		response.BadRequest(ctx, errors.EditFailWrongToken)
+		ctx.Abort()
+		return
+	}
+
+	ctx.SetUserValue(reportParamKey, &ReportParam{Report: r})
+	ctx.Next()
+}
+
+// Report check the token and the report of the current user run or deleted
+// by the reports page.
+func (g *Guard) Report(ctx *context.Context) {
+	if !auth.GetTokenService(g.services.Get(auth.TokenServiceKey)).CheckToken(ctx.FormValue(form.TokenKey)) {
+		response.BadRequest(ctx, errors.EditFailWrongToken)
+		ctx.Abort()
+		return
+	}
+
+	id, _ := strconv.ParseInt(ctx.FormValue("id"), 10, 64)
+	r, ok, err := report.NewStore(g.conn).Get(id)
+	if err != nil {
+		response.Error(ctx, err.Error())
+		ctx.Abort()
+		return
+	}
+	if !ok || r.UserID != auth.Auth(ctx).Id {
+		response.BadRequest(ctx, "wrong report")
+		ctx.Abort()
+		return
+	}
+
+	ctx.SetUserValue(reportParamKey, &ReportParam{Report: r})
+	ctx.Next()
+}
+
+func GetReportParam(ctx *context.Context) *ReportParam {
+	return ctx.UserValue[reportParamKey].(*ReportParam)
+}
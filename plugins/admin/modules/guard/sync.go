@@ -0,0 +1,107 @@
+package guard
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/errors"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+)
+
+type SyncParam struct {
+	Panel  table.SyncTable
+	Prefix string
+	IDs    []string
+	Bundle table.DataBundle
+}
+
+// SyncExport check the ids of the rows exported as a data bundle.
+func (g *Guard) SyncExport(ctx *context.Context) {
+	panel, prefix, syncPanel, ok := g.syncTable(ctx)
+	if !ok {
+		return
+	}
+
+	ids := make([]string, 0)
+	for _, id := range strings.Split(ctx.Query("id"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		response.BadRequest(ctx, "wrong "+panel.GetPrimaryKey().Name)
+		ctx.Abort()
+		return
+	}
+
+	ctx.SetUserValue(syncParamKey, &SyncParam{
+		Panel:  syncPanel,
+		Prefix: prefix,
+		IDs:    ids,
+	})
+	ctx.Next()
+}
+
+// SyncPreview check the data bundle posted for the dry run of the import.
+func (g *Guard) SyncPreview(ctx *context.Context) {
+	g.syncImport(ctx, false)
+}
+
+// SyncImport check the data bundle and the token posted for the import.
+func (g *Guard) SyncImport(ctx *context.Context) {
+	g.syncImport(ctx, true)
+}
+
+func (g *Guard) syncImport(ctx *context.Context, checkToken bool) {
+	panel, prefix, syncPanel, ok := g.syncTable(ctx)
+	if !ok {
+		return
+	}
+
+	// the import inserts and updates the rows.
+	if !panel.GetCanAdd() || !panel.GetEditable() {
+		response.BadRequest(ctx, errors.OperationNotAllow)
+		ctx.Abort()
+		return
+	}
+
+	if checkToken && !auth.GetTokenService(g.services.Get(auth.TokenServiceKey)).CheckToken(ctx.FormValue(form.TokenKey)) {
+		response.BadRequest(ctx, errors.EditFailWrongToken)
+		ctx.Abort()
+		return
+	}
+
+	var bundle table.DataBundle
+	if err := json.Unmarshal([]byte(ctx.FormValue("bundle")), &bundle); err != nil || len(bundle.Rows) == 0 {
+		response.BadRequest(ctx, "wrong data bundle")
+		ctx.Abort()
+		return
+	}
+
+	ctx.SetUserValue(syncParamKey, &SyncParam{
+		Panel:  syncPanel,
+		Prefix: prefix,
+		Bundle: bundle,
+	})
+	ctx.Next()
+}
+
+func (g *Guard) syncTable(ctx *context.Context) (table.Table, string, table.SyncTable, bool) {
+	panel, prefix := g.table(ctx)
+
+	syncPanel, ok := panel.(table.SyncTable)
+	if !ok || panel.GetInfo().SyncField == "" {
+		response.BadRequest(ctx, errors.OperationNotAllow)
+		ctx.Abort()
+		return nil, "", nil, false
+	}
+	return panel, prefix, syncPanel, true
+}
+
+func GetSyncParam(ctx *context.Context) *SyncParam {
+	return ctx.UserValue[syncParamKey].(*SyncParam)
+}
@@ -4,7 +4,9 @@ import (
 	"net/http"
 
 	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/errors"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
 )
 
@@ -17,6 +19,12 @@ type UpdateParam struct {
 func (g *Guard) Update(ctx *context.Context) {
 	panel, prefix := g.table(ctx)
 
+	if !panel.GetEditable() {
+		response.BadRequest(ctx, errors.OperationNotAllow)
+		ctx.Abort()
+		return
+	}
+
 	pname := panel.GetPrimaryKey().Name
 
 	id := ctx.FormValue("pk")
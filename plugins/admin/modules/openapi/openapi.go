@@ -0,0 +1,348 @@
+// Package openapi builds the OpenAPI 3 document of the json apis of the admin
+// plugin and the routes registered by the engine Data method.
+package openapi
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+const (
+	Version = "3.0.3"
+
+	securityName = "cookieAuth"
+)
+
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem is the operations of a path, keyed by the lower case http method.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	OperationID string                `json:"operationId,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Schema struct {
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+}
+
+type Components struct {
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type string `json:"type"`
+	In   string `json:"in,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// Route is a custom route registered by the engine Data method.
+type Route struct {
+	Method  string
+	Path    string
+	Auth    bool
+	Summary string
+}
+
+var (
+	routes    = make([]Route, 0)
+	routeLock sync.Mutex
+)
+
+// AddRoute record a custom route which will be listed in the document.
+func AddRoute(route Route) {
+	routeLock.Lock()
+	defer routeLock.Unlock()
+	routes = append(routes, route)
+}
+
+// Routes return the recorded custom routes.
+func Routes() []Route {
+	routeLock.Lock()
+	defer routeLock.Unlock()
+	r := make([]Route, len(routes))
+	copy(r, routes)
+	return r
+}
+
+// Generate build the document of the given generators, the json apis are only
+// listed when the admin api is open. The operations which need the sign in
+// are only listed when the user of the context has the permission.
+func Generate(ctx *context.Context, generators table.GeneratorList) Document {
+	doc := Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:   config.GetTitle(),
+			Version: "1.0",
+		},
+		Paths: make(map[string]PathItem),
+		Components: Components{
+			SecuritySchemes: map[string]SecurityScheme{
+				securityName: {Type: "apiKey", In: "cookie", Name: auth.DefaultCookieKey},
+			},
+		},
+	}
+
+	for _, route := range Routes() {
+		op := &Operation{
+			Tags:      []string{"custom"},
+			Summary:   route.Summary,
+			Responses: defaultResponses(),
+		}
+		if route.Auth {
+			op.Security = security()
+		}
+		item, ok := doc.Paths[route.Path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[route.Path] = item
+		}
+		item[strings.ToLower(route.Method)] = op
+	}
+
+	if config.GetOpenAdminApi() {
+		for _, prefix := range generators.Keys() {
+			gen, ok := generators.Get(prefix)
+			if !ok {
+				continue
+			}
+			t := gen(ctx)
+			if t.GetInfo().Table == "" && len(t.GetForm().FieldList) == 0 {
+				continue
+			}
+			addTablePaths(doc.Paths, prefix, t)
+		}
+	}
+
+	var user models.UserModel
+	if ctx != nil {
+		user, _ = ctx.User().(models.UserModel)
+	}
+	filterPaths(doc.Paths, user)
+
+	return doc
+}
+
+// filterPaths remove the operations which need the sign in and are not
+// permitted to the user.
+func filterPaths(paths map[string]PathItem, user models.UserModel) {
+	for path, item := range paths {
+		for method, op := range item {
+			if len(op.Security) > 0 && !user.CheckPermissionByUrlMethod(path, strings.ToUpper(method), nil) {
+				delete(item, method)
+			}
+		}
+		if len(item) == 0 {
+			delete(paths, path)
+		}
+	}
+}
+
+func addTablePaths(paths map[string]PathItem, prefix string, t table.Table) {
+	var (
+		info = t.GetInfo()
+		pk   = t.GetPrimaryKey()
+		tags = []string{prefix}
+		api  = func(kind string) string { return config.Url("/api/" + kind + "/" + prefix) }
+	)
+
+	listParams := []Parameter{
+		queryParam(parameter.Page, "page number", &Schema{Type: "integer"}),
+		queryParam(parameter.PageSize, "page size", &Schema{Type: "integer"}),
+		queryParam(parameter.Sort, "sort field", &Schema{Type: "string"}),
+		queryParam(parameter.SortType, "sort type", &Schema{Type: "string", Enum: []string{"asc", "desc"}}),
+	}
+	for _, field := range info.FieldList {
+		if field.Filterable {
+			listParams = append(listParams, queryParam(field.Field, field.Head, schemaOf(field.TypeName)))
+		}
+	}
+
+	paths[api("list")] = PathItem{"get": {
+		Tags:        tags,
+		Summary:     "list " + prefix,
+		OperationID: prefix + "_list",
+		Parameters:  listParams,
+		Responses:   defaultResponses(),
+		Security:    security(),
+	}}
+
+	paths[api("detail")] = PathItem{"get": {
+		Tags:        tags,
+		Summary:     "detail of " + prefix,
+		OperationID: prefix + "_detail",
+		Parameters: []Parameter{{
+			Name:     constant.DetailPKKey,
+			In:       "query",
+			Required: true,
+			Schema:   schemaOf(pk.Type),
+		}},
+		Responses: defaultResponses(),
+		Security:  security(),
+	}}
+
+	if t.GetDeletable() {
+		paths[api("delete")] = PathItem{"post": {
+			Tags:        tags,
+			Summary:     "delete " + prefix,
+			OperationID: prefix + "_delete",
+			RequestBody: formBody(&Schema{
+				Type:       "object",
+				Properties: map[string]*Schema{"id": {Type: "string", Description: "primary keys joined by comma"}},
+				Required:   []string{"id"},
+			}),
+			Responses: defaultResponses(),
+			Security:  security(),
+		}}
+	}
+
+	if t.GetEditable() {
+		paths[api("edit")] = PathItem{"post": {
+			Tags:        tags,
+			Summary:     "edit " + prefix,
+			OperationID: prefix + "_edit",
+			RequestBody: formBody(formSchema(t.GetForm().FieldList, pk, false)),
+			Responses:   defaultResponses(),
+			Security:    security(),
+		}}
+	}
+
+	if t.GetCanAdd() {
+		paths[api("create")] = PathItem{"post": {
+			Tags:        tags,
+			Summary:     "create " + prefix,
+			OperationID: prefix + "_create",
+			RequestBody: formBody(formSchema(t.GetActualNewForm().FieldList, pk, true)),
+			Responses:   defaultResponses(),
+			Security:    security(),
+		}}
+	}
+}
+
+// formSchema return the schema of the form of the new or the edit request,
+// the row of the edit request is told by the edit pk as the edit page does.
+func formSchema(fields types.FormFields, pk table.PrimaryKey, isNew bool) *Schema {
+	s := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+	if !isNew {
+		edit := schemaOf(pk.Type)
+		edit.Description = "primary key of the edited row"
+		s.Properties[constant.EditPKKey] = edit
+		s.Required = append(s.Required, constant.EditPKKey)
+	}
+	for _, field := range fields {
+		if isNew && (field.NotAllowAdd || field.CreateHide) {
+			continue
+		}
+		if !isNew && field.NotAllowEdit {
+			continue
+		}
+		fs := schemaOf(field.TypeName)
+		fs.Description = field.Head
+		for _, op := range field.Options {
+			fs.Enum = append(fs.Enum, op.Value)
+		}
+		s.Properties[field.Field] = fs
+		if field.Must {
+			s.Required = append(s.Required, field.Field)
+		}
+	}
+	return s
+}
+
+// schemaOf return the json schema of the database type.
+func schemaOf(typ db.DatabaseType) *Schema {
+	switch {
+	case db.Contains(typ, db.IntTypeList):
+		return &Schema{Type: "integer"}
+	case db.Contains(typ, db.FloatTypeList):
+		return &Schema{Type: "number"}
+	case typ == db.Date:
+		return &Schema{Type: "string", Format: "date"}
+	case typ == db.Datetime || typ == db.Timestamp:
+		return &Schema{Type: "string", Format: "date-time"}
+	default:
+		return &Schema{Type: "string"}
+	}
+}
+
+func queryParam(name, desc string, schema *Schema) Parameter {
+	return Parameter{Name: name, In: "query", Description: desc, Schema: schema}
+}
+
+func formBody(schema *Schema) *RequestBody {
+	return &RequestBody{
+		Required: true,
+		Content: map[string]MediaType{
+			"multipart/form-data":               {Schema: schema},
+			"application/x-www-form-urlencoded": {Schema: schema},
+		},
+	}
+}
+
+func security() []map[string][]string {
+	return []map[string][]string{{securityName: {}}}
+}
+
+func defaultResponses() map[string]Response {
+	return map[string]Response{
+		"200": {Description: "ok"},
+		"400": {Description: "bad request"},
+		"403": {Description: "permission denied"},
+	}
+}
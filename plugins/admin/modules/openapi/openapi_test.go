@@ -0,0 +1,63 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	"github.com/purpose168/GoAdmin/template/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormSchema(t *testing.T) {
+	fields := types.FormFields{
+		{Field: "id", TypeName: db.Int, NotAllowAdd: true, NotAllowEdit: true},
+		{Field: "name", TypeName: db.Varchar, Must: true},
+		{Field: "created_at", TypeName: db.Datetime, NotAllowEdit: true},
+	}
+
+	pk := table.PrimaryKey{Name: "id", Type: db.Int}
+
+	s := formSchema(fields, pk, true)
+	assert.Equal(t, []string{"name"}, s.Required)
+	assert.Nil(t, s.Properties["id"])
+	assert.Nil(t, s.Properties[constant.EditPKKey])
+	assert.Equal(t, "date-time", s.Properties["created_at"].Format)
+
+	s = formSchema(fields, pk, false)
+	assert.Equal(t, []string{constant.EditPKKey, "name"}, s.Required)
+	assert.Equal(t, "integer", s.Properties[constant.EditPKKey].Type)
+	assert.Nil(t, s.Properties["id"])
+	assert.Nil(t, s.Properties["created_at"])
+}
+
+func TestFilterPaths(t *testing.T) {
+	config.Initialize(&config.Config{UrlPrefix: "admin"})
+
+	op := func(secured bool) *Operation {
+		o := &Operation{Responses: defaultResponses()}
+		if secured {
+			o.Security = security()
+		}
+		return o
+	}
+	paths := map[string]PathItem{
+		"/admin/api/list/posts": {"get": op(true)},
+		"/admin/api/edit/posts": {"post": op(true)},
+		"/admin/api/edit/users": {"post": op(true)},
+		"/public":               {"get": op(false)},
+	}
+	user := models.UserModel{Permissions: []models.PermissionModel{
+		{HttpMethod: []string{"GET"}, HttpPath: []string{"/api/list/posts"}},
+		{HttpMethod: []string{""}, HttpPath: []string{"/api/edit/posts"}},
+	}}
+
+	filterPaths(paths, user)
+	assert.Equal(t, 3, len(paths))
+	assert.NotNil(t, paths["/admin/api/list/posts"]["get"])
+	assert.NotNil(t, paths["/admin/api/edit/posts"]["post"])
+	assert.NotNil(t, paths["/public"]["get"])
+}
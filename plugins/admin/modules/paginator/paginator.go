@@ -19,6 +19,13 @@ type Config struct {
 	Size         int
 	Param        parameter.Parameters
 	PageSizeList []string
+	// NoCount means the total of the rows is not counted, the Size is the
+	// number of the rows up to the current page, and the next page is enabled
+	// when the current page is full.
+	NoCount bool
+	// CountToggle shows the toggle of the total count, see
+	// parameter.NoCount.
+	CountToggle bool
 }
 
 func Get(ctx *context.Context, cfg Config) types.PaginatorAttribute {
@@ -26,6 +33,9 @@ func Get(ctx *context.Context, cfg Config) types.PaginatorAttribute {
 	paginator := template2.Default(ctx).Paginator().(*components.PaginatorAttribute)
 
 	totalPage := int(math.Ceil(float64(cfg.Size) / float64(cfg.Param.PageSizeInt)))
+	if cfg.NoCount && cfg.Size >= cfg.Param.PageInt*cfg.Param.PageSizeInt {
+		totalPage = cfg.Param.PageInt + 1
+	}
 
 	if cfg.Param.PageInt == 1 {
 		paginator.PreviousClass = "disabled"
@@ -132,8 +142,25 @@ func Get(ctx *context.Context, cfg Config) types.PaginatorAttribute {
 		endNum = paginator.Total
 	}
 
-	paginator.SetEntriesInfo(template.HTML(fmt.Sprintf(language.Get("showing <b>%s</b> to <b>%s</b> of <b>%s</b> entries"),
-		paginator.CurPageStartIndex, endNum, paginator.Total)))
+	var entriesInfo string
+	if cfg.NoCount {
+		entriesInfo = fmt.Sprintf(language.Get("showing <b>%s</b> to <b>%s</b> entries"),
+			paginator.CurPageStartIndex, endNum)
+	} else {
+		entriesInfo = fmt.Sprintf(language.Get("showing <b>%s</b> to <b>%s</b> of <b>%s</b> entries"),
+			paginator.CurPageStartIndex, endNum, paginator.Total)
+	}
+
+	var prevURL, nextURL string
+	if paginator.PreviousClass != "disabled" {
+		prevURL = paginator.PreviousUrl
+	}
+	if paginator.NextClass != "disabled" {
+		nextURL = paginator.NextUrl
+	}
+
+	paginator.SetEntriesInfo(template.HTML(entriesInfo) +
+		tools(cfg, totalPage, prevURL, nextURL))
 
 	return paginator.SetPageSizeList(cfg.PageSizeList)
 }
@@ -1,6 +1,7 @@
 package paginator
 
 import (
+	"strings"
 	"testing"
 
 	_ "github.com/purpose168/GoAdmin-themes/sword"
@@ -18,3 +19,40 @@ func TestGet(t *testing.T) {
 		PageSizeList: []string{"10", "20", "50", "100"},
 	})
 }
+
+// initConfig initialize the config unless TestGet has, as the config can not
+// be initialized twice.
+func initConfig() {
+	if config.GetTheme() == "" {
+		config.Initialize(&config.Config{Theme: "sword"})
+	}
+}
+
+func TestGetNoCount(t *testing.T) {
+	initConfig()
+	param := parameter.BaseParam().WithNoCount(true)
+	param.PageInt = 3
+	param.PageSizeInt = 10
+	Get(nil, Config{
+		Size:         30,
+		Param:        param,
+		PageSizeList: []string{"10", "20", "50", "100"},
+		NoCount:      true,
+		CountToggle:  true,
+	})
+}
+
+func TestTools(t *testing.T) {
+	initConfig()
+	param := parameter.BaseParam()
+
+	html := string(tools(Config{Param: param, CountToggle: true}, 5, "", "/next"))
+	if !strings.Contains(html, `max="5"`) || !strings.Contains(html, parameter.NoCount+"=true") {
+		t.Errorf("unexpected tools: %s", html)
+	}
+
+	html = string(tools(Config{Param: param.WithNoCount(true), NoCount: true}, 5, "", "/next"))
+	if strings.Contains(html, `max=`) || strings.Contains(html, "goadmin-total-toggle") {
+		t.Errorf("unexpected tools without count: %s", html)
+	}
+}
@@ -0,0 +1,76 @@
+package paginator
+
+import (
+	"bytes"
+	"html/template"
+
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+)
+
+// toolsTmpl is the go to page input, the toggle of the total count and the
+// keyboard navigation of the table, which turns the pages by the left and
+// right keys when the table has the focus.
+var toolsTmpl = template.Must(template.New("paginator_tools").Parse(`<span class="goadmin-paginator-tools" style="margin-left:10px;">
+<input type="number" min="1"{{if .Max}} max="{{.Max}}"{{end}} class="form-control input-sm goadmin-page-jump" style="display:inline-block;width:80px;" placeholder="{{.JumpText}}" data-url="{{.JumpURL}}">
+{{if .ToggleURL}}<a href="{{.ToggleURL}}" class="goadmin-total-toggle" style="margin-left:6px;">{{.ToggleText}}</a>{{end}}
+</span>
+<script>
+(function () {
+    var tools = $(".goadmin-paginator-tools").last();
+    var go = function (url) {
+        if (!url) { return; }
+        if ($.pjax) { $.pjax({url: url, container: '#pjax-container'}); } else { window.location.href = url; }
+    };
+    tools.find(".goadmin-page-jump").on("keydown", function (e) {
+        if (e.which !== 13) { return; }
+        e.preventDefault();
+        var page = parseInt($(this).val(), 10), max = parseInt($(this).attr("max"), 10);
+        if (!(page > 0)) { return; }
+        if (max > 0 && page > max) { page = max; }
+        var url = new URL($(this).data("url"), window.location.href);
+        url.searchParams.set("{{.PageKey}}", page);
+        go(url.pathname + url.search);
+    });
+    var table = tools.closest(".box").find("table").first();
+    table.attr("tabindex", 0).css("outline", "none");
+    table.off("keydown.goadminPaginator").on("keydown.goadminPaginator", function (e) {
+        if ($(e.target).is("input, textarea, select, [contenteditable]")) { return; }
+        if (e.which === 37) { go({{.PrevURL}}); } else if (e.which === 39) { go({{.NextURL}}); }
+    });
+})();
+</script>`))
+
+// tools return the html of the tools of the paginator.
+func tools(cfg Config, totalPage int, prevURL, nextURL string) template.HTML {
+	var (
+		maxPage    = totalPage
+		toggleURL  string
+		toggleText = language.Get("hide total")
+	)
+	if cfg.NoCount {
+		maxPage = 0
+		toggleText = language.Get("show total")
+	}
+	if cfg.CountToggle {
+		toggleURL = cfg.Param.WithNoCount(!cfg.NoCount).URLNoAnimation("1")
+	}
+
+	buf := new(bytes.Buffer)
+	err := toolsTmpl.Execute(buf, map[string]interface{}{
+		"Max":        maxPage,
+		"JumpText":   language.Get("go to page"),
+		"JumpURL":    cfg.Param.URLNoAnimation("1"),
+		"ToggleURL":  toggleURL,
+		"ToggleText": toggleText,
+		"PageKey":    parameter.Page,
+		"PrevURL":    prevURL,
+		"NextURL":    nextURL,
+	})
+	if err != nil {
+		logger.Error("paginator tools error: ", err)
+		return ""
+	}
+	return template.HTML(buf.String())
+}
@@ -22,6 +22,9 @@ type Parameters struct {
 	URLPath      string
 	Fields       map[string][]string
 	OrConditions map[string]string
+	// PinnedIDs are the ids of the records pinned by the current user, which
+	// are listed only when the Pinned parameter is true.
+	PinnedIDs []string
 
 	cacheFixedStr url.Values
 }
@@ -40,6 +43,8 @@ const (
 
 	IsAll      = "__is_all"
 	PrimaryKey = "__pk"
+	Pinned     = "__pinned"
+	NoCount    = "__no_count"
 
 	True  = "true"
 	False = "false"
@@ -62,6 +67,16 @@ var operators = map[string]string{
 	"le":   "<",
 	"lq":   "<=",
 	"free": "free",
+	// fuzzy is handled by the search index of the table, see table.SetFuzzySearchFn.
+	"fuzzy": "fuzzy",
+	// has matches one of the tags stored in the field, see table.DefaultTable.
+	"has": "has",
+	// tree matches the selected options and their descendants, see table.DefaultTable.
+	"tree": "tree",
+	// between matches the range of the range slider, see table.DefaultTable.
+	"between": "between",
+	// list matches one of the pasted values, see table.DefaultTable.
+	"list": "list",
 }
 
 var keys = []string{Page, PageSize, Sort, Columns, Prefix, Pjax, form.NoAnimationKey}
@@ -84,7 +99,11 @@ func GetParam(u *url.URL, defaultPageSize int, p ...string) Parameters {
 	page := getDefault(values, Page, "1")
 	pageSize := getDefault(values, PageSize, strconv.Itoa(defaultPageSize))
 	sortField := getDefault(values, Sort, primaryKey)
-	sortType := getDefault(values, SortType, defaultSortType)
+	sortType := strings.ToLower(getDefault(values, SortType, defaultSortType))
+	// the sort type is formatted into the query, only asc and desc are allowed.
+	if sortType != sortTypeDesc && sortType != sortTypeAsc {
+		sortType = sortTypeDesc
+	}
 	columns := getDefault(values, Columns, "")
 
 	animation := true
@@ -150,6 +169,42 @@ func (param Parameters) WithPKs(id ...string) Parameters {
 	return param
 }
 
+// IsPinned check whether only the records pinned by the current user are
+// listed.
+func (param Parameters) IsPinned() bool {
+	return param.GetFieldValue(Pinned) == True
+}
+
+// IsNoCount check whether the total count of the rows is skipped, the
+// default is used when the parameter is not set.
+func (param Parameters) IsNoCount(def bool) bool {
+	switch param.GetFieldValue(NoCount) {
+	case True:
+		return true
+	case False:
+		return false
+	}
+	return def
+}
+
+// WithNoCount return a copy of the parameters which skips the total count of
+// the rows or not.
+func (param Parameters) WithNoCount(noCount bool) Parameters {
+	fields := make(map[string][]string, len(param.Fields)+1)
+	for key, value := range param.Fields {
+		fields[key] = value
+	}
+	fields[NoCount] = []string{strconv.FormatBool(noCount)}
+	param.Fields = fields
+	return param
+}
+
+// WithPinnedIDs set the ids of the records pinned by the current user.
+func (param Parameters) WithPinnedIDs(ids []string) Parameters {
+	param.PinnedIDs = ids
+	return param
+}
+
 func (param Parameters) PKs() []string {
 	pk := param.GetFieldValue(PrimaryKey)
 	if pk == "" {
@@ -197,6 +252,16 @@ func (param Parameters) WithIsAll(isAll bool) Parameters {
 	return param
 }
 
+// WithPage set the page and the page size, used to fetch the rows chunk by
+// chunk.
+func (param Parameters) WithPage(page, pageSize int) Parameters {
+	param.Page = strconv.Itoa(page)
+	param.PageInt = page
+	param.PageSize = strconv.Itoa(pageSize)
+	param.PageSizeInt = pageSize
+	return param
+}
+
 func (param Parameters) DeleteIsAll() Parameters {
 	delete(param.Fields, IsAll)
 	return param
@@ -230,6 +295,27 @@ func (param Parameters) DeleteField(fields ...string) Parameters {
 	return param
 }
 
+// WithoutFilter return a copy of the parameters without the filter of the
+// given filter form field, its operator and range values are removed too.
+func (param Parameters) WithoutFilter(name string) Parameters {
+	fields := make(map[string][]string, len(param.Fields))
+	for key, value := range param.Fields {
+		fields[key] = value
+	}
+
+	key, suffix := name, ""
+	if arr := strings.Split(name, FilterParamCountInfix); len(arr) > 1 {
+		key, suffix = arr[0], FilterParamCountInfix+arr[1]
+	}
+	for _, field := range []string{name, key + FilterParamOperatorSuffix + suffix,
+		name + FilterRangeParamStartSuffix, name + FilterRangeParamEndSuffix} {
+		delete(fields, field)
+	}
+
+	param.Fields = fields
+	return param
+}
+
 func (param Parameters) DeleteEditPk() Parameters {
 	delete(param.Fields, constant.EditPKKey)
 	return param
@@ -372,6 +458,9 @@ func (param Parameters) GetFixedParamStrWithoutSort() string {
 	return "&" + p.Encode()
 }
 
+// Statement append the filter conditions of the parameters to the wheres. The
+// columns are the allowlist of the filter fields, which should contain the
+// join fields(table + FilterParamJoinInfix + field) to filter the joined tables.
 func (param Parameters) Statement(wheres, table, delimiter, delimiter2 string, whereArgs []interface{}, columns, existKeys []string,
 	filterProcess func(string, string, string) string) (string, []interface{}, []string) {
 	var multiKey = make(map[string]uint8)
@@ -393,7 +482,9 @@ func (param Parameters) Statement(wheres, table, delimiter, delimiter2 string, w
 		}
 
 		var op string
-		if strings.Contains(key, FilterRangeParamEndSuffix) {
+		if o := operators[param.GetFieldOperator(key, keyIndexSuffix)]; o == "has" || o == "tree" || o == "between" || o == "list" {
+			continue
+		} else if strings.Contains(key, FilterRangeParamEndSuffix) {
 			key = strings.ReplaceAll(key, FilterRangeParamEndSuffix, "")
 			op = "<="
 		} else if strings.Contains(key, FilterRangeParamStartSuffix) {
@@ -402,11 +493,21 @@ func (param Parameters) Statement(wheres, table, delimiter, delimiter2 string, w
 		} else if len(value) > 1 {
 			op = "in"
 		} else if !strings.Contains(key, FilterParamOperatorSuffix) {
-			op = operators[param.GetFieldOperator(key, keyIndexSuffix)]
+			var ok bool
+			op, ok = operators[param.GetFieldOperator(key, keyIndexSuffix)]
+			if !ok || op == "fuzzy" {
+				continue
+			}
 		} else {
 			continue
 		}
 
+		// the fields are concatenated into the query, so only the columns and
+		// the join fields given by the caller are allowed.
+		if !modules.InArray(columns, key) {
+			continue
+		}
+
 		if strings.Contains(key, FilterParamJoinInfix) {
 			keys := strings.Split(key, FilterParamJoinInfix)
 			val := filterProcess(key, value[0], keyIndexSuffix)
@@ -427,25 +528,21 @@ func (param Parameters) Statement(wheres, table, delimiter, delimiter2 string, w
 				}
 			}
 		} else {
-			if modules.InArray(columns, key) {
-				if op == "in" {
-					qmark := ""
-					for range value {
-						qmark += "?,"
-					}
-					wheres += modules.Delimiter(delimiter, delimiter2, table) + "." + modules.FilterField(key, delimiter, delimiter2) + " " + op + " (" + qmark[:len(qmark)-1] + ") and "
-				} else {
-					wheres += modules.Delimiter(delimiter, delimiter2, table) + "." + modules.FilterField(key, delimiter, delimiter2) + " " + op + " ? and "
-				}
-				if op == "like" && !strings.Contains(value[0], "%") {
-					whereArgs = append(whereArgs, "%"+filterProcess(key, value[0], keyIndexSuffix)+"%")
-				} else {
-					for _, v := range value {
-						whereArgs = append(whereArgs, filterProcess(key, v, keyIndexSuffix))
-					}
+			if op == "in" {
+				qmark := ""
+				for range value {
+					qmark += "?,"
 				}
+				wheres += modules.Delimiter(delimiter, delimiter2, table) + "." + modules.FilterField(key, delimiter, delimiter2) + " " + op + " (" + qmark[:len(qmark)-1] + ") and "
 			} else {
-				continue
+				wheres += modules.Delimiter(delimiter, delimiter2, table) + "." + modules.FilterField(key, delimiter, delimiter2) + " " + op + " ? and "
+			}
+			if op == "like" && !strings.Contains(value[0], "%") {
+				whereArgs = append(whereArgs, "%"+filterProcess(key, value[0], keyIndexSuffix)+"%")
+			} else {
+				for _, v := range value {
+					whereArgs = append(whereArgs, filterProcess(key, v, keyIndexSuffix))
+				}
 			}
 		}
 
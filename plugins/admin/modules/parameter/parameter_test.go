@@ -2,6 +2,8 @@ package parameter
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 	"testing"
 )
 
@@ -14,3 +16,56 @@ func TestParameters_PKs(t *testing.T) {
 	pks := BaseParam().PKs()
 	fmt.Println("pks", pks, "len", len(pks))
 }
+
+func TestParameters_Statement_Allowlist(t *testing.T) {
+	u, _ := url.Parse("/admin/info/user?name=a&roles_goadmin_join_name=b&x%3Bdrop_goadmin_join_name=c" +
+		"&unknown=d&city=e&city__goadmin_operator__=drop&__sort_type=asc;drop")
+	param := GetParam(u, 10)
+
+	wheres, args, _ := param.Statement("", "user", "`", "`", nil, []string{"name", "city", "roles_goadmin_join_name"},
+		nil, func(_, value, _ string) string { return value })
+
+	if param.SortType != "desc" {
+		t.Fatalf("wrong sort type %s", param.SortType)
+	}
+	if strings.Contains(wheres, "drop") || strings.Contains(wheres, "unknown") || strings.Contains(wheres, "city") {
+		t.Fatalf("wrong wheres %s", wheres)
+	}
+	if len(args) != 2 {
+		t.Fatalf("wrong args %v", args)
+	}
+}
+
+func TestParameters_WithoutFilter(t *testing.T) {
+	u, _ := url.Parse("/admin/info/user?name=a&age=3&age__goadmin_operator__=gr&name__goadmin_index__1=b" +
+		"&name__goadmin_operator____goadmin_index__1=like&created_start__goadmin=1&created_end__goadmin=2")
+	param := GetParam(u, 10)
+
+	p := param.WithoutFilter("age")
+	if _, ok := p.Fields["age"]; ok {
+		t.Fatalf("wrong fields %v", p.Fields)
+	}
+	if _, ok := p.Fields["age__goadmin_operator__"]; ok {
+		t.Fatalf("wrong fields %v", p.Fields)
+	}
+	if len(param.Fields) != 7 || len(p.Fields) != 5 {
+		t.Fatalf("wrong fields %v %v", param.Fields, p.Fields)
+	}
+
+	p = param.WithoutFilter("name__goadmin_index__1")
+	if len(p.Fields) != 5 || p.GetFieldValue("name") != "a" {
+		t.Fatalf("wrong fields %v", p.Fields)
+	}
+
+	p = param.WithoutFilter("created")
+	if len(p.Fields) != 5 {
+		t.Fatalf("wrong fields %v", p.Fields)
+	}
+}
+
+func TestParameters_WithPage(t *testing.T) {
+	param := GetParamFromURL("/admin/info/user?__page=2&__pageSize=10", 1, "asc", "id").WithPage(3, 500)
+	if param.PageInt != 3 || param.Page != "3" || param.PageSizeInt != 500 || param.PageSize != "500" {
+		t.Fatalf("wrong page %s/%s", param.Page, param.PageSize)
+	}
+}
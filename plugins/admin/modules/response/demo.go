@@ -0,0 +1,135 @@
+package response
+
+import (
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// DemoHeader is the response header of the requests blocked by the demo mode,
+// the pages show a toast for the ajax and pjax requests with it.
+const DemoHeader = "X-Demo-Mode"
+
+// DemoHandler blocks the mutating requests when config.DemoMode is true, or
+// runs them with the writes reverted when config.DemoRevert is also true.
+var DemoHandler = func(ctx *context.Context) {
+	if !config.GetDemoMode() {
+		ctx.Next()
+		return
+	}
+
+	if !config.GetDemoRevert() {
+		if demoMutating(ctx) {
+			demoBlocked(ctx)
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+		return
+	}
+
+	// the handler is added to the routes of the engine and the plugins, only
+	// the first one of a request runs.
+	if ctx.GetUserValue(demoRevertKey) != nil {
+		ctx.Next()
+		return
+	}
+	ctx.SetUserValue(demoRevertKey, true)
+
+	if !demoMutating(ctx) {
+		db.WaitRevert(ctx.Next)
+		return
+	}
+
+	// the writes of the request, including the ones of the goroutines it
+	// starts, are reverted, see db.RevertWrites.
+	db.RevertWrites(ctx.Next)
+}
+
+const demoRevertKey = "goadmin_demo_revert"
+
+// demoMutating report whether the request may write the data. The POST
+// requests which only read the data or sign in are not.
+func demoMutating(ctx *context.Context) bool {
+	switch ctx.Method() {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+
+	path := ctx.Path()
+	for _, allowed := range []string{
+		"/signin",
+		auth.JWTTokenPath,
+		auth.JWTRefreshPath,
+		auth.SAMLACSPath,
+		"/install/database/check",
+		routeBase(config.GetURLFormats().Export),
+		"/api/export/",
+		"/grid/preview/",
+		"/sync/preview/",
+	} {
+		if allowed != "" && (path == config.Url(allowed) || strings.HasSuffix(allowed, "/") &&
+			strings.HasPrefix(path, config.Url(allowed))) {
+			return false
+		}
+	}
+	return true
+}
+
+// routeBase return the part of the route before the parameters with a
+// trailing slash, "/export/:__prefix" => "/export/".
+func routeBase(route string) string {
+	if i := strings.Index(route, "/:"); i > -1 {
+		return route[:i+1]
+	}
+	return route
+}
+
+func demoBlocked(ctx *context.Context) {
+	msg := language.Get("demo mode blocked")
+	ctx.SetHeader(DemoHeader, "blocked")
+	if ctx.IsPjax() || ctx.Headers("X-Requested-With") == "XMLHttpRequest" || ctx.WantJSON() {
+		ctx.JSON(http.StatusForbidden, map[string]interface{}{
+			"code": http.StatusForbidden,
+			"msg":  msg,
+		})
+		return
+	}
+	ctx.HTML(http.StatusForbidden, `<html><body><h1>`+template.HTMLEscapeString(msg)+`</h1>`+
+		`<a href="javascript:history.back()">`+language.Get("demo mode go back")+`</a></body></html>`)
+}
+
+// DemoDecorator show the tip of the demo mode on the top of the pages, and a
+// toast when a request is blocked.
+func DemoDecorator(_ *context.Context, _ models.UserModel, panel types.Panel) types.Panel {
+	if !config.GetDemoMode() {
+		return panel
+	}
+	tip := language.Get("demo mode tip")
+	if config.GetDemoRevert() {
+		tip = language.Get("demo mode revert")
+	}
+	panel.Content = template.HTML(`<div class="callout callout-warning goadmin-demo-mode">`+
+		template.HTMLEscapeString(tip)+`</div>`) + panel.Content
+	panel.JS += template.JS(`(function () {
+    if (window.goadminDemoMode) { return; }
+    window.goadminDemoMode = true;
+    var toast = function (xhr) {
+        if (!xhr || !xhr.getResponseHeader || !xhr.getResponseHeader(` + utils.JSON(DemoHeader) + `)) { return false; }
+        swal(` + utils.JSON(language.Get("demo mode")) + `, (xhr.responseJSON || {}).msg || "", "warning");
+        return true;
+    };
+    $(document).ajaxError(function (e, xhr) { toast(xhr); });
+    $(document).on("pjax:error", function (e, xhr) { if (toast(xhr)) { e.preventDefault(); } });
+})();`)
+	return panel
+}
@@ -0,0 +1,45 @@
+package response
+
+import (
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	_ "github.com/purpose168/GoAdmin/modules/db/drivers/sqlite"
+)
+
+func TestDemoHandler_Revert(t *testing.T) {
+	config.Initialize(&config.Config{UrlPrefix: "admin", DemoMode: true, DemoRevert: true})
+
+	conn := db.GetSqliteDB()
+	conn.InitDB(config.DatabaseList{"default": {
+		Driver: db.DriverSqlite,
+		File:   filepath.Join(t.TempDir(), "demo.db"),
+	}})
+	_, err := conn.Exec("create table posts (id integer primary key autoincrement, title text)")
+	assert.Equal(t, err, nil)
+
+	var title interface{}
+	handler := func(ctx *context.Context) {
+		id, err := db.WithDriver(conn).Table("posts").Insert(map[string]interface{}{"title": "a"})
+		assert.Equal(t, err, nil)
+		row, err := db.WithDriver(conn).Table("posts").Find(id)
+		assert.Equal(t, err, nil)
+		if row != nil {
+			title = row["title"]
+		}
+	}
+
+	ctx := context.NewContext(httptest.NewRequest("POST", "/admin/new/posts", nil))
+	ctx.SetHandlers(context.Handlers{DemoHandler, DemoHandler, handler}).Next()
+
+	// the handler reads its own row, which is not saved.
+	assert.Equal(t, title, "a")
+	rows, err := db.WithDriver(conn).Table("posts").All()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(rows), 0)
+}
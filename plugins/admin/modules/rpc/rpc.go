@@ -0,0 +1,481 @@
+// Package rpc provides an optional gRPC service of the registered generators.
+//
+// The service "goadmin.Admin" has the methods List, Get, Create, Update,
+// Delete and Fields, all of them take and return a google.protobuf.Struct so
+// that no generated code is needed by the clients. Every call must carry the
+// session id of a signed in user in the metadata key "go_admin_session", the
+// permission of the user is checked against the equivalent http route of the
+// admin plugin.
+//
+// Create, Update and Delete are run by the handlers of the equivalent http
+// routes, so the writes go through the same guard, validation, hooks and
+// operation log as the forms. Create and Update take the single use token of
+// the forms in the field "token", a token is returned by Fields and by every
+// write for the next one.
+package rpc
+
+import (
+	context2 "context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/listener"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/service"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	"github.com/purpose168/GoAdmin/template/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ServiceName is the full name of the gRPC service.
+const ServiceName = "goadmin.Admin"
+
+// Server implements the gRPC service.
+type Server struct {
+	services   service.List
+	conn       db.Connection
+	generators table.GeneratorList
+	app        *context.App
+
+	mu      sync.Mutex
+	srv     *grpc.Server
+	stopped bool
+}
+
+// New return a gRPC service of the given generators, the writes are run by
+// the handlers of the named routes "new", "edit" and "delete" of the app.
+func New(services service.List, conn db.Connection, generators table.GeneratorList, app *context.App) *Server {
+	return &Server{services: services, conn: conn, generators: generators, app: app}
+}
+
+// Register register the service to the grpc server.
+func (s *Server) Register(srv *grpc.Server) {
+	srv.RegisterService(&serviceDesc, s)
+}
+
+// Serve listen on the addr and serve the service, the addr can also be a unix
+// socket or a systemd-activated listener, see listener.Listen.
+func (s *Server) Serve(addr string, opts ...grpc.ServerOption) error {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return nil
+	}
+	lis, err := listener.Listen(addr)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	srv := grpc.NewServer(opts...)
+	s.Register(srv)
+	s.srv = srv
+	s.mu.Unlock()
+
+	logger.Info("grpc service listening on " + addr)
+	if err := srv.Serve(lis); err != grpc.ErrServerStopped {
+		return err
+	}
+	return nil
+}
+
+// Stop stop the server of Serve after the running calls return, Serve
+// returns nil then. Serve called after Stop returns at once.
+func (s *Server) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+	if s.srv != nil {
+		s.srv.GracefulStop()
+	}
+}
+
+type handler func(s *Server, ctx context2.Context, req *structpb.Struct) (*structpb.Struct, error)
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*interface{})(nil),
+	Methods: []grpc.MethodDesc{
+		method("List", (*Server).List),
+		method("Get", (*Server).Get),
+		method("Create", (*Server).Create),
+		method("Update", (*Server).Update),
+		method("Delete", (*Server).Delete),
+		method("Fields", (*Server).Fields),
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func method(name string, h handler) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(srv interface{}, ctx context2.Context, dec func(interface{}) error,
+			interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			req := new(structpb.Struct)
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			if interceptor == nil {
+				return h(srv.(*Server), ctx, req)
+			}
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/" + name}
+			return interceptor(ctx, req, info, func(ctx context2.Context, req interface{}) (interface{}, error) {
+				return h(srv.(*Server), ctx, req.(*structpb.Struct))
+			})
+		},
+	}
+}
+
+// List return the rows of a table, the request is {"prefix": "", "params": {}}
+// where params are the query parameters of the list page.
+func (s *Server) List(c context2.Context, req *structpb.Struct) (*structpb.Struct, error) {
+	query := url.Values{}
+	for key, value := range req.GetFields()["params"].GetStructValue().GetFields() {
+		query.Set(key, stringOf(value))
+	}
+	ctx, t, err := s.prepare(c, req, http.MethodGet, "/info/%s", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	info := t.GetInfo()
+	params := parameter.GetParam(ctx.Request.URL, info.DefaultPageSize, info.SortField, info.GetSort())
+	panel, err := t.GetData(ctx, params)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	thead := make([]interface{}, 0, len(panel.Thead))
+	for _, item := range panel.Thead {
+		if item.Hide {
+			continue
+		}
+		thead = append(thead, map[string]interface{}{
+			"field":    item.Field,
+			"head":     item.Head,
+			"sortable": item.Sortable,
+		})
+	}
+
+	return newStruct(map[string]interface{}{
+		"thead":     thead,
+		"rows":      rows(panel.InfoList),
+		"page":      params.PageInt,
+		"page_size": params.PageSizeInt,
+	})
+}
+
+// Get return the record of the primary key, the request is {"prefix": "", "id": ""}.
+func (s *Server) Get(c context2.Context, req *structpb.Struct) (*structpb.Struct, error) {
+	id := stringOf(req.GetFields()["id"])
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	ctx, t, err := s.prepare(c, req, http.MethodGet, "/info/%s/detail",
+		url.Values{constant.DetailPKKey: []string{id}}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	info := t.GetInfo()
+	formInfo, err := t.GetDataWithId(parameter.GetParam(ctx.Request.URL, info.DefaultPageSize,
+		info.SortField, info.GetSort()).WithPKs(id))
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	values := make(map[string]interface{})
+	for _, field := range formInfo.FieldList {
+		values[field.Field] = string(field.Value)
+	}
+
+	return newStruct(map[string]interface{}{"values": values})
+}
+
+// Create insert a record, the request is {"prefix": "", "token": "",
+// "values": {}} and the response is {"token": ""}.
+func (s *Server) Create(c context2.Context, req *structpb.Struct) (*structpb.Struct, error) {
+	values := formValues(req)
+	values.Add(form.TokenKey, stringOf(req.GetFields()["token"]))
+	ctx, t, err := s.prepare(c, req, http.MethodPost, "/new/%s", nil, values)
+	if err != nil {
+		return nil, err
+	}
+	if !t.GetCanAdd() {
+		return nil, status.Error(codes.FailedPrecondition, "operation not allow")
+	}
+	return s.dispatch(ctx, "new", codes.InvalidArgument)
+}
+
+// Update update a record, the request is {"prefix": "", "token": "",
+// "values": {}} and the values must contain the primary key, the response is
+// {"token": ""}.
+func (s *Server) Update(c context2.Context, req *structpb.Struct) (*structpb.Struct, error) {
+	values := formValues(req)
+	values.Add(form.TokenKey, stringOf(req.GetFields()["token"]))
+	ctx, t, err := s.prepare(c, req, http.MethodPost, "/edit/%s", nil, values)
+	if err != nil {
+		return nil, err
+	}
+	if values.Get(t.GetPrimaryKey().Name) == "" {
+		return nil, status.Error(codes.InvalidArgument, "primary key is required")
+	}
+	return s.dispatch(ctx, "edit", codes.InvalidArgument)
+}
+
+// Delete delete the records, the request is {"prefix": "", "id": ""} and the
+// id can be primary keys joined by comma.
+func (s *Server) Delete(c context2.Context, req *structpb.Struct) (*structpb.Struct, error) {
+	id := stringOf(req.GetFields()["id"])
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	ctx, _, err := s.prepare(c, req, http.MethodPost, "/delete/%s", nil, form.Values{"id": []string{id}})
+	if err != nil {
+		return nil, err
+	}
+	return s.dispatch(ctx, "delete", codes.Internal)
+}
+
+// dispatch run the context by the handlers of the named route of the app,
+// and convert the json response to the result. The failures of the handlers
+// are returned with the code fail, and the new token of the failed writes is
+// the detail of the error.
+func (s *Server) dispatch(ctx *context.Context, name string, fail codes.Code) (*structpb.Struct, error) {
+	var handlers context.Handlers
+	if s.app != nil {
+		if route, ok := s.app.Routers[name]; ok {
+			handlers = s.app.Find(route.Patten, route.Method())
+		}
+	}
+	if len(handlers) == 0 {
+		return nil, status.Error(codes.Unimplemented, "route "+name+" not found")
+	}
+	ctx.SetHandlers(handlers).Next()
+
+	var res struct {
+		Code int                    `json:"code"`
+		Msg  string                 `json:"msg"`
+		Data map[string]interface{} `json:"data"`
+	}
+	if ctx.Response.Body != nil {
+		body, _ := io.ReadAll(ctx.Response.Body)
+		_ = json.Unmarshal(body, &res)
+	}
+	if res.Code == 0 && ctx.Response.StatusCode == http.StatusOK {
+		// the responders of the forms write their own response.
+		res.Code = http.StatusOK
+	}
+
+	data := make(map[string]interface{})
+	if token, ok := res.Data["token"].(string); ok {
+		data["token"] = token
+	}
+	result, err := newStruct(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var code codes.Code
+	switch res.Code {
+	case http.StatusOK:
+		return result, nil
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	default:
+		code = fail
+		if res.Msg == "" {
+			res.Msg = http.StatusText(ctx.Response.StatusCode)
+		}
+	}
+	st := status.New(code, res.Msg)
+	if len(data) > 0 {
+		if detailed, err := st.WithDetails(result); err == nil {
+			st = detailed
+		}
+	}
+	return nil, st.Err()
+}
+
+// Fields return the metadata of the list columns and form fields, the request
+// is {"prefix": ""}.
+func (s *Server) Fields(c context2.Context, req *structpb.Struct) (*structpb.Struct, error) {
+	_, t, err := s.prepare(c, req, http.MethodGet, "/info/%s", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	info := make([]interface{}, 0)
+	for _, field := range t.GetInfo().FieldList {
+		if field.Hide {
+			continue
+		}
+		info = append(info, map[string]interface{}{
+			"field":      field.Field,
+			"head":       field.Head,
+			"type":       string(field.TypeName),
+			"sortable":   field.Sortable,
+			"filterable": field.Filterable,
+		})
+	}
+
+	return newStruct(map[string]interface{}{
+		"token":       auth.GetTokenService(s.services.Get(auth.TokenServiceKey)).AddToken(),
+		"primary_key": t.GetPrimaryKey().Name,
+		"can_add":     t.GetCanAdd(),
+		"editable":    t.GetEditable(),
+		"deletable":   t.GetDeletable(),
+		"info":        info,
+		"form":        formFields(t.GetForm().FieldList),
+	})
+}
+
+// prepare find the table of the request prefix, build a Context of the
+// equivalent http route and check the session and permission of the user.
+func (s *Server) prepare(c context2.Context, req *structpb.Struct, method, path string,
+	query url.Values, values form.Values) (*context.Context, table.Table, error) {
+
+	prefix := stringOf(req.GetFields()["prefix"])
+	generator, ok := s.generators.Get(prefix)
+	if prefix == "" || !ok {
+		return nil, nil, status.Error(codes.NotFound, "table not found")
+	}
+
+	ctx, err := newContext(c, method, config.Url(strings.Replace(path, "%s", prefix, 1)), query, values)
+	if err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user, authOk, permissionOk := auth.Filter(ctx, s.conn)
+	if !authOk {
+		return nil, nil, status.Error(codes.Unauthenticated, "login required")
+	}
+	if !permissionOk {
+		return nil, nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+	ctx.SetUserValue("user", user)
+
+	q := ctx.Request.URL.Query()
+	q.Set(constant.PrefixKey, prefix)
+	ctx.Request.URL.RawQuery = q.Encode()
+
+	return ctx, generator(ctx), nil
+}
+
+// newContext build a Context whose request carries the session cookie of the
+// incoming metadata, the values are also the multipart form read by the
+// guards of the forms.
+func newContext(c context2.Context, method, path string, query url.Values, values form.Values) (*context.Context, error) {
+	md, _ := metadata.FromIncomingContext(c)
+	sessions := md.Get(auth.DefaultCookieKey)
+	if len(sessions) == 0 || sessions[0] == "" {
+		return nil, errors.New("missing " + auth.DefaultCookieKey + " metadata")
+	}
+
+	u := path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	r, err := http.NewRequestWithContext(c, method, u, strings.NewReader(url.Values(values).Encode()))
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("Accept", "application/json")
+	r.AddCookie(&http.Cookie{Name: auth.DefaultCookieKey, Value: sessions[0]})
+	_ = r.ParseForm()
+	r.MultipartForm = &multipart.Form{
+		Value: url.Values(values),
+		File:  make(map[string][]*multipart.FileHeader),
+	}
+
+	return context.NewContext(r), nil
+}
+
+func formValues(req *structpb.Struct) form.Values {
+	values := make(form.Values)
+	for key, value := range req.GetFields()["values"].GetStructValue().GetFields() {
+		if list := value.GetListValue(); list != nil {
+			for _, v := range list.GetValues() {
+				values[key] = append(values[key], stringOf(v))
+			}
+			continue
+		}
+		values.Add(key, stringOf(value))
+	}
+	return values
+}
+
+func formFields(fields types.FormFields) []interface{} {
+	list := make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		options := make([]interface{}, 0, len(field.Options))
+		for _, op := range field.Options {
+			options = append(options, map[string]interface{}{"text": op.Text, "value": op.Value})
+		}
+		list = append(list, map[string]interface{}{
+			"field":          field.Field,
+			"head":           field.Head,
+			"type":           string(field.TypeName),
+			"form_type":      field.FormType.String(),
+			"must":           field.Must,
+			"not_allow_add":  field.NotAllowAdd,
+			"not_allow_edit": field.NotAllowEdit,
+			"options":        options,
+		})
+	}
+	return list
+}
+
+func rows(list types.InfoList) []interface{} {
+	res := make([]interface{}, 0, len(list))
+	for _, row := range list {
+		m := make(map[string]interface{}, len(row))
+		for key, item := range row {
+			m[key] = item.Value
+		}
+		res = append(res, m)
+	}
+	return res
+}
+
+func stringOf(v *structpb.Value) string {
+	if v == nil {
+		return ""
+	}
+	switch k := v.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		return k.StringValue
+	case *structpb.Value_NumberValue, *structpb.Value_BoolValue:
+		b, _ := v.MarshalJSON()
+		return string(b)
+	default:
+		return ""
+	}
+}
+
+func newStruct(m map[string]interface{}) (*structpb.Struct, error) {
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return s, nil
+}
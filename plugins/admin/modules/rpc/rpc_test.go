@@ -0,0 +1,179 @@
+package rpc
+
+import (
+	context2 "context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	_ "github.com/purpose168/GoAdmin/modules/db/drivers/sqlite"
+	"github.com/purpose168/GoAdmin/modules/service"
+	"github.com/purpose168/GoAdmin/plugins/admin/controller"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/guard"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	"github.com/purpose168/GoAdmin/template/types"
+	form2 "github.com/purpose168/GoAdmin/template/types/form"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestFormValues(t *testing.T) {
+	req, err := structpb.NewStruct(map[string]interface{}{
+		"prefix": "user",
+		"values": map[string]interface{}{
+			"name":  "jane",
+			"age":   18,
+			"roles": []interface{}{"1", 2},
+		},
+	})
+	assert.Equal(t, err, nil)
+
+	values := formValues(req)
+	assert.Equal(t, values.Get("name"), "jane")
+	assert.Equal(t, values.Get("age"), "18")
+	assert.Equal(t, values["roles"], []string{"1", "2"})
+}
+
+func TestPrepareNotFound(t *testing.T) {
+	s := New(nil, nil, table.GeneratorList{}, nil)
+	req, _ := structpb.NewStruct(map[string]interface{}{"prefix": "user"})
+	_, err := s.List(context2.Background(), req)
+	assert.Equal(t, status.Code(err), codes.NotFound)
+}
+
+// newTestServer return a server of the table posts of a sqlite copy of the
+// admin database, whose writes run by the guards and the handlers of the
+// forms.
+func newTestServer(t *testing.T) (*Server, db.Connection) {
+	data, err := os.ReadFile("../../../../data/admin.db")
+	assert.Equal(t, err, nil)
+	file := filepath.Join(t.TempDir(), "admin.db")
+	assert.Equal(t, os.WriteFile(file, data, 0644), nil)
+
+	cfg := config.Initialize(&config.Config{UrlPrefix: "admin",
+		Databases: config.DatabaseList{"default": {Driver: db.DriverSqlite, File: file}}})
+	conn := db.GetSqliteDB()
+	conn.InitDB(cfg.Databases)
+	_, err = conn.Exec("create table posts (id integer primary key autoincrement, title text)")
+	assert.Equal(t, err, nil)
+	_, err = db.WithDriver(conn).Table("goadmin_session").
+		Insert(map[string]interface{}{"sid": "rpc", "values": `{"user_id":1}`})
+	assert.Equal(t, err, nil)
+
+	services := service.List{}
+	services.Add(db.DriverSqlite, conn)
+	services.Add(auth.InitCSRFTokenSrv(conn))
+	table.SetServices(services)
+
+	generators := table.GeneratorList{"posts": func(ctx *context.Context) table.Table {
+		tb := table.NewDefaultTable(ctx, table.DefaultConfigWithDriver(db.DriverSqlite))
+		tb.GetInfo().SetTable("posts").
+			AddField("ID", "id", db.Int).
+			AddField("Title", "title", db.Varchar)
+		tb.GetForm().SetTable("posts").
+			AddField("ID", "id", db.Int, form2.Default).FieldNotAllowAdd().
+			AddField("Title", "title", db.Varchar, form2.Text).
+			SetPostValidator(func(values form.Values) error {
+				if len(values.Get("title")) > 5 {
+					return errors.New("title too long")
+				}
+				return nil
+			}).
+			SetPreSave(func(hc *types.FormHookContext) error {
+				hc.Values["title"] = []string{strings.ToUpper(hc.Values.Get("title"))}
+				return nil
+			})
+		return tb
+	}}
+
+	g := guard.New(services, conn, generators, nil)
+	h := controller.New()
+	h.UpdateCfg(controller.Config{Config: cfg, Services: services, Generators: generators, Connection: conn})
+
+	app := context.NewApp()
+	route := app.Group(config.Prefix(), func(ctx *context.Context) {
+		defer h.GlobalDeferHandler(ctx)
+		ctx.Next()
+	}, auth.Middleware(conn), g.CheckPrefix)
+	route.POST("/new/:__prefix", g.NewForm, h.NewForm).Name("new")
+	route.POST("/edit/:__prefix", g.EditForm, h.EditForm).Name("edit")
+	route.POST("/delete/:__prefix", g.Delete, h.Delete).Name("delete")
+
+	return New(services, conn, generators, app), conn
+}
+
+func TestServer_Write(t *testing.T) {
+	s, conn := newTestServer(t)
+	c := metadata.NewIncomingContext(context2.Background(), metadata.Pairs(auth.DefaultCookieKey, "rpc"))
+
+	call := func(fn handler, req map[string]interface{}) (*structpb.Struct, error) {
+		req["prefix"] = "posts"
+		r, err := structpb.NewStruct(req)
+		assert.Equal(t, err, nil)
+		return fn(s, c, r)
+	}
+	token := func() string {
+		res, err := call((*Server).Fields, map[string]interface{}{})
+		assert.Equal(t, err, nil)
+		return res.GetFields()["token"].GetStringValue()
+	}
+	titles := func() []string {
+		rows, err := db.WithDriver(conn).Table("posts").OrderBy("id", "asc").All()
+		assert.Equal(t, err, nil)
+		list := make([]string, len(rows))
+		for i, row := range rows {
+			list[i], _ = row["title"].(string)
+		}
+		return list
+	}
+
+	// the writes need the single use token of the forms.
+	_, err := call((*Server).Create, map[string]interface{}{"values": map[string]interface{}{"title": "a"}})
+	assert.Equal(t, status.Code(err), codes.InvalidArgument)
+
+	// the values are validated, and the error returns a new token.
+	_, err = call((*Server).Create, map[string]interface{}{"token": token(),
+		"values": map[string]interface{}{"title": "abcdef"}})
+	assert.Equal(t, status.Code(err), codes.InvalidArgument)
+	assert.Equal(t, strings.Contains(status.Convert(err).Message(), "title too long"), true)
+	assert.Equal(t, len(status.Convert(err).Details()), 1)
+	assert.Equal(t, len(titles()), 0)
+
+	// the pre save hook runs, the token is not reused.
+	used := token()
+	res, err := call((*Server).Create, map[string]interface{}{"token": used,
+		"values": map[string]interface{}{"title": "abc"}})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, titles(), []string{"ABC"})
+	_, err = call((*Server).Create, map[string]interface{}{"token": used,
+		"values": map[string]interface{}{"title": "xyz"}})
+	assert.Equal(t, status.Code(err), codes.InvalidArgument)
+
+	_, err = call((*Server).Update, map[string]interface{}{"token": res.GetFields()["token"].GetStringValue(),
+		"values": map[string]interface{}{"id": "1", "title": "abcdef"}})
+	assert.Equal(t, status.Code(err), codes.InvalidArgument)
+	_, err = call((*Server).Update, map[string]interface{}{"token": token(),
+		"values": map[string]interface{}{"id": "1", "title": "def"}})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, titles(), []string{"DEF"})
+
+	_, err = call((*Server).Delete, map[string]interface{}{"id": "1"})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(titles()), 0)
+
+	// the calls are in the operation log as the forms.
+	logs, err := db.WithDriver(conn).Table("goadmin_operation_log").
+		Where("path", "=", "/admin/new/posts").All()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(logs), 4)
+}
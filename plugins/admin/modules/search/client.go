@@ -0,0 +1,168 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client is a minimal client of the REST api shared by Elasticsearch and
+// OpenSearch.
+type Client struct {
+	addr     string
+	username string
+	password string
+	http     *http.Client
+}
+
+// NewClient return a client of the address, such as "http://127.0.0.1:9200".
+func NewClient(addr string) *Client {
+	return &Client{
+		addr: strings.TrimRight(addr, "/"),
+		http: &http.Client{Timeout: time.Second * 10},
+	}
+}
+
+// SetBasicAuth set the username and password of the requests.
+func (c *Client) SetBasicAuth(username, password string) *Client {
+	c.username = username
+	c.password = password
+	return c
+}
+
+// SetHTTPClient set the http client of the requests.
+func (c *Client) SetHTTPClient(client *http.Client) *Client {
+	c.http = client
+	return c
+}
+
+// Hit is a document matched by the search.
+type Hit struct {
+	Index  string                 `json:"_index"`
+	ID     string                 `json:"_id"`
+	Score  float64                `json:"_score"`
+	Source map[string]interface{} `json:"_source"`
+}
+
+// Put index the document of the id.
+func (c *Client) Put(index, id string, doc map[string]interface{}) error {
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return c.do(http.MethodPut, "/"+url.PathEscape(index)+"/_doc/"+url.PathEscape(id), "application/json", body, nil)
+}
+
+// Delete remove the document of the id, a missing document is not an error.
+func (c *Client) Delete(index, id string) error {
+	err := c.do(http.MethodDelete, "/"+url.PathEscape(index)+"/_doc/"+url.PathEscape(id), "", nil, nil)
+	if errors.Is(err, errNotFound) {
+		return nil
+	}
+	return err
+}
+
+// DeleteIndex remove the index, a missing index is not an error.
+func (c *Client) DeleteIndex(index string) error {
+	err := c.do(http.MethodDelete, "/"+url.PathEscape(index), "", nil, nil)
+	if errors.Is(err, errNotFound) {
+		return nil
+	}
+	return err
+}
+
+// Bulk index the documents of the ids.
+func (c *Client) Bulk(index string, docs map[string]map[string]interface{}) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for id, doc := range docs {
+		if err := enc.Encode(map[string]interface{}{"index": map[string]string{"_index": index, "_id": id}}); err != nil {
+			return err
+		}
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+	}
+	var res struct {
+		Errors bool `json:"errors"`
+	}
+	if err := c.do(http.MethodPost, "/_bulk", "application/x-ndjson", buf.Bytes(), &res); err != nil {
+		return err
+	}
+	if res.Errors {
+		return errors.New("search: bulk index of " + index + " partially failed")
+	}
+	return nil
+}
+
+// Search query the indices and return the hits.
+func (c *Client) Search(indices []string, query map[string]interface{}, size int) ([]Hit, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"size":  size,
+		"query": query,
+	})
+	if err != nil {
+		return nil, err
+	}
+	escaped := make([]string, len(indices))
+	for i, index := range indices {
+		escaped[i] = url.PathEscape(index)
+	}
+	var res struct {
+		Hits struct {
+			Hits []Hit `json:"hits"`
+		} `json:"hits"`
+	}
+	err = c.do(http.MethodPost, "/"+strings.Join(escaped, ",")+"/_search?ignore_unavailable=true",
+		"application/json", body, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res.Hits.Hits, nil
+}
+
+var errNotFound = errors.New("search: not found")
+
+func (c *Client) do(method, path, contentType string, body []byte, res interface{}) error {
+	req, err := http.NewRequest(method, c.addr+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	if resp.StatusCode >= 300 {
+		return errors.New("search: " + resp.Status + ": " + string(data))
+	}
+	if res == nil {
+		return nil
+	}
+	return json.Unmarshal(data, res)
+}
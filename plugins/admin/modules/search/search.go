@@ -0,0 +1,327 @@
+// Package search mirrors the rows of the selected tables into Elasticsearch or
+// OpenSearch indices.
+//
+// The indices are kept updated by the data change events of the event bus and
+// are used by the global search of the admin plugin and the filters of the
+// operator types.FilterOperatorFuzzy.
+package search
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/event"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+)
+
+// Table is the index config of a generator.
+type Table struct {
+	Prefix     string
+	Table      string
+	Connection string
+	PrimaryKey string
+	Title      string
+	Fields     []string
+}
+
+// SetPrimaryKey set the primary key of the table, default "id".
+func (t *Table) SetPrimaryKey(pk string) *Table {
+	t.PrimaryKey = pk
+	return t
+}
+
+// SetConnection set the connection name of the table, default "default".
+func (t *Table) SetConnection(conn string) *Table {
+	t.Connection = conn
+	return t
+}
+
+// SetTitle set the field shown as the title of the global search results,
+// default the first indexed field.
+func (t *Table) SetTitle(field string) *Table {
+	t.Title = field
+	return t
+}
+
+func (t *Table) title() string {
+	if t.Title != "" {
+		return t.Title
+	}
+	if len(t.Fields) > 0 {
+		return t.Fields[0]
+	}
+	return t.PrimaryKey
+}
+
+// Result is an item of the global search.
+type Result struct {
+	Prefix string  `json:"prefix"`
+	ID     string  `json:"id"`
+	Title  string  `json:"title"`
+	Score  float64 `json:"score"`
+}
+
+// Indexer mirrors the tables into the indices.
+type Indexer struct {
+	conn        db.Connection
+	client      *Client
+	indexPrefix string
+	fuzzySize   int
+
+	lock   sync.RWMutex
+	tables map[string]*Table
+}
+
+// New return an indexer of the database connection and the search client.
+func New(conn db.Connection, client *Client) *Indexer {
+	return &Indexer{
+		conn:        conn,
+		client:      client,
+		indexPrefix: "goadmin_",
+		fuzzySize:   1000,
+		tables:      make(map[string]*Table),
+	}
+}
+
+// SetIndexPrefix set the prefix of the index names, default "goadmin_".
+func (ix *Indexer) SetIndexPrefix(prefix string) *Indexer {
+	ix.indexPrefix = prefix
+	return ix
+}
+
+// SetFuzzySize set the max count of the rows matched by a fuzzy filter.
+func (ix *Indexer) SetFuzzySize(size int) *Indexer {
+	ix.fuzzySize = size
+	return ix
+}
+
+// Add index the fields of the table of the generator, all the fields are
+// indexed when no field is given.
+func (ix *Indexer) Add(prefix, tableName string, fields ...string) *Table {
+	ix.lock.Lock()
+	defer ix.lock.Unlock()
+	t := &Table{
+		Prefix:     prefix,
+		Table:      tableName,
+		Connection: table.DefaultConnectionName,
+		PrimaryKey: table.DefaultPrimaryKeyName,
+		Fields:     fields,
+	}
+	ix.tables[prefix] = t
+	return t
+}
+
+// Index return the index name of the generator.
+func (ix *Indexer) Index(prefix string) string {
+	return ix.indexPrefix + prefix
+}
+
+var (
+	current     *Indexer
+	currentLock sync.RWMutex
+)
+
+// Current return the started indexer, nil when there is no one.
+func Current() *Indexer {
+	currentLock.RLock()
+	defer currentLock.RUnlock()
+	return current
+}
+
+// Start subscribe the event bus and use the indexer as the global search and
+// the fuzzy search of the filters.
+func (ix *Indexer) Start() {
+	currentLock.Lock()
+	current = ix
+	currentLock.Unlock()
+
+	event.Subscribe(ix.handle)
+	table.SetFuzzySearchFn(ix.FuzzySearch)
+}
+
+func (ix *Indexer) tablesOf(conn, tableName string) []*Table {
+	if conn == "" {
+		conn = table.DefaultConnectionName
+	}
+	ix.lock.RLock()
+	defer ix.lock.RUnlock()
+	list := make([]*Table, 0)
+	for _, t := range ix.tables {
+		if t.Table == tableName && t.Connection == conn {
+			list = append(list, t)
+		}
+	}
+	return list
+}
+
+func (ix *Indexer) handle(e event.Event) {
+	for _, t := range ix.tablesOf(e.Connection, e.Table) {
+		var err error
+		if e.Action == event.Delete {
+			for _, id := range e.IDs {
+				if err = ix.client.Delete(ix.Index(t.Prefix), id); err != nil {
+					break
+				}
+			}
+		} else {
+			err = ix.sync(t, e.IDs)
+		}
+		if err != nil {
+			logger.Error("update search index of "+t.Prefix+" error: ", err)
+		}
+	}
+}
+
+func (ix *Indexer) query(t *Table) *db.SQL {
+	sql := db.WithDriverAndConnection(t.Connection, ix.conn).Table(t.Table)
+	if len(t.Fields) > 0 {
+		sql = sql.Select(append([]string{t.PrimaryKey}, t.Fields...)...)
+	}
+	return sql
+}
+
+// sync index the rows of the ids.
+func (ix *Indexer) sync(t *Table, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	rows, err := ix.query(t).WhereIn(t.PrimaryKey, args).All()
+	if err != nil {
+		return err
+	}
+	return ix.client.Bulk(ix.Index(t.Prefix), ix.docs(t, rows))
+}
+
+// Rebuild drop the index of the generator and index all the rows of the table.
+func (ix *Indexer) Rebuild(prefix string) error {
+	ix.lock.RLock()
+	t, ok := ix.tables[prefix]
+	ix.lock.RUnlock()
+	if !ok {
+		return fmt.Errorf("search: %s is not indexed", prefix)
+	}
+
+	if err := ix.client.DeleteIndex(ix.Index(prefix)); err != nil {
+		return err
+	}
+
+	const batch = 500
+	for offset := 0; ; offset += batch {
+		rows, err := ix.query(t).OrderBy(t.PrimaryKey, "asc").Skip(offset).Take(batch).All()
+		if err != nil {
+			return err
+		}
+		if err := ix.client.Bulk(ix.Index(prefix), ix.docs(t, rows)); err != nil {
+			return err
+		}
+		if len(rows) < batch {
+			return nil
+		}
+	}
+}
+
+func (ix *Indexer) docs(t *Table, rows []map[string]interface{}) map[string]map[string]interface{} {
+	docs := make(map[string]map[string]interface{}, len(rows))
+	for _, row := range rows {
+		doc := make(map[string]interface{}, len(row))
+		for k, v := range row {
+			if b, ok := v.([]byte); ok {
+				v = string(b)
+			}
+			doc[k] = v
+		}
+		docs[fmt.Sprintf("%v", doc[t.PrimaryKey])] = doc
+	}
+	return docs
+}
+
+// Search query all the indices of the given generators, or of all the indexed
+// generators when no prefix is given.
+func (ix *Indexer) Search(keyword string, size int, prefixes ...string) ([]Result, error) {
+	ix.lock.RLock()
+	tables := make(map[string]*Table)
+	for prefix, t := range ix.tables {
+		if len(prefixes) == 0 || utils.InArray(prefixes, prefix) {
+			tables[ix.Index(prefix)] = t
+		}
+	}
+	ix.lock.RUnlock()
+
+	if len(tables) == 0 {
+		return []Result{}, nil
+	}
+
+	indices := make([]string, 0, len(tables))
+	for index := range tables {
+		indices = append(indices, index)
+	}
+	sort.Strings(indices)
+
+	hits, err := ix.client.Search(indices, map[string]interface{}{
+		"multi_match": map[string]interface{}{
+			"query":     keyword,
+			"fuzziness": "AUTO",
+			"lenient":   true,
+		},
+	}, size)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(hits))
+	for _, hit := range hits {
+		t, ok := tables[hit.Index]
+		if !ok {
+			continue
+		}
+		title := ""
+		if v, ok := hit.Source[t.title()]; ok && v != nil {
+			title = fmt.Sprintf("%v", v)
+		}
+		results = append(results, Result{
+			Prefix: t.Prefix,
+			ID:     hit.ID,
+			Title:  title,
+			Score:  hit.Score,
+		})
+	}
+	return results, nil
+}
+
+// FuzzySearch implements table.FuzzySearchFn.
+func (ix *Indexer) FuzzySearch(conn, tableName, field, keyword string) ([]string, error) {
+	tables := ix.tablesOf(conn, tableName)
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("search: table %s is not indexed", tableName)
+	}
+	t := tables[0]
+	if len(t.Fields) > 0 && !utils.InArray(t.Fields, field) {
+		return nil, fmt.Errorf("search: field %s of %s is not indexed", field, tableName)
+	}
+
+	hits, err := ix.client.Search([]string{ix.Index(t.Prefix)}, map[string]interface{}{
+		"match": map[string]interface{}{
+			field: map[string]interface{}{
+				"query":     keyword,
+				"fuzziness": "AUTO",
+			},
+		},
+	}, ix.fuzzySize)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+	return ids, nil
+}
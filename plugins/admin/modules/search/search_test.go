@@ -0,0 +1,52 @@
+package search
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestFuzzySearch(t *testing.T) {
+	var (
+		path  string
+		query map[string]interface{}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&query)
+		_, _ = w.Write([]byte(`{"hits":{"hits":[{"_index":"goadmin_user","_id":"1"},{"_index":"goadmin_user","_id":"3"}]}}`))
+	}))
+	defer srv.Close()
+
+	ix := New(nil, NewClient(srv.URL))
+	ix.Add("user", "users", "name", "email")
+
+	ids, err := ix.FuzzySearch("default", "users", "name", "jon")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, ids, []string{"1", "3"})
+	assert.Equal(t, path, "/goadmin_user/_search")
+	assert.Equal(t, query["size"], float64(1000))
+
+	_, err = ix.FuzzySearch("default", "users", "password", "jon")
+	assert.Equal(t, err != nil, true)
+
+	_, err = ix.FuzzySearch("default", "posts", "title", "jon")
+	assert.Equal(t, err != nil, true)
+}
+
+func TestSearch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"hits":{"hits":[{"_index":"goadmin_user","_id":"1","_score":2,"_source":{"id":1,"name":"jon"}}]}}`))
+	}))
+	defer srv.Close()
+
+	ix := New(nil, NewClient(srv.URL))
+	ix.Add("user", "users", "name")
+
+	results, err := ix.Search("jon", 10)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, results, []Result{{Prefix: "user", ID: "1", Title: "jon", Score: 2}})
+}
@@ -0,0 +1,122 @@
+package table
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	_ "github.com/purpose168/GoAdmin-themes/adminlte"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// benchConnection is a mysql connection replying the columns and the rows
+// of the list query, so the query building is benchmarked without a database.
+type benchConnection struct {
+	db.Connection
+	columns []map[string]interface{}
+	rows    []map[string]interface{}
+	onQuery func(query string, args []interface{})
+}
+
+func (c *benchConnection) Name() string          { return db.DriverMysql }
+func (c *benchConnection) GetDelimiter() string  { return "`" }
+func (c *benchConnection) GetDelimiter2() string { return "`" }
+func (c *benchConnection) GetDelimiters() []string {
+	return []string{"`", "`"}
+}
+
+func (c *benchConnection) QueryWithConnection(_, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	if c.onQuery != nil {
+		c.onQuery(query, args)
+	}
+	switch {
+	case strings.HasPrefix(query, "show columns"):
+		return c.columns, nil
+	case strings.HasPrefix(query, "select count(*)"):
+		return []map[string]interface{}{{"count(*)": int64(len(c.rows))}}, nil
+	default:
+		return c.rows, nil
+	}
+}
+
+func (c *benchConnection) QueryWith(_ *sql.Tx, conn, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	return c.QueryWithConnection(conn, query, args...)
+}
+
+func (c *benchConnection) ExecWith(_ *sql.Tx, _, query string, args ...interface{}) (sql.Result, error) {
+	if c.onQuery != nil {
+		c.onQuery(query, args)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+var benchConfigOnce sync.Once
+
+func newBenchTable() *DefaultTable {
+	benchConfigOnce.Do(func() {
+		config.Initialize(&config.Config{UrlPrefix: "admin", Theme: "adminlte"})
+	})
+
+	var (
+		fields = []string{"id", "name", "email", "phone", "city", "role_id", "status", "created_at", "updated_at"}
+		conn   = &benchConnection{}
+	)
+	for _, field := range fields {
+		conn.columns = append(conn.columns, map[string]interface{}{"Field": field})
+	}
+	for i := 0; i < 20; i++ {
+		row := map[string]interface{}{"roles_goadmin_join_name": "role"}
+		for _, field := range fields {
+			row[field] = field + strconv.Itoa(i)
+		}
+		row["id"] = int64(i + 1)
+		conn.rows = append(conn.rows, row)
+	}
+
+	tb := NewDefaultTable(nil, DefaultConfigWithDriver(db.DriverMysql)).(*DefaultTable)
+	tb.dbObj = conn
+
+	info := tb.GetInfo()
+	info.AddField("ID", "id", db.Int).FieldSortable()
+	info.AddField("Name", "name", db.Varchar).FieldFilterable()
+	info.AddField("Email", "email", db.Varchar).FieldFilterable(types.FilterType{Operator: types.FilterOperatorLike})
+	info.AddField("Phone", "phone", db.Varchar)
+	info.AddField("City", "city", db.Varchar).FieldFilterable()
+	info.AddField("Role", "name", db.Varchar).FieldJoin(types.Join{
+		Table:     "roles",
+		Field:     "role_id",
+		JoinField: "id",
+	}).FieldFilterable()
+	info.AddField("Status", "status", db.Tinyint).FieldDisplay(func(model types.FieldModel) interface{} {
+		return strings.ToUpper(model.Value)
+	})
+	info.AddField("Created", "created_at", db.Timestamp)
+	info.AddField("Updated", "updated_at", db.Timestamp)
+	info.SetTable("users")
+
+	return tb
+}
+
+func BenchmarkDefaultTable_GetData(b *testing.B) {
+	var (
+		tb     = newBenchTable()
+		u, _   = url.Parse("/admin/info/users?__page=2&__pageSize=20&name=foo&email=bar&__sort=id&__sort_type=desc")
+		params = parameter.GetParam(u, 20)
+	)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := tb.GetData(nil, params); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
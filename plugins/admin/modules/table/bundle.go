@@ -0,0 +1,317 @@
+package table
+
+import (
+	dbsql "database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// ErrSyncNotSupported is returned by the data sync of the tables whose data
+// are not from the database or which do not enable the data sync.
+var ErrSyncNotSupported = errors.New("data sync is not supported by the table")
+
+// errDryRun rolls back the transaction of a dry run import.
+var errDryRun = errors.New("dry run")
+
+// The actions of the rows of an import.
+const (
+	BundleInsert    = "insert"
+	BundleUpdate    = "update"
+	BundleUnchanged = "unchanged"
+)
+
+// SyncTable is a table supporting the data sync between the environments,
+// implemented by DefaultTable.
+type SyncTable interface {
+	ExportBundle(ids []string) (DataBundle, error)
+	ImportBundle(bundle DataBundle, dryRun bool) ([]BundleChange, error)
+}
+
+// DataBundle is the portable rows of a table with the rows of the option
+// tables they refer to. The primary keys differ between the environments, so
+// the rows are matched by the sync field of the table and the text fields of
+// the option tables when imported.
+type DataBundle struct {
+	Table     string                   `json:"table"`
+	Rows      []map[string]interface{} `json:"rows"`
+	Relations []BundleRelation         `json:"relations"`
+}
+
+// BundleRelation is the rows of the option table of a field, see
+// types.FormPanel.FieldOptionsFromTable.
+type BundleRelation struct {
+	Field      string                   `json:"field"`
+	Table      string                   `json:"table"`
+	KeyField   string                   `json:"key_field"`
+	MatchField string                   `json:"match_field"`
+	Rows       []map[string]interface{} `json:"rows"`
+}
+
+// BundleChange is the result of importing a row, Key is the primary key in
+// the bundle and NewKey is the one in this environment, which is empty for
+// the inserted rows of a dry run.
+type BundleChange struct {
+	Table   string       `json:"table"`
+	Key     string       `json:"key"`
+	NewKey  string       `json:"new_key"`
+	Action  string       `json:"action"`
+	Changes []GridChange `json:"changes"`
+}
+
+// syncRelations return the fields of the form whose options are from the
+// tables, which are the foreign keys remapped by the import.
+func (tb *DefaultTable) syncRelations() types.FormFields {
+	fields := make(types.FormFields, 0)
+	columns, _ := tb.getColumns(tb.Form.Table)
+	for _, field := range tb.Form.FieldList {
+		ot := field.OptionTable
+		if ot.Table != "" && ot.TextField != "" && ot.ValueField != "" && modules.InArray(columns, field.Field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func (tb *DefaultTable) syncSupported() bool {
+	return tb.Info.SyncField != "" && tb.getDataFromDB() && tb.Form.Table != ""
+}
+
+// ExportBundle return the rows of the ids and the rows of the option tables
+// referred by them.
+func (tb *DefaultTable) ExportBundle(ids []string) (DataBundle, error) {
+	if !tb.syncSupported() {
+		return DataBundle{}, ErrSyncNotSupported
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	rows, err := tb.sql().Table(tb.Form.Table).WhereIn(tb.PrimaryKey.Name, args).All()
+	if err != nil {
+		return DataBundle{}, err
+	}
+
+	bundle := DataBundle{
+		Table:     tb.Form.Table,
+		Rows:      bundleRows(rows),
+		Relations: make([]BundleRelation, 0),
+	}
+
+	for _, field := range tb.syncRelations() {
+		keys := make([]interface{}, 0)
+		seen := make(map[string]bool)
+		for _, row := range bundle.Rows {
+			if key := gridCell(row[field.Field]); row[field.Field] != nil && key != "" && !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		ot := field.OptionTable
+		related, err := tb.sql().Table(ot.Table).WhereIn(ot.ValueField, keys).All()
+		if err != nil {
+			return DataBundle{}, err
+		}
+		bundle.Relations = append(bundle.Relations, BundleRelation{
+			Field:      field.Field,
+			Table:      ot.Table,
+			KeyField:   ot.ValueField,
+			MatchField: ot.TextField,
+			Rows:       bundleRows(related),
+		})
+	}
+
+	return bundle, nil
+}
+
+// ImportBundle import the rows of the bundle in a transaction. The rows of the
+// option tables are imported first, the foreign keys of the rows are remapped
+// to the primary keys of this environment. Nothing is written for a dry run,
+// the changes which would be made are returned.
+func (tb *DefaultTable) ImportBundle(bundle DataBundle, dryRun bool) ([]BundleChange, error) {
+	if !tb.syncSupported() {
+		return nil, ErrSyncNotSupported
+	}
+	if bundle.Table != tb.Form.Table {
+		return nil, fmt.Errorf("the bundle is of the table %s", bundle.Table)
+	}
+
+	// only the relations of the option tables of the form are imported, the
+	// bundle can not write any other table.
+	relations := make(map[string]types.OptionTable)
+	for _, field := range tb.syncRelations() {
+		relations[field.Field] = field.OptionTable
+	}
+	for _, relation := range bundle.Relations {
+		ot, ok := relations[relation.Field]
+		if !ok || ot.Table != relation.Table || ot.ValueField != relation.KeyField || ot.TextField != relation.MatchField {
+			return nil, fmt.Errorf("wrong relation of the field %s", relation.Field)
+		}
+	}
+
+	// the columns are read before the transaction, which holds the only
+	// connection of some drivers.
+	columns := map[string]Columns{tb.Form.Table: nil}
+	for _, relation := range bundle.Relations {
+		columns[relation.Table] = nil
+	}
+	for table := range columns {
+		columns[table], _ = tb.getColumns(table)
+	}
+
+	changes := make([]BundleChange, 0)
+	_, err := tb.sql().WithTransaction(func(tx *dbsql.Tx) (error, map[string]interface{}) {
+		keys := make(map[string]map[string]string)
+		for _, relation := range bundle.Relations {
+			mapping, relationChanges, err := tb.importRows(tx, columns[relation.Table], relation.Table, relation.KeyField, relation.MatchField,
+				relation.Rows, nil, dryRun)
+			if err != nil {
+				return err, nil
+			}
+			keys[relation.Field] = mapping
+			changes = append(changes, relationChanges...)
+		}
+		_, rowChanges, err := tb.importRows(tx, columns[tb.Form.Table], tb.Form.Table, tb.PrimaryKey.Name, tb.Info.SyncField,
+			bundle.Rows, keys, dryRun)
+		if err != nil {
+			return err, nil
+		}
+		changes = append(changes, rowChanges...)
+		if dryRun {
+			return errDryRun, nil
+		}
+		return nil, nil
+	})
+	if err != nil && err != errDryRun {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// importRows insert or update the rows matched by the match field, the values
+// of the remapped fields are replaced by the keys of this environment. The
+// mapping from the keys of the bundle to the keys of this environment is
+// returned.
+func (tb *DefaultTable) importRows(tx *dbsql.Tx, columns Columns, table, key, match string,
+	rows []map[string]interface{}, remap map[string]map[string]string, dryRun bool) (map[string]string, []BundleChange, error) {
+
+	if !modules.InArray(columns, key) || !modules.InArray(columns, match) {
+		return nil, nil, fmt.Errorf("wrong fields %s, %s of the table %s", key, match, table)
+	}
+
+	find := func(value interface{}) (map[string]interface{}, error) {
+		res, err := tb.sql().WithTx(tx).Table(table).Where(match, "=", value).All()
+		if err != nil || len(res) == 0 {
+			return nil, err
+		}
+		return res[0], nil
+	}
+
+	mapping := make(map[string]string)
+	changes := make([]BundleChange, 0, len(rows))
+	for _, row := range rows {
+		oldKey := gridCell(row[key])
+		if row[match] == nil {
+			return nil, nil, fmt.Errorf("%s %s of the table %s has no %s", key, oldKey, table, match)
+		}
+
+		values := dialect.H{}
+		for name, value := range row {
+			if name == key || !modules.InArray(columns, name) {
+				continue
+			}
+			if keys, ok := remap[name]; ok && value != nil && gridCell(value) != "" {
+				newKey, ok := keys[gridCell(value)]
+				if !ok {
+					return nil, nil, fmt.Errorf("%s %s of the table %s refers to a missing row by %s",
+						key, oldKey, table, name)
+				}
+				value = newKey
+			}
+			values[name] = value
+		}
+
+		existing, err := find(row[match])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		change := BundleChange{Table: table, Key: oldKey, Changes: make([]GridChange, 0)}
+		if existing == nil {
+			change.Action = BundleInsert
+			for _, name := range sortedKeys(values) {
+				change.Changes = append(change.Changes, GridChange{Field: name, New: gridCell(values[name])})
+			}
+			if _, err := tb.sql().WithTx(tx).Table(table).Insert(values); db.CheckError(err, db.INSERT) {
+				return nil, nil, err
+			}
+			inserted, err := find(row[match])
+			if err != nil {
+				return nil, nil, err
+			}
+			if inserted == nil {
+				return nil, nil, fmt.Errorf("%s %s of the table %s is not inserted", key, oldKey, table)
+			}
+			mapping[oldKey] = gridCell(inserted[key])
+			if !dryRun {
+				change.NewKey = mapping[oldKey]
+			}
+		} else {
+			change.Action = BundleUnchanged
+			change.NewKey = gridCell(existing[key])
+			updates := dialect.H{}
+			for _, name := range sortedKeys(values) {
+				if old, value := gridCell(existing[name]), gridCell(values[name]); old != value {
+					updates[name] = values[name]
+					change.Changes = append(change.Changes, GridChange{Field: name, Old: old, New: value})
+				}
+			}
+			if len(updates) > 0 {
+				change.Action = BundleUpdate
+				if _, err := tb.sql().WithTx(tx).Table(table).Where(key, "=", existing[key]).
+					Update(updates); db.CheckError(err, db.UPDATE) {
+					return nil, nil, err
+				}
+			}
+			mapping[oldKey] = change.NewKey
+		}
+		changes = append(changes, change)
+	}
+
+	return mapping, changes, nil
+}
+
+// bundleRows format the values of the rows as the texts, so the bundle is
+// the same whatever the driver is.
+func bundleRows(rows []map[string]interface{}) []map[string]interface{} {
+	res := make([]map[string]interface{}, len(rows))
+	for i, row := range rows {
+		res[i] = make(map[string]interface{}, len(row))
+		for name, value := range row {
+			if value == nil {
+				res[i][name] = nil
+			} else {
+				res[i][name] = gridCell(value)
+			}
+		}
+	}
+	return res
+}
+
+func sortedKeys(values dialect.H) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,94 @@
+package table
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	_ "github.com/purpose168/GoAdmin/modules/db/drivers/sqlite"
+	"github.com/purpose168/GoAdmin/template/types/form"
+)
+
+func newSyncTable(t *testing.T, name string, statements ...string) *DefaultTable {
+	newBenchTable()
+
+	conn := db.GetSqliteDB()
+	conn.InitDB(config.DatabaseList{"default": {
+		Driver: db.DriverSqlite,
+		File:   filepath.Join(t.TempDir(), name+".db"),
+	}})
+	for _, statement := range statements {
+		_, err := conn.Exec(statement)
+		assert.Equal(t, err, nil)
+	}
+
+	tb := NewDefaultTable(nil, DefaultConfigWithDriver(db.DriverSqlite)).(*DefaultTable)
+	tb.dbObj = conn
+	tb.GetInfo().SetTable("posts").EnableDataSync("slug")
+	tb.GetForm().SetTable("posts").AddField("Category", "category_id", db.Int, form.SelectSingle).
+		FieldOptionsFromTable("categories", "name", "id")
+	return tb
+}
+
+func TestDefaultTable_Bundle(t *testing.T) {
+	source := newSyncTable(t, "source",
+		"create table categories (id integer primary key autoincrement, name text)",
+		"create table posts (id integer primary key autoincrement, slug text, title text, category_id integer)",
+		"insert into categories (name) values ('go'), ('db')",
+		"insert into posts (slug, title, category_id) values ('a', 'A', 1), ('b', 'B', 2), ('c', 'C', 2)")
+
+	bundle, err := source.ExportBundle([]string{"1", "2"})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(bundle.Rows), 2)
+	assert.Equal(t, len(bundle.Relations), 1)
+	assert.Equal(t, len(bundle.Relations[0].Rows), 2)
+
+	// the bundle is transferred as json.
+	data, _ := json.Marshal(bundle)
+	bundle = DataBundle{}
+	assert.Equal(t, json.Unmarshal(data, &bundle), nil)
+
+	target := newSyncTable(t, "target",
+		"create table categories (id integer primary key autoincrement, name text)",
+		"create table posts (id integer primary key autoincrement, slug text, title text, category_id integer)",
+		"insert into categories (name) values ('misc'), ('db')",
+		"insert into posts (slug, title, category_id) values ('x', 'X', 1), ('b', 'Old', 1)")
+
+	changes, err := target.ImportBundle(bundle, true)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(changes), 4)
+	assert.Equal(t, changes[0].Action, BundleInsert)
+	assert.Equal(t, changes[0].NewKey, "")
+	assert.Equal(t, changes[1].Action, BundleUnchanged)
+	assert.Equal(t, changes[1].NewKey, "2")
+
+	// nothing is written by the dry run.
+	count, _ := target.sql().Table("categories").Count()
+	assert.Equal(t, count, int64(2))
+
+	changes, err = target.ImportBundle(bundle, false)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, changes[0].NewKey, "3")
+
+	posts, _ := target.sql().Table("posts").OrderBy("id", "asc").All()
+	assert.Equal(t, len(posts), 3)
+	// b is updated and remapped to the existing category db.
+	assert.Equal(t, posts[1]["title"], "B")
+	assert.Equal(t, posts[1]["category_id"], int64(2))
+	// a is inserted and remapped to the inserted category go.
+	assert.Equal(t, posts[2]["slug"], "a")
+	assert.Equal(t, posts[2]["category_id"], int64(3))
+
+	changes, err = target.ImportBundle(bundle, true)
+	assert.Equal(t, err, nil)
+	for _, change := range changes {
+		assert.Equal(t, change.Action, BundleUnchanged)
+	}
+
+	bundle.Relations[0].Table = "goadmin_users"
+	_, err = target.ImportBundle(bundle, true)
+	assert.Equal(t, err != nil, true)
+}
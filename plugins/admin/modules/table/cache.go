@@ -0,0 +1,95 @@
+package table
+
+import (
+	"sync"
+
+	"github.com/purpose168/GoAdmin/context"
+)
+
+// generatorCache keeps the tables built by the cached generators, keyed by
+// the generator key and the language of the request.
+var generatorCache = struct {
+	lock   sync.RWMutex
+	tables map[string]map[string]*DefaultTable
+}{tables: make(map[string]map[string]*DefaultTable)}
+
+// CacheGenerator return a generator building the table of gen lazily on the
+// first request of each language, and then cloning the cached table for the
+// requests, so the field lists and the filters are not rebuilt every time.
+//
+// Only the generators whose tables depend on nothing of the request but the
+// language should be cached. The tables not of DefaultTable are not cached.
+// Call InvalidateGeneratorCache after the definition of the table changes.
+func CacheGenerator(key string, gen Generator) Generator {
+	return func(ctx *context.Context) Table {
+		lang := ""
+		if ctx != nil {
+			lang = ctx.Lang()
+		}
+
+		generatorCache.lock.RLock()
+		tb, ok := generatorCache.tables[key][lang]
+		generatorCache.lock.RUnlock()
+
+		if !ok {
+			t := gen(ctx)
+			if tb, ok = t.(*DefaultTable); !ok {
+				return t
+			}
+			generatorCache.lock.Lock()
+			if generatorCache.tables[key] == nil {
+				generatorCache.tables[key] = make(map[string]*DefaultTable)
+			}
+			generatorCache.tables[key][lang] = tb
+			generatorCache.lock.Unlock()
+		}
+
+		return tb.clone(ctx)
+	}
+}
+
+// Cached return the list of which the generators are cached by CacheGenerator.
+func (g GeneratorList) Cached() GeneratorList {
+	list := make(GeneratorList)
+	for _, key := range g.Keys() {
+		gen, _ := g.Get(key)
+		list[key] = CacheGenerator(key, gen)
+	}
+	return list
+}
+
+// InvalidateGeneratorCache remove the cached tables of the keys, all the
+// cached tables are removed when no key is given. The tables are built again
+// on the next request.
+func InvalidateGeneratorCache(keys ...string) {
+	generatorCache.lock.Lock()
+	defer generatorCache.lock.Unlock()
+	if len(keys) == 0 {
+		generatorCache.tables = make(map[string]map[string]*DefaultTable)
+		return
+	}
+	for _, key := range keys {
+		delete(generatorCache.tables, key)
+	}
+}
+
+// clone return a copy of the table for a request, the panels are copied
+// and the fields are shared.
+func (tb *DefaultTable) clone(ctx *context.Context) *DefaultTable {
+	c := *tb
+	base := *tb.BaseTable
+	if tb.Info != nil {
+		base.Info = tb.Info.Clone(ctx)
+	}
+	if tb.Detail != nil {
+		base.Detail = tb.Detail.Clone(ctx)
+	}
+	if tb.Form != nil {
+		base.Form = tb.Form.Clone()
+	}
+	if tb.NewForm != nil {
+		base.NewForm = tb.NewForm.Clone()
+	}
+	c.BaseTable = &base
+	return &c
+}
@@ -0,0 +1,35 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/db"
+)
+
+func TestCacheGenerator(t *testing.T) {
+	built := 0
+	gen := CacheGenerator("cache_test", func(ctx *context.Context) Table {
+		built++
+		tb := NewDefaultTable(ctx, DefaultConfigWithDriver(db.DriverMysql))
+		tb.GetInfo().AddField("ID", "id", db.Int).FieldFilterable()
+		tb.GetForm().AddField("Name", "name", db.Varchar, 0)
+		return tb
+	})
+
+	first := gen(nil)
+	first.GetInfo().FieldList[0].Head = "changed"
+	first.GetInfo().ActionButtonFold = true
+
+	second := gen(nil)
+	assert.Equal(t, built, 1)
+	assert.Equal(t, second.GetInfo().FieldList[0].Head, "ID")
+	assert.Equal(t, second.GetInfo().ActionButtonFold, false)
+	assert.Equal(t, len(second.GetInfo().FieldList[0].FilterFormFields), 1)
+	assert.Equal(t, len(second.GetForm().FieldList), 1)
+
+	InvalidateGeneratorCache("cache_test")
+	gen(nil)
+	assert.Equal(t, built, 2)
+}
@@ -19,6 +19,7 @@ type Config struct {
 	OnlyNewForm    bool
 	OnlyUpdateForm bool
 	OnlyDetail     bool
+	ReadOnly       bool
 }
 
 func DefaultConfig() Config {
@@ -97,6 +98,17 @@ func (config Config) SetOnlyDetail() Config {
 	return config
 }
 
+// SetReadOnly set the table read only, as a database view or a materialized
+// view. The rows can not be added, edited nor deleted, and the primary key is
+// not queried when it is not a column of the view.
+func (config Config) SetReadOnly() Config {
+	config.ReadOnly = true
+	config.CanAdd = false
+	config.Editable = false
+	config.Deletable = false
+	return config
+}
+
 func (config Config) SetExportable(exportable bool) Config {
 	config.Exportable = exportable
 	return config
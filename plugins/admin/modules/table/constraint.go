@@ -0,0 +1,65 @@
+package table
+
+import (
+	"fmt"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// FieldError is the error of the value of a form field, such as a value
+// violating a unique index of the table, shown to the user instead of the
+// error of the driver.
+type FieldError struct {
+	Field string
+	Msg   string
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return e.Msg
+}
+
+// Unwrap return the error of the driver.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// constraintError maps the violation of a unique index or a foreign key to
+// the form field of the violating column, the error is returned unchanged
+// when it is not a violation.
+func (tb *DefaultTable) constraintError(f *types.FormPanel, dataList form.Values, err error) error {
+	ce, ok := db.ParseConstraintError(tb.connectionDriver, err)
+	if !ok {
+		return err
+	}
+	logger.Error("constraint violation: ", err)
+
+	msg := language.Get("the record already exists")
+	if ce.Kind == db.ConstraintForeignKey {
+		msg = language.Get("the related record does not exist")
+	}
+
+	columns := make([]string, 0, len(f.FieldList))
+	for _, field := range f.FieldList {
+		if _, ok := dataList[field.Field]; ok {
+			columns = append(columns, field.Field)
+		}
+	}
+	col, ok := ce.MatchColumn(columns)
+	if !ok {
+		return &FieldError{Msg: msg, Err: err}
+	}
+
+	field := f.FieldList.FindByFieldName(col)
+	if ce.Kind == db.ConstraintUnique {
+		msg = fmt.Sprintf(language.Get("%s already exists"), field.Head)
+	} else {
+		msg = fmt.Sprintf(language.Get("%s does not exist"), field.Head)
+	}
+	return &FieldError{Field: col, Msg: msg, Err: err}
+}
@@ -1,6 +1,8 @@
 package table
 
 import (
+	stdctx "context"
+	dbsql "database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,6 +18,7 @@ import (
 
 	"github.com/purpose168/GoAdmin/modules/db"
 	"github.com/purpose168/GoAdmin/modules/db/dialect"
+	"github.com/purpose168/GoAdmin/modules/event"
 	errs "github.com/purpose168/GoAdmin/modules/errors"
 	"github.com/purpose168/GoAdmin/modules/language"
 	"github.com/purpose168/GoAdmin/modules/logger"
@@ -51,7 +54,7 @@ func NewDefaultTable(ctx *context.Context, cfgs ...Config) Table {
 		cfg = DefaultConfig()
 	}
 
-	return &DefaultTable{
+	tb := &DefaultTable{
 		BaseTable: &BaseTable{
 			Info:           types.NewInfoPanel(ctx, cfg.PrimaryKey.Name),
 			Form:           types.NewFormPanel(),
@@ -66,6 +69,7 @@ func NewDefaultTable(ctx *context.Context, cfgs ...Config) Table {
 			OnlyUpdateForm: cfg.OnlyUpdateForm,
 			OnlyDetail:     cfg.OnlyDetail,
 			OnlyInfo:       cfg.OnlyInfo,
+			ReadOnly:       cfg.ReadOnly,
 		},
 		connectionDriver:     cfg.Driver,
 		connectionDriverMode: cfg.DriverMode,
@@ -73,6 +77,10 @@ func NewDefaultTable(ctx *context.Context, cfgs ...Config) Table {
 		sourceURL:            cfg.SourceURL,
 		getDataFun:           cfg.GetDataFun,
 	}
+	if cfg.ReadOnly {
+		tb.hideWriteButtons()
+	}
+	return tb
 }
 
 // Copy copy a new table.Table from origin DefaultTable
@@ -97,6 +105,7 @@ func (tb *DefaultTable) Copy() Table {
 			Editable:   tb.Editable,
 			Deletable:  tb.Deletable,
 			Exportable: tb.Exportable,
+			ReadOnly:   tb.ReadOnly,
 			PrimaryKey: tb.PrimaryKey,
 		},
 		connectionDriver:     tb.connectionDriver,
@@ -144,15 +153,14 @@ func (tb *DefaultTable) GetData(ctx *context.Context, params parameter.Parameter
 	} else if tb.Info.GetDataFn != nil {
 		data, size = tb.Info.GetDataFn(params)
 	} else if params.IsAll() {
-		return tb.getAllDataFromDatabase(params)
+		return tb.getAllDataFromDatabase(ctx, params)
 	} else {
 		return tb.getDataFromDatabase(ctx, params)
 	}
 
-	infoList := make(types.InfoList, 0)
-
-	for i := 0; i < len(data); i++ {
-		infoList = append(infoList, tb.getTempModelData(data[i], params, []string{}))
+	infoList, err := tb.displayRows(ctx, data, params, []string{})
+	if err != nil {
+		return PanelInfo{}, err
 	}
 
 	thead, _, _, _, _, filterForm := tb.getTheadAndFilterForm(params, []string{})
@@ -239,10 +247,9 @@ func (tb *DefaultTable) GetDataWithIds(ctx *context.Context, params parameter.Pa
 		return tb.getDataFromDatabase(ctx, params)
 	}
 
-	infoList := make([]map[string]types.InfoItem, 0)
-
-	for i := 0; i < len(data); i++ {
-		infoList = append(infoList, tb.getTempModelData(data[i], params, []string{}))
+	infoList, err := tb.displayRows(ctx, data, params, []string{})
+	if err != nil {
+		return PanelInfo{}, err
 	}
 
 	thead, _, _, _, _, filterForm := tb.getTheadAndFilterForm(params, []string{})
@@ -265,7 +272,10 @@ func (tb *DefaultTable) GetDataWithIds(ctx *context.Context, params parameter.Pa
 	}, nil
 }
 
-func (tb *DefaultTable) getTempModelData(res map[string]interface{}, params parameter.Parameters, columns Columns) map[string]types.InfoItem {
+// getTempModelData return the items of the row, it returns nil when dctx is
+// done before all the fields are displayed.
+func (tb *DefaultTable) getTempModelData(dctx stdctx.Context, res map[string]interface{}, params parameter.Parameters,
+	columns Columns) map[string]types.InfoItem {
 
 	var tempModelData = map[string]types.InfoItem{
 		"__goadmin_edit_params":   {},
@@ -281,6 +291,10 @@ func (tb *DefaultTable) getTempModelData(res map[string]interface{}, params para
 
 	for _, field := range tb.Info.FieldList {
 
+		if dctx.Err() != nil {
+			return nil
+		}
+
 		headField = field.Field
 
 		if field.Joins.Valid() {
@@ -306,15 +320,17 @@ func (tb *DefaultTable) getTempModelData(res map[string]interface{}, params para
 		var value interface{}
 		if len(columns) == 0 || modules.InArray(columns, headField) || field.Joins.Valid() {
 			value = field.ToDisplay(types.FieldModel{
-				ID:    primaryKeyValue.String(),
-				Value: combineValue,
-				Row:   res,
+				ID:      primaryKeyValue.String(),
+				Value:   combineValue,
+				Row:     res,
+				Context: dctx,
 			})
 		} else {
 			value = field.ToDisplay(types.FieldModel{
-				ID:    primaryKeyValue.String(),
-				Value: "",
-				Row:   res,
+				ID:      primaryKeyValue.String(),
+				Value:   "",
+				Row:     res,
+				Context: dctx,
 			})
 		}
 		var valueStr string
@@ -355,9 +371,10 @@ func (tb *DefaultTable) getTempModelData(res map[string]interface{}, params para
 
 	primaryKeyField := tb.Info.FieldList.GetFieldByFieldName(tb.PrimaryKey.Name)
 	value := primaryKeyField.ToDisplay(types.FieldModel{
-		ID:    primaryKeyValue.String(),
-		Value: primaryKeyValue.String(),
-		Row:   res,
+		ID:      primaryKeyValue.String(),
+		Value:   primaryKeyValue.String(),
+		Row:     res,
+		Context: dctx,
 	})
 	if valueStr, ok := value.(string); ok {
 		tempModelData[tb.PrimaryKey.Name] = types.InfoItem{
@@ -374,13 +391,17 @@ func (tb *DefaultTable) getTempModelData(res map[string]interface{}, params para
 	return tempModelData
 }
 
-func (tb *DefaultTable) getAllDataFromDatabase(params parameter.Parameters) (PanelInfo, error) {
+func (tb *DefaultTable) getAllDataFromDatabase(ctx *context.Context, params parameter.Parameters) (PanelInfo, error) {
+	if err := tb.connectionError(); err != nil {
+		return PanelInfo{}, err
+	}
+
 	var (
 		connection     = tb.db()
 		queryStatement = "select %s from %s %s %s %s order by " + modules.Delimiter(connection.GetDelimiter(), connection.GetDelimiter2(), "%s") + " %s"
 	)
 
-	columns, _ := tb.getColumns(tb.Info.Table)
+	columns := tb.infoColumns()
 
 	thead, fields, joins := tb.Info.FieldList.GetThead(types.TableInfo{
 		Table:      tb.Info.Table,
@@ -390,11 +411,20 @@ func (tb *DefaultTable) getAllDataFromDatabase(params parameter.Parameters) (Pan
 		PrimaryKey: tb.PrimaryKey.Name,
 	}, params, columns)
 
-	fields += tb.Info.Table + "." + modules.FilterField(tb.PrimaryKey.Name, connection.GetDelimiter(), connection.GetDelimiter2())
+	keyless := tb.keyless(columns)
+	if keyless {
+		fields = strings.TrimSuffix(fields, ",")
+	} else {
+		fields += tb.Info.Table + "." + modules.FilterField(tb.PrimaryKey.Name, connection.GetDelimiter(), connection.GetDelimiter2())
+	}
 
 	groupBy := ""
 	if joins != "" {
-		groupBy = " GROUP BY " + tb.Info.Table + "." + modules.Delimiter(connection.GetDelimiter(), connection.GetDelimiter2(), tb.PrimaryKey.Name)
+		if keyless {
+			groupBy = " GROUP BY " + fields
+		} else {
+			groupBy = " GROUP BY " + tb.Info.Table + "." + modules.Delimiter(connection.GetDelimiter(), connection.GetDelimiter2(), tb.PrimaryKey.Name)
+		}
 	}
 
 	var (
@@ -403,8 +433,11 @@ func (tb *DefaultTable) getAllDataFromDatabase(params parameter.Parameters) (Pan
 		existKeys = make([]string, 0)
 	)
 
-	wheres, whereArgs, existKeys = params.Statement(wheres, tb.Info.Table, connection.GetDelimiter(), connection.GetDelimiter2(), whereArgs, columns, existKeys,
-		tb.Info.FieldList.GetFieldFilterProcessValue)
+	wheres, whereArgs, existKeys = params.Statement(wheres, tb.Info.Table, connection.GetDelimiter(), connection.GetDelimiter2(), whereArgs, tb.Info.FieldList.FilterColumns(columns), existKeys,
+		tb.filterProcess(ctx))
+	wheres, whereArgs = tb.listStatement(params, wheres, whereArgs,
+		modules.Delimiter(connection.GetDelimiter(), connection.GetDelimiter2(), tb.Info.Table),
+		connection.GetDelimiter(), connection.GetDelimiter2())
 	wheres, whereArgs = tb.Info.Wheres.Statement(wheres, connection.GetDelimiter(), connection.GetDelimiter2(), whereArgs, existKeys, columns)
 	wheres, whereArgs = tb.Info.WhereRaws.Statement(wheres, whereArgs)
 
@@ -413,23 +446,26 @@ func (tb *DefaultTable) getAllDataFromDatabase(params parameter.Parameters) (Pan
 	}
 
 	if !modules.InArray(columns, params.SortField) {
-		params.SortField = tb.PrimaryKey.Name
+		params.SortField = tb.sortField(columns)
 	}
 
-	queryCmd := fmt.Sprintf(queryStatement, fields, tb.Info.Table, joins, wheres, groupBy, params.SortField, params.SortType)
+	queryCmd := fmt.Sprintf(queryStatement, fields, tb.source(connection.GetDelimiter(), connection.GetDelimiter2()),
+		joins, wheres, groupBy, params.SortField, params.SortType)
+	whereArgs = tb.sourceArgs(whereArgs)
 
-	logger.LogSQL(queryCmd, []interface{}{})
+	queryBeginTime := time.Now()
 
 	res, err := connection.QueryWithConnection(tb.connection, queryCmd, whereArgs...)
 
+	logger.LogSQLCtx(ctx, queryCmd, whereArgs, time.Since(queryBeginTime))
+
 	if err != nil {
 		return PanelInfo{}, err
 	}
 
-	infoList := make([]map[string]types.InfoItem, 0)
-
-	for i := 0; i < len(res); i++ {
-		infoList = append(infoList, tb.getTempModelData(res[i], params, columns))
+	infoList, err := tb.displayRows(ctx, res, params, columns)
+	if err != nil {
+		return PanelInfo{}, err
 	}
 
 	return PanelInfo{
@@ -442,6 +478,9 @@ func (tb *DefaultTable) getAllDataFromDatabase(params parameter.Parameters) (Pan
 
 // TODO: refactor
 func (tb *DefaultTable) getDataFromDatabase(ctx *context.Context, params parameter.Parameters) (PanelInfo, error) {
+	if err := tb.connectionError(); err != nil {
+		return PanelInfo{}, err
+	}
 
 	var (
 		connection     = tb.db()
@@ -463,29 +502,36 @@ func (tb *DefaultTable) getDataFromDatabase(ctx *context.Context, params paramet
 			countExtra = "as [size]"
 		}
 		// %s means: fields, table, join table, pk values, group by, order by field,  order by type
-		queryStatement = "select %s from " + placeholder + " %s where " + pk + " in (%s) %s ORDER BY %s." + placeholder + " %s"
+		queryStatement = "select %s from %s %s where " + pk + " in (%s) %s ORDER BY %s." + placeholder + " %s"
 		// %s means: table, join table, pk values
-		countStatement = "select count(*) " + countExtra + " from " + placeholder + " %s where " + pk + " in (%s)"
+		countStatement = "select count(*) " + countExtra + " from %s %s where " + pk + " in (%s)"
 	} else {
 		if connection.Name() == db.DriverMssql {
 			// %s means: order by field, order by type, fields, table, join table, wheres, group by
 			queryStatement = "SELECT * FROM (SELECT ROW_NUMBER() OVER (ORDER BY %s." + placeholder + " %s) as ROWNUMBER_, %s from " +
-				placeholder + "%s %s %s ) as TMP_ WHERE TMP_.ROWNUMBER_ > ? AND TMP_.ROWNUMBER_ <= ?"
+				"%s%s %s %s ) as TMP_ WHERE TMP_.ROWNUMBER_ > ? AND TMP_.ROWNUMBER_ <= ?"
 			// %s means: table, join table, wheres
-			countStatement = "select count(*) as [size] from (select 1 as [size] from " + placeholder + " %s %s %s) src"
+			countStatement = "select count(*) as [size] from (select 1 as [size] from %s %s %s %s) src"
 		} else {
 			// %s means: fields, table, join table, wheres, group by, order by field, order by type
-			queryStatement = "select %s from " + placeholder + "%s %s %s order by " + placeholder + "." + placeholder + " %s LIMIT ? OFFSET ?"
+			queryStatement = "select %s from %s%s %s %s order by " + placeholder + "." + placeholder + " %s LIMIT ? OFFSET ?"
 			// %s means: table, join table, wheres
-			countStatement = "select count(*) from (select " + pk + " from " + placeholder + " %s %s %s) src"
+			countStatement = "select count(*) from (select " + pk + " from %s %s %s %s) src"
 		}
 	}
 
-	columns, _ := tb.getColumns(tb.Info.Table)
+	columns := tb.infoColumns()
 
 	thead, fields, joinFields, joins, joinTables, filterForm := tb.getTheadAndFilterForm(params, columns)
 
-	fields += pk
+	keyless := tb.keyless(columns)
+	if keyless {
+		fields = strings.TrimSuffix(fields, ",")
+		countStatement = strings.Replace(countStatement, "select "+pk+" from", "select 1 from", 1)
+		tb.Info.IsHideDetailButton = true
+	} else {
+		fields += pk
+	}
 
 	allFields := fields
 	groupFields := fields
@@ -505,7 +551,7 @@ func (tb *DefaultTable) getDataFromDatabase(ctx *context.Context, params paramet
 	}
 
 	if !modules.InArray(columns, params.SortField) {
-		params.SortField = tb.PrimaryKey.Name
+		params.SortField = tb.sortField(columns)
 	}
 
 	var (
@@ -526,8 +572,14 @@ func (tb *DefaultTable) getDataFromDatabase(ctx *context.Context, params paramet
 	} else {
 
 		// parameter
-		wheres, whereArgs, existKeys = params.Statement(wheres, tb.Info.Table, connection.GetDelimiter(), connection.GetDelimiter2(), whereArgs, columns, existKeys,
-			tb.Info.FieldList.GetFieldFilterProcessValue)
+		wheres, whereArgs, existKeys = params.Statement(wheres, tb.Info.Table, connection.GetDelimiter(), connection.GetDelimiter2(), whereArgs, tb.Info.FieldList.FilterColumns(columns), existKeys,
+			tb.filterProcess(ctx))
+		wheres, whereArgs = tb.fuzzyStatement(params, wheres, whereArgs, table, pk, delimiter, delimiter2)
+		wheres, whereArgs = tb.tagStatement(params, wheres, whereArgs, table, delimiter, delimiter2)
+		wheres, whereArgs = tb.treeStatement(params, wheres, whereArgs, table, delimiter, delimiter2)
+		wheres, whereArgs = tb.rangeStatement(params, wheres, whereArgs, table, delimiter, delimiter2)
+		wheres, whereArgs = tb.listStatement(params, wheres, whereArgs, table, delimiter, delimiter2)
+		wheres, whereArgs = tb.pinnedStatement(params, wheres, whereArgs, pk)
 		// pre query
 		wheres, whereArgs = tb.Info.Wheres.Statement(wheres, connection.GetDelimiter(), connection.GetDelimiter2(), whereArgs, existKeys, columns)
 		wheres, whereArgs = tb.Info.WhereRaws.Statement(wheres, whereArgs)
@@ -545,50 +597,62 @@ func (tb *DefaultTable) getDataFromDatabase(ctx *context.Context, params paramet
 
 	groupBy := ""
 	if len(joinTables) > 0 {
-		if connection.Name() == db.DriverMssql || connection.Name() == db.DriverPostgresql {
+		if connection.Name() == db.DriverMssql || connection.Name() == db.DriverPostgresql || keyless {
 			groupBy = " GROUP BY " + groupFields
 		} else {
 			groupBy = " GROUP BY " + pk
 		}
 	}
 
-	queryCmd := ""
+	var (
+		queryCmd = ""
+		source   = tb.source(delimiter, delimiter2)
+	)
+	args = tb.sourceArgs(args)
+	whereArgs = tb.sourceArgs(whereArgs)
 	if connection.Name() == db.DriverMssql && len(ids) == 0 {
 		queryCmd = fmt.Sprintf(queryStatement, tb.Info.Table, params.SortField, params.SortType,
-			allFields, tb.Info.Table, joins, wheres, groupBy)
+			allFields, source, joins, wheres, groupBy)
 	} else {
-		queryCmd = fmt.Sprintf(queryStatement, allFields, tb.Info.Table, joins, wheres, groupBy,
+		queryCmd = fmt.Sprintf(queryStatement, allFields, source, joins, wheres, groupBy,
 			tb.Info.Table, params.SortField, params.SortType)
 	}
 
-	logger.LogSQL(queryCmd, args)
+	queryBeginTime := time.Now()
 
 	res, err := connection.QueryWithConnection(tb.connection, queryCmd, args...)
 
+	queryDuration := time.Since(queryBeginTime)
+	logger.LogSQLCtx(ctx, queryCmd, args, queryDuration)
+
 	if err != nil {
 		return PanelInfo{}, err
 	}
 
-	infoList := make([]map[string]types.InfoItem, 0)
-
-	for i := 0; i < len(res); i++ {
-		infoList = append(infoList, tb.getTempModelData(res[i], params, columns))
+	infoList, err := tb.displayRows(ctx, res, params, columns)
+	if err != nil {
+		return PanelInfo{}, err
 	}
 
 	// TODO: use the dialect
 	var size int
 
-	if len(ids) == 0 {
-		countCmd := fmt.Sprintf(countStatement, tb.Info.Table, joins, wheres, groupBy)
+	if len(ids) == 0 && params.IsNoCount(tb.Info.IsHideTotal) {
+		// the rows up to the current page, see paginator.Config.
+		size = (params.PageInt-1)*params.PageSizeInt + len(res)
+	} else if len(ids) == 0 {
+		countCmd := fmt.Sprintf(countStatement, source, joins, wheres, groupBy)
+
+		countBeginTime := time.Now()
 
 		total, err := connection.QueryWithConnection(tb.connection, countCmd, whereArgs...)
 
+		logger.LogSQLCtx(ctx, countCmd, whereArgs, time.Since(countBeginTime))
+
 		if err != nil {
 			return PanelInfo{}, err
 		}
 
-		logger.LogSQL(countCmd, nil)
-
 		if tb.connectionDriver == "postgresql" {
 			if tb.connectionDriverMode == "h2" {
 				size = int(total[0]["count(*)"].(int64))
@@ -615,6 +679,14 @@ func (tb *DefaultTable) getDataFromDatabase(ctx *context.Context, params paramet
 		Title:          tb.Info.Title,
 		FilterFormData: filterForm,
 		Description:    tb.Info.Description,
+		Query: QueryInfo{
+			Statement: queryCmd,
+			Args:      args,
+			Duration:  queryDuration,
+			Elapsed:   endTime.Sub(beginTime),
+			Rows:      len(res),
+			Total:     size,
+		},
 	}, nil
 }
 
@@ -644,6 +716,10 @@ func (tb *DefaultTable) GetDataWithId(param parameter.Parameters) (FormInfo, err
 		res = getDataRes(tb.Info.GetDataFn(param))
 	} else {
 
+		if err := tb.connectionError(); err != nil {
+			return FormInfo{Title: tb.Form.Title, Description: tb.Form.Description}, err
+		}
+
 		columns, _ = tb.getColumns(tb.Form.Table)
 
 		var (
@@ -756,16 +832,41 @@ func (tb *DefaultTable) GetDataWithId(param parameter.Parameters) (FormInfo, err
 
 // UpdateData update data.
 func (tb *DefaultTable) UpdateData(ctx *context.Context, dataList form.Values) error {
+	finish, err := tb.updateData(ctx, dataList, nil)
+	finish(nil)
+	return err
+}
+
+// updateData validate the values and update the row, in the transaction when
+// tx is not nil. The returned finish runs the post hooks of the form and
+// publishes the event of the change, it is called with the error of the
+// transaction after the transaction is committed or rolled back.
+func (tb *DefaultTable) updateData(ctx *context.Context, dataList form.Values, tx *dbsql.Tx) (func(error), error) {
 
 	dataList.Add(form.PostTypeKey, "0")
 
 	var (
-		errMsg = ""
-		err    error
+		err     error
+		changed *event.Event
+		hc      = types.NewFormHookContext(ctx, dataList, types.PostTypeUpdate)
 	)
 
-	if tb.Form.PostHook != nil {
-		defer func() {
+	finish := func(txErr error) {
+		hookErr := err
+		if hookErr == nil {
+			hookErr = txErr
+		}
+		if hookErr == nil && changed != nil {
+			event.Publish(*changed)
+		}
+		if tb.Form.PostSaveFn != nil {
+			tb.Form.RunPostSave(hc, hookErr)
+		}
+		if tb.Form.PostHook != nil {
+			errMsg := ""
+			if hookErr != nil {
+				errMsg = "post error: " + hookErr.Error()
+			}
 			dataList.Add(form.PostTypeKey, "0")
 			dataList.Add(form.PostResultKey, errMsg)
 			go func() {
@@ -780,46 +881,66 @@ func (tb *DefaultTable) UpdateData(ctx *context.Context, dataList form.Values) e
 					logger.ErrorCtx(ctx, "UpdateData PostHook error %+v", err)
 				}
 			}()
-		}()
+		}
 	}
 
-	if tb.Form.Validator != nil {
-		if err := tb.Form.Validator(dataList); err != nil {
-			errMsg = "post error: " + err.Error()
-			return err
-		}
+	if err = tb.Form.RunPreValidate(hc); err != nil {
+		return finish, err
+	}
+	dataList = hc.Values
+
+	if err = tb.Form.NormalizeInput(dataList); err != nil {
+		return finish, err
 	}
 
-	if tb.Form.PreProcessFn != nil {
-		dataList = tb.Form.PreProcessFn(dataList)
+	if err = tb.Form.Validate(dataList); err != nil {
+		return finish, err
+	}
+
+	dataList = tb.Form.PreProcess(dataList)
+	dataList = tb.uniqueSlugs(tb.Form, dataList, dataList.Get(tb.PrimaryKey.Name))
+
+	hc.Values = dataList
+	if err = tb.Form.RunPreSave(hc); err != nil {
+		return finish, err
 	}
+	dataList = hc.Values
 
 	if tb.Form.UpdateFn != nil {
 		dataList.Delete(form.PostTypeKey)
 		err = tb.Form.UpdateFn(tb.PreProcessValue(dataList, types.PostTypeUpdate))
-		if err != nil {
-			errMsg = "post error: " + err.Error()
-		}
-		return err
+		return finish, err
 	}
 
 	if len(dataList) == 0 {
-		return nil
+		return finish, nil
 	}
 
-	_, err = tb.sql().Table(tb.Form.Table).
-		Where(tb.PrimaryKey.Name, "=", dataList.Get(tb.PrimaryKey.Name)).
-		Update(tb.getInjectValueFromFormValue(dataList, types.PostTypeUpdate))
+	var (
+		id     = dataList.Get(tb.PrimaryKey.Name)
+		values = tb.getInjectValueFromFormValue(dataList, types.PostTypeUpdate)
+		exec   = func(sql *db.SQL, _ *event.Event) error {
+			_, updateErr := sql.Table(tb.Form.Table).
+				Where(tb.PrimaryKey.Name, "=", id).
+				Update(values)
+			return updateErr
+		}
+	)
 
-	// NOTE: some errors should be ignored.
-	if db.CheckError(err, db.UPDATE) {
-		if err != nil {
-			errMsg = "post error: " + err.Error()
+	if tx == nil {
+		err = tb.change(tb.Form.Table, event.Update, []string{id}, values, db.UPDATE, exec)
+	} else {
+		e := tb.changeEvent(tb.Form.Table, event.Update, []string{id}, values)
+		if err = tb.changeTx(tx, &e, db.UPDATE, exec); err == nil {
+			changed = &e
 		}
-		return err
 	}
 
-	return nil
+	if err != nil {
+		return finish, tb.constraintError(tb.Form, dataList, err)
+	}
+
+	return finish, nil
 }
 
 // InsertData insert data.
@@ -828,7 +949,7 @@ func (tb *DefaultTable) InsertData(ctx *context.Context, dataList form.Values) e
 	dataList.Add(form.PostTypeKey, "1")
 
 	var (
-		id     = int64(0)
+		id     = ""
 		err    error
 		errMsg = ""
 		f      = tb.GetActualNewForm()
@@ -837,7 +958,7 @@ func (tb *DefaultTable) InsertData(ctx *context.Context, dataList form.Values) e
 	if f.PostHook != nil {
 		defer func() {
 			dataList.Add(form.PostTypeKey, "1")
-			dataList.Add(tb.GetPrimaryKey().Name, strconv.Itoa(int(id)))
+			dataList.Add(tb.GetPrimaryKey().Name, id)
 			dataList.Add(form.PostResultKey, errMsg)
 
 			go func() {
@@ -855,16 +976,40 @@ func (tb *DefaultTable) InsertData(ctx *context.Context, dataList form.Values) e
 		}()
 	}
 
-	if f.Validator != nil {
-		if err := f.Validator(dataList); err != nil {
-			errMsg = "post error: " + err.Error()
-			return err
-		}
+	hc := types.NewFormHookContext(ctx, dataList, types.PostTypeCreate)
+	if f.PostSaveFn != nil {
+		defer func() {
+			f.RunPostSave(hc, err)
+		}()
+	}
+
+	if err = f.RunPreValidate(hc); err != nil {
+		errMsg = "post error: " + err.Error()
+		return err
+	}
+	dataList = hc.Values
+
+	if err = f.NormalizeInput(dataList); err != nil {
+		errMsg = "post error: " + err.Error()
+		return err
+	}
+
+	f.GenerateDefaults(dataList)
+
+	if err = f.Validate(dataList); err != nil {
+		errMsg = "post error: " + err.Error()
+		return err
 	}
 
-	if f.PreProcessFn != nil {
-		dataList = f.PreProcessFn(dataList)
+	dataList = f.PreProcess(dataList)
+	dataList = tb.uniqueSlugs(f, dataList, "")
+
+	hc.Values = dataList
+	if err = f.RunPreSave(hc); err != nil {
+		errMsg = "post error: " + err.Error()
+		return err
 	}
+	dataList = hc.Values
 
 	if f.InsertFn != nil {
 		dataList.Delete(form.PostTypeKey)
@@ -879,17 +1024,73 @@ func (tb *DefaultTable) InsertData(ctx *context.Context, dataList form.Values) e
 		return nil
 	}
 
-	id, err = tb.sql().Table(f.Table).Insert(tb.getInjectValueFromFormValue(dataList, types.PostTypeCreate))
+	values := tb.getInjectValueFromFormValue(dataList, types.PostTypeCreate)
 
-	// NOTE: some errors should be ignored.
-	if db.CheckError(err, db.INSERT) {
+	err = tb.change(f.Table, event.Insert, nil, values, db.INSERT, func(sql *db.SQL, e *event.Event) error {
+		lastID, insertErr := sql.Table(f.Table).Insert(values)
+		// The id of a string primary key, as an uuid, is the inserted value
+		// instead of the last insert id, and is empty when generated by the
+		// database.
+		if tb.stringPrimaryKey() {
+			id, _ = values[tb.PrimaryKey.Name].(string)
+		} else {
+			id = strconv.FormatInt(lastID, 10)
+		}
+		e.IDs = []string{id}
+		return insertErr
+	})
+
+	if err != nil {
 		errMsg = "post error: " + err.Error()
-		return err
+		return tb.constraintError(f, dataList, err)
 	}
 
 	return nil
 }
 
+// uniqueSlugs normalizes the values of the slug fields, generating them from
+// the source fields when empty, and appends a suffix such as "-2" when the
+// slug is used by another row.
+func (tb *DefaultTable) uniqueSlugs(f *types.FormPanel, dataList form.Values, id string) form.Values {
+	for _, field := range f.FieldList {
+		if field.SlugFrom == "" {
+			continue
+		}
+		if _, ok := dataList[field.Field]; !ok {
+			continue
+		}
+
+		slug := types.Slugify(dataList.Get(field.Field))
+		if slug == "" {
+			slug = types.Slugify(dataList.Get(field.SlugFrom))
+		}
+		if slug == "" || tb.connectionDriver == "" || f.Table == "" {
+			dataList.Add(field.Field, slug)
+			continue
+		}
+
+		query := tb.sql().Table(f.Table).Select(field.Field).Where(field.Field, "like", slug+"%")
+		if id != "" {
+			query = query.Where(tb.PrimaryKey.Name, "!=", id)
+		}
+		rows, err := query.All()
+		if err != nil {
+			logger.Error("query slugs error: ", err)
+		}
+
+		used := make(map[string]bool, len(rows))
+		for _, row := range rows {
+			used[fmt.Sprintf("%v", row[field.Field])] = true
+		}
+		unique := slug
+		for i := 2; used[unique]; i++ {
+			unique = slug + "-" + strconv.Itoa(i)
+		}
+		dataList.Add(field.Field, unique)
+	}
+	return dataList
+}
+
 func (tb *DefaultTable) getInjectValueFromFormValue(dataList form.Values, typ types.PostType) dialect.H {
 
 	var (
@@ -901,7 +1102,8 @@ func (tb *DefaultTable) getInjectValueFromFormValue(dataList form.Values, typ ty
 	)
 
 	// If a key is a auto increment primary key, it can`t be insert or update.
-	if auto {
+	// A string primary key, as an uuid or an ulid, is never auto increment.
+	if auto && !tb.stringPrimaryKey() {
 		exceptString = []string{tb.PrimaryKey.Name, form.PreviousKey, form.MethodKey, form.TokenKey,
 			constant.IframeKey, constant.IframeIDKey}
 	} else {
@@ -961,9 +1163,21 @@ func (tb *DefaultTable) getInjectValueFromFormValue(dataList form.Values, typ ty
 			}
 		}
 	}
+
+	// An empty string primary key is left to the default of the database, as
+	// gen_random_uuid() of postgresql.
+	if typ == types.PostTypeCreate && tb.stringPrimaryKey() && value[tb.PrimaryKey.Name] == "" {
+		delete(value, tb.PrimaryKey.Name)
+	}
 	return value
 }
 
+// stringPrimaryKey reports whether the primary key is a string, as an uuid or
+// an ulid, which is generated by the form or the database.
+func (tb *DefaultTable) stringPrimaryKey() bool {
+	return db.Contains(tb.PrimaryKey.Type, db.StringTypeList)
+}
+
 func (tb *DefaultTable) PreProcessValue(dataList form.Values, typ types.PostType) form.Values {
 
 	exceptString := []string{form.PreviousKey, form.MethodKey, form.TokenKey,
@@ -1076,9 +1290,295 @@ func (tb *DefaultTable) delete(table, key string, values []string) error {
 		vals[i] = v
 	}
 
-	return tb.sql().Table(table).
-		WhereIn(key, vals).
-		Delete()
+	return tb.change(table, event.Delete, values, nil, db.DELETE, func(sql *db.SQL, _ *event.Event) error {
+		return sql.Table(table).
+			WhereIn(key, vals).
+			Delete()
+	})
+}
+
+// change exec the statement and publish the event of the change, the statement
+// and the transaction handlers of the event share one transaction when there
+// are any. Errors ignored by db.CheckError are not treated as failures.
+func (tb *DefaultTable) change(table string, action event.Action, ids []string, values dialect.H, typ int,
+	exec func(sql *db.SQL, e *event.Event) error) error {
+
+	e := tb.changeEvent(table, action, ids, values)
+
+	if !event.HasTxSubscriber() {
+		if err := exec(tb.sql(), &e); db.CheckError(err, typ) {
+			return err
+		}
+		event.Publish(e)
+		return nil
+	}
+
+	_, err := tb.sql().WithTransaction(func(tx *dbsql.Tx) (error, map[string]interface{}) {
+		return tb.changeTx(tx, &e, typ, exec), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	event.Publish(e)
+	return nil
+}
+
+// changeEvent return the event of the change of the table.
+func (tb *DefaultTable) changeEvent(table string, action event.Action, ids []string, values dialect.H) event.Event {
+	return event.Event{
+		Connection: tb.connection,
+		Table:      table,
+		Action:     action,
+		IDs:        ids,
+		Values:     values,
+		Time:       time.Now(),
+	}
+}
+
+// changeTx exec the statement of the change in the transaction and publish the
+// event to the transaction handlers. The event is published to the others by
+// the caller after the transaction is committed.
+func (tb *DefaultTable) changeTx(tx *dbsql.Tx, e *event.Event, typ int, exec func(sql *db.SQL, e *event.Event) error) error {
+	if err := exec(tb.sql().WithTx(tx), e); db.CheckError(err, typ) {
+		return err
+	}
+	if !event.HasTxSubscriber() {
+		return nil
+	}
+	return event.PublishTx(tx, *e)
+}
+
+// filterProcess return the process function of the filter values, the date
+// and the datetime values are normalized into the format of the database in
+// the timezone of the database before the process functions of the fields.
+func (tb *DefaultTable) filterProcess(ctx *context.Context) func(string, string, string) string {
+	loc := types.UserLocation(ctx)
+	return func(key, value, keyIndex string) string {
+		value = tb.Info.FieldList.NormalizeFilterTime(key, value, keyIndex, loc)
+		return tb.Info.FieldList.GetFieldFilterProcessValue(key, value, keyIndex)
+	}
+}
+
+// fuzzyStatement add the conditions of the fuzzy filters. The primary keys
+// found by the fuzzy search function are used, or the like operator when there
+// is no such function.
+func (tb *DefaultTable) fuzzyStatement(params parameter.Parameters, wheres string, whereArgs []interface{},
+	table, pk, delimiter, delimiter2 string) (string, []interface{}) {
+
+	searchFn := getFuzzySearchFn()
+
+	for _, field := range tb.Info.FieldList {
+		for index, filter := range field.FilterFormFields {
+			if filter.Operator != types.FilterOperatorFuzzy {
+				continue
+			}
+			keySuffix := ""
+			if index > 0 {
+				keySuffix = parameter.FilterParamCountInfix + strconv.Itoa(index)
+			}
+			keyword := params.GetFieldValue(field.Field + keySuffix)
+			if keyword == "" {
+				continue
+			}
+			if wheres != "" {
+				wheres += " and "
+			}
+
+			if searchFn != nil {
+				ids, err := searchFn(tb.connection, tb.Info.Table, field.Field, keyword)
+				if err == nil {
+					if len(ids) == 0 {
+						wheres += "1 = 0"
+						continue
+					}
+					wheres += pk + " in (" + strings.Repeat("?,", len(ids)-1) + "?)"
+					for _, id := range ids {
+						whereArgs = append(whereArgs, id)
+					}
+					continue
+				}
+				logger.Error("fuzzy search error: ", err)
+			}
+
+			wheres += table + "." + modules.FilterField(field.Field, delimiter, delimiter2) + " like ?"
+			whereArgs = append(whereArgs, "%"+keyword+"%")
+		}
+	}
+
+	return wheres, whereArgs
+}
+
+// tagStatement add the conditions of the tag filters. The tags are stored as
+// a comma separated list or a json array, a row matches if one of its tags is
+// equal to the keyword.
+func (tb *DefaultTable) tagStatement(params parameter.Parameters, wheres string, whereArgs []interface{},
+	table, delimiter, delimiter2 string) (string, []interface{}) {
+
+	for _, field := range tb.Info.FieldList {
+		for index, filter := range field.FilterFormFields {
+			if filter.Operator != types.FilterOperatorHasTag {
+				continue
+			}
+			keySuffix := ""
+			if index > 0 {
+				keySuffix = parameter.FilterParamCountInfix + strconv.Itoa(index)
+			}
+			tag := strings.TrimSpace(params.GetFieldValue(field.Field + keySuffix))
+			if tag == "" {
+				continue
+			}
+			if wheres != "" {
+				wheres += " and "
+			}
+
+			column := table + "." + modules.FilterField(field.Field, delimiter, delimiter2)
+			wheres += "(" + column + " = ? or " + column + " like ? or " + column + " like ? or " +
+				column + " like ? or " + column + " like ?)"
+			whereArgs = append(whereArgs, tag, tag+",%", "%,"+tag, "%,"+tag+",%", `%"`+tag+`"%`)
+		}
+	}
+
+	return wheres, whereArgs
+}
+
+// treeStatement add the conditions of the tree filters. A row matches if the
+// field is one of the checked options or one of their descendants.
+func (tb *DefaultTable) treeStatement(params parameter.Parameters, wheres string, whereArgs []interface{},
+	table, delimiter, delimiter2 string) (string, []interface{}) {
+
+	for _, field := range tb.Info.FieldList {
+		for index, filter := range field.FilterFormFields {
+			if filter.Operator != types.FilterOperatorTree {
+				continue
+			}
+			keySuffix := ""
+			if index > 0 {
+				keySuffix = parameter.FilterParamCountInfix + strconv.Itoa(index)
+			}
+			values := params.GetFieldValues(field.Field + keySuffix)
+			if len(values) == 0 || (len(values) == 1 && values[0] == "") {
+				continue
+			}
+
+			tree, err := types.LoadOptionTree(tb.sql(), filter.OptionTable)
+			if err != nil {
+				logger.Error("load option tree error: ", err)
+			}
+			ids := tree.Subtree(values)
+			if len(ids) == 0 {
+				continue
+			}
+			if wheres != "" {
+				wheres += " and "
+			}
+
+			wheres += table + "." + modules.FilterField(field.Field, delimiter, delimiter2) +
+				" in (" + strings.Repeat("?,", len(ids)-1) + "?)"
+			for _, id := range ids {
+				whereArgs = append(whereArgs, id)
+			}
+		}
+	}
+
+	return wheres, whereArgs
+}
+
+// rangeStatement add the conditions of the range slider filters. The filter
+// is ignored when the whole range is selected, so that the rows without the
+// value are still listed.
+func (tb *DefaultTable) rangeStatement(params parameter.Parameters, wheres string, whereArgs []interface{},
+	table, delimiter, delimiter2 string) (string, []interface{}) {
+
+	for _, field := range tb.Info.FieldList {
+		for index, filter := range field.FilterFormFields {
+			if filter.Operator != types.FilterOperatorBetween || filter.RangeSlider == nil {
+				continue
+			}
+			keySuffix := ""
+			if index > 0 {
+				keySuffix = parameter.FilterParamCountInfix + strconv.Itoa(index)
+			}
+			from, to, ok := types.ParseRange(params.GetFieldValue(field.Field + keySuffix))
+			if !ok {
+				continue
+			}
+			if min, max := filter.RangeSlider.Bounds(tb.sql()); from <= min && to >= max {
+				continue
+			}
+			if wheres != "" {
+				wheres += " and "
+			}
+
+			wheres += table + "." + modules.FilterField(field.Field, delimiter, delimiter2) + " between ? and ?"
+			whereArgs = append(whereArgs, from, to)
+		}
+	}
+
+	return wheres, whereArgs
+}
+
+// listStatement add the conditions of the pasted list filters. A row matches
+// if the field is one of the values, the values are checked by the in
+// conditions of at most types.FilterListChunkSize values joined by or.
+func (tb *DefaultTable) listStatement(params parameter.Parameters, wheres string, whereArgs []interface{},
+	table, delimiter, delimiter2 string) (string, []interface{}) {
+
+	for _, field := range tb.Info.FieldList {
+		for index, filter := range field.FilterFormFields {
+			if filter.Operator != types.FilterOperatorList {
+				continue
+			}
+			keySuffix := ""
+			if index > 0 {
+				keySuffix = parameter.FilterParamCountInfix + strconv.Itoa(index)
+			}
+			values := types.ParseFilterList(params.GetFieldValue(field.Field + keySuffix))
+			if len(values) == 0 {
+				continue
+			}
+			if wheres != "" {
+				wheres += " and "
+			}
+
+			column := table + "." + modules.FilterField(field.Field, delimiter, delimiter2)
+			conditions := make([]string, 0, len(values)/types.FilterListChunkSize+1)
+			for start := 0; start < len(values); start += types.FilterListChunkSize {
+				end := start + types.FilterListChunkSize
+				if end > len(values) {
+					end = len(values)
+				}
+				conditions = append(conditions, column+" in ("+strings.Repeat("?,", end-start-1)+"?)")
+				for _, value := range values[start:end] {
+					whereArgs = append(whereArgs, value)
+				}
+			}
+			wheres += "(" + strings.Join(conditions, " or ") + ")"
+		}
+	}
+
+	return wheres, whereArgs
+}
+
+// pinnedStatement add the condition of the "My pinned" filter, only the
+// records pinned by the current user are listed.
+func (tb *DefaultTable) pinnedStatement(params parameter.Parameters, wheres string, whereArgs []interface{},
+	pk string) (string, []interface{}) {
+
+	if tb.Info.FavoriteField == "" || !params.IsPinned() {
+		return wheres, whereArgs
+	}
+	if wheres != "" {
+		wheres += " and "
+	}
+	if len(params.PinnedIDs) == 0 {
+		return wheres + "1 = 0", whereArgs
+	}
+	wheres += pk + " in (" + strings.Repeat("?,", len(params.PinnedIDs)-1) + "?)"
+	for _, id := range params.PinnedIDs {
+		whereArgs = append(whereArgs, id)
+	}
+	return wheres, whereArgs
 }
 
 func (tb *DefaultTable) getTheadAndFilterForm(params parameter.Parameters, columns Columns) (types.Thead,
@@ -1101,6 +1601,15 @@ func (tb *DefaultTable) db() db.Connection {
 	return tb.dbObj
 }
 
+// connectionError return the error shown instead of the data when the
+// connection of the table is unreachable, see db.ConnectionError.
+func (tb *DefaultTable) connectionError() error {
+	if !tb.getDataFromDB() || db.ConnectionError(tb.connection) == nil {
+		return nil
+	}
+	return errors.New(fmt.Sprintf(language.Get("database %s is temporarily unavailable"), tb.connection))
+}
+
 func (tb *DefaultTable) delimiter() string {
 	if tb.getDataFromDB() {
 		return tb.db().GetDelimiter()
@@ -1 +1,210 @@
 package table
+
+import (
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+	"github.com/purpose168/GoAdmin/template/types"
+	form2 "github.com/purpose168/GoAdmin/template/types/form"
+)
+
+func TestDefaultTable_uniqueSlugs(t *testing.T) {
+	tb := newBenchTable()
+	f := tb.GetForm()
+	f.AddField("Title", "name", db.Varchar, form2.Text)
+	f.AddField("Slug", "city", db.Varchar, form2.Text).FieldSlugFrom("name")
+	f.SetTable("users")
+
+	conn := tb.dbObj.(*benchConnection)
+	rows := conn.rows
+	conn.rows = []map[string]interface{}{{"city": "hello-world"}, {"city": "hello-world-2"}}
+	var queries []string
+	conn.onQuery = func(query string, _ []interface{}) { queries = append(queries, query) }
+	defer func() {
+		conn.rows = rows
+		conn.onQuery = nil
+	}()
+
+	values := tb.uniqueSlugs(f, form.Values{"name": {"Hello, World!"}, "city": {""}}, "")
+	assert.Equal(t, values.Get("city"), "hello-world-3")
+	assert.Equal(t, strings.Contains(queries[0], "like ?"), true)
+
+	conn.rows = nil
+	values = tb.uniqueSlugs(f, form.Values{"name": {"Hello"}, "city": {"My Slug"}}, "1")
+	assert.Equal(t, values.Get("city"), "my-slug")
+	assert.Equal(t, strings.Contains(queries[len(queries)-1], "!= ?"), true)
+
+	// The slug is kept when it is not posted.
+	values = tb.uniqueSlugs(f, form.Values{"name": {"Hello"}}, "1")
+	_, ok := values["city"]
+	assert.Equal(t, ok, false)
+}
+
+func TestDefaultTable_UpdateDataHooks(t *testing.T) {
+	tb := newBenchTable()
+	f := tb.GetForm()
+	f.AddField("ID", "id", db.Int, form2.Default)
+	f.AddField("Name", "name", db.Varchar, form2.Text).FieldPreSave(func(hc *types.FormHookContext, value string) (string, error) {
+		return strings.TrimSpace(value), nil
+	})
+	f.SetTable("users")
+
+	var calls []string
+	f.SetPreValidate(func(hc *types.FormHookContext) error {
+		calls = append(calls, "pre validate")
+		hc.Values.Add("city", hc.User.Name)
+		return nil
+	}).SetPreSave(func(hc *types.FormHookContext) error {
+		calls = append(calls, "pre save "+hc.Values.Get("name"))
+		return nil
+	}).SetPostSaveCtx(func(hc *types.FormHookContext, err error) {
+		calls = append(calls, "post save")
+		assert.Equal(t, hc.IsUpdate(), true)
+		assert.Equal(t, err, nil)
+	})
+
+	conn := tb.dbObj.(*benchConnection)
+	var args []interface{}
+	conn.onQuery = func(query string, a []interface{}) {
+		if strings.HasPrefix(query, "update") {
+			args = a
+		}
+	}
+	defer func() { conn.onQuery = nil }()
+
+	ctx := context.NewContext(httptest.NewRequest("POST", "/admin/edit/users", nil))
+	ctx.SetUserValue("user", models.UserModel{Name: "admin"})
+	err := tb.UpdateData(ctx, form.Values{"id": {"1"}, "name": {" jack "}})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, calls, []string{"pre validate", "pre save jack", "post save"})
+	saved := make(map[interface{}]bool)
+	for _, arg := range args {
+		saved[arg] = true
+	}
+	assert.Equal(t, saved["jack"], true)
+	assert.Equal(t, saved["admin"], true)
+
+	f.SetPreSave(func(hc *types.FormHookContext) error { return errors.New("locked") })
+	f.SetPostSaveCtx(func(hc *types.FormHookContext, err error) {
+		assert.Equal(t, err.Error(), "locked")
+	})
+	args = nil
+	err = tb.UpdateData(ctx, form.Values{"id": {"1"}, "name": {"jack"}})
+	assert.Equal(t, err.Error(), "locked")
+	assert.Equal(t, len(args), 0)
+}
+
+func TestDefaultTable_displayRows(t *testing.T) {
+	var (
+		tb     = newBenchTable()
+		u, _   = url.Parse("/admin/info/users?__page=1&__pageSize=20")
+		params = parameter.GetParam(u, 20)
+	)
+
+	want, err := tb.GetData(nil, params)
+	assert.Equal(t, err, nil)
+
+	// the rows keep their order when processed in parallel.
+	tb.GetInfo().SetDisplayWorkers(4)
+	got, err := tb.GetData(nil, params)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, got.InfoList, want.InfoList)
+
+	// the rows not displayed in time are replaced by placeholders without
+	// waiting for the running display functions, which get the canceled
+	// context, and the workers take no more rows.
+	var (
+		calls    int32
+		canceled = make(chan struct{}, 20)
+	)
+	tb.GetInfo().FieldList[1].FieldDisplay.Display = func(model types.FieldModel) interface{} {
+		atomic.AddInt32(&calls, 1)
+		if model.ID != "1" {
+			select {
+			case <-time.After(time.Second):
+			case <-model.Context.Done():
+				canceled <- struct{}{}
+			}
+		}
+		return model.Value
+	}
+	tb.GetInfo().SetDisplayWorkers(2, 30*time.Millisecond)
+	start := time.Now()
+	got, err = tb.GetData(nil, params)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, time.Since(start) < 500*time.Millisecond, true)
+	assert.Equal(t, len(got.InfoList), 20)
+	assert.Equal(t, got.InfoList[0]["name"].Value, "name0")
+	assert.Equal(t, got.InfoList[19]["id"].Value, "20")
+	assert.Equal(t, strings.Contains(string(got.InfoList[19]["id"].Content), DisplayPlaceholderClass), true)
+	_, ok := got.InfoList[19]["name"]
+	assert.Equal(t, ok, false)
+	<-canceled
+	<-canceled
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, atomic.LoadInt32(&calls), int32(3))
+
+	tb.GetInfo().FieldList[1].FieldDisplay.Display = func(model types.FieldModel) interface{} {
+		panic("broken display")
+	}
+	tb.GetInfo().SetDisplayWorkers(2)
+	_, err = tb.GetData(nil, params)
+	assert.Equal(t, err != nil && strings.Contains(err.Error(), "broken display"), true)
+}
+
+func TestDefaultTable_InsertDataUUID(t *testing.T) {
+	tb := newBenchTable()
+	tb.PrimaryKey.Type = db.UUID
+	f := tb.GetForm()
+	f.AddField("ID", "id", db.UUID, form2.Default).FieldNotAllowAdd().FieldUUIDDefault()
+	f.AddField("Name", "name", db.Varchar, form2.Text)
+	f.SetTable("users")
+
+	conn := tb.dbObj.(*benchConnection)
+	var (
+		query string
+		args  []interface{}
+	)
+	conn.onQuery = func(q string, a []interface{}) {
+		if strings.HasPrefix(q, "insert") {
+			query, args = q, a
+		}
+	}
+	defer func() { conn.onQuery = nil }()
+
+	var id string
+	f.SetPostSaveCtx(func(hc *types.FormHookContext, err error) {
+		assert.Equal(t, err, nil)
+		id = hc.Values.Get("id")
+	})
+
+	ctx := context.NewContext(httptest.NewRequest("POST", "/admin/new/users", nil))
+	err := tb.InsertData(ctx, form.Values{"name": {"jack"}})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(id), 36)
+	assert.Equal(t, strings.Contains(query, "`id`"), true)
+	inserted := false
+	for _, arg := range args {
+		if arg == id {
+			inserted = true
+		}
+	}
+	assert.Equal(t, inserted, true)
+
+	// An empty id without a generator is left to the database.
+	f.FieldList[0].GenerateFn = nil
+	err = tb.InsertData(ctx, form.Values{"id": {""}, "name": {"jack"}})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.Contains(query, "`id`"), false)
+}
@@ -0,0 +1,484 @@
+package table
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/expr"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/template/types"
+	"github.com/purpose168/GoAdmin/template/types/form"
+)
+
+// Definition is the JSON definition of a table, which can be exported from a
+// table by NewDefinition and loaded as a generator by Generator, so the
+// labels, the visibility and the filters of the fields can be changed
+// without recompiling. The computed displays, the row classes, the
+// validations and the visibility of the fields are written as the
+// expressions of modules/expr. For example:
+//
+//	{
+//	  "name": "posts",
+//	  "table": "posts",
+//	  "title": "Posts",
+//	  "primary_key": {"name": "id", "type": "INT"},
+//	  "info": [
+//	    {"field": "id", "head": "ID", "type": "INT", "sortable": true},
+//	    {"field": "title", "head": "Title", "type": "VARCHAR", "filter": {"type": "text", "operator": "like"}},
+//	    {"field": "total", "head": "Total", "display_expr": "fixed(price * qty, 2)"}
+//	  ],
+//	  "row_classes": [{"when": "status == 'draft'", "class": "warning"}],
+//	  "form": [
+//	    {"field": "title", "head": "Title", "type": "VARCHAR", "form_type": "text", "must": true,
+//	     "validate_expr": "len(value) <= 80", "validate_message": "title too long"}
+//	  ]
+//	}
+type Definition struct {
+	// Name is the key of the generator, the file name without the extension
+	// is used when it is empty.
+	Name        string                `json:"name"`
+	Driver      string                `json:"driver,omitempty"`
+	Connection  string                `json:"connection,omitempty"`
+	Table       string                `json:"table"`
+	Title       string                `json:"title"`
+	Description string                `json:"description,omitempty"`
+	PrimaryKey  DefinitionPrimaryKey  `json:"primary_key"`
+	CanAdd      *bool                 `json:"can_add,omitempty"`
+	Editable    *bool                 `json:"editable,omitempty"`
+	Deletable   *bool                 `json:"deletable,omitempty"`
+	Exportable  *bool                 `json:"exportable,omitempty"`
+	ReadOnly    bool                  `json:"read_only,omitempty"`
+	Info        []InfoFieldDefinition `json:"info"`
+	RowClasses  []RowClassDefinition  `json:"row_classes,omitempty"`
+	Form        []FormFieldDefinition `json:"form"`
+}
+
+// DefinitionPrimaryKey is the primary key of a Definition.
+type DefinitionPrimaryKey struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// InfoFieldDefinition is a field of the list page.
+type InfoFieldDefinition struct {
+	Field    string            `json:"field"`
+	Head     string            `json:"head"`
+	Type     string            `json:"type"`
+	Hide     bool              `json:"hide,omitempty"`
+	Sortable bool              `json:"sortable,omitempty"`
+	Editable bool              `json:"editable,omitempty"`
+	Fixed    bool              `json:"fixed,omitempty"`
+	Width    int               `json:"width,omitempty"`
+	Filter   *FilterDefinition `json:"filter,omitempty"`
+	// DisplayExpr is the expression of the displayed value, see
+	// types.InfoPanel.FieldDisplayExpr.
+	DisplayExpr string `json:"display_expr,omitempty"`
+}
+
+// RowClassDefinition is a class added to the rows of the list page when the
+// expression is true.
+type RowClassDefinition struct {
+	When  string `json:"when"`
+	Class string `json:"class"`
+}
+
+// FilterDefinition is the filter of a field of the list page.
+type FilterDefinition struct {
+	Type        string             `json:"type,omitempty"`
+	Operator    string             `json:"operator,omitempty"`
+	Placeholder string             `json:"placeholder,omitempty"`
+	Options     []OptionDefinition `json:"options,omitempty"`
+}
+
+// FormFieldDefinition is a field of the form page.
+type FormFieldDefinition struct {
+	Field        string             `json:"field"`
+	Head         string             `json:"head"`
+	Type         string             `json:"type"`
+	FormType     string             `json:"form_type"`
+	Default      string             `json:"default,omitempty"`
+	Help         string             `json:"help,omitempty"`
+	Must         bool               `json:"must,omitempty"`
+	Hide         bool               `json:"hide,omitempty"`
+	NotAllowAdd  bool               `json:"not_allow_add,omitempty"`
+	NotAllowEdit bool               `json:"not_allow_edit,omitempty"`
+	Options      []OptionDefinition `json:"options,omitempty"`
+	// ValidateExpr is the expression the posted value must satisfy and
+	// HideExpr hides the field of the edit form, see types.FormPanel.
+	ValidateExpr    string `json:"validate_expr,omitempty"`
+	ValidateMessage string `json:"validate_message,omitempty"`
+	HideExpr        string `json:"hide_expr,omitempty"`
+}
+
+// OptionDefinition is an option of a select field or filter.
+type OptionDefinition struct {
+	Text  string `json:"text"`
+	Value string `json:"value"`
+}
+
+// NewDefinition return the definition of the table of the given name.
+func NewDefinition(name string, t Table) Definition {
+	var (
+		info = t.GetInfo()
+		f    = t.GetForm()
+		pk   = t.GetPrimaryKey()
+	)
+
+	canAdd, editable, deletable, exportable := t.GetCanAdd(), t.GetEditable(), t.GetDeletable(), t.GetExportable()
+
+	d := Definition{
+		Name:        name,
+		Table:       info.Table,
+		Title:       info.Title,
+		Description: info.Description,
+		PrimaryKey:  DefinitionPrimaryKey{Name: pk.Name, Type: string(pk.Type)},
+		CanAdd:      &canAdd,
+		Editable:    &editable,
+		Deletable:   &deletable,
+		Exportable:  &exportable,
+		ReadOnly:    t.GetReadOnly(),
+		Info:        make([]InfoFieldDefinition, 0, len(info.FieldList)),
+		Form:        make([]FormFieldDefinition, 0, len(f.FieldList)),
+	}
+
+	if d.Table == "" {
+		d.Table = f.Table
+	}
+
+	if tb, ok := t.(*DefaultTable); ok {
+		d.Driver = tb.connectionDriver
+		d.Connection = tb.connection
+	}
+
+	for _, field := range info.FieldList {
+		fd := InfoFieldDefinition{
+			Field:    field.Field,
+			Head:     field.Head,
+			Type:     string(field.TypeName),
+			Hide:     field.Hide,
+			Sortable: field.Sortable,
+			Editable: field.EditAble,
+			Fixed:    field.Fixed,
+			Width:    field.Width,
+
+			DisplayExpr: field.DisplayExpr,
+		}
+		if field.Filterable && len(field.FilterFormFields) > 0 {
+			filter := field.FilterFormFields[0]
+			fd.Filter = &FilterDefinition{
+				Type:        filter.Type.String(),
+				Operator:    string(filter.Operator),
+				Placeholder: filter.Placeholder,
+				Options:     optionDefinitions(filter.Options),
+			}
+		}
+		d.Info = append(d.Info, fd)
+	}
+
+	for _, rc := range info.RowClasses {
+		d.RowClasses = append(d.RowClasses, RowClassDefinition{When: rc.When, Class: rc.Class})
+	}
+
+	for _, field := range f.FieldList {
+		d.Form = append(d.Form, FormFieldDefinition{
+			Field:        field.Field,
+			Head:         field.Head,
+			Type:         string(field.TypeName),
+			FormType:     field.FormType.String(),
+			Default:      string(field.Default),
+			Help:         string(field.HelpMsg),
+			Must:         field.Must,
+			Hide:         field.Hide,
+			NotAllowAdd:  field.NotAllowAdd,
+			NotAllowEdit: field.NotAllowEdit,
+			Options:      optionDefinitions(field.Options),
+
+			ValidateExpr:    field.ValidateExpr,
+			ValidateMessage: field.ValidateExprMsg,
+			HideExpr:        field.HideExpr,
+		})
+	}
+
+	return d
+}
+
+// Check return the error of the wrong database or form types.
+func (d Definition) Check() error {
+	if d.Name == "" {
+		return fmt.Errorf("definition of table %s: empty name", d.Table)
+	}
+	if d.PrimaryKey.Type != "" && !validDatabaseType(d.PrimaryKey.Type) {
+		return fmt.Errorf("definition %s: wrong primary key type %s", d.Name, d.PrimaryKey.Type)
+	}
+	for _, field := range d.Info {
+		if field.Type != "" && !validDatabaseType(field.Type) {
+			return fmt.Errorf("definition %s: wrong type %s of info field %s", d.Name, field.Type, field.Field)
+		}
+		if field.Filter != nil && field.Filter.Type != "" {
+			if _, ok := form.GetTypeFromString(field.Filter.Type); !ok {
+				return fmt.Errorf("definition %s: wrong filter type %s of info field %s", d.Name, field.Filter.Type, field.Field)
+			}
+		}
+		if err := checkExpr(field.DisplayExpr); err != nil {
+			return fmt.Errorf("definition %s: wrong display expression of info field %s: %v", d.Name, field.Field, err)
+		}
+	}
+	for _, rc := range d.RowClasses {
+		if err := types.CheckRowClass(types.RowClass{When: rc.When, Class: rc.Class}); err != nil {
+			return fmt.Errorf("definition %s: wrong row class: %v", d.Name, err)
+		}
+	}
+	for _, field := range d.Form {
+		if field.Type != "" && !validDatabaseType(field.Type) {
+			return fmt.Errorf("definition %s: wrong type %s of form field %s", d.Name, field.Type, field.Field)
+		}
+		if field.FormType != "" {
+			if _, ok := form.GetTypeFromString(field.FormType); !ok {
+				return fmt.Errorf("definition %s: wrong form type %s of form field %s", d.Name, field.FormType, field.Field)
+			}
+		}
+		if err := checkExpr(field.ValidateExpr); err != nil {
+			return fmt.Errorf("definition %s: wrong validate expression of form field %s: %v", d.Name, field.Field, err)
+		}
+		if err := checkExpr(field.HideExpr); err != nil {
+			return fmt.Errorf("definition %s: wrong hide expression of form field %s: %v", d.Name, field.Field, err)
+		}
+	}
+	return nil
+}
+
+// Generator return the generator of the table defined.
+func (d Definition) Generator() Generator {
+	return func(ctx *context.Context) Table {
+		conn := d.Connection
+		if conn == "" {
+			conn = DefaultConnectionName
+		}
+		driver := d.Driver
+		if driver == "" {
+			driver = config.GetDatabases()[conn].Driver
+		}
+
+		cfg := DefaultConfigWithDriverAndConnection(driver, conn)
+		if d.PrimaryKey.Name != "" {
+			cfg = cfg.SetPrimaryKey(d.PrimaryKey.Name, databaseType(d.PrimaryKey.Type, db.Int))
+		}
+		cfg.CanAdd = boolOr(d.CanAdd, cfg.CanAdd)
+		cfg.Editable = boolOr(d.Editable, cfg.Editable)
+		cfg.Deletable = boolOr(d.Deletable, cfg.Deletable)
+		cfg.Exportable = boolOr(d.Exportable, cfg.Exportable)
+		if d.ReadOnly {
+			cfg = cfg.SetReadOnly()
+		}
+
+		t := NewDefaultTable(ctx, cfg)
+
+		info := t.GetInfo()
+		for _, field := range d.Info {
+			info.AddField(field.Head, field.Field, databaseType(field.Type, db.Varchar))
+		}
+		info.SetTable(d.Table).SetTitle(d.Title).SetDescription(d.Description)
+
+		formList := t.GetForm()
+		for _, field := range d.Form {
+			formType, _ := form.GetTypeFromString(field.FormType)
+			formList.AddField(field.Head, field.Field, databaseType(field.Type, db.Varchar), form.CheckType(formType, form.Text))
+		}
+		formList.SetTable(d.Table).SetTitle(d.Title).SetDescription(d.Description)
+
+		d.Apply(t)
+		return t
+	}
+}
+
+// Apply set the heads, the visibility, the filters and the form options of
+// the fields of the table as the definition, the fields not in the
+// definition are kept. It is used to change the tables defined in Go.
+func (d Definition) Apply(t Table) {
+	info := t.GetInfo()
+	if d.Title != "" {
+		info.Title = d.Title
+	}
+	if d.Description != "" {
+		info.Description = d.Description
+	}
+	for _, fd := range d.Info {
+		for i := range info.FieldList {
+			field := &info.FieldList[i]
+			if field.Field != fd.Field {
+				continue
+			}
+			if fd.Head != "" {
+				field.Head = fd.Head
+			}
+			field.Hide = fd.Hide
+			field.Sortable = fd.Sortable
+			field.EditAble = fd.Editable
+			field.Fixed = fd.Fixed
+			field.Width = fd.Width
+			field.Filterable = fd.Filter != nil
+			field.FilterFormFields = nil
+			if fd.Filter != nil {
+				field.FilterFormFields = []types.FilterFormField{fd.Filter.formField(field.Head)}
+			}
+			if p, err := expr.Compile(fd.DisplayExpr); err == nil && fd.DisplayExpr != "" {
+				field.DisplayExpr = fd.DisplayExpr
+				field.Display = types.ExprDisplayFn(p)
+			}
+		}
+	}
+	if len(d.RowClasses) > 0 {
+		info.RowClasses = make([]types.RowClass, 0, len(d.RowClasses))
+		for _, rc := range d.RowClasses {
+			if rowClass, err := types.NewRowClass(rc.When, rc.Class); err == nil {
+				info.RowClasses = append(info.RowClasses, rowClass)
+			}
+		}
+	}
+
+	f := t.GetForm()
+	if d.Title != "" {
+		f.Title = d.Title
+	}
+	if d.Description != "" {
+		f.Description = d.Description
+	}
+	for _, fd := range d.Form {
+		field := f.FieldList.FindByFieldName(fd.Field)
+		if field == nil {
+			continue
+		}
+		if fd.Head != "" {
+			field.Head = fd.Head
+		}
+		if formType, ok := form.GetTypeFromString(fd.FormType); ok && fd.FormType != "" {
+			field.FormType = formType
+		}
+		field.Default = template.HTML(fd.Default)
+		field.HelpMsg = template.HTML(fd.Help)
+		field.Must = fd.Must
+		field.Hide = fd.Hide
+		field.NotAllowAdd = fd.NotAllowAdd
+		field.NotAllowEdit = fd.NotAllowEdit
+		if len(fd.Options) > 0 {
+			field.Options = fieldOptions(fd.Options)
+		}
+		if p, err := expr.Compile(fd.ValidateExpr); err == nil && fd.ValidateExpr != "" {
+			field.ValidateExpr = fd.ValidateExpr
+			field.ValidateExprMsg = fd.ValidateMessage
+			field.ValidateFn = types.ExprValidator(p, fd.ValidateMessage)
+		}
+		_ = field.SetHideExpr(fd.HideExpr)
+	}
+}
+
+// LoadDefinitions load the definitions of the JSON files of the directory,
+// sorted by the file names.
+func LoadDefinitions(dir string) ([]Definition, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	defs := make([]Definition, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var d Definition
+		if err := json.Unmarshal(data, &d); err != nil {
+			return nil, fmt.Errorf("%s: %v", file, err)
+		}
+		if d.Name == "" {
+			d.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		}
+		if err := d.Check(); err != nil {
+			return nil, fmt.Errorf("%s: %v", file, err)
+		}
+		defs = append(defs, d)
+	}
+	return defs, nil
+}
+
+func (fd *FilterDefinition) formField(head string) types.FilterFormField {
+	formType, ok := form.GetTypeFromString(fd.Type)
+	if !ok || fd.Type == "" {
+		formType = form.Text
+	}
+	ff := types.FilterFormField{
+		Type:        formType,
+		Head:        head,
+		Operator:    types.FilterOperator(fd.Operator),
+		Placeholder: fd.Placeholder,
+		Options:     fieldOptions(fd.Options),
+	}
+	if ff.Placeholder == "" {
+		ff.Placeholder = language.Get("input") + " " + head
+	}
+	if len(ff.Options) > 0 {
+		ff.OptionExt = `{"allowClear": "true"}`
+	}
+	return ff
+}
+
+func optionDefinitions(options types.FieldOptions) []OptionDefinition {
+	if len(options) == 0 {
+		return nil
+	}
+	res := make([]OptionDefinition, len(options))
+	for i, op := range options {
+		res[i] = OptionDefinition{Text: op.Text, Value: op.Value}
+	}
+	return res
+}
+
+func fieldOptions(options []OptionDefinition) types.FieldOptions {
+	if len(options) == 0 {
+		return nil
+	}
+	res := make(types.FieldOptions, len(options))
+	for i, op := range options {
+		res[i] = types.FieldOption{Text: op.Text, Value: op.Value}
+	}
+	return res
+}
+
+// checkExpr check the expression of a field, the empty one is no expression.
+func checkExpr(src string) error {
+	if src == "" {
+		return nil
+	}
+	_, err := expr.Compile(src)
+	return err
+}
+
+func validDatabaseType(s string) bool {
+	t := db.DatabaseType(s)
+	return db.Contains(t, db.BoolTypeList) || db.Contains(t, db.IntTypeList) ||
+		db.Contains(t, db.FloatTypeList) || db.Contains(t, db.UintTypeList) ||
+		db.Contains(t, db.StringTypeList)
+}
+
+func databaseType(s string, def db.DatabaseType) db.DatabaseType {
+	if s == "" || !validDatabaseType(s) {
+		return def
+	}
+	return db.DatabaseType(s)
+}
+
+func boolOr(b *bool, def bool) bool {
+	if b == nil {
+		return def
+	}
+	return *b
+}
@@ -0,0 +1,110 @@
+package table
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/template/types"
+	"github.com/purpose168/GoAdmin/template/types/form"
+)
+
+func testDefinitionTable() Table {
+	t := NewDefaultTable(nil, DefaultConfigWithDriver(db.DriverMysql))
+	info := t.GetInfo()
+	info.AddField("ID", "id", db.Int).FieldSortable()
+	info.AddField("Name", "name", db.Varchar).FieldFilterable(types.FilterType{Operator: types.FilterOperatorLike})
+	info.AddField("Note", "note", db.Text)
+	info.SetTable("users").SetTitle("Users")
+	t.GetForm().AddField("Name", "name", db.Varchar, form.Text).FieldMust()
+	t.GetForm().SetTable("users").SetTitle("Users")
+	return t
+}
+
+func TestDefinition(t *testing.T) {
+	d := NewDefinition("users", testDefinitionTable())
+
+	assert.Equal(t, d.Table, "users")
+	assert.Equal(t, d.Driver, db.DriverMysql)
+	assert.Equal(t, len(d.Info), 3)
+	assert.Equal(t, d.Info[0].Sortable, true)
+	assert.Equal(t, d.Info[1].Filter.Operator, "like")
+	assert.Equal(t, d.Form[0].FormType, "text")
+	assert.Equal(t, d.Form[0].Must, true)
+	assert.Equal(t, d.Check(), nil)
+
+	data, err := json.Marshal(d)
+	assert.Equal(t, err, nil)
+	var loaded Definition
+	assert.Equal(t, json.Unmarshal(data, &loaded), nil)
+
+	loaded.Info = []InfoFieldDefinition{
+		{Field: "name", Head: "Full name"},
+		{Field: "note", Head: "Note", Hide: true, Filter: &FilterDefinition{Type: "select_single",
+			Options: []OptionDefinition{{Text: "A", Value: "a"}}}},
+	}
+	loaded.Form[0].Help = "the full name"
+
+	tb := testDefinitionTable()
+	loaded.Apply(tb)
+	fields := tb.GetInfo().FieldList
+	assert.Equal(t, fields[0].Sortable, true)
+	assert.Equal(t, fields[1].Head, "Full name")
+	assert.Equal(t, fields[1].Filterable, false)
+	assert.Equal(t, fields[2].Hide, true)
+	assert.Equal(t, fields[2].FilterFormFields[0].Type, form.SelectSingle)
+	assert.Equal(t, fields[2].FilterFormFields[0].Options[0].Value, "a")
+	assert.Equal(t, string(tb.GetForm().FieldList[0].HelpMsg), "the full name")
+
+	generated := d.Generator()(nil)
+	assert.Equal(t, generated.GetInfo().Table, "users")
+	assert.Equal(t, len(generated.GetInfo().FieldList), 3)
+	assert.Equal(t, generated.GetInfo().FieldList[1].Filterable, true)
+	assert.Equal(t, generated.GetForm().FieldList[0].Must, true)
+}
+
+func TestLoadDefinitions(t *testing.T) {
+	dir := t.TempDir()
+	assert.Equal(t, os.WriteFile(filepath.Join(dir, "posts.json"),
+		[]byte(`{"table": "posts", "info": [{"field": "id", "head": "ID", "type": "INT"}]}`), 0644), nil)
+
+	defs, err := LoadDefinitions(dir)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(defs), 1)
+	assert.Equal(t, defs[0].Name, "posts")
+
+	assert.Equal(t, os.WriteFile(filepath.Join(dir, "wrong.json"),
+		[]byte(`{"table": "wrong", "form": [{"field": "id", "form_type": "unknown"}]}`), 0644), nil)
+	_, err = LoadDefinitions(dir)
+	assert.Equal(t, err != nil, true)
+}
+
+func TestDefinitionExpressions(t *testing.T) {
+	d := NewDefinition("users", testDefinitionTable())
+	d.Info[2].DisplayExpr = "upper(name) + ': ' + value"
+	d.RowClasses = []RowClassDefinition{{When: "note == ''", Class: "warning"}}
+	d.Form[0].ValidateExpr = "len(value) <= 3"
+	d.Form[0].ValidateMessage = "too long"
+	assert.Equal(t, d.Check(), nil)
+
+	tb := testDefinitionTable()
+	d.Apply(tb)
+	info := tb.GetInfo()
+	assert.Equal(t, info.FieldList[2].ToDisplay(types.FieldModel{Value: "x", Row: map[string]interface{}{"name": "ann"}}), "ANN: x")
+	assert.Equal(t, info.RowClass(map[string]types.InfoItem{"note": {Value: ""}}), "warning")
+	assert.Equal(t, info.RowClass(map[string]types.InfoItem{"note": {Value: "a"}}), "")
+
+	f := tb.GetForm().FieldList[0]
+	assert.Equal(t, f.ValidateFn(types.PostFieldModel{Value: types.FieldModelValue{"abc"}}), nil)
+	assert.Equal(t, f.ValidateFn(types.PostFieldModel{Value: types.FieldModelValue{"abcd"}}).Error(), "too long")
+	assert.Equal(t, NewDefinition("users", tb).Form[0].ValidateExpr, "len(value) <= 3")
+
+	d.Info[2].DisplayExpr = "name +"
+	assert.Equal(t, d.Check() != nil, true)
+	d.Info[2].DisplayExpr = ""
+	d.RowClasses[0].Class = `x" onclick="y`
+	assert.Equal(t, d.Check() != nil, true)
+}
@@ -0,0 +1,139 @@
+package table
+
+import (
+	stdctx "context"
+	"fmt"
+	"html/template"
+	"sync"
+	"sync/atomic"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// DisplayPlaceholderClass is the class of the primary key of the rows not
+// displayed in time, the actions of the rows are removed by the list page.
+const DisplayPlaceholderClass = "goadmin-display-placeholder"
+
+// displayRows return the items of the rows in the order of the rows. The rows
+// are processed by a bounded pool of goroutines when the display workers of
+// the info panel are set. At the display timeout or when the request is
+// canceled, it returns at once without waiting for the running display
+// functions, and the rows not finished are replaced by placeholders. The
+// workers then take no more rows, and the display functions get the canceled
+// context by FieldModel.Context to return early.
+func (tb *DefaultTable) displayRows(ctx *context.Context, rows []map[string]interface{},
+	params parameter.Parameters, columns Columns) (types.InfoList, error) {
+
+	parent := stdctx.Background()
+	if ctx != nil && ctx.Request != nil {
+		parent = ctx.Request.Context()
+	}
+
+	list := make(types.InfoList, len(rows))
+	workers := tb.Info.DisplayWorkers
+	if workers < 2 || len(rows) < 2 {
+		for i := range rows {
+			list[i] = tb.getTempModelData(parent, rows[i], params, columns)
+			if list[i] == nil {
+				list[i] = tb.displayPlaceholder(rows[i])
+			}
+		}
+		return list, nil
+	}
+	if workers > len(rows) {
+		workers = len(rows)
+	}
+
+	var cancel stdctx.CancelFunc
+	if tb.Info.DisplayTimeout > 0 {
+		parent, cancel = stdctx.WithTimeout(parent, tb.Info.DisplayTimeout)
+	} else {
+		parent, cancel = stdctx.WithCancel(parent)
+	}
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		stopped  bool
+		next     = int64(-1)
+		panicked atomic.Value
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					panicked.Store(fmt.Errorf("display panic: %v", r))
+					cancel()
+				}
+			}()
+			for parent.Err() == nil {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= len(rows) {
+					return
+				}
+				item := tb.getTempModelData(parent, rows[i], params, columns)
+				mu.Lock()
+				if !stopped && item != nil {
+					list[i] = item
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-parent.Done():
+	}
+
+	if err, ok := panicked.Load().(error); ok {
+		return nil, err
+	}
+	// the workers still running drop their rows once stopped.
+	mu.Lock()
+	defer mu.Unlock()
+	stopped = true
+	unfinished := 0
+	for i := range list {
+		if list[i] == nil {
+			list[i] = tb.displayPlaceholder(rows[i])
+			unfinished++
+		}
+	}
+	if unfinished > 0 {
+		logger.Warn(fmt.Sprintf("display of %d rows: %v, %d rows are not displayed",
+			len(rows), parent.Err(), unfinished))
+	}
+	return list, nil
+}
+
+// displayPlaceholder return the item of a row whose display is not finished in
+// time. Only the primary key is kept, as the raw values of the other columns
+// may be masked or hidden by their display functions, and it is marked by
+// DisplayPlaceholderClass so that the actions of the row are not shown.
+func (tb *DefaultTable) displayPlaceholder(row map[string]interface{}) map[string]types.InfoItem {
+	pk := db.GetValueFromDatabaseType(tb.PrimaryKey.Type, row[tb.PrimaryKey.Name], true).String()
+	return map[string]types.InfoItem{
+		"__goadmin_edit_params":   {},
+		"__goadmin_delete_params": {},
+		"__goadmin_detail_params": {},
+		tb.PrimaryKey.Name: {
+			Content: template.HTML(`<span class="` + DisplayPlaceholderClass + `" title="` +
+				template.HTMLEscapeString(language.Get("not displayed in time")) + `">` +
+				template.HTMLEscapeString(pk) + `</span>`),
+			Value: pk,
+		},
+	}
+}
@@ -0,0 +1,149 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+)
+
+// QueryInfoPermission is the slug of the permission showing the details and
+// the plan of the list query, the super administrators always have it.
+const QueryInfoPermission = "query_info"
+
+// ErrExplainNotSupported is returned by the explain of the tables whose data
+// are not from the database or whose driver has no explain statement.
+var ErrExplainNotSupported = errors.New("explain is not supported by the table")
+
+// QueryInfo is the list query of the table, empty when the data are not
+// from the database.
+type QueryInfo struct {
+	Statement string
+	Args      []interface{}
+	// Duration is the execution time of the statement, Elapsed is the time
+	// of the whole data loading including the count query.
+	Duration time.Duration
+	Elapsed  time.Duration
+	Rows     int
+	Total    int
+}
+
+// QueryPlan is the result of the explain of the list query.
+type QueryPlan struct {
+	Query   QueryInfo
+	Columns []string
+	Rows    []map[string]interface{}
+	// Examined is the number of the rows examined estimated by the plan, -1
+	// when the driver does not tell it.
+	Examined int64
+}
+
+// ExplainTable is a table supporting the explain of the list query,
+// implemented by DefaultTable.
+type ExplainTable interface {
+	Explain(ctx *context.Context, params parameter.Parameters) (QueryPlan, error)
+}
+
+// explainPrefix is the explain statement of the drivers.
+var explainPrefix = map[string]string{
+	db.DriverMysql:      "EXPLAIN ",
+	db.DriverPostgresql: "EXPLAIN ",
+	db.DriverSqlite:     "EXPLAIN QUERY PLAN ",
+}
+
+// explainColumns is the order of the known columns of the plans.
+var explainColumns = []string{"id", "select_type", "table", "partitions", "type", "possible_keys", "key",
+	"key_len", "ref", "rows", "filtered", "Extra", "QUERY PLAN", "parent", "notused", "detail"}
+
+var postgresRowsReg = regexp.MustCompile(`rows=(\d+)`)
+
+// Explain run the list query of the parameters and the explain statement of
+// the driver on it.
+func (tb *DefaultTable) Explain(ctx *context.Context, params parameter.Parameters) (QueryPlan, error) {
+	prefix, ok := explainPrefix[tb.connectionDriver]
+	if !ok || !tb.getDataFromDB() || tb.Info.Table == "" {
+		return QueryPlan{}, ErrExplainNotSupported
+	}
+
+	info, err := tb.getDataFromDatabase(ctx, params)
+	if err != nil {
+		return QueryPlan{}, err
+	}
+
+	rows, err := tb.db().QueryWithConnection(tb.connection, prefix+info.Query.Statement, info.Query.Args...)
+	if err != nil {
+		return QueryPlan{}, err
+	}
+
+	return QueryPlan{
+		Query:    info.Query,
+		Columns:  planColumns(rows),
+		Rows:     rows,
+		Examined: examinedRows(tb.connectionDriver, rows),
+	}, nil
+}
+
+// planColumns return the columns of the plan, the known columns come first.
+func planColumns(rows []map[string]interface{}) []string {
+	keys := make(map[string]bool)
+	for _, row := range rows {
+		for key := range row {
+			keys[key] = true
+		}
+	}
+
+	columns := make([]string, 0, len(keys))
+	for _, column := range explainColumns {
+		if keys[column] {
+			columns = append(columns, column)
+			delete(keys, column)
+		}
+	}
+	others := make([]string, 0, len(keys))
+	for key := range keys {
+		others = append(others, key)
+	}
+	sort.Strings(others)
+	return append(columns, others...)
+}
+
+// examinedRows return the rows examined estimated by the plan, the sum of the
+// rows column of mysql or the rows of the top node of postgresql.
+func examinedRows(driver string, rows []map[string]interface{}) int64 {
+	switch driver {
+	case db.DriverMysql:
+		var sum int64
+		for _, row := range rows {
+			sum += planInt(row["rows"])
+		}
+		return sum
+	case db.DriverPostgresql:
+		if len(rows) > 0 {
+			plan := db.GetValueFromDatabaseType(db.Varchar, rows[0]["QUERY PLAN"], false).String()
+			if m := postgresRowsReg.FindStringSubmatch(plan); len(m) > 1 {
+				return planInt(m[1])
+			}
+		}
+	}
+	return -1
+}
+
+func planInt(value interface{}) int64 {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case []byte:
+		s = string(v)
+	default:
+		s = fmt.Sprintf("%v", v)
+	}
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
@@ -0,0 +1,62 @@
+package table
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+)
+
+func TestDefaultTable_Explain(t *testing.T) {
+	tb := newBenchTable()
+	conn := tb.dbObj.(*benchConnection)
+	defer func() {
+		conn.onQuery = nil
+	}()
+
+	var explains []string
+	conn.onQuery = func(query string, args []interface{}) {
+		if strings.HasPrefix(query, "EXPLAIN ") {
+			explains = append(explains, query)
+		}
+	}
+
+	u, _ := url.Parse("/admin/info/users?name=foo")
+	plan, err := tb.Explain(nil, parameter.GetParam(u, 10))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(explains), 1)
+	assert.Equal(t, explains[0], "EXPLAIN "+plan.Query.Statement)
+	assert.Equal(t, plan.Query.Args[0], "foo")
+	assert.Equal(t, plan.Query.Rows, 20)
+	assert.Equal(t, plan.Query.Total, 20)
+	assert.Equal(t, plan.Columns[0], "id")
+
+	tb.connectionDriver = db.DriverMssql
+	_, err = tb.Explain(nil, parameter.GetParam(u, 10))
+	assert.Equal(t, err, ErrExplainNotSupported)
+}
+
+func TestExaminedRows(t *testing.T) {
+	assert.Equal(t, examinedRows(db.DriverMysql, []map[string]interface{}{
+		{"id": int64(1), "rows": []byte("120")},
+		{"id": int64(1), "rows": int64(3)},
+		{"id": int64(2), "rows": nil},
+	}), int64(123))
+	assert.Equal(t, examinedRows(db.DriverPostgresql, []map[string]interface{}{
+		{"QUERY PLAN": "Seq Scan on users  (cost=0.00..18.10 rows=810 width=68)"},
+		{"QUERY PLAN": "  Filter: (name = 'foo')"},
+	}), int64(810))
+	assert.Equal(t, examinedRows(db.DriverSqlite, []map[string]interface{}{
+		{"id": int64(2), "parent": int64(0), "detail": "SCAN users"},
+	}), int64(-1))
+}
+
+func TestPlanColumns(t *testing.T) {
+	assert.Equal(t, planColumns([]map[string]interface{}{
+		{"detail": "SCAN users", "notused": 0, "parent": 0, "id": 2},
+		{"zeta": 1, "alpha": 2},
+	}), []string{"id", "parent", "notused", "detail", "alpha", "zeta"})
+}
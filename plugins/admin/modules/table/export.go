@@ -0,0 +1,112 @@
+package table
+
+import (
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// ExportIterator fetches the rows of the table chunk by chunk with the list
+// query of the parameters, so the memory of an export does not grow with the
+// number of the rows.
+//
+//	it := table.NewExportIterator(ctx, panel, params, 500, 10000)
+//	for it.Next() {
+//		write(it.Data())
+//	}
+//	if it.Err() != nil { ... }
+type ExportIterator struct {
+	ctx       *context.Context
+	panel     Table
+	params    parameter.Parameters
+	chunkSize int
+	limit     int
+
+	page  int
+	count int
+	data  PanelInfo
+	err   error
+	done  bool
+}
+
+// NewExportIterator return an iterator of the rows of the parameters, at most
+// limit rows are returned, no limit when it is 0.
+func NewExportIterator(ctx *context.Context, panel Table, params parameter.Parameters, chunkSize, limit int) *ExportIterator {
+	if chunkSize <= 0 {
+		chunkSize = types.DefaultExportChunkSize
+	}
+	return &ExportIterator{
+		ctx:       ctx,
+		panel:     panel,
+		params:    params.WithIsAll(false),
+		chunkSize: chunkSize,
+		limit:     limit,
+	}
+}
+
+// Next fetch the next chunk, it returns false when all the rows are fetched,
+// the limit is reached or an error occurs.
+func (it *ExportIterator) Next() bool {
+	if it.done {
+		return false
+	}
+
+	if it.limit > 0 && it.count >= it.limit {
+		it.done = true
+		return false
+	}
+
+	it.page++
+	data, err := it.panel.GetData(it.ctx, it.params.WithPage(it.page, it.chunkSize))
+	if err != nil {
+		it.err = err
+		it.done = true
+		return false
+	}
+	if len(data.InfoList) == 0 {
+		// the empty chunk still gives the thead of the export.
+		it.data = data
+		it.done = true
+		return false
+	}
+
+	if len(data.InfoList) < it.chunkSize {
+		it.done = true
+	}
+	if it.limit > 0 && it.count+len(data.InfoList) > it.limit {
+		data.InfoList = data.InfoList[:it.limit-it.count]
+		it.done = true
+	}
+
+	it.count += len(data.InfoList)
+	it.data = data
+	return true
+}
+
+// Data return the chunk fetched by the last call of Next.
+func (it *ExportIterator) Data() PanelInfo {
+	return it.data
+}
+
+// Count return the number of the rows fetched.
+func (it *ExportIterator) Count() int {
+	return it.count
+}
+
+// Truncated check whether there are more rows than the limit by probing the
+// row after the limit, it can be called before the iteration.
+func (it *ExportIterator) Truncated() (bool, error) {
+	if it.limit <= 0 {
+		return false, nil
+	}
+	probe, err := it.panel.GetData(it.ctx, it.params.WithPage(it.limit+1, 1))
+	if err != nil {
+		return false, err
+	}
+	return len(probe.InfoList) > 0, nil
+}
+
+// Err return the error of fetching the rows.
+func (it *ExportIterator) Err() error {
+	return it.err
+}
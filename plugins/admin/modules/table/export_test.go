@@ -0,0 +1,71 @@
+package table
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+)
+
+func newExportTable(rows int) (*DefaultTable, *[]string) {
+	newBenchTable()
+
+	var pages []string
+	tb := NewDefaultTable(nil, DefaultConfigWithDriver(db.DriverMysql)).(*DefaultTable)
+	info := tb.GetInfo()
+	info.AddField("ID", "id", db.Int)
+	info.SetGetDataFn(func(param parameter.Parameters) ([]map[string]interface{}, int) {
+		pages = append(pages, param.Page+"/"+param.PageSize)
+		data := make([]map[string]interface{}, 0)
+		for i := (param.PageInt - 1) * param.PageSizeInt; i < param.PageInt*param.PageSizeInt && i < rows; i++ {
+			data = append(data, map[string]interface{}{"id": i + 1})
+		}
+		return data, rows
+	})
+	return tb, &pages
+}
+
+func TestExportIterator(t *testing.T) {
+	u, _ := url.Parse("/admin/info/users")
+	params := parameter.GetParam(u, 10).WithIsAll(true)
+
+	tb, pages := newExportTable(12)
+	it := NewExportIterator(nil, tb, params, 5, 0)
+	sizes := make([]int, 0)
+	for it.Next() {
+		sizes = append(sizes, len(it.Data().InfoList))
+	}
+	assert.Equal(t, it.Err(), nil)
+	assert.Equal(t, sizes, []int{5, 5, 2})
+	assert.Equal(t, it.Count(), 12)
+	assert.Equal(t, *pages, []string{"1/5", "2/5", "3/5"})
+	truncated, _ := it.Truncated()
+	assert.Equal(t, truncated, false)
+
+	tb, pages = newExportTable(12)
+	it = NewExportIterator(nil, tb, params, 5, 7)
+	truncated, _ = it.Truncated()
+	assert.Equal(t, truncated, true)
+	sizes = sizes[:0]
+	for it.Next() {
+		sizes = append(sizes, len(it.Data().InfoList))
+	}
+	assert.Equal(t, sizes, []int{5, 2})
+	assert.Equal(t, it.Data().InfoList[1]["id"].Value, "7")
+	assert.Equal(t, *pages, []string{"8/1", "1/5", "2/5"})
+
+	tb, _ = newExportTable(10)
+	it = NewExportIterator(nil, tb, params, 5, 10)
+	truncated, _ = it.Truncated()
+	assert.Equal(t, truncated, false)
+	for it.Next() {
+	}
+	assert.Equal(t, it.Count(), 10)
+
+	tb, _ = newExportTable(0)
+	it = NewExportIterator(nil, tb, params, 5, 0)
+	assert.Equal(t, it.Next(), false)
+	assert.Equal(t, len(it.Data().Thead), 1)
+}
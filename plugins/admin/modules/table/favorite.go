@@ -0,0 +1,39 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrFavoriteNotSupported is returned by the favorites of the tables whose
+// data are not from the database.
+var ErrFavoriteNotSupported = errors.New("favorite is not supported by the table")
+
+// FavoriteTable is a table supporting the favorites, implemented by
+// DefaultTable.
+type FavoriteTable interface {
+	FavoriteTitle(id string) (string, error)
+}
+
+// FavoriteTitle return the value of the favorite field of the record, which
+// is saved as the title of the pinned record. An error is returned if there
+// is no such record.
+func (tb *DefaultTable) FavoriteTitle(id string) (string, error) {
+	field := tb.Info.FavoriteField
+	if field == "" || !tb.getDataFromDB() || tb.Info.Table == "" {
+		return "", ErrFavoriteNotSupported
+	}
+
+	row, err := tb.sql().Table(tb.Info.Table).Select(tb.PrimaryKey.Name, field).
+		Where(tb.PrimaryKey.Name, "=", id).First()
+	if err != nil {
+		return "", err
+	}
+	if row == nil {
+		return "", fmt.Errorf("wrong %s", tb.PrimaryKey.Name)
+	}
+	if row[field] == nil {
+		return "", nil
+	}
+	return fmt.Sprintf("%v", row[field]), nil
+}
@@ -0,0 +1,47 @@
+package table
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+)
+
+func TestDefaultTable_pinned(t *testing.T) {
+	tb := newBenchTable()
+	conn := tb.dbObj.(*benchConnection)
+	defer func() {
+		conn.onQuery = nil
+	}()
+
+	var selects []string
+	conn.onQuery = func(query string, args []interface{}) {
+		if strings.HasPrefix(query, "select") && !strings.HasPrefix(query, "select count(*)") {
+			selects = append(selects, query)
+		}
+	}
+
+	u, _ := url.Parse("/admin/info/users?__pinned=true")
+	params := parameter.GetParam(u, 10)
+
+	// the parameter is ignored by the tables not enabling the favorites.
+	_, err := tb.GetData(nil, params.WithPinnedIDs([]string{"1", "2"}))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.Contains(selects[len(selects)-1], "`users`.`id` in (?,?)"), false)
+
+	tb.GetInfo().EnableFavorite("name")
+
+	_, err = tb.GetData(nil, params.WithPinnedIDs([]string{"1", "2"}))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.Contains(selects[len(selects)-1], "`users`.`id` in (?,?)"), true)
+
+	_, err = tb.GetData(nil, params.WithPinnedIDs(nil))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.Contains(selects[len(selects)-1], "1 = 0"), true)
+
+	title, err := tb.FavoriteTitle("1")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, title, "name0")
+}
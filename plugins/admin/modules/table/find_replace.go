@@ -0,0 +1,195 @@
+package table
+
+import (
+	dbsql "database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+	"github.com/purpose168/GoAdmin/modules/event"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// FindReplacePermission is the slug of the permission running the find and
+// replace on the filtered rows, the super administrators always have it.
+const FindReplacePermission = "find_replace"
+
+// FindReplaceLimit is the most rows of the filtered set of a find and
+// replace, the filters should be narrowed when there are more.
+const FindReplaceLimit = 10000
+
+// findReplaceChunkSize is the number of the rows fetched at a time.
+const findReplaceChunkSize = 500
+
+// ErrFindReplaceNotSupported is returned by the find and replace of the
+// tables whose data are not from the database.
+var ErrFindReplaceNotSupported = errors.New("find and replace is not supported by the table")
+
+// findReplaceTypes is the column types can be replaced.
+var findReplaceTypes = []db.DatabaseType{db.Varchar, db.Char, db.Text, db.Tinytext, db.Mediumtext, db.Longtext,
+	db.Nvarchar, db.Nchar, db.Bpchar, db.Character, db.Varyingcharacter, db.Clob}
+
+// FindReplaceTable is a table supporting the find and replace on a column of
+// the filtered rows, implemented by DefaultTable.
+type FindReplaceTable interface {
+	FindReplaceFields() types.FieldList
+	PreviewFindReplace(ctx *context.Context, params parameter.Parameters, fr FindReplace) ([]FindReplaceRow, error)
+	ApplyFindReplace(ctx *context.Context, params parameter.Parameters, fr FindReplace) ([]FindReplaceRow, error)
+}
+
+// FindReplace is a find and replace on a column.
+type FindReplace struct {
+	Field     string `json:"field"`
+	Find      string `json:"find"`
+	Replace   string `json:"replace"`
+	MatchCase bool   `json:"match_case"`
+}
+
+// FindReplaceRow is a row affected by a find and replace.
+type FindReplaceRow struct {
+	ID  string `json:"id"`
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// FindReplaceFields return the text columns of the info panel can be
+// replaced, the primary key and the joined fields are excluded.
+func (tb *DefaultTable) FindReplaceFields() types.FieldList {
+	fields := make(types.FieldList, 0)
+	if !tb.getDataFromDB() || tb.Info.Table == "" {
+		return fields
+	}
+	columns, _ := tb.getColumns(tb.Info.Table)
+	for _, field := range tb.Info.FieldList {
+		if !field.Hide && field.Field != tb.PrimaryKey.Name && !field.Joins.Valid() &&
+			db.Contains(field.TypeName, findReplaceTypes) && modules.InArray(columns, field.Field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// PreviewFindReplace return the rows of the filtered set whose values of the
+// column are changed by the find and replace.
+func (tb *DefaultTable) PreviewFindReplace(ctx *context.Context, params parameter.Parameters, fr FindReplace) ([]FindReplaceRow, error) {
+	if fr.Find == "" {
+		return nil, errors.New(language.Get("find replace empty find"))
+	}
+	if tb.FindReplaceFields().GetFieldByFieldName(fr.Field).Field == "" {
+		return nil, fmt.Errorf(language.Get("find replace field %s can not be replaced"), fr.Field)
+	}
+
+	ids, err := tb.findReplaceIDs(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]FindReplaceRow, 0)
+	for start := 0; start < len(ids); start += findReplaceChunkSize {
+		end := start + findReplaceChunkSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		res, err := tb.sql().Table(tb.Info.Table).Select(tb.PrimaryKey.Name, fr.Field).
+			WhereIn(tb.PrimaryKey.Name, ids[start:end]).All()
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range res {
+			old := gridCell(item[fr.Field])
+			if value := replaceText(old, fr); value != old {
+				rows = append(rows, FindReplaceRow{ID: gridCell(item[tb.PrimaryKey.Name]), Old: old, New: value})
+			}
+		}
+	}
+	return rows, nil
+}
+
+// ApplyFindReplace update the rows of the preview in one transaction. A row
+// is updated only when its value is still the one of the preview, so the
+// whole replace is rolled back when any row is changed in the meantime.
+func (tb *DefaultTable) ApplyFindReplace(ctx *context.Context, params parameter.Parameters, fr FindReplace) ([]FindReplaceRow, error) {
+	rows, err := tb.PreviewFindReplace(ctx, params, fr)
+	if err != nil || len(rows) == 0 {
+		return rows, err
+	}
+
+	events := make([]event.Event, len(rows))
+	_, err = tb.sql().WithTransaction(func(tx *dbsql.Tx) (error, map[string]interface{}) {
+		for i, row := range rows {
+			values := dialect.H{fr.Field: row.New}
+			_, err := tb.sql().WithTx(tx).Table(tb.Info.Table).
+				Where(tb.PrimaryKey.Name, "=", row.ID).
+				Where(fr.Field, "=", row.Old).
+				Update(values)
+			if err != nil {
+				return fmt.Errorf("%s %s: %v", tb.PrimaryKey.Name, row.ID, err), nil
+			}
+			events[i] = event.Event{
+				Connection: tb.connection,
+				Table:      tb.Info.Table,
+				Action:     event.Update,
+				IDs:        []string{row.ID},
+				Values:     values,
+				Time:       time.Now(),
+			}
+			if err := event.PublishTx(tx, events[i]); err != nil {
+				return err, nil
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return rows, err
+	}
+
+	for _, e := range events {
+		event.Publish(e)
+	}
+	return rows, nil
+}
+
+// findReplaceIDs return the primary keys of the filtered rows.
+func (tb *DefaultTable) findReplaceIDs(ctx *context.Context, params parameter.Parameters) ([]interface{}, error) {
+	if !tb.getDataFromDB() || tb.Info.Table == "" {
+		return nil, ErrFindReplaceNotSupported
+	}
+
+	it := NewExportIterator(ctx, tb, params, findReplaceChunkSize, FindReplaceLimit)
+	truncated, err := it.Truncated()
+	if err != nil {
+		return nil, err
+	}
+	if truncated {
+		return nil, fmt.Errorf(language.Get("find replace more than %d rows"), FindReplaceLimit)
+	}
+
+	ids := make([]interface{}, 0)
+	for it.Next() {
+		for _, item := range it.Data().InfoList {
+			ids = append(ids, item[tb.PrimaryKey.Name].Value)
+		}
+	}
+	return ids, it.Err()
+}
+
+// replaceText replace all the occurrences of the find text, the case is
+// ignored unless MatchCase is set.
+func replaceText(s string, fr FindReplace) string {
+	if fr.Find == "" {
+		return s
+	}
+	if fr.MatchCase {
+		return strings.ReplaceAll(s, fr.Find, fr.Replace)
+	}
+	reg := regexp.MustCompile("(?i)" + regexp.QuoteMeta(fr.Find))
+	return reg.ReplaceAllLiteralString(s, fr.Replace)
+}
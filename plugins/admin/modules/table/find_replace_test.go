@@ -0,0 +1,14 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestReplaceText(t *testing.T) {
+	assert.Equal(t, replaceText("Acme Corp, acme", FindReplace{Find: "acme", Replace: "Globex", MatchCase: true}), "Acme Corp, Globex")
+	assert.Equal(t, replaceText("Acme Corp, acme", FindReplace{Find: "acme", Replace: "Globex"}), "Globex Corp, Globex")
+	assert.Equal(t, replaceText("a.b.c", FindReplace{Find: ".", Replace: "$1"}), "a$1b$1c")
+	assert.Equal(t, replaceText("abc", FindReplace{Replace: "x"}), "abc")
+}
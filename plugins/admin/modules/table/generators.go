@@ -129,6 +129,8 @@ func (s *SystemTable) GetManagerTable(ctx *context.Context) (managerTable Table)
 				return nil, nil
 			})
 
+			models.InvalidateAuthCache()
+
 			return txErr
 		})
 
@@ -247,6 +249,8 @@ func (s *SystemTable) GetManagerTable(ctx *context.Context) (managerTable Table)
 			return nil, nil
 		})
 
+		models.InvalidateAuthCache()
+
 		return txErr
 	})
 	formList.SetInsertFn(func(values form2.Values) error {
@@ -287,6 +291,7 @@ func (s *SystemTable) GetManagerTable(ctx *context.Context) (managerTable Table)
 
 			return nil, nil
 		})
+		models.InvalidateAuthCache()
 		return txErr
 	})
 
@@ -438,6 +443,8 @@ func (s *SystemTable) GetNormalManagerTable(ctx *context.Context) (managerTable
 				return nil, nil
 			})
 
+			models.InvalidateAuthCache()
+
 			return txErr
 		})
 
@@ -594,6 +601,8 @@ func (s *SystemTable) GetPermissionTable(ctx *context.Context) (permissionTable
 				return nil, nil
 			})
 
+			models.InvalidateAuthCache()
+
 			return txErr
 		})
 
@@ -647,6 +656,9 @@ func (s *SystemTable) GetPermissionTable(ctx *context.Context) (permissionTable
 			return nil
 		}
 
+		// the edited paths and methods apply to the cached permissions.
+		models.InvalidateAuthCache()
+
 		_, err := s.connection().Table("goadmin_permissions").
 			Where("id", "=", values.Get("id")).
 			Update(dialect.H{
@@ -722,6 +734,8 @@ func (s *SystemTable) GetRolesTable(ctx *context.Context) (roleTable Table) {
 				return nil, nil
 			})
 
+			models.InvalidateAuthCache()
+
 			return txErr
 		})
 
@@ -788,6 +802,8 @@ func (s *SystemTable) GetRolesTable(ctx *context.Context) (roleTable Table) {
 			return nil, nil
 		})
 
+		models.InvalidateAuthCache()
+
 		return txErr
 	})
 
@@ -814,6 +830,8 @@ func (s *SystemTable) GetRolesTable(ctx *context.Context) (roleTable Table) {
 			return nil, nil
 		})
 
+		models.InvalidateAuthCache()
+
 		return txErr
 	})
 
@@ -947,6 +965,8 @@ func (s *SystemTable) GetMenuTable(ctx *context.Context) (menuTable Table) {
 				return nil, map[string]interface{}{}
 			})
 
+			models.InvalidateAuthCache()
+
 			return txErr
 		})
 
@@ -1124,6 +1144,27 @@ func (s *SystemTable) GetSiteTable(ctx *context.Context) (siteTable Table) {
 	formList.AddField(lgWithConfigScore("custom 500 Html"), "custom_500_html", db.Varchar, form.Code)
 	formList.AddField(lgWithConfigScore("footer info"), "footer_info", db.Varchar, form.Code)
 	formList.AddField(lgWithConfigScore("login logo"), "login_logo", db.Varchar, form.Code)
+	formList.AddField(lgWithConfigScore("login layout"), "login_layout", db.Varchar, form.SelectSingle).
+		FieldOptions(types.FieldOptions{
+			{Text: lgWithConfigScore("login layout center"), Value: config.LoginLayoutCenter},
+			{Text: lgWithConfigScore("login layout left"), Value: config.LoginLayoutLeft},
+			{Text: lgWithConfigScore("login layout right"), Value: config.LoginLayoutRight},
+		}).FieldDisplay(defaultFilterFn(config.LoginLayoutCenter))
+	formList.AddField(lgWithConfigScore("login background"), "login_background", db.Varchar, form.Text).
+		FieldHelpMsg("image url or css color, e.g. /uploads/bg.jpg or #2c3e50")
+	formList.AddField(lgWithConfigScore("login username label"), "login_username_label", db.Varchar, form.Text)
+	formList.AddField(lgWithConfigScore("login tenant label"), "login_tenant_label", db.Varchar, form.Text).
+		FieldHelpMsg("a required tenant field is shown when it is not empty")
+	formList.AddField(lgWithConfigScore("login qr"), "login_qr", db.Varchar, form.Switch).
+		FieldOptions(types.FieldOptions{
+			{Text: trueStr, Value: "true"},
+			{Text: falseStr, Value: "false"},
+		}).
+		FieldHelpMsg("a signed in mobile scans the qr code of the login page to approve the login")
+	formList.AddField(lgWithConfigScore("login terms"), "login_terms", db.Varchar, form.Code).
+		FieldHelpMsg("a checkbox with the text must be checked to login when it is not empty")
+	formList.AddField(lgWithConfigScore("login links"), "login_links", db.Varchar, form.TextArea).
+		FieldHelpMsg(`e.g. [{"text": "Help", "url": "https://example.com/help"}]`)
 	formList.AddField(lgWithConfigScore("no limit login ip"), "no_limit_login_ip", db.Varchar, form.Switch).
 		FieldOptions(types.FieldOptions{
 			{Text: trueStr, Value: "true"},
@@ -1306,6 +1347,7 @@ func (s *SystemTable) GetSiteTable(ctx *context.Context) (siteTable Table) {
 			"logger_encoder_caller_key", "logger_encoder_message_key", "logger_encoder_stacktrace_key", "logger_encoder_level",
 			"logger_encoder_time", "logger_encoder_duration", "logger_encoder_caller").
 		AddGroup("logo", "mini_logo", "custom_head_html", "custom_foot_html", "footer_info", "login_logo",
+			"login_layout", "login_background", "login_username_label", "login_tenant_label", "login_qr", "login_terms", "login_links",
 			"custom_404_html", "custom_403_html", "custom_500_html")).
 		SetTabHeaders(lgWithConfigScore("general"), lgWithConfigScore("log"), lgWithConfigScore("custom"))
 
@@ -1323,6 +1365,9 @@ func (s *SystemTable) GetSiteTable(ctx *context.Context) (siteTable Table) {
 		if err := checkJSON(values, "file_upload_engine"); err != nil {
 			return err
 		}
+		if err := checkJSON(values, "login_links"); err != nil {
+			return err
+		}
 
 		values["logo"][0] = escape(values.Get("logo"))
 		values["mini_logo"][0] = escape(values.Get("mini_logo"))
@@ -1333,6 +1378,7 @@ func (s *SystemTable) GetSiteTable(ctx *context.Context) (siteTable Table) {
 		values["custom_500_html"][0] = escape(values.Get("custom_500_html"))
 		values["footer_info"][0] = escape(values.Get("footer_info"))
 		values["login_logo"][0] = escape(values.Get("login_logo"))
+		values["login_terms"][0] = escape(values.Get("login_terms"))
 
 		var err error
 		if s.c.UpdateProcessFn != nil {
@@ -1385,8 +1431,15 @@ func (s *SystemTable) GetGenerateForm(ctx *context.Context) (generateTool Table)
 	// General options
 	// ================================
 
+	// the connection and table can be filled in by the query, e.g. the jump from the dbexplorer plugin.
+	var (
+		queryConn  = ctx.Query("conn")
+		queryTable = ctx.Query("table")
+	)
+
 	formList.AddField(lgWithScore("connection", "tool"), "conn", db.Varchar, form.SelectSingle).
 		FieldOptions(ops).
+		FieldDefault(queryConn).
 		FieldOnChooseAjax("table", "/tool/choose/conn",
 			func(ctx *context.Context) (success bool, msg string, data interface{}) {
 				connName := ctx.FormValue("value")
@@ -1451,6 +1504,13 @@ func (s *SystemTable) GetGenerateForm(ctx *context.Context) (generateTool Table)
 				return true, "ok", [][]string{headName, fieldName, dbTypeList, formTypeList}
 			}, template.HTML(utils.ParseText("choose_table_ajax", tmpls["choose_table_ajax"], nil)),
 			`"conn":$('.conn').val(),`)
+	if queryConn != "" && queryTable != "" {
+		formList.FieldOptions(types.FieldOptions{{Text: queryTable, Value: queryTable}}).
+			FieldDefault(queryTable)
+		formList.AddJS(`$(function () {
+	$("select.table").trigger("select2:select");
+});`)
+	}
 	formList.AddField(lgWithScore("package", "tool"), "package", db.Varchar, form.Text).FieldDefault("tables")
 	formList.AddField(lgWithScore("primarykey", "tool"), "pk", db.Varchar, form.Text).FieldDefault("id")
 
@@ -0,0 +1,202 @@
+package table
+
+import (
+	dbsql "database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// ErrGridNotSupported is returned by the grid edit of the tables whose data
+// are not from the database.
+var ErrGridNotSupported = errors.New("grid edit is not supported by the table")
+
+// GridTable is a table supporting the grid edit, implemented by DefaultTable.
+type GridTable interface {
+	GridFields() types.FieldList
+	DiffGrid(rows []GridRow) ([]GridRow, bool, error)
+	CommitGrid(ctx *context.Context, rows []GridRow) ([]GridRow, error)
+}
+
+// GridRow is a row of the grid edit, the values are keyed by the field names.
+type GridRow struct {
+	ID      string            `json:"id"`
+	Values  map[string]string `json:"values"`
+	Changes []GridChange      `json:"changes"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// GridChange is a changed cell of a grid row.
+type GridChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// GridFields return the fields can be edited by the grid, which are the
+// editable fields of the info panel except the primary key. The tables with
+// an UpdateFn are not supported, as their updates can not be rolled back.
+func (tb *DefaultTable) GridFields() types.FieldList {
+	fields := make(types.FieldList, 0)
+	if !tb.getDataFromDB() || tb.Form.Table == "" || tb.Form.UpdateFn != nil {
+		return fields
+	}
+	columns, _ := tb.getColumns(tb.Form.Table)
+	for _, field := range tb.Info.FieldList {
+		if field.EditAble && !field.Hide && field.Field != tb.PrimaryKey.Name &&
+			!field.Joins.Valid() && modules.InArray(columns, field.Field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// DiffGrid check the rows and fill in the changes against the current values,
+// the second return value reports whether all the rows are valid.
+func (tb *DefaultTable) DiffGrid(rows []GridRow) ([]GridRow, bool, error) {
+	fields := tb.GridFields()
+	if len(fields) == 0 {
+		return nil, false, ErrGridNotSupported
+	}
+
+	ids := make([]interface{}, 0, len(rows))
+	for _, row := range rows {
+		if row.ID != "" {
+			ids = append(ids, row.ID)
+		}
+	}
+
+	current := make(map[string]map[string]interface{})
+	if len(ids) > 0 {
+		columns := []string{tb.PrimaryKey.Name}
+		for _, field := range fields {
+			columns = append(columns, field.Field)
+		}
+		res, err := tb.sql().Table(tb.Form.Table).Select(columns...).WhereIn(tb.PrimaryKey.Name, ids).All()
+		if err != nil {
+			return nil, false, err
+		}
+		for _, item := range res {
+			current[gridCell(item[tb.PrimaryKey.Name])] = item
+		}
+	}
+
+	valid := true
+	seen := make(map[string]bool)
+	result := make([]GridRow, len(rows))
+	for i, row := range rows {
+		row.Changes = make([]GridChange, 0)
+		row.Error = ""
+		result[i] = row
+
+		old, ok := current[row.ID]
+		switch {
+		case row.ID == "":
+			row.Error = language.Get("grid row without primary key")
+		case !ok:
+			row.Error = language.Get("grid row not found")
+		case seen[row.ID]:
+			row.Error = language.Get("grid row duplicated")
+		}
+		seen[row.ID] = true
+		if row.Error != "" {
+			valid = false
+			result[i] = row
+			continue
+		}
+
+		values := form.Values{
+			tb.PrimaryKey.Name:         []string{row.ID},
+			form.PostIsSingleUpdateKey: []string{"1"},
+		}
+		for name, value := range row.Values {
+			field := fields.GetFieldByFieldName(name)
+			if field.Field == "" {
+				row.Error = fmt.Sprintf(language.Get("grid field %s can not be edited"), name)
+				break
+			}
+			if formField := tb.Form.FieldList.FindByFieldName(name); formField != nil &&
+				formField.Must && value == "" {
+				row.Error = fmt.Sprintf(language.Get("grid field %s is required"), language.Get(field.Head))
+				break
+			}
+			values.Add(name, value)
+			if oldValue := gridCell(old[name]); oldValue != value {
+				row.Changes = append(row.Changes, GridChange{Field: name, Old: oldValue, New: value})
+			}
+		}
+		// the validator is run as the single update of the list page does.
+		if row.Error == "" && len(row.Changes) > 0 {
+			if err := tb.Form.Validate(values); err != nil {
+				row.Error = err.Error()
+			}
+		}
+		if row.Error != "" {
+			valid = false
+		}
+		result[i] = row
+	}
+
+	return result, valid, nil
+}
+
+// CommitGrid check the rows again and update the changed rows by UpdateData
+// in one transaction, so the validators and the hooks of the form are applied
+// and either all the rows are updated or none of them. The post hooks of the
+// rows are run after the transaction with its result.
+func (tb *DefaultTable) CommitGrid(ctx *context.Context, rows []GridRow) ([]GridRow, error) {
+	rows, valid, err := tb.DiffGrid(rows)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return rows, errors.New(language.Get("grid has invalid rows"))
+	}
+
+	finishes := make([]func(error), 0, len(rows))
+	_, err = tb.sql().WithTransaction(func(tx *dbsql.Tx) (error, map[string]interface{}) {
+		for i, row := range rows {
+			if len(row.Changes) == 0 {
+				continue
+			}
+			values := form.Values{
+				tb.PrimaryKey.Name:         []string{row.ID},
+				form.PostIsSingleUpdateKey: []string{"1"},
+			}
+			for _, change := range row.Changes {
+				values.Add(change.Field, change.New)
+			}
+			finish, err := tb.updateData(ctx, values, tx)
+			finishes = append(finishes, finish)
+			if err != nil {
+				rows[i].Error = err.Error()
+				return fmt.Errorf("%s %s: %v", tb.PrimaryKey.Name, row.ID, err), nil
+			}
+		}
+		return nil, nil
+	})
+	for _, finish := range finishes {
+		finish(err)
+	}
+	return rows, err
+}
+
+// gridCell format the database value as the text of a cell.
+func gridCell(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(value)
+	case time.Time:
+		return value.Format("2006-01-02 15:04:05")
+	default:
+		return fmt.Sprint(value)
+	}
+}
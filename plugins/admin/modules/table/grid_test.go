@@ -0,0 +1,138 @@
+package table
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	_ "github.com/purpose168/GoAdmin/modules/db/drivers/sqlite"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/template/types"
+	form2 "github.com/purpose168/GoAdmin/template/types/form"
+)
+
+func TestGridCell(t *testing.T) {
+	assert.Equal(t, gridCell(nil), "")
+	assert.Equal(t, gridCell([]byte("abc")), "abc")
+	assert.Equal(t, gridCell(int64(12)), "12")
+	assert.Equal(t, gridCell(1.5), "1.5")
+	assert.Equal(t, gridCell(time.Date(2026, 10, 16, 8, 30, 0, 0, time.UTC)), "2026-10-16 08:30:00")
+}
+
+func newGridTable(t *testing.T) *DefaultTable {
+	newBenchTable()
+
+	conn := db.GetSqliteDB()
+	conn.InitDB(config.DatabaseList{"default": {
+		Driver: db.DriverSqlite,
+		File:   filepath.Join(t.TempDir(), "grid.db"),
+	}})
+	for _, statement := range []string{
+		"create table posts (id integer primary key autoincrement, title text check (length(title) <= 10), views integer)",
+		"insert into posts (title, views) values ('a', 1), ('b', 2), ('c', 3), ('d', 4)",
+	} {
+		_, err := conn.Exec(statement)
+		assert.Equal(t, err, nil)
+	}
+
+	tb := NewDefaultTable(nil, DefaultConfigWithDriver(db.DriverSqlite)).(*DefaultTable)
+	tb.dbObj = conn
+	tb.GetInfo().SetTable("posts").
+		AddField("ID", "id", db.Int).
+		AddField("Title", "title", db.Varchar).FieldEditAble().
+		AddField("Views", "views", db.Int).FieldEditAble()
+	tb.GetForm().SetTable("posts").
+		AddField("Title", "title", db.Varchar, form2.Text).FieldMust().
+		AddField("Views", "views", db.Int, form2.Number)
+	return tb
+}
+
+func gridTitles(t *testing.T, tb *DefaultTable) []string {
+	rows, err := tb.sql().Table("posts").OrderBy("id", "asc").All()
+	assert.Equal(t, err, nil)
+	titles := make([]string, len(rows))
+	for i, row := range rows {
+		titles[i] = gridCell(row["title"])
+	}
+	return titles
+}
+
+func TestDefaultTable_DiffGrid(t *testing.T) {
+	tb := newGridTable(t)
+	assert.Equal(t, len(tb.GridFields()), 2)
+
+	rows, valid, err := tb.DiffGrid([]GridRow{
+		{ID: "1", Values: map[string]string{"title": "a2", "views": "1"}},
+		{ID: "2", Values: map[string]string{"title": "b"}},
+		{ID: "9", Values: map[string]string{"title": "x"}},
+		{ID: "1", Values: map[string]string{"title": "x"}},
+		{ID: "3", Values: map[string]string{"title": ""}},
+		{ID: "4", Values: map[string]string{"id": "5"}},
+		{ID: "", Values: map[string]string{"title": "x"}},
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, valid, false)
+	assert.Equal(t, rows[0].Error, "")
+	assert.Equal(t, rows[0].Changes, []GridChange{{Field: "title", Old: "a", New: "a2"}})
+	assert.Equal(t, rows[1].Error, "")
+	assert.Equal(t, len(rows[1].Changes), 0)
+	assert.Equal(t, rows[2].Error, language.Get("grid row not found"))
+	assert.Equal(t, rows[3].Error, language.Get("grid row duplicated"))
+	assert.Equal(t, rows[4].Error != "", true)
+	assert.Equal(t, rows[5].Error != "", true)
+	assert.Equal(t, rows[6].Error, language.Get("grid row without primary key"))
+
+	// the tables with an UpdateFn are not supported.
+	tb.GetForm().SetUpdateFn(func(values form.Values) error { return nil })
+	_, _, err = tb.DiffGrid(nil)
+	assert.Equal(t, err, ErrGridNotSupported)
+}
+
+func TestDefaultTable_CommitGrid(t *testing.T) {
+	tb := newGridTable(t)
+
+	var saved []error
+	tb.GetForm().SetPostSaveCtx(func(hc *types.FormHookContext, err error) {
+		saved = append(saved, err)
+	})
+
+	// nothing is written when a row is invalid.
+	_, err := tb.CommitGrid(nil, []GridRow{
+		{ID: "1", Values: map[string]string{"title": "a2"}},
+		{ID: "9", Values: map[string]string{"title": "x"}},
+	})
+	assert.Equal(t, err != nil, true)
+	assert.Equal(t, gridTitles(t, tb), []string{"a", "b", "c", "d"})
+	assert.Equal(t, len(saved), 0)
+
+	// the rows updated before a failed one are rolled back.
+	rows, err := tb.CommitGrid(nil, []GridRow{
+		{ID: "1", Values: map[string]string{"title": "a2"}},
+		{ID: "2", Values: map[string]string{"title": "a title too long"}},
+		{ID: "3", Values: map[string]string{"title": "c2"}},
+	})
+	assert.Equal(t, err != nil, true)
+	assert.Equal(t, rows[1].Error != "", true)
+	assert.Equal(t, gridTitles(t, tb), []string{"a", "b", "c", "d"})
+	assert.Equal(t, len(saved), 2)
+	assert.Equal(t, saved[0] != nil, true)
+
+	saved = nil
+	rows, err = tb.CommitGrid(nil, []GridRow{
+		{ID: "1", Values: map[string]string{"title": "a2"}},
+		{ID: "2", Values: map[string]string{"title": "b"}},
+		{ID: "3", Values: map[string]string{"title": "c2", "views": "30"}},
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(rows), 3)
+	assert.Equal(t, gridTitles(t, tb), []string{"a2", "b", "c2", "d"})
+	assert.Equal(t, saved, []error{nil, nil})
+
+	views, err := tb.sql().Table("posts").Where("id", "=", 3).First()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, views["views"], int64(30))
+}
@@ -0,0 +1,40 @@
+package table
+
+import (
+	"github.com/purpose168/GoAdmin/plugins/admin/modules"
+)
+
+// source return the source of the list queries, the table or the query of the
+// info panel as a derived table named as the table.
+func (tb *DefaultTable) source(delimiter, delimiter2 string) string {
+	table := modules.Delimiter(delimiter, delimiter2, tb.Info.Table)
+	if tb.Info.Query == "" {
+		return table
+	}
+	return "(" + tb.Info.Query + ") " + table
+}
+
+// sourceArgs prepend the args of the query of the info panel to the args of
+// the list queries.
+func (tb *DefaultTable) sourceArgs(args []interface{}) []interface{} {
+	if len(tb.Info.QueryArgs) == 0 {
+		return args
+	}
+	return append(append(make([]interface{}, 0, len(tb.Info.QueryArgs)+len(args)), tb.Info.QueryArgs...), args...)
+}
+
+// infoColumns return the columns of the list. The columns of a query are the
+// fields of the info panel, as the query can not be described like a table.
+func (tb *DefaultTable) infoColumns() Columns {
+	if tb.Info.Query == "" {
+		columns, _ := tb.getColumns(tb.Info.Table)
+		return columns
+	}
+	columns := make(Columns, 0, len(tb.Info.FieldList))
+	for _, field := range tb.Info.FieldList {
+		if !field.Joins.Valid() && !modules.InArray(columns, field.Field) {
+			columns = append(columns, field.Field)
+		}
+	}
+	return columns
+}
@@ -0,0 +1,51 @@
+package table
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+)
+
+func TestDefaultTable_GetData_Query(t *testing.T) {
+	tb := newBenchTable()
+	tb.GetInfo().SetQuery("select * from users where status = ?", "active")
+
+	conn := tb.dbObj.(*benchConnection)
+	var (
+		queries []string
+		args    [][]interface{}
+	)
+	conn.onQuery = func(query string, a []interface{}) {
+		queries = append(queries, query)
+		args = append(args, a)
+	}
+	defer func() { conn.onQuery = nil }()
+
+	u, _ := url.Parse("/admin/info/users?__page=2&__pageSize=10&name=foo")
+	info, err := tb.GetData(nil, parameter.GetParam(u, 10))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(info.InfoList), 20)
+
+	// the columns are the fields, the query is not described.
+	for _, query := range queries {
+		assert.Equal(t, strings.HasPrefix(query, "show columns"), false)
+	}
+	assert.Equal(t, len(queries), 2)
+	for i, query := range queries {
+		assert.Equal(t, strings.Contains(query, "from (select * from users where status = ?) `users`"), true)
+		assert.Equal(t, args[i][0], "active")
+		assert.Equal(t, args[i][1], "foo")
+	}
+	assert.Equal(t, strings.Contains(queries[0], "LIMIT ? OFFSET ?"), true)
+	assert.Equal(t, args[0][2:], []interface{}{10, 10})
+
+	// the export of all the rows queries the same source.
+	queries, args = nil, nil
+	_, err = tb.GetData(nil, parameter.GetParam(u, 10).WithIsAll(true))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.Contains(queries[0], "from (select * from users where status = ?) `users`"), true)
+	assert.Equal(t, args[0][0], "active")
+}
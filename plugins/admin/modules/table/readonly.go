@@ -0,0 +1,61 @@
+package table
+
+import (
+	"fmt"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// hideWriteButtons hides the new, edit and delete buttons and the row
+// selector of the batch operations of a read only table.
+func (tb *DefaultTable) hideWriteButtons() {
+	tb.Info.HideNewButton().HideEditButton().HideDeleteButton().HideRowSelector()
+	tb.Detail.HideEditButton().HideDeleteButton()
+}
+
+// keyless reports whether the table is read only or the list of a query, and
+// the primary key is not a column of the table, as most views, so the primary
+// key is neither queried nor used to sort the rows.
+func (tb *DefaultTable) keyless(columns Columns) bool {
+	return (tb.ReadOnly || tb.Info.Query != "") && len(columns) > 0 &&
+		!modules.InArray(columns, tb.PrimaryKey.Name)
+}
+
+// sortField return the default sort field of the rows, the primary key or the
+// first column of a keyless table.
+func (tb *DefaultTable) sortField(columns Columns) string {
+	if tb.keyless(columns) {
+		return columns[0]
+	}
+	return tb.PrimaryKey.Name
+}
+
+// RefreshViewHandler return the handler of an action refreshing the
+// materialized view of the table, e.g.:
+//
+//	info.AddButton(ctx, "Refresh", icon.Refresh, action.Ajax("stats_refresh", tb.RefreshViewHandler(false)))
+//
+// Concurrently refreshes the view without locking the reads, which requires a
+// unique index of the view. Only postgresql supports materialized views.
+func (tb *DefaultTable) RefreshViewHandler(concurrently bool) types.Handler {
+	return func(ctx *context.Context) (bool, string, interface{}) {
+		if tb.connectionDriver != db.DriverPostgresql {
+			return false, fmt.Sprintf("materialized views are not supported by %s", tb.connectionDriver), nil
+		}
+		statement := "REFRESH MATERIALIZED VIEW "
+		if concurrently {
+			statement += "CONCURRENTLY "
+		}
+		statement += modules.Delimiter(tb.db().GetDelimiter(), tb.db().GetDelimiter2(), tb.Info.Table)
+		if _, err := tb.db().ExecWithConnection(tb.connection, statement); err != nil {
+			logger.ErrorCtx(ctx, "refresh view %s error: %v", tb.Info.Table, err)
+			return false, err.Error(), nil
+		}
+		return true, language.Get("success"), nil
+	}
+}
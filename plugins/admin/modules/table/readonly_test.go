@@ -0,0 +1,54 @@
+package table
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+)
+
+func TestDefaultTable_ReadOnly(t *testing.T) {
+	cfg := DefaultConfigWithDriver(db.DriverMysql).SetReadOnly()
+	assert.Equal(t, cfg.CanAdd || cfg.Editable || cfg.Deletable, false)
+
+	tb := NewDefaultTable(nil, cfg).(*DefaultTable)
+	assert.Equal(t, tb.GetReadOnly(), true)
+	assert.Equal(t, tb.GetInfo().IsHideNewButton, true)
+	assert.Equal(t, tb.GetInfo().IsHideEditButton, true)
+	assert.Equal(t, tb.GetInfo().IsHideDeleteButton, true)
+	assert.Equal(t, tb.GetInfo().IsHideRowSelector, true)
+	assert.Equal(t, tb.Copy().GetReadOnly(), true)
+
+	ok, msg, _ := tb.RefreshViewHandler(false)(nil)
+	assert.Equal(t, ok, false)
+	assert.Equal(t, msg, "materialized views are not supported by mysql")
+}
+
+func TestDefaultTable_GetData_Keyless(t *testing.T) {
+	tb := newBenchTable()
+	tb.ReadOnly = true
+
+	// the view has no id column.
+	conn := tb.dbObj.(*benchConnection)
+	conn.columns = conn.columns[1:]
+	var queries []string
+	conn.onQuery = func(query string, _ []interface{}) { queries = append(queries, query) }
+	defer func() { conn.onQuery = nil }()
+
+	u, _ := url.Parse("/admin/info/users?__page=1&__pageSize=10")
+	_, err := tb.GetData(nil, parameter.GetParam(u, 10))
+	assert.Equal(t, err, nil)
+	sorted := false
+	for _, query := range queries {
+		if strings.HasPrefix(query, "show columns") {
+			continue
+		}
+		assert.Equal(t, strings.Contains(query, "`users`.`id`"), false)
+		sorted = sorted || strings.Contains(query, "order by `users`.`name`")
+	}
+	assert.Equal(t, sorted, true)
+	assert.Equal(t, tb.GetInfo().IsHideDetailButton, true)
+}
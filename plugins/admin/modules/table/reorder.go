@@ -0,0 +1,88 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+)
+
+// ErrReorderNotSupported is returned by the row reorder of the tables whose
+// data are not from the database.
+var ErrReorderNotSupported = errors.New("row reorder is not supported by the table")
+
+// ReorderTable is a table supporting the row reorder, implemented by
+// DefaultTable.
+type ReorderTable interface {
+	ReorderRows(ctx *context.Context, ids []string, desc bool) error
+}
+
+// ReorderRows saves the new order of the rows dragged in the list page. Only
+// the order values of the given rows, which are the visible rows of the
+// current filter, are exchanged so that the other rows keep their places.
+// The first row takes the smallest value, or the largest when the list is
+// sorted descending by the order field.
+func (tb *DefaultTable) ReorderRows(ctx *context.Context, ids []string, desc bool) error {
+	field := tb.Info.RowReorderField
+	if field == "" || !tb.getDataFromDB() || tb.Info.Table == "" {
+		return ErrReorderNotSupported
+	}
+
+	args := make([]interface{}, len(ids))
+	for k, id := range ids {
+		args[k] = id
+	}
+	rows, err := tb.sql().Table(tb.Info.Table).Select(tb.PrimaryKey.Name, field).
+		WhereIn(tb.PrimaryKey.Name, args).All()
+	if err != nil {
+		return err
+	}
+
+	var (
+		current = make(map[string]int64, len(rows))
+		orders  = make([]int64, 0, len(rows))
+	)
+	for _, row := range rows {
+		order, err := strconv.ParseInt(fmt.Sprintf("%v", row[field]), 10, 64)
+		if err != nil {
+			order = 0
+		}
+		current[fmt.Sprintf("%v", row[tb.PrimaryKey.Name])] = order
+		orders = append(orders, order)
+	}
+	if len(current) != len(ids) {
+		return fmt.Errorf("wrong %s", tb.PrimaryKey.Name)
+	}
+
+	sort.Slice(orders, func(i, j int) bool { return orders[i] < orders[j] })
+	// The rows never sorted before share the same value, which can not be
+	// exchanged, so they are numbered from the smallest one.
+	for k := 1; k < len(orders); k++ {
+		if orders[k] <= orders[k-1] {
+			orders[k] = orders[k-1] + 1
+		}
+	}
+	if desc {
+		for i, j := 0, len(orders)-1; i < j; i, j = i+1, j-1 {
+			orders[i], orders[j] = orders[j], orders[i]
+		}
+	}
+
+	for k, id := range ids {
+		if current[id] == orders[k] {
+			continue
+		}
+		values := form.Values{
+			tb.PrimaryKey.Name:         []string{id},
+			form.PostIsSingleUpdateKey: []string{"1"},
+			field:                      []string{strconv.FormatInt(orders[k], 10)},
+		}
+		if err := tb.UpdateData(ctx, values); err != nil {
+			return fmt.Errorf("%s %s: %v", tb.PrimaryKey.Name, id, err)
+		}
+	}
+	return nil
+}
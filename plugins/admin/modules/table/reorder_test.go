@@ -0,0 +1,63 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	_ "github.com/purpose168/GoAdmin/template/types/display"
+)
+
+func TestDefaultTable_ReorderRows(t *testing.T) {
+	tb := newBenchTable()
+	tb.GetForm().SetTable("users")
+	conn := tb.dbObj.(*benchConnection)
+
+	rows := conn.rows
+	defer func() {
+		conn.rows = rows
+		conn.onQuery = nil
+	}()
+
+	assert.Equal(t, tb.ReorderRows(nil, []string{"1", "2"}, false), ErrReorderNotSupported)
+	tb.GetInfo().EnableRowReorder("status")
+
+	// updates records the new order of the updated rows, keyed by the id.
+	var updates map[string]string
+	conn.onQuery = func(query string, args []interface{}) {
+		if strings.HasPrefix(query, "update") {
+			id := fmt.Sprintf("%v", args[len(args)-1])
+			for _, arg := range args[:len(args)-1] {
+				if arg != id {
+					updates[id] = fmt.Sprintf("%v", arg)
+				}
+			}
+		}
+	}
+
+	// The rows 1, 2, 3 are sorted as 10, 20, 30 and dragged to 3, 1, 2.
+	conn.rows = []map[string]interface{}{
+		{"id": int64(1), "status": int64(10)},
+		{"id": int64(2), "status": int64(20)},
+		{"id": int64(3), "status": int64(30)},
+	}
+	updates = map[string]string{}
+	assert.Equal(t, tb.ReorderRows(nil, []string{"3", "1", "2"}, false), nil)
+	assert.Equal(t, updates, map[string]string{"3": "10", "1": "20", "2": "30"})
+
+	updates = map[string]string{}
+	assert.Equal(t, tb.ReorderRows(nil, []string{"3", "1", "2"}, true), nil)
+	assert.Equal(t, updates, map[string]string{"1": "20", "2": "10"})
+
+	// The rows never sorted are numbered from the smallest value.
+	updates = map[string]string{}
+	conn.rows = []map[string]interface{}{
+		{"id": int64(5), "status": int64(0)},
+		{"id": int64(6), "status": int64(0)},
+	}
+	assert.Equal(t, tb.ReorderRows(nil, []string{"6", "5"}, false), nil)
+	assert.Equal(t, updates, map[string]string{"5": "1"})
+
+	assert.Equal(t, tb.ReorderRows(nil, []string{"6", "5", "9"}, false).Error(), "wrong id")
+}
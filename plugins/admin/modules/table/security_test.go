@@ -0,0 +1,195 @@
+package table
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// injectionPayloads are the values tried in every parameter of the list page,
+// none of them should be found in the query text.
+var injectionPayloads = []string{
+	"id;drop table users",
+	"id` or 1=1 -- ",
+	"(select password from goadmin_users)",
+	"1 union select * from goadmin_users",
+	"asc,(select sleep(5))",
+}
+
+func TestDefaultTable_GetData_Injection(t *testing.T) {
+	tb := newBenchTable()
+	conn := tb.dbObj.(*benchConnection)
+
+	var queries []string
+	conn.onQuery = func(query string, _ []interface{}) {
+		queries = append(queries, query)
+	}
+	defer func() { conn.onQuery = nil }()
+
+	for _, payload := range injectionPayloads {
+		escaped := url.QueryEscape(payload)
+		for _, query := range []string{
+			parameter.Sort + "=" + escaped,
+			parameter.SortType + "=" + escaped,
+			escaped + "=1",
+			"name" + parameter.FilterParamOperatorSuffix + "=" + escaped + "&name=1",
+			escaped + parameter.FilterParamJoinInfix + "name=1",
+			"roles" + parameter.FilterParamJoinInfix + escaped + "=1",
+			escaped + parameter.FilterRangeParamStartSuffix + "=1",
+			"name" + parameter.FilterParamCountInfix + escaped + "=1",
+			parameter.Columns + "=" + escaped,
+			"name=" + escaped,
+		} {
+			queries = queries[:0]
+			u, _ := url.Parse("/admin/info/users?" + query)
+			if _, err := tb.GetData(nil, parameter.GetParam(u, 20)); err != nil {
+				t.Fatalf("%s: %v", query, err)
+			}
+			for _, q := range queries {
+				if strings.Contains(q, payload) {
+					t.Fatalf("%s: the payload is found in the query %s", query, q)
+				}
+			}
+		}
+	}
+}
+
+func TestDefaultTable_GetData_FilterJoin(t *testing.T) {
+	tb := newBenchTable()
+	conn := tb.dbObj.(*benchConnection)
+
+	var (
+		queries []string
+		args    []interface{}
+	)
+	conn.onQuery = func(query string, a []interface{}) {
+		queries = append(queries, query)
+		args = append(args, a...)
+	}
+	defer func() { conn.onQuery = nil }()
+
+	u, _ := url.Parse("/admin/info/users?roles" + parameter.FilterParamJoinInfix + "name=admin&__sort_type=ASC")
+	_, err := tb.GetData(nil, parameter.GetParam(u, 20))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.Contains(strings.Join(queries, "\n"), "roles.`name` = ?"), true)
+	assert.Equal(t, strings.Contains(strings.Join(queries, "\n"), "asc LIMIT"), true)
+	assert.Equal(t, args[0], "admin")
+}
+
+func TestDefaultTable_GetData_FilterHasTag(t *testing.T) {
+	tb := newBenchTable()
+	tb.GetInfo().AddField("Tags", "city", db.Varchar).FieldTagsFilterable()
+	conn := tb.dbObj.(*benchConnection)
+
+	var (
+		queries []string
+		args    []interface{}
+	)
+	conn.onQuery = func(query string, a []interface{}) {
+		queries = append(queries, query)
+		args = append(args, a...)
+	}
+	defer func() { conn.onQuery = nil }()
+
+	u, _ := url.Parse("/admin/info/users?city=go&city" + parameter.FilterParamOperatorSuffix + "=has")
+	_, err := tb.GetData(nil, parameter.GetParam(u, 20))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.Contains(strings.Join(queries, "\n"),
+		"(`users`.`city` = ? or `users`.`city` like ? or `users`.`city` like ? or `users`.`city` like ? or `users`.`city` like ?)"), true)
+	assert.Equal(t, args[:5], []interface{}{"go", "go,%", "%,go", "%,go,%", `%"go"%`})
+}
+
+func TestDefaultTable_GetData_FilterTree(t *testing.T) {
+	tb := newBenchTable()
+	tb.GetInfo().AddField("Role", "role_id", db.Int).FieldFilterable().
+		FieldFilterOptionsFromTree("roles", "name", "id", "parent_id")
+	conn := tb.dbObj.(*benchConnection)
+
+	var (
+		queries []string
+		args    []interface{}
+	)
+	conn.onQuery = func(query string, a []interface{}) {
+		queries = append(queries, query)
+		args = append(args, a...)
+	}
+	defer func() { conn.onQuery = nil }()
+
+	u, _ := url.Parse("/admin/info/users?role_id=1&role_id=2&role_id" + parameter.FilterParamOperatorSuffix + "=tree")
+	_, err := tb.GetData(nil, parameter.GetParam(u, 20))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.Contains(strings.Join(queries, "\n"), "`users`.`role_id` in (?,?)"), true)
+	assert.Equal(t, strings.Contains(strings.Join(queries, "\n"), "`users`.`role_id` = ?"), false)
+	assert.Equal(t, args[:2], []interface{}{"1", "2"})
+}
+
+func TestDefaultTable_GetData_FilterRangeSlider(t *testing.T) {
+	tb := newBenchTable()
+	tb.GetInfo().AddField("Status", "status", db.Int).
+		FieldRangeSliderFilterable(types.FieldRangeSliderParam{Min: 0, Max: 10})
+	conn := tb.dbObj.(*benchConnection)
+
+	var (
+		queries []string
+		args    []interface{}
+	)
+	conn.onQuery = func(query string, a []interface{}) {
+		queries = append(queries, query)
+		args = append(args, a...)
+	}
+	defer func() { conn.onQuery = nil }()
+
+	u, _ := url.Parse("/admin/info/users?status=2%3B5&status" + parameter.FilterParamOperatorSuffix + "=between")
+	_, err := tb.GetData(nil, parameter.GetParam(u, 20))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.Contains(strings.Join(queries, "\n"), "`users`.`status` between ? and ?"), true)
+	assert.Equal(t, args[:2], []interface{}{float64(2), float64(5)})
+
+	// the whole range is not a condition.
+	queries = nil
+	u, _ = url.Parse("/admin/info/users?status=0%3B10&status" + parameter.FilterParamOperatorSuffix + "=between")
+	_, err = tb.GetData(nil, parameter.GetParam(u, 20))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.Contains(strings.Join(queries, "\n"), "between"), false)
+}
+
+func TestDefaultTable_GetData_FilterList(t *testing.T) {
+	tb := newBenchTable()
+	tb.GetInfo().AddField("Phone", "phone", db.Varchar).FieldListFilterable()
+	conn := tb.dbObj.(*benchConnection)
+
+	var (
+		queries []string
+		args    []interface{}
+	)
+	conn.onQuery = func(query string, a []interface{}) {
+		queries = append(queries, query)
+		args = append(args, a...)
+	}
+	defer func() { conn.onQuery = nil }()
+
+	u, _ := url.Parse("/admin/info/users?phone=" + url.QueryEscape("13800000001\n13800000002, 13800000001") +
+		"&phone" + parameter.FilterParamOperatorSuffix + "=list")
+	_, err := tb.GetData(nil, parameter.GetParam(u, 20))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.Contains(strings.Join(queries, "\n"), "(`users`.`phone` in (?,?))"), true)
+	assert.Equal(t, args[:2], []interface{}{"13800000001", "13800000002"})
+
+	// the long lists are split into several in conditions.
+	values := make([]string, types.FilterListChunkSize+1)
+	for i := range values {
+		values[i] = strconv.Itoa(i)
+	}
+	queries = nil
+	u, _ = url.Parse("/admin/info/users?phone=" + url.QueryEscape(strings.Join(values, "\n")) +
+		"&phone" + parameter.FilterParamOperatorSuffix + "=list")
+	_, err = tb.GetData(nil, parameter.GetParam(u, 20))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.Contains(strings.Join(queries, "\n"), ") or `users`.`phone` in (?))"), true)
+}
@@ -2,6 +2,7 @@ package table
 
 import (
 	"html/template"
+	"sort"
 	"sync"
 	"sync/atomic"
 
@@ -18,11 +19,46 @@ type Generator func(ctx *context.Context) Table
 
 type GeneratorList map[string]Generator
 
+// generatorLock guards the generator lists, which are shared by the admin
+// plugin, the handlers and the guard, and can be changed after Use.
+var generatorLock sync.RWMutex
+
 func (g GeneratorList) Add(key string, gen Generator) {
+	generatorLock.Lock()
+	defer generatorLock.Unlock()
 	g[key] = gen
 }
 
+// Get return the generator of the key.
+func (g GeneratorList) Get(key string) (Generator, bool) {
+	generatorLock.RLock()
+	defer generatorLock.RUnlock()
+	gen, ok := g[key]
+	return gen, ok
+}
+
+// Remove remove the generator of the key.
+func (g GeneratorList) Remove(key string) {
+	generatorLock.Lock()
+	defer generatorLock.Unlock()
+	delete(g, key)
+}
+
+// Keys return the sorted keys of the generators.
+func (g GeneratorList) Keys() []string {
+	generatorLock.RLock()
+	keys := make([]string, 0, len(g))
+	for key := range g {
+		keys = append(keys, key)
+	}
+	generatorLock.RUnlock()
+	sort.Strings(keys)
+	return keys
+}
+
 func (g GeneratorList) Combine(list GeneratorList) GeneratorList {
+	generatorLock.Lock()
+	defer generatorLock.Unlock()
 	for key, gen := range list {
 		if _, ok := g[key]; !ok {
 			g[key] = gen
@@ -32,6 +68,8 @@ func (g GeneratorList) Combine(list GeneratorList) GeneratorList {
 }
 
 func (g GeneratorList) CombineAll(gens []GeneratorList) GeneratorList {
+	generatorLock.Lock()
+	defer generatorLock.Unlock()
 	for _, list := range gens {
 		for key, gen := range list {
 			if _, ok := g[key]; !ok {
@@ -53,6 +91,7 @@ type Table interface {
 	GetCanAdd() bool
 	GetEditable() bool
 	GetDeletable() bool
+	GetReadOnly() bool
 	GetExportable() bool
 
 	GetPrimaryKey() PrimaryKey
@@ -87,6 +126,7 @@ type BaseTable struct {
 	OnlyDetail     bool
 	OnlyNewForm    bool
 	OnlyUpdateForm bool
+	ReadOnly       bool
 	PrimaryKey     PrimaryKey
 }
 
@@ -128,6 +168,7 @@ func (base *BaseTable) GetPrimaryKey() PrimaryKey { return base.PrimaryKey }
 func (base *BaseTable) GetEditable() bool         { return base.Editable }
 func (base *BaseTable) GetDeletable() bool        { return base.Deletable }
 func (base *BaseTable) GetExportable() bool       { return base.Exportable }
+func (base *BaseTable) GetReadOnly() bool         { return base.ReadOnly }
 func (base *BaseTable) GetOnlyInfo() bool         { return base.OnlyInfo }
 func (base *BaseTable) GetOnlyDetail() bool       { return base.OnlyDetail }
 func (base *BaseTable) GetOnlyNewForm() bool      { return base.OnlyNewForm }
@@ -145,6 +186,8 @@ func (base *BaseTable) GetPaginator(ctx *context.Context, size int, params param
 		Size:         size,
 		Param:        params,
 		PageSizeList: base.Info.GetPageSizeList(),
+		NoCount:      params.IsNoCount(base.Info.IsHideTotal),
+		CountToggle:  true,
 	}).SetExtraInfo(eh)
 }
 
@@ -155,6 +198,7 @@ type PanelInfo struct {
 	Paginator      types.PaginatorAttribute `json:"-"`
 	Title          string                   `json:"title"`
 	Description    string                   `json:"description"`
+	Query          QueryInfo                `json:"-"`
 }
 
 type FormInfo struct {
@@ -181,6 +225,26 @@ var (
 	lock     sync.Mutex
 )
 
+// FuzzySearchFn return the primary keys of the rows of which the field matches
+// the keyword. It is used by the filters of the operator types.FilterOperatorFuzzy.
+type FuzzySearchFn func(connection, table, field, keyword string) ([]string, error)
+
+var fuzzySearchFn FuzzySearchFn
+
+// SetFuzzySearchFn set the search of the fuzzy filters, the filters fall back
+// to the like operator when it is not set or returns an error.
+func SetFuzzySearchFn(fn FuzzySearchFn) {
+	lock.Lock()
+	defer lock.Unlock()
+	fuzzySearchFn = fn
+}
+
+func getFuzzySearchFn() FuzzySearchFn {
+	lock.Lock()
+	defer lock.Unlock()
+	return fuzzySearchFn
+}
+
 func SetServices(srv service.List) {
 	lock.Lock()
 	defer lock.Unlock()
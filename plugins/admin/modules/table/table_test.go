@@ -0,0 +1,38 @@
+package table
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/context"
+)
+
+func TestGeneratorList(t *testing.T) {
+	var (
+		list = make(GeneratorList)
+		gen  = func(ctx *context.Context) Table { return nil }
+		wg   sync.WaitGroup
+	)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := "t" + strconv.Itoa(i)
+			list.Add(key, gen)
+			_, ok := list.Get(key)
+			assert.Equal(t, ok, true)
+			_ = list.Keys()
+			if i%2 == 0 {
+				list.Remove(key)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, list.Keys(), []string{"t1", "t3", "t5", "t7", "t9"})
+	_, ok := list.Get("t0")
+	assert.Equal(t, ok, false)
+}
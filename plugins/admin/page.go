@@ -0,0 +1,137 @@
+package admin
+
+import (
+	dbsql "database/sql"
+	"errors"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+	"github.com/purpose168/GoAdmin/template/icon"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// Page is a custom page registered by AddPage.
+type Page struct {
+	// Path is the path of the page without the url prefix, such as "/reports/sales".
+	Path string
+	// Title is the title of the menu item and the name of the permission.
+	Title string
+	// Icon is the icon of the menu item, icon.File by default.
+	Icon string
+	// MenuParent is the title of the top level menu of the menu item, which is
+	// created if it does not exist. The menu item is at the top level when it
+	// is empty.
+	MenuParent string
+	// Permission is the slug of the permission of the page, no permission is
+	// created when it is empty.
+	Permission string
+	// Handler returns the panel of the page.
+	Handler types.GetPanelInfoFn
+}
+
+// ErrPageWithoutPath is returned when the page has no path.
+var ErrPageWithoutPath = errors.New("page without path")
+
+// AddPage create the menu item and the permission of the custom page, and
+// update the title and the parent of the existing menu item. The menu item
+// and the permission are written in one transaction, the existing permission
+// is kept. The page is synced when the plugin is initialized if the
+// connection is not ready yet, the route is registered by Engine.AddPage.
+func (admin *Admin) AddPage(page Page) error {
+	if page.Path == "" {
+		return ErrPageWithoutPath
+	}
+	if page.Title == "" {
+		page.Title = page.Path
+	}
+	if page.Icon == "" {
+		page.Icon = icon.File
+	}
+	admin.pages = append(admin.pages, page)
+	if admin.Conn == nil {
+		return nil
+	}
+	return admin.syncPage(page)
+}
+
+func (admin *Admin) syncPage(page Page) error {
+	_, err := db.WithDriver(admin.Conn).WithTransaction(func(tx *dbsql.Tx) (error, map[string]interface{}) {
+		table := func(name string) *db.SQL {
+			return db.WithDriver(admin.Conn).WithTx(tx).Table(name)
+		}
+
+		if page.Permission != "" {
+			perm, err := table("goadmin_permissions").Where("slug", "=", page.Permission).First()
+			if db.CheckError(err, db.QUERY) {
+				return err, nil
+			}
+			if perm == nil {
+				_, err = table("goadmin_permissions").Insert(dialect.H{
+					"name":        page.Title,
+					"slug":        page.Permission,
+					"http_method": "GET",
+					"http_path":   page.Path,
+				})
+				if db.CheckError(err, db.INSERT) {
+					return err, nil
+				}
+			}
+		}
+
+		var parent int64
+		if page.MenuParent != "" {
+			id, err := pageMenu(table, page.MenuParent, icon.Folder, "", 0)
+			if err != nil {
+				return err, nil
+			}
+			parent = id
+		}
+
+		item, err := table("goadmin_menu").Where("uri", "=", page.Path).First()
+		if db.CheckError(err, db.QUERY) {
+			return err, nil
+		}
+		if item == nil {
+			_, err = pageMenu(table, page.Title, page.Icon, page.Path, parent)
+			return err, nil
+		}
+		if db.GetValueFromDatabaseType(db.Varchar, item["title"], false).String() == page.Title &&
+			db.GetValueFromDatabaseType(db.Int, item["parent_id"], false).ToInt64() == parent {
+			return nil, nil
+		}
+		_, err = table("goadmin_menu").Where("id", "=", item["id"]).Update(dialect.H{
+			"title":      page.Title,
+			"parent_id":  parent,
+			"updated_at": time.Now().Format("2006-01-02 15:04:05"),
+		})
+		if db.CheckError(err, db.UPDATE) {
+			return err, nil
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// pageMenu return the id of the menu of the title and the uri under the
+// parent, which is created if it does not exist.
+func pageMenu(table func(string) *db.SQL, title, ico, uri string, parent int64) (int64, error) {
+	item, err := table("goadmin_menu").
+		Where("parent_id", "=", parent).Where("title", "=", title).Where("uri", "=", uri).
+		First()
+	if db.CheckError(err, db.QUERY) {
+		return 0, err
+	}
+	if item == nil {
+		return table("goadmin_menu").InsertAndGetID(dialect.H{
+			"title":       title,
+			"parent_id":   parent,
+			"icon":        ico,
+			"uri":         uri,
+			"order":       0,
+			"header":      "",
+			"plugin_name": "",
+		}, "title", "parent_id", "uri")
+	}
+	return db.GetValueFromDatabaseType(db.Int, item["id"], false).ToInt64(), nil
+}
@@ -0,0 +1,67 @@
+package admin
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dbtest"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddPage(t *testing.T) {
+	page := Page{Path: "/reports/sales", Title: "Sales", MenuParent: "Reports", Permission: "sales_report"}
+
+	execs := func(conn *dbtest.Connection, prefix string) [][]interface{} {
+		var res [][]interface{}
+		for _, call := range conn.Calls() {
+			if call.Exec && strings.HasPrefix(call.Query, prefix) {
+				res = append(res, call.Args)
+			}
+		}
+		return res
+	}
+
+	// the permission, the parent menu and the menu item are created.
+	var lastID int64 = 10
+	conn := dbtest.New(db.DriverMysql).
+		OnExecFn("insert into `goadmin_menu`", func(string, []interface{}) (sql.Result, error) {
+			lastID++
+			return dbtest.Result{LastID: lastID, Affected: 1}, nil
+		}).
+		OnExec("", dbtest.Result{LastID: 1, Affected: 1})
+
+	admin := NewAdmin(table.GeneratorList{})
+	admin.Conn = conn
+	assert.Nil(t, admin.AddPage(page))
+
+	perms := execs(conn, "insert into `goadmin_permissions`")
+	assert.Equal(t, 1, len(perms))
+	assert.True(t, contains(perms[0], "sales_report") && contains(perms[0], "/reports/sales") && contains(perms[0], "GET"))
+	menus := execs(conn, "insert into `goadmin_menu`")
+	assert.Equal(t, 2, len(menus))
+	assert.True(t, contains(menus[0], "Reports") && contains(menus[0], ""))
+	assert.True(t, contains(menus[1], "Sales") && contains(menus[1], "/reports/sales") && contains(menus[1], int64(11)))
+
+	// the existing permission is kept and the existing menu item is renamed.
+	conn = dbtest.New(db.DriverMysql).
+		OnQuery("from `goadmin_permissions`", map[string]interface{}{"id": int64(5), "slug": "sales_report"}).
+		OnQueryFn("from `goadmin_menu`", func(query string, args []interface{}) ([]map[string]interface{}, error) {
+			if len(args) == 1 {
+				return []map[string]interface{}{{"id": int64(12), "parent_id": int64(11), "title": "Sales (old)", "uri": "/reports/sales"}}, nil
+			}
+			return []map[string]interface{}{{"id": int64(11), "parent_id": int64(0), "title": "Reports", "uri": ""}}, nil
+		}).
+		OnExec("", dbtest.Result{Affected: 1})
+
+	admin.Conn = conn
+	assert.Nil(t, admin.AddPage(page))
+	assert.Equal(t, 0, len(execs(conn, "insert into")))
+	updates := execs(conn, "update `goadmin_menu`")
+	assert.Equal(t, 1, len(updates))
+	assert.True(t, contains(updates[0], "Sales") && contains(updates[0], int64(11)))
+
+	assert.Equal(t, ErrPageWithoutPath, admin.AddPage(Page{Title: "Sales"}))
+}
@@ -2,12 +2,20 @@ package admin
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/purpose168/GoAdmin/context"
 	"github.com/purpose168/GoAdmin/modules/auth"
 	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/inline"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/menu"
+	"github.com/purpose168/GoAdmin/modules/notify"
 	"github.com/purpose168/GoAdmin/modules/trace"
 	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins/admin/controller"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
 	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
 	"github.com/purpose168/GoAdmin/template"
 )
@@ -16,11 +24,27 @@ import (
 func (admin *Admin) initRouter() *Admin {
 	app := context.NewApp()
 
-	route := app.Group(config.Prefix(), admin.globalErrorHandler, admin.traceIDMiddleware, admin.themeMiddleware)
+	route := app.Group(config.Prefix(), admin.globalErrorHandler, admin.traceIDMiddleware,
+		admin.slowRequestMiddleware, admin.themeMiddleware)
 
 	// auth
 	route.GET(config.GetLoginUrl(), admin.handler.ShowLogin)
 	route.POST("/signin", admin.handler.Auth)
+	route.POST(auth.QRLoginPath, admin.handler.QRLogin)
+	route.POST(auth.QRLoginStatusPath, admin.handler.QRLoginStatus)
+
+	if admin.saml != nil {
+		route.GET(auth.SAMLMetadataPath, admin.saml.Metadata)
+		route.GET(auth.SAMLLoginPath, admin.saml.Login)
+		route.POST(auth.SAMLACSPath, admin.saml.ACS)
+	}
+
+	if jwt, ok := auth.GetJWTServiceOrNot(admin.Services); ok {
+		route.POST(auth.JWTTokenPath, jwt.TokenHandler(admin.Conn))
+		route.POST(auth.JWTRefreshPath, jwt.RefreshHandler(admin.Conn))
+		route.POST(auth.JWTRevokePath, jwt.RevokeHandler(admin.Conn))
+		route.GET(auth.JWKSPath, jwt.JWKSHandler)
+	}
 
 	// auto install
 	route.GET("/install", admin.handler.ShowInstall)
@@ -46,6 +70,11 @@ func (admin *Admin) initRouter() *Admin {
 
 	// auth
 	authRoute.GET("/logout", admin.handler.Logout)
+	authRoute.GET(inline.Path+":__name", admin.handler.InlineAsset)
+	authRoute.GET("/notifications", admin.handler.Notifications).Name("notifications")
+	authRoute.POST(auth.KeepAlivePath, admin.handler.KeepAlive).Name("keepalive")
+	authRoute.GET(auth.QRLoginApprovePath, admin.handler.ShowQRLoginApprove).Name("qrlogin_approve_show")
+	authRoute.POST(auth.QRLoginApprovePath, admin.handler.QRLoginApprove).Name("qrlogin_approve")
 
 	authPrefixRoute := route.Group("/", auth.Middleware(admin.Conn), admin.guardian.CheckPrefix)
 
@@ -60,6 +89,9 @@ func (admin *Admin) initRouter() *Admin {
 
 	authRoute.GET("/plugins", admin.handler.Plugins).Name("plugins")
 
+	// the embed pages check the menus of the user instead of the permissions.
+	route.GET(menu.EmbedPath(":"+controller.EmbedIDKey), auth.SignedInMiddleware(admin.Conn), admin.handler.ShowEmbed).Name("embed")
+
 	if config.IsNotProductionEnvironment() {
 		authRoute.GET("/plugins/store", admin.handler.PluginStore).Name("plugins_store")
 		authRoute.POST("/plugin/download", admin.handler.PluginDownload).Name("plugin_download")
@@ -82,14 +114,43 @@ func (admin *Admin) initRouter() *Admin {
 
 	authPrefixRoute.POST(formats.Update, admin.guardian.Update, admin.handler.Update).Name("update")
 
+	authPrefixRoute.GET("/definition/:__prefix", admin.handler.ExportDefinition).Name("definition")
+
+	authPrefixRoute.GET("/grid/:__prefix", admin.handler.ShowGridEdit).Name("grid")
+	authPrefixRoute.POST("/grid/preview/:__prefix", admin.guardian.GridPreview, admin.handler.GridPreview).Name("grid_preview")
+	authPrefixRoute.POST("/grid/commit/:__prefix", admin.guardian.GridCommit, admin.handler.GridCommit).Name("grid_commit")
+	authPrefixRoute.GET("/find_replace/:__prefix", admin.guardian.ShowFindReplace, admin.handler.ShowFindReplace).Name("find_replace")
+	authPrefixRoute.POST("/find_replace/preview/:__prefix", admin.guardian.FindReplacePreview, admin.handler.FindReplacePreview).Name("find_replace_preview")
+	authPrefixRoute.POST("/find_replace/apply/:__prefix", admin.guardian.FindReplaceApply, admin.handler.FindReplaceApply).Name("find_replace_apply")
+	authPrefixRoute.POST("/reorder/:__prefix", admin.guardian.RowReorder, admin.handler.RowReorder).Name("reorder")
+	authPrefixRoute.POST("/favorite/:__prefix", admin.guardian.Favorite, admin.handler.Favorite).Name("favorite")
+	authPrefixRoute.GET("/explain/:__prefix", admin.guardian.Explain, admin.handler.ShowExplain).Name("explain")
+	authPrefixRoute.GET("/sync/:__prefix", admin.handler.ShowDataSync).Name("sync")
+	authPrefixRoute.GET("/sync/export/:__prefix", admin.guardian.SyncExport, admin.handler.ExportBundle).Name("sync_export")
+	authPrefixRoute.POST("/sync/preview/:__prefix", admin.guardian.SyncPreview, admin.handler.SyncPreview).Name("sync_preview")
+	authPrefixRoute.POST("/sync/import/:__prefix", admin.guardian.SyncImport, admin.handler.SyncImport).Name("sync_import")
+	authPrefixRoute.POST("/reports/add/:__prefix", admin.guardian.ReportAdd, admin.handler.AddReport).Name("report_add")
+
 	authRoute.GET("/application/info", admin.handler.SystemInfo)
+	authRoute.GET("/erd", admin.handler.ShowERDiagram).Name("erd")
+	authRoute.GET("/search", admin.handler.Search).Name("search")
+	authRoute.GET("/openapi.json", admin.handler.OpenAPI).Name("openapi_json")
+	authRoute.GET("/openapi", admin.handler.ShowOpenAPI).Name("openapi")
+	authRoute.GET("/reports", admin.handler.ShowReports).Name("reports")
+	authRoute.POST("/reports/run", admin.guardian.Report, admin.handler.RunReport).Name("report_run")
+	authRoute.POST("/reports/delete", admin.guardian.Report, admin.handler.DeleteReport).Name("report_delete")
 
 	route.ANY("/operation/:__goadmin_op_id", auth.Middleware(admin.Conn), admin.handler.Operation)
 
 	if config.GetOpenAdminApi() {
 
-		// crud json apis
-		apiRoute := route.Group("/api", auth.Middleware(admin.Conn), admin.guardian.CheckPrefix)
+		// crud json apis, the api clients can use the bearer tokens when the
+		// JWT service is set.
+		apiAuth := auth.Middleware(admin.Conn)
+		if jwt, ok := auth.GetJWTServiceOrNot(admin.Services); ok {
+			apiAuth = jwt.Middleware(admin.Conn)
+		}
+		apiRoute := route.Group("/api", apiAuth, admin.guardian.CheckPrefix)
 		apiRoute.GET("/list/:__prefix", admin.handler.ApiList).Name("api_info")
 		apiRoute.GET("/detail/:__prefix", admin.handler.ApiDetail).Name("api_detail")
 		apiRoute.POST("/delete/:__prefix", admin.guardian.Delete, admin.handler.Delete).Name("api_delete")
@@ -120,6 +181,12 @@ func (admin *Admin) traceIDMiddleware(ctx *context.Context) {
 
 	ctx.SetUserValue(trace.TraceIDKey, traceID)
 	ctx.SetHeader(traceIDHeaderKey, traceID)
+
+	// the statements of the page are listed by the debug panel, and the
+	// slowest ones are logged by the watchdog.
+	if admin.config.Debug || config.GetSlowRequest() > 0 {
+		logger.RecordSQL(ctx)
+	}
 	ctx.Next()
 }
 
@@ -127,6 +194,45 @@ const (
 	traceIDHeaderKey = "x-request-id"
 )
 
+// slowRequestMiddleware is the watchdog of the requests slower than the
+// threshold of the config. A request still running after the threshold is
+// logged and, when the notify is on, its session is warned by a toast. When
+// it ends, it is logged again with the user and the slowest statements.
+func (admin *Admin) slowRequestMiddleware(ctx *context.Context) {
+	threshold := config.GetSlowRequest()
+	if threshold <= 0 {
+		ctx.Next()
+		return
+	}
+
+	var (
+		start   = time.Now()
+		traceID = trace.GetTraceID(ctx)
+		method  = ctx.Method()
+		path    = ctx.Path()
+		key     = notify.SessionKey(ctx)
+	)
+	// the timer runs beside the request, so it only uses the values read
+	// above instead of the ctx.
+	timer := time.AfterFunc(threshold, func() {
+		logger.LogLongRequest(traceID, method, path, time.Since(start))
+		if key != "" && config.GetSlowRequestNotify() {
+			notify.Default().Publish(key, notify.TypeToast, notify.ToastData{
+				Level: notify.LevelWarning,
+				Msg:   language.Get("the request is taking longer than usual"),
+			})
+		}
+	})
+	defer func() {
+		timer.Stop()
+		if elapsed := time.Since(start); elapsed > threshold {
+			user, _ := ctx.User().(models.UserModel)
+			logger.LogSlowRequest(ctx, user.UserName, elapsed)
+		}
+	}()
+	ctx.Next()
+}
+
 func (admin *Admin) themeMiddleware(ctx *context.Context) {
 	theme := ctx.Query(context.ThemeKey)
 
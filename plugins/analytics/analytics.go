@@ -0,0 +1,91 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+// Package analytics provides the opt-in usage analytics of the admin. The
+// page views and the actions are recorded by modules/analytics into the local
+// table goadmin_usage once the plugin is added, and the report page lists the
+// most used tables and the menus not visited, to guide the cleanup of the
+// panels:
+//
+//	eng.AddPlugins(analytics.NewAnalytics())
+package analytics
+
+import (
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/analytics"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/service"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins"
+)
+
+// Analytics is a GoAdmin plugin.
+type Analytics struct {
+	*plugins.Base
+
+	interval time.Duration
+	recorder *analytics.Recorder
+}
+
+// Name is the name of the plugin, also used as the url prefix.
+const Name = "analytics"
+
+// NewAnalytics return an Analytics plugin.
+func NewAnalytics() *Analytics {
+	return &Analytics{
+		Base:     &plugins.Base{PlugName: Name},
+		interval: time.Minute,
+	}
+}
+
+// SetFlushInterval set how often the hits counted in memory are written into
+// the table, default 1 minute.
+func (a *Analytics) SetFlushInterval(interval time.Duration) *Analytics {
+	a.interval = interval
+	return a
+}
+
+// InitPlugin implements Plugin.InitPlugin.
+func (a *Analytics) InitPlugin(srv service.List) {
+	a.InitBase(srv, Name)
+	language.AppendTo(language.CN, cn)
+
+	a.recorder = analytics.NewRecorder(db.GetConnection(srv))
+	analytics.Use(a.recorder)
+	go a.flush()
+
+	a.App = a.initRouter(config.Prefix(), srv)
+}
+
+func (a *Analytics) flush() {
+	for range time.Tick(a.interval) {
+		if err := a.recorder.Flush(); err != nil {
+			logger.Error("flush usage error: ", err)
+		}
+	}
+}
+
+func (a *Analytics) GetIndexURL() string {
+	return config.Url("/" + Name)
+}
+
+func (a *Analytics) IsInstalled() bool {
+	return true
+}
+
+func (a *Analytics) GetInfo() plugins.Info {
+	return plugins.Info{
+		Title:       "Usage Analytics",
+		Name:        Name,
+		Description: "Record the usage of the admin locally and report the most used tables and the unused menus.",
+		Author:      "official",
+		Version:     "v0.0.1",
+		CreateDate:  utils.ParseTime("2026-10-22 00:00:00"),
+		UpdateDate:  utils.ParseTime("2026-10-22 00:00:00"),
+	}
+}
@@ -0,0 +1,107 @@
+package analytics
+
+import (
+	"html/template"
+	"strconv"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/analytics"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	template2 "github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+var periods = []int{7, 30, 90}
+
+// ShowReport show the most used tables and the unused menus of a period, the
+// period is given by the query "days", default 30 days.
+func (a *Analytics) ShowReport(ctx *context.Context) {
+	days, _ := strconv.Atoi(ctx.Query("days"))
+	if days <= 0 {
+		days = 30
+	}
+
+	if err := a.recorder.Flush(); err != nil {
+		logger.Error("flush usage error: ", err)
+	}
+
+	report, err := analytics.Load(db.GetConnection(a.Services), time.Now().AddDate(0, 0, 1-days))
+	if err != nil {
+		a.alert(ctx, err.Error())
+		return
+	}
+
+	comp := template2.Default(ctx)
+
+	tables := template.HTML("<p>" + lg("no usage") + "</p>")
+	if len(report.Tables) > 0 {
+		infos := make([]map[string]types.InfoItem, len(report.Tables))
+		for i, usage := range report.Tables {
+			infos[i] = map[string]types.InfoItem{
+				lg("table"): {Content: template.HTML(`<a href="` + template.HTMLEscapeString(config.Url("/info/"+usage.Prefix)) + `">` +
+					template.HTMLEscapeString(usage.Prefix) + `</a>`)},
+				lg("views"):    {Content: template.HTML(strconv.FormatInt(usage.Views, 10))},
+				lg("actions"):  {Content: template.HTML(strconv.FormatInt(usage.Actions, 10))},
+				lg("users"):    {Content: template.HTML(strconv.Itoa(usage.Users))},
+				lg("last day"): {Content: escape(usage.LastDay)},
+			}
+		}
+		tables = comp.Table().SetThead(types.Thead{
+			{Head: lg("table")},
+			{Head: lg("views")},
+			{Head: lg("actions")},
+			{Head: lg("users")},
+			{Head: lg("last day")},
+		}).SetInfoList(infos).GetContent()
+	}
+
+	menus := template.HTML("<p>" + lg("no unused menus") + "</p>")
+	if len(report.UnusedMenus) > 0 {
+		infos := make([]map[string]types.InfoItem, len(report.UnusedMenus))
+		for i, menu := range report.UnusedMenus {
+			infos[i] = map[string]types.InfoItem{
+				lg("menu"): {Content: escape(language.Get(menu.Title))},
+				lg("uri"):  {Content: escape(menu.URI)},
+			}
+		}
+		menus = comp.Table().SetThead(types.Thead{
+			{Head: lg("menu")},
+			{Head: lg("uri")},
+		}).SetInfoList(infos).GetContent()
+	}
+
+	links := template.HTML("")
+	for _, period := range periods {
+		class := "btn-default"
+		if period == days {
+			class = "btn-primary"
+		}
+		links += template.HTML(`<a class="btn btn-sm ` + class + `" href="` +
+			template.HTMLEscapeString(config.Url("/"+Name+"?days="+strconv.Itoa(period))) + `">` +
+			lg("last "+strconv.Itoa(period)+" days") + `</a> `)
+	}
+
+	a.HTML(ctx, types.Panel{
+		Content: template.HTML(`<p>`+links+`</p>`) +
+			comp.Box().WithHeadBorder().SetHeader(template.HTML(lg("most used tables"))).SetBody(tables).GetContent() +
+			comp.Box().WithHeadBorder().SetHeader(template.HTML(lg("unused menus"))).SetBody(menus).GetContent(),
+		Title:       template.HTML(lg("usage analytics")),
+		Description: template.HTML(lg("since") + " " + report.Since),
+	})
+}
+
+func (a *Analytics) alert(ctx *context.Context, msg string) {
+	a.HTML(ctx, template2.WarningPanel(ctx, msg).GetContent(config.IsProductionEnvironment()))
+}
+
+func escape(s string) template.HTML {
+	return template.HTML(template.HTMLEscapeString(s))
+}
+
+func lg(v string) string {
+	return language.GetWithScope(v, Name)
+}
@@ -0,0 +1,20 @@
+package analytics
+
+var cn = map[string]string{
+	"analytics.usage analytics":  "使用分析",
+	"analytics.since":            "统计自",
+	"analytics.most used tables": "最常用的表",
+	"analytics.unused menus":     "未使用的菜单",
+	"analytics.table":            "表",
+	"analytics.views":            "浏览",
+	"analytics.actions":          "操作",
+	"analytics.users":            "用户数",
+	"analytics.last day":         "最近使用",
+	"analytics.menu":             "菜单",
+	"analytics.uri":              "路径",
+	"analytics.last 7 days":      "最近7天",
+	"analytics.last 30 days":     "最近30天",
+	"analytics.last 90 days":     "最近90天",
+	"analytics.no usage":         "暂无使用记录",
+	"analytics.no unused menus":  "所有菜单都有访问",
+}
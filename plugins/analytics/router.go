@@ -0,0 +1,17 @@
+package analytics
+
+import (
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/service"
+)
+
+func (a *Analytics) initRouter(prefix string, srv service.List) *context.App {
+
+	app := context.NewApp()
+	route := app.Group(prefix, auth.Middleware(db.GetConnection(srv)))
+	route.GET("/"+Name, a.ShowReport).Name("analytics_report")
+
+	return app
+}
@@ -0,0 +1,83 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+// Package announcement provides the banners published by the super
+// administrators and shown atop all the admin pages. The banners have a level,
+// a schedule window and the target roles, the users can dismiss them and find
+// them again in the archive page.
+package announcement
+
+import (
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/service"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// Announcement is a GoAdmin plugin.
+type Announcement struct {
+	*plugins.Base
+
+	store *Store
+	ttl   time.Duration
+}
+
+// Name is the name of the plugin, also used as the url prefix.
+const Name = "announcement"
+
+// NewAnnouncement return an Announcement plugin.
+func NewAnnouncement() *Announcement {
+	return &Announcement{
+		Base: &plugins.Base{PlugName: Name},
+		ttl:  time.Second * 30,
+	}
+}
+
+// SetTTL set how long the announcements are cached, default 30 seconds.
+func (a *Announcement) SetTTL(ttl time.Duration) *Announcement {
+	a.ttl = ttl
+	return a
+}
+
+// InitPlugin implements Plugin.InitPlugin.
+func (a *Announcement) InitPlugin(srv service.List) {
+	a.InitBase(srv, Name)
+	language.AppendTo(language.CN, cn)
+
+	a.store = NewStore(a.Conn, a.ttl)
+	types.AddPageDecorator(a.decorate)
+
+	a.App = a.initRouter(config.Prefix(), srv)
+}
+
+// GetGenerators implements Plugin.GetGenerators, the announcements are
+// managed by the table "announcements".
+func (a *Announcement) GetGenerators() table.GeneratorList {
+	return table.GeneratorList{GeneratorKey: a.table}
+}
+
+func (a *Announcement) GetIndexURL() string {
+	return config.Url("/" + Name + "/archive")
+}
+
+func (a *Announcement) IsInstalled() bool {
+	return true
+}
+
+func (a *Announcement) GetInfo() plugins.Info {
+	return plugins.Info{
+		Title:       "Announcement",
+		Name:        Name,
+		Description: "Publish the banners shown atop all the admin pages.",
+		Author:      "official",
+		Version:     "v0.0.1",
+		CreateDate:  utils.ParseTime("2026-10-19 00:00:00"),
+		UpdateDate:  utils.ParseTime("2026-10-19 00:00:00"),
+	}
+}
@@ -0,0 +1,156 @@
+package announcement
+
+import (
+	"html/template"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+	template2 "github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// decorate prepend the banners of the user to the panel of the page.
+func (a *Announcement) decorate(ctx *context.Context, user models.UserModel, panel types.Panel) types.Panel {
+	if a.store == nil || user.Id == 0 {
+		return panel
+	}
+	items, err := a.store.Banners(user)
+	if err != nil {
+		logger.Error("load announcements error: ", err)
+		return panel
+	}
+	if len(items) == 0 {
+		return panel
+	}
+	tokens := make([]string, len(items))
+	for i := range items {
+		tokens[i] = auth.GetTokenService(a.Services.Get(auth.TokenServiceKey)).AddToken()
+	}
+	panel.Content = banners(items, tokens) + panel.Content
+	return panel
+}
+
+// ShowArchive show the announcements of the user, including the dismissed and
+// the expired ones.
+func (a *Announcement) ShowArchive(ctx *context.Context) {
+	var (
+		comp = template2.Default(ctx)
+		user = auth.Auth(ctx)
+		body template.HTML
+	)
+
+	items, err := a.store.All()
+	if err != nil {
+		a.alert(ctx, err.Error())
+		return
+	}
+
+	infos := make([]map[string]types.InfoItem, 0)
+	for _, item := range items {
+		if !item.Visible(user) {
+			continue
+		}
+		infos = append(infos, map[string]types.InfoItem{
+			lg("title"):    {Content: escape(item.Title)},
+			lg("content"):  {Content: content(item.Content)},
+			lg("level"):    {Content: template.HTML(levelLabel(item.Level))},
+			lg("start at"): {Content: escape(formatTime(item.StartAt))},
+			lg("end at"):   {Content: escape(formatTime(item.EndAt))},
+		})
+	}
+
+	if len(infos) == 0 {
+		body = template.HTML("<p>" + lg("no announcements") + "</p>")
+	} else {
+		body = comp.Table().SetThead(types.Thead{
+			{Head: lg("title")},
+			{Head: lg("content")},
+			{Head: lg("level")},
+			{Head: lg("start at")},
+			{Head: lg("end at")},
+		}).SetInfoList(infos).GetContent()
+	}
+
+	a.HTML(ctx, types.Panel{
+		Content:     comp.Box().SetBody(body).GetContent(),
+		Title:       template.HTML(lg("announcements")),
+		Description: template.HTML(lg("archive")),
+	})
+}
+
+// Dismiss save the dismiss state of an announcement of the current user.
+func (a *Announcement) Dismiss(ctx *context.Context) {
+	if !auth.GetTokenService(a.Services.Get(auth.TokenServiceKey)).CheckToken(ctx.FormValue(form.TokenKey)) {
+		response.BadRequest(ctx, "wrong token")
+		return
+	}
+	id, err := strconv.ParseInt(ctx.FormValue("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "wrong parameter")
+		return
+	}
+	if err := a.store.Dismiss(auth.Auth(ctx).Id, id); err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+	response.Ok(ctx)
+}
+
+// banners return the html of the banners, each dismiss button has a token as
+// the token is used once.
+func banners(items []Item, tokens []string) template.HTML {
+	html := ""
+	for i, item := range items {
+		html += `<div class="alert alert-` + alertClass(item.Level) + ` alert-dismissible goadmin-announcement">` +
+			`<button type="button" class="close" data-dismiss="alert" data-id="` + strconv.FormatInt(item.ID, 10) + `" data-token="` + template.HTMLEscapeString(tokens[i]) + `">&times;</button>` +
+			`<h4>` + template.HTMLEscapeString(item.Title) + `</h4>` + string(content(item.Content)) + `</div>`
+	}
+	return template.HTML(html + `<script>
+$(".goadmin-announcement .close").on("click", function () {
+    $.post("` + template.JSEscapeString(config.Url("/"+Name+"/dismiss")) + `", {
+        id: $(this).data("id"),
+        "` + form.TokenKey + `": $(this).data("token")
+    });
+});
+</script>`)
+}
+
+func alertClass(level string) string {
+	switch level {
+	case LevelWarning, LevelDanger:
+		return level
+	}
+	return LevelInfo
+}
+
+func content(s string) template.HTML {
+	return template.HTML(strings.ReplaceAll(template.HTMLEscapeString(s), "\n", "<br>"))
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+func (a *Announcement) alert(ctx *context.Context, msg string) {
+	a.HTML(ctx, template2.WarningPanel(ctx, msg).GetContent(config.IsProductionEnvironment()))
+}
+
+func escape(s string) template.HTML {
+	return template.HTML(template.HTMLEscapeString(s))
+}
+
+func lg(v string) string {
+	return language.GetWithScope(v, Name)
+}
@@ -0,0 +1,20 @@
+package announcement
+
+var cn = map[string]string{
+	"announcement.announcements":          "公告",
+	"announcement.archive":                "历史公告",
+	"announcement.title":                  "标题",
+	"announcement.content":                "内容",
+	"announcement.level":                  "级别",
+	"announcement.info":                   "通知",
+	"announcement.warning":                "警告",
+	"announcement.danger":                 "紧急",
+	"announcement.roles":                  "目标角色",
+	"announcement.all":                    "全部",
+	"announcement.all the users if empty": "为空时对所有用户可见",
+	"announcement.start at":               "开始时间",
+	"announcement.end at":                 "结束时间",
+	"announcement.createdAt":              "创建时间",
+	"announcement.updatedAt":              "更新时间",
+	"announcement.no announcements":       "暂无公告",
+}
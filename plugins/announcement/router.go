@@ -0,0 +1,20 @@
+package announcement
+
+import (
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/service"
+)
+
+func (a *Announcement) initRouter(prefix string, srv service.List) *context.App {
+
+	app := context.NewApp()
+	// the announcements are shown to all the users, so the routes only need
+	// the sign in.
+	route := app.Group(prefix, auth.SignedInMiddleware(db.GetConnection(srv)))
+	route.GET("/"+Name+"/archive", a.ShowArchive).Name("announcement_archive")
+	route.POST("/"+Name+"/dismiss", a.Dismiss).Name("announcement_dismiss")
+
+	return app
+}
@@ -0,0 +1,192 @@
+package announcement
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+)
+
+// Tables of the plugin, see the migrations of data directory for the schema.
+const (
+	TableName          = "goadmin_announcements"
+	DismissalTableName = "goadmin_announcement_dismissals"
+)
+
+// Levels of the announcements.
+const (
+	LevelInfo    = "info"
+	LevelWarning = "warning"
+	LevelDanger  = "danger"
+)
+
+// Item is an announcement.
+type Item struct {
+	ID      int64
+	Title   string
+	Content string
+	Level   string
+	StartAt time.Time
+	EndAt   time.Time
+	// Roles are the slugs of the target roles, all the users when empty.
+	Roles     []string
+	CreatedAt time.Time
+}
+
+// Active check the announcement if it is in the schedule window.
+func (item Item) Active(now time.Time) bool {
+	return (item.StartAt.IsZero() || !now.Before(item.StartAt)) && (item.EndAt.IsZero() || now.Before(item.EndAt))
+}
+
+// Visible check the announcement if it targets the user.
+func (item Item) Visible(user models.UserModel) bool {
+	if len(item.Roles) == 0 || user.IsSuperAdmin() {
+		return true
+	}
+	for _, role := range user.Roles {
+		for _, slug := range item.Roles {
+			if role.Slug == slug {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Store loads the announcements and saves the dismiss state.
+type Store struct {
+	conn db.Connection
+	ttl  time.Duration
+
+	lock   sync.RWMutex
+	items  []Item
+	loaded time.Time
+}
+
+// NewStore return the store of the connection, the announcements are cached
+// for the ttl.
+func NewStore(conn db.Connection, ttl time.Duration) *Store {
+	return &Store{conn: conn, ttl: ttl}
+}
+
+// All return all the announcements, the latest first.
+func (s *Store) All() ([]Item, error) {
+	s.lock.RLock()
+	if !s.loaded.IsZero() && time.Since(s.loaded) <= s.ttl {
+		items := s.items
+		s.lock.RUnlock()
+		return items, nil
+	}
+	s.lock.RUnlock()
+
+	rows, err := db.WithDriver(s.conn).Table(TableName).All()
+	if err != nil {
+		return nil, err
+	}
+	items := make([]Item, len(rows))
+	for i, row := range rows {
+		items[i] = Item{
+			ID:        db.GetValueFromDatabaseType(db.Int, row["id"], false).ToInt64(),
+			Title:     db.GetValueFromDatabaseType(db.Varchar, row["title"], false).String(),
+			Content:   db.GetValueFromDatabaseType(db.Text, row["content"], false).String(),
+			Level:     db.GetValueFromDatabaseType(db.Varchar, row["level"], false).String(),
+			StartAt:   db.ParseTime(row["start_at"]),
+			EndAt:     db.ParseTime(row["end_at"]),
+			Roles:     splitRoles(db.GetValueFromDatabaseType(db.Varchar, row["roles"], false).String()),
+			CreatedAt: db.ParseTime(row["created_at"]),
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].ID > items[j].ID
+	})
+
+	s.lock.Lock()
+	s.items = items
+	s.loaded = time.Now()
+	s.lock.Unlock()
+	return items, nil
+}
+
+// Expire drop the cache, such as after the announcements are edited.
+func (s *Store) Expire() {
+	s.lock.Lock()
+	s.loaded = time.Time{}
+	s.lock.Unlock()
+}
+
+// Dismissed return the ids of the announcements dismissed by the user.
+func (s *Store) Dismissed(userID int64) (map[int64]bool, error) {
+	rows, err := db.WithDriver(s.conn).Table(DismissalTableName).
+		Select("announcement_id").Where("user_id", "=", userID).All()
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[int64]bool, len(rows))
+	for _, row := range rows {
+		ids[db.GetValueFromDatabaseType(db.Int, row["announcement_id"], false).ToInt64()] = true
+	}
+	return ids, nil
+}
+
+// Dismiss save the dismiss state of the announcement of the user.
+func (s *Store) Dismiss(userID, id int64) error {
+	item, err := db.WithDriver(s.conn).Table(DismissalTableName).
+		Where("user_id", "=", userID).Where("announcement_id", "=", id).First()
+	if err != nil {
+		return err
+	}
+	if item != nil {
+		return nil
+	}
+	_, err = db.WithDriver(s.conn).Table(DismissalTableName).Insert(dialect.H{
+		"announcement_id": id,
+		"user_id":         userID,
+	})
+	if db.CheckError(err, db.INSERT) {
+		return err
+	}
+	return nil
+}
+
+// Banners return the active announcements of the user which are not dismissed.
+func (s *Store) Banners(user models.UserModel) ([]Item, error) {
+	items, err := s.All()
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	now := time.Now()
+	active := make([]Item, 0)
+	for _, item := range items {
+		if item.Active(now) && item.Visible(user) {
+			active = append(active, item)
+		}
+	}
+	if len(active) == 0 {
+		return active, nil
+	}
+	dismissed, err := s.Dismissed(user.Id)
+	if err != nil {
+		return nil, err
+	}
+	banners := make([]Item, 0, len(active))
+	for _, item := range active {
+		if !dismissed[item.ID] {
+			banners = append(banners, item)
+		}
+	}
+	return banners, nil
+}
+
+func splitRoles(s string) []string {
+	roles := make([]string, 0)
+	for _, role := range strings.Split(s, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
@@ -0,0 +1,28 @@
+package announcement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItemActive(t *testing.T) {
+	now := time.Now()
+	assert.True(t, Item{}.Active(now))
+	assert.True(t, Item{StartAt: now.Add(-time.Hour), EndAt: now.Add(time.Hour)}.Active(now))
+	assert.False(t, Item{StartAt: now.Add(time.Hour)}.Active(now))
+	assert.False(t, Item{EndAt: now.Add(-time.Hour)}.Active(now))
+}
+
+func TestItemVisible(t *testing.T) {
+	operator := models.UserModel{Roles: []models.RoleModel{{Slug: "operator"}}}
+	assert.True(t, Item{}.Visible(operator))
+	assert.True(t, Item{Roles: []string{"auditor", "operator"}}.Visible(operator))
+	assert.False(t, Item{Roles: []string{"auditor"}}.Visible(operator))
+}
+
+func TestSplitRoles(t *testing.T) {
+	assert.Equal(t, splitRoles(" operator, ,auditor"), []string{"operator", "auditor"})
+}
@@ -0,0 +1,103 @@
+package announcement
+
+import (
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	form2 "github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	"github.com/purpose168/GoAdmin/template/types"
+	"github.com/purpose168/GoAdmin/template/types/form"
+)
+
+// GeneratorKey is the key of the table managing the announcements, the table
+// is at /info/announcements.
+const GeneratorKey = "announcements"
+
+var levels = types.FieldOptions{
+	{Value: LevelInfo, Text: "info"},
+	{Value: LevelWarning, Text: "warning"},
+	{Value: LevelDanger, Text: "danger"},
+}
+
+func (a *Announcement) table(ctx *context.Context) table.Table {
+	t := table.NewDefaultTable(ctx, table.DefaultConfigWithDriver(config.GetDatabases().GetDefault().Driver))
+
+	info := t.GetInfo().AddXssJsFilter()
+	info.AddField("ID", "id", db.Int).FieldSortable()
+	info.AddField(lg("title"), "title", db.Varchar).FieldFilterable()
+	info.AddField(lg("level"), "level", db.Varchar).FieldDisplay(func(model types.FieldModel) interface{} {
+		return levelLabel(model.Value)
+	})
+	info.AddField(lg("roles"), "roles", db.Varchar).FieldDisplay(func(model types.FieldModel) interface{} {
+		if model.Value == "" {
+			return lg("all")
+		}
+		return model.Value
+	})
+	info.AddField(lg("start at"), "start_at", db.Timestamp).FieldSortable()
+	info.AddField(lg("end at"), "end_at", db.Timestamp).FieldSortable()
+	info.AddField(lg("createdAt"), "created_at", db.Timestamp)
+
+	info.SetTable(TableName).
+		SetTitle(lg("announcements")).
+		SetDescription(lg("announcements")).
+		SetDeleteHook(func(ids []string) error {
+			defer a.store.Expire()
+			args := make([]interface{}, len(ids))
+			for i, id := range ids {
+				args[i] = id
+			}
+			err := db.WithDriver(a.Conn).Table(DismissalTableName).WhereIn("announcement_id", args).Delete()
+			if db.CheckError(err, db.DELETE) {
+				return err
+			}
+			return nil
+		})
+
+	formList := t.GetForm().AddXssJsFilter()
+	formList.AddField("ID", "id", db.Int, form.Default).FieldDisplayButCanNotEditWhenUpdate().FieldDisableWhenCreate()
+	formList.AddField(lg("title"), "title", db.Varchar, form.Text).FieldMust()
+	formList.AddField(lg("content"), "content", db.Text, form.TextArea).FieldMust()
+	formList.AddField(lg("level"), "level", db.Varchar, form.SelectSingle).
+		FieldOptions(levels).FieldDefault(LevelInfo)
+	formList.AddField(lg("roles"), "roles", db.Varchar, form.Select).
+		FieldOptionsFromTable("goadmin_roles", "slug", "slug").
+		FieldDisplay(func(model types.FieldModel) interface{} {
+			return splitRoles(model.Value)
+		}).
+		FieldPostFilterFn(func(model types.PostFieldModel) interface{} {
+			return strings.Join(model.Value, ",")
+		}).
+		FieldHelpMsg(template.HTML(lg("all the users if empty")))
+	formList.AddField(lg("start at"), "start_at", db.Timestamp, form.Datetime).
+		FieldDefault(time.Now().Format("2006-01-02 15:04:05")).FieldMust()
+	formList.AddField(lg("end at"), "end_at", db.Timestamp, form.Datetime).FieldMust()
+	formList.AddField(lg("updatedAt"), "updated_at", db.Timestamp, form.Default).FieldDisableWhenCreate()
+	formList.AddField(lg("createdAt"), "created_at", db.Timestamp, form.Default).FieldDisableWhenCreate()
+
+	formList.SetTable(TableName).
+		SetTitle(lg("announcements")).
+		SetDescription(lg("announcements")).
+		SetPostHook(func(values form2.Values) error {
+			a.store.Expire()
+			return nil
+		})
+
+	return t
+}
+
+func levelLabel(level string) string {
+	class := "info"
+	switch level {
+	case LevelWarning:
+		class = "warning"
+	case LevelDanger:
+		class = "danger"
+	}
+	return `<span class="label label-` + class + `">` + template.HTMLEscapeString(lg(level)) + `</span>`
+}
@@ -0,0 +1,235 @@
+package dbexplorer
+
+import (
+	"fmt"
+	"html/template"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/language"
+	template2 "github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// ShowConnections show the configured connections.
+func (e *DBExplorer) ShowConnections(ctx *context.Context) {
+	var (
+		comp  = template2.Default(ctx)
+		list  = make([]map[string]types.InfoItem, 0)
+		names = config.GetDatabases().Connections()
+	)
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		cfg := config.GetDatabases()[name]
+		list = append(list, map[string]types.InfoItem{
+			lg("connection"): {Content: link(tablesURL(name), name)},
+			lg("driver"):     {Content: template.HTML(template.HTMLEscapeString(cfg.Driver))},
+			lg("database"):   {Content: template.HTML(template.HTMLEscapeString(cfg.Name + cfg.File))},
+		})
+	}
+
+	table := comp.Table().SetThead(types.Thead{
+		{Head: lg("connection")},
+		{Head: lg("driver")},
+		{Head: lg("database")},
+	}).SetInfoList(list).GetContent()
+
+	e.HTML(ctx, types.Panel{
+		Content:     comp.Box().SetNoPadding().SetBody(table).GetContent(),
+		Title:       template.HTML(lg("database explorer")),
+		Description: template.HTML(lg("connections")),
+	})
+}
+
+// ShowTables show the tables of the given connection.
+func (e *DBExplorer) ShowTables(ctx *context.Context) {
+	connName := ctx.Query("conn")
+
+	conn, cfg, err := connection(e.Services, connName)
+	if err != nil {
+		e.alert(ctx, err)
+		return
+	}
+
+	list, err := tables(conn, connName, cfg)
+	if err != nil {
+		e.alert(ctx, err)
+		return
+	}
+
+	var (
+		comp  = template2.Default(ctx)
+		infos = make([]map[string]types.InfoItem, len(list))
+	)
+
+	for i, table := range list {
+		infos[i] = map[string]types.InfoItem{
+			lg("table"):     {Content: link(tableURL(connName, table, 1), table)},
+			lg("operation"): {Content: generatorLink(connName, table)},
+		}
+	}
+
+	table := comp.Table().SetThead(types.Thead{
+		{Head: lg("table")},
+		{Head: lg("operation"), Width: "200px"},
+	}).SetInfoList(infos).GetContent()
+
+	e.HTML(ctx, types.Panel{
+		Content:     comp.Box().SetNoPadding().SetBody(table).GetContent(),
+		Title:       template.HTML(lg("database explorer")),
+		Description: template.HTML(template.HTMLEscapeString(connName)),
+	})
+}
+
+// ShowTable show the columns, indexes and a paged data preview of the given table.
+func (e *DBExplorer) ShowTable(ctx *context.Context) {
+	var (
+		connName = ctx.Query("conn")
+		table    = ctx.Query("table")
+	)
+
+	conn, cfg, err := connection(e.Services, connName)
+	if err != nil {
+		e.alert(ctx, err)
+		return
+	}
+
+	if err = checkTable(conn, connName, cfg, table); err != nil {
+		e.alert(ctx, err)
+		return
+	}
+
+	page, _ := strconv.Atoi(ctx.QueryDefault("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+
+	columnList, err := columns(conn, connName, table)
+	if err != nil {
+		e.alert(ctx, err)
+		return
+	}
+
+	indexList, err := indexes(conn, connName, table)
+	if err != nil {
+		e.alert(ctx, err)
+		return
+	}
+
+	order, err := orderColumn(conn, connName, table, columnList)
+	if err != nil {
+		e.alert(ctx, err)
+		return
+	}
+
+	rows, total, err := preview(conn, connName, table, order, page, e.pageSize)
+	if err != nil {
+		e.alert(ctx, err)
+		return
+	}
+
+	comp := template2.Default(ctx)
+
+	tabs := comp.Tabs().SetData([]map[string]template.HTML{
+		{"title": template.HTML(lg("data preview")), "content": rowsTable(comp, rows) + pager(connName, table, page, e.pageSize, total)},
+		{"title": template.HTML(lg("columns")), "content": rowsTable(comp, columnList)},
+		{"title": template.HTML(lg("indexes")), "content": rowsTable(comp, indexList)},
+	}).GetContent()
+
+	e.HTML(ctx, types.Panel{
+		Content:     generatorLink(connName, table) + tabs,
+		Title:       template.HTML(template.HTMLEscapeString(table)),
+		Description: template.HTML(template.HTMLEscapeString(connName)),
+	})
+}
+
+func (e *DBExplorer) alert(ctx *context.Context, err error) {
+	e.HTML(ctx, template2.WarningPanel(ctx, err.Error()).GetContent(config.IsProductionEnvironment()))
+}
+
+// rowsTable render the query results, the columns are ordered by name since
+// the results of the different drivers have no fixed order.
+func rowsTable(comp template2.Template, rows []map[string]interface{}) template.HTML {
+	if len(rows) == 0 {
+		return template.HTML("<p>" + lg("no data") + "</p>")
+	}
+
+	keys := make([]string, 0, len(rows[0]))
+	for key := range rows[0] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	thead := make(types.Thead, len(keys))
+	for i, key := range keys {
+		thead[i] = types.TheadItem{Head: key}
+	}
+
+	infos := make([]map[string]types.InfoItem, len(rows))
+	for i, row := range rows {
+		infos[i] = make(map[string]types.InfoItem, len(keys))
+		for _, key := range keys {
+			value := ""
+			if row[key] != nil {
+				value = fmt.Sprintf("%v", row[key])
+			}
+			infos[i][key] = types.InfoItem{Content: template.HTML(template.HTMLEscapeString(value)), Value: value}
+		}
+	}
+
+	return `<div class="table-responsive">` + comp.Table().SetThead(thead).SetInfoList(infos).GetContent() + `</div>`
+}
+
+func pager(connName, table string, page, size int, total int64) template.HTML {
+	last := int((total + int64(size) - 1) / int64(size))
+	if last < 1 {
+		last = 1
+	}
+
+	html := template.HTML(`<ul class="pagination pagination-sm no-margin pull-right">`)
+	if page > 1 {
+		html += template.HTML(`<li><a href="` + tableURL(connName, table, page-1) + `">&laquo;</a></li>`)
+	}
+	html += template.HTML(fmt.Sprintf(`<li class="active"><a href="javascript:;">%d / %d</a></li>`, page, last))
+	if page < last {
+		html += template.HTML(`<li><a href="` + tableURL(connName, table, page+1) + `">&raquo;</a></li>`)
+	}
+	html += template.HTML(fmt.Sprintf(`</ul><p>%s: %d</p>`, lg("total"), total))
+	return html
+}
+
+// generatorLink jump to the code generate tool with the connection and table
+// filled in, the tool is only available in the non-production environment.
+func generatorLink(connName, table string) template.HTML {
+	if config.IsProductionEnvironment() {
+		return ""
+	}
+	u := config.Url("/info/generate/new?" + url.Values{"conn": {connName}, "table": {table}}.Encode())
+	return template.HTML(`<a class="btn btn-sm btn-default" href="` + template.HTMLEscapeString(u) + `">` +
+		lg("create generator") + `</a>`)
+}
+
+func tablesURL(connName string) string {
+	return config.Url("/" + Name + "/tables?" + url.Values{"conn": {connName}}.Encode())
+}
+
+func tableURL(connName, table string, page int) string {
+	return config.Url("/" + Name + "/table?" + url.Values{
+		"conn":  {connName},
+		"table": {table},
+		"page":  {strconv.Itoa(page)},
+	}.Encode())
+}
+
+func link(u, content string) template.HTML {
+	return template.HTML(`<a href="` + template.HTMLEscapeString(u) + `">` + template.HTMLEscapeString(content) + `</a>`)
+}
+
+func lg(v string) string {
+	return language.GetWithScope(v, Name)
+}
@@ -0,0 +1,68 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+// Package dbexplorer provides a read-only browser of the configured
+// database connections: tables, columns, indexes and a paged data preview.
+package dbexplorer
+
+import (
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/service"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins"
+)
+
+// DBExplorer is a GoAdmin plugin.
+type DBExplorer struct {
+	*plugins.Base
+
+	pageSize int
+}
+
+// Name is the name of the plugin, also used as the url prefix.
+const Name = "dbexplorer"
+
+// NewDBExplorer return a DBExplorer plugin.
+func NewDBExplorer() *DBExplorer {
+	return &DBExplorer{
+		Base:     &plugins.Base{PlugName: Name},
+		pageSize: defaultPageSize,
+	}
+}
+
+// SetPageSize set the row count of the data preview.
+func (e *DBExplorer) SetPageSize(size int) *DBExplorer {
+	if size > 0 {
+		e.pageSize = size
+	}
+	return e
+}
+
+// InitPlugin implements Plugin.InitPlugin.
+func (e *DBExplorer) InitPlugin(srv service.List) {
+	e.InitBase(srv, Name)
+	language.AppendTo(language.CN, cn)
+	e.App = e.initRouter(config.Prefix(), srv)
+}
+
+func (e *DBExplorer) GetIndexURL() string {
+	return config.Url("/" + Name)
+}
+
+func (e *DBExplorer) IsInstalled() bool {
+	return true
+}
+
+func (e *DBExplorer) GetInfo() plugins.Info {
+	return plugins.Info{
+		Title:       "Database Explorer",
+		Name:        Name,
+		Description: "A read-only explorer of the tables, columns and indexes of the configured connections.",
+		Author:      "official",
+		Version:     "v0.0.1",
+		CreateDate:  utils.ParseTime("2026-10-15 00:00:00"),
+		UpdateDate:  utils.ParseTime("2026-10-15 00:00:00"),
+	}
+}
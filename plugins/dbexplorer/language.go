@@ -0,0 +1,17 @@
+package dbexplorer
+
+var cn = map[string]string{
+	"dbexplorer.database explorer": "数据库浏览器",
+	"dbexplorer.connections":       "连接列表",
+	"dbexplorer.connection":        "连接",
+	"dbexplorer.driver":            "驱动",
+	"dbexplorer.database":          "数据库",
+	"dbexplorer.table":             "表",
+	"dbexplorer.operation":         "操作",
+	"dbexplorer.data preview":      "数据预览",
+	"dbexplorer.columns":           "字段",
+	"dbexplorer.indexes":           "索引",
+	"dbexplorer.no data":           "暂无数据",
+	"dbexplorer.total":             "总数",
+	"dbexplorer.create generator":  "生成CRUD模型",
+}
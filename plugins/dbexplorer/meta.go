@@ -0,0 +1,166 @@
+package dbexplorer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/service"
+	"github.com/purpose168/GoAdmin/modules/utils"
+)
+
+const defaultPageSize = 20
+
+var errTableNotFound = errors.New("table not found")
+
+// connection return the db.Connection and the config of the given connection name.
+func connection(srv service.List, name string) (db.Connection, config.Database, error) {
+	cfg, ok := config.GetDatabases()[name]
+	if !ok {
+		return nil, cfg, errors.New("connection not found")
+	}
+	s, ok := srv.GetOrNot(cfg.Driver)
+	if !ok {
+		return nil, cfg, errors.New("connection not initialized")
+	}
+	return db.GetConnectionFromService(s), cfg, nil
+}
+
+func tables(conn db.Connection, connName string, cfg config.Database) ([]string, error) {
+	return db.WithDriverAndConnection(connName, conn).Table(cfg.Name).ShowTables()
+}
+
+// checkTable makes sure the table exists in the connection, since the table
+// name is used to build the statements below.
+func checkTable(conn db.Connection, connName string, cfg config.Database, table string) error {
+	list, err := tables(conn, connName, cfg)
+	if err != nil {
+		return err
+	}
+	if !utils.InArray(list, table) {
+		return errTableNotFound
+	}
+	return nil
+}
+
+func columns(conn db.Connection, connName, table string) ([]map[string]interface{}, error) {
+	return db.WithDriverAndConnection(connName, conn).Table(table).ShowColumns()
+}
+
+// indexes return the index list of the table, the statement depends on the driver.
+func indexes(conn db.Connection, connName, table string) ([]map[string]interface{}, error) {
+	var (
+		query string
+		args  []interface{}
+		d     = conn.GetDelimiter()
+		d2    = conn.GetDelimiter2()
+	)
+
+	switch conn.Name() {
+	case db.DriverMysql, db.DriverOceanBase:
+		query = "show index from " + d + table + d2
+	case db.DriverSqlite:
+		query = "PRAGMA index_list(" + d + table + d2 + ")"
+	case db.DriverPostgresql:
+		query = "select indexname, indexdef from pg_indexes where tablename = ?"
+		args = []interface{}{table}
+	case db.DriverMssql:
+		query = `select i.name as index_name, c.name as column_name, i.is_unique, i.is_primary_key
+			from sys.indexes i
+			join sys.index_columns ic on i.object_id = ic.object_id and i.index_id = ic.index_id
+			join sys.columns c on ic.object_id = c.object_id and ic.column_id = c.column_id
+			where i.object_id = OBJECT_ID(?)`
+		args = []interface{}{table}
+	default:
+		return nil, errors.New("driver not supported")
+	}
+
+	return conn.QueryWithConnection(connName, query, args...)
+}
+
+// primaryKey return the first column of the primary key of the table, or an
+// empty string when the table has none. The columns are the ones of columns.
+func primaryKey(conn db.Connection, connName, table string, columnList []map[string]interface{}) (string, error) {
+	switch conn.Name() {
+	case db.DriverMysql, db.DriverOceanBase:
+		for _, column := range columnList {
+			if column["Key"] == "PRI" {
+				return fmt.Sprint(column["Field"]), nil
+			}
+		}
+	case db.DriverSqlite:
+		for _, column := range columnList {
+			if pk, ok := column["pk"].(int64); ok && pk == 1 {
+				return fmt.Sprint(column["name"]), nil
+			}
+		}
+	case db.DriverPostgresql, db.DriverMssql:
+		list, err := conn.QueryWithConnection(connName, `select kcu.column_name as column_name
+			from information_schema.table_constraints tc
+			join information_schema.key_column_usage kcu on tc.constraint_name = kcu.constraint_name
+				and tc.table_schema = kcu.table_schema and tc.table_name = kcu.table_name
+			where tc.constraint_type = 'PRIMARY KEY' and tc.table_name = ?
+			order by kcu.ordinal_position`, table)
+		if err != nil {
+			return "", err
+		}
+		if len(list) > 0 {
+			return fmt.Sprint(list[0]["column_name"]), nil
+		}
+	}
+	return "", nil
+}
+
+// orderColumn return the column ordering the preview, which is the primary key
+// or the first column of the table, since the offset of some drivers, as mssql,
+// requires an order and the pages are not stable without one.
+func orderColumn(conn db.Connection, connName, table string, columnList []map[string]interface{}) (string, error) {
+	if key, err := primaryKey(conn, connName, table, columnList); err != nil || key != "" {
+		return key, err
+	}
+
+	field := "column_name"
+	switch conn.Name() {
+	case db.DriverMysql, db.DriverOceanBase:
+		field = "Field"
+	case db.DriverSqlite:
+		field = "name"
+	}
+	var (
+		first    string
+		position int64
+	)
+	for _, column := range columnList {
+		name, ok := column[field].(string)
+		if !ok {
+			continue
+		}
+		// the columns of information_schema are not ordered.
+		pos, _ := column["ordinal_position"].(int64)
+		if first == "" || pos > 0 && pos < position {
+			first, position = name, pos
+		}
+	}
+	return first, nil
+}
+
+// preview return one page of the table data ordered by the column, and the
+// total count.
+func preview(conn db.Connection, connName, table, order string, page, size int) ([]map[string]interface{}, int64, error) {
+	total, err := db.WithDriverAndConnection(connName, conn).Table(table).Count()
+	if err != nil {
+		return nil, 0, err
+	}
+	sql := db.WithDriverAndConnection(connName, conn).Table(table)
+	if order != "" {
+		sql = sql.OrderBy(order, "asc")
+	}
+	list, err := sql.Skip((page - 1) * size).
+		Take(size).
+		All()
+	if err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
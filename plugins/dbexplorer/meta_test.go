@@ -0,0 +1,74 @@
+package dbexplorer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	_ "github.com/purpose168/GoAdmin/modules/db/drivers/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSqlite(t *testing.T) (db.Connection, config.Database) {
+	cfg := config.Database{Driver: db.DriverSqlite, File: filepath.Join(t.TempDir(), "explorer.db")}
+	conn := db.GetSqliteDB()
+	conn.InitDB(config.DatabaseList{"default": cfg})
+	for _, statement := range []string{
+		"create table posts (code text primary key, title text)",
+		"insert into posts (code, title) values ('c', 'C'), ('a', 'A'), ('b', 'B')",
+		"create table logs (level integer, msg text)",
+		"insert into logs (level, msg) values (3, 'x'), (1, 'y'), (2, 'z')",
+	} {
+		_, err := conn.Exec(statement)
+		assert.NoError(t, err)
+	}
+	return conn, cfg
+}
+
+func TestTables(t *testing.T) {
+	conn, cfg := newSqlite(t)
+
+	list, err := tables(conn, "default", cfg)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"posts", "logs"}, list)
+
+	assert.NoError(t, checkTable(conn, "default", cfg, "posts"))
+	assert.Equal(t, errTableNotFound, checkTable(conn, "default", cfg, "posts; drop table posts"))
+}
+
+func TestPreview(t *testing.T) {
+	conn, _ := newSqlite(t)
+
+	// the table is ordered by the primary key.
+	columnList, err := columns(conn, "default", "posts")
+	assert.NoError(t, err)
+	order, err := orderColumn(conn, "default", "posts", columnList)
+	assert.NoError(t, err)
+	assert.Equal(t, "code", order)
+
+	rows, total, err := preview(conn, "default", "posts", order, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "a", rows[0]["code"])
+	assert.Equal(t, "b", rows[1]["code"])
+
+	rows, _, err = preview(conn, "default", "posts", order, 2, 2)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, "c", rows[0]["code"])
+
+	// the table without a primary key is ordered by the first column.
+	columnList, err = columns(conn, "default", "logs")
+	assert.NoError(t, err)
+	order, err = orderColumn(conn, "default", "logs", columnList)
+	assert.NoError(t, err)
+	assert.Equal(t, "level", order)
+
+	rows, total, err = preview(conn, "default", "logs", order, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Equal(t, int64(1), rows[0]["level"])
+	assert.Equal(t, int64(2), rows[1]["level"])
+}
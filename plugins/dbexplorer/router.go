@@ -0,0 +1,19 @@
+package dbexplorer
+
+import (
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/service"
+)
+
+func (e *DBExplorer) initRouter(prefix string, srv service.List) *context.App {
+
+	app := context.NewApp()
+	route := app.Group(prefix, auth.Middleware(db.GetConnection(srv)))
+	route.GET("/"+Name, e.ShowConnections).Name("dbexplorer_connections")
+	route.GET("/"+Name+"/tables", e.ShowTables).Name("dbexplorer_tables")
+	route.GET("/"+Name+"/table", e.ShowTable).Name("dbexplorer_table")
+
+	return app
+}
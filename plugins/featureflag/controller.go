@@ -0,0 +1,170 @@
+package featureflag
+
+import (
+	"html/template"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/featureflag"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	template2 "github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+var envs = []string{featureflag.AllEnv, config.EnvLocal, config.EnvTest, config.EnvProd}
+
+// ShowFlags show the defined flags and their rules.
+func (f *FeatureFlag) ShowFlags(ctx *context.Context) {
+	var (
+		comp  = template2.Default(ctx)
+		flags = featureflag.Flags()
+		user  = auth.Auth(ctx)
+		body  template.HTML
+	)
+
+	if len(flags) == 0 {
+		body = template.HTML("<p>" + lg("no flags") + "</p>")
+	} else {
+		token := auth.GetTokenService(f.Services.Get(auth.TokenServiceKey)).AddToken()
+		infos := make([]map[string]types.InfoItem, len(flags))
+		for i, flag := range flags {
+			infos[i] = map[string]types.InfoItem{
+				lg("name"):        {Content: escape(flag.Name)},
+				lg("description"): {Content: escape(flag.Description)},
+				lg("default"):     {Content: state(flag.Default)},
+				lg("current"):     {Content: state(f.store.Enabled(flag.Name, config.GetEnv(), user.UserName))},
+				lg("rules"):       {Content: rules(f.store.Rules(flag.Name))},
+				lg("operation"):   {Content: editForm(flag.Name, token)},
+			}
+		}
+		body = comp.Table().SetThead(types.Thead{
+			{Head: lg("name")},
+			{Head: lg("description")},
+			{Head: lg("default")},
+			{Head: lg("current")},
+			{Head: lg("rules")},
+			{Head: lg("operation")},
+		}).SetInfoList(infos).GetContent()
+	}
+
+	f.HTML(ctx, types.Panel{
+		Content:     comp.Box().SetBody(body).GetContent(),
+		Title:       template.HTML(lg("feature flags")),
+		Description: template.HTML(lg("toggle at runtime")),
+	})
+}
+
+// Set save the rule of a flag, the rule is removed when the state is
+// "default".
+func (f *FeatureFlag) Set(ctx *context.Context) {
+	if !auth.GetTokenService(f.Services.Get(auth.TokenServiceKey)).CheckToken(ctx.FormValue(form.TokenKey)) {
+		f.alert(ctx, "wrong token")
+		return
+	}
+
+	var (
+		name = ctx.FormValue("name")
+		env  = ctx.FormValue("env")
+		err  error
+	)
+
+	if _, ok := featureflag.Get(name); !ok || !validEnv(env) {
+		f.alert(ctx, "wrong parameter")
+		return
+	}
+
+	switch ctx.FormValue("state") {
+	case "default":
+		err = f.store.Delete(name, env)
+	case "on", "off":
+		percentage, perr := strconv.Atoi(ctx.FormValue("percentage"))
+		if perr != nil {
+			percentage = 100
+		}
+		err = f.store.Set(name, featureflag.Rule{
+			Env:        env,
+			Enabled:    ctx.FormValue("state") == "on",
+			Percentage: percentage,
+		})
+	default:
+		f.alert(ctx, "wrong parameter")
+		return
+	}
+
+	if err != nil {
+		f.alert(ctx, err.Error())
+		return
+	}
+
+	ctx.Redirect(config.Url("/" + Name))
+}
+
+func validEnv(env string) bool {
+	for _, e := range envs {
+		if e == env {
+			return true
+		}
+	}
+	return false
+}
+
+func envLabel(env string) string {
+	if env == featureflag.AllEnv {
+		return lg("all")
+	}
+	return env
+}
+
+func state(on bool) template.HTML {
+	if on {
+		return template.HTML(`<span class="label label-success">` + lg("on") + `</span>`)
+	}
+	return template.HTML(`<span class="label label-default">` + lg("off") + `</span>`)
+}
+
+func rules(list map[string]featureflag.Rule) template.HTML {
+	keys := make([]string, 0, len(list))
+	for env := range list {
+		keys = append(keys, env)
+	}
+	sort.Strings(keys)
+	items := make([]string, len(keys))
+	for i, env := range keys {
+		items[i] = template.HTMLEscapeString(envLabel(env) + ": " + list[env].String())
+	}
+	return template.HTML(strings.Join(items, "<br>"))
+}
+
+func editForm(name, token string) template.HTML {
+	options := ""
+	for _, env := range envs {
+		options += `<option value="` + env + `">` + template.HTMLEscapeString(envLabel(env)) + `</option>`
+	}
+	return template.HTML(`<form method="post" action="` + template.HTMLEscapeString(config.Url("/"+Name+"/set")) + `" class="form-inline">` +
+		`<input type="hidden" name="` + form.TokenKey + `" value="` + template.HTMLEscapeString(token) + `">` +
+		`<input type="hidden" name="name" value="` + template.HTMLEscapeString(name) + `">` +
+		`<select name="env" class="form-control input-sm">` + options + `</select> ` +
+		`<select name="state" class="form-control input-sm">` +
+		`<option value="on">` + lg("on") + `</option>` +
+		`<option value="off">` + lg("off") + `</option>` +
+		`<option value="default">` + lg("use default") + `</option></select> ` +
+		`<input type="number" name="percentage" min="0" max="100" value="100" class="form-control input-sm" style="width:80px;" title="` + lg("percentage") + `">% ` +
+		`<button type="submit" class="btn btn-sm btn-primary">` + lg("save") + `</button></form>`)
+}
+
+func (f *FeatureFlag) alert(ctx *context.Context, msg string) {
+	f.HTML(ctx, template2.WarningPanel(ctx, msg).GetContent(config.IsProductionEnvironment()))
+}
+
+func escape(s string) template.HTML {
+	return template.HTML(template.HTMLEscapeString(s))
+}
+
+func lg(v string) string {
+	return language.GetWithScope(v, Name)
+}
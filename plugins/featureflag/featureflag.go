@@ -0,0 +1,83 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+// Package featureflag provides the page toggling the feature flags of
+// modules/featureflag at runtime, per environment and by percentage rollout.
+// It also adds the template function "feature" checking a flag.
+package featureflag
+
+import (
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/featureflag"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/service"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins"
+	"github.com/purpose168/GoAdmin/template"
+)
+
+// FeatureFlag is a GoAdmin plugin.
+type FeatureFlag struct {
+	*plugins.Base
+
+	ttl   time.Duration
+	store *featureflag.Store
+}
+
+// Name is the name of the plugin, also used as the url prefix.
+const Name = "featureflag"
+
+// NewFeatureFlag return a FeatureFlag plugin.
+func NewFeatureFlag() *FeatureFlag {
+	return &FeatureFlag{
+		Base: &plugins.Base{PlugName: Name},
+		ttl:  time.Second * 30,
+	}
+}
+
+// SetTTL set how long the rules are cached before reloaded from the database,
+// default 30 seconds.
+func (f *FeatureFlag) SetTTL(ttl time.Duration) *FeatureFlag {
+	f.ttl = ttl
+	return f
+}
+
+// InitPlugin implements Plugin.InitPlugin.
+func (f *FeatureFlag) InitPlugin(srv service.List) {
+	f.InitBase(srv, Name)
+	language.AppendTo(language.CN, cn)
+
+	f.store = featureflag.NewStore(db.GetConnection(srv), f.ttl)
+	if err := f.store.Reload(); err != nil {
+		logger.Error("load feature flags error: ", err)
+	}
+	featureflag.Use(f.store)
+	template.AddFunc("feature", featureflag.Enabled)
+
+	f.App = f.initRouter(config.Prefix(), srv)
+}
+
+func (f *FeatureFlag) GetIndexURL() string {
+	return config.Url("/" + Name)
+}
+
+func (f *FeatureFlag) IsInstalled() bool {
+	return true
+}
+
+func (f *FeatureFlag) GetInfo() plugins.Info {
+	return plugins.Info{
+		Title:       "Feature Flags",
+		Name:        Name,
+		Description: "Toggle the feature flags at runtime, per environment and by percentage rollout.",
+		Author:      "official",
+		Version:     "v0.0.1",
+		CreateDate:  utils.ParseTime("2026-10-18 00:00:00"),
+		UpdateDate:  utils.ParseTime("2026-10-18 00:00:00"),
+	}
+}
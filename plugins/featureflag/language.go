@@ -0,0 +1,19 @@
+package featureflag
+
+var cn = map[string]string{
+	"featureflag.feature flags":     "功能开关",
+	"featureflag.toggle at runtime": "运行时切换功能，可按环境和百分比灰度发布",
+	"featureflag.name":              "名称",
+	"featureflag.description":       "描述",
+	"featureflag.default":           "默认",
+	"featureflag.current":           "当前环境",
+	"featureflag.rules":             "规则",
+	"featureflag.all":               "所有环境",
+	"featureflag.on":                "开启",
+	"featureflag.off":               "关闭",
+	"featureflag.use default":       "使用默认",
+	"featureflag.percentage":        "百分比",
+	"featureflag.operation":         "操作",
+	"featureflag.save":              "保存",
+	"featureflag.no flags":          "暂无功能开关，请在代码中使用 featureflag.Define 定义",
+}
@@ -0,0 +1,18 @@
+package featureflag
+
+import (
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/service"
+)
+
+func (f *FeatureFlag) initRouter(prefix string, srv service.List) *context.App {
+
+	app := context.NewApp()
+	route := app.Group(prefix, auth.Middleware(db.GetConnection(srv)))
+	route.GET("/"+Name, f.ShowFlags).Name("featureflag_list")
+	route.POST("/"+Name+"/set", f.Set).Name("featureflag_set")
+
+	return app
+}
@@ -0,0 +1,114 @@
+package ldapsync
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	template2 "github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// ShowReport show the report of the last sync.
+func (l *LDAPSync) ShowReport(ctx *context.Context) {
+	l.showReport(ctx, l.syncer.Last())
+}
+
+// Run run the sync, or the dry-run when the form value "dry_run" is "1", and
+// show the report.
+func (l *LDAPSync) Run(ctx *context.Context) {
+	if !auth.GetTokenService(l.Services.Get(auth.TokenServiceKey)).CheckToken(ctx.FormValue(form.TokenKey)) {
+		l.alert(ctx, "wrong token")
+		return
+	}
+
+	report, err := l.syncer.Sync(ctx.FormValue("dry_run") == "1")
+	if err != nil {
+		l.alert(ctx, err.Error())
+		return
+	}
+	l.showReport(ctx, &report)
+}
+
+func (l *LDAPSync) showReport(ctx *context.Context, report *Report) {
+	var (
+		comp  = template2.Default(ctx)
+		token = auth.GetTokenService(l.Services.Get(auth.TokenServiceKey)).AddToken()
+		body  = l.buttons(token)
+	)
+
+	if report == nil {
+		body += template.HTML("<p>" + lg("no report") + "</p>")
+	} else {
+		body += summary(report)
+		if len(report.Changes) > 0 {
+			infos := make([]map[string]types.InfoItem, len(report.Changes))
+			for i, change := range report.Changes {
+				infos[i] = map[string]types.InfoItem{
+					lg("username"): {Content: escape(change.Username)},
+					lg("action"):   {Content: template.HTML(lg(change.Action))},
+					lg("roles"):    {Content: escape(strings.Join(change.Roles, ", "))},
+					lg("detail"):   {Content: escape(change.Detail)},
+				}
+			}
+			body += comp.Table().SetThead(types.Thead{
+				{Head: lg("username")},
+				{Head: lg("action")},
+				{Head: lg("roles")},
+				{Head: lg("detail")},
+			}).SetInfoList(infos).GetContent()
+		}
+	}
+
+	l.HTML(ctx, types.Panel{
+		Content:     comp.Box().SetBody(body).GetContent(),
+		Title:       template.HTML(lg("ldap sync")),
+		Description: template.HTML(lg("sync report")),
+	})
+}
+
+func (l *LDAPSync) buttons(token string) template.HTML {
+	action := template.HTMLEscapeString(config.Url("/" + Name + "/run"))
+	btn := func(dryRun, class, label string) string {
+		return `<form method="post" action="` + action + `" style="display:inline-block;margin-right:10px;">` +
+			`<input type="hidden" name="` + form.TokenKey + `" value="` + template.HTMLEscapeString(token) + `">` +
+			`<input type="hidden" name="dry_run" value="` + dryRun + `">` +
+			`<button type="submit" class="btn btn-sm ` + class + `">` + label + `</button></form>`
+	}
+	return template.HTML(`<p>` + btn("1", "btn-default", lg("dry run")) + btn("0", "btn-primary", lg("sync now")) + `</p>`)
+}
+
+func summary(report *Report) template.HTML {
+	html := fmt.Sprintf(`<p><b>%s</b>: %s &nbsp; <b>%s</b>: %s &nbsp; <b>%s</b>: %d</p>`,
+		lg("time"), report.Time.Format("2006-01-02 15:04:05"),
+		lg("duration"), report.Duration.String(),
+		lg("unchanged"), report.Unchanged)
+	if report.DryRun {
+		html += `<p class="text-warning">` + lg("dry run result") + `</p>`
+	}
+	if len(report.Errors) > 0 {
+		html += `<div class="text-danger"><b>` + lg("errors") + `</b><ul>`
+		for _, msg := range report.Errors {
+			html += "<li>" + template.HTMLEscapeString(msg) + "</li>"
+		}
+		html += "</ul></div>"
+	}
+	return template.HTML(html)
+}
+
+func (l *LDAPSync) alert(ctx *context.Context, msg string) {
+	l.HTML(ctx, template2.WarningPanel(ctx, msg).GetContent(config.IsProductionEnvironment()))
+}
+
+func escape(s string) template.HTML {
+	return template.HTML(template.HTMLEscapeString(s))
+}
+
+func lg(v string) string {
+	return language.GetWithScope(v, Name)
+}
@@ -0,0 +1,91 @@
+package ldapsync
+
+import (
+	"crypto/tls"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// Entry is a user of the directory.
+type Entry struct {
+	DN       string
+	Username string
+	Name     string
+	Groups   []string
+}
+
+// Directory lists the users to be synced.
+type Directory interface {
+	Users() ([]Entry, error)
+}
+
+// LDAP is the directory of a LDAP server.
+type LDAP struct {
+	URL                string
+	BindDN             string
+	BindPassword       string
+	BaseDN             string
+	InsecureSkipVerify bool
+
+	// UserFilter is the filter of the users, default "(objectClass=person)".
+	UserFilter string
+	// UsernameAttr is the attribute of the username, default "uid".
+	UsernameAttr string
+	// NameAttr is the attribute of the display name, default "cn".
+	NameAttr string
+	// GroupAttr is the attribute of the group DNs, default "memberOf".
+	GroupAttr string
+}
+
+// Users implements Directory.Users.
+func (l LDAP) Users() ([]Entry, error) {
+	conn, err := ldap.DialURL(l.URL, ldap.DialWithTLSConfig(&tls.Config{InsecureSkipVerify: l.InsecureSkipVerify}))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	if l.BindDN != "" {
+		if err = conn.Bind(l.BindDN, l.BindPassword); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		usernameAttr = orDefault(l.UsernameAttr, "uid")
+		nameAttr     = orDefault(l.NameAttr, "cn")
+		groupAttr    = orDefault(l.GroupAttr, "memberOf")
+	)
+
+	res, err := conn.SearchWithPaging(ldap.NewSearchRequest(l.BaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		orDefault(l.UserFilter, "(objectClass=person)"),
+		[]string{usernameAttr, nameAttr, groupAttr}, nil), 500)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(res.Entries))
+	for _, e := range res.Entries {
+		username := e.GetAttributeValue(usernameAttr)
+		if username == "" {
+			continue
+		}
+		entries = append(entries, Entry{
+			DN:       e.DN,
+			Username: username,
+			Name:     orDefault(e.GetAttributeValue(nameAttr), username),
+			Groups:   e.GetAttributeValues(groupAttr),
+		})
+	}
+	return entries, nil
+}
+
+func orDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
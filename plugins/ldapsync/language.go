@@ -0,0 +1,22 @@
+package ldapsync
+
+var cn = map[string]string{
+	"ldapsync.ldap sync":      "LDAP同步",
+	"ldapsync.sync report":    "同步报告",
+	"ldapsync.dry run":        "试运行",
+	"ldapsync.sync now":       "立即同步",
+	"ldapsync.no report":      "暂无同步记录",
+	"ldapsync.username":       "用户名",
+	"ldapsync.action":         "操作",
+	"ldapsync.roles":          "角色",
+	"ldapsync.detail":         "详情",
+	"ldapsync.time":           "时间",
+	"ldapsync.duration":       "耗时",
+	"ldapsync.unchanged":      "未变更",
+	"ldapsync.errors":         "错误",
+	"ldapsync.create":         "创建",
+	"ldapsync.update":         "更新",
+	"ldapsync.deactivate":     "停用",
+	"ldapsync.skip":           "跳过",
+	"ldapsync.dry run result": "试运行结果，未写入任何数据",
+}
@@ -0,0 +1,127 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+// Package ldapsync provides a scheduled job importing the users and groups of
+// a LDAP directory into the users and roles of GoAdmin, with a dry-run mode
+// and a page of the sync report.
+package ldapsync
+
+import (
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/service"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins"
+)
+
+// LDAPSync is a GoAdmin plugin.
+type LDAPSync struct {
+	*plugins.Base
+
+	dir          Directory
+	rules        []Rule
+	defaultRoles []string
+	linkLocal    bool
+	interval     time.Duration
+	syncer       *Syncer
+}
+
+// Name is the name of the plugin, also used as the url prefix.
+const Name = "ldapsync"
+
+// NewLDAPSync return a LDAPSync plugin of the directory.
+func NewLDAPSync(dir Directory) *LDAPSync {
+	return &LDAPSync{
+		Base: &plugins.Base{PlugName: Name},
+		dir:  dir,
+	}
+}
+
+// AddRule add a rule mapping the group to the role slugs.
+func (l *LDAPSync) AddRule(group string, roles ...string) *LDAPSync {
+	l.rules = append(l.rules, Rule{Group: group, Roles: roles})
+	return l
+}
+
+// SetDefaultRoles set the role slugs of all the synced users.
+func (l *LDAPSync) SetDefaultRoles(roles ...string) *LDAPSync {
+	l.defaultRoles = roles
+	return l
+}
+
+// SetLinkLocalUsers link the local users whose usernames match the entries,
+// they are skipped by default, see Syncer.SetLinkLocalUsers.
+func (l *LDAPSync) SetLinkLocalUsers(link bool) *LDAPSync {
+	l.linkLocal = link
+	return l
+}
+
+// SetInterval run the sync periodically, the sync only runs manually when the
+// interval is zero.
+func (l *LDAPSync) SetInterval(interval time.Duration) *LDAPSync {
+	l.interval = interval
+	return l
+}
+
+// InitPlugin implements Plugin.InitPlugin.
+func (l *LDAPSync) InitPlugin(srv service.List) {
+	l.InitBase(srv, Name)
+	language.AppendTo(language.CN, cn)
+
+	l.syncer = NewSyncer(db.GetConnection(srv), l.dir).SetDefaultRoles(l.defaultRoles...).
+		SetLinkLocalUsers(l.linkLocal)
+	for _, rule := range l.rules {
+		l.syncer.AddRule(rule.Group, rule.Roles...)
+	}
+
+	l.App = l.initRouter(config.Prefix(), srv)
+
+	if l.interval > 0 {
+		go l.run()
+	}
+}
+
+func (l *LDAPSync) run() {
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		report, err := l.syncer.Sync(false)
+		if err != nil {
+			logger.Error("ldap sync error: ", err)
+			continue
+		}
+		for _, msg := range report.Errors {
+			logger.Error("ldap sync error: ", msg)
+		}
+	}
+}
+
+// Syncer return the syncer of the plugin, it is nil before the plugin is initialized.
+func (l *LDAPSync) Syncer() *Syncer {
+	return l.syncer
+}
+
+func (l *LDAPSync) GetIndexURL() string {
+	return config.Url("/" + Name)
+}
+
+func (l *LDAPSync) IsInstalled() bool {
+	return true
+}
+
+func (l *LDAPSync) GetInfo() plugins.Info {
+	return plugins.Info{
+		Title:       "LDAP Sync",
+		Name:        Name,
+		Description: "Import the users and groups of a LDAP directory into the users and roles.",
+		Author:      "official",
+		Version:     "v0.0.1",
+		CreateDate:  utils.ParseTime("2026-10-16 00:00:00"),
+		UpdateDate:  utils.ParseTime("2026-10-16 00:00:00"),
+	}
+}
@@ -0,0 +1,18 @@
+package ldapsync
+
+import (
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/service"
+)
+
+func (l *LDAPSync) initRouter(prefix string, srv service.List) *context.App {
+
+	app := context.NewApp()
+	route := app.Group(prefix, auth.Middleware(db.GetConnection(srv)))
+	route.GET("/"+Name, l.ShowReport).Name("ldapsync_report")
+	route.POST("/"+Name+"/run", l.Run).Name("ldapsync_run")
+
+	return app
+}
@@ -0,0 +1,405 @@
+package ldapsync
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+)
+
+// TableName is the table of the users managed by the sync, see the migrations
+// of data directory for the schema.
+const TableName = "goadmin_ldap_users"
+
+// Rule map the users of the group to the roles. Group is a group DN or the
+// value of its first RDN, such as "cn=admins,ou=groups,dc=example,dc=com" or
+// "admins".
+type Rule struct {
+	Group string
+	Roles []string
+}
+
+// Actions of the changes.
+const (
+	ActionCreate     = "create"
+	ActionUpdate     = "update"
+	ActionDeactivate = "deactivate"
+	ActionSkip       = "skip"
+)
+
+// Change is a change made, or to be made in the dry-run mode, to a user.
+type Change struct {
+	Username string
+	Action   string
+	Roles    []string
+	Detail   string
+}
+
+// Report is the result of a sync.
+type Report struct {
+	DryRun    bool
+	Time      time.Time
+	Duration  time.Duration
+	Changes   []Change
+	Unchanged int
+	Errors    []string
+}
+
+// Syncer imports the users of the directory into goadmin_users and the roles
+// of goadmin_role_users by the rules. The users which are synced before but
+// removed from the directory are deactivated: the password is cleared so they
+// can not sign in, the roles are removed and the sessions are deleted.
+//
+// The local users not created by the sync whose usernames match the entries
+// are skipped and reported, as they may be other persons, unless
+// SetLinkLocalUsers is set.
+type Syncer struct {
+	conn         db.Connection
+	dir          Directory
+	rules        []Rule
+	defaultRoles []string
+	linkLocal    bool
+
+	lock sync.Mutex
+	last *Report
+}
+
+// NewSyncer return a syncer of the directory.
+func NewSyncer(conn db.Connection, dir Directory) *Syncer {
+	return &Syncer{conn: conn, dir: dir}
+}
+
+// AddRule add a rule mapping the group to the role slugs.
+func (s *Syncer) AddRule(group string, roles ...string) *Syncer {
+	s.rules = append(s.rules, Rule{Group: group, Roles: roles})
+	return s
+}
+
+// SetDefaultRoles set the role slugs of all the synced users.
+func (s *Syncer) SetDefaultRoles(roles ...string) *Syncer {
+	s.defaultRoles = roles
+	return s
+}
+
+// SetLinkLocalUsers link the local users whose usernames match the entries,
+// their roles and names are then managed by the sync.
+func (s *Syncer) SetLinkLocalUsers(link bool) *Syncer {
+	s.linkLocal = link
+	return s
+}
+
+// Last return the report of the last sync, nil when there is none.
+func (s *Syncer) Last() *Report {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.last
+}
+
+// roles return the sorted role slugs of the entry.
+func (s *Syncer) roles(e Entry) []string {
+	set := make(map[string]struct{})
+	for _, role := range s.defaultRoles {
+		set[role] = struct{}{}
+	}
+	for _, rule := range s.rules {
+		for _, group := range e.Groups {
+			if matchGroup(rule.Group, group) {
+				for _, role := range rule.Roles {
+					set[role] = struct{}{}
+				}
+				break
+			}
+		}
+	}
+	list := make([]string, 0, len(set))
+	for role := range set {
+		list = append(list, role)
+	}
+	sort.Strings(list)
+	return list
+}
+
+func matchGroup(rule, dn string) bool {
+	if strings.EqualFold(rule, dn) {
+		return true
+	}
+	rdn := strings.SplitN(dn, ",", 2)[0]
+	if i := strings.Index(rdn, "="); i > -1 {
+		rdn = rdn[i+1:]
+	}
+	return strings.EqualFold(rule, strings.TrimSpace(rdn))
+}
+
+type localUser struct {
+	id      int64
+	name    string
+	roles   []string
+	managed bool
+	active  bool
+}
+
+// Sync run the sync, nothing is written in the dry-run mode.
+func (s *Syncer) Sync(dryRun bool) (Report, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	report := Report{DryRun: dryRun, Time: time.Now(), Changes: make([]Change, 0), Errors: make([]string, 0)}
+
+	entries, err := s.dir.Users()
+	if err != nil {
+		return report, err
+	}
+
+	roleIDs, users, err := s.load()
+	if err != nil {
+		return report, err
+	}
+
+	seen := make(map[int64]struct{})
+
+	for _, e := range entries {
+		want := s.roles(e)
+		for _, role := range want {
+			if _, ok := roleIDs[role]; !ok {
+				report.Errors = append(report.Errors, fmt.Sprintf("%s: role %s does not exist", e.Username, role))
+			}
+		}
+
+		user, ok := users[e.Username]
+		if !ok {
+			report.Changes = append(report.Changes, Change{Username: e.Username, Action: ActionCreate, Roles: want})
+			if !dryRun {
+				if err := s.create(e, want, roleIDs); err != nil {
+					report.Errors = append(report.Errors, e.Username+": "+err.Error())
+				}
+			}
+			continue
+		}
+
+		if !user.managed && !s.linkLocal {
+			report.Changes = append(report.Changes, Change{Username: e.Username, Action: ActionSkip,
+				Detail: "local user not linked"})
+			continue
+		}
+
+		seen[user.id] = struct{}{}
+
+		details := make([]string, 0)
+		if !user.managed {
+			details = append(details, "linked")
+		} else if !user.active {
+			details = append(details, "reactivated")
+		}
+		if user.name != e.Name {
+			details = append(details, "name: "+user.name+" => "+e.Name)
+		}
+		if strings.Join(user.roles, ",") != strings.Join(want, ",") {
+			details = append(details, "roles: ["+strings.Join(user.roles, ",")+"] => ["+strings.Join(want, ",")+"]")
+		}
+		if len(details) == 0 {
+			report.Unchanged++
+			continue
+		}
+
+		report.Changes = append(report.Changes, Change{Username: e.Username, Action: ActionUpdate, Roles: want,
+			Detail: strings.Join(details, "; ")})
+		if !dryRun {
+			if err := s.update(user, e, want, roleIDs); err != nil {
+				report.Errors = append(report.Errors, e.Username+": "+err.Error())
+			}
+		}
+	}
+
+	for username, user := range users {
+		if _, ok := seen[user.id]; ok || !user.managed || !user.active {
+			continue
+		}
+		report.Changes = append(report.Changes, Change{Username: username, Action: ActionDeactivate})
+		if !dryRun {
+			if err := s.deactivate(user); err != nil {
+				report.Errors = append(report.Errors, username+": "+err.Error())
+			}
+		}
+	}
+
+	sort.SliceStable(report.Changes, func(i, j int) bool {
+		return report.Changes[i].Username < report.Changes[j].Username
+	})
+
+	report.Duration = time.Since(report.Time)
+	s.last = &report
+
+	return report, nil
+}
+
+func (s *Syncer) table(name string) *db.SQL {
+	return db.WithDriver(s.conn).Table(name)
+}
+
+// load return the role ids by slug and the local users by username.
+func (s *Syncer) load() (map[string]int64, map[string]*localUser, error) {
+	roles, err := s.table("goadmin_roles").Select("id", "slug").All()
+	if err != nil {
+		return nil, nil, err
+	}
+	roleIDs := make(map[string]int64, len(roles))
+	slugs := make(map[int64]string, len(roles))
+	for _, role := range roles {
+		id := db.GetValueFromDatabaseType(db.Int, role["id"], false).ToInt64()
+		slug := db.GetValueFromDatabaseType(db.Varchar, role["slug"], false).String()
+		roleIDs[slug] = id
+		slugs[id] = slug
+	}
+
+	rows, err := s.table("goadmin_users").Select("id", "username", "name").All()
+	if err != nil {
+		return nil, nil, err
+	}
+	users := make(map[string]*localUser, len(rows))
+	byID := make(map[int64]*localUser, len(rows))
+	for _, row := range rows {
+		u := &localUser{
+			id:    db.GetValueFromDatabaseType(db.Int, row["id"], false).ToInt64(),
+			name:  db.GetValueFromDatabaseType(db.Varchar, row["name"], false).String(),
+			roles: make([]string, 0),
+		}
+		users[db.GetValueFromDatabaseType(db.Varchar, row["username"], false).String()] = u
+		byID[u.id] = u
+	}
+
+	roleUsers, err := s.table("goadmin_role_users").Select("role_id", "user_id").All()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, row := range roleUsers {
+		u, ok := byID[db.GetValueFromDatabaseType(db.Int, row["user_id"], false).ToInt64()]
+		if !ok {
+			continue
+		}
+		if slug, ok := slugs[db.GetValueFromDatabaseType(db.Int, row["role_id"], false).ToInt64()]; ok {
+			u.roles = append(u.roles, slug)
+		}
+	}
+	for _, u := range users {
+		sort.Strings(u.roles)
+	}
+
+	managed, err := s.table(TableName).Select("user_id", "active").All()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, row := range managed {
+		if u, ok := byID[db.GetValueFromDatabaseType(db.Int, row["user_id"], false).ToInt64()]; ok {
+			u.managed = true
+			u.active = db.GetValueFromDatabaseType(db.Int, row["active"], false).ToInt64() == 1
+		}
+	}
+
+	return roleIDs, users, nil
+}
+
+func (s *Syncer) create(e Entry, roles []string, roleIDs map[string]int64) error {
+	user, err := models.User().SetConn(s.conn).New(e.Username, auth.EncodePassword([]byte(utils.Uuid(32))), e.Name, "")
+	if db.CheckError(err, db.INSERT) {
+		return err
+	}
+	if err := s.setRoles(user, roles, roleIDs); err != nil {
+		return err
+	}
+	_, err = s.table(TableName).Insert(dialect.H{
+		"user_id":   user.Id,
+		"dn":        e.DN,
+		"active":    1,
+		"synced_at": now(),
+	})
+	if db.CheckError(err, db.INSERT) {
+		return err
+	}
+	return nil
+}
+
+func (s *Syncer) update(u *localUser, e Entry, roles []string, roleIDs map[string]int64) error {
+	user := models.UserWithId(fmt.Sprintf("%d", u.id)).SetConn(s.conn)
+	_, err := s.table("goadmin_users").Where("id", "=", u.id).Update(dialect.H{
+		"name":       e.Name,
+		"updated_at": now(),
+	})
+	if db.CheckError(err, db.UPDATE) {
+		return err
+	}
+	if err := s.setRoles(user, roles, roleIDs); err != nil {
+		return err
+	}
+	if u.managed {
+		_, err = s.table(TableName).Where("user_id", "=", u.id).Update(dialect.H{
+			"dn":        e.DN,
+			"active":    1,
+			"synced_at": now(),
+		})
+		if db.CheckError(err, db.UPDATE) {
+			return err
+		}
+		return nil
+	}
+	_, err = s.table(TableName).Insert(dialect.H{
+		"user_id":   u.id,
+		"dn":        e.DN,
+		"active":    1,
+		"synced_at": now(),
+	})
+	if db.CheckError(err, db.INSERT) {
+		return err
+	}
+	return nil
+}
+
+func (s *Syncer) deactivate(u *localUser) error {
+	_, err := s.table("goadmin_users").Where("id", "=", u.id).Update(dialect.H{
+		"password":   "",
+		"updated_at": now(),
+	})
+	if db.CheckError(err, db.UPDATE) {
+		return err
+	}
+	if err := models.UserWithId(fmt.Sprintf("%d", u.id)).SetConn(s.conn).DeleteRoles(); db.CheckError(err, db.DELETE) {
+		return err
+	}
+	if err := auth.DeleteUserSessions(s.conn, u.id); err != nil {
+		return err
+	}
+	_, err = s.table(TableName).Where("user_id", "=", u.id).Update(dialect.H{
+		"active":    0,
+		"synced_at": now(),
+	})
+	if db.CheckError(err, db.UPDATE) {
+		return err
+	}
+	return nil
+}
+
+func (s *Syncer) setRoles(user models.UserModel, roles []string, roleIDs map[string]int64) error {
+	if err := user.DeleteRoles(); db.CheckError(err, db.DELETE) {
+		return err
+	}
+	for _, role := range roles {
+		id, ok := roleIDs[role]
+		if !ok {
+			continue
+		}
+		if _, err := user.AddRole(fmt.Sprintf("%d", id)); db.CheckError(err, db.INSERT) {
+			return err
+		}
+	}
+	return nil
+}
+
+func now() string {
+	return time.Now().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,136 @@
+package ldapsync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+	_ "github.com/purpose168/GoAdmin/modules/db/drivers/sqlite"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+)
+
+func TestMatchGroup(t *testing.T) {
+	assert.Equal(t, matchGroup("admins", "cn=admins,ou=groups,dc=example,dc=com"), true)
+	assert.Equal(t, matchGroup("CN=Admins,OU=Groups,DC=example,DC=com", "cn=admins,ou=groups,dc=example,dc=com"), true)
+	assert.Equal(t, matchGroup("admins", "cn=operators,ou=groups,dc=example,dc=com"), false)
+	assert.Equal(t, matchGroup("groups", "cn=operators,ou=groups,dc=example,dc=com"), false)
+}
+
+func TestRoles(t *testing.T) {
+	s := NewSyncer(nil, nil).
+		SetDefaultRoles("operator").
+		AddRule("admins", "administrator", "operator").
+		AddRule("auditors", "auditor")
+
+	assert.Equal(t, s.roles(Entry{Groups: []string{"cn=admins,dc=example,dc=com"}}), []string{"administrator", "operator"})
+	assert.Equal(t, s.roles(Entry{Groups: []string{"cn=auditors,dc=example,dc=com", "cn=others,dc=example,dc=com"}}),
+		[]string{"auditor", "operator"})
+	assert.Equal(t, s.roles(Entry{}), []string{"operator"})
+}
+
+type staticDir []Entry
+
+func (d staticDir) Users() ([]Entry, error) {
+	return d, nil
+}
+
+func testConn(t *testing.T) db.Connection {
+	data, err := os.ReadFile("../../data/admin.db")
+	assert.Equal(t, err, nil)
+	file := filepath.Join(t.TempDir(), "admin.db")
+	assert.Equal(t, os.WriteFile(file, data, 0644), nil)
+
+	conn := db.GetSqliteDB()
+	conn.InitDB(config.DatabaseList{"default": {Driver: db.DriverSqlite, File: file}})
+	migration, err := os.ReadFile("../../data/migrations/admin_2026_10_16_000000_sqlite.sql")
+	assert.Equal(t, err, nil)
+	_, err = conn.Exec(string(migration))
+	assert.Equal(t, err, nil)
+	return conn
+}
+
+func count(t *testing.T, conn db.Connection, table string) int {
+	rows, err := db.WithDriver(conn).Table(table).All()
+	assert.Equal(t, err, nil)
+	return len(rows)
+}
+
+func TestSyncer_Sync(t *testing.T) {
+	config.Initialize(&config.Config{UrlPrefix: "admin"})
+
+	conn := testConn(t)
+	dir := staticDir{
+		{DN: "uid=alice,dc=example,dc=com", Username: "alice", Name: "Alice",
+			Groups: []string{"cn=admins,dc=example,dc=com"}},
+		{DN: "uid=admin,dc=example,dc=com", Username: "admin", Name: "Admin"},
+	}
+	s := NewSyncer(conn, dir).SetDefaultRoles("operator").AddRule("admins", "administrator")
+
+	// the dry-run leaves the database unchanged.
+	users := count(t, conn, "goadmin_users")
+	report, err := s.Sync(true)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, report.Changes, []Change{
+		{Username: "admin", Action: ActionSkip, Detail: "local user not linked"},
+		{Username: "alice", Action: ActionCreate, Roles: []string{"administrator", "operator"}},
+	})
+	assert.Equal(t, count(t, conn, "goadmin_users"), users)
+	assert.Equal(t, count(t, conn, TableName), 0)
+
+	// create, the local user of the same username is skipped.
+	report, err = s.Sync(false)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(report.Errors), 0)
+	alice := models.User().SetConn(conn).FindByUserName("alice").WithRoles()
+	assert.Equal(t, alice.Name, "Alice")
+	assert.Equal(t, len(alice.Roles), 2)
+	assert.Equal(t, count(t, conn, TableName), 1)
+
+	// link the local user.
+	s.SetLinkLocalUsers(true)
+	report, err = s.Sync(false)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, report.Changes[0].Username, "admin")
+	assert.Equal(t, report.Changes[0].Action, ActionUpdate)
+	assert.Equal(t, strings.HasPrefix(report.Changes[0].Detail, "linked"), true)
+	assert.Equal(t, count(t, conn, TableName), 2)
+
+	// update
+	dir[0].Name = "Alice Liddell"
+	report, err = s.Sync(false)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, report.Changes, []Change{{Username: "alice", Action: ActionUpdate,
+		Roles: []string{"administrator", "operator"}, Detail: "name: Alice => Alice Liddell"}})
+	assert.Equal(t, models.User().SetConn(conn).FindByUserName("alice").Name, "Alice Liddell")
+
+	// deactivate, the sessions of the user are deleted.
+	_, err = db.WithDriver(conn).Table("goadmin_session").Insert(dialect.H{
+		"sid":    "alice",
+		"values": fmt.Sprintf(`{"user_id":%d}`, alice.Id),
+	})
+	assert.Equal(t, err, nil)
+	_, err = db.WithDriver(conn).Table("goadmin_session").Insert(dialect.H{
+		"sid":    "others",
+		"values": fmt.Sprintf(`{"user_id":%d}`, alice.Id+100),
+	})
+	assert.Equal(t, err, nil)
+
+	s.dir = dir[1:]
+	report, err = s.Sync(false)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, report.Changes, []Change{{Username: "alice", Action: ActionDeactivate}})
+	alice = models.User().SetConn(conn).FindByUserName("alice").WithRoles()
+	assert.Equal(t, alice.Password, "")
+	assert.Equal(t, len(alice.Roles), 0)
+	item, _ := db.WithDriver(conn).Table(TableName).Where("user_id", "=", alice.Id).First()
+	assert.Equal(t, db.GetValueFromDatabaseType(db.Int, item["active"], false).ToInt64(), int64(0))
+	sessions, _ := db.WithDriver(conn).Table("goadmin_session").All()
+	assert.Equal(t, len(sessions), 1)
+	assert.Equal(t, sessions[0]["sid"], "others")
+}
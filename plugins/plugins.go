@@ -53,6 +53,13 @@ type Plugin interface {
 	Upgrade() error
 }
 
+// Closer is implemented by the plugins which run services in the background,
+// such as the listening servers. Close stops them, it is called by
+// Engine.Close.
+type Closer interface {
+	Close() error
+}
+
 type Info struct {
 	Title            string    `json:"title" yaml:"title" ini:"title"`
 	Description      string    `json:"description" yaml:"description" ini:"description"`
@@ -0,0 +1,271 @@
+package quality
+
+import (
+	"html/template"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/mail"
+	"github.com/purpose168/GoAdmin/modules/quality"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	template2 "github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// defaultSchedule is the schedule filled in the form, every day 7:00.
+const defaultSchedule = "0 7 * * *"
+
+// statusLabels is the label classes of the statuses of the rules.
+var statusLabels = map[string]string{
+	quality.StatusPending:  "label-default",
+	quality.StatusOK:       "label-success",
+	quality.StatusExceeded: "label-danger",
+	quality.StatusError:    "label-warning",
+}
+
+// ShowDashboard show the rules with their last results and the form of a new
+// rule.
+func (q *Quality) ShowDashboard(ctx *context.Context) {
+	q.dashboard(ctx, quality.Rule{Kind: quality.KindNull, Schedule: defaultSchedule}, "")
+}
+
+func (q *Quality) dashboard(ctx *context.Context, rule quality.Rule, errMsg string) {
+	rules, err := quality.NewStore(db.GetConnection(q.Services)).List()
+	if err != nil {
+		q.alert(ctx, err.Error())
+		return
+	}
+
+	var (
+		comp    = template2.Default(ctx)
+		token   = q.token()
+		content template.HTML
+		counts  = make(map[string]int)
+	)
+
+	if !mail.Enabled() {
+		content += template.HTML(`<div class="callout callout-warning">` + lg("mail not configured") + `</div>`)
+	}
+
+	body := template.HTML("<p>" + lg("no rules") + "</p>")
+	if len(rules) > 0 {
+		infos := make([]map[string]types.InfoItem, len(rules))
+		for i, r := range rules {
+			counts[r.LastStatus]++
+			status := `<span class="label ` + statusLabels[r.LastStatus] + `">` + lg(statusText(r.LastStatus)) + `</span>`
+			if r.LastError != "" {
+				status += `<div class="text-red small">` + template.HTMLEscapeString(r.LastError) + `</div>`
+			}
+			lastRun := "-"
+			if !r.LastRunAt.IsZero() {
+				lastRun = r.LastRunAt.Format("2006-01-02 15:04")
+			}
+			schedule := "<code>" + template.HTMLEscapeString(r.Schedule) + "</code>"
+			if desc, _, err := types.CronPreview(r.Schedule, ctx.Lang()); err == nil {
+				schedule += " " + template.HTMLEscapeString(desc)
+			}
+			infos[i] = map[string]types.InfoItem{
+				lg("name"):      {Content: escape(r.Name)},
+				lg("check"):     {Content: template.HTML("<code>" + template.HTMLEscapeString(checkText(r)) + "</code>")},
+				lg("status"):    {Content: template.HTML(status)},
+				lg("count"):     {Content: template.HTML(strconv.FormatInt(r.LastCount, 10) + " / " + strconv.FormatInt(r.Threshold, 10))},
+				lg("schedule"):  {Content: template.HTML(schedule)},
+				lg("last run"):  {Content: escape(lastRun)},
+				lg("next run"):  {Content: escape(r.NextRunAt.Format("2006-01-02 15:04"))},
+				lg("owners"):    {Content: escape(r.Owners)},
+				lg("operation"): {Content: postForm("run", r.ID, token, lg("run now"), "btn-default") + " " + postForm("delete", r.ID, token, lg("delete"), "btn-danger")},
+			}
+		}
+		body = comp.Table().SetThead(types.Thead{
+			{Head: lg("name")},
+			{Head: lg("check")},
+			{Head: lg("status")},
+			{Head: lg("count")},
+			{Head: lg("schedule")},
+			{Head: lg("last run")},
+			{Head: lg("next run")},
+			{Head: lg("owners")},
+			{Head: lg("operation")},
+		}).SetInfoList(infos).GetContent()
+	}
+
+	summary := template.HTML(`<p>`)
+	for _, status := range []string{quality.StatusExceeded, quality.StatusError, quality.StatusOK, quality.StatusPending} {
+		summary += template.HTML(`<span class="label ` + statusLabels[status] + `" style="font-size: 14px; margin-right: 10px;">` +
+			lg(statusText(status)) + ` ` + strconv.Itoa(counts[status]) + `</span>`)
+	}
+	summary += `</p>`
+
+	content += summary +
+		comp.Box().WithHeadBorder().SetHeader(template.HTML(lg("rules"))).SetBody(body).GetContent() +
+		comp.Box().WithHeadBorder().SetHeader(template.HTML(lg("new rule"))).SetBody(newForm(rule, token, errMsg)).GetContent()
+
+	q.HTML(ctx, types.Panel{
+		Content:     content,
+		Title:       template.HTML(lg("data quality")),
+		Description: template.HTML(lg("rules over the tables and their anomalies")),
+	})
+}
+
+// Add save the rule posted by the form of the dashboard, the dashboard is
+// shown again with the form and the error when the rule is wrong.
+func (q *Quality) Add(ctx *context.Context) {
+	threshold, _ := strconv.ParseInt(strings.TrimSpace(ctx.FormValue("threshold")), 10, 64)
+	rule := quality.Rule{
+		Name:      strings.TrimSpace(ctx.FormValue("name")),
+		Table:     strings.TrimSpace(ctx.FormValue("table")),
+		Kind:      ctx.FormValue("kind"),
+		Field:     strings.TrimSpace(ctx.FormValue("field")),
+		Condition: strings.TrimSpace(ctx.FormValue("condition")),
+		Threshold: threshold,
+		Schedule:  strings.TrimSpace(ctx.FormValue("schedule")),
+		Owners:    ctx.FormValue("owners"),
+	}
+
+	if !q.checkToken(ctx) {
+		q.dashboard(ctx, rule, "wrong token")
+		return
+	}
+
+	if err := quality.NewStore(db.GetConnection(q.Services)).Add(rule, time.Now()); err != nil {
+		q.dashboard(ctx, rule, err.Error())
+		return
+	}
+
+	ctx.Redirect(config.Url("/" + Name))
+}
+
+// Run run the rule now, the next run is the next time of the schedule after
+// now.
+func (q *Quality) Run(ctx *context.Context) {
+	rule, ok := q.rule(ctx)
+	if !ok {
+		return
+	}
+	_, _ = q.checker.Run(rule, time.Now())
+	ctx.Redirect(config.Url("/" + Name))
+}
+
+// Delete delete the rule.
+func (q *Quality) Delete(ctx *context.Context) {
+	rule, ok := q.rule(ctx)
+	if !ok {
+		return
+	}
+	if err := quality.NewStore(db.GetConnection(q.Services)).Delete(rule.ID); err != nil {
+		q.alert(ctx, err.Error())
+		return
+	}
+	ctx.Redirect(config.Url("/" + Name))
+}
+
+// rule check the token and return the rule of the posted id.
+func (q *Quality) rule(ctx *context.Context) (quality.Rule, bool) {
+	if !q.checkToken(ctx) {
+		q.alert(ctx, "wrong token")
+		return quality.Rule{}, false
+	}
+	id, _ := strconv.ParseInt(ctx.FormValue("id"), 10, 64)
+	rule, ok, err := quality.NewStore(db.GetConnection(q.Services)).Get(id)
+	if err != nil {
+		q.alert(ctx, err.Error())
+		return quality.Rule{}, false
+	}
+	if !ok {
+		q.alert(ctx, "wrong parameter")
+		return quality.Rule{}, false
+	}
+	return rule, true
+}
+
+func (q *Quality) checkToken(ctx *context.Context) bool {
+	return auth.GetTokenService(q.Services.Get(auth.TokenServiceKey)).CheckToken(ctx.FormValue(form.TokenKey))
+}
+
+func (q *Quality) token() string {
+	return auth.GetTokenService(q.Services.Get(auth.TokenServiceKey)).AddToken()
+}
+
+func statusText(status string) string {
+	if status == quality.StatusPending {
+		return "pending"
+	}
+	return status
+}
+
+// checkText return the text of the check of the rule, such as
+// "orders.customer_id is null".
+func checkText(r quality.Rule) string {
+	switch r.Kind {
+	case quality.KindNull:
+		return r.Table + "." + r.Field + " is null"
+	case quality.KindDuplicate:
+		return r.Table + "." + r.Field + " is duplicate"
+	default:
+		return r.Table + " where " + r.Condition
+	}
+}
+
+func postForm(action string, id int64, token, label, class string) template.HTML {
+	return template.HTML(`<form method="post" action="` + template.HTMLEscapeString(config.Url("/"+Name+"/"+action)) + `" style="display:inline;">` +
+		`<input type="hidden" name="` + form.TokenKey + `" value="` + template.HTMLEscapeString(token) + `">` +
+		`<input type="hidden" name="id" value="` + strconv.FormatInt(id, 10) + `">` +
+		`<button type="submit" class="btn btn-xs ` + class + `">` + label + `</button></form>`)
+}
+
+func newForm(r quality.Rule, token, errMsg string) template.HTML {
+	input := func(name, label, value, tip string) string {
+		html := `<div class="form-group"><label class="col-sm-2 control-label">` + lg(label) + `</label><div class="col-sm-8">` +
+			`<input type="text" name="` + name + `" class="form-control" value="` + template.HTMLEscapeString(value) + `">`
+		if tip != "" {
+			html += `<span class="help-block">` + lg(tip) + `</span>`
+		}
+		return html + `</div></div>`
+	}
+
+	kinds := ""
+	for _, kind := range quality.Kinds {
+		selected := ""
+		if kind == r.Kind {
+			selected = " selected"
+		}
+		kinds += `<option value="` + kind + `"` + selected + `>` + lg("kind "+kind) + `</option>`
+	}
+
+	html := ""
+	if errMsg != "" {
+		html += `<div class="alert alert-warning">` + template.HTMLEscapeString(language.Get(errMsg)) + `</div>`
+	}
+	html += `<form method="post" action="` + template.HTMLEscapeString(config.Url("/"+Name+"/add")) + `" class="form-horizontal">` +
+		`<input type="hidden" name="` + form.TokenKey + `" value="` + template.HTMLEscapeString(token) + `">` +
+		input("name", "name", r.Name, "") +
+		input("table", "table", r.Table, "") +
+		`<div class="form-group"><label class="col-sm-2 control-label">` + lg("kind") + `</label><div class="col-sm-8">` +
+		`<select name="kind" class="form-control">` + kinds + `</select></div></div>` +
+		input("field", "field", r.Field, "field tip") +
+		input("condition", "condition", r.Condition, "condition tip") +
+		input("threshold", "threshold", strconv.FormatInt(r.Threshold, 10), "threshold tip") +
+		input("schedule", "schedule", r.Schedule, "schedule tip") +
+		input("owners", "owners", r.Owners, "owners tip") +
+		`<div class="form-group"><div class="col-sm-offset-2 col-sm-8">` +
+		`<button type="submit" class="btn btn-sm btn-primary">` + lg("save") + `</button></div></div></form>`
+	return template.HTML(html)
+}
+
+func (q *Quality) alert(ctx *context.Context, msg string) {
+	q.HTML(ctx, template2.WarningPanel(ctx, msg).GetContent(config.IsProductionEnvironment()))
+}
+
+func escape(s string) template.HTML {
+	return template.HTML(template.HTMLEscapeString(s))
+}
+
+func lg(v string) string {
+	return language.GetWithScope(v, Name)
+}
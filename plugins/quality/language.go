@@ -0,0 +1,39 @@
+package quality
+
+var cn = map[string]string{
+	"quality.data quality":                              "数据质量",
+	"quality.rules over the tables and their anomalies": "数据表的质量规则及异常",
+	"quality.rules":                                     "规则",
+	"quality.new rule":                                  "新建规则",
+	"quality.no rules":                                  "暂无规则",
+	"quality.name":                                      "名称",
+	"quality.check":                                     "检查",
+	"quality.status":                                    "状态",
+	"quality.count":                                     "数量 / 阈值",
+	"quality.schedule":                                  "执行计划",
+	"quality.last run":                                  "上次执行",
+	"quality.next run":                                  "下次执行",
+	"quality.owners":                                    "负责人",
+	"quality.operation":                                 "操作",
+	"quality.run now":                                   "立即执行",
+	"quality.delete":                                    "删除",
+	"quality.save":                                      "保存",
+	"quality.table":                                     "表",
+	"quality.kind":                                      "类型",
+	"quality.kind null":                                 "字段为空",
+	"quality.kind duplicate":                            "字段重复",
+	"quality.kind condition":                            "满足条件",
+	"quality.field":                                     "字段",
+	"quality.field tip":                                 "字段为空和字段重复的规则检查的字段",
+	"quality.condition":                                 "条件",
+	"quality.condition tip":                             "满足条件的规则的 SQL 条件，例如 amount < 0",
+	"quality.threshold":                                 "阈值",
+	"quality.threshold tip":                             "异常行数超过阈值时通知负责人",
+	"quality.schedule tip":                              "cron 表达式：分 时 日 月 周，例如 0 7 * * * 为每天 7:00",
+	"quality.owners tip":                                "负责人的邮箱，多个用逗号分隔",
+	"quality.pending":                                   "未执行",
+	"quality.ok":                                        "正常",
+	"quality.exceeded":                                  "超过阈值",
+	"quality.error":                                     "错误",
+	"quality.mail not configured":                       "未配置邮件服务（config.Mail），超过阈值时不会通知负责人。",
+}
@@ -0,0 +1,80 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+// Package quality provides the data quality dashboard of the rules of
+// modules/quality. The admins define the rules over the tables, such as "the
+// orders with null customer_id" or "the duplicate emails", the rules are run
+// by their schedules and the owners are mailed when the counts exceed the
+// thresholds:
+//
+//	eng.AddPlugins(quality.NewQuality())
+package quality
+
+import (
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/quality"
+	"github.com/purpose168/GoAdmin/modules/service"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins"
+)
+
+// Quality is a GoAdmin plugin.
+type Quality struct {
+	*plugins.Base
+
+	interval time.Duration
+	checker  *quality.Checker
+}
+
+// Name is the name of the plugin, also used as the url prefix.
+const Name = "quality"
+
+// NewQuality return a Quality plugin.
+func NewQuality() *Quality {
+	return &Quality{
+		Base:     &plugins.Base{PlugName: Name},
+		interval: time.Minute,
+	}
+}
+
+// SetInterval set how often the due rules are checked, default 1 minute.
+func (q *Quality) SetInterval(interval time.Duration) *Quality {
+	q.interval = interval
+	return q
+}
+
+// InitPlugin implements Plugin.InitPlugin.
+func (q *Quality) InitPlugin(srv service.List) {
+	q.InitBase(srv, Name)
+	language.AppendTo(language.CN, cn)
+
+	q.checker = quality.NewChecker(db.GetConnection(srv))
+	q.checker.Start(q.interval)
+
+	q.App = q.initRouter(config.Prefix(), srv)
+}
+
+func (q *Quality) GetIndexURL() string {
+	return config.Url("/" + Name)
+}
+
+func (q *Quality) IsInstalled() bool {
+	return true
+}
+
+func (q *Quality) GetInfo() plugins.Info {
+	return plugins.Info{
+		Title:       "Data Quality",
+		Name:        Name,
+		Description: "Check the tables by the data quality rules on schedule and notify the owners of the anomalies.",
+		Author:      "official",
+		Version:     "v0.0.1",
+		CreateDate:  utils.ParseTime("2026-10-24 00:00:00"),
+		UpdateDate:  utils.ParseTime("2026-10-24 00:00:00"),
+	}
+}
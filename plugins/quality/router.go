@@ -0,0 +1,20 @@
+package quality
+
+import (
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/service"
+)
+
+func (q *Quality) initRouter(prefix string, srv service.List) *context.App {
+
+	app := context.NewApp()
+	route := app.Group(prefix, auth.Middleware(db.GetConnection(srv)))
+	route.GET("/"+Name, q.ShowDashboard).Name("quality_dashboard")
+	route.POST("/"+Name+"/add", q.Add).Name("quality_add")
+	route.POST("/"+Name+"/run", q.Run).Name("quality_run")
+	route.POST("/"+Name+"/delete", q.Delete).Name("quality_delete")
+
+	return app
+}
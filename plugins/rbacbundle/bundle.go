@@ -0,0 +1,402 @@
+package rbacbundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+)
+
+// Version is the version of the bundle format.
+const Version = 1
+
+// FileName is the name of the json file inside the zip bundle.
+const FileName = "rbac.json"
+
+// Bundle is the users, roles, permissions and menus of an environment. The
+// records refer to each other by the natural keys instead of the ids, which
+// differ between the environments: the username of the users, the slug of
+// the roles and the permissions, and the key of the menus.
+type Bundle struct {
+	Version     int          `json:"version"`
+	ExportedAt  time.Time    `json:"exported_at"`
+	Permissions []Permission `json:"permissions"`
+	Roles       []Role       `json:"roles"`
+	Menus       []Menu       `json:"menus"`
+	Users       []User       `json:"users"`
+}
+
+// Permission is a record of goadmin_permissions.
+type Permission struct {
+	Name       string `json:"name"`
+	Slug       string `json:"slug"`
+	HttpMethod string `json:"http_method"`
+	HttpPath   string `json:"http_path"`
+}
+
+// Role is a record of goadmin_roles with the slugs of its permissions and
+// the keys of its menus.
+type Role struct {
+	Name        string   `json:"name"`
+	Slug        string   `json:"slug"`
+	Permissions []string `json:"permissions"`
+	Menus       []string `json:"menus"`
+}
+
+// Menu is a record of goadmin_menu. Key is the titles of the menu and its
+// parents joined by " / ", such as "Admin / Users", and Parent is the key of
+// the parent menu, empty for the top level menus.
+type Menu struct {
+	Key        string `json:"key"`
+	Parent     string `json:"parent"`
+	Title      string `json:"title"`
+	Type       int64  `json:"type"`
+	Order      int64  `json:"order"`
+	Icon       string `json:"icon"`
+	Uri        string `json:"uri"`
+	Header     string `json:"header"`
+	PluginName string `json:"plugin_name"`
+	Uuid       string `json:"uuid"`
+}
+
+// User is a record of goadmin_users with the slugs of its roles and
+// permissions. Password is the hash of the password, empty when the bundle is
+// exported without the passwords.
+type User struct {
+	Username    string   `json:"username"`
+	Name        string   `json:"name"`
+	Avatar      string   `json:"avatar"`
+	Password    string   `json:"password,omitempty"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+// ErrInvalidBundle is returned when the data is neither a json nor a zip bundle.
+var ErrInvalidBundle = errors.New("invalid rbac bundle")
+
+// MenuKey return the key of the menu of the title under the parent key.
+func MenuKey(parent, title string) string {
+	if parent == "" {
+		return title
+	}
+	return parent + " / " + title
+}
+
+// JSON return the bundle encoded as the indented json.
+func (b *Bundle) JSON() ([]byte, error) {
+	return json.MarshalIndent(b, "", "  ")
+}
+
+// Zip return the zip archive holding the json of the bundle.
+func (b *Bundle) Zip() ([]byte, error) {
+	data, err := b.JSON()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	f, err := w.Create(FileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Parse return the bundle of the json or zip data.
+func Parse(data []byte) (*Bundle, error) {
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, err
+		}
+		var file *zip.File
+		for _, f := range r.File {
+			if f.Name == FileName || (file == nil && strings.HasSuffix(f.Name, ".json")) {
+				file = f
+			}
+		}
+		if file == nil {
+			return nil, ErrInvalidBundle
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = rc.Close()
+		}()
+		if data, err = io.ReadAll(rc); err != nil {
+			return nil, err
+		}
+	}
+
+	var b Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, ErrInvalidBundle
+	}
+	if b.Version == 0 || b.Version > Version {
+		return nil, ErrInvalidBundle
+	}
+	return &b, nil
+}
+
+// Export return the bundle of the users, roles, permissions and menus of the
+// connection. The hashes of the passwords are included only when
+// withPasswords is true.
+func Export(conn db.Connection, withPasswords bool) (*Bundle, error) {
+	s, err := load(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Bundle{
+		Version:     Version,
+		ExportedAt:  time.Now(),
+		Permissions: make([]Permission, 0, len(s.permissions)),
+		Roles:       make([]Role, 0, len(s.roles)),
+		Menus:       make([]Menu, 0, len(s.menus)),
+		Users:       make([]User, 0, len(s.users)),
+	}
+	for _, p := range s.permissions {
+		b.Permissions = append(b.Permissions, p.Permission)
+	}
+	for _, r := range s.roles {
+		b.Roles = append(b.Roles, r.Role)
+	}
+	for _, m := range s.menus {
+		b.Menus = append(b.Menus, m.Menu)
+	}
+	for _, u := range s.users {
+		user := u.User
+		if !withPasswords {
+			user.Password = ""
+		}
+		b.Users = append(b.Users, user)
+	}
+
+	sort.Slice(b.Permissions, func(i, j int) bool { return b.Permissions[i].Slug < b.Permissions[j].Slug })
+	sort.Slice(b.Roles, func(i, j int) bool { return b.Roles[i].Slug < b.Roles[j].Slug })
+	sortMenus(b.Menus)
+	sort.Slice(b.Users, func(i, j int) bool { return b.Users[i].Username < b.Users[j].Username })
+
+	return b, nil
+}
+
+// sortMenus sort the menus so that the parents come before the children.
+func sortMenus(menus []Menu) {
+	depth := func(m Menu) int {
+		return strings.Count(m.Key, " / ")
+	}
+	sort.SliceStable(menus, func(i, j int) bool {
+		if di, dj := depth(menus[i]), depth(menus[j]); di != dj {
+			return di < dj
+		}
+		if menus[i].Parent != menus[j].Parent {
+			return menus[i].Parent < menus[j].Parent
+		}
+		if menus[i].Order != menus[j].Order {
+			return menus[i].Order < menus[j].Order
+		}
+		return menus[i].Key < menus[j].Key
+	})
+}
+
+type localPermission struct {
+	id int64
+	Permission
+}
+
+type localRole struct {
+	id int64
+	Role
+}
+
+type localMenu struct {
+	id int64
+	Menu
+}
+
+type localUser struct {
+	id int64
+	User
+}
+
+// state is the records of the connection by the natural keys.
+type state struct {
+	permissions map[string]*localPermission
+	roles       map[string]*localRole
+	menus       map[string]*localMenu
+	users       map[string]*localUser
+}
+
+func table(conn db.Connection, name string) *db.SQL {
+	return db.WithDriver(conn).Table(name)
+}
+
+func str(row map[string]interface{}, field string) string {
+	return db.GetValueFromDatabaseType(db.Varchar, row[field], false).String()
+}
+
+func num(row map[string]interface{}, field string) int64 {
+	return db.GetValueFromDatabaseType(db.Int, row[field], false).ToInt64()
+}
+
+// load return the records of the connection.
+func load(conn db.Connection) (*state, error) {
+	s := &state{
+		permissions: make(map[string]*localPermission),
+		roles:       make(map[string]*localRole),
+		menus:       make(map[string]*localMenu),
+		users:       make(map[string]*localUser),
+	}
+
+	rows, err := table(conn, "goadmin_permissions").All()
+	if err != nil {
+		return nil, err
+	}
+	permissions := make(map[int64]string, len(rows))
+	for _, row := range rows {
+		p := &localPermission{id: num(row, "id"), Permission: Permission{
+			Name:       str(row, "name"),
+			Slug:       str(row, "slug"),
+			HttpMethod: str(row, "http_method"),
+			HttpPath:   str(row, "http_path"),
+		}}
+		s.permissions[p.Slug] = p
+		permissions[p.id] = p.Slug
+	}
+
+	if rows, err = table(conn, "goadmin_menu").All(); err != nil {
+		return nil, err
+	}
+	menuRows := make(map[int64]map[string]interface{}, len(rows))
+	for _, row := range rows {
+		menuRows[num(row, "id")] = row
+	}
+	menus := make(map[int64]string, len(rows))
+	var key func(id int64, depth int) string
+	key = func(id int64, depth int) string {
+		if k, ok := menus[id]; ok {
+			return k
+		}
+		row, ok := menuRows[id]
+		if !ok || depth > len(menuRows) {
+			return ""
+		}
+		k := MenuKey(key(num(row, "parent_id"), depth+1), str(row, "title"))
+		menus[id] = k
+		return k
+	}
+	for id, row := range menuRows {
+		m := &localMenu{id: id, Menu: Menu{
+			Key:        key(id, 0),
+			Parent:     key(num(row, "parent_id"), 0),
+			Title:      str(row, "title"),
+			Type:       num(row, "type"),
+			Order:      num(row, "order"),
+			Icon:       str(row, "icon"),
+			Uri:        str(row, "uri"),
+			Header:     str(row, "header"),
+			PluginName: str(row, "plugin_name"),
+			Uuid:       str(row, "uuid"),
+		}}
+		s.menus[m.Key] = m
+	}
+
+	if rows, err = table(conn, "goadmin_roles").All(); err != nil {
+		return nil, err
+	}
+	roles := make(map[int64]*localRole, len(rows))
+	slugs := make(map[int64]string, len(rows))
+	for _, row := range rows {
+		r := &localRole{id: num(row, "id"), Role: Role{
+			Name:        str(row, "name"),
+			Slug:        str(row, "slug"),
+			Permissions: make([]string, 0),
+			Menus:       make([]string, 0),
+		}}
+		s.roles[r.Slug] = r
+		roles[r.id] = r
+		slugs[r.id] = r.Slug
+	}
+
+	if rows, err = table(conn, "goadmin_role_permissions").All(); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		r, ok := roles[num(row, "role_id")]
+		if slug, has := permissions[num(row, "permission_id")]; ok && has {
+			r.Permissions = append(r.Permissions, slug)
+		}
+	}
+
+	if rows, err = table(conn, "goadmin_role_menu").All(); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		r, ok := roles[num(row, "role_id")]
+		if k, has := menus[num(row, "menu_id")]; ok && has {
+			r.Menus = append(r.Menus, k)
+		}
+	}
+
+	if rows, err = table(conn, "goadmin_users").All(); err != nil {
+		return nil, err
+	}
+	users := make(map[int64]*localUser, len(rows))
+	for _, row := range rows {
+		u := &localUser{id: num(row, "id"), User: User{
+			Username:    str(row, "username"),
+			Name:        str(row, "name"),
+			Avatar:      str(row, "avatar"),
+			Password:    str(row, "password"),
+			Roles:       make([]string, 0),
+			Permissions: make([]string, 0),
+		}}
+		s.users[u.Username] = u
+		users[u.id] = u
+	}
+
+	if rows, err = table(conn, "goadmin_role_users").All(); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		u, ok := users[num(row, "user_id")]
+		if slug, has := slugs[num(row, "role_id")]; ok && has {
+			u.Roles = append(u.Roles, slug)
+		}
+	}
+
+	if rows, err = table(conn, "goadmin_user_permissions").All(); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		u, ok := users[num(row, "user_id")]
+		if slug, has := permissions[num(row, "permission_id")]; ok && has {
+			u.Permissions = append(u.Permissions, slug)
+		}
+	}
+
+	for _, r := range s.roles {
+		sort.Strings(r.Permissions)
+		sort.Strings(r.Menus)
+	}
+	for _, u := range s.users {
+		sort.Strings(u.Roles)
+		sort.Strings(u.Permissions)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,126 @@
+package rbacbundle
+
+import (
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newConn() *dbtest.Connection {
+	return dbtest.New(db.DriverMysql).
+		OnQuery("from `goadmin_permissions`",
+			map[string]interface{}{"id": int64(1), "name": "All", "slug": "*", "http_method": "", "http_path": "*"},
+			map[string]interface{}{"id": int64(2), "name": "Dashboard", "slug": "dashboard", "http_method": "GET", "http_path": "/"}).
+		OnQuery("from `goadmin_menu`",
+			map[string]interface{}{"id": int64(1), "parent_id": int64(0), "type": int64(1), "order": int64(2), "title": "Admin", "icon": "fa-tasks"},
+			map[string]interface{}{"id": int64(2), "parent_id": int64(1), "type": int64(1), "order": int64(2), "title": "Users", "uri": "/info/manager"}).
+		OnQuery("from `goadmin_roles`",
+			map[string]interface{}{"id": int64(1), "name": "Administrator", "slug": "administrator"}).
+		OnQuery("from `goadmin_role_permissions`",
+			map[string]interface{}{"role_id": int64(1), "permission_id": int64(1)}).
+		OnQuery("from `goadmin_role_menu`",
+			map[string]interface{}{"role_id": int64(1), "menu_id": int64(2)},
+			map[string]interface{}{"role_id": int64(1), "menu_id": int64(1)}).
+		OnQuery("from `goadmin_users`",
+			map[string]interface{}{"id": int64(1), "username": "admin", "name": "admin", "password": "hash"}).
+		OnQuery("from `goadmin_role_users`",
+			map[string]interface{}{"role_id": int64(1), "user_id": int64(1)}).
+		OnQuery("from `goadmin_user_permissions`",
+			map[string]interface{}{"user_id": int64(1), "permission_id": int64(2)})
+}
+
+func TestExport(t *testing.T) {
+	b, err := Export(newConn(), false)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"*", "dashboard"}, []string{b.Permissions[0].Slug, b.Permissions[1].Slug})
+	assert.Equal(t, "Admin", b.Menus[0].Key)
+	assert.Equal(t, "Admin / Users", b.Menus[1].Key)
+	assert.Equal(t, "Admin", b.Menus[1].Parent)
+	assert.Equal(t, []string{"*"}, b.Roles[0].Permissions)
+	assert.Equal(t, []string{"Admin", "Admin / Users"}, b.Roles[0].Menus)
+	assert.Equal(t, "", b.Users[0].Password)
+	assert.Equal(t, []string{"administrator"}, b.Users[0].Roles)
+	assert.Equal(t, []string{"dashboard"}, b.Users[0].Permissions)
+
+	b, err = Export(newConn(), true)
+	assert.Nil(t, err)
+	assert.Equal(t, "hash", b.Users[0].Password)
+}
+
+func TestParse(t *testing.T) {
+	b, err := Export(newConn(), false)
+	assert.Nil(t, err)
+
+	data, err := b.JSON()
+	assert.Nil(t, err)
+	parsed, err := Parse(data)
+	assert.Nil(t, err)
+	assert.Equal(t, b.Roles, parsed.Roles)
+
+	data, err = b.Zip()
+	assert.Nil(t, err)
+	parsed, err = Parse(data)
+	assert.Nil(t, err)
+	assert.Equal(t, b.Menus, parsed.Menus)
+
+	_, err = Parse([]byte("{}"))
+	assert.Equal(t, ErrInvalidBundle, err)
+}
+
+func TestImport(t *testing.T) {
+	b, err := Export(newConn(), false)
+	assert.Nil(t, err)
+
+	// importing the bundle of the same records changes nothing.
+	conn := newConn()
+	report, err := Import(conn, b, StrategyOverwrite, false)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(report.Changes))
+	assert.Equal(t, 6, report.Unchanged)
+	for _, call := range conn.Calls() {
+		assert.False(t, call.Exec)
+	}
+
+	b.Roles[0].Name = "Admin"
+	b.Roles = append(b.Roles, Role{Slug: "operator", Name: "Operator", Permissions: []string{"dashboard", "unknown"}})
+
+	report, err = Import(newConn(), b, StrategySkip, true)
+	assert.Nil(t, err)
+	assert.Equal(t, []Change{
+		{Kind: KindRole, Key: "administrator", Action: ActionSkip, Detail: "name", item: report.Changes[0].item},
+		{Kind: KindRole, Key: "operator", Action: ActionCreate, item: report.Changes[1].item},
+	}, report.Changes)
+	assert.Equal(t, []string{"role operator: unknown permission unknown"}, report.Errors)
+
+	conn = newConn()
+	report, err = Import(conn, b, StrategyFail, false)
+	assert.Equal(t, ErrConflict, err)
+	assert.Equal(t, ActionConflict, report.Changes[0].Action)
+	for _, call := range conn.Calls() {
+		assert.False(t, call.Exec)
+	}
+
+	conn = newConn().
+		OnExec("insert into `goadmin_roles`", dbtest.Result{LastID: 2, Affected: 1}).
+		OnExec("", dbtest.Result{Affected: 1})
+	report, err = Import(conn, b, StrategyOverwrite, false)
+	assert.Nil(t, err)
+	assert.Equal(t, ActionUpdate, report.Changes[0].Action)
+	assert.Equal(t, 1, len(report.Errors))
+
+	var execs []string
+	for _, call := range conn.Calls() {
+		if call.Exec {
+			execs = append(execs, call.Query)
+		}
+	}
+	assert.Equal(t, 10, len(execs))
+	assert.Contains(t, execs[0], "update `goadmin_roles`")
+	assert.Contains(t, execs[1], "delete from `goadmin_role_permissions`")
+	assert.Contains(t, execs[6], "insert into `goadmin_roles`")
+	assert.Contains(t, execs[8], "insert into `goadmin_role_permissions`")
+	assert.Equal(t, []interface{}{int64(2), int64(2)}, conn.Calls()[len(conn.Calls())-2].Args)
+}
@@ -0,0 +1,198 @@
+package rbacbundle
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+	template2 "github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// ShowIndex show the forms of the export and the import.
+func (r *RBACBundle) ShowIndex(ctx *context.Context) {
+	r.showIndex(ctx, nil)
+}
+
+// Export download the bundle, as a zip archive when the query "format" is
+// "zip". The hashes of the passwords are included when the query "passwords"
+// is "1".
+func (r *RBACBundle) Export(ctx *context.Context) {
+	b, err := Export(r.conn, ctx.Query("passwords") == "1")
+	if err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	var (
+		data        []byte
+		contentType = "application/json; charset=utf-8"
+		filename    = "rbac-" + time.Now().Format("20060102150405")
+	)
+	if ctx.Query("format") == "zip" {
+		data, err = b.Zip()
+		contentType = "application/zip"
+		filename += ".zip"
+	} else {
+		data, err = b.JSON()
+		filename += ".json"
+	}
+	if err != nil {
+		response.Error(ctx, err.Error())
+		return
+	}
+
+	ctx.DataWithHeaders(http.StatusOK, map[string]string{
+		"Content-Type":        contentType,
+		"Content-Disposition": `attachment; filename="` + filename + `"`,
+	}, data)
+}
+
+// Import import the uploaded bundle by the conflict strategy, or the dry-run
+// when the form value "dry_run" is "1", and show the report.
+func (r *RBACBundle) Import(ctx *context.Context) {
+	if !auth.GetTokenService(r.Services.Get(auth.TokenServiceKey)).CheckToken(ctx.FormValue(form.TokenKey)) {
+		r.alert(ctx, "wrong token")
+		return
+	}
+
+	strategy, err := ParseStrategy(ctx.FormValue("strategy"))
+	if err != nil {
+		r.alert(ctx, err.Error())
+		return
+	}
+
+	file, _, err := ctx.Request.FormFile("bundle")
+	if err != nil {
+		r.alert(ctx, lg("no file"))
+		return
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		r.alert(ctx, err.Error())
+		return
+	}
+	b, err := Parse(data)
+	if err != nil {
+		r.alert(ctx, err.Error())
+		return
+	}
+
+	report, err := Import(r.conn, b, strategy, ctx.FormValue("dry_run") == "1")
+	if err != nil && err != ErrConflict {
+		r.alert(ctx, err.Error())
+		return
+	}
+	r.showIndex(ctx, &report)
+}
+
+func (r *RBACBundle) showIndex(ctx *context.Context, report *Report) {
+	var (
+		comp  = template2.Default(ctx)
+		token = auth.GetTokenService(r.Services.Get(auth.TokenServiceKey)).AddToken()
+		body  = exportForm() + importForm(token)
+	)
+
+	if report != nil {
+		body += template.HTML(`<hr><h4>`+lg("import report")+`</h4>`) + summary(report)
+		if len(report.Changes) > 0 {
+			infos := make([]map[string]types.InfoItem, len(report.Changes))
+			for i, change := range report.Changes {
+				infos[i] = map[string]types.InfoItem{
+					lg("kind"):   {Content: template.HTML(lg(change.Kind))},
+					lg("key"):    {Content: escape(change.Key)},
+					lg("action"): {Content: template.HTML(lg(change.Action))},
+					lg("detail"): {Content: escape(change.Detail)},
+				}
+			}
+			body += comp.Table().SetThead(types.Thead{
+				{Head: lg("kind")},
+				{Head: lg("key")},
+				{Head: lg("action")},
+				{Head: lg("detail")},
+			}).SetInfoList(infos).GetContent()
+		}
+	}
+
+	r.HTML(ctx, types.Panel{
+		Content:     comp.Box().SetBody(body).GetContent(),
+		Title:       template.HTML(lg("rbac bundle")),
+		Description: template.HTML(lg("export and import")),
+	})
+}
+
+func exportForm() template.HTML {
+	action := template.HTMLEscapeString(config.Url("/" + Name + "/export"))
+	return template.HTML(`<h4>` + lg("export") + `</h4>` +
+		`<form method="get" action="` + action + `" target="_blank" style="margin-bottom:20px;">` +
+		`<div class="checkbox"><label><input type="checkbox" name="passwords" value="1"> ` + lg("with passwords") + `</label></div>` +
+		`<button type="submit" name="format" value="json" class="btn btn-sm btn-default" style="margin-right:10px;">` + lg("export json") + `</button>` +
+		`<button type="submit" name="format" value="zip" class="btn btn-sm btn-default">` + lg("export zip") + `</button>` +
+		`</form>`)
+}
+
+func importForm(token string) template.HTML {
+	action := template.HTMLEscapeString(config.Url("/" + Name + "/import"))
+	option := func(strategy Strategy) string {
+		return `<option value="` + string(strategy) + `">` + lg(string(strategy)) + `</option>`
+	}
+	return template.HTML(`<h4>` + lg("import") + `</h4>` +
+		`<form method="post" action="` + action + `" enctype="multipart/form-data">` +
+		`<input type="hidden" name="` + form.TokenKey + `" value="` + template.HTMLEscapeString(token) + `">` +
+		`<div class="form-group"><label>` + lg("bundle file") + `</label>` +
+		`<input type="file" name="bundle" accept=".json,.zip"></div>` +
+		`<div class="form-group"><label>` + lg("conflict strategy") + `</label>` +
+		`<select name="strategy" class="form-control" style="width:200px;">` +
+		option(StrategySkip) + option(StrategyOverwrite) + option(StrategyFail) + `</select></div>` +
+		`<div class="checkbox"><label><input type="checkbox" name="dry_run" value="1" checked> ` + lg("dry run") + `</label></div>` +
+		`<button type="submit" class="btn btn-sm btn-primary">` + lg("import") + `</button>` +
+		`</form>`)
+}
+
+func summary(report *Report) template.HTML {
+	html := fmt.Sprintf(`<p><b>%s</b>: %s &nbsp; <b>%s</b>: %s &nbsp; <b>%s</b>: %s &nbsp; <b>%s</b>: %d</p>`,
+		lg("time"), report.Time.Format("2006-01-02 15:04:05"),
+		lg("duration"), report.Duration.String(),
+		lg("conflict strategy"), lg(string(report.Strategy)),
+		lg("unchanged"), report.Unchanged)
+	for _, change := range report.Changes {
+		if change.Action == ActionConflict {
+			html += `<p class="text-danger">` + lg("conflict result") + `</p>`
+			break
+		}
+	}
+	if report.DryRun {
+		html += `<p class="text-warning">` + lg("dry run result") + `</p>`
+	}
+	if len(report.Errors) > 0 {
+		html += `<div class="text-danger"><b>` + lg("errors") + `</b><ul>`
+		for _, msg := range report.Errors {
+			html += "<li>" + template.HTMLEscapeString(msg) + "</li>"
+		}
+		html += "</ul></div>"
+	}
+	return template.HTML(html)
+}
+
+func (r *RBACBundle) alert(ctx *context.Context, msg string) {
+	r.HTML(ctx, template2.WarningPanel(ctx, msg).GetContent(config.IsProductionEnvironment()))
+}
+
+func escape(s string) template.HTML {
+	return template.HTML(template.HTMLEscapeString(s))
+}
+
+func lg(v string) string {
+	return language.GetWithScope(v, Name)
+}
@@ -0,0 +1,467 @@
+package rbacbundle
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+)
+
+// Strategy decides what to do with the records of the bundle which exist but
+// differ in the target environment.
+type Strategy string
+
+// Strategies of the conflicts.
+const (
+	// StrategySkip keeps the existing records.
+	StrategySkip Strategy = "skip"
+	// StrategyOverwrite updates the existing records by the bundle.
+	StrategyOverwrite Strategy = "overwrite"
+	// StrategyFail imports nothing when any record conflicts.
+	StrategyFail Strategy = "fail"
+)
+
+// ParseStrategy return the strategy of the name.
+func ParseStrategy(name string) (Strategy, error) {
+	switch s := Strategy(name); s {
+	case StrategySkip, StrategyOverwrite, StrategyFail:
+		return s, nil
+	}
+	return "", fmt.Errorf("unknown conflict strategy %q", name)
+}
+
+// Kinds of the records.
+const (
+	KindPermission = "permission"
+	KindMenu       = "menu"
+	KindRole       = "role"
+	KindUser       = "user"
+)
+
+// Actions of the changes.
+const (
+	ActionCreate   = "create"
+	ActionUpdate   = "update"
+	ActionSkip     = "skip"
+	ActionConflict = "conflict"
+)
+
+// Change is a change made, or to be made in the dry-run mode, to a record.
+type Change struct {
+	Kind   string
+	Key    string
+	Action string
+	Detail string
+
+	item interface{}
+}
+
+// Report is the result of an import.
+type Report struct {
+	DryRun    bool
+	Strategy  Strategy
+	Time      time.Time
+	Duration  time.Duration
+	Changes   []Change
+	Unchanged int
+	Errors    []string
+}
+
+// ErrConflict is returned by the fail strategy when any record conflicts.
+var ErrConflict = errors.New("the bundle conflicts with the existing records")
+
+// Import import the bundle into the connection. The records are matched by
+// the natural keys, so importing the same bundle again changes nothing. The
+// records missing are created, and the ones differing are handled by the
+// strategy. Nothing is written in the dry-run mode, the report tells what
+// would be changed.
+func Import(conn db.Connection, b *Bundle, strategy Strategy, dryRun bool) (Report, error) {
+	report := Report{DryRun: dryRun, Strategy: strategy, Time: time.Now()}
+
+	s, err := load(conn)
+	if err != nil {
+		return report, err
+	}
+
+	im := &importer{conn: conn, strategy: strategy, state: s, report: &report}
+	im.plan(b)
+
+	if strategy == StrategyFail {
+		for _, change := range report.Changes {
+			if change.Action == ActionConflict {
+				report.Duration = time.Since(report.Time)
+				return report, ErrConflict
+			}
+		}
+	}
+
+	if !dryRun {
+		for _, change := range report.Changes {
+			if change.Action != ActionCreate && change.Action != ActionUpdate {
+				continue
+			}
+			if err := im.apply(change); err != nil {
+				report.Errors = append(report.Errors, change.Kind+" "+change.Key+": "+err.Error())
+			}
+		}
+		models.InvalidateAuthCache()
+	}
+
+	report.Duration = time.Since(report.Time)
+	return report, nil
+}
+
+type importer struct {
+	conn     db.Connection
+	strategy Strategy
+	state    *state
+	report   *Report
+
+	permissions map[string]bool
+	menus       map[string]bool
+	roles       map[string]bool
+}
+
+// plan add the changes of the bundle into the report, the permissions first,
+// then the menus, the roles and the users, so the records referred are always
+// created before.
+func (im *importer) plan(b *Bundle) {
+	im.permissions = make(map[string]bool)
+	im.menus = make(map[string]bool)
+	im.roles = make(map[string]bool)
+	for slug := range im.state.permissions {
+		im.permissions[slug] = true
+	}
+	for key := range im.state.menus {
+		im.menus[key] = true
+	}
+	for slug := range im.state.roles {
+		im.roles[slug] = true
+	}
+
+	for i := range b.Permissions {
+		p := b.Permissions[i]
+		im.permissions[p.Slug] = true
+		local, ok := im.state.permissions[p.Slug]
+		var diff []string
+		if ok {
+			diff = compare(
+				"name", local.Name, p.Name,
+				"http_method", local.HttpMethod, p.HttpMethod,
+				"http_path", local.HttpPath, p.HttpPath)
+		}
+		im.add(KindPermission, p.Slug, ok, diff, &p)
+	}
+
+	menus := make([]Menu, len(b.Menus))
+	copy(menus, b.Menus)
+	sortMenus(menus)
+	for i := range menus {
+		m := menus[i]
+		m.Key = MenuKey(m.Parent, m.Title)
+		if m.Parent != "" && !im.menus[m.Parent] {
+			im.error(KindMenu, m.Key, "unknown parent menu "+m.Parent)
+			continue
+		}
+		im.menus[m.Key] = true
+		local, ok := im.state.menus[m.Key]
+		var diff []string
+		if ok {
+			diff = compare(
+				"type", fmt.Sprint(local.Type), fmt.Sprint(m.Type),
+				"order", fmt.Sprint(local.Order), fmt.Sprint(m.Order),
+				"icon", local.Icon, m.Icon,
+				"uri", local.Uri, m.Uri,
+				"header", local.Header, m.Header,
+				"plugin_name", local.PluginName, m.PluginName,
+				"uuid", local.Uuid, m.Uuid)
+		}
+		im.add(KindMenu, m.Key, ok, diff, &m)
+	}
+
+	for i := range b.Roles {
+		r := b.Roles[i]
+		r.Permissions = im.known(KindRole, r.Slug, KindPermission, r.Permissions, im.permissions)
+		r.Menus = im.known(KindRole, r.Slug, KindMenu, r.Menus, im.menus)
+		im.roles[r.Slug] = true
+		local, ok := im.state.roles[r.Slug]
+		var diff []string
+		if ok {
+			diff = compare(
+				"name", local.Name, r.Name,
+				"permissions", join(local.Permissions), join(r.Permissions),
+				"menus", join(local.Menus), join(r.Menus))
+		}
+		im.add(KindRole, r.Slug, ok, diff, &r)
+	}
+
+	for i := range b.Users {
+		u := b.Users[i]
+		u.Roles = im.known(KindUser, u.Username, KindRole, u.Roles, im.roles)
+		u.Permissions = im.known(KindUser, u.Username, KindPermission, u.Permissions, im.permissions)
+		local, ok := im.state.users[u.Username]
+		var diff []string
+		if ok {
+			password := local.Password
+			if u.Password != "" {
+				password = u.Password
+			}
+			diff = compare(
+				"name", local.Name, u.Name,
+				"avatar", local.Avatar, u.Avatar,
+				"password", local.Password, password,
+				"roles", join(local.Roles), join(u.Roles),
+				"permissions", join(local.Permissions), join(u.Permissions))
+		}
+		im.add(KindUser, u.Username, ok, diff, &u)
+	}
+}
+
+func (im *importer) add(kind, key string, exists bool, diff []string, item interface{}) {
+	change := Change{Kind: kind, Key: key, item: item}
+	switch {
+	case !exists:
+		change.Action = ActionCreate
+		if u, ok := item.(*User); ok && u.Password == "" {
+			change.Detail = "no password in the bundle, a random one is set"
+		}
+	case len(diff) == 0:
+		im.report.Unchanged++
+		return
+	case im.strategy == StrategyOverwrite:
+		change.Action = ActionUpdate
+		change.Detail = strings.Join(diff, ", ")
+	case im.strategy == StrategyFail:
+		change.Action = ActionConflict
+		change.Detail = strings.Join(diff, ", ")
+	default:
+		change.Action = ActionSkip
+		change.Detail = strings.Join(diff, ", ")
+	}
+	im.report.Changes = append(im.report.Changes, change)
+}
+
+func (im *importer) error(kind, key, msg string) {
+	im.report.Errors = append(im.report.Errors, kind+" "+key+": "+msg)
+}
+
+// known return the keys existing in the bundle or the connection, the others
+// are reported and dropped.
+func (im *importer) known(kind, key, refKind string, refs []string, known map[string]bool) []string {
+	res := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if known[ref] {
+			res = append(res, ref)
+		} else {
+			im.error(kind, key, "unknown "+refKind+" "+ref)
+		}
+	}
+	return res
+}
+
+func (im *importer) apply(change Change) error {
+	switch item := change.item.(type) {
+	case *Permission:
+		return im.applyPermission(change.Action, item)
+	case *Menu:
+		return im.applyMenu(change.Action, item)
+	case *Role:
+		return im.applyRole(change.Action, item)
+	case *User:
+		return im.applyUser(change.Action, item)
+	}
+	return nil
+}
+
+func (im *importer) applyPermission(action string, p *Permission) error {
+	values := dialect.H{
+		"name":        p.Name,
+		"slug":        p.Slug,
+		"http_method": p.HttpMethod,
+		"http_path":   p.HttpPath,
+	}
+	if action == ActionUpdate {
+		local := im.state.permissions[p.Slug]
+		return im.update("goadmin_permissions", local.id, values)
+	}
+	id, err := table(im.conn, "goadmin_permissions").InsertAndGetID(values, "slug")
+	if err != nil {
+		return err
+	}
+	im.state.permissions[p.Slug] = &localPermission{id: id, Permission: *p}
+	return nil
+}
+
+func (im *importer) applyMenu(action string, m *Menu) error {
+	values := dialect.H{
+		"type":        m.Type,
+		"order":       m.Order,
+		"icon":        m.Icon,
+		"uri":         m.Uri,
+		"header":      m.Header,
+		"plugin_name": m.PluginName,
+		"uuid":        m.Uuid,
+	}
+	if action == ActionUpdate {
+		local := im.state.menus[m.Key]
+		return im.update("goadmin_menu", local.id, values)
+	}
+	var parentID int64
+	if m.Parent != "" {
+		parent, ok := im.state.menus[m.Parent]
+		if !ok {
+			return errors.New("parent menu is not imported")
+		}
+		parentID = parent.id
+	}
+	values["title"] = m.Title
+	values["parent_id"] = parentID
+	id, err := table(im.conn, "goadmin_menu").InsertAndGetID(values, "title", "parent_id")
+	if err != nil {
+		return err
+	}
+	im.state.menus[m.Key] = &localMenu{id: id, Menu: *m}
+	return nil
+}
+
+func (im *importer) applyRole(action string, r *Role) error {
+	var id int64
+	values := dialect.H{
+		"name": r.Name,
+		"slug": r.Slug,
+	}
+	if action == ActionUpdate {
+		id = im.state.roles[r.Slug].id
+		if err := im.update("goadmin_roles", id, values); err != nil {
+			return err
+		}
+	} else {
+		var err error
+		id, err = table(im.conn, "goadmin_roles").InsertAndGetID(values, "slug")
+		if err != nil {
+			return err
+		}
+		im.state.roles[r.Slug] = &localRole{id: id, Role: *r}
+	}
+
+	permissions := make([]int64, 0, len(r.Permissions))
+	for _, slug := range r.Permissions {
+		if p, ok := im.state.permissions[slug]; ok {
+			permissions = append(permissions, p.id)
+		}
+	}
+	if err := im.link("goadmin_role_permissions", "role_id", id, "permission_id", permissions); err != nil {
+		return err
+	}
+
+	menus := make([]int64, 0, len(r.Menus))
+	for _, key := range r.Menus {
+		if m, ok := im.state.menus[key]; ok {
+			menus = append(menus, m.id)
+		}
+	}
+	return im.link("goadmin_role_menu", "role_id", id, "menu_id", menus)
+}
+
+func (im *importer) applyUser(action string, u *User) error {
+	var id int64
+	values := dialect.H{
+		"username": u.Username,
+		"name":     u.Name,
+		"avatar":   u.Avatar,
+	}
+	if u.Password != "" {
+		values["password"] = u.Password
+	}
+	if action == ActionUpdate {
+		id = im.state.users[u.Username].id
+		if err := im.update("goadmin_users", id, values); err != nil {
+			return err
+		}
+	} else {
+		if u.Password == "" {
+			values["password"] = auth.EncodePassword([]byte(utils.Uuid(32)))
+		}
+		var err error
+		id, err = table(im.conn, "goadmin_users").InsertAndGetID(values, "username")
+		if err != nil {
+			return err
+		}
+		im.state.users[u.Username] = &localUser{id: id, User: *u}
+	}
+
+	roles := make([]int64, 0, len(u.Roles))
+	for _, slug := range u.Roles {
+		if r, ok := im.state.roles[slug]; ok {
+			roles = append(roles, r.id)
+		}
+	}
+	if err := im.link("goadmin_role_users", "user_id", id, "role_id", roles); err != nil {
+		return err
+	}
+
+	permissions := make([]int64, 0, len(u.Permissions))
+	for _, slug := range u.Permissions {
+		if p, ok := im.state.permissions[slug]; ok {
+			permissions = append(permissions, p.id)
+		}
+	}
+	return im.link("goadmin_user_permissions", "user_id", id, "permission_id", permissions)
+}
+
+func (im *importer) update(name string, id int64, values dialect.H) error {
+	values["updated_at"] = now()
+	_, err := table(im.conn, name).Where("id", "=", id).Update(values)
+	if db.CheckError(err, db.UPDATE) {
+		return err
+	}
+	return nil
+}
+
+// link replace the records of the link table of the owner by the ids.
+func (im *importer) link(name, ownerField string, owner int64, field string, ids []int64) error {
+	err := table(im.conn, name).Where(ownerField, "=", owner).Delete()
+	if db.CheckError(err, db.DELETE) {
+		return err
+	}
+	for _, id := range ids {
+		_, err := table(im.conn, name).Insert(dialect.H{
+			ownerField: owner,
+			field:      id,
+		})
+		if db.CheckError(err, db.INSERT) {
+			return err
+		}
+	}
+	return nil
+}
+
+// compare return the names of the fields differing, the arguments are the
+// triples of the name, the local value and the bundle value.
+func compare(fields ...string) []string {
+	var diff []string
+	for i := 0; i+2 < len(fields); i += 3 {
+		if fields[i+1] != fields[i+2] {
+			diff = append(diff, fields[i])
+		}
+	}
+	return diff
+}
+
+func join(keys []string) string {
+	sorted := make([]string, len(keys))
+	copy(sorted, keys)
+	sort.Strings(sorted)
+	return strings.Join(sorted, "\n")
+}
+
+func now() string {
+	return time.Now().Format("2006-01-02 15:04:05")
+}
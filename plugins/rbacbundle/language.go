@@ -0,0 +1,36 @@
+package rbacbundle
+
+var cn = map[string]string{
+	"rbacbundle.rbac bundle":       "权限包",
+	"rbacbundle.export and import": "导出与导入用户、角色、权限和菜单",
+	"rbacbundle.export":            "导出",
+	"rbacbundle.import":            "导入",
+	"rbacbundle.export json":       "导出JSON",
+	"rbacbundle.export zip":        "导出ZIP",
+	"rbacbundle.with passwords":    "包含密码哈希",
+	"rbacbundle.bundle file":       "权限包文件",
+	"rbacbundle.conflict strategy": "冲突策略",
+	"rbacbundle.skip":              "跳过",
+	"rbacbundle.overwrite":         "覆盖",
+	"rbacbundle.fail":              "失败",
+	"rbacbundle.dry run":           "试运行",
+	"rbacbundle.import report":     "导入报告",
+	"rbacbundle.kind":              "类型",
+	"rbacbundle.key":               "标识",
+	"rbacbundle.action":            "操作",
+	"rbacbundle.detail":            "详情",
+	"rbacbundle.time":              "时间",
+	"rbacbundle.duration":          "耗时",
+	"rbacbundle.unchanged":         "未变更",
+	"rbacbundle.errors":            "错误",
+	"rbacbundle.permission":        "权限",
+	"rbacbundle.menu":              "菜单",
+	"rbacbundle.role":              "角色",
+	"rbacbundle.user":              "用户",
+	"rbacbundle.create":            "创建",
+	"rbacbundle.update":            "更新",
+	"rbacbundle.conflict":          "冲突",
+	"rbacbundle.dry run result":    "试运行结果，未写入任何数据",
+	"rbacbundle.conflict result":   "存在冲突，未导入任何数据",
+	"rbacbundle.no file":           "请选择权限包文件",
+}
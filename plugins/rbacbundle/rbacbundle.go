@@ -0,0 +1,73 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+// Package rbacbundle provides the export and import of the users, roles,
+// permissions and menus as a bundle, for promoting the access setup from an
+// environment to another. The bundle is a json file, or a zip archive holding
+// it, and the records refer to each other by the natural keys, so importing a
+// bundle is idempotent. The records which exist but differ are skipped,
+// overwritten or fail the import by the conflict strategy:
+//
+//	eng.AddPlugins(rbacbundle.NewRBACBundle())
+//
+// The bundle can also be exported and imported without the plugin:
+//
+//	b, err := rbacbundle.Export(conn, false)
+//	report, err := rbacbundle.Import(conn, b, rbacbundle.StrategySkip, true)
+package rbacbundle
+
+import (
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/service"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins"
+)
+
+// RBACBundle is a GoAdmin plugin.
+type RBACBundle struct {
+	*plugins.Base
+
+	conn db.Connection
+}
+
+// Name is the name of the plugin, also used as the url prefix.
+const Name = "rbacbundle"
+
+// NewRBACBundle return a RBACBundle plugin.
+func NewRBACBundle() *RBACBundle {
+	return &RBACBundle{
+		Base: &plugins.Base{PlugName: Name},
+	}
+}
+
+// InitPlugin implements Plugin.InitPlugin.
+func (r *RBACBundle) InitPlugin(srv service.List) {
+	r.InitBase(srv, Name)
+	language.AppendTo(language.CN, cn)
+
+	r.conn = db.GetConnection(srv)
+	r.App = r.initRouter(config.Prefix(), srv)
+}
+
+func (r *RBACBundle) GetIndexURL() string {
+	return config.Url("/" + Name)
+}
+
+func (r *RBACBundle) IsInstalled() bool {
+	return true
+}
+
+func (r *RBACBundle) GetInfo() plugins.Info {
+	return plugins.Info{
+		Title:       "RBAC Bundle",
+		Name:        Name,
+		Description: "Export and import the users, roles, permissions and menus between the environments.",
+		Author:      "official",
+		Version:     "v0.0.1",
+		CreateDate:  utils.ParseTime("2026-10-22 00:00:00"),
+		UpdateDate:  utils.ParseTime("2026-10-22 00:00:00"),
+	}
+}
@@ -0,0 +1,19 @@
+package rbacbundle
+
+import (
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/service"
+)
+
+func (r *RBACBundle) initRouter(prefix string, srv service.List) *context.App {
+
+	app := context.NewApp()
+	route := app.Group(prefix, auth.Middleware(db.GetConnection(srv)))
+	route.GET("/"+Name, r.ShowIndex).Name("rbacbundle_index")
+	route.GET("/"+Name+"/export", r.Export).Name("rbacbundle_export")
+	route.POST("/"+Name+"/import", r.Import).Name("rbacbundle_import")
+
+	return app
+}
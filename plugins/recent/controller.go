@@ -0,0 +1,246 @@
+package recent
+
+import (
+	"encoding/json"
+	"html"
+	"html/template"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/response"
+	template2 "github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// ShowHistory show the pages visited by the user.
+func (r *Recent) ShowHistory(ctx *context.Context) {
+	var (
+		comp  = template2.Default(ctx)
+		items = r.store.Items(auth.Auth(ctx).Id)
+		body  template.HTML
+	)
+
+	if len(items) == 0 {
+		body = template.HTML("<p>" + lg("no visited pages") + "</p>")
+	} else {
+		infos := make([]map[string]types.InfoItem, len(items))
+		for i, item := range items {
+			infos[i] = map[string]types.InfoItem{
+				lg("title"): {Content: template.HTML(`<a href="` + template.HTMLEscapeString(item.URL) + `">` +
+					template.HTMLEscapeString(item.Title) + `</a>`)},
+				lg("table"):      {Content: escape(item.Prefix)},
+				lg("page"):       {Content: escape(lg(item.Kind))},
+				lg("visited at"): {Content: escape(item.Time.Format("2006-01-02 15:04:05"))},
+			}
+		}
+		body = comp.Table().SetThead(types.Thead{
+			{Head: lg("title")},
+			{Head: lg("table")},
+			{Head: lg("page")},
+			{Head: lg("visited at")},
+		}).SetInfoList(infos).GetContent()
+		body += template.HTML(`<button type="button" class="btn btn-sm btn-default goadmin-recent-clear" data-token="` +
+			template.HTMLEscapeString(auth.GetTokenService(r.Services.Get(auth.TokenServiceKey)).AddToken()) + `">` +
+			lg("clear") + `</button>
+<script>
+$(".goadmin-recent-clear").on("click", function () {
+    $.post("` + config.Url("/"+Name+"/clear") + `", {"` + form.TokenKey + `": $(this).data("token")}, function () {
+        $.pjax.reload("#pjax-container");
+    });
+});
+</script>`)
+	}
+
+	r.HTML(ctx, types.Panel{
+		Content:     comp.Box().SetBody(body).GetContent(),
+		Title:       template.HTML(lg("recent")),
+		Description: template.HTML(lg("recently visited pages")),
+	})
+}
+
+// Clear remove the pages visited by the user.
+func (r *Recent) Clear(ctx *context.Context) {
+	if !auth.GetTokenService(r.Services.Get(auth.TokenServiceKey)).CheckToken(ctx.FormValue(form.TokenKey)) {
+		response.BadRequest(ctx, "wrong token")
+		return
+	}
+	r.store.Clear(auth.Auth(ctx).Id)
+	response.Ok(ctx)
+}
+
+// decorate record the visited detail or edit page, and append the script
+// filling the dropdown of the navigation bar and the command palette.
+func (r *Recent) decorate(ctx *context.Context, user models.UserModel, panel types.Panel) types.Panel {
+	if ctx == nil || user.Id == 0 {
+		return panel
+	}
+	if item, ok := visited(ctx, panel); ok {
+		r.store.Add(user.Id, item)
+	}
+
+	btn := ""
+	if r.navBtn != nil {
+		btn = r.navBtn.Id
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"items":  r.store.Items(user.Id),
+		"button": btn,
+		"all":    config.Url("/" + Name),
+		"labels": map[string]string{
+			"empty":  lg("no visited pages"),
+			"all":    lg("view all"),
+			"search": lg("search the visited pages"),
+		},
+	})
+	if err != nil {
+		logger.Error("marshal recent items error: ", err)
+		return panel
+	}
+	panel.Content += template.HTML(`<script>(` + runner + `)(` + string(data) + `);</script>`)
+	return panel
+}
+
+// visited return the item of the page if it is a detail or edit page.
+func visited(ctx *context.Context, panel types.Panel) (Item, bool) {
+	if ctx.Method() != "GET" {
+		return Item{}, false
+	}
+	formats := config.GetURLFormats()
+	for _, page := range []struct {
+		kind, format, key string
+	}{
+		{KindDetail, formats.Detail, constant.DetailPKKey},
+		{KindEdit, formats.ShowEdit, constant.EditPKKey},
+	} {
+		prefix, ok := matchPrefix(config.Url(page.format), ctx.Path())
+		id := ctx.Query(page.key)
+		if !ok || id == "" {
+			continue
+		}
+		title := plainText(panel.Title)
+		if title == "" {
+			title = prefix
+		}
+		return Item{
+			Kind:   page.kind,
+			Prefix: prefix,
+			ID:     id,
+			Title:  title + " #" + id,
+			URL:    ctx.Path() + "?" + page.key + "=" + url.QueryEscape(id),
+			Time:   time.Now(),
+		}, true
+	}
+	return Item{}, false
+}
+
+// matchPrefix return the prefix of the path matching the format such as
+// "/admin/info/:__prefix/detail".
+func matchPrefix(format, path string) (string, bool) {
+	i := strings.Index(format, ":"+constant.PrefixKey)
+	if i < 0 {
+		return "", false
+	}
+	before, after := format[:i], format[i+len(":"+constant.PrefixKey):]
+	if len(path) <= len(before)+len(after) || !strings.HasPrefix(path, before) || !strings.HasSuffix(path, after) {
+		return "", false
+	}
+	prefix := path[len(before) : len(path)-len(after)]
+	if strings.Contains(prefix, "/") {
+		return "", false
+	}
+	return prefix, true
+}
+
+var tagReg = regexp.MustCompile(`<[^>]*>`)
+
+func plainText(s template.HTML) string {
+	return strings.TrimSpace(html.UnescapeString(tagReg.ReplaceAllString(string(s), "")))
+}
+
+// runner turns the nav button into the dropdown of the visited pages, and
+// binds Ctrl+K to open the command palette searching them. The script runs
+// again on every page, the latest items are kept in window.goAdminRecent.
+const runner = `function (opt) {
+    window.goAdminRecent = opt;
+    var a = opt.button ? $("." + opt.button) : $();
+    if (a.length > 0) {
+        var li = a.closest("li").addClass("dropdown");
+        a.attr("data-toggle", "dropdown").addClass("dropdown-toggle");
+        li.find("ul.goadmin-recent-menu").remove();
+        var ul = $('<ul class="dropdown-menu dropdown-menu-right goadmin-recent-menu" style="max-height:400px;overflow-y:auto;"></ul>');
+        if (opt.items.length === 0) {
+            ul.append($('<li class="dropdown-header"></li>').text(opt.labels.empty));
+        }
+        $.each(opt.items, function (_, item) {
+            var link = $("<a></a>").attr("href", item.url).text(" " + item.title);
+            link.prepend($("<i></i>").addClass("fa " + (item.kind === "edit" ? "fa-edit" : "fa-eye")));
+            ul.append($("<li></li>").append(link));
+        });
+        ul.append('<li class="divider"></li>');
+        ul.append($("<li></li>").append($("<a></a>").attr("href", opt.all).text(opt.labels.all)));
+        li.append(ul);
+    }
+
+    var open = function () {
+        var o = window.goAdminRecent, shown = [], active = 0;
+        $(".goadmin-palette").remove();
+        var box = $('<div class="goadmin-palette" style="position:fixed;top:0;left:0;right:0;bottom:0;z-index:2000;background:rgba(0,0,0,.3);">' +
+            '<div class="box box-solid" style="width:500px;max-width:90%;margin:80px auto;"><div class="box-body">' +
+            '<input type="text" class="form-control">' +
+            '<ul class="list-unstyled" style="margin:10px 0 0;max-height:360px;overflow-y:auto;"></ul></div></div></div>');
+        var input = box.find("input").attr("placeholder", o.labels.search), list = box.find("ul");
+        var render = function () {
+            var q = $.trim(input.val()).toLowerCase();
+            shown = $.grep(o.items, function (item) {
+                return (item.title + " " + item.prefix).toLowerCase().indexOf(q) >= 0;
+            });
+            active = Math.max(Math.min(active, shown.length - 1), 0);
+            list.empty();
+            if (shown.length === 0) {
+                list.append($('<li class="text-muted" style="padding:6px 8px;"></li>').text(o.labels.empty));
+            }
+            $.each(shown, function (i, item) {
+                list.append($('<li style="padding:6px 8px;cursor:pointer;"></li>').attr("data-index", i)
+                    .toggleClass("bg-gray", i === active).text(item.title));
+            });
+        };
+        var go = function (i) {
+            if (!shown[i]) { return; }
+            box.remove();
+            if ($.pjax) { $.pjax({url: shown[i].url, container: '#pjax-container'}); } else { window.location.href = shown[i].url; }
+        };
+        input.on("input", function () { active = 0; render(); });
+        input.on("keydown", function (e) {
+            if (e.keyCode === 40) { active = Math.min(active + 1, shown.length - 1); render(); e.preventDefault(); }
+            if (e.keyCode === 38) { active = Math.max(active - 1, 0); render(); e.preventDefault(); }
+            if (e.keyCode === 13) { go(active); e.preventDefault(); }
+            if (e.keyCode === 27) { box.remove(); }
+        });
+        list.on("click", "li[data-index]", function () { go(parseInt($(this).attr("data-index"), 10)); });
+        box.on("click", function (e) { if (e.target === box[0]) { box.remove(); } });
+        $("body").append(box);
+        render();
+        input.focus();
+    };
+    $(document).off("keydown.goadminRecent").on("keydown.goadminRecent", function (e) {
+        if ((e.ctrlKey || e.metaKey) && e.keyCode === 75) { e.preventDefault(); open(); }
+    });
+}`
+
+func escape(s string) template.HTML {
+	return template.HTML(template.HTMLEscapeString(s))
+}
+
+func lg(v string) string {
+	return language.GetWithScope(v, Name)
+}
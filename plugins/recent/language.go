@@ -0,0 +1,16 @@
+package recent
+
+var cn = map[string]string{
+	"recent.recent":                   "最近访问",
+	"recent.recently visited pages":   "最近访问的详情和编辑页面",
+	"recent.title":                    "标题",
+	"recent.table":                    "数据表",
+	"recent.page":                     "页面",
+	"recent.visited at":               "访问时间",
+	"recent.detail":                   "详情",
+	"recent.edit":                     "编辑",
+	"recent.clear":                    "清空",
+	"recent.view all":                 "查看全部",
+	"recent.no visited pages":         "暂无访问记录",
+	"recent.search the visited pages": "搜索最近访问的页面",
+}
@@ -0,0 +1,85 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+// Package recent provides the navigation history of the users. The detail
+// and edit pages visited by a user are kept in memory, at most 10 pages per
+// user by default, and listed by the dropdown of the navigation bar, the
+// command palette opened by Ctrl+K and the history page:
+//
+//	eng.AddPlugins(recent.NewRecent().SetSize(20))
+package recent
+
+import (
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/service"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins"
+	"github.com/purpose168/GoAdmin/template/icon"
+	"github.com/purpose168/GoAdmin/template/types"
+	"github.com/purpose168/GoAdmin/template/types/action"
+)
+
+// Recent is a GoAdmin plugin.
+type Recent struct {
+	*plugins.Base
+
+	store  *Store
+	size   int
+	navBtn *types.NavButton
+}
+
+// Name is the name of the plugin, also used as the url prefix.
+const Name = "recent"
+
+// NewRecent return a Recent plugin.
+func NewRecent() *Recent {
+	return &Recent{
+		Base: &plugins.Base{PlugName: Name},
+		size: 10,
+	}
+}
+
+// SetSize set how many pages are kept per user, default 10.
+func (r *Recent) SetSize(size int) *Recent {
+	r.size = size
+	return r
+}
+
+// InitPlugin implements Plugin.InitPlugin.
+func (r *Recent) InitPlugin(srv service.List) {
+	r.InitBase(srv, Name)
+	language.AppendTo(language.CN, cn)
+
+	r.store = NewStore(r.size)
+	types.AddPageDecorator(r.decorate)
+
+	if r.UI != nil && r.UI.NavButtons != nil {
+		r.navBtn = types.GetNavButton("", icon.History, action.Jump(config.Url("/"+Name)), Name)
+		r.navBtn.Public = true
+		*r.UI.NavButtons = append(*r.UI.NavButtons, r.navBtn)
+	}
+
+	r.App = r.initRouter(config.Prefix(), srv)
+}
+
+func (r *Recent) GetIndexURL() string {
+	return config.Url("/" + Name)
+}
+
+func (r *Recent) IsInstalled() bool {
+	return true
+}
+
+func (r *Recent) GetInfo() plugins.Info {
+	return plugins.Info{
+		Title:       "Recent",
+		Name:        Name,
+		Description: "Recently visited detail and edit pages in the navigation bar.",
+		Author:      "official",
+		Version:     "v0.0.1",
+		CreateDate:  utils.ParseTime("2026-10-21 00:00:00"),
+		UpdateDate:  utils.ParseTime("2026-10-21 00:00:00"),
+	}
+}
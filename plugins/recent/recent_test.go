@@ -0,0 +1,48 @@
+package recent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	s := NewStore(3)
+
+	s.Add(1, Item{Kind: KindDetail, Prefix: "users", ID: "1"})
+	s.Add(1, Item{Kind: KindDetail, Prefix: "users", ID: "2"})
+	s.Add(1, Item{Kind: KindEdit, Prefix: "users", ID: "1"})
+	s.Add(2, Item{Kind: KindDetail, Prefix: "posts", ID: "1"})
+
+	items := s.Items(1)
+	assert.Equal(t, 2, len(items))
+	assert.Equal(t, KindEdit, items[0].Kind)
+	assert.Equal(t, "2", items[1].ID)
+
+	s.Add(1, Item{Prefix: "posts", ID: "1"})
+	s.Add(1, Item{Prefix: "posts", ID: "2"})
+	items = s.Items(1)
+	assert.Equal(t, 3, len(items))
+	assert.Equal(t, "posts", items[1].Prefix)
+	assert.Equal(t, "1", items[2].ID)
+	assert.Equal(t, "users", items[2].Prefix)
+
+	s.Clear(1)
+	assert.Equal(t, 0, len(s.Items(1)))
+	assert.Equal(t, 1, len(s.Items(2)))
+}
+
+func TestMatchPrefix(t *testing.T) {
+	prefix, ok := matchPrefix("/admin/info/:__prefix/detail", "/admin/info/users/detail")
+	assert.True(t, ok)
+	assert.Equal(t, "users", prefix)
+
+	_, ok = matchPrefix("/admin/info/:__prefix/detail", "/admin/info/users")
+	assert.False(t, ok)
+	_, ok = matchPrefix("/admin/info/:__prefix/detail", "/admin/info/a/b/detail")
+	assert.False(t, ok)
+	_, ok = matchPrefix("/admin/info/:__prefix/edit", "/admin/info//edit")
+	assert.False(t, ok)
+
+	assert.Equal(t, "Users Detail", plainText("<b>Users</b> Detail"))
+}
@@ -0,0 +1,19 @@
+package recent
+
+import (
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/service"
+)
+
+func (r *Recent) initRouter(prefix string, srv service.List) *context.App {
+
+	app := context.NewApp()
+	// the history of the user is open to all the users.
+	route := app.Group(prefix, auth.SignedInMiddleware(db.GetConnection(srv)))
+	route.GET("/"+Name, r.ShowHistory).Name("recent_history")
+	route.POST("/"+Name+"/clear", r.Clear).Name("recent_clear")
+
+	return app
+}
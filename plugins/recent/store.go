@@ -0,0 +1,75 @@
+package recent
+
+import (
+	"sync"
+	"time"
+)
+
+// Kinds of the visited pages.
+const (
+	KindDetail = "detail"
+	KindEdit   = "edit"
+)
+
+// Item is a visited detail or edit page.
+type Item struct {
+	Kind   string    `json:"kind"`
+	Prefix string    `json:"prefix"`
+	ID     string    `json:"id"`
+	Title  string    `json:"title"`
+	URL    string    `json:"url"`
+	Time   time.Time `json:"time"`
+}
+
+// Store keeps the latest visited pages of each user in memory, at most size
+// pages per user.
+type Store struct {
+	lock  sync.RWMutex
+	size  int
+	items map[int64][]Item
+}
+
+// NewStore return a store keeping size pages per user.
+func NewStore(size int) *Store {
+	return &Store{size: size, items: make(map[int64][]Item)}
+}
+
+// Add add the visited page as the latest of the user. The page of the same
+// record visited before is moved to the front, so that the detail and the
+// edit page of a record take one place.
+func (s *Store) Add(userID int64, item Item) {
+	if s.size <= 0 {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	old := s.items[userID]
+	items := make([]Item, 0, len(old)+1)
+	items = append(items, item)
+	for _, v := range old {
+		if len(items) >= s.size {
+			break
+		}
+		if v.Prefix != item.Prefix || v.ID != item.ID {
+			items = append(items, v)
+		}
+	}
+	s.items[userID] = items
+}
+
+// Items return the visited pages of the user, the latest first.
+func (s *Store) Items(userID int64) []Item {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	items := make([]Item, len(s.items[userID]))
+	copy(items, s.items[userID])
+	return items
+}
+
+// Clear remove the visited pages of the user.
+func (s *Store) Clear(userID int64) {
+	s.lock.Lock()
+	delete(s.items, userID)
+	s.lock.Unlock()
+}
@@ -0,0 +1,139 @@
+package seed
+
+import (
+	"html/template"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/seed"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	template2 "github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// ShowSeeders show the registered seeders and whether they have been run.
+func (s *Seed) ShowSeeders(ctx *context.Context) {
+	var (
+		comp     = template2.Default(ctx)
+		seeders  = seed.Seeders()
+		body     template.HTML
+		ran, err = seed.Ran(db.GetConnection(s.Services))
+	)
+
+	if err != nil {
+		s.alert(ctx, err.Error())
+		return
+	}
+
+	if len(seeders) == 0 {
+		body = template.HTML("<p>" + lg("no seeders") + "</p>")
+	} else {
+		token := s.token()
+		infos := make([]map[string]types.InfoItem, len(seeders))
+		pending := false
+		for i, seeder := range seeders {
+			at, done := ran[seeder.Name]
+			status := template.HTML(`<span class="label label-warning">` + lg("pending") + `</span>`)
+			if done {
+				status = template.HTML(`<span class="label label-success">` + lg("ran at") + " " +
+					at.Format("2006-01-02 15:04:05") + `</span>`)
+			} else {
+				pending = true
+			}
+			infos[i] = map[string]types.InfoItem{
+				lg("name"):        {Content: escape(seeder.Name)},
+				lg("description"): {Content: escape(seeder.Description)},
+				lg("status"):      {Content: status},
+				lg("operation"):   {Content: runForm(seeder.Name, token, done)},
+			}
+		}
+		body = comp.Table().SetThead(types.Thead{
+			{Head: lg("name")},
+			{Head: lg("description")},
+			{Head: lg("status")},
+			{Head: lg("operation")},
+		}).SetInfoList(infos).GetContent()
+		if pending {
+			body += `<div class="text-right">` + runForm("", token, false) + `</div>`
+		}
+	}
+
+	if !s.allowed() {
+		body = template.HTML(`<p class="text-danger">`+lg("production not allowed")+`</p>`) + body
+	}
+
+	s.HTML(ctx, types.Panel{
+		Content:     comp.Box().SetBody(body).GetContent(),
+		Title:       template.HTML(lg("seeders")),
+		Description: template.HTML(lg("fill the demo and the test data")),
+	})
+}
+
+// Run run a seeder, the seeder which has been run is run again. All the
+// pending seeders are run when the name is empty.
+func (s *Seed) Run(ctx *context.Context) {
+	if !auth.GetTokenService(s.Services.Get(auth.TokenServiceKey)).CheckToken(ctx.FormValue(form.TokenKey)) {
+		s.alert(ctx, "wrong token")
+		return
+	}
+	if !s.allowed() {
+		s.alert(ctx, lg("production not allowed"))
+		return
+	}
+
+	var (
+		conn = db.GetConnection(s.Services)
+		name = ctx.FormValue("name")
+		err  error
+	)
+
+	if name == "" {
+		_, err = seed.RunPending(conn)
+	} else {
+		if _, ok := seed.Get(name); !ok {
+			s.alert(ctx, "wrong parameter")
+			return
+		}
+		_, err = seed.Run(conn, name, true)
+	}
+
+	if err != nil {
+		s.alert(ctx, err.Error())
+		return
+	}
+
+	ctx.Redirect(config.Url("/" + Name))
+}
+
+func (s *Seed) token() string {
+	return auth.GetTokenService(s.Services.Get(auth.TokenServiceKey)).AddToken()
+}
+
+func runForm(name, token string, ran bool) template.HTML {
+	label, class := lg("run"), "btn-primary"
+	switch {
+	case name == "":
+		label = lg("run all pending")
+	case ran:
+		label, class = lg("run again"), "btn-default"
+	}
+	return template.HTML(`<form method="post" action="` + template.HTMLEscapeString(config.Url("/"+Name+"/run")) + `" class="form-inline" style="display:inline;">` +
+		`<input type="hidden" name="` + form.TokenKey + `" value="` + template.HTMLEscapeString(token) + `">` +
+		`<input type="hidden" name="name" value="` + template.HTMLEscapeString(name) + `">` +
+		`<button type="submit" class="btn btn-sm ` + class + `">` + label + `</button></form>`)
+}
+
+func (s *Seed) alert(ctx *context.Context, msg string) {
+	s.HTML(ctx, template2.WarningPanel(ctx, msg).GetContent(config.IsProductionEnvironment()))
+}
+
+func escape(s string) template.HTML {
+	return template.HTML(template.HTMLEscapeString(s))
+}
+
+func lg(v string) string {
+	return language.GetWithScope(v, Name)
+}
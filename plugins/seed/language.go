@@ -0,0 +1,17 @@
+package seed
+
+var cn = map[string]string{
+	"seed.seeders":                         "数据填充",
+	"seed.fill the demo and the test data": "填充演示和测试数据",
+	"seed.name":                            "名称",
+	"seed.description":                     "描述",
+	"seed.status":                          "状态",
+	"seed.pending":                         "未执行",
+	"seed.ran at":                          "执行于",
+	"seed.operation":                       "操作",
+	"seed.run":                             "执行",
+	"seed.run again":                       "重新执行",
+	"seed.run all pending":                 "执行所有未执行的",
+	"seed.production not allowed":          "生产环境不允许执行数据填充",
+	"seed.no seeders":                      "暂无数据填充，请在代码中使用 seed.Register 注册",
+}
@@ -0,0 +1,18 @@
+package seed
+
+import (
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/service"
+)
+
+func (s *Seed) initRouter(prefix string, srv service.List) *context.App {
+
+	app := context.NewApp()
+	route := app.Group(prefix, auth.Middleware(db.GetConnection(srv)))
+	route.GET("/"+Name, s.ShowSeeders).Name("seed_list")
+	route.POST("/"+Name+"/run", s.Run).Name("seed_run")
+
+	return app
+}
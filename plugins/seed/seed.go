@@ -0,0 +1,92 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+// Package seed provides the page running the seeders of modules/seed, the
+// pending seeders can also be run at the startup.
+package seed
+
+import (
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/seed"
+	"github.com/purpose168/GoAdmin/modules/service"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins"
+)
+
+// Seed is a GoAdmin plugin.
+type Seed struct {
+	*plugins.Base
+
+	runOnStart      bool
+	allowProduction bool
+}
+
+// Name is the name of the plugin, also used as the url prefix.
+const Name = "seed"
+
+// NewSeed return a Seed plugin.
+func NewSeed() *Seed {
+	return &Seed{
+		Base: &plugins.Base{PlugName: Name},
+	}
+}
+
+// RunOnStart run the pending seeders when the plugin is initialized, it is
+// usually set by a command line flag.
+func (s *Seed) RunOnStart(run bool) *Seed {
+	s.runOnStart = run
+	return s
+}
+
+// AllowProduction allow the seeders to be run in the production environment,
+// which is refused by default.
+func (s *Seed) AllowProduction() *Seed {
+	s.allowProduction = true
+	return s
+}
+
+// InitPlugin implements Plugin.InitPlugin.
+func (s *Seed) InitPlugin(srv service.List) {
+	s.InitBase(srv, Name)
+	language.AppendTo(language.CN, cn)
+
+	if s.runOnStart {
+		if !s.allowed() {
+			logger.Warn("seeders are not run in the production environment")
+		} else if names, err := seed.RunPending(db.GetConnection(srv)); err != nil {
+			logger.Error("run seeders error: ", err)
+		} else if len(names) > 0 {
+			logger.Infof("seeders run: %v", names)
+		}
+	}
+
+	s.App = s.initRouter(config.Prefix(), srv)
+}
+
+func (s *Seed) allowed() bool {
+	return s.allowProduction || !config.IsProductionEnvironment()
+}
+
+func (s *Seed) GetIndexURL() string {
+	return config.Url("/" + Name)
+}
+
+func (s *Seed) IsInstalled() bool {
+	return true
+}
+
+func (s *Seed) GetInfo() plugins.Info {
+	return plugins.Info{
+		Title:       "Seeders",
+		Name:        Name,
+		Description: "Run the seeders registered by the plugins and the applications.",
+		Author:      "official",
+		Version:     "v0.0.1",
+		CreateDate:  utils.ParseTime("2026-10-21 00:00:00"),
+		UpdateDate:  utils.ParseTime("2026-10-21 00:00:00"),
+	}
+}
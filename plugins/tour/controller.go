@@ -0,0 +1,174 @@
+package tour
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/url"
+	"strconv"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	template2 "github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// StartParam is the query parameter starting the guide of the name.
+const StartParam = "__tour"
+
+// ShowGuides show the guides of the pages the user can access.
+func (t *Tour) ShowGuides(ctx *context.Context) {
+	var (
+		comp = template2.Default(ctx)
+		user = auth.Auth(ctx)
+		body template.HTML
+	)
+
+	infos := make([]map[string]types.InfoItem, 0)
+	for _, g := range t.Guides() {
+		if !user.CheckPermissionByUrlMethod(config.Url(g.Path), "GET", url.Values{}) {
+			continue
+		}
+		start := config.Url(g.Path) + "?" + StartParam + "=" + url.QueryEscape(g.Name)
+		infos = append(infos, map[string]types.InfoItem{
+			lg("title"):       {Content: escape(language.Get(g.Title))},
+			lg("description"): {Content: escape(language.Get(g.Description))},
+			lg("page"):        {Content: escape(g.Path)},
+			lg("steps"):       {Content: template.HTML(strconv.Itoa(len(g.Steps)))},
+			lg("operation"): {Content: template.HTML(`<a href="` + template.HTMLEscapeString(start) +
+				`" class="btn btn-xs btn-primary">` + lg("start") + `</a>`)},
+		})
+	}
+
+	if len(infos) == 0 {
+		body = template.HTML("<p>" + lg("no guides") + "</p>")
+	} else {
+		body = comp.Table().SetThead(types.Thead{
+			{Head: lg("title")},
+			{Head: lg("description")},
+			{Head: lg("page")},
+			{Head: lg("steps")},
+			{Head: lg("operation")},
+		}).SetInfoList(infos).GetContent()
+	}
+
+	t.HTML(ctx, types.Panel{
+		Content:     comp.Box().SetBody(body).GetContent(),
+		Title:       template.HTML(lg("help")),
+		Description: template.HTML(lg("guided tours")),
+	})
+}
+
+// decorate append the guides of the page and the script running them to the
+// panel.
+func (t *Tour) decorate(ctx *context.Context, user models.UserModel, panel types.Panel) types.Panel {
+	if ctx == nil || user.Id == 0 {
+		return panel
+	}
+	guides := t.pageGuides(ctx.Request.URL.Path)
+	if len(guides) == 0 {
+		return panel
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"guides": guides,
+		"user":   user.Id,
+		"start":  ctx.Query(StartParam),
+		"labels": map[string]string{
+			"next": lg("next"),
+			"prev": lg("prev"),
+			"skip": lg("skip"),
+			"done": lg("done"),
+		},
+	})
+	if err != nil {
+		logger.Error("marshal tour guides error: ", err)
+		return panel
+	}
+	panel.Content += template.HTML(`<script>(` + runner + `)(` + string(data) + `);</script>`)
+	return panel
+}
+
+// pageGuides return the translated guides of the page.
+func (t *Tour) pageGuides(path string) []Guide {
+	guides := make([]Guide, 0)
+	for _, g := range t.Guides() {
+		if config.Url(g.Path) != path || len(g.Steps) == 0 {
+			continue
+		}
+		g.Title = language.Get(g.Title)
+		steps := make([]Step, len(g.Steps))
+		for i, s := range g.Steps {
+			s.Title = language.Get(s.Title)
+			s.Content = language.Get(s.Content)
+			steps[i] = s
+		}
+		g.Steps = steps
+		guides = append(guides, g)
+	}
+	return guides
+}
+
+// runner runs the guide of the start parameter, or the first automatic guide
+// the user has not seen. The seen guides are kept in the local storage.
+const runner = `function (opt) {
+    var key = function (g) { return "goadmin_tour_" + opt.user + "_" + g.name; };
+    var seen = function (g) { try { return localStorage.getItem(key(g)) === "1"; } catch (e) { return false; } };
+    var guide = null;
+    $.each(opt.guides, function (_, g) {
+        if (guide === null && (opt.start ? g.name === opt.start : !g.manual && !seen(g))) { guide = g; }
+    });
+    if (guide === null) { return; }
+
+    $(".goadmin-tour").remove();
+    var box = $('<div class="popover goadmin-tour" style="display:block;position:absolute;z-index:1060;max-width:320px;">' +
+        '<h3 class="popover-title"></h3><div class="popover-content"><p></p><div class="text-right">' +
+        '<button type="button" class="btn btn-xs btn-default goadmin-tour-skip"></button> ' +
+        '<button type="button" class="btn btn-xs btn-default goadmin-tour-prev"></button> ' +
+        '<button type="button" class="btn btn-xs btn-primary goadmin-tour-next"></button></div></div></div>');
+    box.find(".goadmin-tour-skip").text(opt.labels.skip);
+    box.find(".goadmin-tour-prev").text(opt.labels.prev);
+    $("body").append(box);
+
+    var i = 0, dir = 1;
+    var end = function () {
+        box.remove();
+        try { localStorage.setItem(key(guide), "1"); } catch (e) {}
+    };
+    var show = function () {
+        if (i < 0) { i = 0; dir = 1; }
+        if (i >= guide.steps.length) { end(); return; }
+        var step = guide.steps[i], el = $(step.selector).filter(":visible").first();
+        if (el.length === 0) { i += dir; show(); return; }
+
+        box.find(".popover-title").text(step.title);
+        box.find(".popover-content p").text(step.content);
+        box.find(".goadmin-tour-prev").toggle(i > 0);
+        box.find(".goadmin-tour-next").text(i === guide.steps.length - 1 ? opt.labels.done : opt.labels.next);
+
+        var placement = step.placement || "bottom", off = el.offset(), top, left;
+        box.removeClass("top bottom left right").addClass(placement);
+        switch (placement) {
+            case "top": top = off.top - box.outerHeight() - 10; left = off.left; break;
+            case "left": top = off.top; left = off.left - box.outerWidth() - 10; break;
+            case "right": top = off.top; left = off.left + el.outerWidth() + 10; break;
+            default: top = off.top + el.outerHeight() + 10; left = off.left;
+        }
+        box.css({top: Math.max(top, 0), left: Math.max(left, 0)});
+        $("html, body").animate({scrollTop: Math.max(top - 100, 0)}, 200);
+    };
+    box.find(".goadmin-tour-skip").on("click", end);
+    box.find(".goadmin-tour-prev").on("click", function () { i--; dir = -1; show(); });
+    box.find(".goadmin-tour-next").on("click", function () { i++; dir = 1; show(); });
+    show();
+}`
+
+func escape(s string) template.HTML {
+	return template.HTML(template.HTMLEscapeString(s))
+}
+
+func lg(v string) string {
+	return language.GetWithScope(v, Name)
+}
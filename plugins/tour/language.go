@@ -0,0 +1,17 @@
+package tour
+
+var cn = map[string]string{
+	"tour.help":         "帮助",
+	"tour.guided tours": "页面导览，快速熟悉后台的使用",
+	"tour.title":        "标题",
+	"tour.description":  "描述",
+	"tour.page":         "页面",
+	"tour.steps":        "步骤数",
+	"tour.operation":    "操作",
+	"tour.start":        "开始",
+	"tour.no guides":    "暂无导览",
+	"tour.next":         "下一步",
+	"tour.prev":         "上一步",
+	"tour.skip":         "跳过",
+	"tour.done":         "完成",
+}
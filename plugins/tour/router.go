@@ -0,0 +1,18 @@
+package tour
+
+import (
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/auth"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/service"
+)
+
+func (t *Tour) initRouter(prefix string, srv service.List) *context.App {
+
+	app := context.NewApp()
+	// the help page is open to all the users.
+	route := app.Group(prefix, auth.SignedInMiddleware(db.GetConnection(srv)))
+	route.GET("/"+Name, t.ShowGuides).Name("tour_guides")
+
+	return app
+}
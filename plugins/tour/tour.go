@@ -0,0 +1,162 @@
+// Copyright 2019 GoAdmin Core Team. All rights reserved.
+// Use of this source code is governed by a Apache-2.0 style
+// license that can be found in the LICENSE file.
+
+// Package tour provides the guided tours of the admin pages. A guide is a
+// list of steps pointing at the elements of a page, defined in Go or loaded
+// from JSON:
+//
+//	t := tour.NewTour().AddGuides(tour.Guide{
+//		Name:  "users",
+//		Title: "Manage the users",
+//		Path:  "/info/manager",
+//		Steps: []tour.Step{
+//			{Selector: ".grid-create-btn", Title: "New", Content: "Create a user here."},
+//		},
+//	})
+//
+// The guides of a page are started the first time the user opens the page,
+// and all the guides are listed by the help button of the navigation bar.
+package tour
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/service"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins"
+	"github.com/purpose168/GoAdmin/template/icon"
+	"github.com/purpose168/GoAdmin/template/types"
+	"github.com/purpose168/GoAdmin/template/types/action"
+)
+
+// Tour is a GoAdmin plugin.
+type Tour struct {
+	*plugins.Base
+
+	lock   sync.RWMutex
+	guides []Guide
+}
+
+// Name is the name of the plugin, also used as the url prefix.
+const Name = "tour"
+
+// Step is a step of a guide.
+type Step struct {
+	// Selector is the jQuery selector of the element, the step is skipped
+	// when the element is not found.
+	Selector string `json:"selector"`
+	Title    string `json:"title"`
+	Content  string `json:"content"`
+	// Placement is top, bottom, left or right of the element, default bottom.
+	Placement string `json:"placement"`
+}
+
+// Guide is a guided tour of a page.
+type Guide struct {
+	Name        string `json:"name"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// Path is the path of the page without the global prefix, such as
+	// "/info/manager".
+	Path  string `json:"path"`
+	Steps []Step `json:"steps"`
+	// Manual guides are only started from the help page instead of the first
+	// visit of the page.
+	Manual bool `json:"manual"`
+}
+
+// NewTour return a Tour plugin.
+func NewTour() *Tour {
+	return &Tour{
+		Base: &plugins.Base{PlugName: Name},
+	}
+}
+
+// AddGuides add the guides, the guide of the same name is replaced.
+func (t *Tour) AddGuides(guides ...Guide) *Tour {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for _, g := range guides {
+		replaced := false
+		for i := range t.guides {
+			if t.guides[i].Name == g.Name {
+				t.guides[i] = g
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			t.guides = append(t.guides, g)
+		}
+	}
+	return t
+}
+
+// AddGuidesFromJSON add the guides of the JSON array.
+func (t *Tour) AddGuidesFromJSON(data []byte) error {
+	var guides []Guide
+	if err := json.Unmarshal(data, &guides); err != nil {
+		return err
+	}
+	t.AddGuides(guides...)
+	return nil
+}
+
+// AddGuidesFromFile add the guides of the JSON file.
+func (t *Tour) AddGuidesFromFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return t.AddGuidesFromJSON(data)
+}
+
+// Guides return all the guides.
+func (t *Tour) Guides() []Guide {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	guides := make([]Guide, len(t.guides))
+	copy(guides, t.guides)
+	return guides
+}
+
+// InitPlugin implements Plugin.InitPlugin.
+func (t *Tour) InitPlugin(srv service.List) {
+	t.InitBase(srv, Name)
+	language.AppendTo(language.CN, cn)
+
+	types.AddPageDecorator(t.decorate)
+
+	if t.UI != nil && t.UI.NavButtons != nil {
+		btn := types.GetNavButton("", icon.QuestionCircle, action.Jump(config.Url("/"+Name)), Name)
+		btn.Public = true
+		*t.UI.NavButtons = append(*t.UI.NavButtons, btn)
+	}
+
+	t.App = t.initRouter(config.Prefix(), srv)
+}
+
+func (t *Tour) GetIndexURL() string {
+	return config.Url("/" + Name)
+}
+
+func (t *Tour) IsInstalled() bool {
+	return true
+}
+
+func (t *Tour) GetInfo() plugins.Info {
+	return plugins.Info{
+		Title:       "Tour",
+		Name:        Name,
+		Description: "Guided tours of the admin pages for the new users.",
+		Author:      "official",
+		Version:     "v0.0.1",
+		CreateDate:  utils.ParseTime("2026-10-20 00:00:00"),
+		UpdateDate:  utils.ParseTime("2026-10-20 00:00:00"),
+	}
+}
@@ -0,0 +1,29 @@
+package tour
+
+import (
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddGuides(t *testing.T) {
+	tr := NewTour().AddGuides(Guide{Name: "users", Title: "Users", Path: "/info/manager", Steps: []Step{{Selector: ".a"}}})
+
+	err := tr.AddGuidesFromJSON([]byte(`[
+		{"name": "users", "title": "Manage the users", "path": "/info/manager", "steps": [{"selector": ".b", "content": "b"}]},
+		{"name": "roles", "path": "/info/roles", "manual": true, "steps": [{"selector": ".c"}]}
+	]`))
+	assert.Nil(t, err)
+
+	guides := tr.Guides()
+	assert.Equal(t, len(guides), 2)
+	assert.Equal(t, guides[0].Title, "Manage the users")
+	assert.Equal(t, guides[0].Steps[0].Selector, ".b")
+	assert.True(t, guides[1].Manual)
+
+	assert.NotNil(t, tr.AddGuidesFromJSON([]byte(`{`)))
+
+	assert.Equal(t, len(tr.pageGuides(config.Url("/info/roles"))), 1)
+	assert.Equal(t, len(tr.pageGuides(config.Url("/info/permission"))), 0)
+}
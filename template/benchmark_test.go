@@ -0,0 +1,67 @@
+package template_test
+
+import (
+	template2 "html/template"
+	"strconv"
+	"sync"
+	"testing"
+
+	_ "github.com/purpose168/GoAdmin-themes/adminlte"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+var benchConfigOnce sync.Once
+
+func initBenchConfig() {
+	benchConfigOnce.Do(func() {
+		config.Initialize(&config.Config{UrlPrefix: "admin", Theme: "adminlte", EarlyHints: true})
+	})
+}
+
+func BenchmarkDataTable_GetContent(b *testing.B) { benchmarkDataTable(b, 20) }
+
+// BenchmarkDataTable_GetContentLarge renders a page of thousands of rows, the
+// buffers of the components are taken from the pool.
+func BenchmarkDataTable_GetContentLarge(b *testing.B) { benchmarkDataTable(b, 5000) }
+
+func benchmarkDataTable(b *testing.B, rows int) {
+	initBenchConfig()
+
+	thead := types.Thead{
+		{Head: "ID", Field: "id", Sortable: true},
+		{Head: "Name", Field: "name"},
+		{Head: "Email", Field: "email"},
+		{Head: "City", Field: "city"},
+		{Head: "Created", Field: "created_at"},
+	}
+	list := make([]map[string]types.InfoItem, rows)
+	for i := range list {
+		id := strconv.Itoa(i + 1)
+		list[i] = map[string]types.InfoItem{
+			"id":         {Content: template2.HTML(id), Value: id},
+			"name":       {Content: template2.HTML("user " + id), Value: "user " + id},
+			"email":      {Content: template2.HTML("user" + id + "@example.com"), Value: "user" + id + "@example.com"},
+			"city":       {Content: "city", Value: "city"},
+			"created_at": {Content: "2026-10-16 10:00:00", Value: "2026-10-16 10:00:00"},
+		}
+	}
+
+	tmpl := template.Get(nil, "adminlte")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		body := tmpl.DataTable().
+			SetThead(thead).
+			SetInfoList(list).
+			SetPrimaryKey("id").
+			SetEditUrl("/admin/info/users/edit").
+			SetDeleteUrl("/admin/delete/users").
+			SetNewUrl("/admin/info/users/new").
+			GetContent()
+		tmpl.Box().SetBody(body).SetNoPadding().GetContent()
+	}
+}
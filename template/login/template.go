@@ -26,18 +26,43 @@ const loginTmpl = `{{define "login_theme1"}}
         <script src="{{link .CdnUrl .UrlPrefix "/assets/login/dist/respond.min.js"}}"></script>
         <![endif]-->
 
+        <style>
+            .login-logo { margin-bottom: 20px; text-align: center; }
+            .login-logo img { max-width: 100%; max-height: 80px; }
+            .login-links { margin-top: 10px; text-align: center; }
+            .login-links a { margin: 0 8px; }
+            .login-terms label { font-weight: normal; }
+            .login-layout-left .login-panel, .login-layout-right .login-panel {
+                position: fixed; top: 0; bottom: 0; z-index: 2; width: 420px; max-width: 100%;
+                padding: 80px 30px 30px; overflow-y: auto; background: #fff;
+            }
+            .login-layout-left .login-panel { left: 0; }
+            .login-layout-right .login-panel { right: 0; }
+            .login-layout-left .fh5co-form, .login-layout-right .fh5co-form { box-shadow: none; padding: 0; }
+            {{if .Page.BackgroundCSS}}body.login-background { {{.Page.BackgroundCSS}} }{{end}}
+        </style>
+
     </head>
-    <body>
+    <body class="login-layout-{{.Page.Layout}}{{if .Page.BackgroundCSS}} login-background{{end}}">
 
-    <div class="container">
-        <div class="row" style="margin-top: 80px;">
-            <div class="col-md-4 col-md-offset-4">
+    <div class="{{if eq .Page.Layout "left" "right"}}login-panel{{else}}container{{end}}">
+        <div class="row"{{if not (eq .Page.Layout "left" "right")}} style="margin-top: 80px;"{{end}}>
+            <div class="{{if eq .Page.Layout "left" "right"}}col-md-12{{else}}col-md-4 col-md-offset-4{{end}}">
                 <form action="##" onsubmit="return false" method="post" id="sign-up-form" class="fh5co-form animate-box"
                       data-animate-effect="fadeIn">
+                    {{if .Logo}}<div class="login-logo">{{.Logo}}</div>{{end}}
                     <h2>{{.Title}}</h2>
+                    {{if .Page.TenantLabel}}
+                    <div class="form-group">
+                        <label for="tenant" class="sr-only">{{.Page.TenantLabel}}</label>
+                        <input type="text" class="form-control" id="tenant" placeholder="{{.Page.TenantLabel}}"
+                               autocomplete="organization">
+                    </div>
+                    {{end}}
                     <div class="form-group">
-                        <label for="username" class="sr-only">Username</label>
-                        <input type="text" class="form-control" id="username" placeholder="{{lang "username"}}"
+                        <label for="username" class="sr-only">{{if .Page.UsernameLabel}}{{.Page.UsernameLabel}}{{else}}Username{{end}}</label>
+                        <input type="text" class="form-control" id="username"
+                               placeholder="{{if .Page.UsernameLabel}}{{.Page.UsernameLabel}}{{else}}{{lang "username"}}{{end}}"
                                autocomplete="off">
                     </div>
                     <div class="form-group">
@@ -45,9 +70,37 @@ const loginTmpl = `{{define "login_theme1"}}
                         <input type="password" class="form-control" id="password" placeholder="{{lang "password"}}"
                                autocomplete="off">
                     </div>
+                    {{if .Page.Terms}}
+                    <div class="form-group login-terms">
+                        <label><input type="checkbox" id="terms"> {{.Page.Terms}}</label>
+                    </div>
+                    {{end}}
                     <div class="form-group">
                         <button class="btn btn-primary" onclick="submitData()">{{lang "login"}}</button>
                     </div>
+                    {{if .SSOUrl}}
+                    <div class="form-group">
+                        <a class="btn btn-default" href="{{.SSOUrl}}{{if .Ref}}?ref={{.Ref}}{{end}}">{{lang "single sign-on"}}</a>
+                    </div>
+                    {{end}}
+                    {{if .Page.QRLogin}}
+                    <div class="form-group">
+                        <a class="btn btn-default" href="javascript:;" onclick="showQRLogin()">{{lang "scan to log in"}}</a>
+                    </div>
+                    <div id="qr-login" class="text-center" style="display: none;">
+                        <p>{{lang "scan the qr code with the signed in mobile"}}</p>
+                        <div class="qr-login-code"></div>
+                        <p class="qr-login-expired" style="display: none;">
+                            {{lang "qr code expired"}} <a href="javascript:;" onclick="showQRLogin()">{{lang "refresh"}}</a>
+                        </p>
+                        <p><a href="javascript:;" onclick="hideQRLogin()">{{lang "login with password"}}</a></p>
+                    </div>
+                    {{end}}
+                    {{if .Page.Links}}
+                    <div class="login-links">
+                        {{range .Page.Links}}<a href="{{.URL}}">{{.Text}}</a>{{end}}
+                    </div>
+                    {{end}}
                 </form>
             </div>
         </div>
@@ -60,23 +113,41 @@ const loginTmpl = `{{define "login_theme1"}}
         </div>
     </div>
 
+    {{if not .Page.BackgroundCSS}}
     <div id="particles-js">
         <canvas class="particles-js-canvas-el" width="1606" height="1862" style="width: 100%; height: 100%;"></canvas>
     </div>
+    {{end}}
 
     <script src="{{link .CdnUrl .UrlPrefix "/assets/login/dist/all.min.js"}}"></script>
 
     <script>
         function submitData() {
+            var data = {
+                'username': $("#username").val(),
+                'password': $("#password").val(),
+                'ref': '{{.Ref}}'
+            };
+            {{if .Page.TenantLabel}}
+            data.tenant = $("#tenant").val();
+            if ($.trim(data.tenant) === "") {
+                alert('{{lang "login tenant required"}}');
+                return;
+            }
+            {{end}}
+            {{if .Page.Terms}}
+            if (!$("#terms").prop("checked")) {
+                alert('{{lang "login terms required"}}');
+                return;
+            }
+            data.terms = "on";
+            {{end}}
             $.ajax({
                 dataType: 'json',
                 type: 'POST',
                 url: '{{.UrlPrefix}}/signin',
                 async: 'true',
-                data: {
-                    'username': $("#username").val(),
-                    'password': $("#password").val()
-                },
+                data: data,
                 success: function (data) {
                     location.href = data.data.url
                 },
@@ -85,6 +156,57 @@ const loginTmpl = `{{define "login_theme1"}}
                 }
             });
         }
+        {{if .Page.QRLogin}}
+        var qrLoginTimer = null;
+
+        function hideQRLogin() {
+            clearTimeout(qrLoginTimer);
+            $("#qr-login").hide();
+            $("#sign-up-form > .form-group").show();
+        }
+
+        function showQRLogin() {
+            clearTimeout(qrLoginTimer);
+            $("#sign-up-form > .form-group").hide();
+            $("#qr-login").show().find(".qr-login-expired").hide();
+            $.ajax({
+                dataType: 'json',
+                type: 'POST',
+                url: '{{.UrlPrefix}}/qrlogin',
+                success: function (data) {
+                    $("#qr-login .qr-login-code").html(data.data.qr_code);
+                    pollQRLogin(data.data.code, data.data.token);
+                },
+                error: function () {
+                    $("#qr-login .qr-login-expired").show();
+                }
+            });
+        }
+
+        function pollQRLogin(code, token) {
+            qrLoginTimer = setTimeout(function () {
+                $.ajax({
+                    dataType: 'json',
+                    type: 'POST',
+                    url: '{{.UrlPrefix}}/qrlogin/status',
+                    data: {'code': code, 'token': token, 'ref': '{{.Ref}}'},
+                    success: function (data) {
+                        if (data.data.state === "approved") {
+                            location.href = data.data.url;
+                        } else if (data.data.state === "pending") {
+                            pollQRLogin(code, token);
+                        } else {
+                            $("#qr-login .qr-login-code").empty();
+                            $("#qr-login .qr-login-expired").show();
+                        }
+                    },
+                    error: function () {
+                        pollQRLogin(code, token);
+                    }
+                });
+            }, 2000);
+        }
+        {{end}}
     </script>
 
     </body>
@@ -0,0 +1,42 @@
+package template_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/constant"
+	"github.com/purpose168/GoAdmin/template"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPreloadLinks(t *testing.T) {
+	initBenchConfig()
+
+	links := template.GetPreloadLinks(context.NewContext(httptest.NewRequest("GET", "/admin", nil)))
+	assert.NotEmpty(t, links)
+
+	var style, script bool
+	for _, link := range links {
+		assert.True(t, strings.HasPrefix(link, "</admin/assets/"), link)
+		style = style || strings.HasSuffix(link, "; rel=preload; as=style")
+		script = script || strings.HasSuffix(link, "; rel=preload; as=script")
+	}
+	assert.True(t, style)
+	assert.True(t, script)
+}
+
+func TestAddPreloadHeader(t *testing.T) {
+	initBenchConfig()
+
+	ctx := context.NewContext(httptest.NewRequest("GET", "/admin", nil))
+	template.AddPreloadHeader(ctx)
+	assert.Equal(t, strings.Join(template.GetPreloadLinks(ctx), ", "), ctx.Response.Header.Get("Link"))
+
+	req := httptest.NewRequest("GET", "/admin", nil)
+	req.Header.Set(constant.PjaxHeader, "true")
+	ctx = context.NewContext(req)
+	template.AddPreloadHeader(ctx)
+	assert.Empty(t, ctx.Response.Header.Get("Link"))
+}
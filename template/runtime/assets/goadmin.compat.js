@@ -0,0 +1,428 @@
+/*
+ * GoAdmin jQuery compatibility layer.
+ *
+ * The scripts of the core components are written against jQuery. When the
+ * theme does not load jQuery, this file defines a small subset of it over
+ * the GoAdmin runtime, the DOM selection and traversal, the classes and the
+ * attributes, the events, and $.ajax, $.get and $.post by fetch. The select2
+ * calls keep the native selects. It does nothing when jQuery is loaded.
+ */
+(function (window, document) {
+    "use strict";
+
+    var G = window.GoAdmin;
+    if (!G || typeof window.jQuery === "function") {
+        return;
+    }
+
+    var Wrapper = function (nodes) {
+        var self = this;
+        self.length = nodes.length;
+        nodes.forEach(function (node, i) {
+            self[i] = node;
+        });
+    };
+
+    var parseHTML = function (html) {
+        var tpl = document.createElement("template");
+        tpl.innerHTML = html.trim();
+        return Array.prototype.slice.call(tpl.content.childNodes);
+    };
+
+    var $ = function (target, root) {
+        if (typeof target === "function") {
+            G.ready(target);
+            return $(document);
+        }
+        if (target instanceof Wrapper) {
+            return target;
+        }
+        if (typeof target === "string" && target.trim().charAt(0) === "<") {
+            return new Wrapper(parseHTML(target));
+        }
+        if (root instanceof Wrapper) {
+            root = root[0];
+        }
+        return new Wrapper(G.all(target, root));
+    };
+
+    var fn = Wrapper.prototype;
+
+    fn.toArray = function () {
+        return Array.prototype.slice.call(this);
+    };
+    fn.get = function (i) {
+        return i === undefined ? this.toArray() : this[i < 0 ? this.length + i : i];
+    };
+    fn.each = function (cb) {
+        this.toArray().forEach(function (node, i) {
+            cb.call(node, i, node);
+        });
+        return this;
+    };
+    fn.eq = function (i) {
+        var node = this.get(i);
+        return new Wrapper(node ? [node] : []);
+    };
+    fn.first = function () {
+        return this.eq(0);
+    };
+    fn.last = function () {
+        return this.eq(-1);
+    };
+
+    var unique = function (nodes) {
+        return nodes.filter(function (node, i) {
+            return node && nodes.indexOf(node) === i;
+        });
+    };
+
+    fn.find = function (selector) {
+        var res = [];
+        this.each(function () {
+            res = res.concat(G.all(selector, this));
+        });
+        return new Wrapper(unique(res));
+    };
+    fn.closest = function (selector) {
+        return new Wrapper(unique(this.toArray().map(function (node) {
+            return node.closest ? node.closest(selector) : null;
+        })));
+    };
+    fn.parent = function () {
+        return new Wrapper(unique(this.toArray().map(function (node) {
+            return node.parentElement;
+        })));
+    };
+    fn.children = function (selector) {
+        var res = [];
+        this.each(function () {
+            Array.prototype.forEach.call(this.children, function (child) {
+                if (!selector || child.matches(selector)) {
+                    res.push(child);
+                }
+            });
+        });
+        return new Wrapper(res);
+    };
+    fn.filter = function (selector) {
+        return new Wrapper(this.toArray().filter(function (node, i) {
+            return typeof selector === "function" ? selector.call(node, i, node) : node.matches && node.matches(selector);
+        }));
+    };
+    fn.is = function (selector) {
+        return this.toArray().some(function (node) {
+            return node.matches && node.matches(selector);
+        });
+    };
+
+    fn.on = function (events, selector, handler) {
+        var self = this;
+        var remove = G.on(self.toArray(), events, selector, function (e) {
+            var res = (handler || selector).call(this, e, e.detail);
+            if (res === false) {
+                e.preventDefault();
+                e.stopPropagation();
+            }
+            return res;
+        });
+        self.each(function () {
+            this.goadminHandlers = (this.goadminHandlers || []).concat([{events: events, remove: remove}]);
+        });
+        return self;
+    };
+    fn.off = function (events) {
+        return this.each(function () {
+            (this.goadminHandlers || []).forEach(function (h) {
+                if (!events || h.events === events) {
+                    h.remove();
+                }
+            });
+            this.goadminHandlers = events ? (this.goadminHandlers || []).filter(function (h) {
+                return h.events !== events;
+            }) : [];
+        });
+    };
+    fn.trigger = function (name, detail) {
+        var self = this;
+        if (name === "click" || name === "submit" || name === "focus") {
+            return self.each(function () {
+                if (typeof this[name] === "function") {
+                    this[name]();
+                }
+            });
+        }
+        G.trigger(self.toArray(), name, detail);
+        return self;
+    };
+    ["click", "change", "submit"].forEach(function (name) {
+        fn[name] = function (handler) {
+            return handler ? this.on(name, handler) : this.trigger(name);
+        };
+    });
+
+    fn.val = function (v) {
+        if (v === undefined) {
+            return G.value(this.toArray());
+        }
+        G.setValue(this.toArray(), v);
+        return this;
+    };
+    fn.text = function (v) {
+        if (v === undefined) {
+            return this.toArray().map(function (node) {
+                return node.textContent;
+            }).join("");
+        }
+        return this.each(function () {
+            this.textContent = v;
+        });
+    };
+    fn.html = function (v) {
+        if (v === undefined) {
+            return this[0] ? this[0].innerHTML : undefined;
+        }
+        return this.each(function () {
+            this.innerHTML = v;
+        });
+    };
+    fn.attr = function (name, v) {
+        if (v === undefined) {
+            return this[0] ? this[0].getAttribute(name) : undefined;
+        }
+        return this.each(function () {
+            this.setAttribute(name, v);
+        });
+    };
+    fn.removeAttr = function (name) {
+        return this.each(function () {
+            this.removeAttribute(name);
+        });
+    };
+    fn.prop = function (name, v) {
+        if (v === undefined) {
+            return this[0] ? this[0][name] : undefined;
+        }
+        return this.each(function () {
+            this[name] = v;
+        });
+    };
+    fn.data = function (name, v) {
+        var key = "data-" + name.replace(/[A-Z]/g, function (c) {
+            return "-" + c.toLowerCase();
+        });
+        if (v === undefined) {
+            var raw = this.attr(key);
+            if (raw === null || raw === undefined) {
+                return undefined;
+            }
+            try {
+                return JSON.parse(raw);
+            } catch (e) {
+                return raw;
+            }
+        }
+        return this.attr(key, typeof v === "string" ? v : JSON.stringify(v));
+    };
+    fn.css = function (name, v) {
+        if (typeof name === "object") {
+            var self = this;
+            Object.keys(name).forEach(function (k) {
+                self.css(k, name[k]);
+            });
+            return self;
+        }
+        if (v === undefined) {
+            return this[0] ? window.getComputedStyle(this[0])[name] : undefined;
+        }
+        return this.each(function () {
+            this.style[name] = typeof v === "number" ? v + "px" : v;
+        });
+    };
+
+    var classes = function (names) {
+        return String(names || "").split(/\s+/).filter(Boolean);
+    };
+    fn.addClass = function (names) {
+        return this.each(function () {
+            DOMTokenList.prototype.add.apply(this.classList, classes(names));
+        });
+    };
+    fn.removeClass = function (names) {
+        return this.each(function () {
+            DOMTokenList.prototype.remove.apply(this.classList, classes(names));
+        });
+    };
+    fn.toggleClass = function (names, state) {
+        return this.each(function () {
+            var list = this.classList;
+            classes(names).forEach(function (name) {
+                list.toggle(name, state);
+            });
+        });
+    };
+    fn.hasClass = function (name) {
+        return this.toArray().some(function (node) {
+            return node.classList.contains(name);
+        });
+    };
+    fn.show = function () {
+        G.show(this.toArray());
+        return this;
+    };
+    fn.hide = function () {
+        G.hide(this.toArray());
+        return this;
+    };
+    fn.toggle = function (show) {
+        return this.each(function () {
+            var visible = show === undefined ? this.style.display === "none" : show;
+            this.style.display = visible ? "" : "none";
+        });
+    };
+
+    var nodesOf = function (content) {
+        if (typeof content === "string") {
+            return parseHTML(content);
+        }
+        return $(content).toArray();
+    };
+    fn.append = function (content) {
+        return this.each(function (i) {
+            var parent = this;
+            nodesOf(content).forEach(function (node) {
+                parent.appendChild(i === 0 ? node : node.cloneNode(true));
+            });
+        });
+    };
+    fn.prepend = function (content) {
+        return this.each(function (i) {
+            var parent = this;
+            nodesOf(content).reverse().forEach(function (node) {
+                parent.insertBefore(i === 0 ? node : node.cloneNode(true), parent.firstChild);
+            });
+        });
+    };
+    fn.remove = function () {
+        return this.each(function () {
+            if (this.parentNode) {
+                this.parentNode.removeChild(this);
+            }
+        });
+    };
+    fn.empty = function () {
+        return this.html("");
+    };
+    fn.serialize = function () {
+        return this[0] ? G.encode(this[0]) : "";
+    };
+
+    // the select2 of the compatibility layer keeps the native selects.
+    fn.select2 = function () {
+        return this;
+    };
+
+    // deferred wrap the promise with the done, fail and always of jQuery.
+    var deferred = function (promise) {
+        promise.done = function (cb) {
+            promise.then(function (res) {
+                cb(res.data, "success", res.xhr);
+            }, function () {
+            });
+            return promise;
+        };
+        promise.fail = function (cb) {
+            promise.then(null, function (xhr) {
+                cb(xhr, "error", xhr.statusText);
+            });
+            return promise;
+        };
+        promise.always = function (cb) {
+            promise.then(function (res) {
+                cb(res.data, "success", res.xhr);
+            }, function (xhr) {
+                cb(xhr, "error", xhr.statusText);
+            });
+            return promise;
+        };
+        return promise;
+    };
+
+    $.ajax = function (url, opts) {
+        if (typeof url === "object") {
+            opts = url;
+            url = opts.url;
+        }
+        opts = opts || {};
+        var method = (opts.method || opts.type || "GET").toUpperCase();
+        var promise = G.request(method, url, opts.data, {headers: opts.headers}).then(function (data) {
+            var xhr = {status: 200, responseJSON: data, responseText: typeof data === "string" ? data : JSON.stringify(data)};
+            return {data: data, xhr: xhr};
+        }, function (err) {
+            var body = err.response;
+            throw {
+                status: err.status || 0,
+                statusText: err.message,
+                responseJSON: typeof body === "object" ? body : undefined,
+                responseText: typeof body === "string" ? body : JSON.stringify(body)
+            };
+        });
+        promise.then(function (res) {
+            if (opts.success) {
+                opts.success(res.data, "success", res.xhr);
+            }
+            if (opts.complete) {
+                opts.complete(res.xhr, "success");
+            }
+        }, function (xhr) {
+            if (opts.error) {
+                opts.error(xhr, "error", xhr.statusText);
+            }
+            if (opts.complete) {
+                opts.complete(xhr, "error");
+            }
+        });
+        return deferred(promise);
+    };
+    $.get = function (url, data, success) {
+        if (typeof data === "function") {
+            success = data;
+            data = undefined;
+        }
+        return $.ajax({url: url, type: "GET", data: data, success: success});
+    };
+    $.post = function (url, data, success) {
+        if (typeof data === "function") {
+            success = data;
+            data = undefined;
+        }
+        return $.ajax({url: url, type: "POST", data: data, success: success});
+    };
+
+    $.each = function (obj, cb) {
+        if (Array.isArray(obj) || obj instanceof Wrapper) {
+            Array.prototype.slice.call(obj).forEach(function (v, i) {
+                cb.call(v, i, v);
+            });
+        } else {
+            Object.keys(obj || {}).forEach(function (k) {
+                cb.call(obj[k], k, obj[k]);
+            });
+        }
+        return obj;
+    };
+    $.extend = function () {
+        return Object.assign.apply(Object, Array.prototype.filter.call(arguments, function (a) {
+            return typeof a === "object";
+        }));
+    };
+    $.trim = function (s) {
+        return String(s === null || s === undefined ? "" : s).trim();
+    };
+    $.isArray = Array.isArray;
+    $.param = G.encode;
+    $.fn = fn;
+    $.goadminCompat = true;
+
+    window.jQuery = window.$ = $;
+})(window, document);
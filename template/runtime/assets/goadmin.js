@@ -0,0 +1,275 @@
+/*
+ * GoAdmin component runtime.
+ *
+ * A small dependency free runtime for the scripts of the components. It uses
+ * jQuery, select2, pjax and sweetalert when the theme loads them, and falls
+ * back to the browser APIs otherwise, so the components keep working with
+ * the themes without the legacy libraries.
+ *
+ * The runtime is available as window.GoAdmin, and as an ES module by
+ * importing goadmin.mjs.
+ */
+(function (window, document) {
+    "use strict";
+
+    if (window.GoAdmin && window.GoAdmin.version) {
+        return;
+    }
+
+    var hasJQuery = function () {
+        return typeof window.jQuery === "function" && !window.jQuery.goadminCompat;
+    };
+
+    // all return the elements of the selector, a node, a list of nodes or a
+    // jQuery object.
+    var all = function (target, root) {
+        if (!target) {
+            return [];
+        }
+        if (typeof target === "string") {
+            return Array.prototype.slice.call((root || document).querySelectorAll(target));
+        }
+        if (target.nodeType || target === window) {
+            return [target];
+        }
+        if (typeof target.length === "number") {
+            return Array.prototype.slice.call(target);
+        }
+        return [];
+    };
+
+    var one = function (target, root) {
+        return all(target, root)[0] || null;
+    };
+
+    var ready = function (fn) {
+        if (document.readyState !== "loading") {
+            fn();
+        } else {
+            document.addEventListener("DOMContentLoaded", fn);
+        }
+    };
+
+    // on bind the handler of the events, the events of the children matching
+    // the selector are delegated when it is given. It returns a function
+    // removing the handler.
+    var on = function (target, events, selector, handler) {
+        if (typeof selector === "function") {
+            handler = selector;
+            selector = null;
+        }
+        var names = events.split(/\s+/).filter(Boolean);
+        var listener = function (e) {
+            if (!selector) {
+                return handler.call(this, e);
+            }
+            var el = e.target && e.target.closest ? e.target.closest(selector) : null;
+            if (el && this.contains(el)) {
+                return handler.call(el, e);
+            }
+        };
+        var nodes = all(target);
+        nodes.forEach(function (node) {
+            names.forEach(function (name) {
+                node.addEventListener(name, listener);
+            });
+        });
+        return function () {
+            nodes.forEach(function (node) {
+                names.forEach(function (name) {
+                    node.removeEventListener(name, listener);
+                });
+            });
+        };
+    };
+
+    var trigger = function (target, name, detail) {
+        all(target).forEach(function (node) {
+            node.dispatchEvent(new CustomEvent(name, {bubbles: true, cancelable: true, detail: detail}));
+        });
+    };
+
+    // encode return the url encoded form of the object, a FormData or a form.
+    var encode = function (data) {
+        if (!data) {
+            return "";
+        }
+        if (typeof data === "string") {
+            return data;
+        }
+        if (data.nodeName === "FORM") {
+            data = new FormData(data);
+        }
+        var params = new URLSearchParams();
+        if (typeof FormData !== "undefined" && data instanceof FormData) {
+            data.forEach(function (v, k) {
+                params.append(k, v);
+            });
+            return params.toString();
+        }
+        Object.keys(data).forEach(function (k) {
+            var v = data[k];
+            if (Array.isArray(v)) {
+                v.forEach(function (item) {
+                    params.append(k, item);
+                });
+            } else if (v !== undefined && v !== null) {
+                params.append(k, v);
+            }
+        });
+        return params.toString();
+    };
+
+    // request send the request by fetch and resolve the parsed json, or the
+    // text when the response is not json. It rejects with an error with the
+    // status and the response body when the status is not 2xx.
+    var request = function (method, url, data, options) {
+        options = options || {};
+        method = method.toUpperCase();
+        var headers = Object.assign({"X-Requested-With": "XMLHttpRequest"}, options.headers || {});
+        var init = {method: method, headers: headers, credentials: "same-origin"};
+        if (data !== undefined && data !== null) {
+            if (method === "GET" || method === "HEAD") {
+                var qs = encode(data);
+                if (qs) {
+                    url += (url.indexOf("?") === -1 ? "?" : "&") + qs;
+                }
+            } else if (typeof FormData !== "undefined" && data instanceof FormData) {
+                init.body = data;
+            } else {
+                headers["Content-Type"] = "application/x-www-form-urlencoded; charset=UTF-8";
+                init.body = encode(data);
+            }
+        }
+        return window.fetch(url, init).then(function (res) {
+            var type = res.headers.get("Content-Type") || "";
+            return (type.indexOf("json") !== -1 ? res.json() : res.text()).then(function (body) {
+                if (!res.ok) {
+                    var err = new Error((body && body.msg) || res.statusText);
+                    err.status = res.status;
+                    err.response = body;
+                    throw err;
+                }
+                return body;
+            });
+        });
+    };
+
+    // notify show the message by sweetalert, or by alert.
+    var notify = function (msg, type) {
+        if (typeof window.swal === "function") {
+            window.swal(msg, "", type || "info");
+        } else {
+            window.alert(msg);
+        }
+    };
+
+    var confirmAction = function (msg) {
+        return Promise.resolve(window.confirm(msg));
+    };
+
+    // visit load the url into the pjax container, or the whole page.
+    var visit = function (url) {
+        if (hasJQuery() && window.jQuery.pjax && document.getElementById("pjax-container")) {
+            window.jQuery.pjax({url: url, container: "#pjax-container"});
+        } else {
+            window.location.href = url;
+        }
+    };
+
+    var reload = function () {
+        if (hasJQuery() && window.jQuery.pjax && document.getElementById("pjax-container")) {
+            window.jQuery.pjax.reload("#pjax-container");
+        } else {
+            window.location.reload();
+        }
+    };
+
+    // select enhance the selects by select2 when it is loaded, the native
+    // selects are kept otherwise.
+    var select = function (target, options) {
+        var nodes = all(target);
+        if (hasJQuery() && window.jQuery.fn && window.jQuery.fn.select2) {
+            window.jQuery(nodes).select2(options || {});
+        }
+        return nodes;
+    };
+
+    // value return the value of the input, the values of a multiple select
+    // or a group of checkboxes are returned as an array.
+    var value = function (target) {
+        var nodes = all(target);
+        if (nodes.length === 0) {
+            return undefined;
+        }
+        var el = nodes[0];
+        if (el.multiple) {
+            return Array.prototype.filter.call(el.options, function (o) {
+                return o.selected;
+            }).map(function (o) {
+                return o.value;
+            });
+        }
+        if (el.type === "checkbox" || el.type === "radio") {
+            var checked = nodes.filter(function (n) {
+                return n.checked;
+            }).map(function (n) {
+                return n.value;
+            });
+            return el.type === "radio" ? checked[0] : checked;
+        }
+        return el.value;
+    };
+
+    var setValue = function (target, v) {
+        all(target).forEach(function (el) {
+            if (el.multiple && Array.isArray(v)) {
+                Array.prototype.forEach.call(el.options, function (o) {
+                    o.selected = v.indexOf(o.value) !== -1;
+                });
+            } else if (el.type === "checkbox" || el.type === "radio") {
+                el.checked = Array.isArray(v) ? v.indexOf(el.value) !== -1 : el.value === String(v);
+            } else {
+                el.value = v;
+            }
+        });
+        trigger(target, "change");
+    };
+
+    var toggle = function (target, show) {
+        all(target).forEach(function (el) {
+            el.style.display = show ? "" : "none";
+        });
+    };
+
+    window.GoAdmin = {
+        version: "1",
+        hasJQuery: hasJQuery,
+        all: all,
+        one: one,
+        ready: ready,
+        on: on,
+        trigger: trigger,
+        encode: encode,
+        request: request,
+        get: function (url, data, options) {
+            return request("GET", url, data, options);
+        },
+        post: function (url, data, options) {
+            return request("POST", url, data, options);
+        },
+        notify: notify,
+        confirm: confirmAction,
+        visit: visit,
+        reload: reload,
+        select: select,
+        value: value,
+        setValue: setValue,
+        show: function (target) {
+            toggle(target, true);
+        },
+        hide: function (target) {
+            toggle(target, false);
+        }
+    };
+})(window, document);
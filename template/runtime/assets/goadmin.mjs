@@ -0,0 +1,15 @@
+/*
+ * The ES module of the GoAdmin component runtime, see goadmin.js.
+ *
+ *     import GoAdmin, {on, post} from "/admin/assets/goadmin/runtime/goadmin.mjs";
+ */
+import "./goadmin.js";
+
+const GoAdmin = window.GoAdmin;
+
+export const {
+    version, hasJQuery, all, one, ready, on, trigger, encode, request, get, post,
+    notify, confirm, visit, reload, select, value, setValue, show, hide
+} = GoAdmin;
+
+export default GoAdmin;
@@ -0,0 +1,66 @@
+// Package runtime is the component of the GoAdmin JavaScript runtime, a small
+// dependency free runtime for the scripts of the components with a jQuery
+// compatibility layer, so the themes can drop jQuery and select2 while the
+// components keep working.
+//
+// The component is added when the package is imported, which is done by the
+// engine. The runtime and the compatibility layer are imported into every
+// page, and the ES module is served for the themes and the custom pages:
+//
+//	<script type="module">
+//	import {on, post} from "/admin/assets/goadmin/runtime/goadmin.mjs";
+//	</script>
+package runtime
+
+import (
+	"embed"
+	"errors"
+	"html/template"
+	"strings"
+
+	template2 "github.com/purpose168/GoAdmin/template"
+)
+
+// Name is the name of the component.
+const Name = "goadmin_runtime"
+
+// AssetsList is the assets of the runtime, the compatibility layer is after
+// the runtime as it is built on it.
+var AssetsList = []string{
+	"/goadmin/runtime/goadmin.js",
+	"/goadmin/runtime/goadmin.compat.js",
+	"/goadmin/runtime/goadmin.mjs",
+}
+
+//go:embed assets/*
+var assets embed.FS
+
+// Runtime is the component of the runtime.
+type Runtime struct {
+	*template2.BaseComponent
+}
+
+// New return the component of the runtime.
+func New() *Runtime {
+	return &Runtime{
+		BaseComponent: &template2.BaseComponent{Name: Name},
+	}
+}
+
+func init() {
+	template2.AddComp(New())
+}
+
+// GetAssetList implements the template.Component.GetAssetList.
+func (r *Runtime) GetAssetList() []string { return AssetsList }
+
+// GetAsset implements the template.Component.GetAsset.
+func (r *Runtime) GetAsset(name string) ([]byte, error) {
+	if !strings.HasPrefix(name, "/goadmin/runtime/") {
+		return nil, errors.New(name + " not found")
+	}
+	return assets.ReadFile("assets/" + strings.TrimPrefix(name, "/goadmin/runtime/"))
+}
+
+// GetContent implements the template.Component.GetContent.
+func (r *Runtime) GetContent() template.HTML { return "" }
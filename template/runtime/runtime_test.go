@@ -0,0 +1,30 @@
+package runtime
+
+import (
+	"strings"
+	"testing"
+
+	template2 "github.com/purpose168/GoAdmin/template"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssets(t *testing.T) {
+	r := New()
+	for _, name := range r.GetAssetList() {
+		data, err := r.GetAsset(name)
+		assert.Nil(t, err, name)
+		assert.NotEmpty(t, data, name)
+	}
+
+	_, err := r.GetAsset("/goadmin/runtime/missing.js")
+	assert.NotNil(t, err)
+	_, err = r.GetAsset("/chart.min.js")
+	assert.NotNil(t, err)
+
+	mjs, _ := r.GetAsset("/goadmin/runtime/goadmin.mjs")
+	assert.True(t, strings.Contains(string(mjs), "export default GoAdmin"))
+
+	data, err := template2.GetAsset("/goadmin/runtime/goadmin.js")
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(string(data), "window.GoAdmin = {"))
+}
@@ -0,0 +1,45 @@
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/logger"
+)
+
+// sqlDebugTmpl 是调试模式下页面右下角的 SQL 面板，列出渲染页面时执行的语句
+var sqlDebugTmpl = template.Must(template.New("sql_debug").Parse(`<div class="goadmin-sql-debug" style="position:fixed;right:12px;bottom:12px;z-index:2000;max-width:60%;max-height:50%;overflow:auto;background:#fff;border:1px solid #d2d6de;box-shadow:0 1px 6px rgba(0,0,0,.2);font-size:12px;">
+<details>
+<summary style="padding:6px 10px;cursor:pointer;">SQL: {{len .Records}} / {{.Total}}</summary>
+<table class="table table-condensed" style="margin:0;">
+{{range .Records}}<tr><td style="white-space:nowrap;">{{.Elapsed}}</td><td><code>{{.Statement}}</code>{{if .Args}}<br><small>{{printf "%v" .Args}}</small>{{end}}</td></tr>
+{{end}}</table>
+</details>
+</div>`))
+
+// sqlDebugPanel 生成调试模式下的 SQL 面板，见 logger.RecordSQL
+// 参数:
+//   - ctx: 上下文对象
+//
+// 返回: 面板的 HTML，请求没有记录 SQL 时返回空字符串
+func sqlDebugPanel(ctx *context.Context) template.HTML {
+	records := logger.SQLRecords(ctx)
+	if records == nil {
+		return ""
+	}
+	var total time.Duration
+	for _, record := range records {
+		total += record.Elapsed
+	}
+	buf := new(bytes.Buffer)
+	if err := sqlDebugTmpl.Execute(buf, struct {
+		Records []logger.SQLRecord
+		Total   time.Duration
+	}{Records: records, Total: total}); err != nil {
+		return template.HTML(fmt.Sprintf("<!-- sql debug: %s -->", template.HTMLEscapeString(err.Error())))
+	}
+	return template.HTML(buf.String())
+}
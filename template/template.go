@@ -8,8 +8,12 @@ import (
 	"bytes"
 	"errors"
 	"html/template"
+	"io"
+	"net/url"
 	"path"
 	"plugin"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -75,6 +79,9 @@ type Template interface {
 	Get403HTML() template.HTML
 }
 
+// Template 可以用于 types.NewLayout 组合页面布局
+var _ types.LayoutComponents = Template(nil)
+
 // PageType 页面类型枚举
 type PageType uint8
 
@@ -419,6 +426,57 @@ func GetComponentAssetImportHTML(ctx *context.Context) (res template.HTML) {
 	return
 }
 
+// assetURLReg 匹配资源导入HTML中的资源URL
+var assetURLReg = regexp.MustCompile(`(?:href|src)="([^"]+)"`)
+
+// GetPreloadLinks 获取页面关键资源的 Link 预加载头
+// 资源从主题的头部HTML和 GetComponentAssetImportHTML 中获取，用于 103 Early Hints 或 Link 响应头
+// 参数:
+//   - ctx: 上下文对象
+//
+// 返回: Link 头的值列表，如 </admin/assets/dist/css/all.min.css>; rel=preload; as=style
+func GetPreloadLinks(ctx *context.Context) []string {
+	var (
+		links = make([]string, 0)
+		seen  = make(map[string]bool)
+	)
+	// 头部资源保持原有顺序；组件资源来自 map，排序后保证 Link 头稳定
+	for i, html := range []template.HTML{Default(ctx).GetHeadHTML(), GetComponentAssetImportHTML(ctx)} {
+		urls := make([]string, 0)
+		for _, match := range assetURLReg.FindAllStringSubmatch(string(html), -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				urls = append(urls, match[1])
+			}
+		}
+		if i > 0 {
+			sort.Strings(urls)
+		}
+		for _, u := range urls {
+			switch path.Ext(strings.SplitN(u, "?", 2)[0]) {
+			case ".css":
+				links = append(links, "<"+u+">; rel=preload; as=style")
+			case ".js":
+				links = append(links, "<"+u+">; rel=preload; as=script")
+			}
+		}
+	}
+	return links
+}
+
+// AddPreloadHeader 开启 EarlyHints 时为完整页面添加 Link 预加载响应头
+// 适配器只复制每个响应头的第一个值，因此多个链接合并为一个以逗号分隔的 Link 头
+// 参数:
+//   - ctx: 上下文对象
+func AddPreloadHeader(ctx *context.Context) {
+	if !c.GetEarlyHints() || ctx.IsPjax() {
+		return
+	}
+	if links := GetPreloadLinks(ctx); len(links) > 0 {
+		ctx.SetHeader("Link", strings.Join(links, ", "))
+	}
+}
+
 // getHTMLFromAssetUrl 根据资源URL获取对应的HTML标签
 // 参数:
 //   - s: 资源URL后缀
@@ -566,30 +624,48 @@ func GetExecuteOptions(options []ExecuteOptions) ExecuteOptions {
 //   - ctx: 上下文对象
 //   - param: 执行参数
 //
-// 返回: 渲染后的缓冲区
+// 返回: 渲染后的缓冲区，缓冲区取自 context.GetBuffer，可以通过 ctx.HTMLBuffer 输出并在响应后归还
 func Execute(ctx *context.Context, param *ExecuteParam) *bytes.Buffer {
+	buf := context.GetBuffer()
+	if err := ExecuteTo(buf, ctx, param); err != nil {
+		logger.Error("template execute error", err)
+	}
+	return buf
+}
 
-	buf := new(bytes.Buffer)
-	err := param.Tmpl.ExecuteTemplate(buf, param.TmplName,
+// ExecuteTo 执行模板渲染，将页面写入 w
+//
+// 注意: Execute 通过它渲染到池化的缓冲区，页面并不边渲染边输出，
+// 而是在中间件处理完成后由适配器通过 ctx.WriteBody 整体写入响应，
+// 这样中间件仍然可以修改状态码和响应头，渲染出错时也不会输出半个页面
+// 参数:
+//   - w: 写入的目标
+//   - ctx: 上下文对象
+//   - param: 执行参数
+//
+// 返回: 模板执行的错误
+func ExecuteTo(w io.Writer, ctx *context.Context, param *ExecuteParam) error {
+	panel := param.Panel.
+		GetContent(append([]bool{param.Config.IsProductionEnvironment() && !param.NoCompress},
+			param.Animation)...).AddJS(param.Menu.GetUpdateJS(param.IsPjax)).
+		AddJS(updateNavAndLogoJS(param.Logo)).AddJS(updateNavJS(param.IsPjax))
+	// 调试模式下列出渲染页面时执行的 SQL
+	if param.Config.Debug {
+		panel.Content += sqlDebugPanel(ctx)
+	}
+	return param.Tmpl.ExecuteTemplate(w, param.TmplName,
 		types.NewPage(ctx, &types.NewPageParam{
-			User:       param.User,
-			Menu:       param.Menu,
-			Assets:     GetComponentAssetImportHTML(ctx),
-			Buttons:    param.Buttons,
-			Iframe:     param.Iframe,
-			UpdateMenu: param.IsPjax,
-			Panel: param.Panel.
-				GetContent(append([]bool{param.Config.IsProductionEnvironment() && !param.NoCompress},
-					param.Animation)...).AddJS(param.Menu.GetUpdateJS(param.IsPjax)).
-				AddJS(updateNavAndLogoJS(param.Logo)).AddJS(updateNavJS(param.IsPjax)),
+			User:         param.User,
+			Menu:         param.Menu,
+			Assets:       GetComponentAssetImportHTML(ctx),
+			Buttons:      param.Buttons,
+			Iframe:       param.Iframe,
+			UpdateMenu:   param.IsPjax,
+			Panel:        panel,
 			TmplHeadHTML: Default(ctx).GetHeadHTML(),
 			TmplFootJS:   Default(ctx).GetFootJS(),
 			Logo:         param.Logo,
 		}))
-	if err != nil {
-		logger.Error("template execute error", err)
-	}
-	return buf
 }
 
 // WarningPanel 创建警告面板
@@ -683,6 +759,18 @@ var DefaultFuncMap = template.FuncMap{
 		}
 		return ""
 	},
+	"can": func(user models.UserModel, slugs ...string) bool {
+		// 检查用户是否拥有全部权限标识
+		return user.Can(slugs...)
+	},
+	"canAny": func(user models.UserModel, slugs ...string) bool {
+		// 检查用户是否拥有任一权限标识
+		return user.CanAny(slugs...)
+	},
+	"canVisit": func(user models.UserModel, path, method string) bool {
+		// 检查用户是否可以访问路径，路径不含URL前缀
+		return user.CheckPermissionByUrlMethod(c.Url(path), method, url.Values{})
+	},
 	"changeValue": func(f types.FormField, index int) types.FormField {
 		// 更新表单字段的值
 		if len(f.ValueArr) > 0 {
@@ -698,6 +786,15 @@ var DefaultFuncMap = template.FuncMap{
 	},
 }
 
+// AddFunc 添加模板函数，主题、组件和登录页面的模板均可使用，需在服务启动前调用
+// 参数:
+//   - name: 函数名称
+//   - fn: 函数，需满足html/template对模板函数的要求
+func AddFunc(name string, fn interface{}) {
+	DefaultFuncMap[name] = fn
+	login.DefaultFuncMap[name] = fn
+}
+
 // BaseComponent 基础组件结构体
 type BaseComponent struct {
 	Name      string          // 组件名称
@@ -756,7 +853,8 @@ func (b *BaseComponent) BindActionTo(ctx *context.Context, action types.Action,
 //
 // 返回: HTML内容
 func (b *BaseComponent) GetContentWithData(obj interface{}) template.HTML {
-	buffer := new(bytes.Buffer)
+	buffer := context.GetBuffer()
+	defer context.PutBuffer(buffer)
 	tmpl, defineName := b.GetTemplate()
 	err := tmpl.ExecuteTemplate(buffer, defineName, obj)
 	if err != nil {
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+)
+
+func noSQL() *db.SQL { return nil }
+
+func BenchmarkFieldList_GetTheadAndFilterForm(b *testing.B) {
+	var (
+		info    = NewInfoPanel(nil, "id")
+		columns = []string{"id", "name", "email", "phone", "city", "role_id", "status", "created_at", "updated_at"}
+		u, _    = url.Parse("/admin/info/users?name=foo&email=bar&__sort=id&__sort_type=desc")
+		params  = parameter.GetParam(u, 20)
+		table   = TableInfo{Table: "users", PrimaryKey: "id", Delimiter: "`", Delimiter2: "`", Driver: db.DriverMysql}
+	)
+
+	info.AddField("ID", "id", db.Int).FieldSortable()
+	info.AddField("Name", "name", db.Varchar).FieldFilterable()
+	info.AddField("Email", "email", db.Varchar).FieldFilterable(FilterType{Operator: FilterOperatorLike})
+	info.AddField("Phone", "phone", db.Varchar)
+	info.AddField("City", "city", db.Varchar).FieldFilterable()
+	info.AddField("Role", "name", db.Varchar).FieldJoin(Join{Table: "roles", Field: "role_id", JoinField: "id"})
+	info.AddField("Status", "status", db.Tinyint)
+	info.AddField("Created", "created_at", db.Timestamp)
+	info.AddField("Updated", "updated_at", db.Timestamp)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		info.FieldList.GetTheadAndFilterForm(table, params, columns, noSQL)
+	}
+}
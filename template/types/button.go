@@ -2,7 +2,9 @@ package types
 
 import (
 	"html/template"
+	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/purpose168/GoAdmin/context"
 	"github.com/purpose168/GoAdmin/modules/language"
@@ -34,11 +36,16 @@ type Button interface {
 
 // BaseButton 是按钮的基础结构体
 type BaseButton struct {
-	Id, Url, Method, Name, TypeName string        // 按钮ID、URL、方法、名称和类型名称
-	Title                           template.HTML // 按钮标题
-	Action                          Action        // 按钮动作
+	Id, Url, Method, Name, TypeName string             // 按钮ID、URL、方法、名称和类型名称
+	Title                           template.HTML      // 按钮标题
+	Action                          Action             // 按钮动作
+	Permissions                     []string           // 显示按钮所需的权限标识，为空时不检查
+	PermissionFn                    ButtonPermissionFn // 判断用户能否使用按钮的函数，为空时不检查
 }
 
+// ButtonPermissionFn 判断用户能否看到并使用按钮
+type ButtonPermissionFn func(user models.UserModel) bool
+
 // Content 返回空内容
 func (b *BaseButton) Content() (template.HTML, template.JS) { return "", "" }
 
@@ -66,6 +73,22 @@ func (b *BaseButton) GetName() string { return b.Name }
 // SetName 设置按钮名称
 func (b *BaseButton) SetName(name string) { b.Name = name }
 
+// GetPermissions 返回显示按钮所需的权限标识
+func (b *BaseButton) GetPermissions() []string { return b.Permissions }
+
+// SetPermissions 设置显示按钮所需的权限标识，用户需拥有全部权限标识才显示按钮
+// 参数:
+//   - slugs: 权限标识，如"users.edit"
+func (b *BaseButton) SetPermissions(slugs ...string) { b.Permissions = slugs }
+
+// GetPermissionFn 返回判断用户能否使用按钮的函数
+func (b *BaseButton) GetPermissionFn() ButtonPermissionFn { return b.PermissionFn }
+
+// SetPermissionFn 设置判断用户能否使用按钮的函数
+// 参数:
+//   - fn: 判断函数，返回false时不显示按钮
+func (b *BaseButton) SetPermissionFn(fn ButtonPermissionFn) { b.PermissionFn = fn }
+
 // DefaultButton 是默认按钮结构体
 type DefaultButton struct {
 	*BaseButton
@@ -238,11 +261,12 @@ func (b Buttons) Add(btn Button) Buttons {
 func (b Buttons) Content(ctx *context.Context) (template.HTML, template.JS) {
 	h := template.HTML("")
 	j := template.JS("")
+	scripts := make(ScriptRegistry)
 
 	for _, btn := range b {
 		hh, jj := btn.Content(ctx)
 		h += hh
-		j += jj
+		j += scripts.OnceJS(jj)
 	}
 	return h, j
 }
@@ -257,9 +281,10 @@ func (b Buttons) Copy() Buttons {
 // FooterContent 生成所有按钮的页脚内容
 func (b Buttons) FooterContent(ctx *context.Context) template.HTML {
 	footer := template.HTML("")
+	scripts := make(ScriptRegistry)
 
 	for _, btn := range b {
-		footer += btn.GetAction().FooterContent(ctx)
+		footer += scripts.Once(btn.GetAction().FooterContent(ctx))
 	}
 	return footer
 }
@@ -278,6 +303,10 @@ func (b Buttons) CheckPermission(user models.UserModel) Buttons {
 			if len(items) > 0 {
 				btns = append(btns, btn)
 			}
+		} else if !permitted(btn, user) {
+			continue
+		} else if nav, ok := btn.(*NavButton); ok && nav.Public {
+			btns = append(btns, btn)
 		} else if user.CheckPermissionByUrlMethod(btn.URL(), btn.METHOD(), url.Values{}) {
 			btns = append(btns, btn)
 		}
@@ -289,6 +318,9 @@ func (b Buttons) CheckPermission(user models.UserModel) Buttons {
 func (b Buttons) CheckPermissionWhenURLAndMethodNotEmpty(user models.UserModel) Buttons {
 	btns := make(Buttons, 0)
 	for _, b := range b {
+		if !permitted(b, user) {
+			continue
+		}
 		if b.URL() == "" || b.METHOD() == "" || user.CheckPermissionByUrlMethod(b.URL(), b.METHOD(), url.Values{}) {
 			btns = append(btns, b)
 		}
@@ -296,6 +328,68 @@ func (b Buttons) CheckPermissionWhenURLAndMethodNotEmpty(user models.UserModel)
 	return btns
 }
 
+// permissionButton 是可以绑定权限的按钮
+type permissionButton interface {
+	GetPermissions() []string
+	SetPermissions(slugs ...string)
+	GetPermissionFn() ButtonPermissionFn
+	SetPermissionFn(fn ButtonPermissionFn)
+}
+
+// permitted 检查用户是否拥有显示按钮所需的全部权限标识，并满足按钮的判断函数
+func permitted(btn Button, user models.UserModel) bool {
+	b, ok := btn.(permissionButton)
+	if !ok {
+		return true
+	}
+	if !user.Can(b.GetPermissions()...) {
+		return false
+	}
+	if fn := b.GetPermissionFn(); fn != nil && !fn(user) {
+		return false
+	}
+	return true
+}
+
+// buttonGuardKey 是节点值中标记回调路由已添加按钮权限检查的键
+const buttonGuardKey = "__goadmin_button_guard"
+
+// guard 为按钮的回调路由添加权限检查，无权限的用户请求时返回403，不执行回调
+// 参数:
+//   - btn: 按钮
+//
+// 返回: 更新后的回调列表
+func (c Callbacks) guard(btn Button) Callbacks {
+	for k, node := range c {
+		if node.Path != btn.URL() || !strings.EqualFold(node.Method, btn.METHOD()) {
+			continue
+		}
+		if guarded, _ := node.Value[buttonGuardKey].(bool); guarded {
+			continue
+		}
+		handlers := node.Handlers
+		value := map[string]interface{}{buttonGuardKey: true}
+		for key, v := range node.Value {
+			value[key] = v
+		}
+		c[k].Value = value
+		c[k].Handlers = []context.Handler{func(ctx *context.Context) {
+			user, _ := ctx.User().(models.UserModel)
+			if !permitted(btn, user) {
+				ctx.JSON(http.StatusForbidden, map[string]interface{}{
+					"code": http.StatusForbidden,
+					"msg":  language.Get("permission denied"),
+				})
+				return
+			}
+			for _, handler := range handlers {
+				handler(ctx)
+			}
+		}}
+	}
+	return c
+}
+
 // AddNavButton 添加导航按钮
 func (b Buttons) AddNavButton(ico, name string, action Action) Buttons {
 	if !b.CheckExist(name) {
@@ -370,7 +464,38 @@ func (b Buttons) RemovePlugNavButton() Buttons {
 // NavButton 是导航按钮结构体
 type NavButton struct {
 	*BaseButton
-	Icon string // 图标
+	Icon      string             // 图标
+	Public    bool               // 是否对所有已登录用户可见，为 true 时不检查权限
+	VisibleFn NavButtonVisibleFn // 判断按钮是否在当前请求的页面显示，为空时总是显示
+}
+
+// NavButtonVisibleFn 判断导航按钮是否在当前请求的页面显示
+type NavButtonVisibleFn func(ctx *context.Context) bool
+
+// SetVisibleFn 设置判断按钮是否显示的函数，如只在某个表格的列表页显示
+// 参数:
+//   - fn: 判断函数，返回false时不显示按钮
+//
+// 返回: 导航按钮
+func (n *NavButton) SetVisibleFn(fn NavButtonVisibleFn) *NavButton {
+	n.VisibleFn = fn
+	return n
+}
+
+// Visible 过滤掉在当前请求的页面不显示的导航按钮
+// 参数:
+//   - ctx: 上下文对象
+//
+// 返回: 显示的按钮列表
+func (b Buttons) Visible(ctx *context.Context) Buttons {
+	btns := make(Buttons, 0, len(b))
+	for _, btn := range b {
+		if nav, ok := btn.(*NavButton); ok && nav.VisibleFn != nil && !nav.VisibleFn(ctx) {
+			continue
+		}
+		btns = append(btns, btn)
+	}
+	return btns
 }
 
 // GetNavButton 创建导航按钮
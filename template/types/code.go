@@ -0,0 +1,46 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/utils"
+)
+
+// FieldCodeParam 是代码编辑器字段参数结构体
+type FieldCodeParam struct {
+	Language      string // 编辑器的语言，如 html、sql、json，默认为 html
+	LanguageField string // 可选的语言选择字段，编辑器的语言随该字段的值切换
+	Theme         string // 编辑器的主题，默认为 monokai
+	FontSize      int    // 字号，默认为 14
+	MaxSize       int    // 内容的最大字节数，为 0 时不限制
+}
+
+// codeValidator 返回校验代码字段大小的函数，语言为 json 时同时校验格式
+func codeValidator(p FieldCodeParam) FieldValidateFn {
+	return func(value PostFieldModel) error {
+		v := value.Value.Value()
+		if p.MaxSize > 0 && len(v) > p.MaxSize {
+			return fmt.Errorf(language.Get("content exceeds the maximum size of %d bytes"), p.MaxSize)
+		}
+		if p.Language == "json" && p.LanguageField == "" && v != "" && !utils.IsJSON(v) {
+			return errors.New(language.Get("invalid json"))
+		}
+		return nil
+	}
+}
+
+// codeJS 生成提交原始内容以及切换语言的 JS
+func codeJS(field string, p FieldCodeParam) template.HTML {
+	return utils.ParseHTML("code", tmpls["code"], struct {
+		Field         template.JS
+		LanguageField template.JS
+		MaxSize       int
+	}{
+		Field:         template.JS(field),
+		LanguageField: template.JS(p.LanguageField),
+		MaxSize:       p.MaxSize,
+	})
+}
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	form2 "github.com/purpose168/GoAdmin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormPanel_FieldCode(t *testing.T) {
+	panel := NewFormPanel()
+	panel.AddField("Config", "config", db.Text, form2.TextArea).FieldCode(FieldCodeParam{Language: "json", MaxSize: 20})
+	panel.AddField("Language", "lang", db.Varchar, form2.SelectSingle)
+	panel.AddField("Snippet", "snippet", db.Text, form2.Code).FieldCode(FieldCodeParam{LanguageField: "lang"})
+	panel.SetPrimaryKey("id", db.Int)
+
+	field := panel.FieldList.FindByFieldName("config")
+	assert.Equal(t, form2.Code, field.FormType)
+	assert.Contains(t, string(field.OptionExt), `language = "json";`)
+	assert.Contains(t, string(field.OptionExt), `theme = "monokai";`)
+	assert.Contains(t, string(panel.FieldList.FindByFieldName("snippet").OptionExt), `language = "html";`)
+	assert.Contains(t, string(panel.FooterHtml), `$(".lang")`)
+
+	assert.NoError(t, panel.Validate(form.Values{"config": {`{"a": 1}`}, "snippet": {"<b>"}}))
+	assert.Error(t, panel.Validate(form.Values{"config": {`{"a": }`}}))
+	assert.Error(t, panel.Validate(form.Values{"config": {`{"a": "` + strings.Repeat("x", 20) + `"}`}}))
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// NormalizeHexColor 将颜色值转换为小写的十六进制格式
+// 支持 #rgb、#rrggbb、#rrggbbaa、不带 # 的十六进制以及 rgb()、rgba()
+// 参数:
+//   - value: 颜色值
+//
+// 返回: 形如 #rrggbb 的颜色，透明度小于 1 时为 #rrggbbaa；无法解析时返回 false
+func NormalizeHexColor(value string) (string, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return "", false
+	}
+
+	if strings.HasPrefix(value, "rgb") {
+		return rgbToHex(value)
+	}
+
+	hex := strings.TrimPrefix(value, "#")
+	if _, err := strconv.ParseUint(hex, 16, 32); err != nil {
+		return "", false
+	}
+	switch len(hex) {
+	case 3:
+		return "#" + string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]}), true
+	case 6:
+		return "#" + hex, true
+	case 8:
+		if strings.HasSuffix(hex, "ff") {
+			return "#" + hex[:6], true
+		}
+		return "#" + hex, true
+	}
+	return "", false
+}
+
+// rgbToHex 转换 rgb(r, g, b) 和 rgba(r, g, b, a)
+func rgbToHex(value string) (string, bool) {
+	start, end := strings.Index(value, "("), strings.LastIndex(value, ")")
+	if start < 0 || end < start {
+		return "", false
+	}
+	parts := strings.Split(value[start+1:end], ",")
+	if len(parts) != 3 && len(parts) != 4 {
+		return "", false
+	}
+
+	res := "#"
+	for _, part := range parts[:3] {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 0 || n > 255 {
+			return "", false
+		}
+		res += fmt.Sprintf("%02x", n)
+	}
+	if len(parts) == 4 {
+		alpha, err := strconv.ParseFloat(strings.TrimSpace(parts[3]), 64)
+		if err != nil || alpha < 0 || alpha > 1 {
+			return "", false
+		}
+		if alpha < 1 {
+			res += fmt.Sprintf("%02x", int(math.Round(alpha*255)))
+		}
+	}
+	return res, true
+}
@@ -0,0 +1,134 @@
+package types
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/modules/language"
+)
+
+// FieldContactParam 是电话和邮箱显示的参数结构体
+type FieldContactParam struct {
+	Mask bool // 是否脱敏显示，脱敏后不生成链接
+}
+
+// NormalizePhone 规范化电话号码
+// 去除空格、横线、点和括号，00 开头的国际号码转换为 + 开头
+// 参数:
+//   - value: 电话号码
+//
+// 返回: 规范化后的电话号码，号码不是 6 到 15 位数字时返回 false
+func NormalizePhone(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", false
+	}
+
+	var (
+		sb     strings.Builder
+		digits = 0
+	)
+	for i, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			sb.WriteRune(r)
+			digits++
+		case r == '+' && i == 0:
+			sb.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", false
+		}
+	}
+
+	res := sb.String()
+	if strings.HasPrefix(res, "00") {
+		res = "+" + res[2:]
+		digits -= 2
+	}
+	if digits < 6 || digits > 15 {
+		return "", false
+	}
+	return res, true
+}
+
+// NormalizeEmail 规范化邮箱地址，域名转换为小写
+// 参数:
+//   - value: 邮箱地址
+//
+// 返回: 规范化后的邮箱地址，不是单个不带名称的邮箱地址时返回 false
+func NormalizeEmail(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	addr, err := mail.ParseAddress(value)
+	if err != nil || addr.Name != "" || addr.Address != value {
+		return "", false
+	}
+	at := strings.LastIndex(value, "@")
+	if !strings.Contains(value[at+1:], ".") {
+		return "", false
+	}
+	return value[:at] + strings.ToLower(value[at:]), true
+}
+
+// MaskPhone 电话号码脱敏，保留前三位和后四位数字
+func MaskPhone(value string) string {
+	runes := []rune(value)
+	keepHead, keepTail := 3, 4
+	if len(runes) < 8 {
+		keepHead, keepTail = 0, 2
+	}
+	if len(runes) <= keepTail {
+		return value
+	}
+	if strings.HasPrefix(value, "+") && keepHead > 0 {
+		keepHead++
+	}
+	return string(runes[:keepHead]) + strings.Repeat("*", len(runes)-keepHead-keepTail) +
+		string(runes[len(runes)-keepTail:])
+}
+
+// MaskEmail 邮箱地址脱敏，只保留用户名的第一个字符和域名
+func MaskEmail(value string) string {
+	at := strings.LastIndex(value, "@")
+	if at < 1 {
+		return value
+	}
+	return string([]rune(value)[:1]) + "***" + value[at:]
+}
+
+// phonePostFilter 校验通过后保存规范化的电话号码
+func phonePostFilter(value PostFieldModel) interface{} {
+	if phone, ok := NormalizePhone(value.Value.Value()); ok {
+		return phone
+	}
+	return value.Value.Value()
+}
+
+// emailPostFilter 校验通过后保存规范化的邮箱地址
+func emailPostFilter(value PostFieldModel) interface{} {
+	if email, ok := NormalizeEmail(value.Value.Value()); ok {
+		return email
+	}
+	return value.Value.Value()
+}
+
+// validatePhone 校验电话号码，空值由 FieldMust 校验
+func validatePhone(value PostFieldModel) error {
+	if v := value.Value.Value(); v != "" {
+		if _, ok := NormalizePhone(v); !ok {
+			return errors.New(language.Get("invalid phone number"))
+		}
+	}
+	return nil
+}
+
+// validateEmail 校验邮箱地址，空值由 FieldMust 校验
+func validateEmail(value PostFieldModel) error {
+	if v := value.Value.Value(); v != "" {
+		if _, ok := NormalizeEmail(v); !ok {
+			return errors.New(language.Get("invalid email address"))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	form2 "github.com/purpose168/GoAdmin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	cases := []struct {
+		value, want string
+		ok          bool
+	}{
+		{"138 0013 8000", "13800138000", true},
+		{"+86 (138) 0013-8000", "+8613800138000", true},
+		{"0086 138.0013.8000", "+8613800138000", true},
+		{"123", "", false},
+		{"1380013800a", "", false},
+		{"13+800138000", "", false},
+		{"1234567890123456", "", false},
+		{"", "", false},
+	}
+
+	for _, c := range cases {
+		got, ok := NormalizePhone(c.value)
+		assert.Equal(t, c.want, got, c.value)
+		assert.Equal(t, c.ok, ok, c.value)
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	cases := []struct {
+		value, want string
+		ok          bool
+	}{
+		{" John.Doe@Example.COM ", "John.Doe@example.com", true},
+		{"a@b.co", "a@b.co", true},
+		{"John <john@example.com>", "", false},
+		{"john@localhost", "", false},
+		{"john.example.com", "", false},
+		{"", "", false},
+	}
+
+	for _, c := range cases {
+		got, ok := NormalizeEmail(c.value)
+		assert.Equal(t, c.want, got, c.value)
+		assert.Equal(t, c.ok, ok, c.value)
+	}
+}
+
+func TestMaskContact(t *testing.T) {
+	assert.Equal(t, "138****8000", MaskPhone("13800138000"))
+	assert.Equal(t, "+861******8000", MaskPhone("+8613800138000"))
+	assert.Equal(t, "*****89", MaskPhone("1234589"))
+	assert.Equal(t, "j***@example.com", MaskEmail("john@example.com"))
+	assert.Equal(t, "invalid", MaskEmail("invalid"))
+}
+
+func TestFormPanel_Validate(t *testing.T) {
+	panel := NewFormPanel()
+	panel.AddField("Phone", "phone", db.Varchar, form2.Text).FieldPhone()
+	panel.AddField("Email", "email", db.Varchar, form2.Text).FieldEmail()
+	panel.AddField("Name", "name", db.Varchar, form2.Text).FieldValidator(func(value PostFieldModel) error {
+		assert.Equal(t, "1", value.ID)
+		return nil
+	})
+	panel.SetPrimaryKey("id", db.Int)
+
+	assert.Equal(t, form2.Email, panel.FieldList.FindByFieldName("email").FormType)
+	assert.NoError(t, panel.Validate(form.Values{"id": {"1"}, "phone": {"138 0013 8000"}, "email": {""}, "name": {"a"}}))
+	assert.Error(t, panel.Validate(form.Values{"phone": {"abc"}}))
+	assert.Error(t, panel.Validate(form.Values{"email": {"abc"}}))
+	// 未提交的字段不验证
+	assert.NoError(t, panel.Validate(form.Values{}))
+
+	panel.SetPostValidator(func(values form.Values) error { return assert.AnError })
+	assert.Equal(t, assert.AnError, panel.Validate(form.Values{"phone": {"13800138000"}}))
+
+	phone := panel.FieldList.FindByFieldName("phone")
+	assert.Equal(t, "+8613800138000", phone.PostFilterFn(PostFieldModel{Value: FieldModelValue{"0086 138 0013 8000"}}))
+	email := panel.FieldList.FindByFieldName("email")
+	assert.Equal(t, "a@b.com", email.PostFilterFn(PostFieldModel{Value: FieldModelValue{"a@B.com"}}))
+}
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"errors"
+	"html/template"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/constant"
+	"github.com/purpose168/GoAdmin/modules/cron"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/utils"
+)
+
+// cronPreviewSize 是预览的运行时间个数
+const cronPreviewSize = 5
+
+// CronPreview 返回 cron 表达式的描述和接下来的运行时间
+// 参数:
+//   - expr: cron 表达式
+//   - lang: 语言，为空时使用配置的语言
+//
+// 返回: 表达式的描述，以及格式为 2006-01-02 15:04 的运行时间；表达式无效时返回错误
+func CronPreview(expr, lang string) (string, []string, error) {
+	s, err := cron.Parse(expr)
+	if err != nil {
+		return "", nil, err
+	}
+	times := s.NextN(time.Now(), cronPreviewSize)
+	next := make([]string, len(times))
+	for k, t := range times {
+		next[k] = t.Format("2006-01-02 15:04")
+	}
+	return s.Describe(lang), next, nil
+}
+
+// cronPostFilter 校验通过后保存去除多余空白的表达式
+func cronPostFilter(value PostFieldModel) interface{} {
+	if s, err := cron.Parse(value.Value.Value()); err == nil {
+		return s.String()
+	}
+	return value.Value.Value()
+}
+
+// validateCron 校验 cron 表达式，空值由 FieldMust 校验
+func validateCron(value PostFieldModel) error {
+	if v := value.Value.Value(); v != "" {
+		if _, err := cron.Parse(v); err != nil {
+			return errors.New(language.Get("invalid cron expression"))
+		}
+	}
+	return nil
+}
+
+// cronPreviewHandler 处理表单中 cron 表达式的预览请求
+func cronPreviewHandler(ctx *context.Context) (bool, string, interface{}) {
+	desc, next, err := CronPreview(ctx.FormValue("value"), ctx.Lang())
+	if err != nil {
+		return false, language.GetWithLang("invalid cron expression", ctx.Lang()), nil
+	}
+	return true, "ok", map[string]interface{}{
+		"description": desc,
+		"next":        next,
+	}
+}
+
+// cronPreviewJS 生成在输入时请求并显示预览的 JS 及其回调节点
+func cronPreviewJS(field, url string) (template.HTML, context.Node) {
+	return utils.ParseHTML("cron_preview", tmpls["cron_preview"], struct {
+		Field template.JS
+		Url   template.JS
+	}{
+		Field: template.JS(field),
+		Url:   template.JS(url),
+	}), context.Node{
+		Path:     url,
+		Method:   "post",
+		Handlers: context.Handlers{Handler(cronPreviewHandler).Wrap()},
+		Value:    map[string]interface{}{constant.ContextNodeNeedAuth: 1},
+	}
+}
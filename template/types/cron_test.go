@@ -0,0 +1,45 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	form2 "github.com/purpose168/GoAdmin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCronPreview(t *testing.T) {
+	desc, next, err := CronPreview("0 9 * * MON-FRI", language.EN)
+	assert.NoError(t, err)
+	assert.Equal(t, "at 09:00, on Monday through Friday", desc)
+	assert.Len(t, next, cronPreviewSize)
+	for _, item := range next {
+		tm, ok := ParseTime(item)
+		assert.True(t, ok)
+		assert.Equal(t, 9, tm.Hour())
+	}
+
+	_, _, err = CronPreview("0 9 * *", language.EN)
+	assert.Error(t, err)
+}
+
+func TestFormPanel_FieldCron(t *testing.T) {
+	panel := NewFormPanel()
+	panel.AddField("Schedule", "schedule", db.Varchar, form2.Text).FieldCron()
+	panel.AddField("Backup", "backup", db.Varchar, form2.Text).FieldCron()
+	panel.SetPrimaryKey("id", db.Int)
+
+	assert.NoError(t, panel.Validate(form.Values{"schedule": {"*/5 * * * *"}, "backup": {""}}))
+	assert.Error(t, panel.Validate(form.Values{"schedule": {"* * *"}}))
+
+	field := panel.FieldList.FindByFieldName("schedule")
+	assert.Equal(t, "0 12 * * *", field.PostFilterFn(PostFieldModel{Value: FieldModelValue{" 0  12 * * *"}}))
+
+	// 所有字段共用一个预览回调
+	assert.Len(t, panel.Callbacks, 1)
+	assert.Equal(t, "post", panel.Callbacks[0].Method)
+	assert.Contains(t, string(panel.FooterHtml), `$("input.schedule")`)
+	assert.Contains(t, string(panel.FooterHtml), `$("input.backup")`)
+}
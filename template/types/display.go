@@ -5,6 +5,7 @@ import (
 	"html"
 	"html/template"
 	"strings"
+	"sync"
 
 	"github.com/purpose168/GoAdmin/context"
 	"github.com/purpose168/GoAdmin/modules/config"
@@ -27,14 +28,41 @@ func (base *BaseDisplayFnGenerator) JS() template.HTML   { return "" } // 返回
 func (base *BaseDisplayFnGenerator) HTML() template.HTML { return "" } // 返回空HTML代码
 
 // displayFnGens 显示函数生成器映射
-var displayFnGens = make(map[string]DisplayFnGenerator)
+var (
+	displayFnGens     = make(map[string]DisplayFnGenerator)
+	displayFnGensLock sync.RWMutex
+)
+
+// RegisterDisplayGenerator 注册显示函数生成器，注册后可以通过 InfoPanel.FieldDisplayByName 使用
+// 插件和主题可以用它提供自定义的显示方式，名称重复时 panic
+// 参数:
+//   - name: 生成器名称
+//   - gen: 显示函数生成器
+func RegisterDisplayGenerator(name string, gen DisplayFnGenerator) {
+	displayFnGensLock.Lock()
+	defer displayFnGensLock.Unlock()
+	if _, ok := displayFnGens[name]; ok {
+		panic("display function generator has been registered: " + name)
+	}
+	displayFnGens[name] = gen
+}
 
 // RegisterDisplayFnGenerator 注册显示函数生成器
+// Deprecated: 使用 RegisterDisplayGenerator
 func RegisterDisplayFnGenerator(key string, gen DisplayFnGenerator) {
-	if _, ok := displayFnGens[key]; ok {
-		panic("display function generator has been registered")
-	}
-	displayFnGens[key] = gen
+	RegisterDisplayGenerator(key, gen)
+}
+
+// GetDisplayGenerator 获取已注册的显示函数生成器
+// 参数:
+//   - name: 生成器名称
+//
+// 返回: 显示函数生成器，以及是否已注册
+func GetDisplayGenerator(name string) (DisplayFnGenerator, bool) {
+	displayFnGensLock.RLock()
+	defer displayFnGensLock.RUnlock()
+	gen, ok := displayFnGens[name]
+	return gen, ok
 }
 
 // FieldDisplay 字段显示结构体
@@ -0,0 +1,81 @@
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"html"
+	"html/template"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// Code 代码显示生成器
+// 用于在详情页中只读显示代码字段，如模板、SQL 或 JSON 配置
+type Code struct {
+	types.BaseDisplayFnGenerator
+}
+
+// init 包初始化函数
+// 将 Code 注册到显示函数生成器注册表中，注册键名为 "code"
+func init() {
+	types.RegisterDisplayGenerator("code", new(Code))
+}
+
+// Get 获取字段过滤函数
+// 根据传入的参数生成一个字段过滤函数，用于将代码转换为预格式化的 HTML
+//
+// 参数：
+//   - ctx: 上下文对象
+//   - args: 可变参数，必须包含以下内容：
+//   - args[0]: []string 类型，可选的语言，默认为 text
+//
+// 返回值：
+//   - FieldFilterFn: 字段过滤函数，返回 pre 元素
+//
+// 使用示例：
+//
+//	detail.AddField("Config", "config", db.Text).FieldCode("json")
+//
+// 注意事项：
+//   - 语言为 json 时格式化显示，格式错误时显示原始内容
+//   - 页面加载了 ace 编辑器时以只读编辑器高亮显示，否则只显示等宽文本
+func (c *Code) Get(ctx *context.Context, args ...interface{}) types.FieldFilterFn {
+	language := "text"
+	if param := args[0].([]string); len(param) > 0 && param[0] != "" {
+		language = param[0]
+	}
+
+	return func(value types.FieldModel) interface{} {
+		if value.Value == "" {
+			return ""
+		}
+		code := value.Value
+		if language == "json" {
+			var buf bytes.Buffer
+			if err := json.Indent(&buf, []byte(code), "", "  "); err == nil {
+				code = buf.String()
+			}
+		}
+		return template.HTML(`<pre class="ga-code" data-language="` + html.EscapeString(language) +
+			`" style="max-height: 400px;overflow: auto;">` + html.EscapeString(code) + `</pre>`)
+	}
+}
+
+// JS 返回将代码转换为只读 ace 编辑器的 JavaScript 代码
+func (c *Code) JS() template.HTML {
+	return template.HTML(`
+$(function () {
+	if (typeof (ace) === "undefined") {
+		return;
+	}
+	$("pre.ga-code").each(function () {
+		var lines = $(this).text().split("\n").length;
+		var editor = ace.edit(this);
+		editor.session.setMode("ace/mode/" + $(this).data("language"));
+		editor.setReadOnly(true);
+		editor.setOptions({useWorker: false, maxLines: Math.min(lines, 30), highlightActiveLine: false});
+		$(this).removeClass("ga-code");
+	});
+});`)
+}
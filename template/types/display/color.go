@@ -0,0 +1,65 @@
+package display
+
+import (
+	"html"
+	"html/template"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// ColorSwatch 颜色色块显示生成器
+// 用于将颜色字段显示为色块和十六进制颜色值
+type ColorSwatch struct {
+	types.BaseDisplayFnGenerator
+}
+
+// init 包初始化函数
+// 将 ColorSwatch 注册到显示函数生成器注册表中，注册键名为 "color"
+func init() {
+	types.RegisterDisplayGenerator("color", new(ColorSwatch))
+}
+
+// Get 获取字段过滤函数
+// 根据传入的参数生成一个字段过滤函数，用于将字段值转换为色块显示
+//
+// 参数：
+//   - ctx: 上下文对象，包含请求相关的上下文信息
+//   - args: 可变参数，必须包含以下内容：
+//   - args[0]: bool 类型，是否在色块后显示颜色值
+//
+// 返回值：
+//   - FieldFilterFn: 字段过滤函数，返回色块 HTML
+//
+// 使用示例：
+//
+//	info.AddField("Color", "color", db.Varchar).FieldColorSwatch()
+//
+// 注意事项：
+//   - 颜色值的解析见 types.NormalizeHexColor
+//   - 字段值不是颜色时只显示原始字段值
+func (c *ColorSwatch) Get(ctx *context.Context, args ...interface{}) types.FieldFilterFn {
+	showValue := true
+	if len(args) > 0 {
+		showValue, _ = args[0].(bool)
+	}
+
+	return func(value types.FieldModel) interface{} {
+		hex, ok := types.NormalizeHexColor(value.Value)
+		if !ok {
+			return value.Value
+		}
+		return Swatch(hex, showValue)
+	}
+}
+
+// Swatch 将十六进制颜色渲染为色块
+func Swatch(hex string, showValue bool) template.HTML {
+	hex = html.EscapeString(hex)
+	res := `<span class="color-swatch" title="` + hex + `" style="display: inline-block;width: 16px;height: 16px;` +
+		`vertical-align: middle;border: 1px solid #ddd;border-radius: 3px;background-color: ` + hex + `;"></span>`
+	if showValue {
+		res += `&nbsp;<code>` + hex + `</code>`
+	}
+	return template.HTML(res)
+}
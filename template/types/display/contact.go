@@ -0,0 +1,106 @@
+package display
+
+import (
+	"html"
+	"html/template"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// Phone 电话号码显示生成器
+// 用于将电话号码显示为 tel: 链接，点击即可拨号
+type Phone struct {
+	types.BaseDisplayFnGenerator
+}
+
+// Email 邮箱地址显示生成器
+// 用于将邮箱地址显示为 mailto: 链接，点击即可发送邮件
+type Email struct {
+	types.BaseDisplayFnGenerator
+}
+
+// init 包初始化函数
+// 将 Phone 和 Email 注册到显示函数生成器注册表中，注册键名为 "phone" 和 "email"
+func init() {
+	types.RegisterDisplayGenerator("phone", new(Phone))
+	types.RegisterDisplayGenerator("email", new(Email))
+}
+
+// Get 获取字段过滤函数
+// 根据传入的参数生成一个字段过滤函数，用于将电话号码转换为 tel: 链接
+//
+// 参数：
+//   - ctx: 上下文对象，包含请求相关的上下文信息
+//   - args: 可变参数，必须包含以下内容：
+//   - args[0]: []types.FieldContactParam 类型，显示参数数组
+//   - Mask: 是否脱敏显示（默认 false）
+//
+// 返回值：
+//   - FieldFilterFn: 字段过滤函数，返回链接 HTML
+//
+// 使用示例：
+//
+//	info.AddField("Phone", "phone", db.Varchar).FieldPhone()
+//	info.AddField("Phone", "phone", db.Varchar).FieldPhone(types.FieldContactParam{Mask: true})
+//
+// 注意事项：
+//   - 脱敏显示时不生成链接，避免在页面中泄露完整的号码
+//   - 号码格式不正确时只显示原始字段值
+func (p *Phone) Get(ctx *context.Context, args ...interface{}) types.FieldFilterFn {
+	param := contactParam(args)
+	return func(value types.FieldModel) interface{} {
+		phone, ok := types.NormalizePhone(value.Value)
+		if !ok {
+			return value.Value
+		}
+		if param.Mask {
+			return types.MaskPhone(phone)
+		}
+		return contactLink("tel:"+phone, value.Value)
+	}
+}
+
+// Get 获取字段过滤函数
+// 根据传入的参数生成一个字段过滤函数，用于将邮箱地址转换为 mailto: 链接
+//
+// 参数：
+//   - ctx: 上下文对象，包含请求相关的上下文信息
+//   - args: 可变参数，必须包含以下内容：
+//   - args[0]: []types.FieldContactParam 类型，显示参数数组
+//   - Mask: 是否脱敏显示（默认 false）
+//
+// 返回值：
+//   - FieldFilterFn: 字段过滤函数，返回链接 HTML
+//
+// 使用示例：
+//
+//	info.AddField("Email", "email", db.Varchar).FieldEmail()
+//
+// 注意事项：
+//   - 脱敏显示时不生成链接，避免在页面中泄露完整的地址
+//   - 地址格式不正确时只显示原始字段值
+func (e *Email) Get(ctx *context.Context, args ...interface{}) types.FieldFilterFn {
+	param := contactParam(args)
+	return func(value types.FieldModel) interface{} {
+		email, ok := types.NormalizeEmail(value.Value)
+		if !ok {
+			return value.Value
+		}
+		if param.Mask {
+			return types.MaskEmail(email)
+		}
+		return contactLink("mailto:"+email, email)
+	}
+}
+
+func contactParam(args []interface{}) types.FieldContactParam {
+	if params, ok := args[0].([]types.FieldContactParam); ok && len(params) > 0 {
+		return params[0]
+	}
+	return types.FieldContactParam{}
+}
+
+func contactLink(href, text string) template.HTML {
+	return template.HTML(`<a href="` + html.EscapeString(href) + `">` + html.EscapeString(text) + `</a>`)
+}
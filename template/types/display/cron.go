@@ -0,0 +1,52 @@
+package display
+
+import (
+	"html"
+	"html/template"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// Cron cron 表达式显示生成器
+// 用于将 cron 表达式显示为可读的描述，如 "at 09:30, on Monday through Friday"
+type Cron struct {
+	types.BaseDisplayFnGenerator
+}
+
+// init 包初始化函数
+// 将 Cron 注册到显示函数生成器注册表中，注册键名为 "cron"
+func init() {
+	types.RegisterDisplayGenerator("cron", new(Cron))
+}
+
+// Get 获取字段过滤函数
+// 根据传入的参数生成一个字段过滤函数，用于将 cron 表达式转换为可读的描述
+//
+// 参数：
+//   - ctx: 上下文对象，用于获取请求的语言
+//   - args: 不需要参数
+//
+// 返回值：
+//   - FieldFilterFn: 字段过滤函数，返回描述 HTML，鼠标悬停时显示表达式和接下来的运行时间
+//
+// 使用示例：
+//
+//	info.AddField("Schedule", "schedule", db.Varchar).FieldCron()
+//
+// 注意事项：
+//   - 字段值不是有效的 cron 表达式时只显示原始字段值
+func (c *Cron) Get(ctx *context.Context, args ...interface{}) types.FieldFilterFn {
+	lang := ctxLang(ctx)
+
+	return func(value types.FieldModel) interface{} {
+		desc, next, err := types.CronPreview(value.Value, lang)
+		if err != nil {
+			return value.Value
+		}
+		title := strings.TrimSpace(value.Value) + "\n" + strings.Join(next, "\n")
+		return template.HTML(`<span title="` + html.EscapeString(title) + `">` +
+			html.EscapeString(desc) + `</span>`)
+	}
+}
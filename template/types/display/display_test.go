@@ -0,0 +1,169 @@
+package display
+
+import (
+	"html/template"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/template/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSparklineValues(t *testing.T) {
+	assert.Equal(t, []float64{1, 5, 3}, ParseSparklineValues("[1, 5, 3]"))
+	assert.Equal(t, []float64{1, 2.5}, ParseSparklineValues(`["1", 2.5, null, "x"]`))
+	assert.Equal(t, []float64{1, 5, 3}, ParseSparklineValues(" 1, 5 ,x, 3"))
+	assert.Empty(t, ParseSparklineValues(""))
+	assert.Empty(t, ParseSparklineValues("abc"))
+}
+
+func TestSparkline(t *testing.T) {
+	fn := new(Sparkline).Get(nil, []types.FieldSparklineParam{})
+
+	res, ok := fn(types.FieldModel{Value: "[0, 10, 5]"}).(template.HTML)
+	assert.True(t, ok)
+	assert.Contains(t, string(res), `width="100" height="24"`)
+	assert.Contains(t, string(res), `points="0.0,23.0 50.0,1.0 100.0,12.0"`)
+
+	// 少于两个数值时显示原始值
+	assert.Equal(t, "7", fn(types.FieldModel{Value: "7"}))
+
+	fn = new(Sparkline).Get(nil, []types.FieldSparklineParam{{
+		Width: 20, Height: 10, Color: `"red"`,
+		Values: func(value types.FieldModel) []float64 { return []float64{3, 3} },
+	}})
+	res = fn(types.FieldModel{Value: "ignored"}).(template.HTML)
+	assert.Contains(t, string(res), `points="0.0,5.0 20.0,5.0"`)
+	assert.Contains(t, string(res), `stroke="&#34;red&#34;"`)
+}
+
+func TestRating(t *testing.T) {
+	fn := new(Rating).Get(nil, []types.FieldRatingParam{})
+
+	res := string(fn(types.FieldModel{Value: "3.6"}).(template.HTML))
+	assert.Equal(t, 3, strings.Count(res, `fa-star"`))
+	assert.Equal(t, 1, strings.Count(res, "fa-star-half-o"))
+	assert.Equal(t, 1, strings.Count(res, "fa-star-o"))
+
+	res = string(fn(types.FieldModel{Value: "9"}).(template.HTML))
+	assert.Equal(t, 5, strings.Count(res, `fa-star"`))
+
+	res = string(new(Rating).Get(nil, []types.FieldRatingParam{{Max: 3}})(types.FieldModel{Value: "-1"}).(template.HTML))
+	assert.Equal(t, 3, strings.Count(res, "fa-star-o"))
+
+	assert.Equal(t, "n/a", fn(types.FieldModel{Value: "n/a"}))
+}
+
+func TestInfoPanel_FieldRating(t *testing.T) {
+	info := types.NewInfoPanel(nil, "id")
+	info.AddField("Rating", "rating", db.Int).FieldRating()
+	info.AddField("Trend", "trend", db.Varchar).FieldSparkline()
+
+	rating := info.FieldList.GetFieldByFieldName("rating")
+	assert.Contains(t, rating.ToDisplayString(types.FieldModel{Value: "2"}), "fa-star")
+	trend := info.FieldList.GetFieldByFieldName("trend")
+	assert.Contains(t, trend.ToDisplayString(types.FieldModel{Value: "1,2"}), "<svg")
+}
+
+func TestTags(t *testing.T) {
+	fn := new(Tags).Get(nil, []types.FieldLabelParam{})
+
+	res := string(fn(types.FieldModel{Value: `go, <b>web</b>`}).(template.HTML))
+	assert.Equal(t, 2, strings.Count(res, `class="label label-default"`))
+	assert.Contains(t, res, "&lt;b&gt;web&lt;/b&gt;")
+
+	res = string(new(Tags).Get(nil, []types.FieldLabelParam{{Type: "info", Color: "#000"}})(
+		types.FieldModel{Value: `["a","b"]`}).(template.HTML))
+	assert.Equal(t, 2, strings.Count(res, "label-info"))
+	assert.Contains(t, res, "background-color: #000;")
+
+	assert.Equal(t, template.HTML(""), fn(types.FieldModel{Value: ""}))
+}
+
+func TestColorSwatch(t *testing.T) {
+	fn := new(ColorSwatch).Get(nil, true)
+
+	res := string(fn(types.FieldModel{Value: "#ABC"}).(template.HTML))
+	assert.Contains(t, res, "background-color: #aabbcc;")
+	assert.Contains(t, res, "<code>#aabbcc</code>")
+
+	res = string(new(ColorSwatch).Get(nil, false)(types.FieldModel{Value: "rgb(0,0,0)"}).(template.HTML))
+	assert.Contains(t, res, "background-color: #000000;")
+	assert.NotContains(t, res, "<code>")
+
+	assert.Equal(t, "red", fn(types.FieldModel{Value: "red"}))
+}
+
+func TestInfoPanel_FieldTagsAndColor(t *testing.T) {
+	info := types.NewInfoPanel(nil, "id")
+	info.AddField("Tags", "tags", db.Varchar).FieldTags()
+	info.AddField("Color", "color", db.Varchar).FieldColorSwatch()
+
+	tags := info.FieldList.GetFieldByFieldName("tags")
+	assert.Contains(t, tags.ToDisplayString(types.FieldModel{Value: "a,b"}), "label-default")
+	color := info.FieldList.GetFieldByFieldName("color")
+	assert.Contains(t, color.ToDisplayString(types.FieldModel{Value: "#fff"}), "#ffffff")
+}
+
+func TestContact(t *testing.T) {
+	phone := new(Phone).Get(nil, []types.FieldContactParam{})
+	assert.Equal(t, template.HTML(`<a href="tel:+8613800138000">+86 138 0013 8000</a>`),
+		phone(types.FieldModel{Value: "+86 138 0013 8000"}))
+	assert.Equal(t, "138****8000", new(Phone).Get(nil, []types.FieldContactParam{{Mask: true}})(
+		types.FieldModel{Value: "13800138000"}))
+	assert.Equal(t, "n/a", phone(types.FieldModel{Value: "n/a"}))
+
+	email := new(Email).Get(nil, []types.FieldContactParam{})
+	assert.Equal(t, template.HTML(`<a href="mailto:a@b.com">a@b.com</a>`), email(types.FieldModel{Value: "a@B.com"}))
+	assert.Equal(t, "a***@b.com", new(Email).Get(nil, []types.FieldContactParam{{Mask: true}})(
+		types.FieldModel{Value: "ab@b.com"}))
+	assert.Equal(t, "<x>", email(types.FieldModel{Value: "<x>"}))
+}
+
+func TestDuration(t *testing.T) {
+	fn := new(Duration).Get(nil, time.Second)
+	assert.Equal(t, "2h 13m", fn(types.FieldModel{Value: "7980"}))
+	assert.Equal(t, "1m 30s", new(Duration).Get(nil, time.Millisecond)(types.FieldModel{Value: "90500"}))
+	assert.Equal(t, "n/a", fn(types.FieldModel{Value: "n/a"}))
+}
+
+func TestTimeAgo(t *testing.T) {
+	fn := new(TimeAgo).Get(nil)
+
+	ts := time.Now().Add(-3 * 24 * time.Hour).Unix()
+	res := string(fn(types.FieldModel{Value: strconv.FormatInt(ts, 10)}).(template.HTML))
+	assert.Contains(t, res, `data-time="`+strconv.FormatInt(ts, 10)+`"`)
+	assert.Contains(t, res, ">3 days ago</span>")
+
+	assert.Equal(t, "never", fn(types.FieldModel{Value: "never"}))
+	assert.Contains(t, string(new(TimeAgo).JS()), "goAdminTimeAgoTimer")
+}
+
+func TestCron(t *testing.T) {
+	fn := new(Cron).Get(nil)
+
+	res := string(fn(types.FieldModel{Value: "30 9 * * MON-FRI"}).(template.HTML))
+	assert.Contains(t, res, ">at 09:30, on Monday through Friday</span>")
+	assert.Contains(t, res, `title="30 9 * * MON-FRI`)
+	assert.Equal(t, "daily", fn(types.FieldModel{Value: "daily"}))
+}
+
+func TestCode(t *testing.T) {
+	fn := new(Code).Get(nil, []string{"json"})
+	res := string(fn(types.FieldModel{Value: `{"a":"<b>"}`}).(template.HTML))
+	assert.Contains(t, res, `data-language="json"`)
+	assert.Contains(t, res, "{\n  &#34;a&#34;: &#34;&lt;b&gt;&#34;\n}</pre>")
+
+	res = string(new(Code).Get(nil, []string{})(types.FieldModel{Value: "select 1"}).(template.HTML))
+	assert.Contains(t, res, `data-language="text"`)
+	assert.Equal(t, "", new(Code).Get(nil, []string{})(types.FieldModel{Value: ""}))
+}
+
+func TestRowReorder(t *testing.T) {
+	res := string(new(RowReorder).Get(nil)(types.FieldModel{ID: "1\"", Value: "<3>"}).(template.HTML))
+	assert.Contains(t, res, `data-id="1&#34;"`)
+	assert.Contains(t, res, `</span>&lt;3&gt;`)
+}
@@ -0,0 +1,160 @@
+package display
+
+import (
+	"html"
+	"html/template"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// Duration 时长显示生成器
+// 用于将秒数等数值字段显示为可读的时长，如 "2h 13m"
+type Duration struct {
+	types.BaseDisplayFnGenerator
+}
+
+// TimeAgo 相对时间显示生成器
+// 用于将时间字段显示为相对时间，如 "3 days ago"，页面中每分钟自动更新
+type TimeAgo struct {
+	types.BaseDisplayFnGenerator
+}
+
+// init 包初始化函数
+// 将 Duration 和 TimeAgo 注册到显示函数生成器注册表中，注册键名为 "duration" 和 "timeago"
+func init() {
+	types.RegisterDisplayGenerator("duration", new(Duration))
+	types.RegisterDisplayGenerator("timeago", new(TimeAgo))
+}
+
+// Get 获取字段过滤函数
+// 根据传入的参数生成一个字段过滤函数，用于将数值转换为可读的时长
+//
+// 参数：
+//   - ctx: 上下文对象，用于获取请求的语言
+//   - args: 可变参数，必须包含以下内容：
+//   - args[0]: time.Duration 类型，字段值的单位，如 time.Second、time.Millisecond
+//
+// 返回值：
+//   - FieldFilterFn: 字段过滤函数，返回可读的时长
+//
+// 使用示例：
+//
+//	info.AddField("Uptime", "uptime", db.Int).FieldDuration()
+//	info.AddField("Latency", "latency_ms", db.Int).FieldDuration(time.Millisecond)
+//
+// 注意事项：
+//   - 只显示最大的两个单位，不足一秒的部分舍去
+//   - 字段值不是数字时只显示原始字段值
+func (d *Duration) Get(ctx *context.Context, args ...interface{}) types.FieldFilterFn {
+	unit := args[0].(time.Duration)
+	lang := ctxLang(ctx)
+
+	return func(value types.FieldModel) interface{} {
+		n, err := strconv.ParseFloat(strings.TrimSpace(value.Value), 64)
+		if err != nil {
+			return value.Value
+		}
+		return types.FormatDuration(time.Duration(n*float64(unit)), lang)
+	}
+}
+
+// Get 获取字段过滤函数
+// 根据传入的参数生成一个字段过滤函数，用于将时间转换为相对时间
+//
+// 参数：
+//   - ctx: 上下文对象，用于获取请求的语言
+//   - args: 不需要参数
+//
+// 返回值：
+//   - FieldFilterFn: 字段过滤函数，返回相对时间 HTML，鼠标悬停时显示完整的时间
+//
+// 使用示例：
+//
+//	info.AddField("Updated", "updated_at", db.Timestamp).FieldTimeAgo()
+//
+// 注意事项：
+//   - 字段值的解析见 types.ParseTime
+//   - 页面中的相对时间使用客户端的时钟每分钟更新一次
+//   - 字段值不是时间时只显示原始字段值
+func (ta *TimeAgo) Get(ctx *context.Context, args ...interface{}) types.FieldFilterFn {
+	lang := ctxLang(ctx)
+
+	return func(value types.FieldModel) interface{} {
+		t, ok := types.ParseTime(value.Value)
+		if !ok {
+			return value.Value
+		}
+		return template.HTML(`<span class="ga-time-ago" data-time="` + strconv.FormatInt(t.Unix(), 10) +
+			`" title="` + html.EscapeString(t.Format("2006-01-02 15:04:05")) + `">` +
+			html.EscapeString(types.TimeAgo(t, time.Now(), lang)) + `</span>`)
+	}
+}
+
+// JS 返回每分钟更新相对时间的 JavaScript 代码
+// 翻译来自 InfoPanel.FieldTimeAgo 设置的 window.goAdminTimeAgoLocale
+func (ta *TimeAgo) JS() template.HTML {
+	return template.HTML(`
+(function () {
+	var locale = function (key) {
+		var l = window.goAdminTimeAgoLocale || {};
+		return l[key] || key;
+	};
+	var plural = function (key, n) {
+		return locale(key).replace("%d", Math.round(n));
+	};
+	var timeAgo = function (t) {
+		var seconds = Date.now() / 1000 - t, future = seconds < 0, text;
+		if (future) {
+			seconds = -seconds;
+		}
+		var minutes = seconds / 60, hours = minutes / 60, days = hours / 24;
+		if (seconds < 45) {
+			return locale("just now");
+		} else if (seconds < 90) {
+			text = locale("a minute");
+		} else if (minutes < 45) {
+			text = plural("%d minutes", minutes);
+		} else if (minutes < 90) {
+			text = locale("an hour");
+		} else if (hours < 22) {
+			text = plural("%d hours", hours);
+		} else if (hours < 36) {
+			text = locale("a day");
+		} else if (days < 26) {
+			text = plural("%d days", days);
+		} else if (days < 45) {
+			text = locale("a month");
+		} else if (days < 320) {
+			text = plural("%d months", days / 30);
+		} else if (days < 548) {
+			text = locale("a year");
+		} else {
+			text = plural("%d years", days / 365);
+		}
+		return locale(future ? "in %s" : "%s ago").replace("%s", text);
+	};
+	if (window.goAdminTimeAgoTimer) {
+		clearInterval(window.goAdminTimeAgoTimer);
+	}
+	window.goAdminTimeAgoTimer = setInterval(function () {
+		$(".ga-time-ago").each(function () {
+			var t = parseInt($(this).attr("data-time"), 10);
+			if (!isNaN(t)) {
+				$(this).text(timeAgo(t));
+			}
+		});
+	}, 60000);
+})();`)
+}
+
+// ctxLang 返回请求的语言，没有请求时返回空字符串，即使用配置的语言
+func ctxLang(ctx *context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	return ctx.Lang()
+}
@@ -0,0 +1,94 @@
+package display
+
+import (
+	"html"
+	"html/template"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// Rating 星级评分显示生成器
+// 用于将数值字段显示为星级，支持半星
+type Rating struct {
+	types.BaseDisplayFnGenerator
+}
+
+// init 包初始化函数
+// 将 Rating 注册到显示函数生成器注册表中，注册键名为 "rating"
+func init() {
+	types.RegisterDisplayGenerator("rating", new(Rating))
+}
+
+// Get 获取字段过滤函数
+// 根据传入的参数生成一个字段过滤函数，用于将字段值转换为星级显示
+//
+// 参数：
+//   - ctx: 上下文对象，包含请求相关的上下文信息
+//   - args: 可变参数，必须包含以下内容：
+//   - args[0]: []types.FieldRatingParam 类型，评分参数数组
+//   - Max: 星星数量（默认 5）
+//   - Color: 星星颜色（默认 #f39c12）
+//
+// 返回值：
+//   - FieldFilterFn: 字段过滤函数，返回星级 HTML
+//
+// 使用示例：
+//
+//	info.AddField("Rating", "rating", db.Decimal).FieldRating()
+//	info.AddField("Score", "score", db.Int).FieldRating(types.FieldRatingParam{Max: 10})
+//
+// 注意事项：
+//   - 字段值四舍五入到半星，超出范围时取 0 或 Max
+//   - 字段值不是数字时只显示原始字段值
+//   - 星星图标基于 Font Awesome
+func (r *Rating) Get(ctx *context.Context, args ...interface{}) types.FieldFilterFn {
+	param := types.FieldRatingParam{}
+	if params, ok := args[0].([]types.FieldRatingParam); ok && len(params) > 0 {
+		param = params[0]
+	}
+	if param.Max <= 0 {
+		param.Max = 5
+	}
+	if param.Color == "" {
+		param.Color = "#f39c12"
+	}
+
+	return func(value types.FieldModel) interface{} {
+		score, err := strconv.ParseFloat(strings.TrimSpace(value.Value), 64)
+		if err != nil || math.IsNaN(score) {
+			return value.Value
+		}
+		return RatingStars(score, param.Max, param.Color)
+	}
+}
+
+// RatingStars 将分数渲染为星级，分数四舍五入到半星
+func RatingStars(score float64, max int, color string) template.HTML {
+	halves := int(math.Round(score * 2))
+	if halves < 0 {
+		halves = 0
+	}
+	if halves > max*2 {
+		halves = max * 2
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<span class="rating" title="` + strconv.FormatFloat(score, 'f', -1, 64) +
+		`" style="color: ` + html.EscapeString(color) + `;white-space: nowrap;">`)
+	for i := 0; i < max; i++ {
+		switch {
+		case halves >= 2*(i+1):
+			sb.WriteString(`<i class="fa fa-star"></i>`)
+		case halves == 2*i+1:
+			sb.WriteString(`<i class="fa fa-star-half-o"></i>`)
+		default:
+			sb.WriteString(`<i class="fa fa-star-o"></i>`)
+		}
+	}
+	sb.WriteString(`</span>`)
+	return template.HTML(sb.String())
+}
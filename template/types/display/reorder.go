@@ -0,0 +1,114 @@
+package display
+
+import (
+	"html"
+	"html/template"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// RowReorder 拖拽排序手柄显示生成器
+// 用于 InfoPanel.EnableRowReorder，在排序字段的列中显示拖拽手柄和排序值
+type RowReorder struct {
+	types.BaseDisplayFnGenerator
+}
+
+// init 包初始化函数
+// 将 RowReorder 注册到显示函数生成器注册表中，注册键名为 "row_reorder"
+func init() {
+	types.RegisterDisplayGenerator("row_reorder", new(RowReorder))
+}
+
+// Get 获取字段过滤函数
+// 根据传入的参数生成一个字段过滤函数，用于显示拖拽手柄
+//
+// 参数：
+//   - ctx: 上下文对象，用于获取请求的语言
+//   - args: 不需要参数
+//
+// 返回值：
+//   - FieldFilterFn: 字段过滤函数，返回带有行主键的拖拽手柄和排序值
+//
+// 使用示例：
+//
+//	info.EnableRowReorder("sort_order")
+//
+// 注意事项：
+//   - 拖拽只在当前页中进行，保存的地址由列表页设置，没有修改权限时不能拖拽
+func (r *RowReorder) Get(ctx *context.Context, args ...interface{}) types.FieldFilterFn {
+	title := html.EscapeString(language.GetWithLang("drag to reorder", ctxLang(ctx)))
+
+	return func(value types.FieldModel) interface{} {
+		return template.HTML(`<span class="ga-row-handle" data-id="` + html.EscapeString(value.ID) +
+			`" title="` + title + `" style="cursor: move;color: #999;margin-right: 5px;">` +
+			`<i class="fa fa-bars"></i></span>` + html.EscapeString(value.Value))
+	}
+}
+
+// JS 返回拖拽排序的 JavaScript 代码
+// 拖拽结束后提交当前页中行的新顺序，成功后刷新列表
+func (r *RowReorder) JS() template.HTML {
+	return template.HTML(`
+$(function () {
+	var dragging = null;
+	var rows = function () {
+		return $(".ga-row-handle").closest("tr");
+	};
+	rows().each(function () {
+		var tr = $(this);
+		tr.find(".ga-row-handle").on("mousedown", function () {
+			if (window.goAdminRowReorderUrl) {
+				tr.attr("draggable", "true");
+			}
+		});
+		tr.on("dragstart", function (e) {
+			dragging = tr;
+			e.originalEvent.dataTransfer.effectAllowed = "move";
+			e.originalEvent.dataTransfer.setData("text/plain", "");
+			tr.css("opacity", "0.5");
+		}).on("dragover", function (e) {
+			if (!dragging || dragging[0] === tr[0] || dragging.parent()[0] !== tr.parent()[0]) {
+				return;
+			}
+			e.preventDefault();
+			var half = tr.offset().top + tr.outerHeight() / 2;
+			if (e.originalEvent.pageY < half) {
+				tr.before(dragging);
+			} else {
+				tr.after(dragging);
+			}
+		}).on("dragend", function () {
+			tr.css("opacity", "").removeAttr("draggable");
+			if (!dragging) {
+				return;
+			}
+			var ids = [];
+			dragging.parent().find(".ga-row-handle").each(function () {
+				ids.push($(this).data("id"));
+			});
+			dragging = null;
+			$.ajax({
+				url: window.goAdminRowReorderUrl,
+				type: "post",
+				dataType: "json",
+				data: {
+					ids: ids.join(","),
+					query: window.location.search.replace(/^\?/, "")
+				},
+				success: function (data) {
+					if (data.code !== 200) {
+						swal(data.msg, "", "error");
+					}
+					$.pjax.reload("#pjax-container");
+				},
+				error: function (data) {
+					swal(data.responseJSON ? data.responseJSON.msg : "error", "", "error");
+					$.pjax.reload("#pjax-container");
+				}
+			});
+		});
+	});
+});`)
+}
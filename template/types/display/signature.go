@@ -0,0 +1,57 @@
+package display
+
+import (
+	"html/template"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
+	template2 "github.com/purpose168/GoAdmin/template"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// Signature 签名显示生成器
+// 用于将 FormPanel.FieldSignature 保存的签名路径显示为图片
+type Signature struct {
+	types.BaseDisplayFnGenerator
+}
+
+// init 包初始化函数
+// 将 Signature 注册到显示函数生成器注册表中，注册键名为 "signature"
+func init() {
+	types.RegisterDisplayGenerator("signature", new(Signature))
+}
+
+// Get 获取字段过滤函数
+// 根据传入的参数生成一个字段过滤函数，用于将签名路径转换为图片
+//
+// 参数：
+//   - ctx: 上下文对象，用于获取模板
+//   - args: 可变参数，必须包含以下内容：
+//   - args[0]: []string 类型，可选的图片宽度，默认为 200px
+//
+// 返回值：
+//   - FieldFilterFn: 字段过滤函数，返回白色背景的签名图片
+//
+// 使用示例：
+//
+//	detail.AddField("Signature", "signature", db.Varchar).FieldSignature()
+//	info.AddField("Signature", "signature", db.Varchar).FieldSignature("80px")
+//
+// 注意事项：
+//   - 图片地址由 config.Store.URL 生成，与上传的文件相同
+//   - 字段值为空时返回空字符串
+func (s *Signature) Get(ctx *context.Context, args ...interface{}) types.FieldFilterFn {
+	width := "200px"
+	if param := args[0].([]string); len(param) > 0 {
+		width = param[0]
+	}
+
+	return func(value types.FieldModel) interface{} {
+		if value.Value == "" {
+			return ""
+		}
+		img := template2.Default(ctx).Image().SetWidth(width).SetHeight("auto").
+			SetSrc(template.HTML(config.GetStore().URL(value.Value))).GetContent()
+		return `<span style="display: inline-block;background: #fff;border: 1px solid #d2d6de;">` + img + `</span>`
+	}
+}
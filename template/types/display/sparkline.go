@@ -0,0 +1,146 @@
+package display
+
+import (
+	"encoding/json"
+	"html"
+	"html/template"
+	"strconv"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// Sparkline 迷你折线图显示生成器
+// 用于将一组数值渲染为内联的 SVG 折线图，不依赖前端图表库
+type Sparkline struct {
+	types.BaseDisplayFnGenerator
+}
+
+// init 包初始化函数
+// 将 Sparkline 注册到显示函数生成器注册表中，注册键名为 "sparkline"
+func init() {
+	types.RegisterDisplayGenerator("sparkline", new(Sparkline))
+}
+
+// Get 获取字段过滤函数
+// 根据传入的参数生成一个字段过滤函数，用于将字段值转换为迷你折线图
+//
+// 参数：
+//   - ctx: 上下文对象，包含请求相关的上下文信息
+//   - args: 可变参数，必须包含以下内容：
+//   - args[0]: []types.FieldSparklineParam 类型，折线图参数数组
+//   - Width/Height: 图的宽度和高度（默认 100 和 24）
+//   - Color: 线条颜色（默认 #3c8dbc）
+//   - Values: 可选的取值函数，用于从关联表中查询数据
+//
+// 返回值：
+//   - FieldFilterFn: 字段过滤函数，返回 SVG 折线图 HTML
+//
+// 使用示例：
+//
+//	// 字段值为 JSON 数组 "[1, 5, 3]" 或逗号分隔的 "1,5,3"
+//	info.AddField("Trend", "trend", db.Varchar).FieldSparkline()
+//
+//	// 从关联表中查询数据
+//	info.AddField("Sales", "id", db.Int).FieldSparkline(types.FieldSparklineParam{
+//	    Values: func(value types.FieldModel) []float64 {
+//	        return querySales(value.ID)
+//	    },
+//	})
+//
+// 注意事项：
+//   - 无法解析的数值会被忽略，少于两个数值时只显示原始字段值
+func (s *Sparkline) Get(ctx *context.Context, args ...interface{}) types.FieldFilterFn {
+	param := types.FieldSparklineParam{}
+	if params, ok := args[0].([]types.FieldSparklineParam); ok && len(params) > 0 {
+		param = params[0]
+	}
+	if param.Width == 0 {
+		param.Width = 100
+	}
+	if param.Height == 0 {
+		param.Height = 24
+	}
+	if param.Color == "" {
+		param.Color = "#3c8dbc"
+	}
+
+	return func(value types.FieldModel) interface{} {
+		var values []float64
+		if param.Values != nil {
+			values = param.Values(value)
+		} else {
+			values = ParseSparklineValues(value.Value)
+		}
+		if len(values) < 2 {
+			return value.Value
+		}
+		return SparklineSVG(values, param.Width, param.Height, param.Color)
+	}
+}
+
+// ParseSparklineValues 解析 JSON 数组或逗号分隔的数值，无法解析的数值会被忽略
+func ParseSparklineValues(value string) []float64 {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	var arr []interface{}
+	if strings.HasPrefix(value, "[") && json.Unmarshal([]byte(value), &arr) == nil {
+		values := make([]float64, 0, len(arr))
+		for _, item := range arr {
+			switch v := item.(type) {
+			case float64:
+				values = append(values, v)
+			case string:
+				if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+					values = append(values, f)
+				}
+			}
+		}
+		return values
+	}
+
+	values := make([]float64, 0)
+	for _, item := range strings.Split(value, ",") {
+		if f, err := strconv.ParseFloat(strings.TrimSpace(item), 64); err == nil {
+			values = append(values, f)
+		}
+	}
+	return values
+}
+
+// SparklineSVG 将数值渲染为 SVG 折线图，数值按最小值和最大值缩放到图的高度
+func SparklineSVG(values []float64, width, height int, color string) template.HTML {
+	min, max := values[0], values[0]
+	for _, v := range values {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+
+	var (
+		w      = float64(width)
+		h      = float64(height)
+		step   = w / float64(len(values)-1)
+		points = make([]string, len(values))
+	)
+	for i, v := range values {
+		y := h / 2
+		if max > min {
+			// 上下各留 1px，避免线条被裁剪
+			y = 1 + (h-2)*(max-v)/(max-min)
+		}
+		points[i] = strconv.FormatFloat(float64(i)*step, 'f', 1, 64) + "," + strconv.FormatFloat(y, 'f', 1, 64)
+	}
+
+	return template.HTML(`<svg class="sparkline" width="` + strconv.Itoa(width) + `" height="` + strconv.Itoa(height) +
+		`" viewBox="0 0 ` + strconv.Itoa(width) + ` ` + strconv.Itoa(height) + `" preserveAspectRatio="none">` +
+		`<polyline fill="none" stroke="` + html.EscapeString(color) + `" stroke-width="1.5" points="` +
+		strings.Join(points, " ") + `"/></svg>`)
+}
@@ -0,0 +1,72 @@
+package display
+
+import (
+	"html"
+	"html/template"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/template/types"
+)
+
+// Tags 标签列表显示生成器
+// 用于将逗号分隔或 JSON 数组保存的标签显示为一组标签
+type Tags struct {
+	types.BaseDisplayFnGenerator
+}
+
+// init 包初始化函数
+// 将 Tags 注册到显示函数生成器注册表中，注册键名为 "tags"
+func init() {
+	types.RegisterDisplayGenerator("tags", new(Tags))
+}
+
+// Get 获取字段过滤函数
+// 根据传入的参数生成一个字段过滤函数，用于将字段值转换为标签列表
+//
+// 参数：
+//   - ctx: 上下文对象，包含请求相关的上下文信息
+//   - args: 可变参数，必须包含以下内容：
+//   - args[0]: []types.FieldLabelParam 类型，标签参数数组
+//   - Type: Bootstrap 标签类型（默认 default）
+//   - Color: 自定义背景颜色
+//
+// 返回值：
+//   - FieldFilterFn: 字段过滤函数，返回标签列表 HTML
+//
+// 使用示例：
+//
+//	info.AddField("Tags", "tags", db.Varchar).FieldTags()
+//	info.AddField("Tags", "tags", db.JSON).FieldTags(types.FieldLabelParam{Type: "info"})
+//
+// 注意事项：
+//   - 字段值的解析见 types.ParseTags
+//   - 标签内容会进行 HTML 转义
+func (t *Tags) Get(ctx *context.Context, args ...interface{}) types.FieldFilterFn {
+	param := types.FieldLabelParam{}
+	if params, ok := args[0].([]types.FieldLabelParam); ok && len(params) > 0 {
+		param = params[0]
+	}
+	if param.Type == "" {
+		param.Type = "default"
+	}
+
+	return func(value types.FieldModel) interface{} {
+		return TagChips(types.ParseTags(value.Value), param.Type, string(param.Color))
+	}
+}
+
+// TagChips 将标签渲染为一组 Bootstrap 标签
+func TagChips(tags []string, typ, color string) template.HTML {
+	style := "margin-right: 3px;"
+	if color != "" {
+		style += "background-color: " + html.EscapeString(color) + ";"
+	}
+
+	var sb strings.Builder
+	for _, tag := range tags {
+		sb.WriteString(`<span class="label label-` + html.EscapeString(typ) + `" style="` + style + `">` +
+			html.EscapeString(tag) + `</span>`)
+	}
+	return template.HTML(sb.String())
+}
@@ -1 +1,56 @@
 package types
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/stretchr/testify/assert"
+)
+
+type testStars struct {
+	BaseDisplayFnGenerator
+}
+
+func (s *testStars) Get(_ *context.Context, args ...interface{}) FieldFilterFn {
+	star := args[0].(string)
+	return func(value FieldModel) interface{} {
+		res := ""
+		for i := 0; i < len(value.Value); i++ {
+			res += star
+		}
+		return res
+	}
+}
+
+func (s *testStars) JS() template.HTML { return "var stars = 1;" }
+
+func TestRegisterDisplayGenerator(t *testing.T) {
+	RegisterDisplayGenerator("test_stars", new(testStars))
+
+	_, ok := GetDisplayGenerator("test_stars")
+	assert.True(t, ok)
+	assert.Panics(t, func() { RegisterDisplayGenerator("test_stars", new(testStars)) })
+
+	info := NewInfoPanel(nil, "id")
+	info.AddField("Rating", "rating", db.Int).FieldDisplayByName("test_stars", "*")
+	info.AddField("Score", "score", db.Int).FieldDisplayByName("test_stars", "+")
+
+	rating := info.FieldList.GetFieldByFieldName("rating")
+	assert.Equal(t, "***", rating.ToDisplay(FieldModel{Value: "abc"}))
+	score := info.FieldList.GetFieldByFieldName("score")
+	assert.Equal(t, "++", score.ToDisplay(FieldModel{Value: "ab"}))
+
+	// JS 只添加一次
+	assert.Equal(t, template.HTML("<script>var stars = 1;</script>"), info.FooterHtml)
+}
+
+func TestInfoPanel_FieldDisplayByName_NotRegistered(t *testing.T) {
+	info := NewInfoPanel(nil, "id")
+	info.AddField("Name", "name", db.Varchar).FieldDisplayByName("test_not_registered")
+
+	name := info.FieldList.GetFieldByFieldName("name")
+	assert.Equal(t, "foo", name.ToDisplay(FieldModel{Value: "foo"}))
+	assert.Empty(t, info.FooterHtml)
+}
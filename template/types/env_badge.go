@@ -0,0 +1,59 @@
+package types
+
+import (
+	"html/template"
+	"regexp"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/utils"
+)
+
+// DefaultEnvBadgeColor 环境徽章的默认颜色
+const DefaultEnvBadgeColor = "#f39c12"
+
+// envBadgeColorReg 允许的颜色格式：十六进制、颜色名称以及rgb/rgba/hsl/hsla
+var envBadgeColorReg = regexp.MustCompile(`^(#[0-9a-fA-F]{3,8}|[a-zA-Z]+|(rgb|rgba|hsl|hsla)\([0-9.,%\s]+\))$`)
+
+func init() {
+	AddPageHTMLHook(envBadge)
+}
+
+// envBadge 在导航栏显示当前环境的徽章，并可按配置为页头着色，
+// 通过样式和脚本插入，不依赖主题的模板
+func envBadge(_ *context.Context) (template.HTML, template.HTML) {
+	badge, ok := config.GetEnvBadge()
+	if !ok {
+		return "", ""
+	}
+
+	color := badge.Color
+	if !envBadgeColorReg.MatchString(color) {
+		color = DefaultEnvBadgeColor
+	}
+
+	css := `.goadmin-env-badge{display:inline-block;margin:12px 10px;padding:3px 8px;border-radius:3px;` +
+		`color:#fff;font-size:12px;font-weight:bold;line-height:18px;letter-spacing:1px;background-color:` + color + `;}` +
+		`.goadmin-env-badge.goadmin-env-badge-fixed{position:fixed;top:0;left:50%;z-index:2000;margin:0;transform:translateX(-50%);}`
+	if badge.TintHeader {
+		css += `.main-header,.main-header .navbar,.main-header .logo,header.navbar,.navbar-fixed-top{` +
+			`border-top:3px solid ` + color + `;` +
+			`background-image:linear-gradient(color-mix(in srgb,` + color + ` 25%,transparent),color-mix(in srgb,` + color + ` 25%,transparent));}`
+	}
+
+	head := template.HTML(`<style>` + css + `</style>`)
+	foot := template.HTML(`<script>(function () {
+    if (document.querySelector(".goadmin-env-badge")) { return; }
+    var badge = document.createElement("span");
+    badge.className = "goadmin-env-badge";
+    badge.textContent = ` + utils.JSON(badge.Text) + `;
+    var nav = document.querySelector(".main-header .navbar-custom-menu, .main-header .navbar, header .navbar, .navbar");
+    if (nav) {
+        nav.insertBefore(badge, nav.firstChild);
+    } else {
+        badge.className += " goadmin-env-badge-fixed";
+        document.body.appendChild(badge);
+    }
+})();</script>`)
+	return head, foot
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvBadge(t *testing.T) {
+	config.Initialize(&config.Config{
+		Env: config.EnvTest,
+		EnvBadges: map[string]config.EnvBadge{
+			config.EnvTest: {Color: "red;}body{display:none", TintHeader: true},
+			config.EnvProd: {Text: "PRODUCTION", Color: "#dd4b39"},
+		},
+	})
+
+	head, foot := envBadge(nil)
+	assert.True(t, strings.Contains(string(foot), `"TEST"`))
+	// the invalid color falls back to the default one.
+	assert.True(t, strings.Contains(string(head), DefaultEnvBadgeColor))
+	assert.False(t, strings.Contains(string(head), "display:none"))
+	assert.True(t, strings.Contains(string(head), "border-top"))
+}
@@ -0,0 +1,201 @@
+package types
+
+import (
+	"errors"
+	"html/template"
+	"regexp"
+
+	"github.com/purpose168/GoAdmin/modules/expr"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+)
+
+// RowClass 是按表达式设置的行样式类，使用 NewRowClass 创建
+type RowClass struct {
+	When  string // 条件表达式，成立时为行添加样式类
+	Class string // 样式类，如 danger、warning、success
+
+	program *expr.Program // 编译后的条件表达式
+}
+
+// rowClassReg 是允许的行样式类
+var rowClassReg = regexp.MustCompile(`^[A-Za-z0-9_\- ]+$`)
+
+// NewRowClass 校验行样式的样式类并编译条件表达式
+// 参数:
+//   - when: 条件表达式
+//   - class: 样式类
+//
+// 返回: 行样式和错误信息
+func NewRowClass(when, class string) (RowClass, error) {
+	if !rowClassReg.MatchString(class) {
+		return RowClass{}, errors.New("wrong row class " + class)
+	}
+	p, err := expr.Compile(when)
+	if err != nil {
+		return RowClass{}, err
+	}
+	return RowClass{When: when, Class: class, program: p}, nil
+}
+
+// CheckRowClass 校验行样式的表达式和样式类
+func CheckRowClass(rc RowClass) error {
+	_, err := NewRowClass(rc.When, rc.Class)
+	return err
+}
+
+// ExprDisplayFn 返回按表达式计算显示值的函数，表达式中可以使用当前行各列的值，
+// value 为当前字段的值，计算出错时显示空值并记录错误
+func ExprDisplayFn(p *expr.Program) FieldFilterFn {
+	return func(model FieldModel) interface{} {
+		vars := make(map[string]interface{}, len(model.Row)+1)
+		for k, v := range model.Row {
+			vars[k] = v
+		}
+		vars["value"] = model.Value
+		v, err := p.EvalString(vars)
+		if err != nil {
+			logger.Error("display expression ", p.String(), " error: ", err)
+			return ""
+		}
+		return template.HTMLEscapeString(v)
+	}
+}
+
+// ExprValidator 返回按表达式校验提交的值的函数，表达式中可以使用提交的各字段的值，
+// value 为当前字段的值，表达式不成立时返回 msg，msg 为空时使用默认的错误信息
+func ExprValidator(p *expr.Program, msg string) FieldValidateFn {
+	return func(model PostFieldModel) error {
+		vars := expr.StringVars(model.Row)
+		vars["value"] = model.Value.Value()
+		ok, err := p.EvalBool(vars)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if msg == "" {
+				return errors.New(language.Get("invalid value"))
+			}
+			return errors.New(language.Get(msg))
+		}
+		return nil
+	}
+}
+
+// FieldDisplayExpr 使用表达式计算字段的显示值，如 "price * qty"，可以用于表中不存在的计算列
+// 表达式的语法见 expr 包，表达式错误时 panic
+// 参数:
+//   - src: 表达式
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) FieldDisplayExpr(src string) *InfoPanel {
+	i.FieldList[i.curFieldListIndex].DisplayExpr = src
+	return i.FieldDisplay(ExprDisplayFn(expr.MustCompile(src)))
+}
+
+// AddRowClassExpr 添加按表达式设置的行样式，条件成立的行添加该样式类，如
+// AddRowClassExpr(`status == "overdue"`, "danger")，表达式中使用各列的值
+// 参数:
+//   - when: 条件表达式，表达式错误时 panic
+//   - class: 样式类
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) AddRowClassExpr(when, class string) *InfoPanel {
+	rc, err := NewRowClass(when, class)
+	if err != nil {
+		panic(err)
+	}
+	i.RowClasses = append(i.RowClasses, rc)
+	return i
+}
+
+// RowClass 返回行的样式类，多个条件成立时以空格连接，未经 NewRowClass 编译的行样式被忽略
+// 参数:
+//   - row: 列表中的一行
+//
+// 返回: 样式类，没有条件成立时为空
+func (i *InfoPanel) RowClass(row map[string]InfoItem) string {
+	if len(i.RowClasses) == 0 {
+		return ""
+	}
+	vars := make(map[string]interface{}, len(row))
+	for k, v := range row {
+		vars[k] = v.Value
+	}
+	class := ""
+	for _, rc := range i.RowClasses {
+		if rc.program == nil {
+			continue
+		}
+		if ok, err := rc.program.EvalBool(vars); err != nil {
+			logger.Error("row class expression ", rc.When, " error: ", err)
+		} else if ok {
+			if class != "" {
+				class += " "
+			}
+			class += rc.Class
+		}
+	}
+	return class
+}
+
+// FieldValidateExpr 设置字段的验证表达式，如 "value >= 0 && value <= stock"，
+// 表达式不成立时提交失败
+// 参数:
+//   - src: 表达式，value 为当前字段的值，表达式错误时 panic
+//   - msg: 可选的错误信息
+//
+// 返回: 更新后的 FormPanel 指针
+func (f *FormPanel) FieldValidateExpr(src string, msg ...string) *FormPanel {
+	field := &f.FieldList[f.curFieldListIndex]
+	field.ValidateExpr = src
+	if len(msg) > 0 {
+		field.ValidateExprMsg = msg[0]
+	}
+	field.ValidateFn = ExprValidator(expr.MustCompile(src), field.ValidateExprMsg)
+	return f
+}
+
+// FieldHideWhenExpr 设置编辑时隐藏字段的表达式，按记录的值计算，成立时隐藏该字段，
+// 如 `type != "company"`，隐藏的字段的值仍会提交
+// 参数:
+//   - src: 表达式，表达式错误时 panic
+//
+// 返回: 更新后的 FormPanel 指针
+func (f *FormPanel) FieldHideWhenExpr(src string) *FormPanel {
+	if err := f.FieldList[f.curFieldListIndex].SetHideExpr(src); err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// SetHideExpr 编译并设置编辑时隐藏字段的表达式，见 FieldHideWhenExpr
+// 参数:
+//   - src: 表达式，为空时不隐藏字段
+//
+// 返回: 表达式错误时的错误信息，此时字段不变
+func (f *FormField) SetHideExpr(src string) error {
+	if src == "" {
+		f.HideExpr, f.hideProgram = "", nil
+		return nil
+	}
+	p, err := expr.Compile(src)
+	if err != nil {
+		return err
+	}
+	f.HideExpr, f.hideProgram = src, p
+	return nil
+}
+
+// hiddenByExpr 判断字段是否按记录的值隐藏
+func (f *FormField) hiddenByExpr(res map[string]interface{}) bool {
+	if f.hideProgram == nil {
+		return false
+	}
+	hide, err := f.hideProgram.EvalBool(res)
+	if err != nil {
+		logger.Error("hide expression ", f.HideExpr, " error: ", err)
+		return false
+	}
+	return hide
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInfoPanel_RowClass(t *testing.T) {
+	info := NewInfoPanel(nil, "id").
+		AddRowClassExpr(`status == "overdue"`, "danger").
+		AddRowClassExpr(`qty < 10`, "warning")
+
+	row := map[string]InfoItem{"status": {Value: "overdue"}, "qty": {Value: "3"}}
+	assert.Equal(t, "danger warning", info.RowClass(row))
+	row["status"] = InfoItem{Value: "paid"}
+	assert.Equal(t, "warning", info.RowClass(row))
+
+	// the row classes not created by NewRowClass are ignored.
+	info.RowClasses = append(info.RowClasses, RowClass{When: "true", Class: "success"})
+	assert.Equal(t, "warning", info.RowClass(row))
+
+	_, err := NewRowClass("qty <", "danger")
+	assert.Error(t, err)
+	_, err = NewRowClass("true", `x" onclick="y`)
+	assert.Error(t, err)
+	assert.Panics(t, func() { info.AddRowClassExpr("qty <", "danger") })
+}
+
+func TestFormField_SetHideExpr(t *testing.T) {
+	var field FormField
+	assert.NoError(t, field.SetHideExpr(`type != "company"`))
+	assert.True(t, field.hiddenByExpr(map[string]interface{}{"type": "person"}))
+	assert.False(t, field.hiddenByExpr(map[string]interface{}{"type": "company"}))
+
+	// a wrong expression leaves the field unchanged.
+	assert.Error(t, field.SetHideExpr("type !="))
+	assert.Equal(t, `type != "company"`, field.HideExpr)
+
+	assert.NoError(t, field.SetHideExpr(""))
+	assert.False(t, field.hiddenByExpr(map[string]interface{}{"type": "person"}))
+}
@@ -0,0 +1,82 @@
+package types
+
+import (
+	"html/template"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+)
+
+// FilterChip 是已应用的筛选条件，显示在表格上方并可单独移除
+type FilterChip struct {
+	Field    string        // 筛选表单字段名
+	Head     string        // 字段标题
+	Operator template.HTML // 操作符，如 ">="，相等和模糊匹配时为空
+	Value    string        // 显示的筛选值，选择框为选中选项的文本
+}
+
+// FilterChips 根据筛选表单字段获取已应用的筛选条件
+// 参数:
+//   - fields: 筛选表单字段，见 FieldList.GetTheadAndFilterForm
+//
+// 返回: 已应用的筛选条件列表
+func FilterChips(fields []FormField) []FilterChip {
+	chips := make([]FilterChip, 0)
+	for _, f := range fields {
+		if f.Hide {
+			continue
+		}
+
+		var (
+			value    = string(f.Value)
+			operator = f.Label
+		)
+
+		switch {
+		case f.FormType.IsRange():
+			if value == "" && f.Value2 == "" {
+				continue
+			}
+			value = value + " ~ " + f.Value2
+		case f.FormType.IsSelect():
+			if value == "" {
+				continue
+			}
+			texts := make([]string, 0)
+			for _, option := range f.Options {
+				if option.Selected {
+					texts = append(texts, option.Text)
+				}
+			}
+			if len(texts) > 0 {
+				value = strings.Join(texts, ", ")
+			} else {
+				value = strings.ReplaceAll(value, parameter.Separator, ", ")
+			}
+		case f.FormType.IsSlider():
+			if value == "" {
+				continue
+			}
+			value = strings.ReplaceAll(value, RangeSliderSeparator, " ~ ")
+		default:
+			if value == "" {
+				continue
+			}
+			if operator == template.HTML(FilterOperatorFree) {
+				operator = template.HTML(f.Value2)
+			}
+		}
+
+		if operator == template.HTML(FilterOperatorEqual) {
+			operator = ""
+		}
+
+		chips = append(chips, FilterChip{
+			Field:    f.Field,
+			Head:     f.Head,
+			Operator: operator,
+			Value:    value,
+		})
+	}
+	return chips
+}
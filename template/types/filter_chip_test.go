@@ -0,0 +1,33 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+	"github.com/purpose168/GoAdmin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterChips(t *testing.T) {
+	chips := FilterChips([]FormField{
+		{Field: "name", Head: "Name", FormType: form.Text, Value: "go"},
+		{Field: "age", Head: "Age", FormType: form.Number, Value: "18", Label: ">="},
+		{Field: "age__goadmin_operator__", FormType: form.Number, Value: "gq", Hide: true},
+		{Field: "email", Head: "Email", FormType: form.Text},
+		{Field: "city", Head: "City", FormType: form.Select, Value: "1" + parameter.Separator + "2",
+			Options: FieldOptions{{Text: "Beijing", Value: "1", Selected: true}, {Text: "Shanghai", Value: "2", Selected: true},
+				{Text: "Guangzhou", Value: "3"}}},
+		{Field: "created", Head: "Created", FormType: form.DatetimeRange, Value: "2026-01-01", Value2: "2026-02-01"},
+		{Field: "price", Head: "Price", FormType: form.Slider, Value: "10;20"},
+		{Field: "level", Head: "Level", FormType: form.Text, Value: "3", Value2: "!=", Label: "free"},
+	})
+
+	assert.Equal(t, []FilterChip{
+		{Field: "name", Head: "Name", Value: "go"},
+		{Field: "age", Head: "Age", Operator: ">=", Value: "18"},
+		{Field: "city", Head: "City", Value: "Beijing, Shanghai"},
+		{Field: "created", Head: "Created", Value: "2026-01-01 ~ 2026-02-01"},
+		{Field: "price", Head: "Price", Value: "10 ~ 20"},
+		{Field: "level", Head: "Level", Operator: "!=", Value: "3"},
+	}, chips)
+}
@@ -0,0 +1,163 @@
+package types
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/template/types/form"
+)
+
+// TimezoneCookie 是浏览器时区的 cookie 名称，配置了 Timezone 时由页面的脚本写入
+const TimezoneCookie = "goadmin_tz"
+
+// FilterDatetime 是日期和日期时间筛选的选择器配置
+type FilterDatetime struct {
+	WeekStart int    // 一周的第一天，1 为周一，7 为周日，0 使用语言的默认值
+	Hour12    bool   // 使用12小时制，默认为24小时制
+	Min       string // 可选的最早时间，如 2020-01-01
+	Max       string // 可选的最晚时间
+}
+
+// filterTimeLayouts 是筛选的日期时间支持的格式
+var filterTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 03:04:05 PM",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02 03:04 PM",
+	"2006-01-02",
+}
+
+// UserLocation 获取用户的时区
+// 参数:
+//   - ctx: 当前请求的上下文
+//
+// 返回: 浏览器上报的时区；未配置 Timezone 或时区无效时返回数据库的时区，不做转换
+func UserLocation(ctx *context.Context) *time.Location {
+	storage := storageLocation()
+	if config.GetTimezone() == "" || ctx == nil {
+		return storage
+	}
+	name, _ := url.QueryUnescape(ctx.Cookie(TimezoneCookie))
+	if name == "" || len(name) > 64 {
+		return storage
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return storage
+	}
+	return loc
+}
+
+// storageLocation 获取数据库的时区，未配置或无效时为服务器的本地时区
+func storageLocation() *time.Location {
+	if name := config.GetTimezone(); name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
+
+// parseFilterTime 按用户的时区解析筛选的日期时间
+func parseFilterTime(value string, loc *time.Location) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range filterTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// NormalizeFilterTime 将日期和日期时间筛选的值转换为数据库的格式，日期时间从用户的时区转换为
+// 数据库的时区，使按字符串比较的筛选结果正确。其他的筛选和无法解析的值不变
+// 参数:
+//   - key: 字段名
+//   - value: 筛选的值
+//   - keyIndex: 筛选的序号，如 "__goadmin_index__1"
+//   - loc: 用户的时区，见 UserLocation
+//
+// 返回: 转换后的值，如 "2006-01-02 15:04:05"
+func (f FieldList) NormalizeFilterTime(key, value, keyIndex string, loc *time.Location) string {
+	field := f.GetFieldByFieldName(key)
+	index := filterIndex(keyIndex)
+	if len(field.FilterFormFields) <= index {
+		return value
+	}
+
+	typ := field.FilterFormFields[index].Type
+	switch {
+	case typ.IsDate() || typ.IsDateRange():
+		if t, ok := parseFilterTime(value, loc); ok {
+			return t.Format(dbDateLayout)
+		}
+	case typ.IsDateTime() || typ.IsDateTimeRange():
+		if t, ok := parseFilterTime(value, loc); ok {
+			return t.In(storageLocation()).Format(dbDatetimeLayout)
+		}
+	}
+	return value
+}
+
+// options 将选择器的配置写入选择器的选项
+// 参数:
+//   - typ: 筛选的表单类型
+//   - ops: 选择器的选项，范围筛选有两个
+//
+// 返回: 选择器选项的 JS 表达式，设置一周的第一天时先定义对应的语言
+func (d *FilterDatetime) options(typ form.Type, ops ...map[string]interface{}) []template.JS {
+	var (
+		dow          = strconv.Itoa(d.WeekStart % 7)
+		base, locale string
+	)
+	for _, op := range ops {
+		if op == nil {
+			continue
+		}
+		if d.Hour12 && (typ.IsDateTime() || typ.IsDateTimeRange()) {
+			op["format"] = "YYYY-MM-DD hh:mm:ss A"
+		}
+		if d.Min != "" {
+			op["minDate"] = d.Min
+		}
+		if d.Max != "" {
+			op["maxDate"] = d.Max
+		}
+		if d.WeekStart > 0 {
+			base, _ = op["locale"].(string)
+			base = strings.ToLower(base)
+			locale = base + "-dow" + dow
+			op["locale"] = locale
+		}
+	}
+
+	res := make([]template.JS, len(ops))
+	for k, op := range ops {
+		if op == nil {
+			continue
+		}
+		s, _ := json.Marshal(op)
+		res[k] = template.JS(s)
+		if locale == "" {
+			continue
+		}
+		res[k] = template.JS(`(function () {
+    if (window.moment && moment.locales().indexOf("` + locale + `") === -1) {
+        var current = moment.locale();
+        moment.defineLocale("` + locale + `", {parentLocale: "` + base + `", week: {dow: ` + dow + `, doy: 4}});
+        moment.locale(current);
+    }
+    return ` + string(s) + `;
+})()`)
+	}
+	return res
+}
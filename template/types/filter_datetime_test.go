@@ -0,0 +1,44 @@
+package types
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/parameter"
+	"github.com/purpose168/GoAdmin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldList_NormalizeFilterTime(t *testing.T) {
+	info := NewInfoPanel(nil, "id")
+	info.AddField("Created", "created_at", db.Datetime).
+		FieldFilterable(FilterType{FormType: form.DatetimeRange}, FilterType{FormType: form.Date})
+	info.AddField("Name", "name", db.Varchar).FieldFilterable()
+
+	loc := time.FixedZone("UTC+8", 8*3600)
+	want := time.Date(2025, 12, 31, 20, 30, 0, 0, loc).In(time.Local).Format("2006-01-02 15:04:05")
+
+	fields := info.FieldList
+	assert.Equal(t, want, fields.NormalizeFilterTime("created_at", "2025-12-31 20:30:00", "", loc))
+	assert.Equal(t, want, fields.NormalizeFilterTime("created_at", "2025-12-31T20:30", "", loc))
+	assert.Equal(t, want, fields.NormalizeFilterTime("created_at", "2025-12-31 08:30:00 PM", "", loc))
+	assert.Equal(t, "2025-12-31", fields.NormalizeFilterTime("created_at", "2025-12-31 23:00:00", parameter.FilterParamCountInfix+"1", loc))
+	assert.Equal(t, "last week", fields.NormalizeFilterTime("created_at", "last week", "", loc))
+	assert.Equal(t, "2025-12-31", fields.NormalizeFilterTime("name", "2025-12-31", "", loc))
+}
+
+func TestFilterDatetime_options(t *testing.T) {
+	d := &FilterDatetime{WeekStart: 1, Hour12: true, Min: "2020-01-01"}
+	ops := d.options(form.DatetimeRange, map[string]interface{}{"locale": "zh-CN"}, map[string]interface{}{"locale": "zh-CN"})
+
+	assert.Len(t, ops, 2)
+	assert.True(t, strings.Contains(string(ops[0]), `moment.defineLocale("zh-cn-dow1", {parentLocale: "zh-cn", week: {dow: 1, doy: 4}})`))
+	assert.True(t, strings.Contains(string(ops[1]), `"format":"YYYY-MM-DD hh:mm:ss A"`))
+	assert.True(t, strings.Contains(string(ops[1]), `"minDate":"2020-01-01"`))
+
+	ops = (&FilterDatetime{Max: "2030-01-01"}).options(form.Date, map[string]interface{}{"locale": "en"}, nil)
+	assert.Equal(t, `{"locale":"en","maxDate":"2030-01-01"}`, string(ops[0]))
+	assert.Equal(t, "", string(ops[1]))
+}
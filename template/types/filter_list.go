@@ -0,0 +1,56 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/template/types/form"
+)
+
+// MaxFilterListValues 是粘贴列表筛选最多接受的值的个数，超出的值被忽略
+const MaxFilterListValues = 10000
+
+// FilterListChunkSize 是粘贴列表筛选每个 in 条件的值的个数，值更多时拆分为多个以 or 连接的 in 条件
+const FilterListChunkSize = 1000
+
+// FieldListFilterable 设置字段为可按粘贴的列表筛选，筛选条件为等于列表中的任意一个值
+// 适用于从表格中复制的一列 ID、邮箱等，值以换行、逗号、分号或制表符分隔，见 ParseFilterList
+// 返回: 更新后的信息面板
+func (i *InfoPanel) FieldListFilterable() *InfoPanel {
+	return i.FieldFilterable(FilterType{
+		FormType:    form.TextArea,
+		Operator:    FilterOperatorList,
+		Placeholder: language.Get("paste a list, one value per line"),
+	})
+}
+
+// ParseFilterList 解析粘贴列表筛选的值
+// 值以换行、逗号、分号或制表符分隔，去除首尾的空白和引号，忽略空值和重复的值，
+// 最多返回 MaxFilterListValues 个值
+// 参数:
+//   - value: 筛选值
+//
+// 返回: 按粘贴顺序排列的值
+func ParseFilterList(value string) []string {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == '\n' || r == '\r' || r == ',' || r == ';' || r == '\t'
+	})
+	values := make([]string, 0, len(fields))
+	seen := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		field = strings.Trim(strings.TrimSpace(field), `"'`)
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if _, ok := seen[field]; ok {
+			continue
+		}
+		seen[field] = struct{}{}
+		values = append(values, field)
+		if len(values) == MaxFilterListValues {
+			break
+		}
+	}
+	return values
+}
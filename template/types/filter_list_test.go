@@ -0,0 +1,21 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFilterList(t *testing.T) {
+	assert.Equal(t, []string{"1", "2", "3", "a@b.com", "4"},
+		ParseFilterList("1\r\n2\n\n 3 ,\"a@b.com\";'4'\t2\n"))
+	assert.Equal(t, []string{}, ParseFilterList(" \n,;"))
+
+	values := make([]string, MaxFilterListValues+5)
+	for i := range values {
+		values[i] = strconv.Itoa(i)
+	}
+	assert.Len(t, ParseFilterList(strings.Join(values, "\n")), MaxFilterListValues)
+}
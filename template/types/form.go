@@ -16,6 +16,7 @@ import (
 	"github.com/purpose168/GoAdmin/modules/constant"
 	"github.com/purpose168/GoAdmin/modules/db"
 	"github.com/purpose168/GoAdmin/modules/errors"
+	"github.com/purpose168/GoAdmin/modules/expr"
 	"github.com/purpose168/GoAdmin/modules/file"
 	"github.com/purpose168/GoAdmin/modules/language"
 	"github.com/purpose168/GoAdmin/modules/logger"
@@ -132,6 +133,7 @@ type OptionTable struct {
 	Table          string                    // 数据库表名
 	TextField      string                    // 用于显示文本的字段名
 	ValueField     string                    // 用于值的字段名
+	ParentField    string                    // 父节点字段名，设置后选项按树形显示
 	QueryProcessFn OptionTableQueryProcessFn // 查询处理函数
 	ProcessFn      OptionProcessFn           // 选项处理函数
 }
@@ -201,7 +203,20 @@ type FormField struct {
 	OptionTable     OptionTable     `json:"-"`            // 选项表配置
 
 	FieldDisplay `json:"-"`        // 字段显示配置
-	PostFilterFn PostFieldFilterFn `json:"-"` // 后置过滤函数
+	PostFilterFn   PostFieldFilterFn `json:"-"` // 后置过滤函数
+	ValidateFn     FieldValidateFn   `json:"-"` // 字段验证函数
+	PasswordHasher PasswordHasher    `json:"-"` // 密码哈希函数，保存前计算提交的密码的哈希
+	SlugFrom       string            `json:"-"` // 别名的来源字段，保存时规范化别名并保证唯一
+	GenerateFn     KeyGenerator      `json:"-"` // 新建时值为空的生成函数，见 FieldUUIDDefault
+
+	ValidateExpr    string `json:"-"` // 验证表达式，见 FieldValidateExpr
+	ValidateExprMsg string `json:"-"` // 验证表达式不成立时的错误信息
+	HideExpr        string `json:"-"` // 编辑时隐藏字段的表达式，见 FieldHideWhenExpr
+
+	hideProgram *expr.Program // 编译后的隐藏字段的表达式
+
+	PreSaveFn   FieldPreSaveFn `json:"-"` // 保存前的钩子函数，见 FieldPreSave
+	InputLocale *InputLocale   `json:"-"` // 提交的数字和日期的格式，见 FieldInputLocale
 }
 
 // GetRawValue 从给定的值中获取原始值
@@ -244,6 +259,18 @@ func (f *FormField) UpdateDefaultValue(sql *db.SQL) *FormField {
 func (f *FormField) setOptionsFromSQL(sql *db.SQL) {
 	if sql != nil && f.OptionTable.Table != "" && len(f.Options) == 0 {
 
+		if f.OptionTable.ParentField != "" {
+			tree, err := LoadOptionTree(sql, f.OptionTable)
+			if err == nil {
+				f.Options = tree.Options()
+				f.OptionExt = tree.SelectJS(f.FieldClass, f.OptionExt)
+			}
+			if f.OptionTable.ProcessFn != nil {
+				f.Options = f.OptionTable.ProcessFn(f.Options)
+			}
+			return
+		}
+
 		sql.Table(f.OptionTable.Table).Select(f.OptionTable.ValueField, f.OptionTable.TextField)
 
 		if f.OptionTable.QueryProcessFn != nil {
@@ -407,6 +434,12 @@ type FormPanel struct {
 	PostHook     FormPostFn       `json:"post_hook"`      // 提交后钩子函数
 	PreProcessFn FormPreProcessFn `json:"pre_process_fn"` // 预处理函数
 
+	PreValidateFn FormHookFn     `json:"-"` // 验证前的钩子函数
+	PreSaveFn     FormHookFn     `json:"-"` // 保存前的钩子函数
+	PostSaveFn    FormPostSaveFn `json:"-"` // 保存后的钩子函数，在请求中同步调用
+
+	InputLocale *InputLocale `json:"-"` // 提交的数字和日期的格式，见 SetInputLocale
+
 	Callbacks Callbacks `json:"callbacks"` // 回调函数列表
 
 	primaryKey primaryKey // 主键配置
@@ -448,6 +481,8 @@ type FormPanel struct {
 	HeaderHtml template.HTML `json:"header_html"` // 头部HTML
 	FooterHtml template.HTML `json:"footer_html"` // 底部HTML
 
+	ScriptRecords ScriptRegistry `json:"-"` // 已添加到FooterHtml的脚本和页脚内容，相同的内容只添加一次
+
 	PageError     errors.PageError `json:"page_error"`      // 页面错误
 	PageErrorHTML template.HTML    `json:"page_error_html"` // 页面错误HTML
 
@@ -463,6 +498,7 @@ func NewFormPanel() *FormPanel {
 	return &FormPanel{
 		curFieldListIndex: -1,
 		Callbacks:         make(Callbacks, 0),
+		ScriptRecords:     make(ScriptRegistry),
 		Layout:            form2.LayoutDefault,
 		FormNewTitle:      "新建",
 		FormEditTitle:     "编辑",
@@ -471,6 +507,21 @@ func NewFormPanel() *FormPanel {
 	}
 }
 
+// Clone 复制表单面板，用于缓存的表格在每个请求中使用独立的面板。
+// 字段列表和回调被复制，字段中的函数与原面板共享
+// 返回: 复制的 FormPanel 指针
+func (f *FormPanel) Clone() *FormPanel {
+	c := *f
+	c.FieldList = append(FormFields(nil), f.FieldList...)
+	for i := range c.FieldList {
+		// 选项的选中状态在请求中设置
+		c.FieldList[i].Options = append(FieldOptions(nil), f.FieldList[i].Options...)
+	}
+	c.Callbacks = append(Callbacks(nil), f.Callbacks...)
+	c.ScriptRecords = f.ScriptRecords.Copy()
+	return &c
+}
+
 // AddLimitFilter 添加长度限制过滤器
 // 参数:
 //   - limit: 最大长度限制
@@ -638,6 +689,16 @@ func (f *FormPanel) AddField(head, field string, filedType db.DatabaseType, form
 	// 设置不同表单类型的默认显示过滤函数
 	setDefaultDisplayFnOfFormType(f, formType)
 
+	// 颜色以十六进制保存
+	if formType == form2.Color {
+		f.FieldList[f.curFieldListIndex].PostFilterFn = func(value PostFieldModel) interface{} {
+			if hex, ok := NormalizeHexColor(value.Value.Value()); ok {
+				return hex
+			}
+			return value.Value.Value()
+		}
+	}
+
 	if formType.IsEditor() {
 		f.NoCompress = true
 	}
@@ -726,6 +787,12 @@ func (f *FormPanel) FieldDisplay(filter FieldFilterFn) *FormPanel {
 // 返回: 更新后的 FormPanel 指针
 func (f *FormPanel) SetTable(table string) *FormPanel {
 	f.Table = table
+	// the options of the tag fields added before are from the table.
+	for k := range f.FieldList {
+		if ot := &f.FieldList[k].OptionTable; ot.Table == "" && ot.ValueField != "" {
+			ot.Table = table
+		}
+	}
 	return f
 }
 
@@ -1106,6 +1173,180 @@ func (f *FormPanel) FieldPostFilterFn(post PostFieldFilterFn) *FormPanel {
 	return f
 }
 
+// FieldValidator 设置字段的验证函数，提交时由 Validate 调用
+func (f *FormPanel) FieldValidator(fn FieldValidateFn) *FormPanel {
+	f.FieldList[f.curFieldListIndex].ValidateFn = fn
+	return f
+}
+
+// FieldPhone 设置字段为电话号码，提交时校验格式并保存规范化的号码，见 NormalizePhone
+// 返回: 更新后的 FormPanel 指针
+func (f *FormPanel) FieldPhone() *FormPanel {
+	field := &f.FieldList[f.curFieldListIndex]
+	field.ValidateFn = validatePhone
+	field.PostFilterFn = phonePostFilter
+	return f
+}
+
+// FieldEmail 设置字段为邮箱地址，提交时校验格式并保存规范化的地址，见 NormalizeEmail
+// 返回: 更新后的 FormPanel 指针
+func (f *FormPanel) FieldEmail() *FormPanel {
+	field := &f.FieldList[f.curFieldListIndex]
+	field.FormType = form2.Email
+	field.ValidateFn = validateEmail
+	field.PostFilterFn = emailPostFilter
+	return f
+}
+
+// FieldCron 设置字段为 cron 表达式，提交时校验格式
+// 输入时在字段下方显示表达式的描述和接下来的运行时间，运行时间由服务端计算
+// 返回: 更新后的 FormPanel 指针
+func (f *FormPanel) FieldCron() *FormPanel {
+	field := &f.FieldList[f.curFieldListIndex]
+	field.FormType = form2.Text
+	field.ValidateFn = validateCron
+	field.PostFilterFn = cronPostFilter
+	js, callback := cronPreviewJS(field.Field, f.OperationURL("cron_preview"))
+	f.FooterHtml += js
+	f.Callbacks = f.Callbacks.AddCallback(callback)
+	return f
+}
+
+// FieldSignature 设置字段为签名画布，签名保存为 PNG 或 SVG 图片
+// 提交时图片保存到上传引擎，字段的值为图片的路径，见 FieldSignatureParam
+// 参数:
+//   - param: 可选的签名参数
+//
+// 返回: 更新后的 FormPanel 指针
+func (f *FormPanel) FieldSignature(param ...FieldSignatureParam) *FormPanel {
+	p := FieldSignatureParam{Width: 400, Height: 160}
+	if len(param) > 0 {
+		p.SVG = param[0].SVG
+		if param[0].Width > 0 {
+			p.Width = param[0].Width
+		}
+		if param[0].Height > 0 {
+			p.Height = param[0].Height
+		}
+	}
+
+	field := &f.FieldList[f.curFieldListIndex]
+	field.FormType = form2.Text
+	field.ValidateFn = validateSignature
+	field.PostFilterFn = signaturePostFilter
+	f.FooterHtml += signatureJS(field.Field, p)
+	return f
+}
+
+// FieldCode 设置字段为代码编辑器，见 FieldCodeParam
+// 提交的内容不再经过 URL 编码，超过大小限制或 json 格式错误时校验失败
+// 参数:
+//   - param: 可选的代码编辑器参数
+//
+// 返回: 更新后的 FormPanel 指针
+func (f *FormPanel) FieldCode(param ...FieldCodeParam) *FormPanel {
+	p := FieldCodeParam{Language: "html", Theme: "monokai", FontSize: 14}
+	if len(param) > 0 {
+		p.LanguageField = param[0].LanguageField
+		p.MaxSize = param[0].MaxSize
+		if param[0].Language != "" {
+			p.Language = param[0].Language
+		}
+		if param[0].Theme != "" {
+			p.Theme = param[0].Theme
+		}
+		if param[0].FontSize > 0 {
+			p.FontSize = param[0].FontSize
+		}
+	}
+
+	field := &f.FieldList[f.curFieldListIndex]
+	field.FormType = form2.Code
+	field.ValidateFn = codeValidator(p)
+	f.FieldOptionExt(map[string]interface{}{
+		"theme":     p.Theme,
+		"font_size": strconv.Itoa(p.FontSize),
+		"language":  p.Language,
+		"options":   "{useWorker: false}",
+	})
+	f.FooterHtml += codeJS(field.Field, p)
+	return f
+}
+
+// FieldPassword 设置字段为密码，显示密码强度，编辑时不显示已保存的值
+// 保存前计算提交的密码的哈希，为空时不修改已保存的密码，见 HashPasswordFields
+// 参数:
+//   - param: 可选的密码参数
+//
+// 返回: 更新后的 FormPanel 指针
+func (f *FormPanel) FieldPassword(param ...FieldPasswordParam) *FormPanel {
+	p := FieldPasswordParam{}
+	if len(param) > 0 {
+		p = param[0]
+	}
+
+	field := &f.FieldList[f.curFieldListIndex]
+	field.FormType = form2.Password
+	field.Display = func(value FieldModel) interface{} {
+		return ""
+	}
+	field.ValidateFn = passwordValidator(p)
+	field.PasswordHasher = p.Hasher
+	if field.PasswordHasher == nil {
+		field.PasswordHasher = BcryptHasher
+	}
+	f.FooterHtml += passwordStrengthJS(field.Field)
+	return f
+}
+
+// FieldSlugFrom 设置字段为根据来源字段自动生成的别名，如根据标题生成文章的别名
+// 输入来源字段时自动填写别名，勾选手动编辑后可以修改；保存时规范化别名，
+// 与其他记录重复时添加 -2、-3 等后缀，见 Slugify
+// 参数:
+//   - field: 来源字段
+//
+// 返回: 更新后的 FormPanel 指针
+func (f *FormPanel) FieldSlugFrom(field string) *FormPanel {
+	f.FieldList[f.curFieldListIndex].SlugFrom = field
+	f.FooterHtml += slugJS(f.FieldList[f.curFieldListIndex].Field, field)
+	return f
+}
+
+// FieldTags 设置字段为标签输入，以多选框显示，可以输入新的标签
+// 可选的标签来自表中已有的值，保存为逗号分隔或 JSON 数组
+// 参数:
+//   - param: 可选的标签参数
+//
+// 返回: 更新后的 FormPanel 指针
+func (f *FormPanel) FieldTags(param ...FieldTagsParam) *FormPanel {
+	p := FieldTagsParam{Table: f.Table}
+	if len(param) > 0 {
+		p.JSON = param[0].JSON
+		if param[0].Table != "" {
+			p.Table = param[0].Table
+		}
+	}
+
+	field := &f.FieldList[f.curFieldListIndex]
+	field.FormType = form2.Select
+	field.OptionTable = OptionTable{
+		Table:      p.Table,
+		TextField:  field.Field,
+		ValueField: field.Field,
+		ProcessFn:  tagOptions,
+	}
+	field.Display = func(value FieldModel) interface{} {
+		return ParseTags(value.Value)
+	}
+	field.PostFilterFn = func(value PostFieldModel) interface{} {
+		return FormatTags(value.Value, p.JSON)
+	}
+	return f.FieldOptionExt(map[string]interface{}{
+		"tags":            true,
+		"tokenSeparators": []string{","},
+	})
+}
+
 func (f *FormPanel) FieldNow() *FormPanel {
 	f.FieldList[f.curFieldListIndex].PostFilterFn = func(value PostFieldModel) interface{} {
 		return time.Now().Format("2006-01-02 15:04:05")
@@ -1308,7 +1549,10 @@ func (f *FormPanel) FieldOnChooseDisable(value string, field ...string) *FormPan
 }
 
 func (f *FormPanel) addFooterHTML(footer template.HTML) *FormPanel {
-	f.FooterHtml += template.HTML(ParseTableDataTmpl(footer))
+	if f.ScriptRecords == nil {
+		f.ScriptRecords = make(ScriptRegistry)
+	}
+	f.FooterHtml += f.ScriptRecords.Once(template.HTML(ParseTableDataTmpl(footer)))
 	return f
 }
 
@@ -1584,6 +1828,54 @@ func (f *FormPanel) SetPostValidator(va FormPostFn) *FormPanel {
 	return f
 }
 
+// Validate 验证提交的值，先运行字段的验证函数，再运行表单的验证函数
+// 未提交的字段不验证，如列表页中单个字段的更新
+// 参数:
+//   - values: 提交的值
+//
+// 返回: 第一个验证错误，错误信息以字段标题开头
+func (f *FormPanel) Validate(values form.Values) error {
+	for _, field := range f.FieldList {
+		if field.ValidateFn == nil {
+			continue
+		}
+		value, ok := values[field.Field]
+		if !ok {
+			if value, ok = values[field.Field+"[]"]; !ok {
+				continue
+			}
+		}
+		if err := field.ValidateFn(PostFieldModel{
+			ID:    values.Get(f.primaryKey.Name),
+			Value: value,
+			Row:   values.ToMap(),
+		}); err != nil {
+			return fmt.Errorf("%s: %w", field.Head, err)
+		}
+	}
+	if f.Validator != nil {
+		return f.Validator(values)
+	}
+	return nil
+}
+
+// PreProcess 保存前预处理提交的值，依次执行 PreProcessFn 和密码字段的哈希
+// 参数:
+//   - values: 提交的值
+//
+// 返回: 预处理后的值
+func (f *FormPanel) PreProcess(values form.Values) form.Values {
+	if f.PreProcessFn != nil {
+		values = f.PreProcessFn(values)
+	}
+	for _, field := range f.FieldList {
+		if field.PasswordHasher != nil {
+			values = HashPasswordFields(field.PasswordHasher, field.Field)(values)
+		}
+	}
+	return values
+}
+
 func (f *FormPanel) SetPreProcessFn(fn FormPreProcessFn) *FormPanel {
 	f.PreProcessFn = fn
 	return f
@@ -1696,6 +1988,9 @@ func (f *FormPanel) EnableAjaxData(data AjaxData) *FormPanel {
 		if (data.data && data.data.token !== "") {
 			$("input[name='__go_admin_t_']").val(data.data.token);
 		}
+		if (data.data && data.data.field) {
+			$("[name='" + data.data.field + "']").closest(".form-group").addClass("has-error");
+		}
 		swal({
 			type: "error",
 			title: ` + errorMsg + `,
@@ -1716,6 +2011,9 @@ func (f *FormPanel) EnableAjaxData(data AjaxData) *FormPanel {
 		if (data.responseJSON.data && data.responseJSON.data.token !== "") {
 			$("input[name='__go_admin_t_']").val(data.responseJSON.data.token)
 		}
+		if (data.responseJSON.data && data.responseJSON.data.field) {
+			$("[name='" + data.responseJSON.data.field + "']").closest(".form-group").addClass("has-error");
+		}
 		swal({
 			type: "error",
 			title: ` + errorMsg + `,
@@ -1800,7 +2098,7 @@ func (f *FormPanel) GroupFieldWithValue(pk, id string, columns []string, res map
 				field := f.FieldList.FindByFieldName(fieldName)
 				if field != nil && field.isNotBelongToATable() && !field.NotAllowEdit {
 					if !field.Hide {
-						field.Hide = field.EditHide
+						field.Hide = field.EditHide || field.hiddenByExpr(res)
 					}
 					if field.FormType.IsTable() {
 						for z := 0; z < len(field.TableFields); z++ {
@@ -1905,7 +2203,7 @@ func (f *FormPanel) FieldsWithValue(pk, id string, columns []string, res map[str
 	for i := 0; i < len(f.FieldList); i++ {
 		if !f.FieldList[i].NotAllowEdit {
 			if !f.FieldList[i].Hide {
-				f.FieldList[i].Hide = f.FieldList[i].EditHide
+				f.FieldList[i].Hide = f.FieldList[i].EditHide || f.FieldList[i].hiddenByExpr(res)
 			}
 			rowValue := f.FieldList[i].GetRawValue(columns, res[f.FieldList[i].Field])
 			if f.FieldList[i].FatherField != "" {
@@ -1973,6 +2271,7 @@ func (f *FormPanel) GetNewFormFields(sql ...func() *db.SQL) (FormFields, []FormF
 type (
 	FormPreProcessFn  func(values form.Values) form.Values
 	FormPostFn        func(values form.Values) error
+	FieldValidateFn   func(value PostFieldModel) error
 	FormFields        []FormField
 	GroupFormFields   []FormFields
 	GroupFieldHeaders []string
@@ -358,6 +358,24 @@ func (t Type) String() string {
 	}
 }
 
+// GetTypeFromString 从字符串获取表单类型，与 String 的结果对应
+// 用于从JSON定义等配置中读取表单类型
+//
+// 参数：
+//   - s: 表单类型的字符串表示，如 "text"、"select_single"
+//
+// 返回值：
+//   - Type: 对应的表单类型
+//   - bool: 字符串是否有效
+func GetTypeFromString(s string) (Type, bool) {
+	for _, item := range AllType {
+		if item.String() == s {
+			return item, true
+		}
+	}
+	return Default, false
+}
+
 // IsSelect 判断是否为选择类字段
 // 选择类字段包括下拉框、单选按钮、复选框等
 //
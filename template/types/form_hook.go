@@ -0,0 +1,123 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+)
+
+// FormHookContext 是表单钩子函数的参数，包含当前请求的上下文、登录用户和待保存的值
+// 钩子函数修改 Values 后，修改后的值被后续的验证和保存使用
+type FormHookContext struct {
+	Ctx      *context.Context // 当前请求的上下文
+	User     models.UserModel // 当前登录的用户
+	Values   form.Values      // 待保存的值
+	PostType PostType         // 新建或更新
+}
+
+// NewFormHookContext 创建表单钩子函数的参数
+// 参数:
+//   - ctx: 当前请求的上下文
+//   - values: 待保存的值
+//   - typ: 新建或更新
+//
+// 返回: 表单钩子函数的参数
+func NewFormHookContext(ctx *context.Context, values form.Values, typ PostType) *FormHookContext {
+	hc := &FormHookContext{Ctx: ctx, Values: values, PostType: typ}
+	if ctx != nil {
+		hc.User, _ = ctx.User().(models.UserModel)
+	}
+	return hc
+}
+
+// IsCreate 判断是否为新建
+func (hc *FormHookContext) IsCreate() bool {
+	return hc.PostType == PostTypeCreate
+}
+
+// IsUpdate 判断是否为更新
+func (hc *FormHookContext) IsUpdate() bool {
+	return hc.PostType == PostTypeUpdate
+}
+
+type (
+	// FormHookFn 是表单的钩子函数类型，返回错误时中止保存
+	FormHookFn func(hc *FormHookContext) error
+	// FormPostSaveFn 是表单保存后的钩子函数类型，err 为保存的结果
+	FormPostSaveFn func(hc *FormHookContext, err error)
+	// FieldPreSaveFn 是字段保存前的钩子函数类型，返回字段保存的值，返回错误时中止保存
+	FieldPreSaveFn func(hc *FormHookContext, value string) (string, error)
+)
+
+// SetPreValidate 设置验证前的钩子函数，可以在验证前补充或修改提交的值
+func (f *FormPanel) SetPreValidate(fn FormHookFn) *FormPanel {
+	f.PreValidateFn = fn
+	return f
+}
+
+// SetPreSave 设置保存前的钩子函数，在验证和预处理之后、插入或更新之前调用
+func (f *FormPanel) SetPreSave(fn FormHookFn) *FormPanel {
+	f.PreSaveFn = fn
+	return f
+}
+
+// SetPostSaveCtx 设置保存后的钩子函数，与 PostHook 不同，在请求中同步调用并可以访问请求的上下文
+func (f *FormPanel) SetPostSaveCtx(fn FormPostSaveFn) *FormPanel {
+	f.PostSaveFn = fn
+	return f
+}
+
+// FieldPreSave 设置字段保存前的钩子函数，只有提交了该字段时调用
+func (f *FormPanel) FieldPreSave(fn FieldPreSaveFn) *FormPanel {
+	f.FieldList[f.curFieldListIndex].PreSaveFn = fn
+	return f
+}
+
+// RunPreValidate 运行验证前的钩子函数
+// 参数:
+//   - hc: 钩子函数的参数
+//
+// 返回: 钩子函数的错误
+func (f *FormPanel) RunPreValidate(hc *FormHookContext) error {
+	if f.PreValidateFn == nil {
+		return nil
+	}
+	return f.PreValidateFn(hc)
+}
+
+// RunPreSave 先运行字段保存前的钩子函数，再运行表单保存前的钩子函数
+// 参数:
+//   - hc: 钩子函数的参数
+//
+// 返回: 第一个错误，字段钩子的错误信息以字段标题开头
+func (f *FormPanel) RunPreSave(hc *FormHookContext) error {
+	for _, field := range f.FieldList {
+		if field.PreSaveFn == nil {
+			continue
+		}
+		if _, ok := hc.Values[field.Field]; !ok {
+			continue
+		}
+		value, err := field.PreSaveFn(hc, hc.Values.Get(field.Field))
+		if err != nil {
+			return fmt.Errorf("%s: %w", field.Head, err)
+		}
+		hc.Values.Add(field.Field, value)
+	}
+	if f.PreSaveFn != nil {
+		return f.PreSaveFn(hc)
+	}
+	return nil
+}
+
+// RunPostSave 运行保存后的钩子函数
+// 参数:
+//   - hc: 钩子函数的参数
+//   - err: 保存的结果
+func (f *FormPanel) RunPostSave(hc *FormHookContext, err error) {
+	if f.PostSaveFn != nil {
+		f.PostSaveFn(hc, err)
+	}
+}
@@ -1 +1,44 @@
 package types
+
+import (
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeHexColor(t *testing.T) {
+	cases := []struct {
+		value, want string
+		ok          bool
+	}{
+		{"#FFF", "#ffffff", true},
+		{"3c8dbc", "#3c8dbc", true},
+		{" #3C8DBC ", "#3c8dbc", true},
+		{"#3c8dbcff", "#3c8dbc", true},
+		{"#3c8dbc80", "#3c8dbc80", true},
+		{"rgb(60, 141, 188)", "#3c8dbc", true},
+		{"rgba(60,141,188,1)", "#3c8dbc", true},
+		{"rgba(60,141,188,0.5)", "#3c8dbc80", true},
+		{"rgb(300,0,0)", "", false},
+		{"red", "", false},
+		{"#12345", "", false},
+		{"", "", false},
+	}
+
+	for _, c := range cases {
+		got, ok := NormalizeHexColor(c.value)
+		assert.Equal(t, c.want, got, c.value)
+		assert.Equal(t, c.ok, ok, c.value)
+	}
+}
+
+func TestFormPanel_AddField_Color(t *testing.T) {
+	panel := NewFormPanel()
+	panel.AddField("Color", "color", db.Varchar, form.Color)
+
+	fn := panel.FieldList.FindByFieldName("color").PostFilterFn
+	assert.Equal(t, "#aabbcc", fn(PostFieldModel{Value: FieldModelValue{"#ABC"}}))
+	assert.Equal(t, "red", fn(PostFieldModel{Value: FieldModelValue{"red"}}))
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	stdctx "context"
 	"encoding/json"
 	"fmt"
 	"html"
@@ -8,6 +9,9 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/purpose168/GoAdmin/modules/config"
 
@@ -36,6 +40,11 @@ type FieldModel struct {
 
 	// Post type
 	PostType PostType
+
+	// The context of the display of the list, it is done at the display
+	// timeout or when the request is canceled, so that the slow display
+	// functions can stop. It is nil out of the list.
+	Context stdctx.Context
 }
 
 type PostType uint8
@@ -186,6 +195,8 @@ type Field struct {
 	IsDeleteParam bool // 是否为删除参数
 	IsDetailParam bool // 是否为详情参数
 
+	DisplayExpr string // 计算显示值的表达式，见 FieldDisplayExpr
+
 	FieldDisplay // 字段显示配置
 }
 
@@ -211,6 +222,9 @@ type FilterFormField struct {
 	HelpMsg     template.HTML       // 帮助信息
 	NoIcon      bool                // 是否不显示图标
 	ProcessFn   func(string) string // 处理函数
+
+	RangeSlider *FieldRangeSliderParam // 范围滑块参数
+	Datetime    *FilterDatetime        // 日期时间选择器配置
 }
 
 // GetFilterFormFields 获取筛选表单字段
@@ -263,6 +277,10 @@ func (f Field) GetFilterFormFields(params parameter.Parameters, headField string
 			if js != template.JS("") {
 				optionExt1 = js
 			}
+			if filter.Datetime != nil && op1 != nil {
+				ops := filter.Datetime.options(filter.Type, op1, op2)
+				optionExt1, optionExt2 = ops[0], ops[1]
+			}
 		}
 
 		field := &FormField{
@@ -290,6 +308,11 @@ func (f Field) GetFilterFormFields(params parameter.Parameters, headField string
 
 		field.setOptionsFromSQL(sql[0])
 
+		if filter.RangeSlider != nil && filter.OptionExt == template.JS("") {
+			min, max := filter.RangeSlider.Bounds(sql[0])
+			field.OptionExt = filter.RangeSlider.options(min, max, value)
+		}
+
 		if filter.Type.IsSingleSelect() {
 			field.Options = field.Options.SetSelected(params.GetFieldValue(f.Field), filter.Type.SelectedLabel())
 		}
@@ -408,6 +431,23 @@ func (f FieldList) GetTheadAndFilterForm(info TableInfo, params parameter.Parame
 	return thead, fields, joinFields, joins, joinTables, filterForm
 }
 
+// FilterColumns 获取可用于筛选的字段，包括表的列和关联字段(表名 + 关联中缀 + 字段名)
+// 筛选参数中的字段会被拼接到SQL中，不在其中的字段将被忽略
+// 参数:
+//   - columns: 表的列名数组
+//
+// 返回: 可筛选的字段数组
+func (f FieldList) FilterColumns(columns []string) []string {
+	res := make([]string, 0, len(columns))
+	res = append(res, columns...)
+	for _, field := range f {
+		if field.Joins.Valid() {
+			res = append(res, field.Joins.Last().GetTableName()+parameter.FilterParamJoinInfix+field.Field)
+		}
+	}
+	return res
+}
+
 func (f FieldList) GetThead(info TableInfo, params parameter.Parameters, columns []string) (Thead, string, string) {
 	var (
 		thead      = make(Thead, 0)
@@ -458,10 +498,7 @@ func (f FieldList) GetThead(info TableInfo, params parameter.Parameters, columns
 
 func (f FieldList) GetFieldFilterProcessValue(key, value, keyIndex string) string {
 	field := f.GetFieldByFieldName(key)
-	index := 0
-	if keyIndex != "" {
-		index, _ = strconv.Atoi(keyIndex)
-	}
+	index := filterIndex(keyIndex)
 	if field.FilterFormFields != nil && len(field.FilterFormFields) > index {
 		if field.FilterFormFields[index].ProcessFn != nil {
 			value = field.FilterFormFields[index].ProcessFn(value)
@@ -470,6 +507,16 @@ func (f FieldList) GetFieldFilterProcessValue(key, value, keyIndex string) strin
 	return value
 }
 
+// filterIndex 获取字段的第几个筛选
+// 参数:
+//   - keyIndex: 筛选的序号，如 "__goadmin_index__1"，第一个筛选为空
+//
+// 返回: 筛选的序号
+func filterIndex(keyIndex string) int {
+	index, _ := strconv.Atoi(strings.TrimPrefix(keyIndex, parameter.FilterParamCountInfix))
+	return index
+}
+
 func (f FieldList) GetFieldJoinTable(key string) string {
 	field := f.GetFieldByFieldName(key)
 	if field.Exist() {
@@ -602,6 +649,11 @@ type InfoPanel struct {
 	Table       string
 	Title       string
 	Description string
+	Category    string
+
+	// Query 列表的查询语句，作为以 Table 命名的子查询，见 SetQuery
+	Query     string
+	QueryArgs []interface{}
 
 	// Warn: may be deprecated future.
 	TabGroups  TabGroups
@@ -616,6 +668,18 @@ type InfoPanel struct {
 	ExportType      int
 	ExportProcessFn ExportProcessFn
 
+	// ExportLimit 导出全部时的最大行数，超出的行被截断，为0时不限制
+	ExportLimit int
+	// ExportChunkSize 导出全部时每次从数据库读取并写入文件的行数
+	ExportChunkSize int
+	// ExportCSV 导出为CSV文件，而不是Excel文件
+	ExportCSV bool
+
+	// DisplayWorkers 并行处理行的显示函数的协程数，小于2时按顺序处理
+	DisplayWorkers int
+	// DisplayTimeout 并行处理显示函数的时限，为0时不限制
+	DisplayTimeout time.Duration
+
 	primaryKey primaryKey
 
 	IsHideNewButton    bool
@@ -628,6 +692,7 @@ type InfoPanel struct {
 	IsHidePagination   bool
 	IsHideFilterArea   bool
 	IsHideQueryInfo    bool
+	IsHideTotal        bool
 	FilterFormLayout   form.Layout
 
 	FilterFormHeadWidth  int
@@ -640,6 +705,9 @@ type InfoPanel struct {
 
 	Buttons Buttons
 
+	// NavButtons 只在列表页显示的导航按钮
+	NavButtons Buttons
+
 	TableLayout string
 
 	DeleteHook  DeleteFn
@@ -654,9 +722,13 @@ type InfoPanel struct {
 
 	ActionButtons    Buttons
 	ActionButtonFold bool
+	lastButton       Button
 
 	DisplayGeneratorRecords map[string]struct{}
 
+	// ScriptRecords 是已添加到FooterHtml的脚本和页脚内容，相同的内容只添加一次
+	ScriptRecords ScriptRegistry
+
 	QueryFilterFn       QueryFilterFn
 	UpdateParametersFns []UpdateParametersFn
 
@@ -674,6 +746,24 @@ type InfoPanel struct {
 	HideSideBar bool
 
 	AutoRefresh uint
+
+	// GridEdit 启用表格编辑模式，可从Excel粘贴数据批量修改可编辑字段
+	GridEdit bool
+
+	// RowReorderField 拖拽排序时保存顺序的字段，为空时不启用拖拽排序
+	RowReorderField string
+
+	// FavoriteField 收藏时作为记录标题的字段，为空时不启用收藏
+	FavoriteField string
+
+	// SyncField 数据同步时匹配记录的字段，其值在各环境中唯一，为空时不启用数据同步
+	SyncField string
+
+	// FindReplace 启用查找替换，对筛选结果中某一文本列批量查找替换，需要find_replace权限
+	FindReplace bool
+
+	// RowClasses 按表达式设置行的样式类，见 AddRowClassExpr
+	RowClasses []RowClass
 }
 
 type Where struct {
@@ -731,31 +821,36 @@ type WhereRaw struct {
 	Args []interface{} // 参数数组
 }
 
-// check 检查原始WHERE条件中是否包含and或or关键字
-// 返回: 关键字的位置
+// check 检查原始WHERE条件是否以and或or关键字开头，关键字不区分大小写，
+// 且其后须为空白、括号或结尾，如"order_id = 1"不会被识别为or
+// 返回: 关键字结束的位置，不以关键字开头时返回0
 func (wh WhereRaw) check() int {
-	index := 0
-	for i := 0; i < len(wh.Raw); i++ {
-		if wh.Raw[i] == ' ' {
-			continue
-		}
-		if wh.Raw[i] == 'a' {
-			if len(wh.Raw) < i+3 {
-				break
-			} else if wh.Raw[i+1] == 'n' && wh.Raw[i+2] == 'd' {
-				index = i + 3
-			}
-		} else if wh.Raw[i] == 'o' {
-			if len(wh.Raw) < i+2 {
-				break
-			} else if wh.Raw[i+1] == 'r' {
-				index = i + 2
-			}
-		} else {
+	start := 0
+	for start < len(wh.Raw) {
+		r, size := utf8.DecodeRuneInString(wh.Raw[start:])
+		if !unicode.IsSpace(r) {
 			break
 		}
+		start += size
 	}
-	return index
+
+	end := start
+	for end < len(wh.Raw) && ('a' <= wh.Raw[end] && wh.Raw[end] <= 'z' || 'A' <= wh.Raw[end] && wh.Raw[end] <= 'Z') {
+		end++
+	}
+
+	if word := strings.ToLower(wh.Raw[start:end]); word != "and" && word != "or" {
+		return 0
+	}
+
+	if end < len(wh.Raw) {
+		r, _ := utf8.DecodeRuneInString(wh.Raw[end:])
+		if !unicode.IsSpace(r) && r != '(' {
+			return 0
+		}
+	}
+
+	return end
 }
 
 // Statement 生成WHERE语句
@@ -885,6 +980,9 @@ var DefaultPageSizeList = []int{10, 20, 30, 50, 100}
 // DefaultPageSize 是默认页面大小
 const DefaultPageSize = 10
 
+// DefaultExportChunkSize 是导出全部时默认每次读取的行数
+const DefaultExportChunkSize = 500
+
 // NewInfoPanel 创建新的信息面板
 // 参数:
 //   - ctx: 上下文对象
@@ -897,10 +995,12 @@ func NewInfoPanel(ctx *context.Context, pk string) *InfoPanel {
 		curFieldListIndex:       -1,
 		PageSizeList:            DefaultPageSizeList,
 		DefaultPageSize:         DefaultPageSize,
+		ExportChunkSize:         DefaultExportChunkSize,
 		processChains:           make(DisplayProcessFnChains, 0),
 		Buttons:                 make(Buttons, 0),
 		Callbacks:               make(Callbacks, 0),
 		DisplayGeneratorRecords: make(map[string]struct{}),
+		ScriptRecords:           make(ScriptRegistry),
 		Wheres:                  make([]Where, 0),
 		WhereRaws:               WhereRaw{},
 		SortField:               pk,
@@ -911,6 +1011,33 @@ func NewInfoPanel(ctx *context.Context, pk string) *InfoPanel {
 	}
 }
 
+// Clone 复制信息面板，用于缓存的表格在每个请求中使用独立的面板。
+// 字段列表、筛选表单字段、选项、按钮和回调被复制，字段中的函数与原面板共享
+// 参数:
+//   - ctx: 新面板的上下文对象
+//
+// 返回: 复制的信息面板
+func (i *InfoPanel) Clone(ctx *context.Context) *InfoPanel {
+	c := *i
+	c.Ctx = ctx
+	c.FieldList = append(FieldList(nil), i.FieldList...)
+	for k := range c.FieldList {
+		// 筛选选项的选中状态在请求中设置
+		c.FieldList[k].EditOptions = append(FieldOptions(nil), i.FieldList[k].EditOptions...)
+		c.FieldList[k].FilterFormFields = append([]FilterFormField(nil), i.FieldList[k].FilterFormFields...)
+		for j := range c.FieldList[k].FilterFormFields {
+			c.FieldList[k].FilterFormFields[j].Options = append(FieldOptions(nil), i.FieldList[k].FilterFormFields[j].Options...)
+		}
+	}
+	c.Buttons = append(Buttons(nil), i.Buttons...)
+	c.ActionButtons = append(Buttons(nil), i.ActionButtons...)
+	c.NavButtons = append(Buttons(nil), i.NavButtons...)
+	c.Callbacks = append(Callbacks(nil), i.Callbacks...)
+	c.UpdateParametersFns = append([]UpdateParametersFn(nil), i.UpdateParametersFns...)
+	c.ScriptRecords = i.ScriptRecords.Copy()
+	return &c
+}
+
 // Where 添加WHERE条件
 // 参数:
 //   - field: 字段名
@@ -987,8 +1114,48 @@ func (i *InfoPanel) IsExportValue() bool {
 //
 // 返回: 更新后的信息面板
 func (i *InfoPanel) AddButtonRaw(ctx *context.Context, btn Button, action Action) *InfoPanel {
-	i.Buttons = append(i.Buttons, btn)
-	i.addFooterHTML(action.FooterContent(ctx)).addCallback(action.GetCallbacks())
+	i.addButton(btn).addFooterHTML(action.FooterContent(ctx)).addCallback(action.GetCallbacks())
+	return i
+}
+
+// AddNavButton 添加只在列表页显示的导航按钮，如商品列表页的"立即同步"
+// 参数:
+//   - title: 按钮标题
+//   - icon: 图标
+//   - action: 操作对象
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) AddNavButton(title template.HTML, icon string, action Action) *InfoPanel {
+	i.NavButtons = append(i.NavButtons, GetNavButton(title, icon, action))
+	i.addCallback(action.GetCallbacks())
+	return i
+}
+
+// SetButtonPermission 将最后添加的按钮绑定到权限标识，用户需拥有全部权限标识才显示按钮，
+// 无权限的用户请求按钮的回调路由时返回403
+// 参数:
+//   - slugs: 权限标识，如"users.edit"
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) SetButtonPermission(slugs ...string) *InfoPanel {
+	if btn, ok := i.lastButton.(permissionButton); ok {
+		btn.SetPermissions(slugs...)
+		i.Callbacks = i.Callbacks.guard(i.lastButton)
+	}
+	return i
+}
+
+// SetButtonPermissionFn 将最后添加的按钮绑定到判断函数，函数返回false时不显示按钮，
+// 请求按钮的回调路由时返回403
+// 参数:
+//   - fn: 判断函数
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) SetButtonPermissionFn(fn ButtonPermissionFn) *InfoPanel {
+	if btn, ok := i.lastButton.(permissionButton); ok {
+		btn.SetPermissionFn(fn)
+		i.Callbacks = i.Callbacks.guard(i.lastButton)
+	}
 	return i
 }
 
@@ -1034,7 +1201,8 @@ func (i *InfoPanel) AddActionIconButton(ctx *context.Context, icon string, actio
 // 返回: 更新后的信息面板
 func (i *InfoPanel) AddActionButtonFront(ctx *context.Context, title template.HTML, action Action, ids ...string) *InfoPanel {
 	i.SetActionButtonFold()
-	i.ActionButtons = append([]Button{GetActionButton(title, action, ids...)}, i.ActionButtons...)
+	i.lastButton = GetActionButton(title, action, ids...)
+	i.ActionButtons = append([]Button{i.lastButton}, i.ActionButtons...)
 	i.addFooterHTML(action.FooterContent(ctx)).
 		addCallback(action.GetCallbacks())
 	return i
@@ -1137,6 +1305,66 @@ func (i *InfoPanel) SetExportProcessFn(fn ExportProcessFn) *InfoPanel {
 	return i
 }
 
+// SetExportLimit 设置导出全部时的最大行数，超出的行被截断并在响应头中给出警告
+// 参数:
+//   - limit: 最大行数，为0时不限制
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) SetExportLimit(limit int) *InfoPanel {
+	i.ExportLimit = limit
+	return i
+}
+
+// SetExportChunkSize 设置导出全部时每次从数据库读取的行数，
+// 数据分批读取和写入，内存占用不随导出的行数增长
+// 参数:
+//   - size: 每次读取的行数
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) SetExportChunkSize(size int) *InfoPanel {
+	if size > 0 {
+		i.ExportChunkSize = size
+	}
+	return i
+}
+
+// SetQuery 设置列表的数据来源为查询语句，用于多表统计等无法对应到一张表的列表。
+// 查询作为以 SetTable 设置的表名命名的子查询，筛选、排序、分页和导出在其外层进行，
+// 字段使用查询结果的列名；查询结果不含主键时不查询主键，并隐藏详情按钮
+// 参数:
+//   - query: 查询语句，如 "SELECT user_id, count(*) AS total FROM orders WHERE status = ? GROUP BY user_id"
+//   - args: 查询语句中占位符的参数
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) SetQuery(query string, args ...interface{}) *InfoPanel {
+	i.Query = query
+	i.QueryArgs = args
+	return i
+}
+
+// SetDisplayWorkers 设置并行处理行的显示函数，行的顺序不变。
+// 显示函数计算较多（如大量计算列）的列表使用，显示函数需要可以并发调用
+// 参数:
+//   - workers: 协程数，小于2时按顺序处理
+//   - timeout: 可选的时限，超过时限时不再等待剩余的行，未完成的行只显示主键且不显示操作按钮，
+//     显示函数可以通过FieldModel.Context得知超时并提前返回
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) SetDisplayWorkers(workers int, timeout ...time.Duration) *InfoPanel {
+	i.DisplayWorkers = workers
+	if len(timeout) > 0 {
+		i.DisplayTimeout = timeout[0]
+	}
+	return i
+}
+
+// ExportAsCSV 设置导出为CSV文件，CSV文件边读取边写入响应
+// 返回: 更新后的信息面板
+func (i *InfoPanel) ExportAsCSV() *InfoPanel {
+	i.ExportCSV = true
+	return i
+}
+
 // SetDeleteHook 设置删除钩子
 // 参数:
 //   - fn: 删除函数
@@ -1272,10 +1500,10 @@ func (i *InfoPanel) AddColumnButtons(ctx *context.Context, head string, buttons
 		btnContent, btnJs := btn.Content(ctx)
 		content += btnContent
 		js += template.HTML(btnJs)
-		i.FooterHtml += template.HTML(ParseTableDataTmpl(btn.GetAction().FooterContent(ctx)))
+		i.addFooterHTML(btn.GetAction().FooterContent(ctx))
 		i.Callbacks = i.Callbacks.AddCallback(btn.GetAction().GetCallbacks())
 	}
-	i.FooterHtml += template.HTML("<script>") + template.HTML(ParseTableDataTmpl(js)) + template.HTML("</script>")
+	i.addFooterHTML(template.HTML("<script>") + js + template.HTML("</script>"))
 	i.FieldList = append(i.FieldList, Field{
 		Head:     head,
 		Field:    head,
@@ -1377,6 +1605,32 @@ func (i *InfoPanel) FieldDisplay(filter FieldFilterFn) *InfoPanel {
 	return i
 }
 
+// FieldDisplayByName 使用已注册的显示函数生成器设置字段的显示方式
+// 生成器的 JS 和 HTML 在每个信息面板中只添加一次，未注册的名称会记录错误并忽略
+// 参数:
+//   - name: 通过 RegisterDisplayGenerator 注册的生成器名称
+//   - args: 传给生成器 Get 方法的参数
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) FieldDisplayByName(name string, args ...interface{}) *InfoPanel {
+	gen, ok := GetDisplayGenerator(name)
+	if !ok {
+		logger.Error("display function generator is not registered: ", name)
+		return i
+	}
+	i.addDisplayChains(gen.Get(i.Ctx, args...))
+	if _, ok := i.DisplayGeneratorRecords[name]; !ok {
+		if js := gen.JS(); js != "" {
+			i.addFooterHTML(`<script>` + js + `</script>`)
+		}
+		if html := gen.HTML(); html != "" {
+			i.addFooterHTML(html)
+		}
+		i.DisplayGeneratorRecords[name] = struct{}{}
+	}
+	return i
+}
+
 // FieldLabelParam 是字段标签参数结构体
 type FieldLabelParam struct {
 	Color template.HTML // 颜色
@@ -1389,8 +1643,7 @@ type FieldLabelParam struct {
 //
 // 返回: 更新后的信息面板
 func (i *InfoPanel) FieldLabel(args ...FieldLabelParam) *InfoPanel {
-	i.addDisplayChains(displayFnGens["label"].Get(i.Ctx, args))
-	return i
+	return i.FieldDisplayByName("label", args)
 }
 
 // FieldImage 设置字段为图片显示
@@ -1401,8 +1654,7 @@ func (i *InfoPanel) FieldLabel(args ...FieldLabelParam) *InfoPanel {
 //
 // 返回: 更新后的信息面板
 func (i *InfoPanel) FieldImage(width, height string, prefix ...string) *InfoPanel {
-	i.addDisplayChains(displayFnGens["image"].Get(i.Ctx, width, height, prefix))
-	return i
+	return i.FieldDisplayByName("image", width, height, prefix)
 }
 
 // FieldBool 设置字段为布尔值显示
@@ -1411,8 +1663,7 @@ func (i *InfoPanel) FieldImage(width, height string, prefix ...string) *InfoPane
 //
 // 返回: 更新后的信息面板
 func (i *InfoPanel) FieldBool(flags ...string) *InfoPanel {
-	i.addDisplayChains(displayFnGens["bool"].Get(i.Ctx, flags))
-	return i
+	return i.FieldDisplayByName("bool", flags)
 }
 
 // FieldLink 设置字段为链接显示
@@ -1422,15 +1673,13 @@ func (i *InfoPanel) FieldBool(flags ...string) *InfoPanel {
 //
 // 返回: 更新后的信息面板
 func (i *InfoPanel) FieldLink(src string, openInNewTab ...bool) *InfoPanel {
-	i.addDisplayChains(displayFnGens["link"].Get(i.Ctx, src, openInNewTab))
-	return i
+	return i.FieldDisplayByName("link", src, openInNewTab)
 }
 
 // FieldFileSize 设置字段为文件大小显示
 // 返回: 更新后的信息面板
 func (i *InfoPanel) FieldFileSize() *InfoPanel {
-	i.addDisplayChains(displayFnGens["filesize"].Get(i.Ctx))
-	return i
+	return i.FieldDisplayByName("filesize")
 }
 
 // FieldDate 设置字段为日期显示
@@ -1439,8 +1688,7 @@ func (i *InfoPanel) FieldFileSize() *InfoPanel {
 //
 // 返回: 更新后的信息面板
 func (i *InfoPanel) FieldDate(format string) *InfoPanel {
-	i.addDisplayChains(displayFnGens["date"].Get(i.Ctx, format))
-	return i
+	return i.FieldDisplayByName("date", format)
 }
 
 // FieldIcon 设置字段为图标显示
@@ -1450,8 +1698,7 @@ func (i *InfoPanel) FieldDate(format string) *InfoPanel {
 //
 // 返回: 更新后的信息面板
 func (i *InfoPanel) FieldIcon(icons map[string]string, defaultIcon string) *InfoPanel {
-	i.addDisplayChains(displayFnGens["link"].Get(i.Ctx, icons, defaultIcon))
-	return i
+	return i.FieldDisplayByName("icon", icons, defaultIcon)
 }
 
 // FieldDotColor 是字段点颜色类型
@@ -1471,8 +1718,7 @@ const (
 //
 // 返回: 更新后的信息面板
 func (i *InfoPanel) FieldDot(icons map[string]FieldDotColor, defaultDot FieldDotColor) *InfoPanel {
-	i.addDisplayChains(displayFnGens["dot"].Get(i.Ctx, icons, defaultDot))
-	return i
+	return i.FieldDisplayByName("dot", icons, defaultDot)
 }
 
 // FieldProgressBarData 是进度条数据结构体
@@ -1488,7 +1734,174 @@ type FieldProgressBarData struct {
 //
 // 返回: 更新后的信息面板
 func (i *InfoPanel) FieldProgressBar(data ...FieldProgressBarData) *InfoPanel {
-	i.addDisplayChains(displayFnGens["progressbar"].Get(i.Ctx, data))
+	return i.FieldDisplayByName("progressbar", data)
+}
+
+// FieldSparklineParam 是迷你折线图参数结构体
+type FieldSparklineParam struct {
+	Width  int                              // 宽度，默认 100
+	Height int                              // 高度，默认 24
+	Color  string                           // 线条颜色，默认 #3c8dbc
+	Values func(value FieldModel) []float64 // 可选的取值函数，如从关联表中查询，默认解析字段值
+}
+
+// FieldSparkline 设置字段为迷你折线图显示
+// 字段值为 JSON 数组或逗号分隔的数值
+// 参数:
+//   - param: 可选的折线图参数
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) FieldSparkline(param ...FieldSparklineParam) *InfoPanel {
+	return i.FieldDisplayByName("sparkline", param)
+}
+
+// FieldRatingParam 是星级评分参数结构体
+type FieldRatingParam struct {
+	Max   int    // 星星数量，默认 5
+	Color string // 星星颜色，默认 #f39c12
+}
+
+// FieldRating 设置字段为星级评分显示
+// 参数:
+//   - param: 可选的评分参数
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) FieldRating(param ...FieldRatingParam) *InfoPanel {
+	return i.FieldDisplayByName("rating", param)
+}
+
+// FieldColorSwatch 设置字段为颜色色块显示
+// 参数:
+//   - showValue: 是否在色块后显示十六进制颜色值，默认显示
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) FieldColorSwatch(showValue ...bool) *InfoPanel {
+	return i.FieldDisplayByName("color", len(showValue) == 0 || showValue[0])
+}
+
+// FieldTags 设置字段为标签列表显示
+// 字段值为 JSON 数组或逗号分隔的标签
+// 参数:
+//   - param: 可选的标签参数
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) FieldTags(param ...FieldLabelParam) *InfoPanel {
+	return i.FieldDisplayByName("tags", param)
+}
+
+// FieldTagsFilterable 设置字段为可按标签筛选，筛选条件为包含所选的标签
+// 可选的标签来自表中已有的值
+// 参数:
+//   - param: 可选的标签参数，只使用 Table
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) FieldTagsFilterable(param ...FieldTagsParam) *InfoPanel {
+	table := i.Table
+	if len(param) > 0 && param[0].Table != "" {
+		table = param[0].Table
+	}
+	i.FieldFilterable(FilterType{FormType: form.SelectSingle, Operator: FilterOperatorHasTag})
+	filters := i.FieldList[i.curFieldListIndex].FilterFormFields
+	filters[len(filters)-1].OptionExt = `{"allowClear": "true"}`
+	filters[len(filters)-1].OptionTable = OptionTable{
+		Table:      table,
+		TextField:  i.FieldList[i.curFieldListIndex].Field,
+		ValueField: i.FieldList[i.curFieldListIndex].Field,
+		ProcessFn:  tagOptions,
+	}
+	return i
+}
+
+// FieldRangeSliderFilterable 设置字段为可按范围滑块筛选，筛选条件为 between 所选的范围
+// 适用于价格、年龄等数值字段，未配置边界时通过聚合查询获取字段的最小值和最大值
+// 参数:
+//   - param: 可选的范围滑块参数
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) FieldRangeSliderFilterable(param ...FieldRangeSliderParam) *InfoPanel {
+	var p FieldRangeSliderParam
+	if len(param) > 0 {
+		p = param[0]
+	}
+	if p.Table == "" {
+		p.Table = i.Table
+	}
+	if p.Field == "" {
+		p.Field = i.FieldList[i.curFieldListIndex].Field
+	}
+	i.FieldFilterable(FilterType{FormType: form.Slider, Operator: FilterOperatorBetween})
+	filters := i.FieldList[i.curFieldListIndex].FilterFormFields
+	filters[len(filters)-1].RangeSlider = &p
+	return i
+}
+
+// FieldPhone 设置字段为电话号码显示，点击即可拨号
+// 参数:
+//   - param: 可选的显示参数，脱敏显示时不生成链接
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) FieldPhone(param ...FieldContactParam) *InfoPanel {
+	return i.FieldDisplayByName("phone", param)
+}
+
+// FieldEmail 设置字段为邮箱地址显示，点击即可发送邮件
+// 参数:
+//   - param: 可选的显示参数，脱敏显示时不生成链接
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) FieldEmail(param ...FieldContactParam) *InfoPanel {
+	return i.FieldDisplayByName("email", param)
+}
+
+// FieldCron 设置字段为 cron 表达式的可读描述，鼠标悬停时显示接下来的运行时间
+// 返回: 更新后的信息面板
+func (i *InfoPanel) FieldCron() *InfoPanel {
+	return i.FieldDisplayByName("cron")
+}
+
+// FieldSignature 设置字段为签名图片显示，图片地址由存储配置生成
+// 参数:
+//   - width: 可选的宽度，默认为 200px
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) FieldSignature(width ...string) *InfoPanel {
+	return i.FieldDisplayByName("signature", width)
+}
+
+// FieldCode 设置字段为只读的代码显示，页面加载了代码编辑器时高亮显示
+// 参数:
+//   - language: 可选的语言，如 html、sql、json，默认为 text
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) FieldCode(language ...string) *InfoPanel {
+	return i.FieldDisplayByName("code", language)
+}
+
+// FieldDuration 设置字段为时长显示，如 "2h 13m"
+// 参数:
+//   - unit: 可选的字段值单位，默认为秒
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) FieldDuration(unit ...time.Duration) *InfoPanel {
+	if len(unit) > 0 && unit[0] > 0 {
+		return i.FieldDisplayByName("duration", unit[0])
+	}
+	return i.FieldDisplayByName("duration", time.Second)
+}
+
+// FieldTimeAgo 设置字段为相对时间显示，如 "3 days ago"，页面中每分钟自动更新
+// 字段值为 Unix 时间戳（秒）或日期时间字符串
+// 返回: 更新后的信息面板
+func (i *InfoPanel) FieldTimeAgo() *InfoPanel {
+	i.FieldDisplayByName("timeago")
+	if _, ok := i.DisplayGeneratorRecords["timeago_locale"]; !ok {
+		lang := ""
+		if i.Ctx != nil {
+			lang = i.Ctx.Lang()
+		}
+		i.addFooterHTML(`<script>window.goAdminTimeAgoLocale = ` + template.HTML(timeAgoLocaleJS(lang)) + `;</script>`)
+		i.DisplayGeneratorRecords["timeago_locale"] = struct{}{}
+	}
 	return i
 }
 
@@ -1498,8 +1911,7 @@ func (i *InfoPanel) FieldProgressBar(data ...FieldProgressBarData) *InfoPanel {
 //
 // 返回: 更新后的信息面板
 func (i *InfoPanel) FieldLoading(data []string) *InfoPanel {
-	i.addDisplayChains(displayFnGens["loading"].Get(i.Ctx, data))
-	return i
+	return i.FieldDisplayByName("loading", data)
 }
 
 // FieldDownLoadable 设置字段为可下载显示
@@ -1508,8 +1920,7 @@ func (i *InfoPanel) FieldLoading(data []string) *InfoPanel {
 //
 // 返回: 更新后的信息面板
 func (i *InfoPanel) FieldDownLoadable(prefix ...string) *InfoPanel {
-	i.addDisplayChains(displayFnGens["downloadable"].Get(i.Ctx, prefix))
-	return i
+	return i.FieldDisplayByName("downloadable", prefix)
 }
 
 // FieldCopyable 设置字段为可复制显示
@@ -1518,12 +1929,7 @@ func (i *InfoPanel) FieldDownLoadable(prefix ...string) *InfoPanel {
 //
 // 返回: 更新后的信息面板
 func (i *InfoPanel) FieldCopyable(prefix ...string) *InfoPanel {
-	i.addDisplayChains(displayFnGens["copyable"].Get(i.Ctx, prefix))
-	if _, ok := i.DisplayGeneratorRecords["copyable"]; !ok {
-		i.addFooterHTML(`<script>` + displayFnGens["copyable"].JS() + `</script>`)
-		i.DisplayGeneratorRecords["copyable"] = struct{}{}
-	}
-	return i
+	return i.FieldDisplayByName("copyable", prefix)
 }
 
 // FieldGetImgArrFn 是获取图片数组函数类型
@@ -1536,19 +1942,13 @@ type FieldGetImgArrFn func(value string) []string
 //
 // 返回: 更新后的信息面板
 func (i *InfoPanel) FieldCarousel(fn FieldGetImgArrFn, size ...int) *InfoPanel {
-	i.addDisplayChains(displayFnGens["carousel"].Get(i.Ctx, fn, size))
-	return i
+	return i.FieldDisplayByName("carousel", fn, size)
 }
 
 // FieldQrcode 设置字段为二维码显示
 // 返回: 更新后的信息面板
 func (i *InfoPanel) FieldQrcode() *InfoPanel {
-	i.addDisplayChains(displayFnGens["qrcode"].Get(i.Ctx))
-	if _, ok := i.DisplayGeneratorRecords["qrcode"]; !ok {
-		i.addFooterHTML(`<script>` + displayFnGens["qrcode"].JS() + `</script>`)
-		i.DisplayGeneratorRecords["qrcode"] = struct{}{}
-	}
-	return i
+	return i.FieldDisplayByName("qrcode")
 }
 
 // FieldWidth 设置字段宽度
@@ -1636,6 +2036,7 @@ type FilterType struct {
 	InputWidth  int
 	NoHead      bool
 	NoIcon      bool
+	Datetime    *FilterDatetime
 }
 
 // FieldFilterable set a field filterable which will display in the filter box.
@@ -1670,6 +2071,7 @@ func (i *InfoPanel) FieldFilterable(filterType ...FilterType) *InfoPanel {
 		ff.ProcessFn = filter.Process
 		ff.Placeholder = modules.AorB(filter.Placeholder == "", language.Get("input")+" "+ff.Head, filter.Placeholder)
 		ff.Options = filter.Options
+		ff.Datetime = filter.Datetime
 		if len(filter.OptionExt) > 0 {
 			s, _ := json.Marshal(filter.OptionExt)
 			ff.OptionExt = template.JS(s)
@@ -1712,6 +2114,21 @@ func (i *InfoPanel) FieldFilterOptionsFromTable(table, textFieldName, valueField
 	return i
 }
 
+// FieldFilterOptionsFromTree set the options of a filterable field from a table holding a tree,
+// such as the categories referring to their parents by parent_id. The options are shown as
+// an indented multiple select, checking a parent checks all of its children, and the list is
+// filtered by the checked options and all of their descendants. For example,
+//
+//	`FieldFilterOptionsFromTree("categories", "name", "id", "parent_id")`
+func (i *InfoPanel) FieldFilterOptionsFromTree(table, textFieldName, valueFieldName, parentFieldName string,
+	process ...OptionTableQueryProcessFn) *InfoPanel {
+	i.FieldFilterOptionsFromTable(table, textFieldName, valueFieldName, process...)
+	i.FieldList[i.curFieldListIndex].FilterFormFields[0].OptionTable.ParentField = parentFieldName
+	i.FieldList[i.curFieldListIndex].FilterFormFields[0].Type = form.Select
+	i.FieldList[i.curFieldListIndex].FilterFormFields[0].Operator = FilterOperatorTree
+	return i
+}
+
 // FieldFilterOptionExt set the option extension js of the field.
 func (i *InfoPanel) FieldFilterOptionExt(m map[string]interface{}) *InfoPanel {
 	s, _ := json.Marshal(m)
@@ -1905,6 +2322,14 @@ func (i *InfoPanel) FieldXssFilter() *InfoPanel {
 
 func (i *InfoPanel) SetTable(table string) *InfoPanel {
 	i.Table = table
+	// the options of the tag filters added before are from the table.
+	for k := range i.FieldList {
+		for j := range i.FieldList[k].FilterFormFields {
+			if ot := &i.FieldList[k].FilterFormFields[j].OptionTable; ot.Table == "" && ot.ValueField != "" {
+				ot.Table = table
+			}
+		}
+	}
 	return i
 }
 
@@ -1955,6 +2380,12 @@ func (i *InfoPanel) SetDescription(desc string) *InfoPanel {
 	return i
 }
 
+// SetCategory 设置表格的分类，自动生成菜单时列表页的菜单项归入分类的顶级菜单
+func (i *InfoPanel) SetCategory(category string) *InfoPanel {
+	i.Category = category
+	return i
+}
+
 func (i *InfoPanel) SetFilterFormLayout(layout form.Layout) *InfoPanel {
 	i.FilterFormLayout = layout
 	return i
@@ -2047,6 +2478,63 @@ func (i *InfoPanel) Set500Error(content ...template.HTML) *InfoPanel {
 	return i
 }
 
+// SetGridEdit 启用表格编辑模式，用户可以将Excel中复制的区域粘贴到可编辑字段，
+// 预览修改后批量提交
+func (i *InfoPanel) SetGridEdit() *InfoPanel {
+	i.GridEdit = true
+	return i
+}
+
+// SetFindReplace 启用查找替换，拥有find_replace权限的用户可以对当前筛选结果中的
+// 某一文本列执行查找替换，预览受影响的记录后在一个事务中提交，并记录操作日志
+func (i *InfoPanel) SetFindReplace() *InfoPanel {
+	i.FindReplace = true
+	return i
+}
+
+// EnableRowReorder 启用拖拽排序，在排序字段的列中显示拖拽手柄，拖拽后保存新的顺序
+// 只交换当前页中可见的行的排序值，筛选范围以外的行的位置不变
+// 参数:
+//   - field: 保存顺序的整数字段，不在列表中时添加该列
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) EnableRowReorder(field string) *InfoPanel {
+	i.RowReorderField = field
+
+	for k := range i.FieldList {
+		if i.FieldList[k].Field == field && !i.FieldList[k].Joins.Valid() {
+			cur := i.curFieldListIndex
+			i.curFieldListIndex = k
+			i.FieldDisplayByName("row_reorder")
+			i.curFieldListIndex = cur
+			return i
+		}
+	}
+	return i.AddField(language.Get("sort"), field, db.Int).FieldDisplayByName("row_reorder")
+}
+
+// EnableFavorite 启用收藏，在列表的行和详情页中显示星标，用户可以收藏记录，
+// 并通过列表中的“我的收藏”按钮只显示自己收藏的记录
+// 参数:
+//   - field: 显示星标的字段，其值作为收藏记录的标题
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) EnableFavorite(field string) *InfoPanel {
+	i.FavoriteField = field
+	return i
+}
+
+// EnableDataSync 启用数据同步，选中的记录及其关联的选项表记录可以导出为数据包，
+// 导入到其他环境时按字段匹配记录并重新映射主键和外键，导入前可以预览修改
+// 参数:
+//   - field: 匹配记录的字段，其值在各环境中唯一
+//
+// 返回: 更新后的信息面板
+func (i *InfoPanel) EnableDataSync(field string) *InfoPanel {
+	i.SyncField = field
+	return i
+}
+
 func (i *InfoPanel) HideNewButton() *InfoPanel {
 	i.IsHideNewButton = true
 	return i
@@ -2082,6 +2570,13 @@ func (i *InfoPanel) HideQueryInfo() *InfoPanel {
 	return i
 }
 
+// HideTotal 默认不查询总数，适合数据量大、统计总数较慢的表，用户可在分页处切换
+// 返回: 更新后的 InfoPanel 指针
+func (i *InfoPanel) HideTotal() *InfoPanel {
+	i.IsHideTotal = true
+	return i
+}
+
 func (i *InfoPanel) HideEditButton() *InfoPanel {
 	i.IsHideEditButton = true
 	return i
@@ -2110,7 +2605,10 @@ func (i *InfoPanel) HideColumn(n int) *InfoPanel {
 }
 
 func (i *InfoPanel) addFooterHTML(footer template.HTML) *InfoPanel {
-	i.FooterHtml += template.HTML(ParseTableDataTmpl(footer))
+	if i.ScriptRecords == nil {
+		i.ScriptRecords = make(ScriptRegistry)
+	}
+	i.FooterHtml += i.ScriptRecords.Once(template.HTML(ParseTableDataTmpl(footer)))
 	return i
 }
 
@@ -2133,11 +2631,13 @@ func (i *InfoPanel) addCallback(node context.Node) *InfoPanel {
 
 func (i *InfoPanel) addButton(btn Button) *InfoPanel {
 	i.Buttons = append(i.Buttons, btn)
+	i.lastButton = btn
 	return i
 }
 
 func (i *InfoPanel) addActionButton(btn Button) *InfoPanel {
 	i.ActionButtons = append(i.ActionButtons, btn)
+	i.lastButton = btn
 	return i
 }
 
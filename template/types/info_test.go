@@ -1 +1,142 @@
 package types
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/models"
+	"github.com/purpose168/GoAdmin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWhereRaw_Statement(t *testing.T) {
+	cases := []struct {
+		raw, wheres, want string
+	}{
+		{"id = 1", "", "id = 1 "},
+		{"and id = 1", "", " id = 1 "},
+		{"  OR (id = 1)", "", " (id = 1) "},
+		{"order_id = 1", "", "order_id = 1 "},
+		{"android = 1", "", "android = 1 "},
+		{"or_id = 1", "name = ? ", "name = ?  and or_id = 1 "},
+		{"or id = 1", "name = ? ", "name = ? or id = 1 "},
+		{"　and id = 1", "", " id = 1 "},
+		{"andé = 1", "", "andé = 1 "},
+		{"and", "", " "},
+	}
+
+	for _, c := range cases {
+		got, _ := WhereRaw{Raw: c.raw}.Statement(c.wheres, nil)
+		assert.Equal(t, c.want, got, c.raw)
+	}
+}
+
+func FuzzWhereRaw_Statement(f *testing.F) {
+	for _, seed := range []string{"", "and", "or", "id = 1", "and id = 1", " OR(id=1)", "order = 1", "an", "o", "\xff and", " or x"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, raw string) {
+		index := WhereRaw{Raw: raw}.check()
+		if index < 0 || index > len(raw) {
+			t.Fatalf("index %d out of range of %q", index, raw)
+		}
+		if index == 0 {
+			return
+		}
+		// only a leading keyword is removed.
+		word := strings.ToLower(strings.TrimSpace(raw[:index]))
+		if word != "and" && word != "or" {
+			t.Fatalf("%q is removed from %q", raw[:index], raw)
+		}
+
+		got, _ := WhereRaw{Raw: raw}.Statement("", nil)
+		if got != raw[index:]+" " {
+			t.Fatalf("wrong statement %q of %q", got, raw)
+		}
+	})
+}
+
+func TestInfoPanel_SetButtonPermission(t *testing.T) {
+	var called int
+	btn := &DefaultButton{BaseButton: &BaseButton{Id: "export", Url: "/admin/operation/export", Method: "post"}}
+	info := NewInfoPanel(nil, "id").addButton(btn)
+	info.Callbacks = info.Callbacks.AddCallback(context.Node{
+		Path:     "/admin/operation/export",
+		Method:   "post",
+		Handlers: []context.Handler{func(ctx *context.Context) { called++ }},
+	})
+
+	info.SetButtonPermission("users.export").
+		SetButtonPermissionFn(func(user models.UserModel) bool { return user.Id != 2 })
+	assert.Equal(t, 1, len(info.Callbacks[0].Handlers))
+
+	user := func(id int64, slugs ...string) models.UserModel {
+		u := models.UserModel{Id: id}
+		for _, slug := range slugs {
+			u.Permissions = append(u.Permissions, models.PermissionModel{Slug: slug, HttpMethod: []string{"POST"}, HttpPath: []string{"*"}})
+		}
+		return u
+	}
+	call := func(u models.UserModel) int {
+		ctx := context.NewContext(httptest.NewRequest(http.MethodPost, "/admin/operation/export", nil))
+		ctx.SetUserValue("user", u)
+		for _, handler := range info.Callbacks[0].Handlers {
+			handler(ctx)
+		}
+		return ctx.Response.StatusCode
+	}
+
+	assert.Equal(t, 0, len(info.Buttons.CheckPermissionWhenURLAndMethodNotEmpty(user(1))))
+	assert.Equal(t, http.StatusForbidden, call(user(1)))
+	assert.Equal(t, 0, called)
+
+	assert.Equal(t, 0, len(info.Buttons.CheckPermissionWhenURLAndMethodNotEmpty(user(2, "users.export"))))
+	assert.Equal(t, http.StatusForbidden, call(user(2, "users.export")))
+	assert.Equal(t, 0, called)
+
+	assert.Equal(t, 1, len(info.Buttons.CheckPermissionWhenURLAndMethodNotEmpty(user(1, "users.export"))))
+	call(user(1, "users.export"))
+	assert.Equal(t, 1, called)
+}
+
+func TestInfoPanel_Clone(t *testing.T) {
+	info := NewInfoPanel(nil, "id")
+	info.AddField("Status", "status", db.Varchar).
+		FieldFilterable(FilterType{FormType: form.SelectSingle}).
+		FieldFilterOptions(FieldOptions{{Text: "on", Value: "1"}, {Text: "off", Value: "0"}}).
+		FieldEditOptions(FieldOptions{{Text: "on", Value: "1"}})
+
+	c := info.Clone(nil)
+	c.FieldList[0].FilterFormFields[0].Options.SetSelected("1", []template.HTML{"selected", ""})
+	c.FieldList[0].EditOptions[0].Selected = true
+
+	assert.Equal(t, true, c.FieldList[0].FilterFormFields[0].Options[0].Selected)
+	assert.Equal(t, false, info.FieldList[0].FilterFormFields[0].Options[0].Selected)
+	assert.Equal(t, false, info.FieldList[0].EditOptions[0].Selected)
+}
+
+func TestInfoPanel_AddNavButton(t *testing.T) {
+	info := NewInfoPanel(nil, "id").AddNavButton("Sync now", "fa-refresh", NewDefaultAction("", "", "", ""))
+	assert.Equal(t, 1, len(info.NavButtons))
+	assert.Equal(t, 1, len(info.Clone(nil).NavButtons))
+
+	panel := Panel{}.AddNavButton("Sync now", "fa-refresh", NewDefaultAction("", "", "", ""))
+	assert.Equal(t, 1, len(panel.NavButtons))
+
+	btn := GetNavButton("Products", "fa-cube", NewDefaultAction("", "", "", "")).
+		SetVisibleFn(func(ctx *context.Context) bool {
+			return strings.HasPrefix(ctx.Path(), "/admin/info/products")
+		})
+	btns := Buttons{btn, panel.NavButtons[0]}
+
+	ctx := context.NewContext(httptest.NewRequest(http.MethodGet, "/admin/info/products", nil))
+	assert.Equal(t, 2, len(btns.Visible(ctx)))
+	ctx = context.NewContext(httptest.NewRequest(http.MethodGet, "/admin/info/users", nil))
+	assert.Equal(t, Buttons{panel.NavButtons[0]}, btns.Visible(ctx))
+}
@@ -0,0 +1,181 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	form2 "github.com/purpose168/GoAdmin/template/types/form"
+)
+
+// InputLocale 是表单提交的数字和日期的格式，提交的值在验证和保存前转换为数据库的格式，
+// 如 "1.234,56" 转换为 "1234.56"，"31/12/2025" 转换为 "2025-12-31"
+type InputLocale struct {
+	DecimalSeparator   string   // 小数点，如 ","
+	ThousandsSeparator string   // 千位分隔符，如 "."，空白字符总是被去除
+	DateLayouts        []string // 日期的格式，Go 的时间格式，如 "02/01/2006"
+	DatetimeLayouts    []string // 日期时间的格式，如 "02/01/2006 15:04"
+}
+
+// 常用的输入格式
+var (
+	InputLocaleDE = InputLocale{
+		DecimalSeparator:   ",",
+		ThousandsSeparator: ".",
+		DateLayouts:        []string{"02.01.2006", "2.1.2006"},
+		DatetimeLayouts:    []string{"02.01.2006 15:04:05", "02.01.2006 15:04"},
+	}
+	InputLocaleFR = InputLocale{
+		DecimalSeparator:   ",",
+		ThousandsSeparator: " ",
+		DateLayouts:        []string{"02/01/2006", "2/1/2006"},
+		DatetimeLayouts:    []string{"02/01/2006 15:04:05", "02/01/2006 15:04"},
+	}
+	InputLocalePtBR = InputLocale{
+		DecimalSeparator:   ",",
+		ThousandsSeparator: ".",
+		DateLayouts:        []string{"02/01/2006", "2/1/2006"},
+		DatetimeLayouts:    []string{"02/01/2006 15:04:05", "02/01/2006 15:04"},
+	}
+	InputLocaleEnUS = InputLocale{
+		DecimalSeparator:   ".",
+		ThousandsSeparator: ",",
+		DateLayouts:        []string{"01/02/2006", "1/2/2006"},
+		DatetimeLayouts:    []string{"01/02/2006 15:04:05", "01/02/2006 15:04", "01/02/2006 3:04 PM"},
+	}
+	InputLocaleEnGB = InputLocale{
+		DecimalSeparator:   ".",
+		ThousandsSeparator: ",",
+		DateLayouts:        []string{"02/01/2006", "2/1/2006"},
+		DatetimeLayouts:    []string{"02/01/2006 15:04:05", "02/01/2006 15:04"},
+	}
+)
+
+// 数据库的日期和日期时间格式
+const (
+	dbDateLayout     = "2006-01-02"
+	dbDatetimeLayout = "2006-01-02 15:04:05"
+)
+
+// ParseNumber 将按格式输入的数字转换为数据库的格式
+// 参数:
+//   - value: 输入的数字，如 "1.234,56"
+//
+// 返回: 数据库格式的数字，如 "1234.56"；不是数字时返回 false
+func (l InputLocale) ParseNumber(value string) (string, bool) {
+	value = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '\u00a0', '\u202f':
+			return -1
+		}
+		return r
+	}, value)
+	if sep := strings.TrimSpace(l.ThousandsSeparator); sep != "" {
+		value = strings.ReplaceAll(value, sep, "")
+	}
+	if l.DecimalSeparator != "" && l.DecimalSeparator != "." {
+		if strings.Contains(value, ".") {
+			return "", false
+		}
+		value = strings.Replace(value, l.DecimalSeparator, ".", 1)
+	}
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+// ParseDate 将按格式输入的日期或日期时间转换为数据库的格式
+// 参数:
+//   - value: 输入的日期，如 "31/12/2025"
+//   - withTime: 是否转换为日期时间
+//
+// 返回: 数据库格式的日期或日期时间；无法解析时返回 false。已是数据库格式的值被接受
+func (l InputLocale) ParseDate(value string, withTime bool) (string, bool) {
+	layouts := append(append([]string{}, l.DatetimeLayouts...), l.DateLayouts...)
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return formatDBDate(t, withTime), true
+		}
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return formatDBDate(t, withTime), true
+		}
+	}
+	return "", false
+}
+
+func formatDBDate(t time.Time, withTime bool) string {
+	if withTime {
+		return t.Format(dbDatetimeLayout)
+	}
+	return t.Format(dbDateLayout)
+}
+
+// SetInputLocale 设置表单提交的数字和日期的格式
+func (f *FormPanel) SetInputLocale(l InputLocale) *FormPanel {
+	f.InputLocale = &l
+	return f
+}
+
+// FieldInputLocale 设置字段提交的数字和日期的格式，优先于表单的格式
+func (f *FormPanel) FieldInputLocale(l InputLocale) *FormPanel {
+	f.FieldList[f.curFieldListIndex].InputLocale = &l
+	return f
+}
+
+// NormalizeInput 将提交的数字和日期转换为数据库的格式，在验证前调用。
+// 只转换设置了格式的字段，数字输入框提交的值已是数据库的格式，不转换
+// 参数:
+//   - values: 提交的值，转换后的值被直接写入
+//
+// 返回: 第一个无法解析的值的错误，错误信息以字段标题开头
+func (f *FormPanel) NormalizeInput(values form.Values) error {
+	for _, field := range f.FieldList {
+		locale := field.InputLocale
+		if locale == nil {
+			locale = f.InputLocale
+		}
+		if locale == nil {
+			continue
+		}
+		value := strings.TrimSpace(values.Get(field.Field))
+		if _, ok := values[field.Field]; !ok || value == "" {
+			continue
+		}
+
+		switch {
+		case field.FormType.IsDate() || field.TypeName == db.Date:
+			v, ok := locale.ParseDate(value, false)
+			if !ok {
+				return fmt.Errorf("%s: %s", field.Head, language.Get("invalid date"))
+			}
+			values.Add(field.Field, v)
+		case field.FormType.IsDateTime() || field.TypeName == db.Datetime || field.TypeName == db.Timestamp:
+			v, ok := locale.ParseDate(value, true)
+			if !ok {
+				return fmt.Errorf("%s: %s", field.Head, language.Get("invalid date"))
+			}
+			values.Add(field.Field, v)
+		case isNumberType(field.TypeName) && (field.FormType == form2.Default || field.FormType == form2.Text ||
+			field.FormType == form2.Currency):
+			v, ok := locale.ParseNumber(value)
+			if !ok {
+				return fmt.Errorf("%s: %s", field.Head, language.Get("invalid number"))
+			}
+			values.Add(field.Field, v)
+		}
+	}
+	return nil
+}
+
+// isNumberType 判断是否为数字的数据库类型
+func isNumberType(typ db.DatabaseType) bool {
+	return db.Contains(typ, db.IntTypeList) || db.Contains(typ, db.FloatTypeList) ||
+		(db.Contains(typ, db.UintTypeList) && typ != db.Bit)
+}
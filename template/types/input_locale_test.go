@@ -0,0 +1,84 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	form2 "github.com/purpose168/GoAdmin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInputLocale_ParseNumber(t *testing.T) {
+	for value, want := range map[string]string{
+		"1.234,56":  "1234.56",
+		"1.234.567": "1234567",
+		"-12,5":     "-12.5",
+		" 1 234,5 ": "1234.5",
+		"1 234":     "1234",
+		"12,5,3":    "",
+		"abc":       "",
+	} {
+		got, ok := InputLocaleDE.ParseNumber(value)
+		assert.Equal(t, want, got, value)
+		assert.Equal(t, want != "", ok, value)
+	}
+
+	got, ok := InputLocaleEnUS.ParseNumber("1,234.56")
+	assert.True(t, ok)
+	assert.Equal(t, "1234.56", got)
+
+	// the point is not a decimal separator of the french numbers.
+	_, ok = InputLocaleFR.ParseNumber("1234.56")
+	assert.False(t, ok)
+}
+
+func TestInputLocale_ParseDate(t *testing.T) {
+	got, ok := InputLocaleFR.ParseDate("31/12/2025", false)
+	assert.True(t, ok)
+	assert.Equal(t, "2025-12-31", got)
+
+	got, ok = InputLocaleFR.ParseDate("31/12/2025 08:30", true)
+	assert.True(t, ok)
+	assert.Equal(t, "2025-12-31 08:30:00", got)
+
+	got, ok = InputLocaleEnUS.ParseDate("12/31/2025", false)
+	assert.True(t, ok)
+	assert.Equal(t, "2025-12-31", got)
+
+	got, ok = InputLocaleDE.ParseDate("2025-12-31", false)
+	assert.True(t, ok)
+	assert.Equal(t, "2025-12-31", got)
+
+	_, ok = InputLocaleFR.ParseDate("31.12.2025", false)
+	assert.False(t, ok)
+}
+
+func TestFormPanel_NormalizeInput(t *testing.T) {
+	panel := NewFormPanel()
+	panel.AddField("Price", "price", db.Decimal, form2.Text)
+	panel.AddField("Count", "count", db.Int, form2.Number)
+	panel.AddField("Birthday", "birthday", db.Date, form2.Date)
+	panel.AddField("Due", "due", db.Datetime, form2.Datetime).FieldInputLocale(InputLocaleEnUS)
+	panel.AddField("Name", "name", db.Varchar, form2.Text)
+	panel.SetInputLocale(InputLocaleDE)
+
+	values := form.Values{
+		"price":    {"1.234,50"},
+		"count":    {"1234"},
+		"birthday": {"31.12.2025"},
+		"due":      {"12/31/2025 3:04 PM"},
+		"name":     {"1.234,50"},
+	}
+	assert.NoError(t, panel.NormalizeInput(values))
+	assert.Equal(t, "1234.50", values.Get("price"))
+	assert.Equal(t, "1234", values.Get("count"))
+	assert.Equal(t, "2025-12-31", values.Get("birthday"))
+	assert.Equal(t, "2025-12-31 15:04:00", values.Get("due"))
+	assert.Equal(t, "1.234,50", values.Get("name"))
+
+	err := panel.NormalizeInput(form.Values{"price": {"12,5,3"}})
+	assert.Error(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "Price: "))
+}
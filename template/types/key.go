@@ -0,0 +1,85 @@
+package types
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+)
+
+// KeyGenerator 是生成字段值的函数类型，如 UUID 和 ULID 主键
+type KeyGenerator func() string
+
+// crockford 是 ULID 使用的 Crockford Base32 字母表，不含 I、L、O、U
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// NewUUID 生成随机的 UUID（第 4 版），如 "9b2c7c1e-5f0a-4c3e-8d2b-6a1f0e9d8c7b"
+// 返回: 小写的 36 位 UUID 字符串
+func NewUUID() string {
+	return uuid.New().String()
+}
+
+// NewULID 生成 ULID，如 "01HZX3J8Q4S5T6V7W8X9Y0Z1A2"
+// 前 48 位为毫秒时间戳，后 80 位为随机数，按字符串排序即按生成时间排序，
+// 适合作为需要按新建顺序排序的主键；同一毫秒内生成的 ULID 之间的顺序不确定
+// 返回: 26 位的 ULID 字符串
+func NewULID() string {
+	return ulidAt(time.Now())
+}
+
+// ulidAt 生成指定时间的 ULID
+func ulidAt(t time.Time) string {
+	var b [16]byte
+	ms := uint64(t.UnixMilli())
+	b[0], b[1] = byte(ms>>40), byte(ms>>32)
+	binary.BigEndian.PutUint32(b[2:6], uint32(ms))
+	if _, err := rand.Read(b[6:]); err != nil {
+		panic(err)
+	}
+
+	// 128 位按 5 位一组从低位开始编码，最高的一位字符只有 3 位
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+	var out [26]byte
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+// FieldUUIDDefault 设置字段新建时的值为随机的 UUID，提交的值为空时生成
+// 一般用于 UUID 主键，数据库生成主键时（如 PostgreSQL 的 gen_random_uuid()）不需要设置
+// 返回: 更新后的 FormPanel 指针
+func (f *FormPanel) FieldUUIDDefault() *FormPanel {
+	return f.FieldGenerateDefault(NewUUID)
+}
+
+// FieldULIDDefault 设置字段新建时的值为 ULID，提交的值为空时生成，见 NewULID
+// 返回: 更新后的 FormPanel 指针
+func (f *FormPanel) FieldULIDDefault() *FormPanel {
+	return f.FieldGenerateDefault(NewULID)
+}
+
+// FieldGenerateDefault 设置字段新建时的值的生成函数，提交的值为空时生成
+// 参数:
+//   - fn: 生成函数
+//
+// 返回: 更新后的 FormPanel 指针
+func (f *FormPanel) FieldGenerateDefault(fn KeyGenerator) *FormPanel {
+	f.FieldList[f.curFieldListIndex].GenerateFn = fn
+	return f
+}
+
+// GenerateDefaults 为设置了生成函数且提交的值为空的字段生成值，新建记录时在验证之前调用
+// 参数:
+//   - values: 提交的表单值
+func (f *FormPanel) GenerateDefaults(values form.Values) {
+	for _, field := range f.FieldList {
+		if field.GenerateFn != nil && values.Get(field.Field) == "" {
+			values.Add(field.Field, field.GenerateFn())
+		}
+	}
+}
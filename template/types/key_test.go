@@ -0,0 +1,53 @@
+package types
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	form2 "github.com/purpose168/GoAdmin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUUID(t *testing.T) {
+	id := NewUUID()
+	u, err := uuid.Parse(id)
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.Version(4), u.Version())
+	assert.NotEqual(t, id, NewUUID())
+}
+
+func TestNewULID(t *testing.T) {
+	id := NewULID()
+	assert.Len(t, id, 26)
+	for _, c := range id {
+		assert.Contains(t, crockford, string(c))
+	}
+
+	// 时间戳为 0 时前 10 位为 0，ULID 按字符串排序即按时间排序
+	assert.Equal(t, "0000000000", ulidAt(time.UnixMilli(0))[:10])
+	assert.Equal(t, "7ZZZZZZZZZ", ulidAt(time.UnixMilli(1<<48 - 1))[:10])
+
+	base := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	ids := make([]string, 10)
+	for i := range ids {
+		ids[i] = ulidAt(base.Add(time.Duration(i) * time.Millisecond))
+	}
+	assert.True(t, sort.StringsAreSorted(ids))
+}
+
+func TestFormPanel_GenerateDefaults(t *testing.T) {
+	panel := NewFormPanel()
+	panel.AddField("ID", "id", db.UUID, form2.Default).FieldUUIDDefault()
+	panel.AddField("Code", "code", db.ULID, form2.Text).FieldULIDDefault()
+	panel.AddField("Name", "name", db.Varchar, form2.Text)
+
+	values := form.Values{"code": {"01HZX3J8Q4S5T6V7W8X9Y0Z1A2"}, "name": {"jack"}}
+	panel.GenerateDefaults(values)
+	assert.Len(t, values.Get("id"), 36)
+	assert.Equal(t, "01HZX3J8Q4S5T6V7W8X9Y0Z1A2", values.Get("code"))
+	assert.Equal(t, "jack", values.Get("name"))
+}
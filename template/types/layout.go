@@ -0,0 +1,269 @@
+// 版权所有 2019 GoAdmin 核心团队。保留所有权利。
+// 本源代码的使用受 Apache-2.0 风格许可证管辖
+// 该许可证可在 LICENSE 文件中找到。
+
+package types
+
+import (
+	"html/template"
+)
+
+// LayoutComponents 是组合布局所需的组件，template.Default(ctx) 即满足该接口，
+// 因此布局与主题无关
+type LayoutComponents interface {
+	Row() RowAttribute
+	Col() ColAttribute
+	Box() BoxAttribute
+	Tabs() TabsAttribute
+}
+
+// LayoutItem 是布局中的元素
+type LayoutItem interface {
+	// Render 使用组件渲染元素
+	Render(comp LayoutComponents) template.HTML
+}
+
+// Layout 使用行、列、卡片和标签页组合页面内容，例如：
+//
+//	content := types.NewLayout(tmpl.Default(ctx)).
+//	    Grid(4, types.Stat("用户", "2,349", icon.Users), types.Stat("订单", "760", icon.ShoppingCart)).
+//	    Row(
+//	        types.Column(types.SizeMD(8), types.Card("销售", types.HTML(chart))),
+//	        types.Column(types.SizeMD(4), types.NewTabs().Add("本周", types.HTML(week)).Add("本月", types.HTML(month))),
+//	    ).
+//	    GetContent()
+type Layout struct {
+	comp LayoutComponents
+	rows [][]*LayoutCol
+}
+
+// NewLayout 创建布局
+// 参数:
+//   - comp: 布局组件，通常为 template.Default(ctx)
+//
+// 返回: 布局
+func NewLayout(comp LayoutComponents) *Layout {
+	return &Layout{comp: comp}
+}
+
+// Row 添加一行
+// 参数:
+//   - cols: 行中的列
+//
+// 返回: 布局
+func (l *Layout) Row(cols ...*LayoutCol) *Layout {
+	l.rows = append(l.rows, cols)
+	return l
+}
+
+// Grid 添加一行等宽的列，每个元素占一列，在小屏幕上每行最多两列，在超小屏幕上每行一列
+// 参数:
+//   - cols: 每行的列数，取值 1 到 12
+//   - items: 元素
+//
+// 返回: 布局
+func (l *Layout) Grid(cols int, items ...LayoutItem) *Layout {
+	if cols < 1 {
+		cols = 1
+	}
+	if cols > 12 {
+		cols = 12
+	}
+	sm := 12 / cols
+	if cols > 2 {
+		sm = 6
+	}
+	row := make([]*LayoutCol, len(items))
+	for i, item := range items {
+		row[i] = Column(SizeXS(12).SM(sm).MD(12/cols), item)
+	}
+	return l.Row(row...)
+}
+
+// GetContent 获取布局的内容
+// 返回: HTML内容
+func (l *Layout) GetContent() template.HTML {
+	return l.Render(l.comp)
+}
+
+// Render 实现 LayoutItem.Render，因此布局可以嵌套在列、卡片和标签页中，
+// 嵌套的布局使用外层的组件，创建时可以传入 nil
+func (l *Layout) Render(comp LayoutComponents) template.HTML {
+	var content template.HTML
+	for _, cols := range l.rows {
+		var row template.HTML
+		for _, col := range cols {
+			row += col.Render(comp)
+		}
+		content += comp.Row().SetContent(row).GetContent()
+	}
+	return content
+}
+
+// GetPanel 获取布局的面板
+// 参数:
+//   - title: 标题
+//   - description: 描述
+//
+// 返回: 面板
+func (l *Layout) GetPanel(title, description template.HTML) Panel {
+	return Panel{
+		Content:     l.GetContent(),
+		Title:       title,
+		Description: description,
+	}
+}
+
+// LayoutCol 是布局中的列
+type LayoutCol struct {
+	size  S
+	items []LayoutItem
+}
+
+// Column 创建列，列中的元素依次排列
+// 参数:
+//   - size: 各屏幕尺寸下的列宽，为空时占满整行
+//   - items: 元素
+//
+// 返回: 列
+func Column(size S, items ...LayoutItem) *LayoutCol {
+	if len(size) == 0 {
+		size = SizeMD(12)
+	}
+	return &LayoutCol{size: size, items: items}
+}
+
+// Render 实现 LayoutItem.Render
+func (c *LayoutCol) Render(comp LayoutComponents) template.HTML {
+	var content template.HTML
+	for _, item := range c.items {
+		content += item.Render(comp)
+	}
+	return comp.Col().SetSize(c.size).SetContent(content).GetContent()
+}
+
+// HTML 将组件的内容或原始HTML作为布局元素
+// 参数:
+//   - content: HTML内容
+//
+// 返回: 布局元素
+func HTML(content template.HTML) LayoutItem {
+	return htmlItem(content)
+}
+
+type htmlItem template.HTML
+
+func (h htmlItem) Render(LayoutComponents) template.HTML { return template.HTML(h) }
+
+// CardItem 是卡片，即带有标题的盒子
+type CardItem struct {
+	title     template.HTML
+	footer    template.HTML
+	theme     string
+	noPadding bool
+	items     []LayoutItem
+}
+
+// Card 创建卡片
+// 参数:
+//   - title: 标题，为空时不显示头部
+//   - items: 卡片主体中的元素
+//
+// 返回: 卡片
+func Card(title template.HTML, items ...LayoutItem) *CardItem {
+	return &CardItem{title: title, items: items}
+}
+
+// SetFooter 设置页脚
+func (c *CardItem) SetFooter(footer template.HTML) *CardItem {
+	c.footer = footer
+	return c
+}
+
+// SetTheme 设置主题，如 primary、success、warning、danger
+func (c *CardItem) SetTheme(theme string) *CardItem {
+	c.theme = theme
+	return c
+}
+
+// SetNoPadding 设置主体无内边距，适用于表格等内容
+func (c *CardItem) SetNoPadding() *CardItem {
+	c.noPadding = true
+	return c
+}
+
+// Render 实现 LayoutItem.Render
+func (c *CardItem) Render(comp LayoutComponents) template.HTML {
+	var body template.HTML
+	for _, item := range c.items {
+		body += item.Render(comp)
+	}
+	box := comp.Box().SetBody(body)
+	if c.title != "" {
+		box = box.SetHeader(c.title).WithHeadBorder()
+	}
+	if c.footer != "" {
+		box = box.SetFooter(c.footer)
+	}
+	if c.theme != "" {
+		box = box.SetTheme(c.theme)
+	}
+	if c.noPadding {
+		box = box.SetNoPadding()
+	}
+	return box.GetContent()
+}
+
+// Stat 创建统计卡片，显示数值、说明和图标
+// 参数:
+//   - label: 说明
+//   - value: 数值，可包含HTML，如单位
+//   - icon: 图标，如 icon.Users，为空时不显示
+//
+// 返回: 卡片
+func Stat(label string, value template.HTML, icon string) *CardItem {
+	ico := template.HTML("")
+	if icon != "" {
+		ico = template.HTML(`<i class="fa ` + template.HTMLEscapeString(icon) +
+			` pull-right" style="font-size:40px;opacity:.3;"></i>`)
+	}
+	return Card("", HTML(ico+`<h3 style="margin:0 0 5px;">`+value+`</h3><p style="margin:0;">`+
+		template.HTML(template.HTMLEscapeString(label))+`</p>`))
+}
+
+// TabsItem 是标签页容器
+type TabsItem struct {
+	titles []template.HTML
+	items  [][]LayoutItem
+}
+
+// NewTabs 创建标签页容器
+// 返回: 标签页容器
+func NewTabs() *TabsItem {
+	return &TabsItem{}
+}
+
+// Add 添加标签页
+// 参数:
+//   - title: 标签页标题
+//   - items: 标签页中的元素
+//
+// 返回: 标签页容器
+func (t *TabsItem) Add(title template.HTML, items ...LayoutItem) *TabsItem {
+	t.titles = append(t.titles, title)
+	t.items = append(t.items, items)
+	return t
+}
+
+// Render 实现 LayoutItem.Render
+func (t *TabsItem) Render(comp LayoutComponents) template.HTML {
+	data := make([]map[string]template.HTML, len(t.titles))
+	for i, title := range t.titles {
+		var content template.HTML
+		for _, item := range t.items[i] {
+			content += item.Render(comp)
+		}
+		data[i] = map[string]template.HTML{"title": title, "content": content}
+	}
+	return comp.Tabs().SetData(data).GetContent()
+}
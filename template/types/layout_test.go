@@ -0,0 +1,78 @@
+package types
+
+import (
+	"html/template"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testComponents struct{}
+
+func (testComponents) Row() RowAttribute   { return &testRow{} }
+func (testComponents) Col() ColAttribute   { return &testCol{} }
+func (testComponents) Box() BoxAttribute   { return &testBox{} }
+func (testComponents) Tabs() TabsAttribute { return &testTabs{} }
+
+type testRow struct{ content template.HTML }
+
+func (r *testRow) SetContent(v template.HTML) RowAttribute { r.content = v; return r }
+func (r *testRow) AddContent(v template.HTML) RowAttribute { r.content += v; return r }
+func (r *testRow) GetContent() template.HTML               { return "<row>" + r.content + "</row>" }
+
+type testCol struct {
+	size    S
+	content template.HTML
+}
+
+func (c *testCol) SetSize(v S) ColAttribute                { c.size = v; return c }
+func (c *testCol) SetContent(v template.HTML) ColAttribute { c.content = v; return c }
+func (c *testCol) AddContent(v template.HTML) ColAttribute { c.content += v; return c }
+func (c *testCol) GetContent() template.HTML {
+	sizes := make([]string, 0, len(c.size))
+	for k, v := range c.size {
+		sizes = append(sizes, k+v)
+	}
+	sort.Strings(sizes)
+	return template.HTML("<col " + strings.Join(sizes, " ") + ">" + string(c.content) + "</col>")
+}
+
+type testBox struct {
+	BoxAttribute
+	header, body template.HTML
+}
+
+func (b *testBox) SetHeader(v template.HTML) BoxAttribute { b.header = v; return b }
+func (b *testBox) SetBody(v template.HTML) BoxAttribute   { b.body = v; return b }
+func (b *testBox) WithHeadBorder() BoxAttribute           { return b }
+func (b *testBox) GetContent() template.HTML {
+	return "<box " + b.header + ">" + b.body + "</box>"
+}
+
+type testTabs struct{ data []map[string]template.HTML }
+
+func (t *testTabs) SetData(v []map[string]template.HTML) TabsAttribute { t.data = v; return t }
+func (t *testTabs) GetContent() template.HTML {
+	var s template.HTML
+	for _, d := range t.data {
+		s += "<tab " + d["title"] + ">" + d["content"] + "</tab>"
+	}
+	return s
+}
+
+func TestLayout(t *testing.T) {
+	content := NewLayout(testComponents{}).
+		Grid(3, HTML("a"), HTML("b"), HTML("c")).
+		Row(
+			Column(SizeMD(8), Card("t", HTML("d"))),
+			Column(nil, NewTabs().Add("x", HTML("e")).Add("y", NewLayout(nil).Row(Column(nil, HTML("f"))))),
+		).
+		GetContent()
+
+	assert.Equal(t, string(content), "<row>"+
+		"<col md4 sm6 xs12>a</col><col md4 sm6 xs12>b</col><col md4 sm6 xs12>c</col></row>"+
+		"<row><col md8><box t>d</box></col>"+
+		"<col md12><tab x>e</tab><tab y><row><col md12>f</col></row></tab></col></row>")
+}
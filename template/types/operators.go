@@ -6,14 +6,19 @@ import "html/template"
 type FilterOperator string
 
 const (
-	FilterOperatorLike           FilterOperator = "like" // 模糊匹配操作符
-	FilterOperatorGreater        FilterOperator = ">"    // 大于操作符
-	FilterOperatorGreaterOrEqual FilterOperator = ">="   // 大于等于操作符
-	FilterOperatorEqual          FilterOperator = "="    // 等于操作符
-	FilterOperatorNotEqual       FilterOperator = "!="   // 不等于操作符
-	FilterOperatorLess           FilterOperator = "<"    // 小于操作符
-	FilterOperatorLessOrEqual    FilterOperator = "<="   // 小于等于操作符
-	FilterOperatorFree           FilterOperator = "free" // 自由操作符
+	FilterOperatorLike           FilterOperator = "like"    // 模糊匹配操作符
+	FilterOperatorGreater        FilterOperator = ">"       // 大于操作符
+	FilterOperatorGreaterOrEqual FilterOperator = ">="      // 大于等于操作符
+	FilterOperatorEqual          FilterOperator = "="       // 等于操作符
+	FilterOperatorNotEqual       FilterOperator = "!="      // 不等于操作符
+	FilterOperatorLess           FilterOperator = "<"       // 小于操作符
+	FilterOperatorLessOrEqual    FilterOperator = "<="      // 小于等于操作符
+	FilterOperatorFree           FilterOperator = "free"    // 自由操作符
+	FilterOperatorFuzzy          FilterOperator = "fuzzy"   // 全文索引模糊搜索操作符
+	FilterOperatorHasTag         FilterOperator = "has"     // 包含标签操作符，用于逗号分隔或 JSON 数组保存的标签
+	FilterOperatorTree           FilterOperator = "tree"    // 树形操作符，匹配选中节点及其所有子孙节点
+	FilterOperatorBetween        FilterOperator = "between" // 范围操作符，用于范围滑块筛选
+	FilterOperatorList           FilterOperator = "list"    // 列表操作符，匹配粘贴的值列表中的任意一个
 )
 
 // GetOperatorFromValue 根据值获取对应的筛选操作符
@@ -39,6 +44,16 @@ func GetOperatorFromValue(value string) FilterOperator {
 		return FilterOperatorLessOrEqual
 	case "free":
 		return FilterOperatorFree
+	case "fuzzy":
+		return FilterOperatorFuzzy
+	case "has":
+		return FilterOperatorHasTag
+	case "tree":
+		return FilterOperatorTree
+	case "between":
+		return FilterOperatorBetween
+	case "list":
+		return FilterOperatorList
 	default:
 		return FilterOperatorEqual
 	}
@@ -64,6 +79,16 @@ func (o FilterOperator) Value() string {
 		return "lq"
 	case FilterOperatorFree:
 		return "free"
+	case FilterOperatorFuzzy:
+		return "fuzzy"
+	case FilterOperatorHasTag:
+		return "has"
+	case FilterOperatorTree:
+		return "tree"
+	case FilterOperatorBetween:
+		return "between"
+	case FilterOperatorList:
+		return "list"
 	default:
 		return "eq"
 	}
@@ -76,10 +101,11 @@ func (o FilterOperator) String() string {
 }
 
 // Label 返回操作符的标签HTML
-// 对于like操作符返回空字符串，其他操作符返回其自身
+// 对于like、fuzzy、has、tree、between和list操作符返回空字符串，其他操作符返回其自身
 // 返回: 操作符标签HTML
 func (o FilterOperator) Label() template.HTML {
-	if o == FilterOperatorLike {
+	if o == FilterOperatorLike || o == FilterOperatorFuzzy || o == FilterOperatorHasTag ||
+		o == FilterOperatorTree || o == FilterOperatorBetween || o == FilterOperatorList {
 		return ""
 	}
 	return template.HTML(o)
@@ -97,7 +123,8 @@ func (o FilterOperator) AddOrNot() bool {
 func (o FilterOperator) Valid() bool {
 	switch o {
 	case FilterOperatorLike, FilterOperatorGreater, FilterOperatorGreaterOrEqual,
-		FilterOperatorLess, FilterOperatorLessOrEqual, FilterOperatorFree:
+		FilterOperatorLess, FilterOperatorLessOrEqual, FilterOperatorFree, FilterOperatorFuzzy, FilterOperatorHasTag,
+		FilterOperatorTree, FilterOperatorBetween, FilterOperatorList:
 		return true
 	default:
 		return false
@@ -5,14 +5,18 @@
 package types
 
 import (
-	"bytes"
+	"encoding/json"
 	"fmt"
+	"html"
 	"html/template"
+	"regexp"
 	"strconv"
+	"strings"
 	textTmpl "text/template"
 
 	"github.com/purpose168/GoAdmin/context"
 	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/language"
 	"github.com/purpose168/GoAdmin/modules/menu"
 	"github.com/purpose168/GoAdmin/modules/system"
 	"github.com/purpose168/GoAdmin/modules/utils"
@@ -86,6 +90,31 @@ type Page struct {
 	NavButtonsHTML template.HTML
 }
 
+// PageDecorator 用于装饰所有页面的面板，例如在页面顶部显示公告
+type PageDecorator func(ctx *context.Context, user models.UserModel, panel Panel) Panel
+
+var pageDecorators = make([]PageDecorator, 0)
+
+// AddPageDecorator 添加页面装饰器，需在服务启动前调用
+// 参数:
+//   - fn: 页面装饰器
+func AddPageDecorator(fn PageDecorator) {
+	pageDecorators = append(pageDecorators, fn)
+}
+
+// PageHTMLHook 返回追加到所有页面头部和底部的HTML，主题通过CustomHeadHtml和
+// CustomFootHtml渲染，因此与主题无关，例如环境徽章
+type PageHTMLHook func(ctx *context.Context) (head, foot template.HTML)
+
+var pageHTMLHooks = make([]PageHTMLHook, 0)
+
+// AddPageHTMLHook 添加页面HTML钩子，需在服务启动前调用
+// 参数:
+//   - fn: 页面HTML钩子
+func AddPageHTMLHook(fn PageHTMLHook) {
+	pageHTMLHooks = append(pageHTMLHooks, fn)
+}
+
 // NewPageParam 是创建新页面的参数结构体
 type NewPageParam struct {
 	User           models.UserModel // 用户模型
@@ -143,6 +172,8 @@ func (param *NewPageParam) NavButtonsAndJS(ctx *context.Context) (template.HTML,
 func NewPage(ctx *context.Context, param *NewPageParam) *Page {
 
 	if param.NavButtonsHTML == template.HTML("") {
+		param.Buttons = append(param.Buttons.Visible(ctx),
+			param.Panel.NavButtons.CheckPermission(param.User).Visible(ctx)...)
 		param.NavButtonsHTML, param.NavButtonsJS = param.NavButtonsAndJS(ctx)
 	}
 
@@ -151,24 +182,43 @@ func NewPage(ctx *context.Context, param *NewPageParam) *Page {
 		logo = config.GetLogo()
 	}
 
+	panel := param.Panel
+	for _, fn := range pageDecorators {
+		panel = fn(ctx, param.User, panel)
+	}
+
+	title := panel.GetBrowserTitle()
+	if ctx != nil && ctx.Request != nil && ctx.IsPjax() {
+		// pjax只替换页面内容，需要通过脚本更新浏览器标题
+		t, _ := json.Marshal(title)
+		panel.Content += template.HTML(`<script>document.title = ` + string(t) + `;</script>`)
+	}
+
+	headHTML, footHTML := config.GetCustomHeadHtml(), config.GetCustomFootHtml()+param.NavButtonsJS
+	for _, fn := range pageHTMLHooks {
+		head, foot := fn(ctx)
+		headHTML += head
+		footHTML += foot
+	}
+
 	return &Page{
 		User:       param.User,
 		Menu:       *param.Menu,
-		Panel:      param.Panel,
+		Panel:      panel,
 		UpdateMenu: param.UpdateMenu,
 		System: SystemInfo{
 			Version: system.Version(),
 			Theme:   config.GetTheme(),
 		},
 		UrlPrefix:      config.AssertPrefix(),
-		Title:          config.GetTitle(),
+		Title:          title,
 		Logo:           logo,
 		MiniLogo:       config.GetMiniLogo(),
 		ColorScheme:    config.GetColorScheme(),
 		IndexUrl:       config.GetIndexURL(),
 		CdnUrl:         config.GetAssetUrl(),
-		CustomHeadHtml: config.GetCustomHeadHtml(),
-		CustomFootHtml: config.GetCustomFootHtml() + param.NavButtonsJS,
+		CustomHeadHtml: headHTML,
+		CustomFootHtml: footHTML,
 		FooterInfo:     config.GetFooterInfo(),
 		AssetsList:     param.Assets,
 		navButtons:     param.Buttons,
@@ -239,7 +289,8 @@ func ParseTableDataTmpl(content interface{}) string {
 	}
 	t := template.New("row_data_tmpl")
 	t, _ = t.Parse(c)
-	buf := new(bytes.Buffer)
+	buf := context.GetBuffer()
+	defer context.PutBuffer(buf)
 	_ = t.Execute(buf, TableRowData{Ids: `typeof(selectedRows)==="function" ? selectedRows().join() : ""`})
 	return buf.String()
 }
@@ -254,7 +305,8 @@ func ParseTableDataTmpl(content interface{}) string {
 func ParseTableDataTmplWithID(id template.HTML, content string, value ...map[string]InfoItem) string {
 	t := textTmpl.New("row_data_tmpl")
 	t, _ = t.Parse(content)
-	buf := new(bytes.Buffer)
+	buf := context.GetBuffer()
+	defer context.PutBuffer(buf)
 	v := make(map[string]InfoItem)
 	if len(value) > 0 {
 		v = value[0]
@@ -286,6 +338,66 @@ type Panel struct {
 	RefreshInterval []int
 
 	Callbacks Callbacks // 回调列表
+
+	// NavButtons 只在当前页面显示的导航按钮
+	NavButtons Buttons
+
+	// BrowserTitle 浏览器标题，为空时使用Title
+	BrowserTitle string
+}
+
+// PageTitleSeparator 是浏览器标题各部分之间的分隔符
+const PageTitleSeparator = " – "
+
+var htmlTagReg = regexp.MustCompile(`<[^>]*>`)
+
+// PageTitle 将标题各部分连接为浏览器标题，忽略空的部分及其中的HTML标签
+// 参数:
+//   - parts: 标题各部分，如 "Users", "Edit #42"
+//
+// 返回: 浏览器标题
+func PageTitle(parts ...string) string {
+	titles := make([]string, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(html.UnescapeString(htmlTagReg.ReplaceAllString(part, "")))
+		if part != "" && (len(titles) == 0 || titles[len(titles)-1] != part) {
+			titles = append(titles, part)
+		}
+	}
+	return strings.Join(titles, PageTitleSeparator)
+}
+
+// SetBrowserTitle 设置浏览器标题，站点名称会自动附加在后面
+// 参数:
+//   - title: 浏览器标题
+//
+// 返回: 更新后的面板
+func (p Panel) SetBrowserTitle(title string) Panel {
+	p.BrowserTitle = title
+	return p
+}
+
+// GetBrowserTitle 获取包含站点名称的浏览器标题，如 "Users – Edit #42 – GoAdmin"
+// 返回: 浏览器标题
+func (p Panel) GetBrowserTitle() string {
+	title := p.BrowserTitle
+	if title == "" {
+		title = string(language.GetFromHtml(p.Title))
+	}
+	return PageTitle(title, config.GetTitle())
+}
+
+// AddNavButton 添加只在当前页面显示的导航按钮
+// 参数:
+//   - title: 按钮标题
+//   - icon: 图标
+//   - action: 操作对象
+//
+// 返回: 更新后的面板
+func (p Panel) AddNavButton(title template.HTML, icon string, action Action) Panel {
+	p.NavButtons = append(append(Buttons(nil), p.NavButtons...), GetNavButton(title, icon, action))
+	p.Callbacks = p.Callbacks.AddCallback(action.GetCallbacks())
+	return p
 }
 
 // Component 是组件接口
@@ -0,0 +1,18 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageTitle(t *testing.T) {
+	assert.Equal(t, "Users – Edit #42 – MyAdmin", PageTitle("Users", "Edit #42", "MyAdmin"))
+	assert.Equal(t, "Users – MyAdmin", PageTitle(`<i class="fa fa-star"></i> Users`, "", "MyAdmin"))
+	assert.Equal(t, "Q&A", PageTitle("Q&amp;A", "Q&A"))
+	assert.Equal(t, "", PageTitle("", " "))
+
+	assert.True(t, strings.HasPrefix(Panel{Title: "Users"}.GetBrowserTitle(), "Users"))
+	assert.True(t, strings.HasPrefix(Panel{Title: "Users"}.SetBrowserTitle("Reports").GetBrowserTitle(), "Reports"))
+}
@@ -0,0 +1,157 @@
+package types
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordHasher 是密码哈希函数类型
+type PasswordHasher func(password string) (string, error)
+
+// FieldPasswordParam 是密码字段参数结构体
+type FieldPasswordParam struct {
+	Hasher    PasswordHasher // 密码哈希函数，默认为 BcryptHasher
+	MinLength int            // 密码的最小长度，为 0 时不限制
+}
+
+// argon2 的参数，使用 RFC 9106 推荐的第二组参数
+const (
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+	argon2KeyLen  = 32
+)
+
+// BcryptHasher 使用 bcrypt 计算密码的哈希，密码最长为 72 字节
+func BcryptHasher(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Argon2Hasher 使用 argon2id 计算密码的哈希
+// 返回: 形如 $argon2id$v=19$m=65536,t=3,p=4$salt$hash 的字符串
+func Argon2Hasher(password string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, argon2Memory, argon2Time,
+		argon2Threads, base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// VerifyPassword 校验密码与 BcryptHasher 或 Argon2Hasher 生成的哈希是否匹配
+// 参数:
+//   - password: 密码
+//   - hash: 保存的哈希
+//
+// 返回: 是否匹配
+func VerifyPassword(password, hash string) bool {
+	if !strings.HasPrefix(hash, "$argon2id$") {
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+	}
+
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return false
+	}
+	var (
+		version            int
+		memory, iterations uint32
+		threads            uint8
+		salt, key          []byte
+		err                error
+	)
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false
+	}
+	if _, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &threads); err != nil {
+		return false
+	}
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return false
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return false
+	}
+	other := argon2.IDKey([]byte(password), salt, iterations, memory, threads, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, other) == 1
+}
+
+// HashPasswordFields 返回计算密码字段哈希的预处理函数
+// 为空的字段表示不修改密码，从提交的值中删除，避免覆盖已保存的哈希
+// 参数:
+//   - hasher: 密码哈希函数，为 nil 时使用 BcryptHasher
+//   - fields: 密码字段
+//
+// 返回: 预处理函数，可以用于 SetPreProcessFn 或在自定义的 UpdateFn、InsertFn 之前调用
+func HashPasswordFields(hasher PasswordHasher, fields ...string) FormPreProcessFn {
+	if hasher == nil {
+		hasher = BcryptHasher
+	}
+	return func(values form.Values) form.Values {
+		for _, field := range fields {
+			if _, ok := values[field]; !ok {
+				continue
+			}
+			password := values.Get(field)
+			if password == "" {
+				values.Delete(field)
+				continue
+			}
+			hash, err := hasher(password)
+			if err != nil {
+				logger.Error("hash password error: ", err)
+				values.Delete(field)
+				continue
+			}
+			values.Add(field, hash)
+		}
+		return values
+	}
+}
+
+// passwordValidator 返回校验密码长度的函数，空值表示不修改密码
+func passwordValidator(p FieldPasswordParam) FieldValidateFn {
+	return func(value PostFieldModel) error {
+		v := value.Value.Value()
+		if v == "" {
+			return nil
+		}
+		if p.MinLength > 0 && len([]rune(v)) < p.MinLength {
+			return fmt.Errorf(language.Get("password must be at least %d characters"), p.MinLength)
+		}
+		// 默认的 bcrypt 不支持超过 72 字节的密码
+		if p.Hasher == nil && len(v) > 72 {
+			return errors.New(language.Get("password is too long"))
+		}
+		return nil
+	}
+}
+
+// passwordStrengthJS 生成密码强度条的 JS
+func passwordStrengthJS(field string) template.HTML {
+	return utils.ParseHTML("password_strength", tmpls["password_strength"], struct {
+		Field  template.JS
+		Labels []string
+	}{
+		Field: template.JS(field),
+		Labels: []string{language.Get("very weak"), language.Get("weak"), language.Get("fair"),
+			language.Get("good"), language.Get("strong")},
+	})
+}
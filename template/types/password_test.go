@@ -0,0 +1,67 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	form2 "github.com/purpose168/GoAdmin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordHasher(t *testing.T) {
+	for _, hasher := range []PasswordHasher{BcryptHasher, Argon2Hasher} {
+		hash, err := hasher("secret")
+		assert.NoError(t, err)
+		assert.NotEqual(t, "secret", hash)
+		assert.True(t, VerifyPassword("secret", hash))
+		assert.False(t, VerifyPassword("wrong", hash))
+	}
+
+	hash, _ := Argon2Hasher("secret")
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=3,p=4$"))
+	assert.False(t, VerifyPassword("secret", "$argon2id$v=19$m=65536"))
+	assert.False(t, VerifyPassword("secret", "plain"))
+}
+
+func TestHashPasswordFields(t *testing.T) {
+	hasher := func(password string) (string, error) { return "hash:" + password, nil }
+	fn := HashPasswordFields(hasher, "password", "pin")
+
+	values := fn(form.Values{"password": {"secret"}, "pin": {""}, "name": {"a"}})
+	assert.Equal(t, "hash:secret", values.Get("password"))
+	// 为空时不修改已保存的密码
+	_, ok := values["pin"]
+	assert.False(t, ok)
+	assert.Equal(t, "a", values.Get("name"))
+}
+
+func TestFormPanel_FieldPassword(t *testing.T) {
+	panel := NewFormPanel()
+	panel.AddField("Password", "password", db.Varchar, form2.Text).FieldPassword(FieldPasswordParam{MinLength: 8})
+	panel.AddField("Name", "name", db.Varchar, form2.Text)
+	panel.SetPrimaryKey("id", db.Int)
+	panel.SetPreProcessFn(func(values form.Values) form.Values {
+		values.Add("name", strings.ToUpper(values.Get("name")))
+		return values
+	})
+
+	field := panel.FieldList.FindByFieldName("password")
+	assert.Equal(t, form2.Password, field.FormType)
+	assert.Equal(t, "", field.Display(FieldModel{Value: "$2a$10$saved"}))
+	assert.Contains(t, string(panel.FooterHtml), `$("input.password")`)
+
+	assert.NoError(t, panel.Validate(form.Values{"password": {""}}))
+	assert.NoError(t, panel.Validate(form.Values{"password": {"long enough"}}))
+	assert.Error(t, panel.Validate(form.Values{"password": {"short"}}))
+	assert.Error(t, panel.Validate(form.Values{"password": {strings.Repeat("x", 73)}}))
+
+	values := panel.PreProcess(form.Values{"password": {"long enough"}, "name": {"a"}})
+	assert.Equal(t, "A", values.Get("name"))
+	assert.True(t, VerifyPassword("long enough", values.Get("password")))
+
+	values = panel.PreProcess(form.Values{"password": {""}, "name": {"a"}})
+	_, ok := values["password"]
+	assert.False(t, ok)
+}
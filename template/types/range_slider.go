@@ -0,0 +1,113 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+)
+
+// FieldRangeSliderParam 是范围滑块筛选参数结构体
+type FieldRangeSliderParam struct {
+	Min     float64 // 最小值，Min 和 Max 都为0时通过聚合查询获取
+	Max     float64 // 最大值
+	Step    float64 // 步长，默认为1
+	Prefix  string  // 显示值的前缀，如 "$"
+	Postfix string  // 显示值的后缀，如 "岁"
+	Table   string  // 聚合查询的表，默认为面板的表
+	Field   string  // 聚合查询的字段，默认为当前字段
+}
+
+// RangeSliderSeparator 是范围滑块筛选值中最小值和最大值的分隔符
+const RangeSliderSeparator = ";"
+
+// ParseRange 解析范围滑块的筛选值，如 "10;50"
+// 参数:
+//   - value: 筛选值
+//
+// 返回: 最小值、最大值以及是否有效
+func ParseRange(value string) (float64, float64, bool) {
+	arr := strings.Split(value, RangeSliderSeparator)
+	if len(arr) != 2 {
+		return 0, 0, false
+	}
+	from, err := strconv.ParseFloat(strings.TrimSpace(arr[0]), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	to, err := strconv.ParseFloat(strings.TrimSpace(arr[1]), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if from > to {
+		from, to = to, from
+	}
+	return from, to, true
+}
+
+// Bounds 获取范围滑块的边界，未配置时查询字段的最小值和最大值
+// 参数:
+//   - sql: SQL对象，为nil时只使用配置的边界
+//
+// 返回: 最小值和最大值
+func (p FieldRangeSliderParam) Bounds(sql *db.SQL) (float64, float64) {
+	if p.Min != 0 || p.Max != 0 || sql == nil || p.Table == "" || p.Field == "" {
+		return p.Min, p.Max
+	}
+
+	row, err := sql.Table(p.Table).Select("min("+p.Field+")", "max("+p.Field+")").First()
+	if err != nil {
+		return p.Min, p.Max
+	}
+
+	var min, max float64
+	for key, value := range row {
+		v := rangeValue(value)
+		if strings.HasPrefix(strings.ToLower(key), "min") {
+			min = v
+		} else if strings.HasPrefix(strings.ToLower(key), "max") {
+			max = v
+		}
+	}
+	return min, max
+}
+
+func rangeValue(value interface{}) float64 {
+	var s string
+	if b, ok := value.([]byte); ok {
+		s = string(b)
+	} else if value != nil {
+		s = fmt.Sprintf("%v", value)
+	}
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
+// options 返回范围滑块的参数，已选择的范围通过 from 和 to 显示
+func (p FieldRangeSliderParam) options(min, max float64, value string) template.JS {
+	step := p.Step
+	if step == 0 {
+		step = 1
+	}
+	m := map[string]interface{}{
+		"type":                   "double",
+		"min":                    min,
+		"max":                    max,
+		"from":                   min,
+		"to":                     max,
+		"step":                   step,
+		"grid":                   true,
+		"prettify":               false,
+		"prefix":                 p.Prefix,
+		"postfix":                p.Postfix,
+		"input_values_separator": RangeSliderSeparator,
+	}
+	if from, to, ok := ParseRange(value); ok {
+		m["from"], m["to"] = from, to
+	}
+	s, _ := json.Marshal(m)
+	return template.JS(s)
+}
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRange(t *testing.T) {
+	from, to, ok := ParseRange("10;50.5")
+	assert.True(t, ok)
+	assert.Equal(t, []float64{10, 50.5}, []float64{from, to})
+
+	from, to, ok = ParseRange(" 50 ; 10 ")
+	assert.True(t, ok)
+	assert.Equal(t, []float64{10, 50}, []float64{from, to})
+
+	for _, value := range []string{"", "10", "a;10", "10;b", "1;2;3"} {
+		_, _, ok = ParseRange(value)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestFieldRangeSliderParam(t *testing.T) {
+	p := FieldRangeSliderParam{Min: 0, Max: 100, Prefix: "$"}
+	min, max := p.Bounds(nil)
+	assert.Equal(t, []float64{0, 100}, []float64{min, max})
+
+	assert.Equal(t, `{"from":0,"grid":true,"input_values_separator":";","max":100,"min":0,"postfix":"",`+
+		`"prefix":"$","prettify":false,"step":1,"to":100,"type":"double"}`, string(p.options(min, max, "")))
+	assert.Contains(t, string(p.options(min, max, "20;30")), `"from":20`)
+	assert.Contains(t, string(p.options(min, max, "20;30")), `"to":30`)
+}
@@ -0,0 +1,46 @@
+package types
+
+import (
+	"html/template"
+	"strings"
+)
+
+// ScriptRegistry 是页面级的脚本登记表，同一个操作添加到多个按钮或列时，
+// 相同的脚本和页脚内容在页面中只输出一次
+type ScriptRegistry map[string]struct{}
+
+// Once 登记内容，返回首次登记的内容，已登记过的内容和空白内容返回空
+// 参数:
+//   - content: 脚本或页脚内容
+//
+// 返回: 需要输出的内容
+func (r ScriptRegistry) Once(content template.HTML) template.HTML {
+	key := strings.TrimSpace(string(content))
+	if key == "" {
+		return ""
+	}
+	if _, ok := r[key]; ok {
+		return ""
+	}
+	r[key] = struct{}{}
+	return content
+}
+
+// OnceJS 与Once相同，用于JavaScript代码
+// 参数:
+//   - js: JavaScript代码
+//
+// 返回: 需要输出的代码
+func (r ScriptRegistry) OnceJS(js template.JS) template.JS {
+	return template.JS(r.Once(template.HTML(js)))
+}
+
+// Copy 复制登记表
+// 返回: 登记表的副本
+func (r ScriptRegistry) Copy() ScriptRegistry {
+	c := make(ScriptRegistry, len(r))
+	for k := range r {
+		c[k] = struct{}{}
+	}
+	return c
+}
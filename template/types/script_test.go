@@ -0,0 +1,35 @@
+package types
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScriptRegistry(t *testing.T) {
+	r := make(ScriptRegistry)
+	assert.Equal(t, template.HTML("<script>a()</script>"), r.Once("<script>a()</script>"))
+	assert.Equal(t, template.HTML(""), r.Once("\n<script>a()</script> "))
+	assert.Equal(t, template.JS("b()"), r.OnceJS("b()"))
+	assert.Equal(t, template.HTML(""), r.Once("  "))
+
+	c := r.Copy()
+	c.Once("<script>c()</script>")
+	assert.Equal(t, 2, len(r))
+	assert.Equal(t, 3, len(c))
+}
+
+func TestInfoPanelScriptOnce(t *testing.T) {
+	info := NewInfoPanel(nil, "id")
+	info.AddJS("refresh()").AddJS("refresh()").AddCSS(".a{}").AddJS("refresh()")
+	assert.Equal(t, 1, strings.Count(string(info.FooterHtml), "refresh()"))
+	assert.Equal(t, 1, strings.Count(string(info.FooterHtml), ".a{}"))
+
+	c := info.Clone(nil)
+	c.AddJS("other()")
+	assert.NotContains(t, string(info.FooterHtml), "other()")
+	info.AddJS("other()")
+	assert.Contains(t, string(info.FooterHtml), "other()")
+}
@@ -0,0 +1,163 @@
+package types
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/file"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/logger"
+	"github.com/purpose168/GoAdmin/modules/utils"
+)
+
+// signatureMaxSize 是签名图片的最大字节数
+const signatureMaxSize = 1 << 20
+
+// FieldSignatureParam 是签名字段参数结构体
+type FieldSignatureParam struct {
+	Width  int  // 画布宽度，默认为 400
+	Height int  // 画布高度，默认为 160
+	SVG    bool // 是否保存为 SVG，默认保存为 PNG
+}
+
+// signatureUploader 返回保存签名图片的上传引擎
+var signatureUploader = func() file.Uploader {
+	return file.GetFileEngine(config.GetFileUploadEngine().Name)
+}
+
+// ParseImageDataURL 解析 base64 编码的图片 data URL
+// 参数:
+//   - value: 形如 data:image/png;base64,... 的字符串
+//
+// 返回: 图片的 MIME 类型和内容，只支持 image/png 和 image/svg+xml，不是这两种图片时返回 false
+func ParseImageDataURL(value string) (string, []byte, bool) {
+	if !strings.HasPrefix(value, "data:") {
+		return "", nil, false
+	}
+	comma := strings.Index(value, ",")
+	if comma < 0 {
+		return "", nil, false
+	}
+	meta := strings.Split(value[len("data:"):comma], ";")
+	if len(meta) != 2 || meta[1] != "base64" {
+		return "", nil, false
+	}
+	content, err := base64.StdEncoding.DecodeString(value[comma+1:])
+	if err != nil {
+		return "", nil, false
+	}
+
+	switch meta[0] {
+	case "image/png":
+		if !bytes.HasPrefix(content, []byte("\x89PNG\r\n\x1a\n")) {
+			return "", nil, false
+		}
+	case "image/svg+xml":
+		if !safeSVG(content) {
+			return "", nil, false
+		}
+	default:
+		return "", nil, false
+	}
+	return meta[0], content, true
+}
+
+// svgElements 和 svgAttrs 是签名 SVG 允许的元素和属性，避免保存带有脚本的图片
+var (
+	svgElements = map[string]bool{"svg": true, "g": true, "path": true, "polyline": true, "line": true,
+		"rect": true}
+	svgAttrs = map[string]bool{"xmlns": true, "version": true, "width": true, "height": true, "viewBox": true,
+		"d": true, "points": true, "x": true, "y": true, "x1": true, "y1": true, "x2": true, "y2": true,
+		"fill": true, "stroke": true, "stroke-width": true, "stroke-linecap": true, "stroke-linejoin": true}
+)
+
+// safeSVG 检查 SVG 是否只包含绘制签名所需的元素和属性
+func safeSVG(content []byte) bool {
+	var (
+		decoder = xml.NewDecoder(bytes.NewReader(content))
+		root    = true
+	)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return !root
+		}
+		if err != nil {
+			return false
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if !svgElements[t.Name.Local] || (root && t.Name.Local != "svg") {
+				return false
+			}
+			root = false
+			for _, attr := range t.Attr {
+				if attr.Name.Space != "" || !svgAttrs[attr.Name.Local] ||
+					strings.Contains(strings.ToLower(attr.Value), "url(") {
+					return false
+				}
+			}
+		case xml.Directive:
+			return false
+		case xml.ProcInst:
+			if t.Target != "xml" {
+				return false
+			}
+		}
+	}
+}
+
+// signaturePostFilter 将新的签名保存到上传引擎，保存图片的路径；未修改的签名保持原值
+func signaturePostFilter(value PostFieldModel) interface{} {
+	mime, content, ok := ParseImageDataURL(value.Value.Value())
+	if !ok {
+		return value.Value.Value()
+	}
+	name := "signature.png"
+	if mime == "image/svg+xml" {
+		name = "signature.svg"
+	}
+	path, err := file.UploadBytes(signatureUploader(), name, content)
+	if err != nil {
+		logger.Error("upload signature error: ", err)
+		return ""
+	}
+	return path
+}
+
+// validateSignature 校验新的签名，空值由 FieldMust 校验
+func validateSignature(value PostFieldModel) error {
+	v := value.Value.Value()
+	if !strings.HasPrefix(v, "data:") {
+		return nil
+	}
+	if _, content, ok := ParseImageDataURL(v); !ok || len(content) > signatureMaxSize {
+		return errors.New(language.Get("invalid signature"))
+	}
+	return nil
+}
+
+// signatureJS 生成签名画布的 JS
+func signatureJS(field string, p FieldSignatureParam) template.HTML {
+	return utils.ParseHTML("signature", tmpls["signature"], struct {
+		Field  template.JS
+		Width  int
+		Height int
+		SVG    bool
+		Prefix string
+		Clear  string
+	}{
+		Field:  template.JS(field),
+		Width:  p.Width,
+		Height: p.Height,
+		SVG:    p.SVG,
+		Prefix: config.GetStore().URL("/"),
+		Clear:  language.Get("clear signature"),
+	})
+}
@@ -0,0 +1,77 @@
+package types
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/file"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/form"
+	form2 "github.com/purpose168/GoAdmin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testPNG = "\x89PNG\r\n\x1a\n0000"
+	testSVG = `<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg" width="10" height="10">` +
+		`<polyline points="1,1 5,5" fill="none" stroke="#000"/></svg>`
+)
+
+func dataURL(mime, content string) string {
+	return "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString([]byte(content))
+}
+
+func TestParseImageDataURL(t *testing.T) {
+	mime, content, ok := ParseImageDataURL(dataURL("image/png", testPNG))
+	assert.True(t, ok)
+	assert.Equal(t, "image/png", mime)
+	assert.Equal(t, testPNG, string(content))
+
+	mime, _, ok = ParseImageDataURL(dataURL("image/svg+xml", testSVG))
+	assert.True(t, ok)
+	assert.Equal(t, "image/svg+xml", mime)
+
+	for _, value := range []string{
+		"signature.png",
+		"data:image/png,raw",
+		"data:image/png;base64,!!!",
+		dataURL("image/png", "not a png"),
+		dataURL("image/jpeg", testPNG),
+		dataURL("image/svg+xml", `<svg><script>alert(1)</script></svg>`),
+		dataURL("image/svg+xml", `<svg onload="alert(1)"></svg>`),
+		dataURL("image/svg+xml", `<svg><rect fill="url(#a)"/></svg>`),
+		dataURL("image/svg+xml", `<polyline points="1,1"/>`),
+		dataURL("image/svg+xml", `<!DOCTYPE svg><svg></svg>`),
+		dataURL("image/svg+xml", `<svg>`),
+	} {
+		_, _, ok = ParseImageDataURL(value)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestFormPanel_FieldSignature(t *testing.T) {
+	dir := t.TempDir()
+	uploader := signatureUploader
+	signatureUploader = func() file.Uploader { return &file.LocalFileUploader{BasePath: dir} }
+	defer func() { signatureUploader = uploader }()
+
+	panel := NewFormPanel()
+	panel.AddField("Signature", "signature", db.Varchar, form2.Text).FieldSignature(FieldSignatureParam{SVG: true})
+	panel.SetPrimaryKey("id", db.Int)
+
+	assert.Contains(t, string(panel.FooterHtml), `$("input.signature")`)
+	assert.NoError(t, panel.Validate(form.Values{"signature": {dataURL("image/svg+xml", testSVG)}}))
+	assert.NoError(t, panel.Validate(form.Values{"signature": {"old.png"}}))
+	assert.Error(t, panel.Validate(form.Values{"signature": {dataURL("image/png", "bad")}}))
+
+	field := panel.FieldList.FindByFieldName("signature")
+	path := field.PostFilterFn(PostFieldModel{Value: FieldModelValue{dataURL("image/svg+xml", testSVG)}}).(string)
+	assert.Equal(t, ".svg", filepath.Ext(path))
+	content, err := os.ReadFile(filepath.Join(dir, path))
+	assert.NoError(t, err)
+	assert.Equal(t, testSVG, string(content))
+
+	assert.Equal(t, "old.png", field.PostFilterFn(PostFieldModel{Value: FieldModelValue{"old.png"}}))
+}
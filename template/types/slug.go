@@ -0,0 +1,51 @@
+package types
+
+import (
+	"html/template"
+	"strings"
+	"unicode"
+
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/modules/utils"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Slugify 将文本转换为 URL 中使用的别名，如 "Hello, World!" 转换为 "hello-world"
+// 字母转换为小写并去除重音符号，字母和数字以外的字符转换为横线
+// 参数:
+//   - value: 文本
+//
+// 返回: 别名，文本中没有字母和数字时返回空字符串
+func Slugify(value string) string {
+	var (
+		sb   strings.Builder
+		dash = false
+	)
+	for _, r := range norm.NFKD.String(value) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			dash = false
+			sb.WriteRune(unicode.ToLower(r))
+		default:
+			dash = true
+		}
+	}
+	return sb.String()
+}
+
+// slugJS 生成根据来源字段自动填写别名的 JS
+func slugJS(field, from string) template.HTML {
+	return utils.ParseHTML("slug", tmpls["slug"], struct {
+		Field  template.JS
+		From   template.JS
+		Manual string
+	}{
+		Field:  template.JS(field),
+		From:   template.JS(from),
+		Manual: language.Get("edit manually"),
+	})
+}
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	form2 "github.com/purpose168/GoAdmin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	cases := map[string]string{
+		"Hello, World!":        "hello-world",
+		"  --Go  Admin--  ":    "go-admin",
+		"Crème Brûlée 2024":    "creme-brulee-2024",
+		"snake_case.and/slash": "snake-case-and-slash",
+		"你好 世界":                "你好-世界",
+		"!!!":                  "",
+	}
+	for value, want := range cases {
+		assert.Equal(t, want, Slugify(value), value)
+	}
+}
+
+func TestFormPanel_FieldSlugFrom(t *testing.T) {
+	panel := NewFormPanel()
+	panel.AddField("Title", "title", db.Varchar, form2.Text)
+	panel.AddField("Slug", "slug", db.Varchar, form2.Text).FieldSlugFrom("title")
+
+	assert.Equal(t, "title", panel.FieldList.FindByFieldName("slug").SlugFrom)
+	assert.Contains(t, string(panel.FooterHtml), `$("input.slug")`)
+	assert.Contains(t, string(panel.FooterHtml), `$(".title")`)
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// FieldTagsParam 是标签字段参数结构体
+type FieldTagsParam struct {
+	JSON  bool   // 是否以 JSON 数组保存，默认以逗号分隔保存
+	Table string // 自动补全的标签来源表，默认为面板的表
+}
+
+// ParseTags 解析标签字段的值
+// 参数:
+//   - value: JSON 数组或逗号分隔的标签
+//
+// 返回: 去除空白和重复后的标签列表
+func ParseTags(value string) []string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return []string{}
+	}
+
+	var items []string
+	if strings.HasPrefix(value, "[") {
+		if err := json.Unmarshal([]byte(value), &items); err != nil {
+			items = strings.Split(strings.Trim(value, "[]"), ",")
+		}
+	} else {
+		items = strings.Split(value, ",")
+	}
+
+	return uniqueTags(items)
+}
+
+// FormatTags 将标签列表转换为保存的值
+// 参数:
+//   - tags: 标签列表，每一项也可以是逗号分隔的多个标签
+//   - asJSON: 是否转换为 JSON 数组
+//
+// 返回: 保存到数据库的值
+func FormatTags(tags []string, asJSON bool) string {
+	items := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		items = append(items, strings.Split(tag, ",")...)
+	}
+	items = uniqueTags(items)
+
+	if asJSON {
+		s, _ := json.Marshal(items)
+		return string(s)
+	}
+	return strings.Join(items, ",")
+}
+
+// tagOptions 将来源表中每一行的标签合并为去重排序后的选项
+func tagOptions(options FieldOptions) FieldOptions {
+	var tags []string
+	for _, option := range options {
+		tags = append(tags, ParseTags(option.Value)...)
+	}
+	tags = uniqueTags(tags)
+	sort.Strings(tags)
+
+	res := make(FieldOptions, len(tags))
+	for k, tag := range tags {
+		res[k] = FieldOption{Text: tag, Value: tag}
+	}
+	return res
+}
+
+func uniqueTags(items []string) []string {
+	var (
+		res  = make([]string, 0, len(items))
+		seen = make(map[string]struct{}, len(items))
+	)
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if _, ok := seen[item]; ok {
+			continue
+		}
+		seen[item] = struct{}{}
+		res = append(res, item)
+	}
+	return res
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/template/types/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTags(t *testing.T) {
+	assert.Equal(t, []string{"go", "web"}, ParseTags(" go, web ,,go"))
+	assert.Equal(t, []string{"go", "a,b"}, ParseTags(`["go", "a,b", ""]`))
+	assert.Equal(t, []string{"go", "web"}, ParseTags(`[go,web]`))
+	assert.Equal(t, []string{}, ParseTags(""))
+}
+
+func TestFormatTags(t *testing.T) {
+	assert.Equal(t, "go,web,db", FormatTags([]string{"go", " web", "go,db", ""}, false))
+	assert.Equal(t, `["go","web"]`, FormatTags([]string{"go", "web"}, true))
+	assert.Equal(t, "", FormatTags(nil, false))
+	assert.Equal(t, "[]", FormatTags(nil, true))
+}
+
+func TestFormPanel_FieldTags(t *testing.T) {
+	panel := NewFormPanel()
+	panel.AddField("Tags", "tags", db.Varchar, form.Text).FieldTags()
+	panel.AddField("Labels", "labels", db.JSON, form.Text).FieldTags(FieldTagsParam{JSON: true, Table: "labels"})
+	panel.SetTable("posts")
+
+	tags := panel.FieldList.FindByFieldName("tags")
+	assert.Equal(t, form.Select, tags.FormType)
+	assert.Equal(t, "posts", tags.OptionTable.Table)
+	assert.Contains(t, string(tags.OptionExt), `"tags":true`)
+	assert.Equal(t, []string{"go", "web"}, tags.ToDisplay(FieldModel{Value: "go,web"}))
+	assert.Equal(t, "go,web", tags.PostFilterFn(PostFieldModel{Value: FieldModelValue{"go", "web", "go"}}))
+
+	labels := panel.FieldList.FindByFieldName("labels")
+	assert.Equal(t, "labels", labels.OptionTable.Table)
+	assert.Equal(t, []string{"a"}, labels.ToDisplay(FieldModel{Value: `["a"]`}))
+	assert.Equal(t, `["a","b"]`, labels.PostFilterFn(PostFieldModel{Value: FieldModelValue{"a", "b"}}))
+
+	options := tags.OptionTable.ProcessFn(FieldOptions{{Value: "web,go"}, {Value: `["db","go"]`}, {Value: ""}})
+	assert.Equal(t, FieldOptions{{Text: "db", Value: "db"}, {Text: "go", Value: "go"}, {Text: "web", Value: "web"}}, options)
+}
+
+func TestInfoPanel_FieldTagsFilterable(t *testing.T) {
+	info := NewInfoPanel(nil, "id")
+	info.AddField("Tags", "tags", db.Varchar).FieldTagsFilterable()
+	info.SetTable("posts")
+
+	filter := info.FieldList.GetFieldByFieldName("tags").FilterFormFields[0]
+	assert.Equal(t, FilterOperatorHasTag, filter.Operator)
+	assert.Equal(t, form.SelectSingle, filter.Type)
+	assert.Equal(t, "posts", filter.OptionTable.Table)
+	assert.Equal(t, "tags", filter.OptionTable.ValueField)
+}
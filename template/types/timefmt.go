@@ -0,0 +1,143 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/language"
+)
+
+// timeLayouts 是 ParseTime 支持的时间格式
+var timeLayouts = []string{
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseTime 解析时间字段的值
+// 参数:
+//   - value: Unix 时间戳（秒）或日期时间字符串，如 2006-01-02 15:04:05
+//
+// 返回: 时间，没有时区的时间按本地时区解析；无法解析时返回 false
+func ParseTime(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+	if ts, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(ts, 0), true
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// FormatDuration 将时长转换为可读的格式，如 "2h 13m"，只显示最大的两个单位
+// 参数:
+//   - d: 时长，不足一秒的部分舍去
+//   - lang: 语言，为空时使用配置的语言
+//
+// 返回: 可读的时长
+func FormatDuration(d time.Duration, lang string) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	var (
+		seconds = int64(d / time.Second)
+		units   = []struct {
+			format string
+			size   int64
+		}{{"%dd", 86400}, {"%dh", 3600}, {"%dm", 60}, {"%ds", 1}}
+		parts = make([]string, 0, 2)
+	)
+	for _, unit := range units {
+		if n := seconds / unit.size; n > 0 || (unit.size == 1 && len(parts) == 0) {
+			parts = append(parts, fmt.Sprintf(language.GetWithLang(unit.format, lang), n))
+			seconds -= n * unit.size
+		} else if len(parts) > 0 {
+			// 只显示相邻的单位，如 "1d 5m" 显示为 "1d"
+			break
+		}
+		if len(parts) == 2 {
+			break
+		}
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+// TimeAgo 将时间转换为相对于 now 的可读格式，如 "3 days ago"、"in 2 hours"
+// 参数:
+//   - t: 时间
+//   - now: 当前时间
+//   - lang: 语言，为空时使用配置的语言
+//
+// 返回: 可读的相对时间
+func TimeAgo(t, now time.Time, lang string) string {
+	diff := now.Sub(t)
+	future := diff < 0
+	if future {
+		diff = -diff
+	}
+
+	var (
+		seconds = diff.Seconds()
+		minutes = seconds / 60
+		hours   = minutes / 60
+		days    = hours / 24
+		text    string
+	)
+	switch {
+	case seconds < 45:
+		return language.GetWithLang("just now", lang)
+	case seconds < 90:
+		text = language.GetWithLang("a minute", lang)
+	case minutes < 45:
+		text = fmt.Sprintf(language.GetWithLang("%d minutes", lang), int(math.Round(minutes)))
+	case minutes < 90:
+		text = language.GetWithLang("an hour", lang)
+	case hours < 22:
+		text = fmt.Sprintf(language.GetWithLang("%d hours", lang), int(math.Round(hours)))
+	case hours < 36:
+		text = language.GetWithLang("a day", lang)
+	case days < 26:
+		text = fmt.Sprintf(language.GetWithLang("%d days", lang), int(math.Round(days)))
+	case days < 45:
+		text = language.GetWithLang("a month", lang)
+	case days < 320:
+		text = fmt.Sprintf(language.GetWithLang("%d months", lang), int(math.Round(days/30)))
+	case days < 548:
+		text = language.GetWithLang("a year", lang)
+	default:
+		text = fmt.Sprintf(language.GetWithLang("%d years", lang), int(math.Round(days/365)))
+	}
+
+	if future {
+		return fmt.Sprintf(language.GetWithLang("in %s", lang), text)
+	}
+	return fmt.Sprintf(language.GetWithLang("%s ago", lang), text)
+}
+
+// timeAgoLocaleJS 返回客户端更新相对时间所用的翻译
+func timeAgoLocaleJS(lang string) template.JS {
+	keys := []string{"just now", "a minute", "%d minutes", "an hour", "%d hours", "a day", "%d days",
+		"a month", "%d months", "a year", "%d years", "in %s", "%s ago"}
+	locale := make(map[string]string, len(keys))
+	for _, key := range keys {
+		locale[key] = language.GetWithLang(key, lang)
+	}
+	s, _ := json.Marshal(locale)
+	return template.JS(s)
+}
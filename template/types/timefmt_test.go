@@ -0,0 +1,86 @@
+package types
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTime(t *testing.T) {
+	tm, ok := ParseTime("1700000000")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1700000000), tm.Unix())
+
+	tm, ok = ParseTime("2023-11-14 22:13:20")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2023, 11, 14, 22, 13, 20, 0, time.Local), tm)
+
+	tm, ok = ParseTime("2023-11-14T22:13:20Z")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1700000000), tm.Unix())
+
+	_, ok = ParseTime("yesterday")
+	assert.False(t, ok)
+	_, ok = ParseTime("")
+	assert.False(t, ok)
+}
+
+func TestFormatDuration(t *testing.T) {
+	cases := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{500 * time.Millisecond, "0s"},
+		{59 * time.Second, "59s"},
+		{61 * time.Second, "1m 1s"},
+		{2*time.Hour + 13*time.Minute + 5*time.Second, "2h 13m"},
+		{time.Hour, "1h"},
+		{26*time.Hour + 5*time.Minute, "1d 2h"},
+		{24*time.Hour + 5*time.Minute, "1d"},
+		{-90 * time.Second, "-1m 30s"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, FormatDuration(c.d, language.EN), c.d.String())
+	}
+	assert.Equal(t, "2小时 13分", FormatDuration(2*time.Hour+13*time.Minute, language.CN))
+}
+
+func TestTimeAgo(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		d    time.Duration
+		want string
+	}{
+		{10 * time.Second, "just now"},
+		{time.Minute, "a minute ago"},
+		{10 * time.Minute, "10 minutes ago"},
+		{time.Hour, "an hour ago"},
+		{5 * time.Hour, "5 hours ago"},
+		{30 * time.Hour, "a day ago"},
+		{3 * 24 * time.Hour, "3 days ago"},
+		{40 * 24 * time.Hour, "a month ago"},
+		{90 * 24 * time.Hour, "3 months ago"},
+		{400 * 24 * time.Hour, "a year ago"},
+		{800 * 24 * time.Hour, "2 years ago"},
+		{-2 * time.Hour, "in 2 hours"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, TimeAgo(now.Add(-c.d), now, language.EN), c.d.String())
+	}
+	assert.Equal(t, "3天前", TimeAgo(now.Add(-72*time.Hour), now, language.CN))
+	assert.Equal(t, "2小时后", TimeAgo(now.Add(2*time.Hour), now, language.CN))
+}
+
+func TestInfoPanel_FieldTimeAgo(t *testing.T) {
+	info := NewInfoPanel(nil, "id")
+	info.AddField("Created", "created_at", db.Timestamp).FieldTimeAgo()
+	info.AddField("Updated", "updated_at", db.Timestamp).FieldTimeAgo()
+
+	assert.Equal(t, 1, strings.Count(string(info.FooterHtml), "window.goAdminTimeAgoLocale"))
+	assert.Contains(t, string(info.FooterHtml), `"%s ago":"%s ago"`)
+}
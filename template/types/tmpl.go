@@ -298,4 +298,258 @@ var tmpls = map[string]string{"choose": `{{define "choose"}}
             }
         })
     </script>
+{{end}}`, "cron_preview": `{{define "cron_preview"}}
+    <script>
+        (function () {
+            let input = $("input.{{.Field}}");
+            let preview = $(".cron-preview-{{.Field}}");
+            // 没有预览元素时添加在输入框后
+            if (preview.length === 0) {
+                preview = $('<span class="help-block cron-preview-{{.Field}}"></span>').insertAfter(input);
+            }
+            let timer = null;
+
+            // 发送AJAX请求获取表达式的描述和接下来的运行时间
+            let update = function () {
+                let value = input.val();
+                if (typeof (value) === "undefined" || value === "") {
+                    preview.html("");
+                    return;
+                }
+                $.ajax({
+                    url: "{{.Url}}", // 请求URL
+                    type: 'post', // 请求类型
+                    dataType: 'text', // 数据类型
+                    data: {
+                        'value': value // 当前字段的值
+                    },
+                    success: function (data) {
+                        if (typeof (data) === "string") {
+                            data = JSON.parse(data);
+                        }
+                        preview.html("");
+                        if (data.code === 0) {
+                            $("<div>").text(data.data.description).appendTo(preview);
+                            let list = $("<ul style='padding-left: 20px;margin: 0;'>").appendTo(preview);
+                            data.data.next.forEach(function (item) {
+                                $("<li>").text(item).appendTo(list);
+                            });
+                        } else {
+                            $("<span class='text-danger'>").text(data.msg).appendTo(preview);
+                        }
+                    }
+                });
+            };
+
+            // 输入停止后更新预览
+            input.on("input", function () {
+                clearTimeout(timer);
+                timer = setTimeout(update, 300);
+            });
+            update();
+        })();
+    </script>
+{{end}}`, "signature": `{{define "signature"}}
+    <script>
+        (function () {
+            let input = $("input.{{.Field}}");
+            let group = input.closest(".input-group");
+            // 隐藏输入框，签名以 data URL 写入其中
+            (group.length > 0 ? group : input).hide();
+
+            let width = {{.Width}}, height = {{.Height}}, svg = {{.SVG}};
+            let box = $('<div class="signature-{{.Field}}"></div>').insertAfter(group.length > 0 ? group : input);
+            let canvas = $('<canvas></canvas>').attr({width: width, height: height})
+                .css({border: "1px solid #d2d6de", background: "#fff", "touch-action": "none", "max-width": "100%"})
+                .appendTo(box)[0];
+            $('<br>').appendTo(box);
+            let clear = $('<button type="button" class="btn btn-sm btn-default" style="margin-top: 5px;"></button>')
+                .text({{.Clear}}).appendTo(box);
+
+            let ctx = canvas.getContext("2d");
+            ctx.lineWidth = 2;
+            ctx.lineCap = "round";
+            ctx.lineJoin = "round";
+            ctx.strokeStyle = "#000";
+
+            // 显示已保存的签名
+            let value = input.val();
+            if (value !== "" && value.indexOf("data:") !== 0) {
+                let img = new Image();
+                img.onload = function () {
+                    ctx.drawImage(img, 0, 0, width, height);
+                };
+                img.src = /^(https?:)?\//.test(value) ? value : {{.Prefix}} + value;
+            }
+
+            let strokes = [], drawing = false;
+            let point = function (e) {
+                let rect = canvas.getBoundingClientRect();
+                return [Math.round((e.clientX - rect.left) * width / rect.width),
+                    Math.round((e.clientY - rect.top) * height / rect.height)];
+            };
+
+            // 生成只包含折线的 SVG
+            let toSVG = function () {
+                let lines = strokes.map(function (stroke) {
+                    return '<polyline points="' + stroke.join(" ") + '" fill="none" stroke="#000" ' +
+                        'stroke-width="2" stroke-linecap="round" stroke-linejoin="round"/>';
+                });
+                return '<svg xmlns="http://www.w3.org/2000/svg" width="' + width + '" height="' + height +
+                    '" viewBox="0 0 ' + width + ' ' + height + '">' + lines.join("") + '</svg>';
+            };
+
+            let save = function () {
+                if (svg) {
+                    input.val("data:image/svg+xml;base64," + btoa(toSVG()));
+                } else {
+                    input.val(canvas.toDataURL("image/png"));
+                }
+            };
+
+            canvas.addEventListener("pointerdown", function (e) {
+                if (strokes.length === 0) {
+                    // 重新签名时清除已保存的签名
+                    ctx.clearRect(0, 0, width, height);
+                }
+                drawing = true;
+                canvas.setPointerCapture(e.pointerId);
+                let p = point(e);
+                strokes.push([p]);
+                ctx.beginPath();
+                ctx.moveTo(p[0], p[1]);
+            });
+            canvas.addEventListener("pointermove", function (e) {
+                if (!drawing) {
+                    return;
+                }
+                let p = point(e);
+                strokes[strokes.length - 1].push(p);
+                ctx.lineTo(p[0], p[1]);
+                ctx.stroke();
+            });
+            let end = function () {
+                if (drawing) {
+                    drawing = false;
+                    save();
+                }
+            };
+            canvas.addEventListener("pointerup", end);
+            canvas.addEventListener("pointercancel", end);
+
+            clear.on("click", function () {
+                strokes = [];
+                ctx.clearRect(0, 0, width, height);
+                input.val("");
+            });
+        })();
+    </script>
+{{end}}`, "code": `{{define "code"}}
+    <script>
+        $(function () {
+            let editor = window["{{.Field}}editor"];
+            if (typeof (editor) === "undefined") {
+                return;
+            }
+            let input = $("#{{.Field}}_input");
+            let help = $('<span class="help-block"></span>').insertAfter(input);
+
+            // 提交未编码的内容，超过大小限制时提示
+            editor.session.on("change", function () {
+                let value = editor.getValue();
+                input.val(value);
+                {{if gt .MaxSize 0}}
+                let size = new Blob([value]).size;
+                help.toggleClass("text-danger", size > {{.MaxSize}}).text(size + " / " + {{.MaxSize}});
+                {{end}}
+            });
+            {{if ne .LanguageField ""}}
+            // 根据语言选择字段切换编辑器的语言
+            let choose = $(".{{.LanguageField}}");
+            let setMode = function () {
+                let language = choose.val();
+                if (typeof (language) === "string" && language !== "") {
+                    editor.session.setMode("ace/mode/" + language);
+                }
+            };
+            choose.on("change", setMode);
+            setMode();
+            {{end}}
+        });
+    </script>
+{{end}}`, "password_strength": `{{define "password_strength"}}
+    <script>
+        (function () {
+            let input = $("input.{{.Field}}");
+            let labels = {{.Labels}};
+            let colors = ["#dd4b39", "#dd4b39", "#f39c12", "#00a65a", "#00a65a"];
+            // 不显示已保存的值，也不让浏览器自动填充
+            input.val("").attr("autocomplete", "new-password");
+
+            let meter = $('<div class="password-strength-{{.Field}}" style="margin-top: 5px;display: none;">' +
+                '<div class="progress progress-xxs" style="margin-bottom: 2px;"><div class="progress-bar"></div></div>' +
+                '<small></small></div>');
+            let group = input.closest(".input-group");
+            meter.insertAfter(group.length > 0 ? group : input);
+
+            // 根据长度和字符种类计算 0 到 4 的强度
+            let score = function (value) {
+                let kinds = [/[a-z]/, /[A-Z]/, /[0-9]/, /[^a-zA-Z0-9]/].filter(function (re) {
+                    return re.test(value);
+                }).length;
+                let s = 0;
+                if (value.length >= 8) s++;
+                if (value.length >= 12) s++;
+                if (kinds >= 2) s++;
+                if (kinds >= 3) s++;
+                if (value.length < 6) s = 0;
+                return Math.min(s, 4);
+            };
+
+            input.on("input", function () {
+                let value = input.val();
+                if (value === "") {
+                    meter.hide();
+                    return;
+                }
+                let s = score(value);
+                meter.show();
+                meter.find(".progress-bar").css({width: (s + 1) * 20 + "%", background: colors[s]});
+                meter.find("small").text(labels[s]).css("color", colors[s]);
+            });
+        })();
+    </script>
+{{end}}`, "slug": `{{define "slug"}}
+    <script>
+        (function () {
+            let input = $("input.{{.Field}}");
+            let source = $(".{{.From}}");
+
+            // 与服务端的 Slugify 相同的转换
+            let slugify = function (value) {
+                return value.normalize("NFKD").replace(/\p{Mn}/gu, "").toLowerCase()
+                    .replace(/[^\p{L}\p{N}]+/gu, "-").replace(/^-+|-+$/g, "");
+            };
+
+            let toggle = $('<div class="checkbox" style="margin-bottom: 0;"><label>' +
+                '<input type="checkbox" class="slug-manual-{{.Field}}"> <span></span></label></div>');
+            toggle.find("span").text({{.Manual}});
+            let group = input.closest(".input-group");
+            toggle.insertAfter(group.length > 0 ? group : input);
+            let manual = toggle.find("input");
+
+            // 已保存的别名不是由来源字段生成时，默认手动编辑
+            manual.prop("checked", input.val() !== "" && input.val() !== slugify(source.val() || ""));
+
+            let update = function () {
+                input.prop("readonly", !manual.prop("checked"));
+                if (!manual.prop("checked")) {
+                    input.val(slugify(source.val() || ""));
+                }
+            };
+            source.on("input change", update);
+            manual.on("change", update);
+            update();
+        })();
+    </script>
 {{end}}`}
@@ -0,0 +1,178 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"html/template"
+	"strings"
+
+	"github.com/purpose168/GoAdmin/modules/db"
+)
+
+// OptionTreeNode 是树形选项的节点
+type OptionTreeNode struct {
+	Value  string // 节点的值
+	Text   string // 节点显示的文本
+	Parent string // 父节点的值，顶级节点为空或0
+}
+
+// OptionTree 是带有父子关系的选项，如 parent_id 关联的分类表
+type OptionTree struct {
+	nodes    []OptionTreeNode
+	values   map[string]bool
+	children map[string][]string
+}
+
+// NewOptionTree 创建树形选项
+// 参数:
+//   - nodes: 节点列表，同级节点保持列表中的顺序
+//
+// 返回: 树形选项
+func NewOptionTree(nodes []OptionTreeNode) OptionTree {
+	t := OptionTree{nodes: nodes, values: make(map[string]bool), children: make(map[string][]string)}
+	for _, node := range nodes {
+		t.values[node.Value] = true
+	}
+	for _, node := range nodes {
+		if !t.isRoot(node) {
+			t.children[node.Parent] = append(t.children[node.Parent], node.Value)
+		}
+	}
+	return t
+}
+
+// LoadOptionTree 从选项表中读取树形选项，选项表需要设置 ParentField
+// 参数:
+//   - sql: SQL对象
+//   - ot: 选项表配置
+//
+// 返回: 树形选项和错误信息
+func LoadOptionTree(sql *db.SQL, ot OptionTable) (OptionTree, error) {
+	sql.Table(ot.Table).Select(ot.ValueField, ot.TextField, ot.ParentField)
+
+	if ot.QueryProcessFn != nil {
+		ot.QueryProcessFn(sql)
+	}
+
+	rows, err := sql.All()
+	if err != nil {
+		return OptionTree{}, err
+	}
+
+	nodes := make([]OptionTreeNode, len(rows))
+	for k, row := range rows {
+		nodes[k] = OptionTreeNode{
+			Value:  fmt.Sprintf("%v", row[ot.ValueField]),
+			Text:   fmt.Sprintf("%v", row[ot.TextField]),
+			Parent: treeParent(row[ot.ParentField]),
+		}
+	}
+	return NewOptionTree(nodes), nil
+}
+
+func treeParent(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func (t OptionTree) isRoot(node OptionTreeNode) bool {
+	return node.Parent == "" || node.Parent == "0" || node.Parent == node.Value || !t.values[node.Parent]
+}
+
+// Options 返回按树形顺序排列的选项，子节点排在父节点之后并按层级缩进
+// 返回: 选项列表
+func (t OptionTree) Options() FieldOptions {
+	var (
+		options = make(FieldOptions, 0, len(t.nodes))
+		texts   = make(map[string]string, len(t.nodes))
+		visited = make(map[string]bool, len(t.nodes))
+		walk    func(value string, depth int)
+	)
+	for _, node := range t.nodes {
+		texts[node.Value] = node.Text
+	}
+	walk = func(value string, depth int) {
+		if visited[value] {
+			return
+		}
+		visited[value] = true
+		options = append(options, FieldOption{
+			Value:    value,
+			Text:     texts[value],
+			TextHTML: template.HTML(strings.Repeat("&nbsp;&nbsp;&nbsp;&nbsp;", depth) + html.EscapeString(texts[value])),
+		})
+		for _, child := range t.children[value] {
+			walk(child, depth+1)
+		}
+	}
+	for _, node := range t.nodes {
+		if t.isRoot(node) {
+			walk(node.Value, 0)
+		}
+	}
+	// 循环引用的节点没有根节点，作为顶级节点显示
+	for _, node := range t.nodes {
+		walk(node.Value, 0)
+	}
+	return options
+}
+
+// Subtree 返回给定的节点及其所有子孙节点的值
+// 参数:
+//   - values: 选中的节点值
+//
+// 返回: 去重后的节点值列表
+func (t OptionTree) Subtree(values []string) []string {
+	var (
+		res     = make([]string, 0, len(values))
+		visited = make(map[string]bool)
+		stack   = append([]string(nil), values...)
+	)
+	for len(stack) > 0 {
+		value := stack[0]
+		stack = stack[1:]
+		if value == "" || visited[value] {
+			continue
+		}
+		visited[value] = true
+		res = append(res, value)
+		stack = append(stack, t.children[value]...)
+	}
+	return res
+}
+
+// SelectJS 返回select2的初始化参数，选中父节点时会同时选中其所有子孙节点
+// 参数:
+//   - fieldClass: 选择框的CSS类名
+//   - ext: select2的参数，为空时使用 {"allowClear": true}
+//
+// 返回: 作为select2参数的JavaScript表达式
+func (t OptionTree) SelectJS(fieldClass string, ext template.JS) template.JS {
+	if ext == "" {
+		ext = `{"allowClear": true}`
+	}
+	children, _ := json.Marshal(t.children)
+	class, _ := json.Marshal("select." + fieldClass)
+	return template.JS(`(function () {
+	var children = ` + string(children) + `;
+	$(` + string(class) + `).on("select2:select", function (e) {
+		var values = $(this).val() || [], stack = [e.params.data.id];
+		while (stack.length > 0) {
+			(children[stack.shift()] || []).forEach(function (value) {
+				if (values.indexOf(value) === -1) {
+					values.push(value);
+				}
+				stack.push(value);
+			});
+		}
+		$(this).val(values).trigger("change");
+	});
+	return ` + string(ext) + `;
+})()`)
+}
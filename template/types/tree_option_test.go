@@ -0,0 +1,34 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionTree(t *testing.T) {
+	tree := NewOptionTree([]OptionTreeNode{
+		{Value: "1", Text: "Books"},
+		{Value: "2", Text: "Fiction", Parent: "1"},
+		{Value: "3", Text: "Sci-fi", Parent: "2"},
+		{Value: "4", Text: "Music", Parent: "0"},
+		{Value: "5", Text: "Poetry", Parent: "1"},
+		{Value: "6", Text: "Orphan", Parent: "9"},
+	})
+
+	options := tree.Options()
+	values := make([]string, len(options))
+	for k, option := range options {
+		values[k] = option.Value
+	}
+	assert.Equal(t, []string{"1", "2", "3", "5", "4", "6"}, values)
+	assert.Equal(t, "&nbsp;&nbsp;&nbsp;&nbsp;&nbsp;&nbsp;&nbsp;&nbsp;Sci-fi", string(options[2].TextHTML))
+
+	assert.Equal(t, []string{"1", "2", "5", "3"}, tree.Subtree([]string{"1"}))
+	assert.Equal(t, []string{"2", "3", "4"}, tree.Subtree([]string{"2", "3", "4", ""}))
+
+	// the nodes of a cycle are still listed.
+	tree = NewOptionTree([]OptionTreeNode{{Value: "1", Parent: "2"}, {Value: "2", Parent: "1"}})
+	assert.Equal(t, 2, len(tree.Options()))
+	assert.Equal(t, []string{"1", "2"}, tree.Subtree([]string{"1"}))
+}
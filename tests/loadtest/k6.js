@@ -0,0 +1,54 @@
+// 使用 k6 压测列表接口
+//
+// 运行：k6 run -e TARGET=http://127.0.0.1:9033 -e VUS=20 -e DURATION=30s tests/loadtest/k6.js
+// 每个场景的请求带有 scenario 标签，汇总结果中按场景分别给出延迟的分位数
+import http from 'k6/http';
+import { check } from 'k6';
+
+const target = __ENV.TARGET || 'http://127.0.0.1:9033';
+const vus = parseInt(__ENV.VUS || '20', 10);
+const duration = __ENV.DURATION || '30s';
+
+const paths = {
+    page: '/admin/info/user',
+    page_size_100: '/admin/info/user?__pageSize=100',
+    sort: '/admin/info/user?__sort=created_at&__sort_type=desc',
+    filter: '/admin/info/user?city=beijing',
+    api: '/admin/api/list/user?__pageSize=100',
+};
+
+// 场景依次运行，互不重叠
+export const options = {
+    scenarios: {},
+    thresholds: {},
+    summaryTrendStats: ['avg', 'min', 'med', 'p(90)', 'p(95)', 'p(99)', 'max'],
+};
+Object.keys(paths).forEach(function (name, i) {
+    options.scenarios[name] = {
+        executor: 'constant-vus',
+        vus: vus,
+        duration: duration,
+        startTime: (i * (parseInt(duration, 10) + 5)) + 's',
+        env: { SCENARIO: name },
+        tags: { scenario: name },
+    };
+    options.thresholds['http_req_duration{scenario:' + name + '}'] = ['p(95)>=0'];
+});
+
+// setup 登录并返回会话的 cookie
+export function setup() {
+    const res = http.post(target + '/admin/signin', { username: 'admin', password: 'admin' });
+    const session = res.cookies['go_admin_session'];
+    if (!session || session.length === 0) {
+        throw new Error('login failed: ' + res.status);
+    }
+    return { session: session[0].value };
+}
+
+export default function (data) {
+    const res = http.get(target + paths[__ENV.SCENARIO], {
+        cookies: { go_admin_session: data.session },
+        redirects: 0,
+    });
+    check(res, { 'status is 200': (r) => r.status === 200 });
+}
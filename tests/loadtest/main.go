@@ -0,0 +1,70 @@
+// Package main 提供列表接口压测使用的服务
+//
+// 服务使用 Gin 和测试的数据表生成函数启动管理后台，启动前使用 seed.Factory 将 users 表
+// 补足到指定的行数，随机数的种子固定，同样的参数得到同样的数据集，使压测的结果可以比较。
+// 压测脚本见同目录的 vegeta.sh 和 k6.js，一般通过 make loadtest 运行：
+//
+//	go run ./tests/loadtest -config ./tests/loadtest/config_sqlite.json -rows 10000 -addr 127.0.0.1:9033
+//
+// 注意事项：
+//   - 配置文件的数据库需要已导入测试数据，见 make loadtest-data 和 make import-mysql
+//   - 服务关闭了访问日志和调试模式，避免日志输出影响压测的结果
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/purpose168/GoAdmin-themes/adminlte"
+	"github.com/purpose168/GoAdmin/engine"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/seed"
+	"github.com/purpose168/GoAdmin/tests/tables"
+
+	_ "github.com/purpose168/GoAdmin/adapter/gin"
+	_ "github.com/purpose168/GoAdmin/modules/db/drivers/mysql"
+	_ "github.com/purpose168/GoAdmin/modules/db/drivers/sqlite"
+)
+
+func main() {
+	var (
+		cfgFile = flag.String("config", "./tests/loadtest/config_sqlite.json", "配置文件")
+		rows    = flag.Int("rows", 10000, "users 表的行数")
+		addr    = flag.String("addr", "127.0.0.1:9033", "监听地址")
+	)
+	flag.Parse()
+
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.Use(gin.Recovery())
+
+	eng := engine.Default()
+	if err := eng.AddConfigFromJSON(*cfgFile).
+		AddGenerators(tables.Generators).
+		AddGenerator("user", tables.GetUserTable).
+		Use(r); err != nil {
+		log.Fatalf("loadtest: %v", err)
+	}
+
+	if err := fill(eng.DefaultConnection(), *rows); err != nil {
+		log.Fatalf("loadtest: seed users: %v", err)
+	}
+
+	log.Printf("loadtest: listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, r))
+}
+
+// fill 将 users 表补足到 n 行
+func fill(conn db.Connection, n int) error {
+	count, err := db.WithDriver(conn).Table("users").Count()
+	if err != nil {
+		return err
+	}
+	if int(count) >= n {
+		return nil
+	}
+	log.Printf("loadtest: inserting %d users", n-int(count))
+	return seed.NewFactory(tables.GetUserTable).Seed(1).Insert(conn, nil, n-int(count))
+}
@@ -0,0 +1,49 @@
+#!/bin/sh
+# 使用 vegeta 压测列表接口，每个场景的结果写入 $OUT/<场景>.txt，汇总写入 $OUT/report.txt
+#
+# 环境变量：
+#   TARGET   服务地址，默认 http://127.0.0.1:9033
+#   RATE     每秒请求数，默认 50
+#   DURATION 每个场景的时长，默认 30s
+#   OUT      结果目录，默认 ./build/loadtest
+set -e
+
+TARGET=${TARGET:-http://127.0.0.1:9033}
+RATE=${RATE:-50}
+DURATION=${DURATION:-30s}
+OUT=${OUT:-./build/loadtest}
+
+command -v vegeta > /dev/null 2>&1 || go install github.com/tsenart/vegeta@latest
+mkdir -p "$OUT"
+
+TARGET=$TARGET "$(dirname "$0")/wait.sh"
+
+# 登录并取得会话的 cookie
+curl -s -c "$OUT/cookie.txt" -o /dev/null -d "username=admin&password=admin" "$TARGET/admin/signin"
+SESSION=$(awk '$6 == "go_admin_session" { print $7 }' "$OUT/cookie.txt")
+if [ -z "$SESSION" ]; then
+	echo "loadtest: login failed" >&2
+	exit 1
+fi
+
+: > "$OUT/report.txt"
+run() {
+	name=$1
+	path=$2
+	printf "GET %s%s\nCookie: go_admin_session=%s\n" "$TARGET" "$path" "$SESSION" |
+		vegeta attack -rate="$RATE" -duration="$DURATION" | tee "$OUT/$name.bin" | vegeta report > "$OUT/$name.txt"
+	{
+		echo "## $name ($path)"
+		cat "$OUT/$name.txt"
+		echo
+	} >> "$OUT/report.txt"
+}
+
+# 场景：默认分页、大分页、排序、筛选和列表的 JSON 接口
+run page "/admin/info/user"
+run page_size_100 "/admin/info/user?__pageSize=100"
+run sort "/admin/info/user?__sort=created_at&__sort_type=desc"
+run filter "/admin/info/user?city=beijing"
+run api "/admin/api/list/user?__pageSize=100"
+
+cat "$OUT/report.txt"
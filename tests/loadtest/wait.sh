@@ -0,0 +1,16 @@
+#!/bin/sh
+# 等待压测服务启动，首次启动需要插入测试数据，最多等待 120 秒
+#
+# 环境变量：
+#   TARGET   服务地址，默认 http://127.0.0.1:9033
+TARGET=${TARGET:-http://127.0.0.1:9033}
+
+i=0
+until curl -s -o /dev/null "$TARGET/admin/login"; do
+	i=$((i + 1))
+	if [ $i -gt 120 ]; then
+		echo "loadtest: $TARGET is not ready" >&2
+		exit 1
+	fi
+	sleep 1
+done
@@ -0,0 +1,229 @@
+// Package testkit 提供下游项目的集成测试工具
+//
+// 与 tests 包的黑盒测试不同，本包不依赖 GoAdmin 的源码目录和外部数据库，
+// 下游项目可以直接导入，在测试中完成以下工作：
+//   - SQLite: 在临时目录中创建包含 GoAdmin 数据表的 SQLite 数据库
+//   - Seed: 插入测试数据
+//   - Generate: 按数据表生成函数的表单插入随机的测试数据
+//   - New/Start: 使用下游项目自己的 HTTP 处理器创建测试工具
+//   - Kit: 登录、访问页面和接口，并对页面、接口和数据库进行断言
+//
+// 注意事项：
+//   - GoAdmin 的配置和数据库连接是全局的，一个测试二进制中只能启动一个应用
+//   - 初始数据包含 admin（密码 admin）和 operator 两个用户，operator 只有首页的权限
+//
+// 使用示例：
+//
+//	func TestApp(t *testing.T) {
+//	    cfg := testkit.SQLite(t, "create table users (id integer primary key autoincrement, name varchar(100))")
+//	    testkit.Seed(t, cfg, testkit.Fixtures{
+//	        "users": {{"name": "foo"}},
+//	    })
+//
+//	    r := gin.New()
+//	    _ = engine.Default().AddConfig(&config.Config{Databases: cfg, UrlPrefix: "admin"}).
+//	        AddGenerators(myGenerators).Use(r)
+//
+//	    kit := testkit.New(t, cfg, r).Login("admin", "admin")
+//	    kit.Page("/info/users").Contains("foo")
+//	    kit.AssertCount("users", 1)
+//	}
+package testkit
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gavv/httpexpect"
+	"github.com/purpose168/GoAdmin/data"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/db/dialect"
+	"github.com/purpose168/GoAdmin/modules/seed"
+
+	_ "github.com/purpose168/GoAdmin/modules/db/drivers/sqlite" // 导入 SQLite 数据库驱动
+)
+
+// Fixtures 测试数据，键为表名，值为要插入的行
+type Fixtures map[string][]dialect.H
+
+// AppFn 应用生成函数类型，使用给定的数据库配置创建下游项目的 HTTP 处理器
+type AppFn func(cfg config.DatabaseList) http.Handler
+
+// Kit 测试工具
+type Kit struct {
+	T      testing.TB
+	Config config.DatabaseList
+	Conn   db.Connection
+	Expect *httpexpect.Expect
+}
+
+// SQLite 在测试的临时目录中创建 SQLite 数据库
+// 参数：
+//   - t: 测试对象
+//   - statements: 可选的建表语句，用于创建下游项目自己的数据表
+//
+// 返回值：
+//   - config.DatabaseList: 数据库配置，数据库包含 GoAdmin 的数据表、初始数据和全部迁移
+func SQLite(t testing.TB, statements ...string) config.DatabaseList {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "admin_test.db")
+	if err := os.WriteFile(file, data.SQLiteDatabase, 0600); err != nil {
+		t.Fatalf("testkit: create database: %v", err)
+	}
+
+	migrations, err := data.SQLiteMigrations(data.SQLiteDatabaseVersion)
+	if err != nil {
+		t.Fatalf("testkit: read migrations: %v", err)
+	}
+
+	cfg := config.DatabaseList{
+		"default": {
+			Driver:       db.DriverSqlite,
+			File:         file,
+			MaxIdleConns: 5,
+			MaxOpenConns: 1,
+		},
+	}
+
+	conn := connection(cfg)
+	for _, statement := range append(migrations, statements...) {
+		if _, err := conn.Exec(statement); err != nil {
+			t.Fatalf("testkit: execute %s: %v", statement, err)
+		}
+	}
+
+	return cfg
+}
+
+// Seed 插入测试数据
+// 参数：
+//   - t: 测试对象
+//   - cfg: 数据库配置
+//   - fixtures: 测试数据
+func Seed(t testing.TB, cfg config.DatabaseList, fixtures Fixtures) {
+	t.Helper()
+
+	conn := connection(cfg)
+	for table, rows := range fixtures {
+		for _, row := range rows {
+			if _, err := db.WithDriver(conn).Table(table).Insert(row); err != nil {
+				t.Fatalf("testkit: seed %s: %v", table, err)
+			}
+		}
+	}
+}
+
+// Generate 按数据表生成函数的表单插入随机的测试数据，用于列表页的压力测试
+// 参数：
+//   - t: 测试对象
+//   - cfg: 数据库配置
+//   - factory: 测试数据工厂，如 seed.NewFactory(GetUserTable)
+//   - n: 插入的行数
+func Generate(t testing.TB, cfg config.DatabaseList, factory *seed.Factory, n int) {
+	t.Helper()
+
+	if err := factory.Insert(connection(cfg), nil, n); err != nil {
+		t.Fatalf("testkit: generate: %v", err)
+	}
+}
+
+// New 创建测试工具
+// 参数：
+//   - t: 测试对象
+//   - cfg: 数据库配置
+//   - handler: 下游项目的 HTTP 处理器
+//
+// 返回值：
+//   - *Kit: 测试工具，请求共用同一个 Cookie Jar，登录后保持会话
+func New(t testing.TB, cfg config.DatabaseList, handler http.Handler) *Kit {
+	return &Kit{
+		T:      t,
+		Config: cfg,
+		Conn:   connection(cfg),
+		Expect: httpexpect.WithConfig(httpexpect.Config{
+			// Cookie Jar 只保存绝对 URL 的 Cookie
+			BaseURL: "http://localhost",
+			Client: &http.Client{
+				Transport: httpexpect.NewBinder(handler),
+				Jar:       httpexpect.NewJar(),
+			},
+			Reporter: httpexpect.NewAssertReporter(t),
+		}),
+	}
+}
+
+// Start 创建 SQLite 数据库、插入测试数据并启动应用
+// 参数：
+//   - t: 测试对象
+//   - fn: 应用生成函数
+//   - fixtures: 可选的测试数据
+//
+// 返回值：
+//   - *Kit: 测试工具
+func Start(t testing.TB, fn AppFn, fixtures ...Fixtures) *Kit {
+	t.Helper()
+
+	cfg := SQLite(t)
+	for _, f := range fixtures {
+		Seed(t, cfg, f)
+	}
+	return New(t, cfg, fn(cfg))
+}
+
+// Login 使用用户名和密码登录，失败时测试失败
+func (k *Kit) Login(username, password string) *Kit {
+	k.Expect.POST(config.Url("/signin")).
+		WithFormField("username", username).
+		WithFormField("password", password).
+		Expect().Status(http.StatusOK)
+	return k
+}
+
+// Logout 登出
+func (k *Kit) Logout() *Kit {
+	k.Expect.GET(config.Url("/logout")).Expect()
+	return k
+}
+
+// Page 访问 URL 前缀下的页面，断言状态码为 200 并返回页面内容
+func (k *Kit) Page(path string) *httpexpect.String {
+	return k.Expect.GET(config.Url(path)).Expect().Status(http.StatusOK).Body()
+}
+
+// API 创建 URL 前缀下的接口请求
+func (k *Kit) API(method, path string) *httpexpect.Request {
+	return k.Expect.Request(method, config.Url(path))
+}
+
+// Table 返回表的查询对象，用于查询和修改数据
+func (k *Kit) Table(table string) *db.SQL {
+	return db.WithDriver(k.Conn).Table(table)
+}
+
+// AssertCount 断言表中满足条件的行数，条件为字段和值交替的参数
+// 如：kit.AssertCount("users", 1, "name", "foo")
+func (k *Kit) AssertCount(table string, count int, where ...interface{}) {
+	k.T.Helper()
+
+	sql := k.Table(table)
+	for i := 0; i+1 < len(where); i += 2 {
+		field, _ := where[i].(string)
+		sql = sql.Where(field, "=", where[i+1])
+	}
+	rows, err := sql.All()
+	if err != nil {
+		k.T.Fatalf("testkit: query %s: %v", table, err)
+	}
+	if len(rows) != count {
+		k.T.Errorf("testkit: %s has %d rows, want %d", table, len(rows), count)
+	}
+}
+
+// connection 返回数据库配置对应的连接
+func connection(cfg config.DatabaseList) db.Connection {
+	return db.GetConnectionByDriver(cfg.GetDefault().Driver).InitDB(cfg)
+}
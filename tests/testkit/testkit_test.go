@@ -0,0 +1,59 @@
+package testkit
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/purpose168/GoAdmin-themes/adminlte"
+	ada "github.com/purpose168/GoAdmin/adapter/gin"
+	"github.com/purpose168/GoAdmin/context"
+	"github.com/purpose168/GoAdmin/engine"
+	"github.com/purpose168/GoAdmin/modules/config"
+	"github.com/purpose168/GoAdmin/modules/db"
+	"github.com/purpose168/GoAdmin/modules/language"
+	"github.com/purpose168/GoAdmin/plugins/admin/modules/table"
+	"github.com/purpose168/GoAdmin/template/types/form"
+)
+
+func TestKit(t *testing.T) {
+	cfg := SQLite(t, "create table notes (id integer primary key autoincrement, title varchar(100))")
+
+	app := func(cfg config.DatabaseList) http.Handler {
+		gin.SetMode(gin.ReleaseMode)
+		r := gin.New()
+		if err := engine.Default().AddConfig(&config.Config{
+			Databases: cfg,
+			UrlPrefix: "admin",
+			Language:  language.EN,
+			IndexUrl:  "/",
+			Theme:     "adminlte",
+		}).AddAdapter(new(ada.Gin)).
+			AddGenerator("notes", func(ctx *context.Context) table.Table {
+				notes := table.NewDefaultTable(ctx, table.DefaultConfigWithDriver(db.DriverSqlite))
+				info := notes.GetInfo()
+				info.AddField("ID", "id", db.Int)
+				info.AddField("Title", "title", db.Varchar)
+				info.SetTable("notes").SetTitle("Notes")
+				notes.GetForm().AddField("Title", "title", db.Varchar, form.Text)
+				notes.GetForm().SetTable("notes")
+				return notes
+			}).Use(r); err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+
+	Seed(t, cfg, Fixtures{"goadmin_site": {{"key": "testkit", "value": "1"}}})
+	kit := New(t, cfg, app(cfg))
+
+	Seed(t, kit.Config, Fixtures{"notes": {{"title": "first note"}}})
+
+	kit.AssertCount("goadmin_users", 2)
+	kit.AssertCount("goadmin_site", 1, "key", "testkit")
+	kit.AssertCount("goadmin_announcements", 0)
+
+	kit.Login("admin", "admin")
+	kit.Page("/info/notes").Contains("first note")
+	kit.AssertCount("notes", 1, "title", "first note")
+}